@@ -0,0 +1,46 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler"
+)
+
+func NewCapabilitiesCommand() *cobra.Command {
+	var capabilitiesCmd = &cobra.Command{
+		Use:   "capabilities",
+		Short: "Capabilities of the descheduler",
+		Long: `Prints, as JSON, the compiled-in strategies, the supported policy API versions, the feature gates
+and the usage providers of this build, which the running descheduler also serves at /capabilities.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			encoder := json.NewEncoder(cmd.OutOrStdout())
+			encoder.SetIndent("", "  ")
+			if err := encoder.Encode(descheduler.GetCapabilities()); err != nil {
+				klog.ErrorS(err, "unable to print capabilities")
+				os.Exit(1)
+			}
+		},
+	}
+	return capabilitiesCmd
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/api/v1alpha1"
+	"sigs.k8s.io/descheduler/pkg/api/v1alpha2"
+	"sigs.k8s.io/descheduler/pkg/descheduler"
+	"sigs.k8s.io/descheduler/pkg/descheduler/scheme"
+)
+
+func NewPrintDefaultPolicyCommand() *cobra.Command {
+	var policyConfigFile string
+	var policyOverrides []string
+	var printDefaultPolicyCmd = &cobra.Command{
+		Use:   "print-default-policy",
+		Short: "Defaulted policy of descheduler",
+		Long: `Prints the policy with the values the strategies use when a parameter is not set filled in.
+When no policy config file is given, all strategies are printed disabled.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			policy, err := descheduler.LoadPolicyConfig(policyConfigFile, policyOverrides)
+			if err != nil {
+				klog.ErrorS(err, "unable to load policy")
+				os.Exit(1)
+			}
+			if err := printPolicy(cmd.OutOrStdout(), descheduler.DefaultedPolicy(policy)); err != nil {
+				klog.ErrorS(err, "unable to print policy")
+				os.Exit(1)
+			}
+		},
+	}
+	printDefaultPolicyCmd.Flags().StringVar(&policyConfigFile, "policy-config-file", policyConfigFile, "File with descheduler policy configuration.")
+	printDefaultPolicyCmd.Flags().StringArrayVar(&policyOverrides, "set", policyOverrides, "overrides a value of the policy config file, e.g. strategies.PodLifeTime.params.podLifeTime.maxPodLifeTimeSeconds=3600")
+	return printDefaultPolicyCmd
+}
+
+// printPolicy writes the policy as a YAML document, of version v1alpha2 for policies with profiles, of
+// version v1alpha1 otherwise
+func printPolicy(w io.Writer, policy *api.DeschedulerPolicy) error {
+	var versionedPolicy runtime.Object = &v1alpha1.DeschedulerPolicy{}
+	groupVersion := v1alpha1.SchemeGroupVersion
+	if len(policy.Profiles) > 0 {
+		versionedPolicy = &v1alpha2.DeschedulerPolicy{}
+		groupVersion = v1alpha2.SchemeGroupVersion
+	}
+	if err := scheme.Scheme.Convert(policy, versionedPolicy, nil); err != nil {
+		return fmt.Errorf("failed converting internal policy to versioned policy: %v", err)
+	}
+	versionedPolicy.GetObjectKind().SetGroupVersionKind(groupVersion.WithKind("DeschedulerPolicy"))
+	data, err := yaml.Marshal(versionedPolicy)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
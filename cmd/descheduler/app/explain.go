@@ -0,0 +1,89 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler"
+	"sigs.k8s.io/descheduler/pkg/descheduler/client"
+	eutils "sigs.k8s.io/descheduler/pkg/descheduler/evictions/utils"
+)
+
+func NewExplainCommand() *cobra.Command {
+	var explainCmd = &cobra.Command{
+		Use:   "explain",
+		Short: "Explain the decisions of descheduler",
+		Long:  `Explains the decisions the descheduler would take against the live cluster, without evicting any pod.`,
+	}
+	explainCmd.AddCommand(newExplainPodCommand())
+	return explainCmd
+}
+
+func newExplainPodCommand() *cobra.Command {
+	var kubeconfig, policyConfigFile string
+	var explainPodCmd = &cobra.Command{
+		Use:   "pod <namespace>/<name>",
+		Short: "Explain which strategies would evict a pod",
+		Long:  `Runs the strategies enabled in the policy in dry run mode and prints, for each of them, whether it would evict the pod and why, or which checks protect it.`,
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			parts := strings.Split(args[0], "/")
+			if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+				klog.ErrorS(fmt.Errorf("pod %q is not formatted as namespace/name", args[0]), "invalid pod")
+				os.Exit(1)
+			}
+			rsclient, err := client.CreateClient(kubeconfig)
+			if err != nil {
+				klog.ErrorS(err, "unable to create client")
+				os.Exit(1)
+			}
+			policy, err := descheduler.LoadPolicyConfig(policyConfigFile, nil)
+			if err != nil {
+				klog.ErrorS(err, "unable to load policy")
+				os.Exit(1)
+			}
+			if policy == nil {
+				klog.ErrorS(fmt.Errorf("no policy"), "a policy is required, use --policy-config-file")
+				os.Exit(1)
+			}
+			evictionPolicyGroupVersion, err := eutils.SupportEviction(rsclient)
+			if err != nil || len(evictionPolicyGroupVersion) == 0 {
+				klog.ErrorS(err, "eviction is not supported by the cluster")
+				os.Exit(1)
+			}
+			explanations, err := descheduler.Explain(context.TODO(), rsclient, policy, evictionPolicyGroupVersion, parts[0], parts[1])
+			if err != nil {
+				klog.ErrorS(err, "unable to explain the pod", "pod", args[0])
+				os.Exit(1)
+			}
+			if err := descheduler.PrintExplanations(cmd.OutOrStdout(), args[0], explanations); err != nil {
+				klog.ErrorS(err, "unable to print the explanations")
+				os.Exit(1)
+			}
+		},
+	}
+	explainPodCmd.Flags().StringVar(&kubeconfig, "kubeconfig", kubeconfig, "File with kube configuration.")
+	explainPodCmd.Flags().StringVar(&policyConfigFile, "policy-config-file", policyConfigFile, "File with descheduler policy configuration.")
+	return explainPodCmd
+}
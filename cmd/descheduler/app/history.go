@@ -0,0 +1,59 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/client"
+	"sigs.k8s.io/descheduler/pkg/descheduler/history"
+)
+
+func NewHistoryCommand() *cobra.Command {
+	var kubeconfig string
+	config := &api.EvictionHistory{}
+	var historyCmd = &cobra.Command{
+		Use:   "history",
+		Short: "Eviction history of descheduler",
+		Long:  `Prints the pods evicted during the last descheduling cycles and the pods replacing them.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			rsclient, err := client.CreateClient(kubeconfig)
+			if err != nil {
+				klog.ErrorS(err, "unable to create client")
+				os.Exit(1)
+			}
+			cycles, err := history.NewStore(rsclient, config).Load(context.TODO())
+			if err != nil {
+				klog.ErrorS(err, "unable to load eviction history")
+				os.Exit(1)
+			}
+			if err := history.Print(cmd.OutOrStdout(), cycles); err != nil {
+				klog.ErrorS(err, "unable to print eviction history")
+				os.Exit(1)
+			}
+		},
+	}
+	historyCmd.Flags().StringVar(&kubeconfig, "kubeconfig", kubeconfig, "File with kube configuration.")
+	historyCmd.Flags().StringVar(&config.Namespace, "namespace", history.DefaultNamespace, "Namespace of the eviction history ConfigMap.")
+	historyCmd.Flags().StringVar(&config.Name, "name", history.DefaultName, "Name of the eviction history ConfigMap.")
+	return historyCmd
+}
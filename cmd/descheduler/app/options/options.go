@@ -18,15 +18,20 @@ limitations under the License.
 package options
 
 import (
+	"fmt"
+
 	"github.com/spf13/pflag"
 
+	"k8s.io/apimachinery/pkg/util/clock"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	apiserveroptions "k8s.io/apiserver/pkg/server/options"
+	"k8s.io/client-go/dynamic"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/component-base/logs"
 
 	"sigs.k8s.io/descheduler/pkg/apis/componentconfig"
 	"sigs.k8s.io/descheduler/pkg/apis/componentconfig/v1alpha1"
+	"sigs.k8s.io/descheduler/pkg/descheduler/report"
 	deschedulerscheme "sigs.k8s.io/descheduler/pkg/descheduler/scheme"
 )
 
@@ -39,9 +44,13 @@ type DeschedulerServer struct {
 	componentconfig.DeschedulerConfiguration
 
 	Client         clientset.Interface
+	DynamicClient  dynamic.Interface
 	Logs           *logs.Options
 	SecureServing  *apiserveroptions.SecureServingOptionsWithLoopback
 	DisableMetrics bool
+	// Clock schedules descheduling cycles and is the current time of evictions and strategies, the real clock
+	// when nil. e2e tests and simulations set a fake clock to control time.
+	Clock clock.Clock
 }
 
 // NewDeschedulerServer creates a new DeschedulerServer with default parameters
@@ -65,6 +74,12 @@ func NewDeschedulerServer() (*DeschedulerServer, error) {
 func (s *DeschedulerServer) Validate() error {
 	var errs []error
 	errs = append(errs, s.Logs.Validate()...)
+	if err := report.ValidateFormat(s.ReportFormat); err != nil {
+		errs = append(errs, err)
+	}
+	if s.ReconnectTimeout < 0 {
+		errs = append(errs, fmt.Errorf("reconnect-timeout must not be negative"))
+	}
 	return utilerrors.NewAggregate(errs)
 }
 
@@ -84,6 +99,7 @@ func (rs *DeschedulerServer) AddFlags(fs *pflag.FlagSet) {
 	fs.DurationVar(&rs.DeschedulingInterval, "descheduling-interval", rs.DeschedulingInterval, "Time interval between two consecutive descheduler executions. Setting this value instructs the descheduler to run in a continuous loop at the interval specified.")
 	fs.StringVar(&rs.KubeconfigFile, "kubeconfig", rs.KubeconfigFile, "File with  kube configuration.")
 	fs.StringVar(&rs.PolicyConfigFile, "policy-config-file", rs.PolicyConfigFile, "File with descheduler policy configuration.")
+	fs.StringVar(&rs.PolicyResource, "policy-resource", rs.PolicyResource, "name of a cluster-scoped DeschedulerPolicy custom resource the policy is read from instead of the policy config file, changes of the resource are applied before the next descheduling cycle")
 	fs.BoolVar(&rs.DryRun, "dry-run", rs.DryRun, "execute descheduler in dry run mode.")
 	// node-selector query causes descheduler to run only on nodes that matches the node labels in the query
 	fs.StringVar(&rs.NodeSelector, "node-selector", rs.NodeSelector, "DEPRECATED: selector (label query) to filter on, supports '=', '==', and '!='.(e.g. -l key1=value1,key2=value2)")
@@ -91,6 +107,19 @@ func (rs *DeschedulerServer) AddFlags(fs *pflag.FlagSet) {
 	fs.IntVar(&rs.MaxNoOfPodsToEvictPerNode, "max-pods-to-evict-per-node", rs.MaxNoOfPodsToEvictPerNode, "DEPRECATED: limits the maximum number of pods to be evicted per node by descheduler")
 	// evict-local-storage-pods allows eviction of pods that are using local storage. This is false by default.
 	fs.BoolVar(&rs.EvictLocalStoragePods, "evict-local-storage-pods", rs.EvictLocalStoragePods, "DEPRECATED: enables evicting pods using local storage by descheduler")
+	// exclude-virtual-nodes skips kwok and virtual-kubelet nodes, they are processed as any other node by default.
+	fs.BoolVar(&rs.ExcludeVirtualNodes, "exclude-virtual-nodes", rs.ExcludeVirtualNodes, "excludes kwok and virtual-kubelet nodes from descheduling")
+	fs.StringVar(&rs.PodLabelSelector, "pod-label-selector", rs.PodLabelSelector, "restricts the pods listed by the descheduler to the ones matching the label selector (e.g. team=a)")
+	fs.StringVar(&rs.PodFieldSelector, "pod-field-selector", rs.PodFieldSelector, "restricts the pods listed by the descheduler to the ones matching the field selector (e.g. metadata.namespace!=kube-system)")
+	fs.IntVar(&rs.LogSamplingInitial, "log-sampling-initial", rs.LogSamplingInitial, "number of occurrences of a repetitive message (e.g. logged for every pod) logged per descheduling cycle before sampling it, 0 disables sampling")
+	fs.IntVar(&rs.LogSamplingThereafter, "log-sampling-thereafter", rs.LogSamplingThereafter, "once sampling, only log one every this many occurrences of a repetitive message")
+	fs.StringArrayVar(&rs.PolicyOverrides, "set", rs.PolicyOverrides, "overrides a value of the policy config file, e.g. strategies.LowNodeUtilization.params.nodeResourceUtilizationThresholds.thresholds.cpu=20, can be repeated")
+	fs.BoolVar(&rs.PolicyReload, "policy-reload", rs.PolicyReload, "reloads the policy config file between descheduling cycles when it changes or on SIGHUP, an invalid policy is ignored")
+	fs.StringVar(&rs.UserAgent, "user-agent", rs.UserAgent, "user agent of the requests to the API server, e.g. to identify them in audit logs and API server metrics")
+	fs.IntVar(&rs.ThrottlingRetries, "throttling-retries", rs.ThrottlingRetries, "number of retries of requests rejected by API Priority and Fairness, with an exponential backoff starting at their Retry-After delay, 0 disables them")
+	fs.BoolVar(&rs.Deterministic, "deterministic", rs.Deterministic, "fixes the random seeds and processes nodes in name order, so runs against the same cluster state evict the same pods, e.g. in e2e tests and simulations")
+	fs.StringVar(&rs.ReportFormat, "report-format", rs.ReportFormat, "writes a summary of every descheduling cycle, the pods evicted by every strategy (or which would be in dry run mode) and the node utilizations, to the standard output in the given format: json, markdown or csv")
+	fs.DurationVar(&rs.ReconnectTimeout, "reconnect-timeout", rs.ReconnectTimeout, "how long a descheduling cycle interrupted by the loss of the connection to the API server waits for it, within the descheduling interval, to resume with the interrupted strategy instead of starting over during the next cycle, 0 disables resuming")
 	fs.BoolVar(&rs.DisableMetrics, "disable-metrics", rs.DisableMetrics, "Disables metrics. The metrics are by default served through https://localhost:10258/metrics. Secure address, resp. port can be changed through --bind-address, resp. --secure-port flags.")
 
 	rs.SecureServing.AddFlags(fs)
@@ -0,0 +1,61 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/api/v1alpha1"
+	"sigs.k8s.io/descheduler/pkg/descheduler"
+)
+
+func NewSchemaCommand() *cobra.Command {
+	apiVersion := v1alpha1.SchemeGroupVersion.String()
+	var schemaCmd = &cobra.Command{
+		Use:   "schema [strategy]",
+		Short: "JSON Schema of the descheduler policy",
+		Long: `Prints the JSON Schema of the policy config file, or of the configuration of the given strategy,
+for editors to validate and complete policies. Policies setting unknown fields are refused when loaded.`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			schema, err := descheduler.PolicySchema(apiVersion)
+			if err != nil {
+				klog.ErrorS(err, "unable to print policy schema")
+				os.Exit(1)
+			}
+			if len(args) == 1 {
+				if schema, err = descheduler.StrategySchema(api.StrategyName(args[0])); err != nil {
+					klog.ErrorS(err, "unable to print strategy schema")
+					os.Exit(1)
+				}
+			}
+			encoder := json.NewEncoder(cmd.OutOrStdout())
+			encoder.SetIndent("", "  ")
+			if err := encoder.Encode(schema); err != nil {
+				klog.ErrorS(err, "unable to print schema")
+				os.Exit(1)
+			}
+		},
+	}
+	schemaCmd.Flags().StringVar(&apiVersion, "api-version", apiVersion, "apiVersion of the policy, descheduler/v1alpha1 or descheduler/v1alpha2")
+	return schemaCmd
+}
@@ -68,6 +68,7 @@ func NewDeschedulerCommand(out io.Writer) *cobra.Command {
 				ctx := context.TODO()
 				pathRecorderMux := mux.NewPathRecorderMux("descheduler")
 				pathRecorderMux.Handle("/metrics", legacyregistry.HandlerWithReset())
+				pathRecorderMux.Handle("/capabilities", descheduler.CapabilitiesHandler())
 
 				if _, err := SecureServing.Serve(pathRecorderMux, 0, ctx.Done()); err != nil {
 					klog.Fatalf("failed to start secure server: %v", err)
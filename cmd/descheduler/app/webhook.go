@@ -0,0 +1,82 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"net/http"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler"
+	"sigs.k8s.io/descheduler/pkg/descheduler/client"
+	"sigs.k8s.io/descheduler/pkg/descheduler/webhook"
+)
+
+func NewWebhookCommand() *cobra.Command {
+	var kubeconfig, policyConfigFile, bindAddress, certFile, keyFile string
+	handler := &webhook.NodeCordonHandler{}
+	var webhookCmd = &cobra.Command{
+		Use:   "webhook",
+		Short: "Node cordon and interactive session webhook of descheduler",
+		Long: `Serves a validating admission webhook assessing, when a node is cordoned or labeled for removal,
+whether its evictable pods fit on the other nodes, and recording the interactive sessions opened to pods.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			rsclient, err := client.CreateClient(kubeconfig)
+			if err != nil {
+				klog.ErrorS(err, "unable to create client")
+				os.Exit(1)
+			}
+			handler.Client = rsclient
+			policy, err := descheduler.LoadPolicyConfig(policyConfigFile, nil)
+			if err != nil {
+				klog.ErrorS(err, "unable to load policy")
+				os.Exit(1)
+			}
+			if policy != nil {
+				if policy.EvictLocalStoragePods != nil {
+					handler.EvictionSettings.EvictLocalStoragePods = *policy.EvictLocalStoragePods
+				}
+				if policy.EvictSystemCriticalPods != nil {
+					handler.EvictionSettings.EvictSystemCriticalPods = *policy.EvictSystemCriticalPods
+				}
+				if policy.IgnorePVCPods != nil {
+					handler.EvictionSettings.IgnorePVCPods = *policy.IgnorePVCPods
+				}
+				handler.EvictionSettings.EvictableEmptyDirSizeLimit = policy.EvictableEmptyDirSizeLimit
+			}
+
+			mux := http.NewServeMux()
+			mux.Handle(webhook.NodeCordonPath, handler)
+			mux.Handle(webhook.InteractiveSessionPath, &webhook.InteractiveSessionHandler{Client: rsclient})
+			klog.V(1).InfoS("Serving webhooks", "address", bindAddress, "paths", []string{webhook.NodeCordonPath, webhook.InteractiveSessionPath})
+			if err := http.ListenAndServeTLS(bindAddress, certFile, keyFile, mux); err != nil {
+				klog.ErrorS(err, "webhook server failed")
+				os.Exit(1)
+			}
+		},
+	}
+	webhookCmd.Flags().StringVar(&kubeconfig, "kubeconfig", kubeconfig, "File with kube configuration.")
+	webhookCmd.Flags().StringVar(&policyConfigFile, "policy-config-file", policyConfigFile, "File with descheduler policy configuration, used to decide which pods are evictable.")
+	webhookCmd.Flags().StringVar(&bindAddress, "bind-address", ":8443", "Address the webhook is served on.")
+	webhookCmd.Flags().StringVar(&certFile, "tls-cert-file", certFile, "File containing the x509 certificate for HTTPS.")
+	webhookCmd.Flags().StringVar(&keyFile, "tls-private-key-file", keyFile, "File containing the x509 private key matching --tls-cert-file.")
+	webhookCmd.Flags().StringVar(&handler.RemovalLabel, "removal-label", handler.RemovalLabel, "Label marking nodes about to be removed, in addition to cordoned nodes.")
+	webhookCmd.Flags().BoolVar(&handler.DenyInfeasible, "deny-infeasible", handler.DenyInfeasible, "Reject cordons and removal labels when evictable pods do not fit on other nodes instead of returning a warning.")
+	return webhookCmd
+}
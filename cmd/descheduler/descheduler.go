@@ -27,6 +27,12 @@ func main() {
 	out := os.Stdout
 	cmd := app.NewDeschedulerCommand(out)
 	cmd.AddCommand(app.NewVersionCommand())
+	cmd.AddCommand(app.NewHistoryCommand())
+	cmd.AddCommand(app.NewPrintDefaultPolicyCommand())
+	cmd.AddCommand(app.NewSchemaCommand())
+	cmd.AddCommand(app.NewWebhookCommand())
+	cmd.AddCommand(app.NewExplainCommand())
+	cmd.AddCommand(app.NewCapabilitiesCommand())
 
 	logs.InitLogs()
 	defer logs.FlushLogs()
@@ -0,0 +1,589 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package app implements the kubectl deschedule plugin, running a single strategy once against a cluster.
+package app
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/descheduler/cmd/descheduler/app/options"
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler"
+	"sigs.k8s.io/descheduler/pkg/descheduler/client"
+	eutils "sigs.k8s.io/descheduler/pkg/descheduler/evictions/utils"
+)
+
+// globalOptions are the flags shared by all strategies
+type globalOptions struct {
+	kubeconfig                 string
+	kubeContext                string
+	dryRun                     bool
+	nodeSelector               string
+	evictLocalStoragePods      bool
+	evictSystemCriticalPods    bool
+	ignorePvcPods              bool
+	maxPodsToEvictPerNode      int
+	includeNamespaces          []string
+	excludeNamespaces          []string
+	thresholdPriority          int32
+	thresholdPriorityClassName string
+	labelSelector              string
+	nodeFit                    bool
+	nodeRoles                  []string
+}
+
+// strategyCommand describes a strategy which can be run by the plugin. addFlags registers the flags
+// of the strategy parameters and returns a function setting the parameters from the parsed flags.
+type strategyCommand struct {
+	name     api.StrategyName
+	short    string
+	addFlags func(fs *pflag.FlagSet) func(params *api.StrategyParameters) error
+}
+
+// NewDescheduleCommand creates the kubectl deschedule command, with a sub command per strategy
+func NewDescheduleCommand() *cobra.Command {
+	opts := &globalOptions{}
+	cmd := &cobra.Command{
+		Use:   "kubectl-deschedule",
+		Short: "Run a descheduler strategy once",
+		Long: `Runs a single descheduler strategy once against the cluster of the current kubeconfig context.
+Strategy parameters are set through flags, e.g. kubectl deschedule lownodeutilization --threshold cpu=20 --target-threshold cpu=50.
+Use --dry-run to only log the pods which would be evicted.`,
+	}
+
+	fs := cmd.PersistentFlags()
+	fs.StringVar(&opts.kubeconfig, "kubeconfig", "", "File with kube configuration, looked up as kubectl does when not set.")
+	fs.StringVar(&opts.kubeContext, "context", "", "Kubeconfig context to use, the current context when not set.")
+	fs.BoolVar(&opts.dryRun, "dry-run", false, "only log the pods which would be evicted.")
+	fs.StringVar(&opts.nodeSelector, "node-selector", "", "selector (label query) limiting the nodes which are processed.")
+	fs.BoolVar(&opts.evictLocalStoragePods, "evict-local-storage-pods", false, "allows eviction of pods with local storage.")
+	fs.BoolVar(&opts.evictSystemCriticalPods, "evict-system-critical-pods", false, "allows eviction of pods with any priority, including system pods.")
+	fs.BoolVar(&opts.ignorePvcPods, "ignore-pvc-pods", false, "ignores pods with PVCs.")
+	fs.IntVar(&opts.maxPodsToEvictPerNode, "max-pods-to-evict-per-node", 0, "maximum number of pods evicted from each node, 0 meaning no limit.")
+	fs.StringSliceVar(&opts.includeNamespaces, "include-namespaces", nil, "namespaces whose pods are processed.")
+	fs.StringSliceVar(&opts.excludeNamespaces, "exclude-namespaces", nil, "namespaces whose pods are not processed.")
+	fs.Int32Var(&opts.thresholdPriority, "threshold-priority", 0, "only pods with a lower priority are evicted.")
+	fs.StringVar(&opts.thresholdPriorityClassName, "threshold-priority-class-name", "", "only pods with a lower priority than the priority class are evicted.")
+	fs.StringVar(&opts.labelSelector, "label-selector", "", "only pods matching the label selector are evicted.")
+	fs.BoolVar(&opts.nodeFit, "node-fit", false, "only evict pods fitting on other nodes.")
+	fs.StringSliceVar(&opts.nodeRoles, "node-roles", nil, "roles of the processed nodes, e.g. worker,infra, nodes without role label are workers.")
+
+	// evictions are logged at level 1
+	_ = flag.Set("v", "1")
+	fs.AddGoFlagSet(flag.CommandLine)
+
+	for _, strategy := range strategyCommands {
+		cmd.AddCommand(newStrategyCommand(opts, strategy))
+	}
+	return cmd
+}
+
+func newStrategyCommand(opts *globalOptions, strategy strategyCommand) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   strings.ToLower(string(strategy.name)),
+		Short: strategy.short,
+		Args:  cobra.NoArgs,
+	}
+	setParams := strategy.addFlags(cmd.Flags())
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		params := &api.StrategyParameters{}
+		if err := setParams(params); err != nil {
+			return err
+		}
+		if err := opts.setParams(cmd, params); err != nil {
+			return err
+		}
+		return run(context.Background(), opts, opts.policy(strategy.name, params))
+	}
+	return cmd
+}
+
+// setParams sets the strategy parameters shared by all strategies
+func (opts *globalOptions) setParams(cmd *cobra.Command, params *api.StrategyParameters) error {
+	if len(opts.includeNamespaces) > 0 || len(opts.excludeNamespaces) > 0 {
+		params.Namespaces = &api.Namespaces{Include: opts.includeNamespaces, Exclude: opts.excludeNamespaces}
+	}
+	if cmd.Flags().Changed("threshold-priority") {
+		params.ThresholdPriority = &opts.thresholdPriority
+	}
+	params.ThresholdPriorityClassName = opts.thresholdPriorityClassName
+	if opts.labelSelector != "" {
+		selector, err := metav1.ParseToLabelSelector(opts.labelSelector)
+		if err != nil {
+			return fmt.Errorf("invalid label selector %q: %v", opts.labelSelector, err)
+		}
+		params.LabelSelector = selector
+	}
+	params.NodeFit = opts.nodeFit
+	params.NodeRoles = opts.nodeRoles
+	return nil
+}
+
+// policy returns the policy enabling only the strategy, with the given parameters
+func (opts *globalOptions) policy(name api.StrategyName, params *api.StrategyParameters) *api.DeschedulerPolicy {
+	policy := &api.DeschedulerPolicy{
+		Strategies:              api.StrategyList{name: api.DeschedulerStrategy{Enabled: true, Params: params}},
+		EvictLocalStoragePods:   &opts.evictLocalStoragePods,
+		EvictSystemCriticalPods: &opts.evictSystemCriticalPods,
+		IgnorePVCPods:           &opts.ignorePvcPods,
+	}
+	if opts.nodeSelector != "" {
+		policy.NodeSelector = &opts.nodeSelector
+	}
+	if opts.maxPodsToEvictPerNode > 0 {
+		policy.MaxNoOfPodsToEvictPerNode = &opts.maxPodsToEvictPerNode
+	}
+	return policy
+}
+
+// run runs the policy once against the cluster, replaced in tests
+var run = func(ctx context.Context, opts *globalOptions, policy *api.DeschedulerPolicy) error {
+	rsclient, err := client.CreateClientForContext(opts.kubeconfig, opts.kubeContext)
+	if err != nil {
+		return err
+	}
+	rs, err := options.NewDeschedulerServer()
+	if err != nil {
+		return err
+	}
+	rs.Client = rsclient
+	rs.DryRun = opts.dryRun
+
+	evictionPolicyGroupVersion, err := eutils.SupportEviction(rs.Client)
+	if err != nil || len(evictionPolicyGroupVersion) == 0 {
+		return fmt.Errorf("the cluster does not support evictions: %v", err)
+	}
+	return descheduler.RunDeschedulerStrategies(ctx, rs, policy, evictionPolicyGroupVersion, make(chan struct{}))
+}
+
+// strategyCommands lists the strategies the plugin can run. RemovePodsViolatingAntiColocation is
+// left out as its pairs of label selectors do not map to flags, it can be run with a policy file.
+var strategyCommands = []strategyCommand{
+	{
+		name:  "LowNodeUtilization",
+		short: "Evict pods from overutilized nodes to underutilized nodes",
+		addFlags: func(fs *pflag.FlagSet) func(params *api.StrategyParameters) error {
+			var thresholds, targetThresholds map[string]string
+			var numberOfNodes int
+			var thresholdsOperator string
+			fs.StringToStringVar(&thresholds, "threshold", nil, "resource percentages under which nodes are underutilized, e.g. cpu=20,memory=20")
+			fs.StringToStringVar(&targetThresholds, "target-threshold", nil, "resource percentages over which nodes are overutilized, e.g. cpu=50,memory=50")
+			fs.IntVar(&numberOfNodes, "number-of-nodes", 0, "minimum number of underutilized nodes for the strategy to run")
+			fs.StringVar(&thresholdsOperator, "thresholds-operator", "", "Or (the default) or And, whether any or all resources have to be above target thresholds")
+			return func(params *api.StrategyParameters) error {
+				parsedThresholds, err := parseThresholds(thresholds)
+				if err != nil {
+					return err
+				}
+				parsedTargetThresholds, err := parseThresholds(targetThresholds)
+				if err != nil {
+					return err
+				}
+				params.NodeResourceUtilizationThresholds = &api.NodeResourceUtilizationThresholds{
+					Thresholds:         parsedThresholds,
+					TargetThresholds:   parsedTargetThresholds,
+					NumberOfNodes:      numberOfNodes,
+					ThresholdsOperator: thresholdsOperator,
+				}
+				return nil
+			}
+		},
+	},
+	{
+		name:  "HighNodeUtilization",
+		short: "Evict pods from underutilized nodes so they can be scaled down",
+		addFlags: func(fs *pflag.FlagSet) func(params *api.StrategyParameters) error {
+			var thresholds map[string]string
+			var numberOfNodes int
+			fs.StringToStringVar(&thresholds, "threshold", nil, "resource percentages under which nodes are underutilized, e.g. cpu=20,memory=20")
+			fs.IntVar(&numberOfNodes, "number-of-nodes", 0, "minimum number of underutilized nodes for the strategy to run")
+			return func(params *api.StrategyParameters) error {
+				parsedThresholds, err := parseThresholds(thresholds)
+				if err != nil {
+					return err
+				}
+				params.NodeResourceUtilizationThresholds = &api.NodeResourceUtilizationThresholds{
+					Thresholds:    parsedThresholds,
+					NumberOfNodes: numberOfNodes,
+				}
+				return nil
+			}
+		},
+	},
+	{
+		name:  "RemoveDuplicates",
+		short: "Evict duplicate pods of the same owner running on the same node",
+		addFlags: func(fs *pflag.FlagSet) func(params *api.StrategyParameters) error {
+			var excludeOwnerKinds []string
+			fs.StringSliceVar(&excludeOwnerKinds, "exclude-owner-kinds", nil, "owner kinds whose pods are not evicted, e.g. ReplicaSet")
+			return func(params *api.StrategyParameters) error {
+				if len(excludeOwnerKinds) > 0 {
+					params.RemoveDuplicates = &api.RemoveDuplicates{ExcludeOwnerKinds: excludeOwnerKinds}
+				}
+				return nil
+			}
+		},
+	},
+	{
+		name:  "PodLifeTime",
+		short: "Evict pods older than a maximum lifetime",
+		addFlags: func(fs *pflag.FlagSet) func(params *api.StrategyParameters) error {
+			var maxPodLifeTime time.Duration
+			var podStatusPhases []string
+			fs.DurationVar(&maxPodLifeTime, "max-pod-lifetime", 0, "maximum lifetime of pods, e.g. 24h")
+			fs.StringSliceVar(&podStatusPhases, "pod-status-phases", nil, "only evict pods in these phases, Pending and/or Running")
+			return func(params *api.StrategyParameters) error {
+				if maxPodLifeTime <= 0 {
+					return fmt.Errorf("--max-pod-lifetime must be set")
+				}
+				seconds := uint(maxPodLifeTime.Seconds())
+				params.PodLifeTime = &api.PodLifeTime{MaxPodLifeTimeSeconds: &seconds, PodStatusPhases: podStatusPhases}
+				return nil
+			}
+		},
+	},
+	{
+		name:  "RemovePodsHavingTooManyRestarts",
+		short: "Evict pods restarting too often",
+		addFlags: func(fs *pflag.FlagSet) func(params *api.StrategyParameters) error {
+			podsHavingTooManyRestarts := &api.PodsHavingTooManyRestarts{}
+			fs.Int32Var(&podsHavingTooManyRestarts.PodRestartThreshold, "pod-restart-threshold", 0, "number of container restarts above which pods are evicted")
+			fs.BoolVar(&podsHavingTooManyRestarts.IncludingInitContainers, "including-init-containers", false, "count restarts of init containers")
+			return func(params *api.StrategyParameters) error {
+				params.PodsHavingTooManyRestarts = podsHavingTooManyRestarts
+				return nil
+			}
+		},
+	},
+	{
+		name:  "RemovePodsViolatingNodeTaints",
+		short: "Evict pods not tolerating the NoSchedule taints of their node",
+		addFlags: func(fs *pflag.FlagSet) func(params *api.StrategyParameters) error {
+			return func(params *api.StrategyParameters) error {
+				return nil
+			}
+		},
+	},
+	{
+		name:  "RemovePodsViolatingNodeAffinity",
+		short: "Evict pods whose node affinity is no longer satisfied by their node",
+		addFlags: func(fs *pflag.FlagSet) func(params *api.StrategyParameters) error {
+			var nodeAffinityType []string
+			fs.StringSliceVar(&nodeAffinityType, "node-affinity-type", []string{"requiredDuringSchedulingIgnoredDuringExecution"}, "node affinity types to check, requiredDuringSchedulingIgnoredDuringExecution or nodeSelector")
+			return func(params *api.StrategyParameters) error {
+				params.NodeAffinityType = nodeAffinityType
+				return nil
+			}
+		},
+	},
+	{
+		name:  "RemovePodsViolatingInterPodAntiAffinity",
+		short: "Evict pods violating the inter-pod anti-affinity of other pods of their node",
+		addFlags: func(fs *pflag.FlagSet) func(params *api.StrategyParameters) error {
+			return func(params *api.StrategyParameters) error {
+				return nil
+			}
+		},
+	},
+	{
+		name:  "RemovePodsViolatingTopologySpreadConstraint",
+		short: "Evict pods violating their topology spread constraints",
+		addFlags: func(fs *pflag.FlagSet) func(params *api.StrategyParameters) error {
+			var includeSoftConstraints bool
+			var maxSkewReduction int32
+			var topologyBalanceDomains []string
+			fs.BoolVar(&includeSoftConstraints, "include-soft-constraints", false, "also balance ScheduleAnyway constraints")
+			fs.Int32Var(&maxSkewReduction, "max-skew-reduction", 0, "maximum reduction of the skew of a constraint per run, the whole skew is fixed at once by default")
+			fs.StringSliceVar(&topologyBalanceDomains, "topology-balance-domains", nil, "only balance the constraints of these topology keys, e.g. topology.example.com/chassis, all keys by default")
+			return func(params *api.StrategyParameters) error {
+				params.IncludeSoftConstraints = includeSoftConstraints
+				params.MaxSkewReductionPerCycle = maxSkewReduction
+				params.TopologyBalanceDomains = topologyBalanceDomains
+				return nil
+			}
+		},
+	},
+	{
+		name:  "RemoveFailedPods",
+		short: "Evict failed pods",
+		addFlags: func(fs *pflag.FlagSet) func(params *api.StrategyParameters) error {
+			failedPods := &api.FailedPods{}
+			var minPodLifetime time.Duration
+			fs.StringSliceVar(&failedPods.Reasons, "reasons", nil, "only evict pods failed for these reasons")
+			fs.StringSliceVar(&failedPods.ExcludeOwnerKinds, "exclude-owner-kinds", nil, "owner kinds whose pods are not evicted, e.g. Job")
+			fs.DurationVar(&minPodLifetime, "min-pod-lifetime", 0, "only evict pods older than the duration")
+			fs.BoolVar(&failedPods.IncludingInitContainers, "including-init-containers", false, "also check the reasons of init containers")
+			return func(params *api.StrategyParameters) error {
+				if minPodLifetime > 0 {
+					seconds := uint(minPodLifetime.Seconds())
+					failedPods.MinPodLifetimeSeconds = &seconds
+				}
+				params.FailedPods = failedPods
+				return nil
+			}
+		},
+	},
+	{
+		name:  "RemovePodsViolatingPodDensity",
+		short: "Evict pods from topology domains running too many pods matching a selector",
+		addFlags: func(fs *pflag.FlagSet) func(params *api.StrategyParameters) error {
+			podDensity := &api.PodDensity{}
+			var podSelector string
+			fs.StringVar(&podDensity.TopologyKey, "topology-key", "", "node label defining topology domains")
+			fs.IntVar(&podDensity.MaxPodsPerDomain, "max-pods-per-domain", 0, "maximum number of matching pods per domain")
+			fs.StringVar(&podSelector, "pod-selector", "", "label selector of the counted pods")
+			return func(params *api.StrategyParameters) error {
+				if podSelector != "" {
+					selector, err := metav1.ParseToLabelSelector(podSelector)
+					if err != nil {
+						return fmt.Errorf("invalid pod selector %q: %v", podSelector, err)
+					}
+					podDensity.LabelSelector = selector
+				}
+				params.PodDensity = podDensity
+				return nil
+			}
+		},
+	},
+	{
+		name:  "RemovePodsFromNodesWithProblems",
+		short: "Evict pods from nodes reporting problem conditions",
+		addFlags: func(fs *pflag.FlagSet) func(params *api.StrategyParameters) error {
+			nodeProblems := &api.NodeProblems{}
+			fs.StringSliceVar(&nodeProblems.Conditions, "conditions", nil, "node conditions reporting problems, KernelDeadlock and ReadonlyFilesystem by default")
+			fs.IntVar(&nodeProblems.MaxPodsToEvictPerCycle, "max-pods-to-evict-per-cycle", 0, "maximum number of evicted pods, 0 meaning no limit")
+			return func(params *api.StrategyParameters) error {
+				params.NodeProblems = nodeProblems
+				return nil
+			}
+		},
+	},
+	{
+		name:  "RemovePodsFromOvercommittedNodes",
+		short: "Evict pods with the largest limits to requests ratio from overcommitted nodes",
+		addFlags: func(fs *pflag.FlagSet) func(params *api.StrategyParameters) error {
+			var thresholds map[string]string
+			fs.StringToStringVar(&thresholds, "threshold", nil, "maximum sum of limits in percents of the node allocatable, e.g. cpu=300,memory=150")
+			return func(params *api.StrategyParameters) error {
+				parsedThresholds, err := parseThresholds(thresholds)
+				if err != nil {
+					return err
+				}
+				params.LimitsOvercommit = &api.LimitsOvercommit{Thresholds: parsedThresholds}
+				return nil
+			}
+		},
+	},
+	{
+		name:  "RemoveFinishedJobPods",
+		short: "Evict the pods of Jobs finished for longer than a TTL",
+		addFlags: func(fs *pflag.FlagSet) func(params *api.StrategyParameters) error {
+			var ttl time.Duration
+			var excludeOwnerKinds []string
+			fs.DurationVar(&ttl, "ttl-after-finished", 0, "time after which the pods of finished Jobs are evicted, e.g. 24h")
+			fs.StringSliceVar(&excludeOwnerKinds, "exclude-owner-kinds", nil, "Jobs owned by these kinds are not processed, e.g. CronJob")
+			return func(params *api.StrategyParameters) error {
+				seconds := uint(ttl.Seconds())
+				params.FinishedJobPods = &api.FinishedJobPods{TTLSecondsAfterFinished: &seconds, ExcludeOwnerKinds: excludeOwnerKinds}
+				return nil
+			}
+		},
+	},
+	{
+		name:  "RemovePodsExceedingMemoryRequests",
+		short: "Evict pods using more memory than they request from nodes under memory pressure",
+		addFlags: func(fs *pflag.FlagSet) func(params *api.StrategyParameters) error {
+			memoryOverrun := &api.MemoryOverrun{}
+			fs.Float64Var((*float64)(&memoryOverrun.ExcessPercentage), "excess-percentage", 0, "percentage by which the memory usage of a pod must exceed its requests")
+			fs.Float64Var((*float64)(&memoryOverrun.NodeUsageThreshold), "node-usage-threshold", 0, "percentage of the node allocatable memory used by pods above which a node is under memory pressure")
+			fs.IntVar(&memoryOverrun.MaxPodsToEvictPerNode, "max-pods-per-node", 0, "maximum number of pods evicted from a node, 0 means no limit")
+			return func(params *api.StrategyParameters) error {
+				params.MemoryOverrun = memoryOverrun
+				return nil
+			}
+		},
+	},
+	{
+		name:  "RemovePodsWithOutdatedPriority",
+		short: "Evict pods blocking pending pods because of an updated PriorityClass",
+		addFlags: func(fs *pflag.FlagSet) func(params *api.StrategyParameters) error {
+			return func(params *api.StrategyParameters) error {
+				return nil
+			}
+		},
+	},
+	{
+		name:  "RemovePodsFromInterruptedNodes",
+		short: "Evict pods from nodes about to be interrupted, e.g. spot nodes",
+		addFlags: func(fs *pflag.FlagSet) func(params *api.StrategyParameters) error {
+			nodeInterruption := &api.NodeInterruption{}
+			fs.StringSliceVar(&nodeInterruption.Taints, "taints", nil, "keys of the taints set on nodes about to be interrupted, the AWS Node Termination Handler and GKE taints by default")
+			fs.StringSliceVar(&nodeInterruption.Labels, "labels", nil, "keys of the labels set on nodes about to be interrupted")
+			return func(params *api.StrategyParameters) error {
+				params.NodeInterruption = nodeInterruption
+				return nil
+			}
+		},
+	},
+	{
+		name:  "RemovePodsStuckInCreation",
+		short: "Evict pods whose containers are stuck being created, e.g. because of failed volume mounts",
+		addFlags: func(fs *pflag.FlagSet) func(params *api.StrategyParameters) error {
+			var maxStuck time.Duration
+			stuckPods := &api.StuckPods{}
+			fs.DurationVar(&maxStuck, "max-stuck", 0, "time after which pods with containers waiting to be created are evicted, 10m by default")
+			fs.StringSliceVar(&stuckPods.Reasons, "reasons", nil, "waiting reasons of stuck containers, ContainerCreating and CreateContainerError by default")
+			return func(params *api.StrategyParameters) error {
+				if maxStuck > 0 {
+					seconds := uint(maxStuck.Seconds())
+					stuckPods.MaxStuckSeconds = &seconds
+				}
+				params.StuckPods = stuckPods
+				return nil
+			}
+		},
+	},
+	{
+		name:  "RemovePodsForImageLocality",
+		short: "Evict pods of image-heavy workloads from nodes where they alone use their images",
+		addFlags: func(fs *pflag.FlagSet) func(params *api.StrategyParameters) error {
+			imageLocality := &api.ImageLocality{}
+			var minImageSizeMB uint
+			fs.UintVar(&minImageSizeMB, "min-image-size", 0, "size in MB from which images are considered heavy, 1000 by default")
+			return func(params *api.StrategyParameters) error {
+				if minImageSizeMB > 0 {
+					imageLocality.MinImageSizeMB = &minImageSizeMB
+				}
+				params.ImageLocality = imageLocality
+				return nil
+			}
+		},
+	},
+	{
+		name:  "RemovePodsFromNotReadyNodes",
+		short: "Evict pods from nodes NotReady for too long without being tainted by the node lifecycle controller",
+		addFlags: func(fs *pflag.FlagSet) func(params *api.StrategyParameters) error {
+			var notReady, forceDeleteAfter time.Duration
+			fs.DurationVar(&notReady, "not-ready", 0, "time after which pods are evicted from NotReady nodes, 5m by default")
+			fs.DurationVar(&forceDeleteAfter, "force-delete-after", 0, "time after which pods still terminating on NotReady nodes are force deleted, never by default")
+			return func(params *api.StrategyParameters) error {
+				notReadyNodes := &api.NotReadyNodes{}
+				if notReady > 0 {
+					seconds := uint(notReady.Seconds())
+					notReadyNodes.NotReadySeconds = &seconds
+				}
+				if forceDeleteAfter > 0 {
+					seconds := uint(forceDeleteAfter.Seconds())
+					notReadyNodes.ForceDeleteAfterSeconds = &seconds
+				}
+				params.NotReadyNodes = notReadyNodes
+				return nil
+			}
+		},
+	},
+	{
+		name:  "RemoveDuplicateJobIndexPods",
+		short: "Evict pods of Indexed Jobs running the same completion index as an older pod of the Job",
+		addFlags: func(fs *pflag.FlagSet) func(params *api.StrategyParameters) error {
+			return func(params *api.StrategyParameters) error {
+				return nil
+			}
+		},
+	},
+	{
+		name:  "RemovePodsViolatingGPUModelPreference",
+		short: "Evict GPU pods from nodes of GPU models they rank lower than the model of a node with free GPUs",
+		addFlags: func(fs *pflag.FlagSet) func(params *api.StrategyParameters) error {
+			gpuModelPreference := &api.GPUModelPreference{}
+			var models []string
+			fs.StringVar(&gpuModelPreference.ModelLabel, "model-label", "", "node label holding the GPU model, nvidia.com/gpu.product by default")
+			fs.StringVar(&gpuModelPreference.ResourceName, "resource-name", "", "extended resource of GPUs, nvidia.com/gpu by default")
+			fs.StringSliceVar(&models, "models", nil, "GPU models ranked for all pods, most preferred first, preferred node affinity terms rank models when not set")
+			return func(params *api.StrategyParameters) error {
+				if len(models) > 0 {
+					gpuModelPreference.ModelPriorities = []api.GPUModelPriority{{Models: models}}
+				}
+				params.GPUModelPreference = gpuModelPreference
+				return nil
+			}
+		},
+	},
+	{
+		name:  "RemovePodsStuckTerminating",
+		short: "Evict again, or force delete, pods stuck terminating on NotReady nodes",
+		addFlags: func(fs *pflag.FlagSet) func(params *api.StrategyParameters) error {
+			var maxTerminating time.Duration
+			terminatingPods := &api.TerminatingPods{}
+			fs.DurationVar(&maxTerminating, "max-terminating", 0, "time after their deletion timestamp after which pods are stuck terminating, 10m by default")
+			fs.BoolVar(&terminatingPods.ForceDelete, "force-delete", false, "force deletes stuck pods without grace period instead of evicting them again, assuming their nodes are gone")
+			return func(params *api.StrategyParameters) error {
+				if maxTerminating > 0 {
+					seconds := uint(maxTerminating.Seconds())
+					terminatingPods.MaxTerminatingSeconds = &seconds
+				}
+				params.TerminatingPods = terminatingPods
+				return nil
+			}
+		},
+	},
+	{
+		name:  "RemovePodsFromNodesWithRestartChurn",
+		short: "Evict the pods restarting the most from nodes whose containers restart too often",
+		addFlags: func(fs *pflag.FlagSet) func(params *api.StrategyParameters) error {
+			var window time.Duration
+			restartChurn := &api.RestartChurn{}
+			fs.Int32Var(&restartChurn.NodeRestartThreshold, "node-restart-threshold", 0, "number of container restarts of the pods of a node within the window from which pods are evicted from the node")
+			fs.DurationVar(&window, "window", 0, "time window restarts are counted in, 1h by default")
+			fs.BoolVar(&restartChurn.IncludingInitContainers, "including-init-containers", false, "counts the restarts of init containers")
+			fs.IntVar(&restartChurn.MaxPodsToEvictPerNode, "max-pods-to-evict-per-node", 0, "maximum number of pods evicted from a node, 0 for no limit")
+			return func(params *api.StrategyParameters) error {
+				if window > 0 {
+					seconds := uint(window.Seconds())
+					restartChurn.WindowSeconds = &seconds
+				}
+				params.RestartChurn = restartChurn
+				return nil
+			}
+		},
+	},
+}
+
+// parseThresholds converts resource=percentage flag values
+func parseThresholds(values map[string]string) (api.ResourceThresholds, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	thresholds := make(api.ResourceThresholds, len(values))
+	for name, value := range values {
+		percentage, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s threshold %q: %v", name, value, err)
+		}
+		thresholds[v1.ResourceName(name)] = api.Percentage(percentage)
+	}
+	return thresholds, nil
+}
@@ -0,0 +1,527 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+// runCommand executes the plugin with the given arguments and returns the policy it would have run
+func runCommand(args ...string) (*api.DeschedulerPolicy, error) {
+	var policy *api.DeschedulerPolicy
+	defaultRun := run
+	defer func() {
+		run = defaultRun
+	}()
+	run = func(_ context.Context, _ *globalOptions, p *api.DeschedulerPolicy) error {
+		policy = p
+		return nil
+	}
+
+	cmd := NewDescheduleCommand()
+	cmd.SetArgs(args)
+	cmd.SetOut(ioutil.Discard)
+	cmd.SetErr(ioutil.Discard)
+	err := cmd.Execute()
+	return policy, err
+}
+
+func TestParseThresholds(t *testing.T) {
+	tests := []struct {
+		description string
+		values      map[string]string
+		expected    api.ResourceThresholds
+		err         string
+	}{
+		{
+			description: "no thresholds",
+		},
+		{
+			description: "integer and decimal percentages",
+			values:      map[string]string{"cpu": "20", "memory": "30.5", "pods": "0"},
+			expected:    api.ResourceThresholds{v1.ResourceCPU: 20, v1.ResourceMemory: 30.5, v1.ResourcePods: 0},
+		},
+		{
+			description: "extended resource",
+			values:      map[string]string{"nvidia.com/gpu": "50"},
+			expected:    api.ResourceThresholds{"nvidia.com/gpu": 50},
+		},
+		{
+			description: "percentage which is not a number",
+			values:      map[string]string{"cpu": "abc"},
+			err:         `invalid cpu threshold "abc"`,
+		},
+		{
+			description: "percentage with a percent sign",
+			values:      map[string]string{"memory": "20%"},
+			err:         `invalid memory threshold "20%"`,
+		},
+		{
+			description: "empty percentage",
+			values:      map[string]string{"cpu": ""},
+			err:         `invalid cpu threshold ""`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			thresholds, err := parseThresholds(test.values)
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("expected error containing %q, got %v", test.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(thresholds, test.expected) {
+				t.Errorf("expected thresholds %v, got %v", test.expected, thresholds)
+			}
+		})
+	}
+}
+
+func TestSetParams(t *testing.T) {
+	priority := int32(1000)
+	zeroPriority := int32(0)
+	tests := []struct {
+		description string
+		args        []string
+		expected    *api.StrategyParameters
+		err         string
+	}{
+		{
+			description: "no shared flags",
+			expected:    &api.StrategyParameters{},
+		},
+		{
+			description: "namespaces",
+			args:        []string{"--include-namespaces", "a,b", "--exclude-namespaces", "c"},
+			expected: &api.StrategyParameters{
+				Namespaces: &api.Namespaces{Include: []string{"a", "b"}, Exclude: []string{"c"}},
+			},
+		},
+		{
+			description: "threshold priority",
+			args:        []string{"--threshold-priority", "1000"},
+			expected:    &api.StrategyParameters{ThresholdPriority: &priority},
+		},
+		{
+			description: "zero threshold priority is set when given",
+			args:        []string{"--threshold-priority", "0"},
+			expected:    &api.StrategyParameters{ThresholdPriority: &zeroPriority},
+		},
+		{
+			description: "threshold priority class name, node fit and node roles",
+			args:        []string{"--threshold-priority-class-name", "high", "--node-fit", "--node-roles", "worker,infra"},
+			expected: &api.StrategyParameters{
+				ThresholdPriorityClassName: "high",
+				NodeFit:                    true,
+				NodeRoles:                  []string{"worker", "infra"},
+			},
+		},
+		{
+			description: "label selector",
+			args:        []string{"--label-selector", "app=web,tier in (db,cache)"},
+			expected: &api.StrategyParameters{
+				LabelSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"app": "web"},
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{Key: "tier", Operator: metav1.LabelSelectorOpIn, Values: []string{"cache", "db"}},
+					},
+				},
+			},
+		},
+		{
+			description: "invalid label selector",
+			args:        []string{"--label-selector", "app in web"},
+			err:         `invalid label selector "app in web"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			policy, err := runCommand(append([]string{"removepodsviolatingnodetaints"}, test.args...)...)
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("expected error containing %q, got %v", test.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			params := policy.Strategies["RemovePodsViolatingNodeTaints"].Params
+			if !reflect.DeepEqual(params, test.expected) {
+				t.Errorf("expected params %+v, got %+v", test.expected, params)
+			}
+		})
+	}
+}
+
+func TestGlobalPolicyOptions(t *testing.T) {
+	policy, err := runCommand("removepodsviolatingnodetaints", "--node-selector", "pool=spot", "--max-pods-to-evict-per-node", "2", "--evict-local-storage-pods", "--ignore-pvc-pods")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if policy.NodeSelector == nil || *policy.NodeSelector != "pool=spot" {
+		t.Errorf("expected node selector pool=spot, got %v", policy.NodeSelector)
+	}
+	if policy.MaxNoOfPodsToEvictPerNode == nil || *policy.MaxNoOfPodsToEvictPerNode != 2 {
+		t.Errorf("expected at most 2 pods evicted per node, got %v", policy.MaxNoOfPodsToEvictPerNode)
+	}
+	if !*policy.EvictLocalStoragePods || *policy.EvictSystemCriticalPods || !*policy.IgnorePVCPods {
+		t.Errorf("unexpected eviction options: evictLocalStoragePods=%v evictSystemCriticalPods=%v ignorePVCPods=%v",
+			*policy.EvictLocalStoragePods, *policy.EvictSystemCriticalPods, *policy.IgnorePVCPods)
+	}
+
+	policy, err = runCommand("removepodsviolatingnodetaints")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if policy.NodeSelector != nil || policy.MaxNoOfPodsToEvictPerNode != nil {
+		t.Errorf("expected no node selector nor eviction limit, got %v and %v", policy.NodeSelector, policy.MaxNoOfPodsToEvictPerNode)
+	}
+}
+
+func TestUnknownStrategy(t *testing.T) {
+	for _, args := range [][]string{{"unknownstrategy"}, {"podlifetime", "extra"}, {"podlifetime", "--unknown-flag"}} {
+		if policy, err := runCommand(args...); err == nil {
+			t.Errorf("expected an error running %v, got policy %+v", args, policy)
+		}
+	}
+}
+
+func TestStrategyCommands(t *testing.T) {
+	uintPtr := func(value uint) *uint {
+		return &value
+	}
+
+	tests := []struct {
+		description string
+		args        []string
+		strategy    api.StrategyName
+		expected    *api.StrategyParameters
+		err         string
+	}{
+		{
+			description: "LowNodeUtilization",
+			args:        []string{"lownodeutilization", "--threshold", "cpu=20,memory=20", "--target-threshold", "cpu=50,memory=60.5", "--number-of-nodes", "3", "--thresholds-operator", "And"},
+			strategy:    "LowNodeUtilization",
+			expected: &api.StrategyParameters{
+				NodeResourceUtilizationThresholds: &api.NodeResourceUtilizationThresholds{
+					Thresholds:         api.ResourceThresholds{v1.ResourceCPU: 20, v1.ResourceMemory: 20},
+					TargetThresholds:   api.ResourceThresholds{v1.ResourceCPU: 50, v1.ResourceMemory: 60.5},
+					NumberOfNodes:      3,
+					ThresholdsOperator: "And",
+				},
+			},
+		},
+		{
+			description: "LowNodeUtilization with an invalid threshold",
+			args:        []string{"lownodeutilization", "--threshold", "cpu=low", "--target-threshold", "cpu=50"},
+			err:         `invalid cpu threshold "low"`,
+		},
+		{
+			description: "LowNodeUtilization with an invalid target threshold",
+			args:        []string{"lownodeutilization", "--threshold", "cpu=20", "--target-threshold", "memory=high"},
+			err:         `invalid memory threshold "high"`,
+		},
+		{
+			description: "HighNodeUtilization",
+			args:        []string{"highnodeutilization", "--threshold", "cpu=20", "--number-of-nodes", "1"},
+			strategy:    "HighNodeUtilization",
+			expected: &api.StrategyParameters{
+				NodeResourceUtilizationThresholds: &api.NodeResourceUtilizationThresholds{
+					Thresholds:    api.ResourceThresholds{v1.ResourceCPU: 20},
+					NumberOfNodes: 1,
+				},
+			},
+		},
+		{
+			description: "HighNodeUtilization with an invalid threshold",
+			args:        []string{"highnodeutilization", "--threshold", "cpu=20%"},
+			err:         `invalid cpu threshold "20%"`,
+		},
+		{
+			description: "RemoveDuplicates",
+			args:        []string{"removeduplicates", "--exclude-owner-kinds", "ReplicaSet"},
+			strategy:    "RemoveDuplicates",
+			expected: &api.StrategyParameters{
+				RemoveDuplicates: &api.RemoveDuplicates{ExcludeOwnerKinds: []string{"ReplicaSet"}},
+			},
+		},
+		{
+			description: "RemoveDuplicates without excluded owner kinds",
+			args:        []string{"removeduplicates"},
+			strategy:    "RemoveDuplicates",
+			expected:    &api.StrategyParameters{},
+		},
+		{
+			description: "PodLifeTime",
+			args:        []string{"podlifetime", "--max-pod-lifetime", "24h", "--pod-status-phases", "Pending"},
+			strategy:    "PodLifeTime",
+			expected: &api.StrategyParameters{
+				PodLifeTime: &api.PodLifeTime{MaxPodLifeTimeSeconds: uintPtr(86400), PodStatusPhases: []string{"Pending"}},
+			},
+		},
+		{
+			description: "PodLifeTime without maximum lifetime",
+			args:        []string{"podlifetime"},
+			err:         "--max-pod-lifetime must be set",
+		},
+		{
+			description: "PodLifeTime with an invalid maximum lifetime",
+			args:        []string{"podlifetime", "--max-pod-lifetime", "1day"},
+			err:         "invalid argument",
+		},
+		{
+			description: "RemovePodsHavingTooManyRestarts",
+			args:        []string{"removepodshavingtoomanyrestarts", "--pod-restart-threshold", "10", "--including-init-containers"},
+			strategy:    "RemovePodsHavingTooManyRestarts",
+			expected: &api.StrategyParameters{
+				PodsHavingTooManyRestarts: &api.PodsHavingTooManyRestarts{PodRestartThreshold: 10, IncludingInitContainers: true},
+			},
+		},
+		{
+			description: "RemovePodsViolatingNodeTaints",
+			args:        []string{"removepodsviolatingnodetaints"},
+			strategy:    "RemovePodsViolatingNodeTaints",
+			expected:    &api.StrategyParameters{},
+		},
+		{
+			description: "RemovePodsViolatingNodeAffinity with the default affinity type",
+			args:        []string{"removepodsviolatingnodeaffinity"},
+			strategy:    "RemovePodsViolatingNodeAffinity",
+			expected: &api.StrategyParameters{
+				NodeAffinityType: []string{"requiredDuringSchedulingIgnoredDuringExecution"},
+			},
+		},
+		{
+			description: "RemovePodsViolatingNodeAffinity",
+			args:        []string{"removepodsviolatingnodeaffinity", "--node-affinity-type", "nodeSelector"},
+			strategy:    "RemovePodsViolatingNodeAffinity",
+			expected: &api.StrategyParameters{
+				NodeAffinityType: []string{"nodeSelector"},
+			},
+		},
+		{
+			description: "RemovePodsViolatingInterPodAntiAffinity",
+			args:        []string{"removepodsviolatinginterpodantiaffinity"},
+			strategy:    "RemovePodsViolatingInterPodAntiAffinity",
+			expected:    &api.StrategyParameters{},
+		},
+		{
+			description: "RemovePodsViolatingTopologySpreadConstraint",
+			args:        []string{"removepodsviolatingtopologyspreadconstraint", "--include-soft-constraints", "--max-skew-reduction", "2", "--topology-balance-domains", "topology.example.com/chassis"},
+			strategy:    "RemovePodsViolatingTopologySpreadConstraint",
+			expected: &api.StrategyParameters{
+				IncludeSoftConstraints:   true,
+				MaxSkewReductionPerCycle: 2,
+				TopologyBalanceDomains:   []string{"topology.example.com/chassis"},
+			},
+		},
+		{
+			description: "RemoveFailedPods",
+			args:        []string{"removefailedpods", "--reasons", "NodeAffinity", "--exclude-owner-kinds", "Job", "--min-pod-lifetime", "1h", "--including-init-containers"},
+			strategy:    "RemoveFailedPods",
+			expected: &api.StrategyParameters{
+				FailedPods: &api.FailedPods{
+					Reasons:                 []string{"NodeAffinity"},
+					ExcludeOwnerKinds:       []string{"Job"},
+					MinPodLifetimeSeconds:   uintPtr(3600),
+					IncludingInitContainers: true,
+				},
+			},
+		},
+		{
+			description: "RemoveFailedPods without minimum lifetime",
+			args:        []string{"removefailedpods"},
+			strategy:    "RemoveFailedPods",
+			expected:    &api.StrategyParameters{FailedPods: &api.FailedPods{}},
+		},
+		{
+			description: "RemovePodsViolatingPodDensity",
+			args:        []string{"removepodsviolatingpoddensity", "--topology-key", "topology.kubernetes.io/zone", "--max-pods-per-domain", "5", "--pod-selector", "app=web"},
+			strategy:    "RemovePodsViolatingPodDensity",
+			expected: &api.StrategyParameters{
+				PodDensity: &api.PodDensity{
+					TopologyKey:      "topology.kubernetes.io/zone",
+					MaxPodsPerDomain: 5,
+					LabelSelector:    &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}, MatchExpressions: []metav1.LabelSelectorRequirement{}},
+				},
+			},
+		},
+		{
+			description: "RemovePodsViolatingPodDensity with an invalid pod selector",
+			args:        []string{"removepodsviolatingpoddensity", "--pod-selector", "app in web"},
+			err:         `invalid pod selector "app in web"`,
+		},
+		{
+			description: "RemovePodsFromNodesWithProblems",
+			args:        []string{"removepodsfromnodeswithproblems", "--conditions", "KernelDeadlock", "--max-pods-to-evict-per-cycle", "4"},
+			strategy:    "RemovePodsFromNodesWithProblems",
+			expected: &api.StrategyParameters{
+				NodeProblems: &api.NodeProblems{Conditions: []string{"KernelDeadlock"}, MaxPodsToEvictPerCycle: 4},
+			},
+		},
+		{
+			description: "RemovePodsFromOvercommittedNodes",
+			args:        []string{"removepodsfromovercommittednodes", "--threshold", "cpu=300,memory=150"},
+			strategy:    "RemovePodsFromOvercommittedNodes",
+			expected: &api.StrategyParameters{
+				LimitsOvercommit: &api.LimitsOvercommit{Thresholds: api.ResourceThresholds{v1.ResourceCPU: 300, v1.ResourceMemory: 150}},
+			},
+		},
+		{
+			description: "RemovePodsFromOvercommittedNodes with an invalid threshold",
+			args:        []string{"removepodsfromovercommittednodes", "--threshold", "cpu=three"},
+			err:         `invalid cpu threshold "three"`,
+		},
+		{
+			description: "RemoveFinishedJobPods",
+			args:        []string{"removefinishedjobpods", "--ttl-after-finished", "30m", "--exclude-owner-kinds", "CronJob"},
+			strategy:    "RemoveFinishedJobPods",
+			expected: &api.StrategyParameters{
+				FinishedJobPods: &api.FinishedJobPods{TTLSecondsAfterFinished: uintPtr(1800), ExcludeOwnerKinds: []string{"CronJob"}},
+			},
+		},
+		{
+			description: "RemovePodsExceedingMemoryRequests",
+			args:        []string{"removepodsexceedingmemoryrequests", "--excess-percentage", "50", "--node-usage-threshold", "80.5", "--max-pods-per-node", "2"},
+			strategy:    "RemovePodsExceedingMemoryRequests",
+			expected: &api.StrategyParameters{
+				MemoryOverrun: &api.MemoryOverrun{ExcessPercentage: 50, NodeUsageThreshold: 80.5, MaxPodsToEvictPerNode: 2},
+			},
+		},
+		{
+			description: "RemovePodsWithOutdatedPriority",
+			args:        []string{"removepodswithoutdatedpriority"},
+			strategy:    "RemovePodsWithOutdatedPriority",
+			expected:    &api.StrategyParameters{},
+		},
+		{
+			description: "RemovePodsFromInterruptedNodes",
+			args:        []string{"removepodsfrominterruptednodes", "--taints", "example.com/interruption", "--labels", "example.com/preempted"},
+			strategy:    "RemovePodsFromInterruptedNodes",
+			expected: &api.StrategyParameters{
+				NodeInterruption: &api.NodeInterruption{Taints: []string{"example.com/interruption"}, Labels: []string{"example.com/preempted"}},
+			},
+		},
+		{
+			description: "RemovePodsStuckInCreation",
+			args:        []string{"removepodsstuckincreation", "--max-stuck", "15m", "--reasons", "ContainerCreating"},
+			strategy:    "RemovePodsStuckInCreation",
+			expected: &api.StrategyParameters{
+				StuckPods: &api.StuckPods{MaxStuckSeconds: uintPtr(900), Reasons: []string{"ContainerCreating"}},
+			},
+		},
+		{
+			description: "RemovePodsForImageLocality",
+			args:        []string{"removepodsforimagelocality", "--min-image-size", "500"},
+			strategy:    "RemovePodsForImageLocality",
+			expected: &api.StrategyParameters{
+				ImageLocality: &api.ImageLocality{MinImageSizeMB: uintPtr(500)},
+			},
+		},
+		{
+			description: "RemovePodsForImageLocality with the default image size",
+			args:        []string{"removepodsforimagelocality"},
+			strategy:    "RemovePodsForImageLocality",
+			expected:    &api.StrategyParameters{ImageLocality: &api.ImageLocality{}},
+		},
+		{
+			description: "RemovePodsFromNotReadyNodes",
+			args:        []string{"removepodsfromnotreadynodes", "--not-ready", "10m", "--force-delete-after", "1h"},
+			strategy:    "RemovePodsFromNotReadyNodes",
+			expected: &api.StrategyParameters{
+				NotReadyNodes: &api.NotReadyNodes{NotReadySeconds: uintPtr(600), ForceDeleteAfterSeconds: uintPtr(3600)},
+			},
+		},
+		{
+			description: "RemoveDuplicateJobIndexPods",
+			args:        []string{"removeduplicatejobindexpods"},
+			strategy:    "RemoveDuplicateJobIndexPods",
+			expected:    &api.StrategyParameters{},
+		},
+		{
+			description: "RemovePodsViolatingGPUModelPreference",
+			args:        []string{"removepodsviolatinggpumodelpreference", "--model-label", "gpu.example.com/model", "--resource-name", "example.com/gpu", "--models", "A100,T4"},
+			strategy:    "RemovePodsViolatingGPUModelPreference",
+			expected: &api.StrategyParameters{
+				GPUModelPreference: &api.GPUModelPreference{
+					ModelLabel:      "gpu.example.com/model",
+					ResourceName:    "example.com/gpu",
+					ModelPriorities: []api.GPUModelPriority{{Models: []string{"A100", "T4"}}},
+				},
+			},
+		},
+		{
+			description: "RemovePodsStuckTerminating",
+			args:        []string{"removepodsstuckterminating", "--max-terminating", "20m", "--force-delete"},
+			strategy:    "RemovePodsStuckTerminating",
+			expected: &api.StrategyParameters{
+				TerminatingPods: &api.TerminatingPods{MaxTerminatingSeconds: uintPtr(1200), ForceDelete: true},
+			},
+		},
+		{
+			description: "RemovePodsFromNodesWithRestartChurn",
+			args:        []string{"removepodsfromnodeswithrestartchurn", "--node-restart-threshold", "50", "--window", "30m", "--including-init-containers", "--max-pods-to-evict-per-node", "3"},
+			strategy:    "RemovePodsFromNodesWithRestartChurn",
+			expected: &api.StrategyParameters{
+				RestartChurn: &api.RestartChurn{NodeRestartThreshold: 50, WindowSeconds: uintPtr(1800), IncludingInitContainers: true, MaxPodsToEvictPerNode: 3},
+			},
+		},
+	}
+
+	tested := map[api.StrategyName]bool{}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			policy, err := runCommand(test.args...)
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("expected error containing %q, got %v", test.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			expected := api.StrategyList{test.strategy: api.DeschedulerStrategy{Enabled: true, Params: test.expected}}
+			if !reflect.DeepEqual(policy.Strategies, expected) {
+				t.Errorf("expected strategies %+v, got %+v", expected, policy.Strategies)
+			}
+			tested[test.strategy] = true
+		})
+	}
+
+	for _, strategy := range strategyCommands {
+		if !tested[strategy.name] {
+			t.Errorf("no test runs strategy %v", strategy.name)
+		}
+	}
+}
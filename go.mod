@@ -4,8 +4,11 @@ go 1.16
 
 require (
 	github.com/client9/misspell v0.3.4
+	github.com/fsnotify/fsnotify v1.4.9
+	github.com/golang/snappy v0.0.4
 	github.com/spf13/cobra v1.1.3
 	github.com/spf13/pflag v1.0.5
+	google.golang.org/protobuf v1.26.0
 	k8s.io/api v0.22.0
 	k8s.io/apimachinery v0.22.0
 	k8s.io/apiserver v0.22.0
@@ -15,5 +18,7 @@ require (
 	k8s.io/component-helpers v0.22.0
 	k8s.io/klog/v2 v2.9.0
 	k8s.io/kubectl v0.20.5
+	k8s.io/metrics v0.22.0
 	sigs.k8s.io/mdtoc v1.0.1
+	sigs.k8s.io/yaml v1.2.0
 )
@@ -34,9 +34,50 @@ var (
 		&metrics.CounterOpts{
 			Subsystem:      DeschedulerSubsystem,
 			Name:           "pods_evicted",
-			Help:           "Number of evicted pods, by the result, by the strategy, by the namespace. 'failed' result means a pod could not be evicted",
+			Help:           "Number of evicted pods, by the result, by the strategy, by the eviction cause, by the namespace. 'failed' result means a pod could not be evicted",
 			StabilityLevel: metrics.ALPHA,
-		}, []string{"result", "strategy", "namespace"})
+		}, []string{"result", "strategy", "cause", "namespace"})
+
+	NodeUtilizationWarning = metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Subsystem:      DeschedulerSubsystem,
+			Name:           "node_utilization_warning",
+			Help:           "Whether a node's usage crosses the warning thresholds of a strategy, by the strategy, by the node. 1 means the warning thresholds are crossed",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{"strategy", "node"})
+
+	StrategyErrors = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      DeschedulerSubsystem,
+			Name:           "strategy_errors",
+			Help:           "Number of strategy runs that failed, by the strategy, by the reason of the error",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{"strategy", "reason"})
+
+	EvictionLatency = metrics.NewHistogramVec(
+		&metrics.HistogramOpts{
+			Subsystem:      DeschedulerSubsystem,
+			Name:           "eviction_duration_seconds",
+			Help:           "Latency of eviction API calls in seconds, by the strategy, by the result. Dry run evictions are not observed",
+			Buckets:        metrics.ExponentialBuckets(0.005, 2, 12),
+			StabilityLevel: metrics.ALPHA,
+		}, []string{"strategy", "result"})
+
+	EvictionErrors = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      DeschedulerSubsystem,
+			Name:           "eviction_errors",
+			Help:           "Number of eviction API calls that failed, by the strategy, by the class of the HTTP status: 429 (blocked by a pod disruption budget or throttled), 404, 4xx, 5xx or unknown",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{"strategy", "code"})
+
+	CyclesAborted = metrics.NewCounter(
+		&metrics.CounterOpts{
+			Subsystem:      DeschedulerSubsystem,
+			Name:           "cycles_aborted",
+			Help:           "Number of descheduling cycles aborted because too many of their eviction attempts failed",
+			StabilityLevel: metrics.ALPHA,
+		})
 
 	buildInfo = metrics.NewGauge(
 		&metrics.GaugeOpts{
@@ -50,6 +91,11 @@ var (
 
 	metricsList = []metrics.Registerable{
 		PodsEvicted,
+		NodeUtilizationWarning,
+		StrategyErrors,
+		EvictionLatency,
+		EvictionErrors,
+		CyclesAborted,
 		buildInfo,
 	}
 )
@@ -0,0 +1,52 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/conversion"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+// Convert_api_DeschedulerPolicy_To_v1alpha1_DeschedulerPolicy flattens the profile of a policy with a single
+// profile into the top level strategies, v1alpha1 can not represent more profiles.
+func Convert_api_DeschedulerPolicy_To_v1alpha1_DeschedulerPolicy(in *api.DeschedulerPolicy, out *DeschedulerPolicy, s conversion.Scope) error {
+	if err := autoConvert_api_DeschedulerPolicy_To_v1alpha1_DeschedulerPolicy(in, out, s); err != nil {
+		return err
+	}
+	if len(in.Profiles) == 0 {
+		return nil
+	}
+	if len(in.Profiles) > 1 || len(in.Strategies) > 0 {
+		return fmt.Errorf("policy with %d profiles can not be converted to %s", len(in.Profiles), SchemeGroupVersion)
+	}
+	profile := in.Profiles[0]
+	out.Strategies = make(StrategyList, len(profile.Strategies))
+	for name, strategy := range profile.Strategies {
+		var converted DeschedulerStrategy
+		if err := Convert_api_DeschedulerStrategy_To_v1alpha1_DeschedulerStrategy(&strategy, &converted, s); err != nil {
+			return err
+		}
+		out.Strategies[StrategyName(name)] = converted
+	}
+	out.NodeSelector = profile.NodeSelector
+	out.MaxNoOfPodsToEvictPerNode = profile.MaxNoOfPodsToEvictPerNode
+	out.MaxPerOwnerPerNode = profile.MaxPerOwnerPerNode
+	return nil
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// UnmarshalJSON decodes a percentage from a number. Strings are refused with a suggestion,
+// as they are usually percentages with a "%" suffix or resource quantities like "2000m".
+func (p *Percentage) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		var number float64
+		if err := json.Unmarshal(data, &number); err != nil {
+			return err
+		}
+		*p = Percentage(number)
+		return nil
+	}
+	if percent, err := strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64); err == nil {
+		return fmt.Errorf("percentage %q must be a number, did you mean %v?", value, percent)
+	}
+	if _, err := resource.ParseQuantity(value); err == nil {
+		return fmt.Errorf("percentage %q looks like a resource quantity, percentages are relative to the node capacity, e.g. 20 for 20%%", value)
+	}
+	return fmt.Errorf("percentage %q must be a number", value)
+}
@@ -23,6 +23,8 @@ package v1alpha1
 import (
 	unsafe "unsafe"
 
+	corev1 "k8s.io/api/core/v1"
+	resource "k8s.io/apimachinery/pkg/api/resource"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
@@ -36,13 +38,48 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
-	if err := s.AddGeneratedConversionFunc((*DeschedulerPolicy)(nil), (*api.DeschedulerPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha1_DeschedulerPolicy_To_api_DeschedulerPolicy(a.(*DeschedulerPolicy), b.(*api.DeschedulerPolicy), scope)
+	if err := s.AddGeneratedConversionFunc((*AntiColocation)(nil), (*api.AntiColocation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_AntiColocation_To_api_AntiColocation(a.(*AntiColocation), b.(*api.AntiColocation), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*api.DeschedulerPolicy)(nil), (*DeschedulerPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_api_DeschedulerPolicy_To_v1alpha1_DeschedulerPolicy(a.(*api.DeschedulerPolicy), b.(*DeschedulerPolicy), scope)
+	if err := s.AddGeneratedConversionFunc((*api.AntiColocation)(nil), (*AntiColocation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_AntiColocation_To_v1alpha1_AntiColocation(a.(*api.AntiColocation), b.(*AntiColocation), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*AntiColocationPair)(nil), (*api.AntiColocationPair)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_AntiColocationPair_To_api_AntiColocationPair(a.(*AntiColocationPair), b.(*api.AntiColocationPair), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.AntiColocationPair)(nil), (*AntiColocationPair)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_AntiColocationPair_To_v1alpha1_AntiColocationPair(a.(*api.AntiColocationPair), b.(*AntiColocationPair), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CooldownPersistence)(nil), (*api.CooldownPersistence)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_CooldownPersistence_To_api_CooldownPersistence(a.(*CooldownPersistence), b.(*api.CooldownPersistence), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.CooldownPersistence)(nil), (*CooldownPersistence)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_CooldownPersistence_To_v1alpha1_CooldownPersistence(a.(*api.CooldownPersistence), b.(*CooldownPersistence), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CycleAbort)(nil), (*api.CycleAbort)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_CycleAbort_To_api_CycleAbort(a.(*CycleAbort), b.(*api.CycleAbort), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.CycleAbort)(nil), (*CycleAbort)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_CycleAbort_To_v1alpha1_CycleAbort(a.(*api.CycleAbort), b.(*CycleAbort), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DeschedulerPolicy)(nil), (*api.DeschedulerPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_DeschedulerPolicy_To_api_DeschedulerPolicy(a.(*DeschedulerPolicy), b.(*api.DeschedulerPolicy), scope)
 	}); err != nil {
 		return err
 	}
@@ -56,6 +93,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*EvictedPodAnnotations)(nil), (*api.EvictedPodAnnotations)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_EvictedPodAnnotations_To_api_EvictedPodAnnotations(a.(*EvictedPodAnnotations), b.(*api.EvictedPodAnnotations), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.EvictedPodAnnotations)(nil), (*EvictedPodAnnotations)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_EvictedPodAnnotations_To_v1alpha1_EvictedPodAnnotations(a.(*api.EvictedPodAnnotations), b.(*EvictedPodAnnotations), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*EvictionHistory)(nil), (*api.EvictionHistory)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_EvictionHistory_To_api_EvictionHistory(a.(*EvictionHistory), b.(*api.EvictionHistory), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.EvictionHistory)(nil), (*EvictionHistory)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_EvictionHistory_To_v1alpha1_EvictionHistory(a.(*api.EvictionHistory), b.(*EvictionHistory), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*EvictionLimits)(nil), (*api.EvictionLimits)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_EvictionLimits_To_api_EvictionLimits(a.(*EvictionLimits), b.(*api.EvictionLimits), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.EvictionLimits)(nil), (*EvictionLimits)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_EvictionLimits_To_v1alpha1_EvictionLimits(a.(*api.EvictionLimits), b.(*EvictionLimits), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*FailedPods)(nil), (*api.FailedPods)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_FailedPods_To_api_FailedPods(a.(*FailedPods), b.(*api.FailedPods), scope)
 	}); err != nil {
@@ -66,6 +133,86 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*FinishedJobPods)(nil), (*api.FinishedJobPods)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_FinishedJobPods_To_api_FinishedJobPods(a.(*FinishedJobPods), b.(*api.FinishedJobPods), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.FinishedJobPods)(nil), (*FinishedJobPods)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_FinishedJobPods_To_v1alpha1_FinishedJobPods(a.(*api.FinishedJobPods), b.(*FinishedJobPods), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*GPUModelPreference)(nil), (*api.GPUModelPreference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_GPUModelPreference_To_api_GPUModelPreference(a.(*GPUModelPreference), b.(*api.GPUModelPreference), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.GPUModelPreference)(nil), (*GPUModelPreference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_GPUModelPreference_To_v1alpha1_GPUModelPreference(a.(*api.GPUModelPreference), b.(*GPUModelPreference), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*GPUModelPriority)(nil), (*api.GPUModelPriority)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_GPUModelPriority_To_api_GPUModelPriority(a.(*GPUModelPriority), b.(*api.GPUModelPriority), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.GPUModelPriority)(nil), (*GPUModelPriority)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_GPUModelPriority_To_v1alpha1_GPUModelPriority(a.(*api.GPUModelPriority), b.(*GPUModelPriority), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*HealthGates)(nil), (*api.HealthGates)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_HealthGates_To_api_HealthGates(a.(*HealthGates), b.(*api.HealthGates), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.HealthGates)(nil), (*HealthGates)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_HealthGates_To_v1alpha1_HealthGates(a.(*api.HealthGates), b.(*HealthGates), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ImageLocality)(nil), (*api.ImageLocality)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ImageLocality_To_api_ImageLocality(a.(*ImageLocality), b.(*api.ImageLocality), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.ImageLocality)(nil), (*ImageLocality)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_ImageLocality_To_v1alpha1_ImageLocality(a.(*api.ImageLocality), b.(*ImageLocality), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*LimitsOvercommit)(nil), (*api.LimitsOvercommit)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_LimitsOvercommit_To_api_LimitsOvercommit(a.(*LimitsOvercommit), b.(*api.LimitsOvercommit), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.LimitsOvercommit)(nil), (*LimitsOvercommit)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_LimitsOvercommit_To_v1alpha1_LimitsOvercommit(a.(*api.LimitsOvercommit), b.(*LimitsOvercommit), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*MemoryOverrun)(nil), (*api.MemoryOverrun)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_MemoryOverrun_To_api_MemoryOverrun(a.(*MemoryOverrun), b.(*api.MemoryOverrun), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.MemoryOverrun)(nil), (*MemoryOverrun)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_MemoryOverrun_To_v1alpha1_MemoryOverrun(a.(*api.MemoryOverrun), b.(*MemoryOverrun), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NamespaceRules)(nil), (*api.NamespaceRules)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_NamespaceRules_To_api_NamespaceRules(a.(*NamespaceRules), b.(*api.NamespaceRules), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.NamespaceRules)(nil), (*NamespaceRules)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_NamespaceRules_To_v1alpha1_NamespaceRules(a.(*api.NamespaceRules), b.(*NamespaceRules), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Namespaces)(nil), (*api.Namespaces)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_Namespaces_To_api_Namespaces(a.(*Namespaces), b.(*api.Namespaces), scope)
 	}); err != nil {
@@ -76,6 +223,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*NodeDeletionTrigger)(nil), (*api.NodeDeletionTrigger)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_NodeDeletionTrigger_To_api_NodeDeletionTrigger(a.(*NodeDeletionTrigger), b.(*api.NodeDeletionTrigger), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.NodeDeletionTrigger)(nil), (*NodeDeletionTrigger)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_NodeDeletionTrigger_To_v1alpha1_NodeDeletionTrigger(a.(*api.NodeDeletionTrigger), b.(*NodeDeletionTrigger), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NodeInterruption)(nil), (*api.NodeInterruption)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_NodeInterruption_To_api_NodeInterruption(a.(*NodeInterruption), b.(*api.NodeInterruption), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.NodeInterruption)(nil), (*NodeInterruption)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_NodeInterruption_To_v1alpha1_NodeInterruption(a.(*api.NodeInterruption), b.(*NodeInterruption), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NodeProblems)(nil), (*api.NodeProblems)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_NodeProblems_To_api_NodeProblems(a.(*NodeProblems), b.(*api.NodeProblems), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.NodeProblems)(nil), (*NodeProblems)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_NodeProblems_To_v1alpha1_NodeProblems(a.(*api.NodeProblems), b.(*NodeProblems), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*NodeResourceUtilizationThresholds)(nil), (*api.NodeResourceUtilizationThresholds)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_NodeResourceUtilizationThresholds_To_api_NodeResourceUtilizationThresholds(a.(*NodeResourceUtilizationThresholds), b.(*api.NodeResourceUtilizationThresholds), scope)
 	}); err != nil {
@@ -86,6 +263,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*NotReadyNodes)(nil), (*api.NotReadyNodes)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_NotReadyNodes_To_api_NotReadyNodes(a.(*NotReadyNodes), b.(*api.NotReadyNodes), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.NotReadyNodes)(nil), (*NotReadyNodes)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_NotReadyNodes_To_v1alpha1_NotReadyNodes(a.(*api.NotReadyNodes), b.(*NotReadyNodes), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PodDensity)(nil), (*api.PodDensity)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PodDensity_To_api_PodDensity(a.(*PodDensity), b.(*api.PodDensity), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.PodDensity)(nil), (*PodDensity)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_PodDensity_To_v1alpha1_PodDensity(a.(*api.PodDensity), b.(*PodDensity), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PodFilter)(nil), (*api.PodFilter)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PodFilter_To_api_PodFilter(a.(*PodFilter), b.(*api.PodFilter), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.PodFilter)(nil), (*PodFilter)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_PodFilter_To_v1alpha1_PodFilter(a.(*api.PodFilter), b.(*PodFilter), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*PodLifeTime)(nil), (*api.PodLifeTime)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_PodLifeTime_To_api_PodLifeTime(a.(*PodLifeTime), b.(*api.PodLifeTime), scope)
 	}); err != nil {
@@ -106,6 +313,56 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*PolicyReports)(nil), (*api.PolicyReports)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PolicyReports_To_api_PolicyReports(a.(*PolicyReports), b.(*api.PolicyReports), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.PolicyReports)(nil), (*PolicyReports)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_PolicyReports_To_v1alpha1_PolicyReports(a.(*api.PolicyReports), b.(*PolicyReports), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PriorityBand)(nil), (*api.PriorityBand)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PriorityBand_To_api_PriorityBand(a.(*PriorityBand), b.(*api.PriorityBand), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.PriorityBand)(nil), (*PriorityBand)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_PriorityBand_To_v1alpha1_PriorityBand(a.(*api.PriorityBand), b.(*PriorityBand), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PrometheusUtilization)(nil), (*api.PrometheusUtilization)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PrometheusUtilization_To_api_PrometheusUtilization(a.(*PrometheusUtilization), b.(*api.PrometheusUtilization), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.PrometheusUtilization)(nil), (*PrometheusUtilization)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_PrometheusUtilization_To_v1alpha1_PrometheusUtilization(a.(*api.PrometheusUtilization), b.(*PrometheusUtilization), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ProtectedPodCondition)(nil), (*api.ProtectedPodCondition)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ProtectedPodCondition_To_api_ProtectedPodCondition(a.(*ProtectedPodCondition), b.(*api.ProtectedPodCondition), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.ProtectedPodCondition)(nil), (*ProtectedPodCondition)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_ProtectedPodCondition_To_v1alpha1_ProtectedPodCondition(a.(*api.ProtectedPodCondition), b.(*ProtectedPodCondition), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RemoteWrite)(nil), (*api.RemoteWrite)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RemoteWrite_To_api_RemoteWrite(a.(*RemoteWrite), b.(*api.RemoteWrite), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.RemoteWrite)(nil), (*RemoteWrite)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_RemoteWrite_To_v1alpha1_RemoteWrite(a.(*api.RemoteWrite), b.(*RemoteWrite), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*RemoveDuplicates)(nil), (*api.RemoveDuplicates)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_RemoveDuplicates_To_api_RemoveDuplicates(a.(*RemoveDuplicates), b.(*api.RemoveDuplicates), scope)
 	}); err != nil {
@@ -116,6 +373,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*RestartChurn)(nil), (*api.RestartChurn)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RestartChurn_To_api_RestartChurn(a.(*RestartChurn), b.(*api.RestartChurn), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.RestartChurn)(nil), (*RestartChurn)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_RestartChurn_To_v1alpha1_RestartChurn(a.(*api.RestartChurn), b.(*RestartChurn), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*StrategyParameters)(nil), (*api.StrategyParameters)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_StrategyParameters_To_api_StrategyParameters(a.(*StrategyParameters), b.(*api.StrategyParameters), scope)
 	}); err != nil {
@@ -126,16 +393,162 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*StuckPods)(nil), (*api.StuckPods)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_StuckPods_To_api_StuckPods(a.(*StuckPods), b.(*api.StuckPods), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.StuckPods)(nil), (*StuckPods)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_StuckPods_To_v1alpha1_StuckPods(a.(*api.StuckPods), b.(*StuckPods), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*TerminatingPods)(nil), (*api.TerminatingPods)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_TerminatingPods_To_api_TerminatingPods(a.(*TerminatingPods), b.(*api.TerminatingPods), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.TerminatingPods)(nil), (*TerminatingPods)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_TerminatingPods_To_v1alpha1_TerminatingPods(a.(*api.TerminatingPods), b.(*TerminatingPods), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*UsageTrend)(nil), (*api.UsageTrend)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_UsageTrend_To_api_UsageTrend(a.(*UsageTrend), b.(*api.UsageTrend), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.UsageTrend)(nil), (*UsageTrend)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_UsageTrend_To_v1alpha1_UsageTrend(a.(*api.UsageTrend), b.(*UsageTrend), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*api.DeschedulerPolicy)(nil), (*DeschedulerPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_DeschedulerPolicy_To_v1alpha1_DeschedulerPolicy(a.(*api.DeschedulerPolicy), b.(*DeschedulerPolicy), scope)
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func autoConvert_v1alpha1_AntiColocation_To_api_AntiColocation(in *AntiColocation, out *api.AntiColocation, s conversion.Scope) error {
+	out.Pairs = *(*[]api.AntiColocationPair)(unsafe.Pointer(&in.Pairs))
+	return nil
+}
+
+// Convert_v1alpha1_AntiColocation_To_api_AntiColocation is an autogenerated conversion function.
+func Convert_v1alpha1_AntiColocation_To_api_AntiColocation(in *AntiColocation, out *api.AntiColocation, s conversion.Scope) error {
+	return autoConvert_v1alpha1_AntiColocation_To_api_AntiColocation(in, out, s)
+}
+
+func autoConvert_api_AntiColocation_To_v1alpha1_AntiColocation(in *api.AntiColocation, out *AntiColocation, s conversion.Scope) error {
+	out.Pairs = *(*[]AntiColocationPair)(unsafe.Pointer(&in.Pairs))
+	return nil
+}
+
+// Convert_api_AntiColocation_To_v1alpha1_AntiColocation is an autogenerated conversion function.
+func Convert_api_AntiColocation_To_v1alpha1_AntiColocation(in *api.AntiColocation, out *AntiColocation, s conversion.Scope) error {
+	return autoConvert_api_AntiColocation_To_v1alpha1_AntiColocation(in, out, s)
+}
+
+func autoConvert_v1alpha1_AntiColocationPair_To_api_AntiColocationPair(in *AntiColocationPair, out *api.AntiColocationPair, s conversion.Scope) error {
+	out.Keep = (*v1.LabelSelector)(unsafe.Pointer(in.Keep))
+	out.Evict = (*v1.LabelSelector)(unsafe.Pointer(in.Evict))
+	return nil
+}
+
+// Convert_v1alpha1_AntiColocationPair_To_api_AntiColocationPair is an autogenerated conversion function.
+func Convert_v1alpha1_AntiColocationPair_To_api_AntiColocationPair(in *AntiColocationPair, out *api.AntiColocationPair, s conversion.Scope) error {
+	return autoConvert_v1alpha1_AntiColocationPair_To_api_AntiColocationPair(in, out, s)
+}
+
+func autoConvert_api_AntiColocationPair_To_v1alpha1_AntiColocationPair(in *api.AntiColocationPair, out *AntiColocationPair, s conversion.Scope) error {
+	out.Keep = (*v1.LabelSelector)(unsafe.Pointer(in.Keep))
+	out.Evict = (*v1.LabelSelector)(unsafe.Pointer(in.Evict))
+	return nil
+}
+
+// Convert_api_AntiColocationPair_To_v1alpha1_AntiColocationPair is an autogenerated conversion function.
+func Convert_api_AntiColocationPair_To_v1alpha1_AntiColocationPair(in *api.AntiColocationPair, out *AntiColocationPair, s conversion.Scope) error {
+	return autoConvert_api_AntiColocationPair_To_v1alpha1_AntiColocationPair(in, out, s)
+}
+
+func autoConvert_v1alpha1_CooldownPersistence_To_api_CooldownPersistence(in *CooldownPersistence, out *api.CooldownPersistence, s conversion.Scope) error {
+	out.Kind = in.Kind
+	out.Namespace = in.Namespace
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_v1alpha1_CooldownPersistence_To_api_CooldownPersistence is an autogenerated conversion function.
+func Convert_v1alpha1_CooldownPersistence_To_api_CooldownPersistence(in *CooldownPersistence, out *api.CooldownPersistence, s conversion.Scope) error {
+	return autoConvert_v1alpha1_CooldownPersistence_To_api_CooldownPersistence(in, out, s)
+}
+
+func autoConvert_api_CooldownPersistence_To_v1alpha1_CooldownPersistence(in *api.CooldownPersistence, out *CooldownPersistence, s conversion.Scope) error {
+	out.Kind = in.Kind
+	out.Namespace = in.Namespace
+	out.Name = in.Name
 	return nil
 }
 
+// Convert_api_CooldownPersistence_To_v1alpha1_CooldownPersistence is an autogenerated conversion function.
+func Convert_api_CooldownPersistence_To_v1alpha1_CooldownPersistence(in *api.CooldownPersistence, out *CooldownPersistence, s conversion.Scope) error {
+	return autoConvert_api_CooldownPersistence_To_v1alpha1_CooldownPersistence(in, out, s)
+}
+
+func autoConvert_v1alpha1_CycleAbort_To_api_CycleAbort(in *CycleAbort, out *api.CycleAbort, s conversion.Scope) error {
+	out.MaxErrorRatio = in.MaxErrorRatio
+	out.MinEvictionAttempts = (*uint)(unsafe.Pointer(in.MinEvictionAttempts))
+	out.MaxBackoffSeconds = (*uint)(unsafe.Pointer(in.MaxBackoffSeconds))
+	return nil
+}
+
+// Convert_v1alpha1_CycleAbort_To_api_CycleAbort is an autogenerated conversion function.
+func Convert_v1alpha1_CycleAbort_To_api_CycleAbort(in *CycleAbort, out *api.CycleAbort, s conversion.Scope) error {
+	return autoConvert_v1alpha1_CycleAbort_To_api_CycleAbort(in, out, s)
+}
+
+func autoConvert_api_CycleAbort_To_v1alpha1_CycleAbort(in *api.CycleAbort, out *CycleAbort, s conversion.Scope) error {
+	out.MaxErrorRatio = in.MaxErrorRatio
+	out.MinEvictionAttempts = (*uint)(unsafe.Pointer(in.MinEvictionAttempts))
+	out.MaxBackoffSeconds = (*uint)(unsafe.Pointer(in.MaxBackoffSeconds))
+	return nil
+}
+
+// Convert_api_CycleAbort_To_v1alpha1_CycleAbort is an autogenerated conversion function.
+func Convert_api_CycleAbort_To_v1alpha1_CycleAbort(in *api.CycleAbort, out *CycleAbort, s conversion.Scope) error {
+	return autoConvert_api_CycleAbort_To_v1alpha1_CycleAbort(in, out, s)
+}
+
 func autoConvert_v1alpha1_DeschedulerPolicy_To_api_DeschedulerPolicy(in *DeschedulerPolicy, out *api.DeschedulerPolicy, s conversion.Scope) error {
 	out.Strategies = *(*api.StrategyList)(unsafe.Pointer(&in.Strategies))
 	out.NodeSelector = (*string)(unsafe.Pointer(in.NodeSelector))
 	out.EvictLocalStoragePods = (*bool)(unsafe.Pointer(in.EvictLocalStoragePods))
+	out.EvictableEmptyDirSizeLimit = (*resource.Quantity)(unsafe.Pointer(in.EvictableEmptyDirSizeLimit))
 	out.EvictSystemCriticalPods = (*bool)(unsafe.Pointer(in.EvictSystemCriticalPods))
 	out.IgnorePVCPods = (*bool)(unsafe.Pointer(in.IgnorePVCPods))
 	out.MaxNoOfPodsToEvictPerNode = (*int)(unsafe.Pointer(in.MaxNoOfPodsToEvictPerNode))
+	out.MaxPerOwnerPerNode = (*int)(unsafe.Pointer(in.MaxPerOwnerPerNode))
+	out.HealthGates = (*api.HealthGates)(unsafe.Pointer(in.HealthGates))
+	out.ReschedulingHints = (*bool)(unsafe.Pointer(in.ReschedulingHints))
+	out.EvictionHistory = (*api.EvictionHistory)(unsafe.Pointer(in.EvictionHistory))
+	out.PolicyReports = (*api.PolicyReports)(unsafe.Pointer(in.PolicyReports))
+	out.RemoteWrite = (*api.RemoteWrite)(unsafe.Pointer(in.RemoteWrite))
+	out.ReplacementReadinessTimeoutSeconds = (*uint)(unsafe.Pointer(in.ReplacementReadinessTimeoutSeconds))
+	out.VolumeDetachTimeoutSeconds = (*uint)(unsafe.Pointer(in.VolumeDetachTimeoutSeconds))
+	out.PodEvictionCooldownSeconds = (*uint)(unsafe.Pointer(in.PodEvictionCooldownSeconds))
+	out.CooldownPersistence = (*api.CooldownPersistence)(unsafe.Pointer(in.CooldownPersistence))
+	out.ProtectedDeployments = *(*[]string)(unsafe.Pointer(&in.ProtectedDeployments))
+	out.InteractiveSessionLookbackSeconds = (*uint)(unsafe.Pointer(in.InteractiveSessionLookbackSeconds))
+	out.ProtectedPodConditions = *(*[]api.ProtectedPodCondition)(unsafe.Pointer(&in.ProtectedPodConditions))
+	out.NodeDeletionTrigger = (*api.NodeDeletionTrigger)(unsafe.Pointer(in.NodeDeletionTrigger))
+	out.EvictionFilter = (*api.PodFilter)(unsafe.Pointer(in.EvictionFilter))
+	out.SkipOwnersRollingOut = (*bool)(unsafe.Pointer(in.SkipOwnersRollingOut))
+	out.NamespaceRules = (*api.NamespaceRules)(unsafe.Pointer(in.NamespaceRules))
+	out.EvictedPodAnnotations = (*api.EvictedPodAnnotations)(unsafe.Pointer(in.EvictedPodAnnotations))
+	out.CycleAbort = (*api.CycleAbort)(unsafe.Pointer(in.CycleAbort))
+	out.EvictionLimits = (*api.EvictionLimits)(unsafe.Pointer(in.EvictionLimits))
 	return nil
 }
 
@@ -148,20 +561,41 @@ func autoConvert_api_DeschedulerPolicy_To_v1alpha1_DeschedulerPolicy(in *api.Des
 	out.Strategies = *(*StrategyList)(unsafe.Pointer(&in.Strategies))
 	out.NodeSelector = (*string)(unsafe.Pointer(in.NodeSelector))
 	out.EvictLocalStoragePods = (*bool)(unsafe.Pointer(in.EvictLocalStoragePods))
+	out.EvictableEmptyDirSizeLimit = (*resource.Quantity)(unsafe.Pointer(in.EvictableEmptyDirSizeLimit))
 	out.EvictSystemCriticalPods = (*bool)(unsafe.Pointer(in.EvictSystemCriticalPods))
 	out.IgnorePVCPods = (*bool)(unsafe.Pointer(in.IgnorePVCPods))
 	out.MaxNoOfPodsToEvictPerNode = (*int)(unsafe.Pointer(in.MaxNoOfPodsToEvictPerNode))
+	out.MaxPerOwnerPerNode = (*int)(unsafe.Pointer(in.MaxPerOwnerPerNode))
+	out.HealthGates = (*HealthGates)(unsafe.Pointer(in.HealthGates))
+	out.ReschedulingHints = (*bool)(unsafe.Pointer(in.ReschedulingHints))
+	out.EvictionHistory = (*EvictionHistory)(unsafe.Pointer(in.EvictionHistory))
+	out.PolicyReports = (*PolicyReports)(unsafe.Pointer(in.PolicyReports))
+	out.RemoteWrite = (*RemoteWrite)(unsafe.Pointer(in.RemoteWrite))
+	out.ReplacementReadinessTimeoutSeconds = (*uint)(unsafe.Pointer(in.ReplacementReadinessTimeoutSeconds))
+	out.VolumeDetachTimeoutSeconds = (*uint)(unsafe.Pointer(in.VolumeDetachTimeoutSeconds))
+	out.PodEvictionCooldownSeconds = (*uint)(unsafe.Pointer(in.PodEvictionCooldownSeconds))
+	out.CooldownPersistence = (*CooldownPersistence)(unsafe.Pointer(in.CooldownPersistence))
+	out.ProtectedDeployments = *(*[]string)(unsafe.Pointer(&in.ProtectedDeployments))
+	out.InteractiveSessionLookbackSeconds = (*uint)(unsafe.Pointer(in.InteractiveSessionLookbackSeconds))
+	out.ProtectedPodConditions = *(*[]ProtectedPodCondition)(unsafe.Pointer(&in.ProtectedPodConditions))
+	out.NodeDeletionTrigger = (*NodeDeletionTrigger)(unsafe.Pointer(in.NodeDeletionTrigger))
+	out.EvictionFilter = (*PodFilter)(unsafe.Pointer(in.EvictionFilter))
+	out.SkipOwnersRollingOut = (*bool)(unsafe.Pointer(in.SkipOwnersRollingOut))
+	out.NamespaceRules = (*NamespaceRules)(unsafe.Pointer(in.NamespaceRules))
+	out.EvictedPodAnnotations = (*EvictedPodAnnotations)(unsafe.Pointer(in.EvictedPodAnnotations))
+	// WARNING: in.Profiles requires manual conversion: does not exist in peer-type
+	out.CycleAbort = (*CycleAbort)(unsafe.Pointer(in.CycleAbort))
+	out.EvictionLimits = (*EvictionLimits)(unsafe.Pointer(in.EvictionLimits))
 	return nil
 }
 
-// Convert_api_DeschedulerPolicy_To_v1alpha1_DeschedulerPolicy is an autogenerated conversion function.
-func Convert_api_DeschedulerPolicy_To_v1alpha1_DeschedulerPolicy(in *api.DeschedulerPolicy, out *DeschedulerPolicy, s conversion.Scope) error {
-	return autoConvert_api_DeschedulerPolicy_To_v1alpha1_DeschedulerPolicy(in, out, s)
-}
-
 func autoConvert_v1alpha1_DeschedulerStrategy_To_api_DeschedulerStrategy(in *DeschedulerStrategy, out *api.DeschedulerStrategy, s conversion.Scope) error {
 	out.Enabled = in.Enabled
 	out.Weight = in.Weight
+	out.DryRun = in.DryRun
+	out.LogVerbosity = (*int32)(unsafe.Pointer(in.LogVerbosity))
+	out.TimeoutSeconds = (*uint)(unsafe.Pointer(in.TimeoutSeconds))
+	out.EvictionLimits = (*api.EvictionLimits)(unsafe.Pointer(in.EvictionLimits))
 	out.Params = (*api.StrategyParameters)(unsafe.Pointer(in.Params))
 	return nil
 }
@@ -174,6 +608,10 @@ func Convert_v1alpha1_DeschedulerStrategy_To_api_DeschedulerStrategy(in *Desched
 func autoConvert_api_DeschedulerStrategy_To_v1alpha1_DeschedulerStrategy(in *api.DeschedulerStrategy, out *DeschedulerStrategy, s conversion.Scope) error {
 	out.Enabled = in.Enabled
 	out.Weight = in.Weight
+	out.DryRun = in.DryRun
+	out.LogVerbosity = (*int32)(unsafe.Pointer(in.LogVerbosity))
+	out.TimeoutSeconds = (*uint)(unsafe.Pointer(in.TimeoutSeconds))
+	out.EvictionLimits = (*EvictionLimits)(unsafe.Pointer(in.EvictionLimits))
 	out.Params = (*StrategyParameters)(unsafe.Pointer(in.Params))
 	return nil
 }
@@ -183,6 +621,76 @@ func Convert_api_DeschedulerStrategy_To_v1alpha1_DeschedulerStrategy(in *api.Des
 	return autoConvert_api_DeschedulerStrategy_To_v1alpha1_DeschedulerStrategy(in, out, s)
 }
 
+func autoConvert_v1alpha1_EvictedPodAnnotations_To_api_EvictedPodAnnotations(in *EvictedPodAnnotations, out *api.EvictedPodAnnotations, s conversion.Scope) error {
+	out.Labels = in.Labels
+	return nil
+}
+
+// Convert_v1alpha1_EvictedPodAnnotations_To_api_EvictedPodAnnotations is an autogenerated conversion function.
+func Convert_v1alpha1_EvictedPodAnnotations_To_api_EvictedPodAnnotations(in *EvictedPodAnnotations, out *api.EvictedPodAnnotations, s conversion.Scope) error {
+	return autoConvert_v1alpha1_EvictedPodAnnotations_To_api_EvictedPodAnnotations(in, out, s)
+}
+
+func autoConvert_api_EvictedPodAnnotations_To_v1alpha1_EvictedPodAnnotations(in *api.EvictedPodAnnotations, out *EvictedPodAnnotations, s conversion.Scope) error {
+	out.Labels = in.Labels
+	return nil
+}
+
+// Convert_api_EvictedPodAnnotations_To_v1alpha1_EvictedPodAnnotations is an autogenerated conversion function.
+func Convert_api_EvictedPodAnnotations_To_v1alpha1_EvictedPodAnnotations(in *api.EvictedPodAnnotations, out *EvictedPodAnnotations, s conversion.Scope) error {
+	return autoConvert_api_EvictedPodAnnotations_To_v1alpha1_EvictedPodAnnotations(in, out, s)
+}
+
+func autoConvert_v1alpha1_EvictionHistory_To_api_EvictionHistory(in *EvictionHistory, out *api.EvictionHistory, s conversion.Scope) error {
+	out.Namespace = in.Namespace
+	out.Name = in.Name
+	out.MaxCycles = in.MaxCycles
+	return nil
+}
+
+// Convert_v1alpha1_EvictionHistory_To_api_EvictionHistory is an autogenerated conversion function.
+func Convert_v1alpha1_EvictionHistory_To_api_EvictionHistory(in *EvictionHistory, out *api.EvictionHistory, s conversion.Scope) error {
+	return autoConvert_v1alpha1_EvictionHistory_To_api_EvictionHistory(in, out, s)
+}
+
+func autoConvert_api_EvictionHistory_To_v1alpha1_EvictionHistory(in *api.EvictionHistory, out *EvictionHistory, s conversion.Scope) error {
+	out.Namespace = in.Namespace
+	out.Name = in.Name
+	out.MaxCycles = in.MaxCycles
+	return nil
+}
+
+// Convert_api_EvictionHistory_To_v1alpha1_EvictionHistory is an autogenerated conversion function.
+func Convert_api_EvictionHistory_To_v1alpha1_EvictionHistory(in *api.EvictionHistory, out *EvictionHistory, s conversion.Scope) error {
+	return autoConvert_api_EvictionHistory_To_v1alpha1_EvictionHistory(in, out, s)
+}
+
+func autoConvert_v1alpha1_EvictionLimits_To_api_EvictionLimits(in *EvictionLimits, out *api.EvictionLimits, s conversion.Scope) error {
+	out.MaxNoOfPodsToEvictPerCycle = (*uint)(unsafe.Pointer(in.MaxNoOfPodsToEvictPerCycle))
+	out.MaxNoOfPodsToEvictPerNamespace = (*uint)(unsafe.Pointer(in.MaxNoOfPodsToEvictPerNamespace))
+	out.EvictionsPerSecond = (*float64)(unsafe.Pointer(in.EvictionsPerSecond))
+	out.EvictionBurst = (*uint)(unsafe.Pointer(in.EvictionBurst))
+	return nil
+}
+
+// Convert_v1alpha1_EvictionLimits_To_api_EvictionLimits is an autogenerated conversion function.
+func Convert_v1alpha1_EvictionLimits_To_api_EvictionLimits(in *EvictionLimits, out *api.EvictionLimits, s conversion.Scope) error {
+	return autoConvert_v1alpha1_EvictionLimits_To_api_EvictionLimits(in, out, s)
+}
+
+func autoConvert_api_EvictionLimits_To_v1alpha1_EvictionLimits(in *api.EvictionLimits, out *EvictionLimits, s conversion.Scope) error {
+	out.MaxNoOfPodsToEvictPerCycle = (*uint)(unsafe.Pointer(in.MaxNoOfPodsToEvictPerCycle))
+	out.MaxNoOfPodsToEvictPerNamespace = (*uint)(unsafe.Pointer(in.MaxNoOfPodsToEvictPerNamespace))
+	out.EvictionsPerSecond = (*float64)(unsafe.Pointer(in.EvictionsPerSecond))
+	out.EvictionBurst = (*uint)(unsafe.Pointer(in.EvictionBurst))
+	return nil
+}
+
+// Convert_api_EvictionLimits_To_v1alpha1_EvictionLimits is an autogenerated conversion function.
+func Convert_api_EvictionLimits_To_v1alpha1_EvictionLimits(in *api.EvictionLimits, out *EvictionLimits, s conversion.Scope) error {
+	return autoConvert_api_EvictionLimits_To_v1alpha1_EvictionLimits(in, out, s)
+}
+
 func autoConvert_v1alpha1_FailedPods_To_api_FailedPods(in *FailedPods, out *api.FailedPods, s conversion.Scope) error {
 	out.ExcludeOwnerKinds = *(*[]string)(unsafe.Pointer(&in.ExcludeOwnerKinds))
 	out.MinPodLifetimeSeconds = (*uint)(unsafe.Pointer(in.MinPodLifetimeSeconds))
@@ -209,6 +717,196 @@ func Convert_api_FailedPods_To_v1alpha1_FailedPods(in *api.FailedPods, out *Fail
 	return autoConvert_api_FailedPods_To_v1alpha1_FailedPods(in, out, s)
 }
 
+func autoConvert_v1alpha1_FinishedJobPods_To_api_FinishedJobPods(in *FinishedJobPods, out *api.FinishedJobPods, s conversion.Scope) error {
+	out.TTLSecondsAfterFinished = (*uint)(unsafe.Pointer(in.TTLSecondsAfterFinished))
+	out.ExcludeOwnerKinds = *(*[]string)(unsafe.Pointer(&in.ExcludeOwnerKinds))
+	return nil
+}
+
+// Convert_v1alpha1_FinishedJobPods_To_api_FinishedJobPods is an autogenerated conversion function.
+func Convert_v1alpha1_FinishedJobPods_To_api_FinishedJobPods(in *FinishedJobPods, out *api.FinishedJobPods, s conversion.Scope) error {
+	return autoConvert_v1alpha1_FinishedJobPods_To_api_FinishedJobPods(in, out, s)
+}
+
+func autoConvert_api_FinishedJobPods_To_v1alpha1_FinishedJobPods(in *api.FinishedJobPods, out *FinishedJobPods, s conversion.Scope) error {
+	out.TTLSecondsAfterFinished = (*uint)(unsafe.Pointer(in.TTLSecondsAfterFinished))
+	out.ExcludeOwnerKinds = *(*[]string)(unsafe.Pointer(&in.ExcludeOwnerKinds))
+	return nil
+}
+
+// Convert_api_FinishedJobPods_To_v1alpha1_FinishedJobPods is an autogenerated conversion function.
+func Convert_api_FinishedJobPods_To_v1alpha1_FinishedJobPods(in *api.FinishedJobPods, out *FinishedJobPods, s conversion.Scope) error {
+	return autoConvert_api_FinishedJobPods_To_v1alpha1_FinishedJobPods(in, out, s)
+}
+
+func autoConvert_v1alpha1_GPUModelPreference_To_api_GPUModelPreference(in *GPUModelPreference, out *api.GPUModelPreference, s conversion.Scope) error {
+	out.ModelLabel = in.ModelLabel
+	out.ResourceName = in.ResourceName
+	out.ModelPriorities = *(*[]api.GPUModelPriority)(unsafe.Pointer(&in.ModelPriorities))
+	return nil
+}
+
+// Convert_v1alpha1_GPUModelPreference_To_api_GPUModelPreference is an autogenerated conversion function.
+func Convert_v1alpha1_GPUModelPreference_To_api_GPUModelPreference(in *GPUModelPreference, out *api.GPUModelPreference, s conversion.Scope) error {
+	return autoConvert_v1alpha1_GPUModelPreference_To_api_GPUModelPreference(in, out, s)
+}
+
+func autoConvert_api_GPUModelPreference_To_v1alpha1_GPUModelPreference(in *api.GPUModelPreference, out *GPUModelPreference, s conversion.Scope) error {
+	out.ModelLabel = in.ModelLabel
+	out.ResourceName = in.ResourceName
+	out.ModelPriorities = *(*[]GPUModelPriority)(unsafe.Pointer(&in.ModelPriorities))
+	return nil
+}
+
+// Convert_api_GPUModelPreference_To_v1alpha1_GPUModelPreference is an autogenerated conversion function.
+func Convert_api_GPUModelPreference_To_v1alpha1_GPUModelPreference(in *api.GPUModelPreference, out *GPUModelPreference, s conversion.Scope) error {
+	return autoConvert_api_GPUModelPreference_To_v1alpha1_GPUModelPreference(in, out, s)
+}
+
+func autoConvert_v1alpha1_GPUModelPriority_To_api_GPUModelPriority(in *GPUModelPriority, out *api.GPUModelPriority, s conversion.Scope) error {
+	out.PodSelector = (*v1.LabelSelector)(unsafe.Pointer(in.PodSelector))
+	out.Models = *(*[]string)(unsafe.Pointer(&in.Models))
+	return nil
+}
+
+// Convert_v1alpha1_GPUModelPriority_To_api_GPUModelPriority is an autogenerated conversion function.
+func Convert_v1alpha1_GPUModelPriority_To_api_GPUModelPriority(in *GPUModelPriority, out *api.GPUModelPriority, s conversion.Scope) error {
+	return autoConvert_v1alpha1_GPUModelPriority_To_api_GPUModelPriority(in, out, s)
+}
+
+func autoConvert_api_GPUModelPriority_To_v1alpha1_GPUModelPriority(in *api.GPUModelPriority, out *GPUModelPriority, s conversion.Scope) error {
+	out.PodSelector = (*v1.LabelSelector)(unsafe.Pointer(in.PodSelector))
+	out.Models = *(*[]string)(unsafe.Pointer(&in.Models))
+	return nil
+}
+
+// Convert_api_GPUModelPriority_To_v1alpha1_GPUModelPriority is an autogenerated conversion function.
+func Convert_api_GPUModelPriority_To_v1alpha1_GPUModelPriority(in *api.GPUModelPriority, out *GPUModelPriority, s conversion.Scope) error {
+	return autoConvert_api_GPUModelPriority_To_v1alpha1_GPUModelPriority(in, out, s)
+}
+
+func autoConvert_v1alpha1_HealthGates_To_api_HealthGates(in *HealthGates, out *api.HealthGates, s conversion.Scope) error {
+	out.MaxNotReadyNodesPercentage = (*api.Percentage)(unsafe.Pointer(in.MaxNotReadyNodesPercentage))
+	out.MaxPendingPods = (*int)(unsafe.Pointer(in.MaxPendingPods))
+	out.MaxKubeletRestarts = (*int)(unsafe.Pointer(in.MaxKubeletRestarts))
+	out.KubeletRestartsWindowSeconds = (*uint)(unsafe.Pointer(in.KubeletRestartsWindowSeconds))
+	out.SchedulerLease = in.SchedulerLease
+	return nil
+}
+
+// Convert_v1alpha1_HealthGates_To_api_HealthGates is an autogenerated conversion function.
+func Convert_v1alpha1_HealthGates_To_api_HealthGates(in *HealthGates, out *api.HealthGates, s conversion.Scope) error {
+	return autoConvert_v1alpha1_HealthGates_To_api_HealthGates(in, out, s)
+}
+
+func autoConvert_api_HealthGates_To_v1alpha1_HealthGates(in *api.HealthGates, out *HealthGates, s conversion.Scope) error {
+	out.MaxNotReadyNodesPercentage = (*Percentage)(unsafe.Pointer(in.MaxNotReadyNodesPercentage))
+	out.MaxPendingPods = (*int)(unsafe.Pointer(in.MaxPendingPods))
+	out.MaxKubeletRestarts = (*int)(unsafe.Pointer(in.MaxKubeletRestarts))
+	out.KubeletRestartsWindowSeconds = (*uint)(unsafe.Pointer(in.KubeletRestartsWindowSeconds))
+	out.SchedulerLease = in.SchedulerLease
+	return nil
+}
+
+// Convert_api_HealthGates_To_v1alpha1_HealthGates is an autogenerated conversion function.
+func Convert_api_HealthGates_To_v1alpha1_HealthGates(in *api.HealthGates, out *HealthGates, s conversion.Scope) error {
+	return autoConvert_api_HealthGates_To_v1alpha1_HealthGates(in, out, s)
+}
+
+func autoConvert_v1alpha1_ImageLocality_To_api_ImageLocality(in *ImageLocality, out *api.ImageLocality, s conversion.Scope) error {
+	out.MinImageSizeMB = (*uint)(unsafe.Pointer(in.MinImageSizeMB))
+	return nil
+}
+
+// Convert_v1alpha1_ImageLocality_To_api_ImageLocality is an autogenerated conversion function.
+func Convert_v1alpha1_ImageLocality_To_api_ImageLocality(in *ImageLocality, out *api.ImageLocality, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ImageLocality_To_api_ImageLocality(in, out, s)
+}
+
+func autoConvert_api_ImageLocality_To_v1alpha1_ImageLocality(in *api.ImageLocality, out *ImageLocality, s conversion.Scope) error {
+	out.MinImageSizeMB = (*uint)(unsafe.Pointer(in.MinImageSizeMB))
+	return nil
+}
+
+// Convert_api_ImageLocality_To_v1alpha1_ImageLocality is an autogenerated conversion function.
+func Convert_api_ImageLocality_To_v1alpha1_ImageLocality(in *api.ImageLocality, out *ImageLocality, s conversion.Scope) error {
+	return autoConvert_api_ImageLocality_To_v1alpha1_ImageLocality(in, out, s)
+}
+
+func autoConvert_v1alpha1_LimitsOvercommit_To_api_LimitsOvercommit(in *LimitsOvercommit, out *api.LimitsOvercommit, s conversion.Scope) error {
+	out.Thresholds = *(*api.ResourceThresholds)(unsafe.Pointer(&in.Thresholds))
+	return nil
+}
+
+// Convert_v1alpha1_LimitsOvercommit_To_api_LimitsOvercommit is an autogenerated conversion function.
+func Convert_v1alpha1_LimitsOvercommit_To_api_LimitsOvercommit(in *LimitsOvercommit, out *api.LimitsOvercommit, s conversion.Scope) error {
+	return autoConvert_v1alpha1_LimitsOvercommit_To_api_LimitsOvercommit(in, out, s)
+}
+
+func autoConvert_api_LimitsOvercommit_To_v1alpha1_LimitsOvercommit(in *api.LimitsOvercommit, out *LimitsOvercommit, s conversion.Scope) error {
+	out.Thresholds = *(*ResourceThresholds)(unsafe.Pointer(&in.Thresholds))
+	return nil
+}
+
+// Convert_api_LimitsOvercommit_To_v1alpha1_LimitsOvercommit is an autogenerated conversion function.
+func Convert_api_LimitsOvercommit_To_v1alpha1_LimitsOvercommit(in *api.LimitsOvercommit, out *LimitsOvercommit, s conversion.Scope) error {
+	return autoConvert_api_LimitsOvercommit_To_v1alpha1_LimitsOvercommit(in, out, s)
+}
+
+func autoConvert_v1alpha1_MemoryOverrun_To_api_MemoryOverrun(in *MemoryOverrun, out *api.MemoryOverrun, s conversion.Scope) error {
+	out.ExcessPercentage = api.Percentage(in.ExcessPercentage)
+	out.DurationSeconds = in.DurationSeconds
+	out.NodeUsageThreshold = api.Percentage(in.NodeUsageThreshold)
+	out.MaxPodsToEvictPerNode = in.MaxPodsToEvictPerNode
+	return nil
+}
+
+// Convert_v1alpha1_MemoryOverrun_To_api_MemoryOverrun is an autogenerated conversion function.
+func Convert_v1alpha1_MemoryOverrun_To_api_MemoryOverrun(in *MemoryOverrun, out *api.MemoryOverrun, s conversion.Scope) error {
+	return autoConvert_v1alpha1_MemoryOverrun_To_api_MemoryOverrun(in, out, s)
+}
+
+func autoConvert_api_MemoryOverrun_To_v1alpha1_MemoryOverrun(in *api.MemoryOverrun, out *MemoryOverrun, s conversion.Scope) error {
+	out.ExcessPercentage = Percentage(in.ExcessPercentage)
+	out.DurationSeconds = in.DurationSeconds
+	out.NodeUsageThreshold = Percentage(in.NodeUsageThreshold)
+	out.MaxPodsToEvictPerNode = in.MaxPodsToEvictPerNode
+	return nil
+}
+
+// Convert_api_MemoryOverrun_To_v1alpha1_MemoryOverrun is an autogenerated conversion function.
+func Convert_api_MemoryOverrun_To_v1alpha1_MemoryOverrun(in *api.MemoryOverrun, out *MemoryOverrun, s conversion.Scope) error {
+	return autoConvert_api_MemoryOverrun_To_v1alpha1_MemoryOverrun(in, out, s)
+}
+
+func autoConvert_v1alpha1_NamespaceRules_To_api_NamespaceRules(in *NamespaceRules, out *api.NamespaceRules, s conversion.Scope) error {
+	out.AllowedStrategies = *(*[]api.StrategyName)(unsafe.Pointer(&in.AllowedStrategies))
+	out.Namespaces = (*api.Namespaces)(unsafe.Pointer(in.Namespaces))
+	out.NamespaceSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NamespaceSelector))
+	out.MinPodLifeTimeSeconds = (*uint)(unsafe.Pointer(in.MinPodLifeTimeSeconds))
+	out.MinPodRestartThreshold = (*int32)(unsafe.Pointer(in.MinPodRestartThreshold))
+	return nil
+}
+
+// Convert_v1alpha1_NamespaceRules_To_api_NamespaceRules is an autogenerated conversion function.
+func Convert_v1alpha1_NamespaceRules_To_api_NamespaceRules(in *NamespaceRules, out *api.NamespaceRules, s conversion.Scope) error {
+	return autoConvert_v1alpha1_NamespaceRules_To_api_NamespaceRules(in, out, s)
+}
+
+func autoConvert_api_NamespaceRules_To_v1alpha1_NamespaceRules(in *api.NamespaceRules, out *NamespaceRules, s conversion.Scope) error {
+	out.AllowedStrategies = *(*[]StrategyName)(unsafe.Pointer(&in.AllowedStrategies))
+	out.Namespaces = (*Namespaces)(unsafe.Pointer(in.Namespaces))
+	out.NamespaceSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NamespaceSelector))
+	out.MinPodLifeTimeSeconds = (*uint)(unsafe.Pointer(in.MinPodLifeTimeSeconds))
+	out.MinPodRestartThreshold = (*int32)(unsafe.Pointer(in.MinPodRestartThreshold))
+	return nil
+}
+
+// Convert_api_NamespaceRules_To_v1alpha1_NamespaceRules is an autogenerated conversion function.
+func Convert_api_NamespaceRules_To_v1alpha1_NamespaceRules(in *api.NamespaceRules, out *NamespaceRules, s conversion.Scope) error {
+	return autoConvert_api_NamespaceRules_To_v1alpha1_NamespaceRules(in, out, s)
+}
+
 func autoConvert_v1alpha1_Namespaces_To_api_Namespaces(in *Namespaces, out *api.Namespaces, s conversion.Scope) error {
 	out.Include = *(*[]string)(unsafe.Pointer(&in.Include))
 	out.Exclude = *(*[]string)(unsafe.Pointer(&in.Exclude))
@@ -231,10 +929,98 @@ func Convert_api_Namespaces_To_v1alpha1_Namespaces(in *api.Namespaces, out *Name
 	return autoConvert_api_Namespaces_To_v1alpha1_Namespaces(in, out, s)
 }
 
+func autoConvert_v1alpha1_NodeDeletionTrigger_To_api_NodeDeletionTrigger(in *NodeDeletionTrigger, out *api.NodeDeletionTrigger, s conversion.Scope) error {
+	out.CapacityPercentage = api.Percentage(in.CapacityPercentage)
+	return nil
+}
+
+// Convert_v1alpha1_NodeDeletionTrigger_To_api_NodeDeletionTrigger is an autogenerated conversion function.
+func Convert_v1alpha1_NodeDeletionTrigger_To_api_NodeDeletionTrigger(in *NodeDeletionTrigger, out *api.NodeDeletionTrigger, s conversion.Scope) error {
+	return autoConvert_v1alpha1_NodeDeletionTrigger_To_api_NodeDeletionTrigger(in, out, s)
+}
+
+func autoConvert_api_NodeDeletionTrigger_To_v1alpha1_NodeDeletionTrigger(in *api.NodeDeletionTrigger, out *NodeDeletionTrigger, s conversion.Scope) error {
+	out.CapacityPercentage = Percentage(in.CapacityPercentage)
+	return nil
+}
+
+// Convert_api_NodeDeletionTrigger_To_v1alpha1_NodeDeletionTrigger is an autogenerated conversion function.
+func Convert_api_NodeDeletionTrigger_To_v1alpha1_NodeDeletionTrigger(in *api.NodeDeletionTrigger, out *NodeDeletionTrigger, s conversion.Scope) error {
+	return autoConvert_api_NodeDeletionTrigger_To_v1alpha1_NodeDeletionTrigger(in, out, s)
+}
+
+func autoConvert_v1alpha1_NodeInterruption_To_api_NodeInterruption(in *NodeInterruption, out *api.NodeInterruption, s conversion.Scope) error {
+	out.Taints = *(*[]string)(unsafe.Pointer(&in.Taints))
+	out.Labels = *(*[]string)(unsafe.Pointer(&in.Labels))
+	return nil
+}
+
+// Convert_v1alpha1_NodeInterruption_To_api_NodeInterruption is an autogenerated conversion function.
+func Convert_v1alpha1_NodeInterruption_To_api_NodeInterruption(in *NodeInterruption, out *api.NodeInterruption, s conversion.Scope) error {
+	return autoConvert_v1alpha1_NodeInterruption_To_api_NodeInterruption(in, out, s)
+}
+
+func autoConvert_api_NodeInterruption_To_v1alpha1_NodeInterruption(in *api.NodeInterruption, out *NodeInterruption, s conversion.Scope) error {
+	out.Taints = *(*[]string)(unsafe.Pointer(&in.Taints))
+	out.Labels = *(*[]string)(unsafe.Pointer(&in.Labels))
+	return nil
+}
+
+// Convert_api_NodeInterruption_To_v1alpha1_NodeInterruption is an autogenerated conversion function.
+func Convert_api_NodeInterruption_To_v1alpha1_NodeInterruption(in *api.NodeInterruption, out *NodeInterruption, s conversion.Scope) error {
+	return autoConvert_api_NodeInterruption_To_v1alpha1_NodeInterruption(in, out, s)
+}
+
+func autoConvert_v1alpha1_NodeProblems_To_api_NodeProblems(in *NodeProblems, out *api.NodeProblems, s conversion.Scope) error {
+	out.Conditions = *(*[]string)(unsafe.Pointer(&in.Conditions))
+	out.MaxPodsToEvictPerCycle = in.MaxPodsToEvictPerCycle
+	return nil
+}
+
+// Convert_v1alpha1_NodeProblems_To_api_NodeProblems is an autogenerated conversion function.
+func Convert_v1alpha1_NodeProblems_To_api_NodeProblems(in *NodeProblems, out *api.NodeProblems, s conversion.Scope) error {
+	return autoConvert_v1alpha1_NodeProblems_To_api_NodeProblems(in, out, s)
+}
+
+func autoConvert_api_NodeProblems_To_v1alpha1_NodeProblems(in *api.NodeProblems, out *NodeProblems, s conversion.Scope) error {
+	out.Conditions = *(*[]string)(unsafe.Pointer(&in.Conditions))
+	out.MaxPodsToEvictPerCycle = in.MaxPodsToEvictPerCycle
+	return nil
+}
+
+// Convert_api_NodeProblems_To_v1alpha1_NodeProblems is an autogenerated conversion function.
+func Convert_api_NodeProblems_To_v1alpha1_NodeProblems(in *api.NodeProblems, out *NodeProblems, s conversion.Scope) error {
+	return autoConvert_api_NodeProblems_To_v1alpha1_NodeProblems(in, out, s)
+}
+
 func autoConvert_v1alpha1_NodeResourceUtilizationThresholds_To_api_NodeResourceUtilizationThresholds(in *NodeResourceUtilizationThresholds, out *api.NodeResourceUtilizationThresholds, s conversion.Scope) error {
 	out.Thresholds = *(*api.ResourceThresholds)(unsafe.Pointer(&in.Thresholds))
 	out.TargetThresholds = *(*api.ResourceThresholds)(unsafe.Pointer(&in.TargetThresholds))
 	out.NumberOfNodes = in.NumberOfNodes
+	out.WarningThresholds = *(*api.ResourceThresholds)(unsafe.Pointer(&in.WarningThresholds))
+	out.ExcludedContainers = *(*[]string)(unsafe.Pointer(&in.ExcludedContainers))
+	out.ThresholdsOperator = in.ThresholdsOperator
+	out.TieBreaker = in.TieBreaker
+	out.TieBreakerSeed = in.TieBreakerSeed
+	out.Hysteresis = api.Percentage(in.Hysteresis)
+	out.PriorityBands = *(*[]api.PriorityBand)(unsafe.Pointer(&in.PriorityBands))
+	out.DestinationScorer = in.DestinationScorer
+	out.ThresholdsUnits = in.ThresholdsUnits
+	out.BalancingDomain = in.BalancingDomain
+	out.TopologyKey = in.TopologyKey
+	out.MinFreeCapacityPercent = api.Percentage(in.MinFreeCapacityPercent)
+	out.RelieveResources = *(*[]corev1.ResourceName)(unsafe.Pointer(&in.RelieveResources))
+	out.AnnotateNodes = in.AnnotateNodes
+	out.ExcludeDaemonSetPods = in.ExcludeDaemonSetPods
+	out.Parallelism = in.Parallelism
+	out.UtilizationMetric = in.UtilizationMetric
+	out.MetricsUtilization = in.MetricsUtilization
+	out.Prometheus = (*api.PrometheusUtilization)(unsafe.Pointer(in.Prometheus))
+	out.Trend = (*api.UsageTrend)(unsafe.Pointer(in.Trend))
+	out.CountTerminatingPods = (*bool)(unsafe.Pointer(in.CountTerminatingPods))
+	out.CountNominatedPods = in.CountNominatedPods
+	out.MinPodsOnSourceNode = in.MinPodsOnSourceNode
+	out.UnschedulableNodesAsSources = (*bool)(unsafe.Pointer(in.UnschedulableNodesAsSources))
 	return nil
 }
 
@@ -247,6 +1033,30 @@ func autoConvert_api_NodeResourceUtilizationThresholds_To_v1alpha1_NodeResourceU
 	out.Thresholds = *(*ResourceThresholds)(unsafe.Pointer(&in.Thresholds))
 	out.TargetThresholds = *(*ResourceThresholds)(unsafe.Pointer(&in.TargetThresholds))
 	out.NumberOfNodes = in.NumberOfNodes
+	out.WarningThresholds = *(*ResourceThresholds)(unsafe.Pointer(&in.WarningThresholds))
+	out.ExcludedContainers = *(*[]string)(unsafe.Pointer(&in.ExcludedContainers))
+	out.ThresholdsOperator = in.ThresholdsOperator
+	out.TieBreaker = in.TieBreaker
+	out.TieBreakerSeed = in.TieBreakerSeed
+	out.Hysteresis = Percentage(in.Hysteresis)
+	out.PriorityBands = *(*[]PriorityBand)(unsafe.Pointer(&in.PriorityBands))
+	out.DestinationScorer = in.DestinationScorer
+	out.ThresholdsUnits = in.ThresholdsUnits
+	out.BalancingDomain = in.BalancingDomain
+	out.TopologyKey = in.TopologyKey
+	out.MinFreeCapacityPercent = Percentage(in.MinFreeCapacityPercent)
+	out.RelieveResources = *(*[]corev1.ResourceName)(unsafe.Pointer(&in.RelieveResources))
+	out.AnnotateNodes = in.AnnotateNodes
+	out.ExcludeDaemonSetPods = in.ExcludeDaemonSetPods
+	out.Parallelism = in.Parallelism
+	out.UtilizationMetric = in.UtilizationMetric
+	out.MetricsUtilization = in.MetricsUtilization
+	out.Prometheus = (*PrometheusUtilization)(unsafe.Pointer(in.Prometheus))
+	out.Trend = (*UsageTrend)(unsafe.Pointer(in.Trend))
+	out.CountTerminatingPods = (*bool)(unsafe.Pointer(in.CountTerminatingPods))
+	out.CountNominatedPods = in.CountNominatedPods
+	out.MinPodsOnSourceNode = in.MinPodsOnSourceNode
+	out.UnschedulableNodesAsSources = (*bool)(unsafe.Pointer(in.UnschedulableNodesAsSources))
 	return nil
 }
 
@@ -255,6 +1065,86 @@ func Convert_api_NodeResourceUtilizationThresholds_To_v1alpha1_NodeResourceUtili
 	return autoConvert_api_NodeResourceUtilizationThresholds_To_v1alpha1_NodeResourceUtilizationThresholds(in, out, s)
 }
 
+func autoConvert_v1alpha1_NotReadyNodes_To_api_NotReadyNodes(in *NotReadyNodes, out *api.NotReadyNodes, s conversion.Scope) error {
+	out.NotReadySeconds = (*uint)(unsafe.Pointer(in.NotReadySeconds))
+	out.ForceDeleteAfterSeconds = (*uint)(unsafe.Pointer(in.ForceDeleteAfterSeconds))
+	return nil
+}
+
+// Convert_v1alpha1_NotReadyNodes_To_api_NotReadyNodes is an autogenerated conversion function.
+func Convert_v1alpha1_NotReadyNodes_To_api_NotReadyNodes(in *NotReadyNodes, out *api.NotReadyNodes, s conversion.Scope) error {
+	return autoConvert_v1alpha1_NotReadyNodes_To_api_NotReadyNodes(in, out, s)
+}
+
+func autoConvert_api_NotReadyNodes_To_v1alpha1_NotReadyNodes(in *api.NotReadyNodes, out *NotReadyNodes, s conversion.Scope) error {
+	out.NotReadySeconds = (*uint)(unsafe.Pointer(in.NotReadySeconds))
+	out.ForceDeleteAfterSeconds = (*uint)(unsafe.Pointer(in.ForceDeleteAfterSeconds))
+	return nil
+}
+
+// Convert_api_NotReadyNodes_To_v1alpha1_NotReadyNodes is an autogenerated conversion function.
+func Convert_api_NotReadyNodes_To_v1alpha1_NotReadyNodes(in *api.NotReadyNodes, out *NotReadyNodes, s conversion.Scope) error {
+	return autoConvert_api_NotReadyNodes_To_v1alpha1_NotReadyNodes(in, out, s)
+}
+
+func autoConvert_v1alpha1_PodDensity_To_api_PodDensity(in *PodDensity, out *api.PodDensity, s conversion.Scope) error {
+	out.TopologyKey = in.TopologyKey
+	out.MaxPodsPerDomain = in.MaxPodsPerDomain
+	out.LabelSelector = (*v1.LabelSelector)(unsafe.Pointer(in.LabelSelector))
+	return nil
+}
+
+// Convert_v1alpha1_PodDensity_To_api_PodDensity is an autogenerated conversion function.
+func Convert_v1alpha1_PodDensity_To_api_PodDensity(in *PodDensity, out *api.PodDensity, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PodDensity_To_api_PodDensity(in, out, s)
+}
+
+func autoConvert_api_PodDensity_To_v1alpha1_PodDensity(in *api.PodDensity, out *PodDensity, s conversion.Scope) error {
+	out.TopologyKey = in.TopologyKey
+	out.MaxPodsPerDomain = in.MaxPodsPerDomain
+	out.LabelSelector = (*v1.LabelSelector)(unsafe.Pointer(in.LabelSelector))
+	return nil
+}
+
+// Convert_api_PodDensity_To_v1alpha1_PodDensity is an autogenerated conversion function.
+func Convert_api_PodDensity_To_v1alpha1_PodDensity(in *api.PodDensity, out *PodDensity, s conversion.Scope) error {
+	return autoConvert_api_PodDensity_To_v1alpha1_PodDensity(in, out, s)
+}
+
+func autoConvert_v1alpha1_PodFilter_To_api_PodFilter(in *PodFilter, out *api.PodFilter, s conversion.Scope) error {
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	out.LabelSelector = (*v1.LabelSelector)(unsafe.Pointer(in.LabelSelector))
+	out.NamespaceSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NamespaceSelector))
+	out.MinPriority = (*int32)(unsafe.Pointer(in.MinPriority))
+	out.MaxPriority = (*int32)(unsafe.Pointer(in.MaxPriority))
+	out.AllOf = *(*[]api.PodFilter)(unsafe.Pointer(&in.AllOf))
+	out.AnyOf = *(*[]api.PodFilter)(unsafe.Pointer(&in.AnyOf))
+	out.NoneOf = *(*[]api.PodFilter)(unsafe.Pointer(&in.NoneOf))
+	return nil
+}
+
+// Convert_v1alpha1_PodFilter_To_api_PodFilter is an autogenerated conversion function.
+func Convert_v1alpha1_PodFilter_To_api_PodFilter(in *PodFilter, out *api.PodFilter, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PodFilter_To_api_PodFilter(in, out, s)
+}
+
+func autoConvert_api_PodFilter_To_v1alpha1_PodFilter(in *api.PodFilter, out *PodFilter, s conversion.Scope) error {
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	out.LabelSelector = (*v1.LabelSelector)(unsafe.Pointer(in.LabelSelector))
+	out.NamespaceSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NamespaceSelector))
+	out.MinPriority = (*int32)(unsafe.Pointer(in.MinPriority))
+	out.MaxPriority = (*int32)(unsafe.Pointer(in.MaxPriority))
+	out.AllOf = *(*[]PodFilter)(unsafe.Pointer(&in.AllOf))
+	out.AnyOf = *(*[]PodFilter)(unsafe.Pointer(&in.AnyOf))
+	out.NoneOf = *(*[]PodFilter)(unsafe.Pointer(&in.NoneOf))
+	return nil
+}
+
+// Convert_api_PodFilter_To_v1alpha1_PodFilter is an autogenerated conversion function.
+func Convert_api_PodFilter_To_v1alpha1_PodFilter(in *api.PodFilter, out *PodFilter, s conversion.Scope) error {
+	return autoConvert_api_PodFilter_To_v1alpha1_PodFilter(in, out, s)
+}
+
 func autoConvert_v1alpha1_PodLifeTime_To_api_PodLifeTime(in *PodLifeTime, out *api.PodLifeTime, s conversion.Scope) error {
 	out.MaxPodLifeTimeSeconds = (*uint)(unsafe.Pointer(in.MaxPodLifeTimeSeconds))
 	out.PodStatusPhases = *(*[]string)(unsafe.Pointer(&in.PodStatusPhases))
@@ -299,6 +1189,130 @@ func Convert_api_PodsHavingTooManyRestarts_To_v1alpha1_PodsHavingTooManyRestarts
 	return autoConvert_api_PodsHavingTooManyRestarts_To_v1alpha1_PodsHavingTooManyRestarts(in, out, s)
 }
 
+func autoConvert_v1alpha1_PolicyReports_To_api_PolicyReports(in *PolicyReports, out *api.PolicyReports, s conversion.Scope) error {
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_v1alpha1_PolicyReports_To_api_PolicyReports is an autogenerated conversion function.
+func Convert_v1alpha1_PolicyReports_To_api_PolicyReports(in *PolicyReports, out *api.PolicyReports, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PolicyReports_To_api_PolicyReports(in, out, s)
+}
+
+func autoConvert_api_PolicyReports_To_v1alpha1_PolicyReports(in *api.PolicyReports, out *PolicyReports, s conversion.Scope) error {
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_api_PolicyReports_To_v1alpha1_PolicyReports is an autogenerated conversion function.
+func Convert_api_PolicyReports_To_v1alpha1_PolicyReports(in *api.PolicyReports, out *PolicyReports, s conversion.Scope) error {
+	return autoConvert_api_PolicyReports_To_v1alpha1_PolicyReports(in, out, s)
+}
+
+func autoConvert_v1alpha1_PriorityBand_To_api_PriorityBand(in *PriorityBand, out *api.PriorityBand, s conversion.Scope) error {
+	out.MinPriority = (*int32)(unsafe.Pointer(in.MinPriority))
+	out.MaxPriority = (*int32)(unsafe.Pointer(in.MaxPriority))
+	out.Thresholds = *(*api.ResourceThresholds)(unsafe.Pointer(&in.Thresholds))
+	out.TargetThresholds = *(*api.ResourceThresholds)(unsafe.Pointer(&in.TargetThresholds))
+	return nil
+}
+
+// Convert_v1alpha1_PriorityBand_To_api_PriorityBand is an autogenerated conversion function.
+func Convert_v1alpha1_PriorityBand_To_api_PriorityBand(in *PriorityBand, out *api.PriorityBand, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PriorityBand_To_api_PriorityBand(in, out, s)
+}
+
+func autoConvert_api_PriorityBand_To_v1alpha1_PriorityBand(in *api.PriorityBand, out *PriorityBand, s conversion.Scope) error {
+	out.MinPriority = (*int32)(unsafe.Pointer(in.MinPriority))
+	out.MaxPriority = (*int32)(unsafe.Pointer(in.MaxPriority))
+	out.Thresholds = *(*ResourceThresholds)(unsafe.Pointer(&in.Thresholds))
+	out.TargetThresholds = *(*ResourceThresholds)(unsafe.Pointer(&in.TargetThresholds))
+	return nil
+}
+
+// Convert_api_PriorityBand_To_v1alpha1_PriorityBand is an autogenerated conversion function.
+func Convert_api_PriorityBand_To_v1alpha1_PriorityBand(in *api.PriorityBand, out *PriorityBand, s conversion.Scope) error {
+	return autoConvert_api_PriorityBand_To_v1alpha1_PriorityBand(in, out, s)
+}
+
+func autoConvert_v1alpha1_PrometheusUtilization_To_api_PrometheusUtilization(in *PrometheusUtilization, out *api.PrometheusUtilization, s conversion.Scope) error {
+	out.URL = in.URL
+	out.BearerTokenFile = in.BearerTokenFile
+	out.TimeoutSeconds = (*uint)(unsafe.Pointer(in.TimeoutSeconds))
+	out.WindowSeconds = (*uint)(unsafe.Pointer(in.WindowSeconds))
+	out.Aggregation = in.Aggregation
+	out.CPUQuery = in.CPUQuery
+	out.MemoryQuery = in.MemoryQuery
+	return nil
+}
+
+// Convert_v1alpha1_PrometheusUtilization_To_api_PrometheusUtilization is an autogenerated conversion function.
+func Convert_v1alpha1_PrometheusUtilization_To_api_PrometheusUtilization(in *PrometheusUtilization, out *api.PrometheusUtilization, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PrometheusUtilization_To_api_PrometheusUtilization(in, out, s)
+}
+
+func autoConvert_api_PrometheusUtilization_To_v1alpha1_PrometheusUtilization(in *api.PrometheusUtilization, out *PrometheusUtilization, s conversion.Scope) error {
+	out.URL = in.URL
+	out.BearerTokenFile = in.BearerTokenFile
+	out.TimeoutSeconds = (*uint)(unsafe.Pointer(in.TimeoutSeconds))
+	out.WindowSeconds = (*uint)(unsafe.Pointer(in.WindowSeconds))
+	out.Aggregation = in.Aggregation
+	out.CPUQuery = in.CPUQuery
+	out.MemoryQuery = in.MemoryQuery
+	return nil
+}
+
+// Convert_api_PrometheusUtilization_To_v1alpha1_PrometheusUtilization is an autogenerated conversion function.
+func Convert_api_PrometheusUtilization_To_v1alpha1_PrometheusUtilization(in *api.PrometheusUtilization, out *PrometheusUtilization, s conversion.Scope) error {
+	return autoConvert_api_PrometheusUtilization_To_v1alpha1_PrometheusUtilization(in, out, s)
+}
+
+func autoConvert_v1alpha1_ProtectedPodCondition_To_api_ProtectedPodCondition(in *ProtectedPodCondition, out *api.ProtectedPodCondition, s conversion.Scope) error {
+	out.Type = corev1.PodConditionType(in.Type)
+	out.Status = corev1.ConditionStatus(in.Status)
+	return nil
+}
+
+// Convert_v1alpha1_ProtectedPodCondition_To_api_ProtectedPodCondition is an autogenerated conversion function.
+func Convert_v1alpha1_ProtectedPodCondition_To_api_ProtectedPodCondition(in *ProtectedPodCondition, out *api.ProtectedPodCondition, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ProtectedPodCondition_To_api_ProtectedPodCondition(in, out, s)
+}
+
+func autoConvert_api_ProtectedPodCondition_To_v1alpha1_ProtectedPodCondition(in *api.ProtectedPodCondition, out *ProtectedPodCondition, s conversion.Scope) error {
+	out.Type = corev1.PodConditionType(in.Type)
+	out.Status = corev1.ConditionStatus(in.Status)
+	return nil
+}
+
+// Convert_api_ProtectedPodCondition_To_v1alpha1_ProtectedPodCondition is an autogenerated conversion function.
+func Convert_api_ProtectedPodCondition_To_v1alpha1_ProtectedPodCondition(in *api.ProtectedPodCondition, out *ProtectedPodCondition, s conversion.Scope) error {
+	return autoConvert_api_ProtectedPodCondition_To_v1alpha1_ProtectedPodCondition(in, out, s)
+}
+
+func autoConvert_v1alpha1_RemoteWrite_To_api_RemoteWrite(in *RemoteWrite, out *api.RemoteWrite, s conversion.Scope) error {
+	out.URL = in.URL
+	out.BearerTokenFile = in.BearerTokenFile
+	out.TimeoutSeconds = (*uint)(unsafe.Pointer(in.TimeoutSeconds))
+	return nil
+}
+
+// Convert_v1alpha1_RemoteWrite_To_api_RemoteWrite is an autogenerated conversion function.
+func Convert_v1alpha1_RemoteWrite_To_api_RemoteWrite(in *RemoteWrite, out *api.RemoteWrite, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RemoteWrite_To_api_RemoteWrite(in, out, s)
+}
+
+func autoConvert_api_RemoteWrite_To_v1alpha1_RemoteWrite(in *api.RemoteWrite, out *RemoteWrite, s conversion.Scope) error {
+	out.URL = in.URL
+	out.BearerTokenFile = in.BearerTokenFile
+	out.TimeoutSeconds = (*uint)(unsafe.Pointer(in.TimeoutSeconds))
+	return nil
+}
+
+// Convert_api_RemoteWrite_To_v1alpha1_RemoteWrite is an autogenerated conversion function.
+func Convert_api_RemoteWrite_To_v1alpha1_RemoteWrite(in *api.RemoteWrite, out *RemoteWrite, s conversion.Scope) error {
+	return autoConvert_api_RemoteWrite_To_v1alpha1_RemoteWrite(in, out, s)
+}
+
 func autoConvert_v1alpha1_RemoveDuplicates_To_api_RemoveDuplicates(in *RemoveDuplicates, out *api.RemoveDuplicates, s conversion.Scope) error {
 	out.ExcludeOwnerKinds = *(*[]string)(unsafe.Pointer(&in.ExcludeOwnerKinds))
 	return nil
@@ -319,6 +1333,32 @@ func Convert_api_RemoveDuplicates_To_v1alpha1_RemoveDuplicates(in *api.RemoveDup
 	return autoConvert_api_RemoveDuplicates_To_v1alpha1_RemoveDuplicates(in, out, s)
 }
 
+func autoConvert_v1alpha1_RestartChurn_To_api_RestartChurn(in *RestartChurn, out *api.RestartChurn, s conversion.Scope) error {
+	out.NodeRestartThreshold = in.NodeRestartThreshold
+	out.WindowSeconds = (*uint)(unsafe.Pointer(in.WindowSeconds))
+	out.IncludingInitContainers = in.IncludingInitContainers
+	out.MaxPodsToEvictPerNode = in.MaxPodsToEvictPerNode
+	return nil
+}
+
+// Convert_v1alpha1_RestartChurn_To_api_RestartChurn is an autogenerated conversion function.
+func Convert_v1alpha1_RestartChurn_To_api_RestartChurn(in *RestartChurn, out *api.RestartChurn, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RestartChurn_To_api_RestartChurn(in, out, s)
+}
+
+func autoConvert_api_RestartChurn_To_v1alpha1_RestartChurn(in *api.RestartChurn, out *RestartChurn, s conversion.Scope) error {
+	out.NodeRestartThreshold = in.NodeRestartThreshold
+	out.WindowSeconds = (*uint)(unsafe.Pointer(in.WindowSeconds))
+	out.IncludingInitContainers = in.IncludingInitContainers
+	out.MaxPodsToEvictPerNode = in.MaxPodsToEvictPerNode
+	return nil
+}
+
+// Convert_api_RestartChurn_To_v1alpha1_RestartChurn is an autogenerated conversion function.
+func Convert_api_RestartChurn_To_v1alpha1_RestartChurn(in *api.RestartChurn, out *RestartChurn, s conversion.Scope) error {
+	return autoConvert_api_RestartChurn_To_v1alpha1_RestartChurn(in, out, s)
+}
+
 func autoConvert_v1alpha1_StrategyParameters_To_api_StrategyParameters(in *StrategyParameters, out *api.StrategyParameters, s conversion.Scope) error {
 	out.NodeResourceUtilizationThresholds = (*api.NodeResourceUtilizationThresholds)(unsafe.Pointer(in.NodeResourceUtilizationThresholds))
 	out.NodeAffinityType = *(*[]string)(unsafe.Pointer(&in.NodeAffinityType))
@@ -326,12 +1366,31 @@ func autoConvert_v1alpha1_StrategyParameters_To_api_StrategyParameters(in *Strat
 	out.PodLifeTime = (*api.PodLifeTime)(unsafe.Pointer(in.PodLifeTime))
 	out.RemoveDuplicates = (*api.RemoveDuplicates)(unsafe.Pointer(in.RemoveDuplicates))
 	out.FailedPods = (*api.FailedPods)(unsafe.Pointer(in.FailedPods))
+	out.AntiColocation = (*api.AntiColocation)(unsafe.Pointer(in.AntiColocation))
+	out.PodDensity = (*api.PodDensity)(unsafe.Pointer(in.PodDensity))
+	out.NodeProblems = (*api.NodeProblems)(unsafe.Pointer(in.NodeProblems))
+	out.LimitsOvercommit = (*api.LimitsOvercommit)(unsafe.Pointer(in.LimitsOvercommit))
+	out.FinishedJobPods = (*api.FinishedJobPods)(unsafe.Pointer(in.FinishedJobPods))
+	out.MemoryOverrun = (*api.MemoryOverrun)(unsafe.Pointer(in.MemoryOverrun))
+	out.NodeInterruption = (*api.NodeInterruption)(unsafe.Pointer(in.NodeInterruption))
+	out.StuckPods = (*api.StuckPods)(unsafe.Pointer(in.StuckPods))
+	out.ImageLocality = (*api.ImageLocality)(unsafe.Pointer(in.ImageLocality))
+	out.NotReadyNodes = (*api.NotReadyNodes)(unsafe.Pointer(in.NotReadyNodes))
+	out.GPUModelPreference = (*api.GPUModelPreference)(unsafe.Pointer(in.GPUModelPreference))
+	out.TerminatingPods = (*api.TerminatingPods)(unsafe.Pointer(in.TerminatingPods))
+	out.RestartChurn = (*api.RestartChurn)(unsafe.Pointer(in.RestartChurn))
 	out.IncludeSoftConstraints = in.IncludeSoftConstraints
+	out.MaxSkewReductionPerCycle = in.MaxSkewReductionPerCycle
+	out.TopologyBalanceDomains = *(*[]string)(unsafe.Pointer(&in.TopologyBalanceDomains))
 	out.Namespaces = (*api.Namespaces)(unsafe.Pointer(in.Namespaces))
+	out.NamespaceSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NamespaceSelector))
 	out.ThresholdPriority = (*int32)(unsafe.Pointer(in.ThresholdPriority))
 	out.ThresholdPriorityClassName = in.ThresholdPriorityClassName
 	out.LabelSelector = (*v1.LabelSelector)(unsafe.Pointer(in.LabelSelector))
 	out.NodeFit = in.NodeFit
+	out.IncludeCordonedNodes = (*bool)(unsafe.Pointer(in.IncludeCordonedNodes))
+	out.NodeRoles = *(*[]string)(unsafe.Pointer(&in.NodeRoles))
+	out.PluginArgs = (*runtime.RawExtension)(unsafe.Pointer(in.PluginArgs))
 	return nil
 }
 
@@ -347,12 +1406,31 @@ func autoConvert_api_StrategyParameters_To_v1alpha1_StrategyParameters(in *api.S
 	out.PodLifeTime = (*PodLifeTime)(unsafe.Pointer(in.PodLifeTime))
 	out.RemoveDuplicates = (*RemoveDuplicates)(unsafe.Pointer(in.RemoveDuplicates))
 	out.FailedPods = (*FailedPods)(unsafe.Pointer(in.FailedPods))
+	out.AntiColocation = (*AntiColocation)(unsafe.Pointer(in.AntiColocation))
+	out.PodDensity = (*PodDensity)(unsafe.Pointer(in.PodDensity))
+	out.NodeProblems = (*NodeProblems)(unsafe.Pointer(in.NodeProblems))
+	out.LimitsOvercommit = (*LimitsOvercommit)(unsafe.Pointer(in.LimitsOvercommit))
+	out.FinishedJobPods = (*FinishedJobPods)(unsafe.Pointer(in.FinishedJobPods))
+	out.MemoryOverrun = (*MemoryOverrun)(unsafe.Pointer(in.MemoryOverrun))
+	out.NodeInterruption = (*NodeInterruption)(unsafe.Pointer(in.NodeInterruption))
+	out.StuckPods = (*StuckPods)(unsafe.Pointer(in.StuckPods))
+	out.ImageLocality = (*ImageLocality)(unsafe.Pointer(in.ImageLocality))
+	out.NotReadyNodes = (*NotReadyNodes)(unsafe.Pointer(in.NotReadyNodes))
+	out.GPUModelPreference = (*GPUModelPreference)(unsafe.Pointer(in.GPUModelPreference))
+	out.TerminatingPods = (*TerminatingPods)(unsafe.Pointer(in.TerminatingPods))
+	out.RestartChurn = (*RestartChurn)(unsafe.Pointer(in.RestartChurn))
 	out.IncludeSoftConstraints = in.IncludeSoftConstraints
+	out.MaxSkewReductionPerCycle = in.MaxSkewReductionPerCycle
+	out.TopologyBalanceDomains = *(*[]string)(unsafe.Pointer(&in.TopologyBalanceDomains))
 	out.Namespaces = (*Namespaces)(unsafe.Pointer(in.Namespaces))
+	out.NamespaceSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NamespaceSelector))
 	out.ThresholdPriority = (*int32)(unsafe.Pointer(in.ThresholdPriority))
 	out.ThresholdPriorityClassName = in.ThresholdPriorityClassName
 	out.LabelSelector = (*v1.LabelSelector)(unsafe.Pointer(in.LabelSelector))
 	out.NodeFit = in.NodeFit
+	out.IncludeCordonedNodes = (*bool)(unsafe.Pointer(in.IncludeCordonedNodes))
+	out.NodeRoles = *(*[]string)(unsafe.Pointer(&in.NodeRoles))
+	out.PluginArgs = (*runtime.RawExtension)(unsafe.Pointer(in.PluginArgs))
 	return nil
 }
 
@@ -360,3 +1438,73 @@ func autoConvert_api_StrategyParameters_To_v1alpha1_StrategyParameters(in *api.S
 func Convert_api_StrategyParameters_To_v1alpha1_StrategyParameters(in *api.StrategyParameters, out *StrategyParameters, s conversion.Scope) error {
 	return autoConvert_api_StrategyParameters_To_v1alpha1_StrategyParameters(in, out, s)
 }
+
+func autoConvert_v1alpha1_StuckPods_To_api_StuckPods(in *StuckPods, out *api.StuckPods, s conversion.Scope) error {
+	out.MaxStuckSeconds = (*uint)(unsafe.Pointer(in.MaxStuckSeconds))
+	out.Reasons = *(*[]string)(unsafe.Pointer(&in.Reasons))
+	return nil
+}
+
+// Convert_v1alpha1_StuckPods_To_api_StuckPods is an autogenerated conversion function.
+func Convert_v1alpha1_StuckPods_To_api_StuckPods(in *StuckPods, out *api.StuckPods, s conversion.Scope) error {
+	return autoConvert_v1alpha1_StuckPods_To_api_StuckPods(in, out, s)
+}
+
+func autoConvert_api_StuckPods_To_v1alpha1_StuckPods(in *api.StuckPods, out *StuckPods, s conversion.Scope) error {
+	out.MaxStuckSeconds = (*uint)(unsafe.Pointer(in.MaxStuckSeconds))
+	out.Reasons = *(*[]string)(unsafe.Pointer(&in.Reasons))
+	return nil
+}
+
+// Convert_api_StuckPods_To_v1alpha1_StuckPods is an autogenerated conversion function.
+func Convert_api_StuckPods_To_v1alpha1_StuckPods(in *api.StuckPods, out *StuckPods, s conversion.Scope) error {
+	return autoConvert_api_StuckPods_To_v1alpha1_StuckPods(in, out, s)
+}
+
+func autoConvert_v1alpha1_TerminatingPods_To_api_TerminatingPods(in *TerminatingPods, out *api.TerminatingPods, s conversion.Scope) error {
+	out.MaxTerminatingSeconds = (*uint)(unsafe.Pointer(in.MaxTerminatingSeconds))
+	out.ForceDelete = in.ForceDelete
+	return nil
+}
+
+// Convert_v1alpha1_TerminatingPods_To_api_TerminatingPods is an autogenerated conversion function.
+func Convert_v1alpha1_TerminatingPods_To_api_TerminatingPods(in *TerminatingPods, out *api.TerminatingPods, s conversion.Scope) error {
+	return autoConvert_v1alpha1_TerminatingPods_To_api_TerminatingPods(in, out, s)
+}
+
+func autoConvert_api_TerminatingPods_To_v1alpha1_TerminatingPods(in *api.TerminatingPods, out *TerminatingPods, s conversion.Scope) error {
+	out.MaxTerminatingSeconds = (*uint)(unsafe.Pointer(in.MaxTerminatingSeconds))
+	out.ForceDelete = in.ForceDelete
+	return nil
+}
+
+// Convert_api_TerminatingPods_To_v1alpha1_TerminatingPods is an autogenerated conversion function.
+func Convert_api_TerminatingPods_To_v1alpha1_TerminatingPods(in *api.TerminatingPods, out *TerminatingPods, s conversion.Scope) error {
+	return autoConvert_api_TerminatingPods_To_v1alpha1_TerminatingPods(in, out, s)
+}
+
+func autoConvert_v1alpha1_UsageTrend_To_api_UsageTrend(in *UsageTrend, out *api.UsageTrend, s conversion.Scope) error {
+	out.HorizonSeconds = in.HorizonSeconds
+	out.WindowSeconds = (*uint)(unsafe.Pointer(in.WindowSeconds))
+	out.MinSamples = (*uint)(unsafe.Pointer(in.MinSamples))
+	out.Resources = *(*[]corev1.ResourceName)(unsafe.Pointer(&in.Resources))
+	return nil
+}
+
+// Convert_v1alpha1_UsageTrend_To_api_UsageTrend is an autogenerated conversion function.
+func Convert_v1alpha1_UsageTrend_To_api_UsageTrend(in *UsageTrend, out *api.UsageTrend, s conversion.Scope) error {
+	return autoConvert_v1alpha1_UsageTrend_To_api_UsageTrend(in, out, s)
+}
+
+func autoConvert_api_UsageTrend_To_v1alpha1_UsageTrend(in *api.UsageTrend, out *UsageTrend, s conversion.Scope) error {
+	out.HorizonSeconds = in.HorizonSeconds
+	out.WindowSeconds = (*uint)(unsafe.Pointer(in.WindowSeconds))
+	out.MinSamples = (*uint)(unsafe.Pointer(in.MinSamples))
+	out.Resources = *(*[]corev1.ResourceName)(unsafe.Pointer(&in.Resources))
+	return nil
+}
+
+// Convert_api_UsageTrend_To_v1alpha1_UsageTrend is an autogenerated conversion function.
+func Convert_api_UsageTrend_To_v1alpha1_UsageTrend(in *api.UsageTrend, out *UsageTrend, s conversion.Scope) error {
+	return autoConvert_api_UsageTrend_To_v1alpha1_UsageTrend(in, out, s)
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"k8s.io/apimachinery/pkg/conversion"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+// Convert_api_DeschedulerPolicy_To_v1alpha2_DeschedulerPolicy turns the top level strategies of a policy without
+// profiles, i.e. decoded from v1alpha1, into the default profile.
+func Convert_api_DeschedulerPolicy_To_v1alpha2_DeschedulerPolicy(in *api.DeschedulerPolicy, out *DeschedulerPolicy, s conversion.Scope) error {
+	if err := autoConvert_api_DeschedulerPolicy_To_v1alpha2_DeschedulerPolicy(in, out, s); err != nil {
+		return err
+	}
+	if len(in.Profiles) > 0 || len(in.Strategies) == 0 {
+		return nil
+	}
+	profile := DeschedulerProfile{}
+	if err := Convert_api_DeschedulerProfile_To_v1alpha2_DeschedulerProfile(&api.DeschedulerProfile{
+		Name:                      api.DefaultProfileName,
+		Strategies:                in.Strategies,
+		NodeSelector:              in.NodeSelector,
+		MaxNoOfPodsToEvictPerNode: in.MaxNoOfPodsToEvictPerNode,
+		MaxPerOwnerPerNode:        in.MaxPerOwnerPerNode,
+	}, &profile, s); err != nil {
+		return err
+	}
+	out.Profiles = []DeschedulerProfile{profile}
+	return nil
+}
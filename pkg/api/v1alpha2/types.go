@@ -0,0 +1,610 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type DeschedulerPolicy struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Profiles are named sets of strategies, each with its own node selector and eviction limits. Profiles run
+	// one after the other in every descheduling cycle, in the order they are listed.
+	Profiles []DeschedulerProfile `json:"profiles,omitempty"`
+
+	// EvictLocalStoragePods allows pods using local storage to be evicted.
+	EvictLocalStoragePods *bool `json:"evictLocalStoragePods,omitempty"`
+	// EvictableEmptyDirSizeLimit allows, when local storage pods are not evicted, pods whose local storage only
+	// consists of emptyDir volumes with a sizeLimit up to this quantity to be evicted, as they are cheap to lose.
+	// Pods with hostPath volumes, or with emptyDir volumes without or above this sizeLimit, remain protected.
+	EvictableEmptyDirSizeLimit *resource.Quantity `json:"evictableEmptyDirSizeLimit,omitempty"`
+
+	// EvictSystemCriticalPods allows eviction of pods of any priority (including Kubernetes system pods)
+	EvictSystemCriticalPods *bool `json:"evictSystemCriticalPods,omitempty"`
+
+	// IgnorePVCPods prevents pods with PVCs from being evicted.
+	IgnorePVCPods *bool `json:"ignorePvcPods,omitempty"`
+
+	// HealthGates halt descheduling while the cluster is unhealthy.
+	HealthGates *HealthGates `json:"healthGates,omitempty"`
+
+	// ReschedulingHints annotates owners of evicted pods with a hint on where to schedule their replacements.
+	ReschedulingHints *bool `json:"reschedulingHints,omitempty"`
+
+	// EvictionHistory keeps the evictions of the last descheduling cycles in a ConfigMap.
+	EvictionHistory *EvictionHistory `json:"evictionHistory,omitempty"`
+
+	// PolicyReports summarizes the findings and evictions of every descheduling cycle in wgpolicyk8s.io
+	// PolicyReport and ClusterPolicyReport objects.
+	PolicyReports *PolicyReports `json:"policyReports,omitempty"`
+
+	// RemoteWrite sends the node usages and classifications computed by LowNodeUtilization and HighNodeUtilization
+	// during every descheduling cycle to a Prometheus remote write endpoint.
+	RemoteWrite *RemoteWrite `json:"remoteWrite,omitempty"`
+
+	// ReplacementReadinessTimeoutSeconds bounds the wait for a ready replacement of an evicted pod
+	// before evicting another pod of the same owner. Waiting is disabled when not set.
+	ReplacementReadinessTimeoutSeconds *uint `json:"replacementReadinessTimeoutSeconds,omitempty"`
+
+	// VolumeDetachTimeoutSeconds bounds the wait for the ReadWriteOnce volumes of an evicted pod to be
+	// detached from its node before evicting another pod with such volumes from the node. Waiting is
+	// disabled when not set.
+	VolumeDetachTimeoutSeconds *uint `json:"volumeDetachTimeoutSeconds,omitempty"`
+
+	// PodEvictionCooldownSeconds refuses, whichever strategy evicts them, evictions of pods created to
+	// replace a pod of the same owner and template evicted less than the cooldown ago. Disabled when not set.
+	PodEvictionCooldownSeconds *uint `json:"podEvictionCooldownSeconds,omitempty"`
+	// CooldownPersistence stores the evictions the cooldown is tracked from in the cluster, so that restarting
+	// the descheduler does not reset the cooldown. Not stored when not set.
+	CooldownPersistence *CooldownPersistence `json:"cooldownPersistence,omitempty"`
+
+	// ProtectedDeployments lists deployments, as namespace/name, whose pods are never evicted. The pod of the
+	// descheduler and the other pods of its deployment are always protected.
+	ProtectedDeployments []string `json:"protectedDeployments,omitempty"`
+
+	// InteractiveSessionLookbackSeconds protects pods with an interactive session (exec, attach or port-forward)
+	// recorded within the lookback window from evictions. Disabled when not set.
+	InteractiveSessionLookbackSeconds *uint `json:"interactiveSessionLookbackSeconds,omitempty"`
+
+	// ProtectedPodConditions protects pods having any of these conditions from evictions, e.g. a condition set by
+	// an operator while a backup of the pod is in progress.
+	ProtectedPodConditions []ProtectedPodCondition `json:"protectedPodConditions,omitempty"`
+
+	// NodeDeletionTrigger runs LowNodeUtilization immediately, outside of the descheduling interval,
+	// once nodes holding a large share of the cluster capacity are deleted.
+	NodeDeletionTrigger *NodeDeletionTrigger `json:"nodeDeletionTrigger,omitempty"`
+
+	// EvictionFilter restricts, whichever strategy evicts them, evictions to the pods it matches.
+	EvictionFilter *PodFilter `json:"evictionFilter,omitempty"`
+
+	// SkipOwnersRollingOut skips evicting pods of Deployments and StatefulSets progressing a rollout, until the
+	// rollout completes.
+	SkipOwnersRollingOut *bool `json:"skipOwnersRollingOut,omitempty"`
+
+	// NamespaceRules lets namespace owners opt the pods of their namespace into strategies through
+	// DeschedulingRule objects, within the guardrails it sets. Disabled when not set.
+	NamespaceRules *NamespaceRules `json:"namespaceRules,omitempty"`
+
+	// EvictedPodAnnotations sets annotations describing the eviction on pods right before they are evicted, so
+	// termination hooks and log pipelines of the workloads can tell why they are shut down. Disabled when not set.
+	EvictedPodAnnotations *EvictedPodAnnotations `json:"evictedPodAnnotations,omitempty"`
+
+	// CycleAbort aborts the rest of a descheduling cycle once too many of its eviction attempts failed, and backs
+	// off the next cycles exponentially. Disabled when not set.
+	CycleAbort *CycleAbort `json:"cycleAbort,omitempty"`
+
+	// EvictionLimits caps the evictions of a descheduling cycle, in total and per namespace, and paces them.
+	EvictionLimits *EvictionLimits `json:"evictionLimits,omitempty"`
+}
+
+// DeschedulerProfile is a named set of strategies run against the nodes of its node selector, within its own
+// eviction limits
+type DeschedulerProfile struct {
+	// Name identifies the profile in logs, metrics and the eviction history, it must be unique
+	Name string `json:"name"`
+
+	// Strategies, built-in or plugins, enabled by the profile
+	Strategies StrategyList `json:"strategies,omitempty"`
+
+	// NodeSelector for a set of nodes to operate over
+	NodeSelector *string `json:"nodeSelector,omitempty"`
+
+	// MaxNoOfPodsToEvictPerNode restricts maximum of pods to be evicted per node by the strategies of the profile.
+	MaxNoOfPodsToEvictPerNode *int `json:"maxNoOfPodsToEvictPerNode,omitempty"`
+
+	// MaxPerOwnerPerNode restricts maximum of pods sharing an owner to be evicted per node by the strategies of
+	// the profile.
+	MaxPerOwnerPerNode *int `json:"maxPerOwnerPerNode,omitempty"`
+}
+
+// PodFilter matches pods by namespace, labels and priority. Every condition set on a filter has to match:
+// its namespaces, label selector and priority range, all of AllOf, at least one of AnyOf and none of NoneOf.
+// An empty filter matches every pod.
+type PodFilter struct {
+	Namespaces    []string              `json:"namespaces,omitempty"`
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+	// NamespaceSelector matches the labels of the namespace of the pod
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// MinPriority and MaxPriority are inclusive, pods without priority have a priority of 0
+	MinPriority *int32      `json:"minPriority,omitempty"`
+	MaxPriority *int32      `json:"maxPriority,omitempty"`
+	AllOf       []PodFilter `json:"allOf,omitempty"`
+	AnyOf       []PodFilter `json:"anyOf,omitempty"`
+	NoneOf      []PodFilter `json:"noneOf,omitempty"`
+}
+
+// HealthGates are checked before every descheduling cycle, no pod is evicted
+// during the cycle when any of the configured limits is exceeded.
+type HealthGates struct {
+	// MaxNotReadyNodesPercentage is the maximum percentage of not ready nodes.
+	MaxNotReadyNodesPercentage *Percentage `json:"maxNotReadyNodesPercentage,omitempty"`
+	// MaxPendingPods is the maximum number of pending pods in the cluster.
+	MaxPendingPods *int `json:"maxPendingPods,omitempty"`
+	// MaxKubeletRestarts is the maximum number of kubelet restarts reported
+	// through node events during the last KubeletRestartsWindowSeconds.
+	MaxKubeletRestarts *int `json:"maxKubeletRestarts,omitempty"`
+	// KubeletRestartsWindowSeconds defaults to 600 seconds.
+	KubeletRestartsWindowSeconds *uint `json:"kubeletRestartsWindowSeconds,omitempty"`
+	// SchedulerLease is the leader election lease of the scheduler, as namespace/name or name in
+	// kube-system, which must have been renewed within its duration, i.e. the scheduler must be up
+	// for evicted pods to be rescheduled.
+	SchedulerLease string `json:"schedulerLease,omitempty"`
+}
+
+// EvictionLimits caps evictions, in total and per namespace, and paces them with a token bucket, so a single
+// descheduling cycle can not destabilize a large cluster. Set in the policy they apply to all the evictions of a
+// cycle, set in a strategy to the evictions of the strategy during a cycle.
+type EvictionLimits struct {
+	// MaxNoOfPodsToEvictPerCycle restricts maximum of pods to be evicted per descheduling cycle
+	MaxNoOfPodsToEvictPerCycle *uint `json:"maxNoOfPodsToEvictPerCycle,omitempty"`
+	// MaxNoOfPodsToEvictPerNamespace restricts maximum of pods to be evicted per namespace and descheduling cycle
+	MaxNoOfPodsToEvictPerNamespace *uint `json:"maxNoOfPodsToEvictPerNamespace,omitempty"`
+	// EvictionsPerSecond is the rate evictions are paced at, not paced when not set
+	EvictionsPerSecond *float64 `json:"evictionsPerSecond,omitempty"`
+	// EvictionBurst is the number of evictions allowed at once above the rate, 1 by default
+	EvictionBurst *uint `json:"evictionBurst,omitempty"`
+}
+
+// CycleAbort configures the abort of descheduling cycles with too many failed evictions, caused by systemic
+// problems like missing RBAC permissions or admission webhooks denying evictions
+type CycleAbort struct {
+	// MaxErrorRatio is the ratio, between 0 and 1, of failed eviction attempts of a cycle above which the rest
+	// of the cycle is aborted
+	MaxErrorRatio float64 `json:"maxErrorRatio"`
+	// MinEvictionAttempts is the number of eviction attempts of a cycle before the ratio of failed attempts is
+	// checked, 10 by default
+	MinEvictionAttempts *uint `json:"minEvictionAttempts,omitempty"`
+	// MaxBackoffSeconds bounds the delay of the next cycle after consecutive aborted cycles, 3600 by default
+	MaxBackoffSeconds *uint `json:"maxBackoffSeconds,omitempty"`
+}
+
+// NodeDeletionTrigger configures the descheduling cycles requested by node deletions
+type NodeDeletionTrigger struct {
+	// CapacityPercentage is the percentage of the allocatable cpu or memory of the cluster the nodes
+	// deleted since the last descheduling cycle must exceed to request a cycle
+	CapacityPercentage Percentage `json:"capacityPercentage"`
+}
+
+// PolicyReports configures the wgpolicyk8s.io reports written after every descheduling cycle
+type PolicyReports struct {
+	// Name of the PolicyReport of every namespace and of the ClusterPolicyReport, descheduler by default
+	Name string `json:"name,omitempty"`
+}
+
+// RemoteWrite configures the Prometheus remote write endpoint node usages are sent to
+type RemoteWrite struct {
+	// URL of the remote write endpoint, e.g. http://prometheus:9090/api/v1/write
+	URL string `json:"url"`
+	// BearerTokenFile is the file of the token authenticating the requests, unauthenticated when empty
+	BearerTokenFile string `json:"bearerTokenFile,omitempty"`
+	// TimeoutSeconds bounds the duration of a request, 30 by default
+	TimeoutSeconds *uint `json:"timeoutSeconds,omitempty"`
+}
+
+// NamespaceRules are the guardrails of the DeschedulingRule objects namespace owners create
+type NamespaceRules struct {
+	// AllowedStrategies rules can enable, among PodLifeTime and RemovePodsHavingTooManyRestarts, both by default
+	AllowedStrategies []StrategyName `json:"allowedStrategies,omitempty"`
+	// Namespaces whose rules are honored, all by default
+	Namespaces *Namespaces `json:"namespaces,omitempty"`
+	// NamespaceSelector restricts the honored rules to the namespaces matching it
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// MinPodLifeTimeSeconds is the lowest maxPodLifeTimeSeconds a rule can set
+	MinPodLifeTimeSeconds *uint `json:"minPodLifeTimeSeconds,omitempty"`
+	// MinPodRestartThreshold is the lowest podRestartThreshold a rule can set
+	MinPodRestartThreshold *int32 `json:"minPodRestartThreshold,omitempty"`
+}
+
+// EvictedPodAnnotations configures the annotations set on pods before they are evicted: the strategy, the cause,
+// the descheduling cycle and the reason of the eviction
+type EvictedPodAnnotations struct {
+	// Labels also sets the strategy, the cause and the cycle as labels, e.g. to select the pods in log pipelines
+	Labels bool `json:"labels,omitempty"`
+}
+
+// EvictionHistory configures the ConfigMap the eviction history is stored in
+type EvictionHistory struct {
+	// Namespace of the ConfigMap, kube-system by default
+	Namespace string `json:"namespace,omitempty"`
+	// Name of the ConfigMap, descheduler-history by default
+	Name string `json:"name,omitempty"`
+	// MaxCycles is the number of descheduling cycles retained, 10 by default
+	MaxCycles int `json:"maxCycles,omitempty"`
+}
+
+// PrometheusUtilization configures the Prometheus server and queries the usage of pods is read from
+type PrometheusUtilization struct {
+	// URL of the Prometheus server, e.g. http://prometheus:9090
+	URL string `json:"url"`
+	// BearerTokenFile is the file of the token authenticating the requests, unauthenticated when empty
+	BearerTokenFile string `json:"bearerTokenFile,omitempty"`
+	// TimeoutSeconds bounds the duration of a query, 30 by default
+	TimeoutSeconds *uint `json:"timeoutSeconds,omitempty"`
+	// WindowSeconds is the lookback window usages are aggregated over, 1800 by default
+	WindowSeconds *uint `json:"windowSeconds,omitempty"`
+	// Aggregation is how the default queries aggregate usages over the window: Avg, Max or P95 (the 95th
+	// percentile), Avg by default. Custom queries aggregate usages themselves.
+	Aggregation string `json:"aggregation,omitempty"`
+	// CPUQuery returns the CPU usage, in cores, of containers with namespace, pod and container labels. $window is
+	// replaced by the lookback window. Defaults to the rate of container_cpu_usage_seconds_total over the window.
+	CPUQuery string `json:"cpuQuery,omitempty"`
+	// MemoryQuery returns the memory usage, in bytes, of containers with namespace, pod and container labels. $window
+	// is replaced by the lookback window. Defaults to the average of container_memory_working_set_bytes over the window.
+	MemoryQuery string `json:"memoryQuery,omitempty"`
+}
+
+// UsageTrend configures the linear trend the actual usage of nodes is projected with
+type UsageTrend struct {
+	// HorizonSeconds is how far ahead the usage of nodes is projected
+	HorizonSeconds uint `json:"horizonSeconds"`
+	// WindowSeconds is the lookback window of the usages, sampled at every descheduling cycle, the trend is fitted
+	// on, 3600 by default
+	WindowSeconds *uint `json:"windowSeconds,omitempty"`
+	// MinSamples is the number of samples within the window required to project the usage of a node, 3 by default
+	MinSamples *uint `json:"minSamples,omitempty"`
+	// Resources are the resources whose usage is projected, memory by default
+	Resources []v1.ResourceName `json:"resources,omitempty"`
+}
+
+// CooldownPersistence configures the object the evictions of the pod eviction cooldown are stored in
+type CooldownPersistence struct {
+	// Kind of the object, ConfigMap (stored in its data) or Lease (stored in an annotation), ConfigMap by default
+	Kind string `json:"kind,omitempty"`
+	// Namespace of the object, kube-system by default
+	Namespace string `json:"namespace,omitempty"`
+	// Name of the object, descheduler-cooldown by default
+	Name string `json:"name,omitempty"`
+}
+
+// ProtectedPodCondition is a pod condition protecting the pods having it from evictions
+type ProtectedPodCondition struct {
+	Type v1.PodConditionType `json:"type"`
+	// Status is the status of the condition protecting pods, True by default
+	Status v1.ConditionStatus `json:"status,omitempty"`
+}
+
+type StrategyName string
+type StrategyList map[StrategyName]DeschedulerStrategy
+
+type DeschedulerStrategy struct {
+	// Enabled or disabled
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Weight
+	Weight int `json:"weight,omitempty"`
+
+	// DryRun only logs evictions of the strategy, regardless of the global dry run mode
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// LogVerbosity overrides the log verbosity while the strategy runs
+	LogVerbosity *int32 `json:"logVerbosity,omitempty"`
+
+	// TimeoutSeconds is the runtime budget of the strategy, once exceeded no further pod is evicted by the strategy
+	TimeoutSeconds *uint `json:"timeoutSeconds,omitempty"`
+
+	// EvictionLimits caps and paces the evictions of the strategy during a descheduling cycle
+	EvictionLimits *EvictionLimits `json:"evictionLimits,omitempty"`
+
+	// Strategy parameters
+	Params *StrategyParameters `json:"params,omitempty"`
+}
+
+// Namespaces carries a list of included/excluded namespaces
+// for which a given strategy is applicable.
+type Namespaces struct {
+	Include []string `json:"include"`
+	Exclude []string `json:"exclude"`
+}
+
+// Besides Namespaces ThresholdPriority and ThresholdPriorityClassName only one of its members may be specified
+type StrategyParameters struct {
+	NodeResourceUtilizationThresholds *NodeResourceUtilizationThresholds `json:"nodeResourceUtilizationThresholds,omitempty"`
+	NodeAffinityType                  []string                           `json:"nodeAffinityType,omitempty"`
+	PodsHavingTooManyRestarts         *PodsHavingTooManyRestarts         `json:"podsHavingTooManyRestarts,omitempty"`
+	PodLifeTime                       *PodLifeTime                       `json:"podLifeTime,omitempty"`
+	RemoveDuplicates                  *RemoveDuplicates                  `json:"removeDuplicates,omitempty"`
+	FailedPods                        *FailedPods                        `json:"failedPods,omitempty"`
+	AntiColocation                    *AntiColocation                    `json:"antiColocation,omitempty"`
+	PodDensity                        *PodDensity                        `json:"podDensity,omitempty"`
+	NodeProblems                      *NodeProblems                      `json:"nodeProblems,omitempty"`
+	LimitsOvercommit                  *LimitsOvercommit                  `json:"limitsOvercommit,omitempty"`
+	FinishedJobPods                   *FinishedJobPods                   `json:"finishedJobPods,omitempty"`
+	MemoryOverrun                     *MemoryOverrun                     `json:"memoryOverrun,omitempty"`
+	NodeInterruption                  *NodeInterruption                  `json:"nodeInterruption,omitempty"`
+	StuckPods                         *StuckPods                         `json:"stuckPods,omitempty"`
+	ImageLocality                     *ImageLocality                     `json:"imageLocality,omitempty"`
+	NotReadyNodes                     *NotReadyNodes                     `json:"notReadyNodes,omitempty"`
+	GPUModelPreference                *GPUModelPreference                `json:"gpuModelPreference,omitempty"`
+	TerminatingPods                   *TerminatingPods                   `json:"terminatingPods,omitempty"`
+	RestartChurn                      *RestartChurn                      `json:"restartChurn,omitempty"`
+	IncludeSoftConstraints            bool                               `json:"includeSoftConstraints"`
+	MaxSkewReductionPerCycle          int32                              `json:"maxSkewReductionPerCycle,omitempty"`
+	// TopologyBalanceDomains restricts RemovePodsViolatingTopologySpreadConstraint to the constraints of these
+	// topology keys, e.g. a rack or chassis node label, all keys by default
+	TopologyBalanceDomains []string `json:"topologyBalanceDomains,omitempty"`
+	Namespaces                        *Namespaces                        `json:"namespaces"`
+	// NamespaceSelector restricts the strategy to the namespaces matching it, besides Namespaces
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	ThresholdPriority                 *int32                             `json:"thresholdPriority"`
+	ThresholdPriorityClassName        string                             `json:"thresholdPriorityClassName"`
+	LabelSelector                     *metav1.LabelSelector              `json:"labelSelector"`
+	NodeFit                           bool                               `json:"nodeFit"`
+	IncludeCordonedNodes              *bool                              `json:"includeCordonedNodes,omitempty"`
+	NodeRoles                         []string                              `json:"nodeRoles,omitempty"`
+	// PluginArgs is the configuration of a strategy compiled in as a plugin, decoded by the plugin
+	PluginArgs *runtime.RawExtension `json:"pluginArgs,omitempty"`
+}
+
+type Percentage float64
+type ResourceThresholds map[v1.ResourceName]Percentage
+
+type NodeResourceUtilizationThresholds struct {
+	Thresholds       ResourceThresholds `json:"thresholds,omitempty"`
+	TargetThresholds ResourceThresholds `json:"targetThresholds,omitempty"`
+	NumberOfNodes    int                `json:"numberOfNodes,omitempty"`
+	// WarningThresholds only report nodes crossing them (through logs and metrics),
+	// no pod is evicted because of them.
+	WarningThresholds ResourceThresholds `json:"warningThresholds,omitempty"`
+	// ExcludedContainers lists container name patterns (e.g. "istio-proxy" or "*-sidecar")
+	// whose requests are not counted towards node utilization.
+	ExcludedContainers []string `json:"excludedContainers,omitempty"`
+	// ThresholdsOperator decides whether any ("Or", the default) or all ("And") configured
+	// resources have to exceed targetThresholds for a node to be considered overutilized.
+	ThresholdsOperator string `json:"thresholdsOperator,omitempty"`
+	// TieBreaker orders nodes with the same utilization: "Name" (the default),
+	// "CreationTimestamp" (oldest first) or "Random" (shuffled with TieBreakerSeed).
+	TieBreaker     string `json:"tieBreaker,omitempty"`
+	TieBreakerSeed int64  `json:"tieBreakerSeed,omitempty"`
+	// Hysteresis is a margin, in percents of the node capacity, by which a node usage has to
+	// exceed targetThresholds to be overutilized, or be below thresholds to be underutilized.
+	Hysteresis Percentage `json:"hysteresis,omitempty"`
+	// PriorityBands are balanced by LowNodeUtilization after the thresholds above, each band with
+	// its own thresholds and node usages only counting the pods of the band.
+	PriorityBands []PriorityBand `json:"priorityBands,omitempty"`
+	// DestinationScorer weights the resources destination nodes can still take by their score:
+	// "LeastAllocated" or "Annotation" (see the destination-score node annotation).
+	DestinationScorer string `json:"destinationScorer,omitempty"`
+	// ThresholdsUnits decides how thresholds which look like fractions of the node capacity (all within
+	// [0, 1], e.g. 0.2) are handled: refused with a suggestion ("Strict", the default) or converted to
+	// percentages ("Lenient").
+	ThresholdsUnits string `json:"thresholdsUnits,omitempty"`
+	// BalancingDomain is a node label key, e.g. topology.kubernetes.io/zone, splitting nodes into groups
+	// balanced independently: pods are only moved between nodes with the same value of the label.
+	BalancingDomain string `json:"balancingDomain,omitempty"`
+	// TopologyKey is an alias of BalancingDomain, named after the topologyKey of pod affinity terms.
+	TopologyKey string `json:"topologyKey,omitempty"`
+	// MinFreeCapacityPercent is the share of the capacity of the schedulable nodes, in percents, HighNodeUtilization
+	// keeps free for every resource: underutilized nodes are not drained when the nodes left would have less headroom.
+	MinFreeCapacityPercent Percentage `json:"minFreeCapacityPercent,omitempty"`
+	// RelieveResources are the resources LowNodeUtilization evicts pods for: once an overutilized node is below
+	// targetThresholds for all of them, no more pods are evicted from it. Defaults to all configured resources.
+	RelieveResources []v1.ResourceName `json:"relieveResources,omitempty"`
+	// AnnotateNodes records how the strategy classifies every node, and since when, in a node annotation.
+	AnnotateNodes bool `json:"annotateNodes,omitempty"`
+	// ExcludeDaemonSetPods ignores the requests of DaemonSet pods, which run on every node anyway, when computing
+	// node usages, so thresholds only reflect the load which can be moved.
+	ExcludeDaemonSetPods bool `json:"excludeDaemonSetPods,omitempty"`
+	// Parallelism is the number of nodes whose pods are listed and aggregated concurrently when computing
+	// node usages. Defaults to 16.
+	Parallelism int `json:"parallelism,omitempty"`
+	// UtilizationMetric decides whether node usages sum the requests ("Requests", the default) or the limits
+	// ("Limits") of their pods, for clusters overcommitting nodes on limits. Containers without a limit count their request.
+	UtilizationMetric string `json:"utilizationMetric,omitempty"`
+	// MetricsUtilization accounts pods for their actual CPU and memory usage, as reported by the metrics API, instead
+	// of their requests. Pods without metrics, or all pods when the metrics API is not available, count their requests.
+	MetricsUtilization bool `json:"metricsUtilization,omitempty"`
+	// Prometheus accounts pods for their average CPU and memory usage over a lookback window, as returned by queries
+	// to a Prometheus server, instead of their requests, so nodes are classified by their sustained load. Pods without
+	// usage, or all pods when the queries fail, count their requests. Can not be combined with MetricsUtilization.
+	Prometheus *PrometheusUtilization `json:"prometheus,omitempty"`
+	// Trend projects the actual usage of nodes, read through MetricsUtilization or Prometheus, with a linear trend:
+	// LowNodeUtilization treats nodes whose usage is projected above targetThresholds within the horizon as
+	// overutilized, so load is moved before the kubelet evicts pods under memory pressure.
+	Trend *UsageTrend `json:"trend,omitempty"`
+	// CountTerminatingPods decides whether the requests of terminating pods, which still hold their share of the node
+	// until they are gone, are counted in node usages. Defaults to true.
+	CountTerminatingPods *bool `json:"countTerminatingPods,omitempty"`
+	// CountNominatedPods decides whether pending pods nominated to a node, e.g. preemptors waiting for their victims
+	// to terminate, are counted in the usage of the node they are about to land on
+	CountNominatedPods bool `json:"countNominatedPods,omitempty"`
+	// MinPodsOnSourceNode is the number of pods HighNodeUtilization requires on an underutilized node to drain it:
+	// nodes running fewer, but possibly very large, pods are not worth or able to be consolidated.
+	MinPodsOnSourceNode int `json:"minPodsOnSourceNode,omitempty"`
+	// UnschedulableNodesAsSources decides whether HighNodeUtilization drains unschedulable nodes whatever their usage
+	// (true), never drains them (false), or classifies them by usage like other nodes (unset). When true, cordoned
+	// nodes are included unless includeCordonedNodes is false.
+	UnschedulableNodesAsSources *bool `json:"unschedulableNodesAsSources,omitempty"`
+}
+
+// PriorityBand selects the pods whose priority is at least MinPriority and at most MaxPriority,
+// an unset bound is not checked and pods without priority have a priority of 0.
+type PriorityBand struct {
+	MinPriority      *int32             `json:"minPriority,omitempty"`
+	MaxPriority      *int32             `json:"maxPriority,omitempty"`
+	Thresholds       ResourceThresholds `json:"thresholds,omitempty"`
+	TargetThresholds ResourceThresholds `json:"targetThresholds,omitempty"`
+}
+
+type PodsHavingTooManyRestarts struct {
+	PodRestartThreshold     int32 `json:"podRestartThreshold,omitempty"`
+	IncludingInitContainers bool  `json:"includingInitContainers,omitempty"`
+}
+
+type RemoveDuplicates struct {
+	ExcludeOwnerKinds []string `json:"excludeOwnerKinds,omitempty"`
+}
+
+type PodLifeTime struct {
+	MaxPodLifeTimeSeconds *uint    `json:"maxPodLifeTimeSeconds,omitempty"`
+	PodStatusPhases       []string `json:"podStatusPhases,omitempty"`
+}
+
+type FailedPods struct {
+	ExcludeOwnerKinds       []string `json:"excludeOwnerKinds,omitempty"`
+	MinPodLifetimeSeconds   *uint    `json:"minPodLifetimeSeconds,omitempty"`
+	Reasons                 []string `json:"reasons,omitempty"`
+	IncludingInitContainers bool     `json:"includingInitContainers,omitempty"`
+}
+
+type AntiColocation struct {
+	Pairs []AntiColocationPair `json:"pairs,omitempty"`
+}
+
+// AntiColocationPair describes two sets of pods which must not share a node.
+// Pods matching Evict are evicted from nodes which also run pods matching Keep.
+type AntiColocationPair struct {
+	Keep  *metav1.LabelSelector `json:"keep,omitempty"`
+	Evict *metav1.LabelSelector `json:"evict,omitempty"`
+}
+
+// PodDensity limits the number of pods matching LabelSelector running in a single
+// topology domain. Pods are counted per namespace.
+type PodDensity struct {
+	TopologyKey      string                `json:"topologyKey,omitempty"`
+	MaxPodsPerDomain int                   `json:"maxPodsPerDomain,omitempty"`
+	LabelSelector    *metav1.LabelSelector `json:"labelSelector,omitempty"`
+}
+
+// NodeProblems lists the node conditions, usually reported by the Node Problem Detector,
+// marking nodes whose pods are evicted. MaxPodsToEvictPerCycle limits the evictions
+// per descheduling cycle, 0 meaning no limit.
+type NodeProblems struct {
+	Conditions             []string `json:"conditions,omitempty"`
+	MaxPodsToEvictPerCycle int      `json:"maxPodsToEvictPerCycle,omitempty"`
+}
+
+// LimitsOvercommit sets, per resource, the maximum sum of the limits of the pods running on a node,
+// in percents of the node allocatable. Thresholds can be above 100, only cpu and memory are supported.
+type LimitsOvercommit struct {
+	Thresholds ResourceThresholds `json:"thresholds,omitempty"`
+}
+
+// FinishedJobPods sets how long the pods of a completed or failed Job are kept after the Job finished.
+// Jobs owned by one of ExcludeOwnerKinds, e.g. CronJob, are not processed.
+type FinishedJobPods struct {
+	TTLSecondsAfterFinished *uint    `json:"ttlSecondsAfterFinished,omitempty"`
+	ExcludeOwnerKinds       []string `json:"excludeOwnerKinds,omitempty"`
+}
+
+// MemoryOverrun sets when pods using more memory than they request are evicted: their usage must exceed
+// their requests by more than ExcessPercentage for at least DurationSeconds, on a node reporting memory
+// pressure or whose pods use more than NodeUsageThreshold percents of its allocatable memory.
+// MaxPodsToEvictPerNode limits the number of offenders evicted from a node per run, 0 means no limit.
+type MemoryOverrun struct {
+	ExcessPercentage      Percentage `json:"excessPercentage,omitempty"`
+	DurationSeconds       uint       `json:"durationSeconds,omitempty"`
+	NodeUsageThreshold    Percentage `json:"nodeUsageThreshold,omitempty"`
+	MaxPodsToEvictPerNode int        `json:"maxPodsToEvictPerNode,omitempty"`
+}
+
+// NodeInterruption lists the keys of the node taints and labels signaling that a node is about to be
+// interrupted, usually set on spot or preemptible nodes by the cloud provider or a termination handler.
+type NodeInterruption struct {
+	Taints []string `json:"taints,omitempty"`
+	Labels []string `json:"labels,omitempty"`
+}
+
+// StuckPods configures the eviction of pods whose containers are stuck being created on their node,
+// usually because of node-specific CNI or CSI issues such as failed volume mounts.
+type StuckPods struct {
+	MaxStuckSeconds *uint    `json:"maxStuckSeconds,omitempty"`
+	Reasons         []string `json:"reasons,omitempty"`
+}
+
+// ImageLocality configures the consolidation of pods of image-heavy workloads onto nodes already caching their images.
+type ImageLocality struct {
+	// MinImageSizeMB is the size from which images are considered heavy, 1000 by default
+	MinImageSizeMB *uint `json:"minImageSizeMB,omitempty"`
+}
+
+// NotReadyNodes configures the eviction of pods stranded on nodes which are NotReady, but not tainted by the node
+// lifecycle controller, e.g. because it is delayed or disabled.
+type NotReadyNodes struct {
+	// NotReadySeconds is the time after which pods are evicted from a NotReady node, 300 by default
+	NotReadySeconds *uint `json:"notReadySeconds,omitempty"`
+	// ForceDeleteAfterSeconds is the time after which pods still terminating on a NotReady node are force deleted,
+	// pods are never force deleted when unset or 0
+	ForceDeleteAfterSeconds *uint `json:"forceDeleteAfterSeconds,omitempty"`
+}
+
+// GPUModelPreference configures the eviction of GPU pods from nodes of GPU models they do not prefer, so they move to
+// nodes of the models they prefer, e.g. inference pods off the models needed for training.
+type GPUModelPreference struct {
+	// ModelLabel is the node label holding the GPU model, nvidia.com/gpu.product by default
+	ModelLabel string `json:"modelLabel,omitempty"`
+	// ResourceName is the extended resource of GPUs, nvidia.com/gpu by default
+	ResourceName string `json:"resourceName,omitempty"`
+	// ModelPriorities rank GPU models for the pods they select, the first entry selecting a pod applies. Pods
+	// selected by no entry are ranked by the weights of their preferred node affinity terms on the model label.
+	ModelPriorities []GPUModelPriority `json:"modelPriorities,omitempty"`
+}
+
+// GPUModelPriority ranks GPU models for the pods it selects
+type GPUModelPriority struct {
+	// PodSelector selects the pods the models are ranked for, all pods when unset
+	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
+	// Models are the GPU models, most preferred first. Nodes of other models rank last.
+	Models []string `json:"models,omitempty"`
+}
+
+// TerminatingPods configures the removal of pods stuck terminating on NotReady nodes, whose kubelet can not confirm
+// their deletion.
+type TerminatingPods struct {
+	// MaxTerminatingSeconds is the time after their deletion timestamp after which pods are stuck, 600 by default
+	MaxTerminatingSeconds *uint `json:"maxTerminatingSeconds,omitempty"`
+	// ForceDelete opts in to force deleting stuck pods, without grace period, instead of evicting them again
+	ForceDelete bool `json:"forceDelete,omitempty"`
+}
+
+// RestartChurn configures the eviction of the pods restarting the most from nodes whose containers restart too often,
+// a symptom of node-specific issues such as a bad kernel or a broken CNI.
+type RestartChurn struct {
+	// NodeRestartThreshold is the number of container restarts of the pods of a node within the window from which
+	// the node churns
+	NodeRestartThreshold int32 `json:"nodeRestartThreshold,omitempty"`
+	// WindowSeconds is the time window restarts are counted in, 3600 by default
+	WindowSeconds *uint `json:"windowSeconds,omitempty"`
+	IncludingInitContainers bool `json:"includingInitContainers,omitempty"`
+	// MaxPodsToEvictPerNode limits the pods evicted from a churning node per descheduling cycle, 0 for no limit
+	MaxPodsToEvictPerNode int `json:"maxPodsToEvictPerNode,omitempty"`
+}
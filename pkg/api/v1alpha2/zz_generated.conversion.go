@@ -0,0 +1,1545 @@
+// +build !ignore_autogenerated
+
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by conversion-gen. DO NOT EDIT.
+
+package v1alpha2
+
+import (
+	unsafe "unsafe"
+
+	corev1 "k8s.io/api/core/v1"
+	resource "k8s.io/apimachinery/pkg/api/resource"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	conversion "k8s.io/apimachinery/pkg/conversion"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	api "sigs.k8s.io/descheduler/pkg/api"
+)
+
+func init() {
+	localSchemeBuilder.Register(RegisterConversions)
+}
+
+// RegisterConversions adds conversion functions to the given scheme.
+// Public to allow building arbitrary schemes.
+func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*AntiColocation)(nil), (*api.AntiColocation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_AntiColocation_To_api_AntiColocation(a.(*AntiColocation), b.(*api.AntiColocation), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.AntiColocation)(nil), (*AntiColocation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_AntiColocation_To_v1alpha2_AntiColocation(a.(*api.AntiColocation), b.(*AntiColocation), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*AntiColocationPair)(nil), (*api.AntiColocationPair)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_AntiColocationPair_To_api_AntiColocationPair(a.(*AntiColocationPair), b.(*api.AntiColocationPair), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.AntiColocationPair)(nil), (*AntiColocationPair)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_AntiColocationPair_To_v1alpha2_AntiColocationPair(a.(*api.AntiColocationPair), b.(*AntiColocationPair), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CooldownPersistence)(nil), (*api.CooldownPersistence)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_CooldownPersistence_To_api_CooldownPersistence(a.(*CooldownPersistence), b.(*api.CooldownPersistence), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.CooldownPersistence)(nil), (*CooldownPersistence)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_CooldownPersistence_To_v1alpha2_CooldownPersistence(a.(*api.CooldownPersistence), b.(*CooldownPersistence), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CycleAbort)(nil), (*api.CycleAbort)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_CycleAbort_To_api_CycleAbort(a.(*CycleAbort), b.(*api.CycleAbort), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.CycleAbort)(nil), (*CycleAbort)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_CycleAbort_To_v1alpha2_CycleAbort(a.(*api.CycleAbort), b.(*CycleAbort), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DeschedulerPolicy)(nil), (*api.DeschedulerPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_DeschedulerPolicy_To_api_DeschedulerPolicy(a.(*DeschedulerPolicy), b.(*api.DeschedulerPolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DeschedulerProfile)(nil), (*api.DeschedulerProfile)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_DeschedulerProfile_To_api_DeschedulerProfile(a.(*DeschedulerProfile), b.(*api.DeschedulerProfile), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.DeschedulerProfile)(nil), (*DeschedulerProfile)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_DeschedulerProfile_To_v1alpha2_DeschedulerProfile(a.(*api.DeschedulerProfile), b.(*DeschedulerProfile), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DeschedulerStrategy)(nil), (*api.DeschedulerStrategy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_DeschedulerStrategy_To_api_DeschedulerStrategy(a.(*DeschedulerStrategy), b.(*api.DeschedulerStrategy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.DeschedulerStrategy)(nil), (*DeschedulerStrategy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_DeschedulerStrategy_To_v1alpha2_DeschedulerStrategy(a.(*api.DeschedulerStrategy), b.(*DeschedulerStrategy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*EvictedPodAnnotations)(nil), (*api.EvictedPodAnnotations)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_EvictedPodAnnotations_To_api_EvictedPodAnnotations(a.(*EvictedPodAnnotations), b.(*api.EvictedPodAnnotations), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.EvictedPodAnnotations)(nil), (*EvictedPodAnnotations)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_EvictedPodAnnotations_To_v1alpha2_EvictedPodAnnotations(a.(*api.EvictedPodAnnotations), b.(*EvictedPodAnnotations), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*EvictionHistory)(nil), (*api.EvictionHistory)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_EvictionHistory_To_api_EvictionHistory(a.(*EvictionHistory), b.(*api.EvictionHistory), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.EvictionHistory)(nil), (*EvictionHistory)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_EvictionHistory_To_v1alpha2_EvictionHistory(a.(*api.EvictionHistory), b.(*EvictionHistory), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*EvictionLimits)(nil), (*api.EvictionLimits)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_EvictionLimits_To_api_EvictionLimits(a.(*EvictionLimits), b.(*api.EvictionLimits), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.EvictionLimits)(nil), (*EvictionLimits)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_EvictionLimits_To_v1alpha2_EvictionLimits(a.(*api.EvictionLimits), b.(*EvictionLimits), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*FailedPods)(nil), (*api.FailedPods)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_FailedPods_To_api_FailedPods(a.(*FailedPods), b.(*api.FailedPods), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.FailedPods)(nil), (*FailedPods)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_FailedPods_To_v1alpha2_FailedPods(a.(*api.FailedPods), b.(*FailedPods), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*FinishedJobPods)(nil), (*api.FinishedJobPods)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_FinishedJobPods_To_api_FinishedJobPods(a.(*FinishedJobPods), b.(*api.FinishedJobPods), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.FinishedJobPods)(nil), (*FinishedJobPods)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_FinishedJobPods_To_v1alpha2_FinishedJobPods(a.(*api.FinishedJobPods), b.(*FinishedJobPods), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*GPUModelPreference)(nil), (*api.GPUModelPreference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_GPUModelPreference_To_api_GPUModelPreference(a.(*GPUModelPreference), b.(*api.GPUModelPreference), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.GPUModelPreference)(nil), (*GPUModelPreference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_GPUModelPreference_To_v1alpha2_GPUModelPreference(a.(*api.GPUModelPreference), b.(*GPUModelPreference), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*GPUModelPriority)(nil), (*api.GPUModelPriority)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_GPUModelPriority_To_api_GPUModelPriority(a.(*GPUModelPriority), b.(*api.GPUModelPriority), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.GPUModelPriority)(nil), (*GPUModelPriority)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_GPUModelPriority_To_v1alpha2_GPUModelPriority(a.(*api.GPUModelPriority), b.(*GPUModelPriority), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*HealthGates)(nil), (*api.HealthGates)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_HealthGates_To_api_HealthGates(a.(*HealthGates), b.(*api.HealthGates), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.HealthGates)(nil), (*HealthGates)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_HealthGates_To_v1alpha2_HealthGates(a.(*api.HealthGates), b.(*HealthGates), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ImageLocality)(nil), (*api.ImageLocality)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_ImageLocality_To_api_ImageLocality(a.(*ImageLocality), b.(*api.ImageLocality), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.ImageLocality)(nil), (*ImageLocality)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_ImageLocality_To_v1alpha2_ImageLocality(a.(*api.ImageLocality), b.(*ImageLocality), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*LimitsOvercommit)(nil), (*api.LimitsOvercommit)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_LimitsOvercommit_To_api_LimitsOvercommit(a.(*LimitsOvercommit), b.(*api.LimitsOvercommit), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.LimitsOvercommit)(nil), (*LimitsOvercommit)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_LimitsOvercommit_To_v1alpha2_LimitsOvercommit(a.(*api.LimitsOvercommit), b.(*LimitsOvercommit), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*MemoryOverrun)(nil), (*api.MemoryOverrun)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_MemoryOverrun_To_api_MemoryOverrun(a.(*MemoryOverrun), b.(*api.MemoryOverrun), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.MemoryOverrun)(nil), (*MemoryOverrun)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_MemoryOverrun_To_v1alpha2_MemoryOverrun(a.(*api.MemoryOverrun), b.(*MemoryOverrun), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NamespaceRules)(nil), (*api.NamespaceRules)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_NamespaceRules_To_api_NamespaceRules(a.(*NamespaceRules), b.(*api.NamespaceRules), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.NamespaceRules)(nil), (*NamespaceRules)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_NamespaceRules_To_v1alpha2_NamespaceRules(a.(*api.NamespaceRules), b.(*NamespaceRules), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*Namespaces)(nil), (*api.Namespaces)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_Namespaces_To_api_Namespaces(a.(*Namespaces), b.(*api.Namespaces), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.Namespaces)(nil), (*Namespaces)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_Namespaces_To_v1alpha2_Namespaces(a.(*api.Namespaces), b.(*Namespaces), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NodeDeletionTrigger)(nil), (*api.NodeDeletionTrigger)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_NodeDeletionTrigger_To_api_NodeDeletionTrigger(a.(*NodeDeletionTrigger), b.(*api.NodeDeletionTrigger), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.NodeDeletionTrigger)(nil), (*NodeDeletionTrigger)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_NodeDeletionTrigger_To_v1alpha2_NodeDeletionTrigger(a.(*api.NodeDeletionTrigger), b.(*NodeDeletionTrigger), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NodeInterruption)(nil), (*api.NodeInterruption)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_NodeInterruption_To_api_NodeInterruption(a.(*NodeInterruption), b.(*api.NodeInterruption), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.NodeInterruption)(nil), (*NodeInterruption)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_NodeInterruption_To_v1alpha2_NodeInterruption(a.(*api.NodeInterruption), b.(*NodeInterruption), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NodeProblems)(nil), (*api.NodeProblems)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_NodeProblems_To_api_NodeProblems(a.(*NodeProblems), b.(*api.NodeProblems), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.NodeProblems)(nil), (*NodeProblems)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_NodeProblems_To_v1alpha2_NodeProblems(a.(*api.NodeProblems), b.(*NodeProblems), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NodeResourceUtilizationThresholds)(nil), (*api.NodeResourceUtilizationThresholds)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_NodeResourceUtilizationThresholds_To_api_NodeResourceUtilizationThresholds(a.(*NodeResourceUtilizationThresholds), b.(*api.NodeResourceUtilizationThresholds), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.NodeResourceUtilizationThresholds)(nil), (*NodeResourceUtilizationThresholds)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_NodeResourceUtilizationThresholds_To_v1alpha2_NodeResourceUtilizationThresholds(a.(*api.NodeResourceUtilizationThresholds), b.(*NodeResourceUtilizationThresholds), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NotReadyNodes)(nil), (*api.NotReadyNodes)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_NotReadyNodes_To_api_NotReadyNodes(a.(*NotReadyNodes), b.(*api.NotReadyNodes), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.NotReadyNodes)(nil), (*NotReadyNodes)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_NotReadyNodes_To_v1alpha2_NotReadyNodes(a.(*api.NotReadyNodes), b.(*NotReadyNodes), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PodDensity)(nil), (*api.PodDensity)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_PodDensity_To_api_PodDensity(a.(*PodDensity), b.(*api.PodDensity), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.PodDensity)(nil), (*PodDensity)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_PodDensity_To_v1alpha2_PodDensity(a.(*api.PodDensity), b.(*PodDensity), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PodFilter)(nil), (*api.PodFilter)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_PodFilter_To_api_PodFilter(a.(*PodFilter), b.(*api.PodFilter), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.PodFilter)(nil), (*PodFilter)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_PodFilter_To_v1alpha2_PodFilter(a.(*api.PodFilter), b.(*PodFilter), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PodLifeTime)(nil), (*api.PodLifeTime)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_PodLifeTime_To_api_PodLifeTime(a.(*PodLifeTime), b.(*api.PodLifeTime), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.PodLifeTime)(nil), (*PodLifeTime)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_PodLifeTime_To_v1alpha2_PodLifeTime(a.(*api.PodLifeTime), b.(*PodLifeTime), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PodsHavingTooManyRestarts)(nil), (*api.PodsHavingTooManyRestarts)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_PodsHavingTooManyRestarts_To_api_PodsHavingTooManyRestarts(a.(*PodsHavingTooManyRestarts), b.(*api.PodsHavingTooManyRestarts), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.PodsHavingTooManyRestarts)(nil), (*PodsHavingTooManyRestarts)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_PodsHavingTooManyRestarts_To_v1alpha2_PodsHavingTooManyRestarts(a.(*api.PodsHavingTooManyRestarts), b.(*PodsHavingTooManyRestarts), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PolicyReports)(nil), (*api.PolicyReports)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_PolicyReports_To_api_PolicyReports(a.(*PolicyReports), b.(*api.PolicyReports), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.PolicyReports)(nil), (*PolicyReports)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_PolicyReports_To_v1alpha2_PolicyReports(a.(*api.PolicyReports), b.(*PolicyReports), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PriorityBand)(nil), (*api.PriorityBand)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_PriorityBand_To_api_PriorityBand(a.(*PriorityBand), b.(*api.PriorityBand), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.PriorityBand)(nil), (*PriorityBand)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_PriorityBand_To_v1alpha2_PriorityBand(a.(*api.PriorityBand), b.(*PriorityBand), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PrometheusUtilization)(nil), (*api.PrometheusUtilization)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_PrometheusUtilization_To_api_PrometheusUtilization(a.(*PrometheusUtilization), b.(*api.PrometheusUtilization), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.PrometheusUtilization)(nil), (*PrometheusUtilization)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_PrometheusUtilization_To_v1alpha2_PrometheusUtilization(a.(*api.PrometheusUtilization), b.(*PrometheusUtilization), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ProtectedPodCondition)(nil), (*api.ProtectedPodCondition)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_ProtectedPodCondition_To_api_ProtectedPodCondition(a.(*ProtectedPodCondition), b.(*api.ProtectedPodCondition), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.ProtectedPodCondition)(nil), (*ProtectedPodCondition)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_ProtectedPodCondition_To_v1alpha2_ProtectedPodCondition(a.(*api.ProtectedPodCondition), b.(*ProtectedPodCondition), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RemoteWrite)(nil), (*api.RemoteWrite)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_RemoteWrite_To_api_RemoteWrite(a.(*RemoteWrite), b.(*api.RemoteWrite), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.RemoteWrite)(nil), (*RemoteWrite)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_RemoteWrite_To_v1alpha2_RemoteWrite(a.(*api.RemoteWrite), b.(*RemoteWrite), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RemoveDuplicates)(nil), (*api.RemoveDuplicates)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_RemoveDuplicates_To_api_RemoveDuplicates(a.(*RemoveDuplicates), b.(*api.RemoveDuplicates), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.RemoveDuplicates)(nil), (*RemoveDuplicates)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_RemoveDuplicates_To_v1alpha2_RemoveDuplicates(a.(*api.RemoveDuplicates), b.(*RemoveDuplicates), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RestartChurn)(nil), (*api.RestartChurn)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_RestartChurn_To_api_RestartChurn(a.(*RestartChurn), b.(*api.RestartChurn), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.RestartChurn)(nil), (*RestartChurn)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_RestartChurn_To_v1alpha2_RestartChurn(a.(*api.RestartChurn), b.(*RestartChurn), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*StrategyParameters)(nil), (*api.StrategyParameters)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_StrategyParameters_To_api_StrategyParameters(a.(*StrategyParameters), b.(*api.StrategyParameters), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.StrategyParameters)(nil), (*StrategyParameters)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_StrategyParameters_To_v1alpha2_StrategyParameters(a.(*api.StrategyParameters), b.(*StrategyParameters), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*StuckPods)(nil), (*api.StuckPods)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_StuckPods_To_api_StuckPods(a.(*StuckPods), b.(*api.StuckPods), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.StuckPods)(nil), (*StuckPods)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_StuckPods_To_v1alpha2_StuckPods(a.(*api.StuckPods), b.(*StuckPods), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*TerminatingPods)(nil), (*api.TerminatingPods)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_TerminatingPods_To_api_TerminatingPods(a.(*TerminatingPods), b.(*api.TerminatingPods), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.TerminatingPods)(nil), (*TerminatingPods)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_TerminatingPods_To_v1alpha2_TerminatingPods(a.(*api.TerminatingPods), b.(*TerminatingPods), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*UsageTrend)(nil), (*api.UsageTrend)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_UsageTrend_To_api_UsageTrend(a.(*UsageTrend), b.(*api.UsageTrend), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.UsageTrend)(nil), (*UsageTrend)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_UsageTrend_To_v1alpha2_UsageTrend(a.(*api.UsageTrend), b.(*UsageTrend), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*api.DeschedulerPolicy)(nil), (*DeschedulerPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_DeschedulerPolicy_To_v1alpha2_DeschedulerPolicy(a.(*api.DeschedulerPolicy), b.(*DeschedulerPolicy), scope)
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func autoConvert_v1alpha2_AntiColocation_To_api_AntiColocation(in *AntiColocation, out *api.AntiColocation, s conversion.Scope) error {
+	out.Pairs = *(*[]api.AntiColocationPair)(unsafe.Pointer(&in.Pairs))
+	return nil
+}
+
+// Convert_v1alpha2_AntiColocation_To_api_AntiColocation is an autogenerated conversion function.
+func Convert_v1alpha2_AntiColocation_To_api_AntiColocation(in *AntiColocation, out *api.AntiColocation, s conversion.Scope) error {
+	return autoConvert_v1alpha2_AntiColocation_To_api_AntiColocation(in, out, s)
+}
+
+func autoConvert_api_AntiColocation_To_v1alpha2_AntiColocation(in *api.AntiColocation, out *AntiColocation, s conversion.Scope) error {
+	out.Pairs = *(*[]AntiColocationPair)(unsafe.Pointer(&in.Pairs))
+	return nil
+}
+
+// Convert_api_AntiColocation_To_v1alpha2_AntiColocation is an autogenerated conversion function.
+func Convert_api_AntiColocation_To_v1alpha2_AntiColocation(in *api.AntiColocation, out *AntiColocation, s conversion.Scope) error {
+	return autoConvert_api_AntiColocation_To_v1alpha2_AntiColocation(in, out, s)
+}
+
+func autoConvert_v1alpha2_AntiColocationPair_To_api_AntiColocationPair(in *AntiColocationPair, out *api.AntiColocationPair, s conversion.Scope) error {
+	out.Keep = (*v1.LabelSelector)(unsafe.Pointer(in.Keep))
+	out.Evict = (*v1.LabelSelector)(unsafe.Pointer(in.Evict))
+	return nil
+}
+
+// Convert_v1alpha2_AntiColocationPair_To_api_AntiColocationPair is an autogenerated conversion function.
+func Convert_v1alpha2_AntiColocationPair_To_api_AntiColocationPair(in *AntiColocationPair, out *api.AntiColocationPair, s conversion.Scope) error {
+	return autoConvert_v1alpha2_AntiColocationPair_To_api_AntiColocationPair(in, out, s)
+}
+
+func autoConvert_api_AntiColocationPair_To_v1alpha2_AntiColocationPair(in *api.AntiColocationPair, out *AntiColocationPair, s conversion.Scope) error {
+	out.Keep = (*v1.LabelSelector)(unsafe.Pointer(in.Keep))
+	out.Evict = (*v1.LabelSelector)(unsafe.Pointer(in.Evict))
+	return nil
+}
+
+// Convert_api_AntiColocationPair_To_v1alpha2_AntiColocationPair is an autogenerated conversion function.
+func Convert_api_AntiColocationPair_To_v1alpha2_AntiColocationPair(in *api.AntiColocationPair, out *AntiColocationPair, s conversion.Scope) error {
+	return autoConvert_api_AntiColocationPair_To_v1alpha2_AntiColocationPair(in, out, s)
+}
+
+func autoConvert_v1alpha2_CooldownPersistence_To_api_CooldownPersistence(in *CooldownPersistence, out *api.CooldownPersistence, s conversion.Scope) error {
+	out.Kind = in.Kind
+	out.Namespace = in.Namespace
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_v1alpha2_CooldownPersistence_To_api_CooldownPersistence is an autogenerated conversion function.
+func Convert_v1alpha2_CooldownPersistence_To_api_CooldownPersistence(in *CooldownPersistence, out *api.CooldownPersistence, s conversion.Scope) error {
+	return autoConvert_v1alpha2_CooldownPersistence_To_api_CooldownPersistence(in, out, s)
+}
+
+func autoConvert_api_CooldownPersistence_To_v1alpha2_CooldownPersistence(in *api.CooldownPersistence, out *CooldownPersistence, s conversion.Scope) error {
+	out.Kind = in.Kind
+	out.Namespace = in.Namespace
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_api_CooldownPersistence_To_v1alpha2_CooldownPersistence is an autogenerated conversion function.
+func Convert_api_CooldownPersistence_To_v1alpha2_CooldownPersistence(in *api.CooldownPersistence, out *CooldownPersistence, s conversion.Scope) error {
+	return autoConvert_api_CooldownPersistence_To_v1alpha2_CooldownPersistence(in, out, s)
+}
+
+func autoConvert_v1alpha2_CycleAbort_To_api_CycleAbort(in *CycleAbort, out *api.CycleAbort, s conversion.Scope) error {
+	out.MaxErrorRatio = in.MaxErrorRatio
+	out.MinEvictionAttempts = (*uint)(unsafe.Pointer(in.MinEvictionAttempts))
+	out.MaxBackoffSeconds = (*uint)(unsafe.Pointer(in.MaxBackoffSeconds))
+	return nil
+}
+
+// Convert_v1alpha2_CycleAbort_To_api_CycleAbort is an autogenerated conversion function.
+func Convert_v1alpha2_CycleAbort_To_api_CycleAbort(in *CycleAbort, out *api.CycleAbort, s conversion.Scope) error {
+	return autoConvert_v1alpha2_CycleAbort_To_api_CycleAbort(in, out, s)
+}
+
+func autoConvert_api_CycleAbort_To_v1alpha2_CycleAbort(in *api.CycleAbort, out *CycleAbort, s conversion.Scope) error {
+	out.MaxErrorRatio = in.MaxErrorRatio
+	out.MinEvictionAttempts = (*uint)(unsafe.Pointer(in.MinEvictionAttempts))
+	out.MaxBackoffSeconds = (*uint)(unsafe.Pointer(in.MaxBackoffSeconds))
+	return nil
+}
+
+// Convert_api_CycleAbort_To_v1alpha2_CycleAbort is an autogenerated conversion function.
+func Convert_api_CycleAbort_To_v1alpha2_CycleAbort(in *api.CycleAbort, out *CycleAbort, s conversion.Scope) error {
+	return autoConvert_api_CycleAbort_To_v1alpha2_CycleAbort(in, out, s)
+}
+
+func autoConvert_v1alpha2_DeschedulerPolicy_To_api_DeschedulerPolicy(in *DeschedulerPolicy, out *api.DeschedulerPolicy, s conversion.Scope) error {
+	out.Profiles = *(*[]api.DeschedulerProfile)(unsafe.Pointer(&in.Profiles))
+	out.EvictLocalStoragePods = (*bool)(unsafe.Pointer(in.EvictLocalStoragePods))
+	out.EvictableEmptyDirSizeLimit = (*resource.Quantity)(unsafe.Pointer(in.EvictableEmptyDirSizeLimit))
+	out.EvictSystemCriticalPods = (*bool)(unsafe.Pointer(in.EvictSystemCriticalPods))
+	out.IgnorePVCPods = (*bool)(unsafe.Pointer(in.IgnorePVCPods))
+	out.HealthGates = (*api.HealthGates)(unsafe.Pointer(in.HealthGates))
+	out.ReschedulingHints = (*bool)(unsafe.Pointer(in.ReschedulingHints))
+	out.EvictionHistory = (*api.EvictionHistory)(unsafe.Pointer(in.EvictionHistory))
+	out.PolicyReports = (*api.PolicyReports)(unsafe.Pointer(in.PolicyReports))
+	out.RemoteWrite = (*api.RemoteWrite)(unsafe.Pointer(in.RemoteWrite))
+	out.ReplacementReadinessTimeoutSeconds = (*uint)(unsafe.Pointer(in.ReplacementReadinessTimeoutSeconds))
+	out.VolumeDetachTimeoutSeconds = (*uint)(unsafe.Pointer(in.VolumeDetachTimeoutSeconds))
+	out.PodEvictionCooldownSeconds = (*uint)(unsafe.Pointer(in.PodEvictionCooldownSeconds))
+	out.CooldownPersistence = (*api.CooldownPersistence)(unsafe.Pointer(in.CooldownPersistence))
+	out.ProtectedDeployments = *(*[]string)(unsafe.Pointer(&in.ProtectedDeployments))
+	out.InteractiveSessionLookbackSeconds = (*uint)(unsafe.Pointer(in.InteractiveSessionLookbackSeconds))
+	out.ProtectedPodConditions = *(*[]api.ProtectedPodCondition)(unsafe.Pointer(&in.ProtectedPodConditions))
+	out.NodeDeletionTrigger = (*api.NodeDeletionTrigger)(unsafe.Pointer(in.NodeDeletionTrigger))
+	out.EvictionFilter = (*api.PodFilter)(unsafe.Pointer(in.EvictionFilter))
+	out.SkipOwnersRollingOut = (*bool)(unsafe.Pointer(in.SkipOwnersRollingOut))
+	out.NamespaceRules = (*api.NamespaceRules)(unsafe.Pointer(in.NamespaceRules))
+	out.EvictedPodAnnotations = (*api.EvictedPodAnnotations)(unsafe.Pointer(in.EvictedPodAnnotations))
+	out.CycleAbort = (*api.CycleAbort)(unsafe.Pointer(in.CycleAbort))
+	out.EvictionLimits = (*api.EvictionLimits)(unsafe.Pointer(in.EvictionLimits))
+	return nil
+}
+
+// Convert_v1alpha2_DeschedulerPolicy_To_api_DeschedulerPolicy is an autogenerated conversion function.
+func Convert_v1alpha2_DeschedulerPolicy_To_api_DeschedulerPolicy(in *DeschedulerPolicy, out *api.DeschedulerPolicy, s conversion.Scope) error {
+	return autoConvert_v1alpha2_DeschedulerPolicy_To_api_DeschedulerPolicy(in, out, s)
+}
+
+func autoConvert_api_DeschedulerPolicy_To_v1alpha2_DeschedulerPolicy(in *api.DeschedulerPolicy, out *DeschedulerPolicy, s conversion.Scope) error {
+	// WARNING: in.Strategies requires manual conversion: does not exist in peer-type
+	// WARNING: in.NodeSelector requires manual conversion: does not exist in peer-type
+	out.EvictLocalStoragePods = (*bool)(unsafe.Pointer(in.EvictLocalStoragePods))
+	out.EvictableEmptyDirSizeLimit = (*resource.Quantity)(unsafe.Pointer(in.EvictableEmptyDirSizeLimit))
+	out.EvictSystemCriticalPods = (*bool)(unsafe.Pointer(in.EvictSystemCriticalPods))
+	out.IgnorePVCPods = (*bool)(unsafe.Pointer(in.IgnorePVCPods))
+	// WARNING: in.MaxNoOfPodsToEvictPerNode requires manual conversion: does not exist in peer-type
+	// WARNING: in.MaxPerOwnerPerNode requires manual conversion: does not exist in peer-type
+	out.HealthGates = (*HealthGates)(unsafe.Pointer(in.HealthGates))
+	out.ReschedulingHints = (*bool)(unsafe.Pointer(in.ReschedulingHints))
+	out.EvictionHistory = (*EvictionHistory)(unsafe.Pointer(in.EvictionHistory))
+	out.PolicyReports = (*PolicyReports)(unsafe.Pointer(in.PolicyReports))
+	out.RemoteWrite = (*RemoteWrite)(unsafe.Pointer(in.RemoteWrite))
+	out.ReplacementReadinessTimeoutSeconds = (*uint)(unsafe.Pointer(in.ReplacementReadinessTimeoutSeconds))
+	out.VolumeDetachTimeoutSeconds = (*uint)(unsafe.Pointer(in.VolumeDetachTimeoutSeconds))
+	out.PodEvictionCooldownSeconds = (*uint)(unsafe.Pointer(in.PodEvictionCooldownSeconds))
+	out.CooldownPersistence = (*CooldownPersistence)(unsafe.Pointer(in.CooldownPersistence))
+	out.ProtectedDeployments = *(*[]string)(unsafe.Pointer(&in.ProtectedDeployments))
+	out.InteractiveSessionLookbackSeconds = (*uint)(unsafe.Pointer(in.InteractiveSessionLookbackSeconds))
+	out.ProtectedPodConditions = *(*[]ProtectedPodCondition)(unsafe.Pointer(&in.ProtectedPodConditions))
+	out.NodeDeletionTrigger = (*NodeDeletionTrigger)(unsafe.Pointer(in.NodeDeletionTrigger))
+	out.EvictionFilter = (*PodFilter)(unsafe.Pointer(in.EvictionFilter))
+	out.SkipOwnersRollingOut = (*bool)(unsafe.Pointer(in.SkipOwnersRollingOut))
+	out.NamespaceRules = (*NamespaceRules)(unsafe.Pointer(in.NamespaceRules))
+	out.EvictedPodAnnotations = (*EvictedPodAnnotations)(unsafe.Pointer(in.EvictedPodAnnotations))
+	out.Profiles = *(*[]DeschedulerProfile)(unsafe.Pointer(&in.Profiles))
+	out.CycleAbort = (*CycleAbort)(unsafe.Pointer(in.CycleAbort))
+	out.EvictionLimits = (*EvictionLimits)(unsafe.Pointer(in.EvictionLimits))
+	return nil
+}
+
+func autoConvert_v1alpha2_DeschedulerProfile_To_api_DeschedulerProfile(in *DeschedulerProfile, out *api.DeschedulerProfile, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Strategies = *(*api.StrategyList)(unsafe.Pointer(&in.Strategies))
+	out.NodeSelector = (*string)(unsafe.Pointer(in.NodeSelector))
+	out.MaxNoOfPodsToEvictPerNode = (*int)(unsafe.Pointer(in.MaxNoOfPodsToEvictPerNode))
+	out.MaxPerOwnerPerNode = (*int)(unsafe.Pointer(in.MaxPerOwnerPerNode))
+	return nil
+}
+
+// Convert_v1alpha2_DeschedulerProfile_To_api_DeschedulerProfile is an autogenerated conversion function.
+func Convert_v1alpha2_DeschedulerProfile_To_api_DeschedulerProfile(in *DeschedulerProfile, out *api.DeschedulerProfile, s conversion.Scope) error {
+	return autoConvert_v1alpha2_DeschedulerProfile_To_api_DeschedulerProfile(in, out, s)
+}
+
+func autoConvert_api_DeschedulerProfile_To_v1alpha2_DeschedulerProfile(in *api.DeschedulerProfile, out *DeschedulerProfile, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Strategies = *(*StrategyList)(unsafe.Pointer(&in.Strategies))
+	out.NodeSelector = (*string)(unsafe.Pointer(in.NodeSelector))
+	out.MaxNoOfPodsToEvictPerNode = (*int)(unsafe.Pointer(in.MaxNoOfPodsToEvictPerNode))
+	out.MaxPerOwnerPerNode = (*int)(unsafe.Pointer(in.MaxPerOwnerPerNode))
+	return nil
+}
+
+// Convert_api_DeschedulerProfile_To_v1alpha2_DeschedulerProfile is an autogenerated conversion function.
+func Convert_api_DeschedulerProfile_To_v1alpha2_DeschedulerProfile(in *api.DeschedulerProfile, out *DeschedulerProfile, s conversion.Scope) error {
+	return autoConvert_api_DeschedulerProfile_To_v1alpha2_DeschedulerProfile(in, out, s)
+}
+
+func autoConvert_v1alpha2_DeschedulerStrategy_To_api_DeschedulerStrategy(in *DeschedulerStrategy, out *api.DeschedulerStrategy, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.Weight = in.Weight
+	out.DryRun = in.DryRun
+	out.LogVerbosity = (*int32)(unsafe.Pointer(in.LogVerbosity))
+	out.TimeoutSeconds = (*uint)(unsafe.Pointer(in.TimeoutSeconds))
+	out.EvictionLimits = (*api.EvictionLimits)(unsafe.Pointer(in.EvictionLimits))
+	out.Params = (*api.StrategyParameters)(unsafe.Pointer(in.Params))
+	return nil
+}
+
+// Convert_v1alpha2_DeschedulerStrategy_To_api_DeschedulerStrategy is an autogenerated conversion function.
+func Convert_v1alpha2_DeschedulerStrategy_To_api_DeschedulerStrategy(in *DeschedulerStrategy, out *api.DeschedulerStrategy, s conversion.Scope) error {
+	return autoConvert_v1alpha2_DeschedulerStrategy_To_api_DeschedulerStrategy(in, out, s)
+}
+
+func autoConvert_api_DeschedulerStrategy_To_v1alpha2_DeschedulerStrategy(in *api.DeschedulerStrategy, out *DeschedulerStrategy, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.Weight = in.Weight
+	out.DryRun = in.DryRun
+	out.LogVerbosity = (*int32)(unsafe.Pointer(in.LogVerbosity))
+	out.TimeoutSeconds = (*uint)(unsafe.Pointer(in.TimeoutSeconds))
+	out.EvictionLimits = (*EvictionLimits)(unsafe.Pointer(in.EvictionLimits))
+	out.Params = (*StrategyParameters)(unsafe.Pointer(in.Params))
+	return nil
+}
+
+// Convert_api_DeschedulerStrategy_To_v1alpha2_DeschedulerStrategy is an autogenerated conversion function.
+func Convert_api_DeschedulerStrategy_To_v1alpha2_DeschedulerStrategy(in *api.DeschedulerStrategy, out *DeschedulerStrategy, s conversion.Scope) error {
+	return autoConvert_api_DeschedulerStrategy_To_v1alpha2_DeschedulerStrategy(in, out, s)
+}
+
+func autoConvert_v1alpha2_EvictedPodAnnotations_To_api_EvictedPodAnnotations(in *EvictedPodAnnotations, out *api.EvictedPodAnnotations, s conversion.Scope) error {
+	out.Labels = in.Labels
+	return nil
+}
+
+// Convert_v1alpha2_EvictedPodAnnotations_To_api_EvictedPodAnnotations is an autogenerated conversion function.
+func Convert_v1alpha2_EvictedPodAnnotations_To_api_EvictedPodAnnotations(in *EvictedPodAnnotations, out *api.EvictedPodAnnotations, s conversion.Scope) error {
+	return autoConvert_v1alpha2_EvictedPodAnnotations_To_api_EvictedPodAnnotations(in, out, s)
+}
+
+func autoConvert_api_EvictedPodAnnotations_To_v1alpha2_EvictedPodAnnotations(in *api.EvictedPodAnnotations, out *EvictedPodAnnotations, s conversion.Scope) error {
+	out.Labels = in.Labels
+	return nil
+}
+
+// Convert_api_EvictedPodAnnotations_To_v1alpha2_EvictedPodAnnotations is an autogenerated conversion function.
+func Convert_api_EvictedPodAnnotations_To_v1alpha2_EvictedPodAnnotations(in *api.EvictedPodAnnotations, out *EvictedPodAnnotations, s conversion.Scope) error {
+	return autoConvert_api_EvictedPodAnnotations_To_v1alpha2_EvictedPodAnnotations(in, out, s)
+}
+
+func autoConvert_v1alpha2_EvictionHistory_To_api_EvictionHistory(in *EvictionHistory, out *api.EvictionHistory, s conversion.Scope) error {
+	out.Namespace = in.Namespace
+	out.Name = in.Name
+	out.MaxCycles = in.MaxCycles
+	return nil
+}
+
+// Convert_v1alpha2_EvictionHistory_To_api_EvictionHistory is an autogenerated conversion function.
+func Convert_v1alpha2_EvictionHistory_To_api_EvictionHistory(in *EvictionHistory, out *api.EvictionHistory, s conversion.Scope) error {
+	return autoConvert_v1alpha2_EvictionHistory_To_api_EvictionHistory(in, out, s)
+}
+
+func autoConvert_api_EvictionHistory_To_v1alpha2_EvictionHistory(in *api.EvictionHistory, out *EvictionHistory, s conversion.Scope) error {
+	out.Namespace = in.Namespace
+	out.Name = in.Name
+	out.MaxCycles = in.MaxCycles
+	return nil
+}
+
+// Convert_api_EvictionHistory_To_v1alpha2_EvictionHistory is an autogenerated conversion function.
+func Convert_api_EvictionHistory_To_v1alpha2_EvictionHistory(in *api.EvictionHistory, out *EvictionHistory, s conversion.Scope) error {
+	return autoConvert_api_EvictionHistory_To_v1alpha2_EvictionHistory(in, out, s)
+}
+
+func autoConvert_v1alpha2_EvictionLimits_To_api_EvictionLimits(in *EvictionLimits, out *api.EvictionLimits, s conversion.Scope) error {
+	out.MaxNoOfPodsToEvictPerCycle = (*uint)(unsafe.Pointer(in.MaxNoOfPodsToEvictPerCycle))
+	out.MaxNoOfPodsToEvictPerNamespace = (*uint)(unsafe.Pointer(in.MaxNoOfPodsToEvictPerNamespace))
+	out.EvictionsPerSecond = (*float64)(unsafe.Pointer(in.EvictionsPerSecond))
+	out.EvictionBurst = (*uint)(unsafe.Pointer(in.EvictionBurst))
+	return nil
+}
+
+// Convert_v1alpha2_EvictionLimits_To_api_EvictionLimits is an autogenerated conversion function.
+func Convert_v1alpha2_EvictionLimits_To_api_EvictionLimits(in *EvictionLimits, out *api.EvictionLimits, s conversion.Scope) error {
+	return autoConvert_v1alpha2_EvictionLimits_To_api_EvictionLimits(in, out, s)
+}
+
+func autoConvert_api_EvictionLimits_To_v1alpha2_EvictionLimits(in *api.EvictionLimits, out *EvictionLimits, s conversion.Scope) error {
+	out.MaxNoOfPodsToEvictPerCycle = (*uint)(unsafe.Pointer(in.MaxNoOfPodsToEvictPerCycle))
+	out.MaxNoOfPodsToEvictPerNamespace = (*uint)(unsafe.Pointer(in.MaxNoOfPodsToEvictPerNamespace))
+	out.EvictionsPerSecond = (*float64)(unsafe.Pointer(in.EvictionsPerSecond))
+	out.EvictionBurst = (*uint)(unsafe.Pointer(in.EvictionBurst))
+	return nil
+}
+
+// Convert_api_EvictionLimits_To_v1alpha2_EvictionLimits is an autogenerated conversion function.
+func Convert_api_EvictionLimits_To_v1alpha2_EvictionLimits(in *api.EvictionLimits, out *EvictionLimits, s conversion.Scope) error {
+	return autoConvert_api_EvictionLimits_To_v1alpha2_EvictionLimits(in, out, s)
+}
+
+func autoConvert_v1alpha2_FailedPods_To_api_FailedPods(in *FailedPods, out *api.FailedPods, s conversion.Scope) error {
+	out.ExcludeOwnerKinds = *(*[]string)(unsafe.Pointer(&in.ExcludeOwnerKinds))
+	out.MinPodLifetimeSeconds = (*uint)(unsafe.Pointer(in.MinPodLifetimeSeconds))
+	out.Reasons = *(*[]string)(unsafe.Pointer(&in.Reasons))
+	out.IncludingInitContainers = in.IncludingInitContainers
+	return nil
+}
+
+// Convert_v1alpha2_FailedPods_To_api_FailedPods is an autogenerated conversion function.
+func Convert_v1alpha2_FailedPods_To_api_FailedPods(in *FailedPods, out *api.FailedPods, s conversion.Scope) error {
+	return autoConvert_v1alpha2_FailedPods_To_api_FailedPods(in, out, s)
+}
+
+func autoConvert_api_FailedPods_To_v1alpha2_FailedPods(in *api.FailedPods, out *FailedPods, s conversion.Scope) error {
+	out.ExcludeOwnerKinds = *(*[]string)(unsafe.Pointer(&in.ExcludeOwnerKinds))
+	out.MinPodLifetimeSeconds = (*uint)(unsafe.Pointer(in.MinPodLifetimeSeconds))
+	out.Reasons = *(*[]string)(unsafe.Pointer(&in.Reasons))
+	out.IncludingInitContainers = in.IncludingInitContainers
+	return nil
+}
+
+// Convert_api_FailedPods_To_v1alpha2_FailedPods is an autogenerated conversion function.
+func Convert_api_FailedPods_To_v1alpha2_FailedPods(in *api.FailedPods, out *FailedPods, s conversion.Scope) error {
+	return autoConvert_api_FailedPods_To_v1alpha2_FailedPods(in, out, s)
+}
+
+func autoConvert_v1alpha2_FinishedJobPods_To_api_FinishedJobPods(in *FinishedJobPods, out *api.FinishedJobPods, s conversion.Scope) error {
+	out.TTLSecondsAfterFinished = (*uint)(unsafe.Pointer(in.TTLSecondsAfterFinished))
+	out.ExcludeOwnerKinds = *(*[]string)(unsafe.Pointer(&in.ExcludeOwnerKinds))
+	return nil
+}
+
+// Convert_v1alpha2_FinishedJobPods_To_api_FinishedJobPods is an autogenerated conversion function.
+func Convert_v1alpha2_FinishedJobPods_To_api_FinishedJobPods(in *FinishedJobPods, out *api.FinishedJobPods, s conversion.Scope) error {
+	return autoConvert_v1alpha2_FinishedJobPods_To_api_FinishedJobPods(in, out, s)
+}
+
+func autoConvert_api_FinishedJobPods_To_v1alpha2_FinishedJobPods(in *api.FinishedJobPods, out *FinishedJobPods, s conversion.Scope) error {
+	out.TTLSecondsAfterFinished = (*uint)(unsafe.Pointer(in.TTLSecondsAfterFinished))
+	out.ExcludeOwnerKinds = *(*[]string)(unsafe.Pointer(&in.ExcludeOwnerKinds))
+	return nil
+}
+
+// Convert_api_FinishedJobPods_To_v1alpha2_FinishedJobPods is an autogenerated conversion function.
+func Convert_api_FinishedJobPods_To_v1alpha2_FinishedJobPods(in *api.FinishedJobPods, out *FinishedJobPods, s conversion.Scope) error {
+	return autoConvert_api_FinishedJobPods_To_v1alpha2_FinishedJobPods(in, out, s)
+}
+
+func autoConvert_v1alpha2_GPUModelPreference_To_api_GPUModelPreference(in *GPUModelPreference, out *api.GPUModelPreference, s conversion.Scope) error {
+	out.ModelLabel = in.ModelLabel
+	out.ResourceName = in.ResourceName
+	out.ModelPriorities = *(*[]api.GPUModelPriority)(unsafe.Pointer(&in.ModelPriorities))
+	return nil
+}
+
+// Convert_v1alpha2_GPUModelPreference_To_api_GPUModelPreference is an autogenerated conversion function.
+func Convert_v1alpha2_GPUModelPreference_To_api_GPUModelPreference(in *GPUModelPreference, out *api.GPUModelPreference, s conversion.Scope) error {
+	return autoConvert_v1alpha2_GPUModelPreference_To_api_GPUModelPreference(in, out, s)
+}
+
+func autoConvert_api_GPUModelPreference_To_v1alpha2_GPUModelPreference(in *api.GPUModelPreference, out *GPUModelPreference, s conversion.Scope) error {
+	out.ModelLabel = in.ModelLabel
+	out.ResourceName = in.ResourceName
+	out.ModelPriorities = *(*[]GPUModelPriority)(unsafe.Pointer(&in.ModelPriorities))
+	return nil
+}
+
+// Convert_api_GPUModelPreference_To_v1alpha2_GPUModelPreference is an autogenerated conversion function.
+func Convert_api_GPUModelPreference_To_v1alpha2_GPUModelPreference(in *api.GPUModelPreference, out *GPUModelPreference, s conversion.Scope) error {
+	return autoConvert_api_GPUModelPreference_To_v1alpha2_GPUModelPreference(in, out, s)
+}
+
+func autoConvert_v1alpha2_GPUModelPriority_To_api_GPUModelPriority(in *GPUModelPriority, out *api.GPUModelPriority, s conversion.Scope) error {
+	out.PodSelector = (*v1.LabelSelector)(unsafe.Pointer(in.PodSelector))
+	out.Models = *(*[]string)(unsafe.Pointer(&in.Models))
+	return nil
+}
+
+// Convert_v1alpha2_GPUModelPriority_To_api_GPUModelPriority is an autogenerated conversion function.
+func Convert_v1alpha2_GPUModelPriority_To_api_GPUModelPriority(in *GPUModelPriority, out *api.GPUModelPriority, s conversion.Scope) error {
+	return autoConvert_v1alpha2_GPUModelPriority_To_api_GPUModelPriority(in, out, s)
+}
+
+func autoConvert_api_GPUModelPriority_To_v1alpha2_GPUModelPriority(in *api.GPUModelPriority, out *GPUModelPriority, s conversion.Scope) error {
+	out.PodSelector = (*v1.LabelSelector)(unsafe.Pointer(in.PodSelector))
+	out.Models = *(*[]string)(unsafe.Pointer(&in.Models))
+	return nil
+}
+
+// Convert_api_GPUModelPriority_To_v1alpha2_GPUModelPriority is an autogenerated conversion function.
+func Convert_api_GPUModelPriority_To_v1alpha2_GPUModelPriority(in *api.GPUModelPriority, out *GPUModelPriority, s conversion.Scope) error {
+	return autoConvert_api_GPUModelPriority_To_v1alpha2_GPUModelPriority(in, out, s)
+}
+
+func autoConvert_v1alpha2_HealthGates_To_api_HealthGates(in *HealthGates, out *api.HealthGates, s conversion.Scope) error {
+	out.MaxNotReadyNodesPercentage = (*api.Percentage)(unsafe.Pointer(in.MaxNotReadyNodesPercentage))
+	out.MaxPendingPods = (*int)(unsafe.Pointer(in.MaxPendingPods))
+	out.MaxKubeletRestarts = (*int)(unsafe.Pointer(in.MaxKubeletRestarts))
+	out.KubeletRestartsWindowSeconds = (*uint)(unsafe.Pointer(in.KubeletRestartsWindowSeconds))
+	out.SchedulerLease = in.SchedulerLease
+	return nil
+}
+
+// Convert_v1alpha2_HealthGates_To_api_HealthGates is an autogenerated conversion function.
+func Convert_v1alpha2_HealthGates_To_api_HealthGates(in *HealthGates, out *api.HealthGates, s conversion.Scope) error {
+	return autoConvert_v1alpha2_HealthGates_To_api_HealthGates(in, out, s)
+}
+
+func autoConvert_api_HealthGates_To_v1alpha2_HealthGates(in *api.HealthGates, out *HealthGates, s conversion.Scope) error {
+	out.MaxNotReadyNodesPercentage = (*Percentage)(unsafe.Pointer(in.MaxNotReadyNodesPercentage))
+	out.MaxPendingPods = (*int)(unsafe.Pointer(in.MaxPendingPods))
+	out.MaxKubeletRestarts = (*int)(unsafe.Pointer(in.MaxKubeletRestarts))
+	out.KubeletRestartsWindowSeconds = (*uint)(unsafe.Pointer(in.KubeletRestartsWindowSeconds))
+	out.SchedulerLease = in.SchedulerLease
+	return nil
+}
+
+// Convert_api_HealthGates_To_v1alpha2_HealthGates is an autogenerated conversion function.
+func Convert_api_HealthGates_To_v1alpha2_HealthGates(in *api.HealthGates, out *HealthGates, s conversion.Scope) error {
+	return autoConvert_api_HealthGates_To_v1alpha2_HealthGates(in, out, s)
+}
+
+func autoConvert_v1alpha2_ImageLocality_To_api_ImageLocality(in *ImageLocality, out *api.ImageLocality, s conversion.Scope) error {
+	out.MinImageSizeMB = (*uint)(unsafe.Pointer(in.MinImageSizeMB))
+	return nil
+}
+
+// Convert_v1alpha2_ImageLocality_To_api_ImageLocality is an autogenerated conversion function.
+func Convert_v1alpha2_ImageLocality_To_api_ImageLocality(in *ImageLocality, out *api.ImageLocality, s conversion.Scope) error {
+	return autoConvert_v1alpha2_ImageLocality_To_api_ImageLocality(in, out, s)
+}
+
+func autoConvert_api_ImageLocality_To_v1alpha2_ImageLocality(in *api.ImageLocality, out *ImageLocality, s conversion.Scope) error {
+	out.MinImageSizeMB = (*uint)(unsafe.Pointer(in.MinImageSizeMB))
+	return nil
+}
+
+// Convert_api_ImageLocality_To_v1alpha2_ImageLocality is an autogenerated conversion function.
+func Convert_api_ImageLocality_To_v1alpha2_ImageLocality(in *api.ImageLocality, out *ImageLocality, s conversion.Scope) error {
+	return autoConvert_api_ImageLocality_To_v1alpha2_ImageLocality(in, out, s)
+}
+
+func autoConvert_v1alpha2_LimitsOvercommit_To_api_LimitsOvercommit(in *LimitsOvercommit, out *api.LimitsOvercommit, s conversion.Scope) error {
+	out.Thresholds = *(*api.ResourceThresholds)(unsafe.Pointer(&in.Thresholds))
+	return nil
+}
+
+// Convert_v1alpha2_LimitsOvercommit_To_api_LimitsOvercommit is an autogenerated conversion function.
+func Convert_v1alpha2_LimitsOvercommit_To_api_LimitsOvercommit(in *LimitsOvercommit, out *api.LimitsOvercommit, s conversion.Scope) error {
+	return autoConvert_v1alpha2_LimitsOvercommit_To_api_LimitsOvercommit(in, out, s)
+}
+
+func autoConvert_api_LimitsOvercommit_To_v1alpha2_LimitsOvercommit(in *api.LimitsOvercommit, out *LimitsOvercommit, s conversion.Scope) error {
+	out.Thresholds = *(*ResourceThresholds)(unsafe.Pointer(&in.Thresholds))
+	return nil
+}
+
+// Convert_api_LimitsOvercommit_To_v1alpha2_LimitsOvercommit is an autogenerated conversion function.
+func Convert_api_LimitsOvercommit_To_v1alpha2_LimitsOvercommit(in *api.LimitsOvercommit, out *LimitsOvercommit, s conversion.Scope) error {
+	return autoConvert_api_LimitsOvercommit_To_v1alpha2_LimitsOvercommit(in, out, s)
+}
+
+func autoConvert_v1alpha2_MemoryOverrun_To_api_MemoryOverrun(in *MemoryOverrun, out *api.MemoryOverrun, s conversion.Scope) error {
+	out.ExcessPercentage = api.Percentage(in.ExcessPercentage)
+	out.DurationSeconds = in.DurationSeconds
+	out.NodeUsageThreshold = api.Percentage(in.NodeUsageThreshold)
+	out.MaxPodsToEvictPerNode = in.MaxPodsToEvictPerNode
+	return nil
+}
+
+// Convert_v1alpha2_MemoryOverrun_To_api_MemoryOverrun is an autogenerated conversion function.
+func Convert_v1alpha2_MemoryOverrun_To_api_MemoryOverrun(in *MemoryOverrun, out *api.MemoryOverrun, s conversion.Scope) error {
+	return autoConvert_v1alpha2_MemoryOverrun_To_api_MemoryOverrun(in, out, s)
+}
+
+func autoConvert_api_MemoryOverrun_To_v1alpha2_MemoryOverrun(in *api.MemoryOverrun, out *MemoryOverrun, s conversion.Scope) error {
+	out.ExcessPercentage = Percentage(in.ExcessPercentage)
+	out.DurationSeconds = in.DurationSeconds
+	out.NodeUsageThreshold = Percentage(in.NodeUsageThreshold)
+	out.MaxPodsToEvictPerNode = in.MaxPodsToEvictPerNode
+	return nil
+}
+
+// Convert_api_MemoryOverrun_To_v1alpha2_MemoryOverrun is an autogenerated conversion function.
+func Convert_api_MemoryOverrun_To_v1alpha2_MemoryOverrun(in *api.MemoryOverrun, out *MemoryOverrun, s conversion.Scope) error {
+	return autoConvert_api_MemoryOverrun_To_v1alpha2_MemoryOverrun(in, out, s)
+}
+
+func autoConvert_v1alpha2_NamespaceRules_To_api_NamespaceRules(in *NamespaceRules, out *api.NamespaceRules, s conversion.Scope) error {
+	out.AllowedStrategies = *(*[]api.StrategyName)(unsafe.Pointer(&in.AllowedStrategies))
+	out.Namespaces = (*api.Namespaces)(unsafe.Pointer(in.Namespaces))
+	out.NamespaceSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NamespaceSelector))
+	out.MinPodLifeTimeSeconds = (*uint)(unsafe.Pointer(in.MinPodLifeTimeSeconds))
+	out.MinPodRestartThreshold = (*int32)(unsafe.Pointer(in.MinPodRestartThreshold))
+	return nil
+}
+
+// Convert_v1alpha2_NamespaceRules_To_api_NamespaceRules is an autogenerated conversion function.
+func Convert_v1alpha2_NamespaceRules_To_api_NamespaceRules(in *NamespaceRules, out *api.NamespaceRules, s conversion.Scope) error {
+	return autoConvert_v1alpha2_NamespaceRules_To_api_NamespaceRules(in, out, s)
+}
+
+func autoConvert_api_NamespaceRules_To_v1alpha2_NamespaceRules(in *api.NamespaceRules, out *NamespaceRules, s conversion.Scope) error {
+	out.AllowedStrategies = *(*[]StrategyName)(unsafe.Pointer(&in.AllowedStrategies))
+	out.Namespaces = (*Namespaces)(unsafe.Pointer(in.Namespaces))
+	out.NamespaceSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NamespaceSelector))
+	out.MinPodLifeTimeSeconds = (*uint)(unsafe.Pointer(in.MinPodLifeTimeSeconds))
+	out.MinPodRestartThreshold = (*int32)(unsafe.Pointer(in.MinPodRestartThreshold))
+	return nil
+}
+
+// Convert_api_NamespaceRules_To_v1alpha2_NamespaceRules is an autogenerated conversion function.
+func Convert_api_NamespaceRules_To_v1alpha2_NamespaceRules(in *api.NamespaceRules, out *NamespaceRules, s conversion.Scope) error {
+	return autoConvert_api_NamespaceRules_To_v1alpha2_NamespaceRules(in, out, s)
+}
+
+func autoConvert_v1alpha2_Namespaces_To_api_Namespaces(in *Namespaces, out *api.Namespaces, s conversion.Scope) error {
+	out.Include = *(*[]string)(unsafe.Pointer(&in.Include))
+	out.Exclude = *(*[]string)(unsafe.Pointer(&in.Exclude))
+	return nil
+}
+
+// Convert_v1alpha2_Namespaces_To_api_Namespaces is an autogenerated conversion function.
+func Convert_v1alpha2_Namespaces_To_api_Namespaces(in *Namespaces, out *api.Namespaces, s conversion.Scope) error {
+	return autoConvert_v1alpha2_Namespaces_To_api_Namespaces(in, out, s)
+}
+
+func autoConvert_api_Namespaces_To_v1alpha2_Namespaces(in *api.Namespaces, out *Namespaces, s conversion.Scope) error {
+	out.Include = *(*[]string)(unsafe.Pointer(&in.Include))
+	out.Exclude = *(*[]string)(unsafe.Pointer(&in.Exclude))
+	return nil
+}
+
+// Convert_api_Namespaces_To_v1alpha2_Namespaces is an autogenerated conversion function.
+func Convert_api_Namespaces_To_v1alpha2_Namespaces(in *api.Namespaces, out *Namespaces, s conversion.Scope) error {
+	return autoConvert_api_Namespaces_To_v1alpha2_Namespaces(in, out, s)
+}
+
+func autoConvert_v1alpha2_NodeDeletionTrigger_To_api_NodeDeletionTrigger(in *NodeDeletionTrigger, out *api.NodeDeletionTrigger, s conversion.Scope) error {
+	out.CapacityPercentage = api.Percentage(in.CapacityPercentage)
+	return nil
+}
+
+// Convert_v1alpha2_NodeDeletionTrigger_To_api_NodeDeletionTrigger is an autogenerated conversion function.
+func Convert_v1alpha2_NodeDeletionTrigger_To_api_NodeDeletionTrigger(in *NodeDeletionTrigger, out *api.NodeDeletionTrigger, s conversion.Scope) error {
+	return autoConvert_v1alpha2_NodeDeletionTrigger_To_api_NodeDeletionTrigger(in, out, s)
+}
+
+func autoConvert_api_NodeDeletionTrigger_To_v1alpha2_NodeDeletionTrigger(in *api.NodeDeletionTrigger, out *NodeDeletionTrigger, s conversion.Scope) error {
+	out.CapacityPercentage = Percentage(in.CapacityPercentage)
+	return nil
+}
+
+// Convert_api_NodeDeletionTrigger_To_v1alpha2_NodeDeletionTrigger is an autogenerated conversion function.
+func Convert_api_NodeDeletionTrigger_To_v1alpha2_NodeDeletionTrigger(in *api.NodeDeletionTrigger, out *NodeDeletionTrigger, s conversion.Scope) error {
+	return autoConvert_api_NodeDeletionTrigger_To_v1alpha2_NodeDeletionTrigger(in, out, s)
+}
+
+func autoConvert_v1alpha2_NodeInterruption_To_api_NodeInterruption(in *NodeInterruption, out *api.NodeInterruption, s conversion.Scope) error {
+	out.Taints = *(*[]string)(unsafe.Pointer(&in.Taints))
+	out.Labels = *(*[]string)(unsafe.Pointer(&in.Labels))
+	return nil
+}
+
+// Convert_v1alpha2_NodeInterruption_To_api_NodeInterruption is an autogenerated conversion function.
+func Convert_v1alpha2_NodeInterruption_To_api_NodeInterruption(in *NodeInterruption, out *api.NodeInterruption, s conversion.Scope) error {
+	return autoConvert_v1alpha2_NodeInterruption_To_api_NodeInterruption(in, out, s)
+}
+
+func autoConvert_api_NodeInterruption_To_v1alpha2_NodeInterruption(in *api.NodeInterruption, out *NodeInterruption, s conversion.Scope) error {
+	out.Taints = *(*[]string)(unsafe.Pointer(&in.Taints))
+	out.Labels = *(*[]string)(unsafe.Pointer(&in.Labels))
+	return nil
+}
+
+// Convert_api_NodeInterruption_To_v1alpha2_NodeInterruption is an autogenerated conversion function.
+func Convert_api_NodeInterruption_To_v1alpha2_NodeInterruption(in *api.NodeInterruption, out *NodeInterruption, s conversion.Scope) error {
+	return autoConvert_api_NodeInterruption_To_v1alpha2_NodeInterruption(in, out, s)
+}
+
+func autoConvert_v1alpha2_NodeProblems_To_api_NodeProblems(in *NodeProblems, out *api.NodeProblems, s conversion.Scope) error {
+	out.Conditions = *(*[]string)(unsafe.Pointer(&in.Conditions))
+	out.MaxPodsToEvictPerCycle = in.MaxPodsToEvictPerCycle
+	return nil
+}
+
+// Convert_v1alpha2_NodeProblems_To_api_NodeProblems is an autogenerated conversion function.
+func Convert_v1alpha2_NodeProblems_To_api_NodeProblems(in *NodeProblems, out *api.NodeProblems, s conversion.Scope) error {
+	return autoConvert_v1alpha2_NodeProblems_To_api_NodeProblems(in, out, s)
+}
+
+func autoConvert_api_NodeProblems_To_v1alpha2_NodeProblems(in *api.NodeProblems, out *NodeProblems, s conversion.Scope) error {
+	out.Conditions = *(*[]string)(unsafe.Pointer(&in.Conditions))
+	out.MaxPodsToEvictPerCycle = in.MaxPodsToEvictPerCycle
+	return nil
+}
+
+// Convert_api_NodeProblems_To_v1alpha2_NodeProblems is an autogenerated conversion function.
+func Convert_api_NodeProblems_To_v1alpha2_NodeProblems(in *api.NodeProblems, out *NodeProblems, s conversion.Scope) error {
+	return autoConvert_api_NodeProblems_To_v1alpha2_NodeProblems(in, out, s)
+}
+
+func autoConvert_v1alpha2_NodeResourceUtilizationThresholds_To_api_NodeResourceUtilizationThresholds(in *NodeResourceUtilizationThresholds, out *api.NodeResourceUtilizationThresholds, s conversion.Scope) error {
+	out.Thresholds = *(*api.ResourceThresholds)(unsafe.Pointer(&in.Thresholds))
+	out.TargetThresholds = *(*api.ResourceThresholds)(unsafe.Pointer(&in.TargetThresholds))
+	out.NumberOfNodes = in.NumberOfNodes
+	out.WarningThresholds = *(*api.ResourceThresholds)(unsafe.Pointer(&in.WarningThresholds))
+	out.ExcludedContainers = *(*[]string)(unsafe.Pointer(&in.ExcludedContainers))
+	out.ThresholdsOperator = in.ThresholdsOperator
+	out.TieBreaker = in.TieBreaker
+	out.TieBreakerSeed = in.TieBreakerSeed
+	out.Hysteresis = api.Percentage(in.Hysteresis)
+	out.PriorityBands = *(*[]api.PriorityBand)(unsafe.Pointer(&in.PriorityBands))
+	out.DestinationScorer = in.DestinationScorer
+	out.ThresholdsUnits = in.ThresholdsUnits
+	out.BalancingDomain = in.BalancingDomain
+	out.TopologyKey = in.TopologyKey
+	out.MinFreeCapacityPercent = api.Percentage(in.MinFreeCapacityPercent)
+	out.RelieveResources = *(*[]corev1.ResourceName)(unsafe.Pointer(&in.RelieveResources))
+	out.AnnotateNodes = in.AnnotateNodes
+	out.ExcludeDaemonSetPods = in.ExcludeDaemonSetPods
+	out.Parallelism = in.Parallelism
+	out.UtilizationMetric = in.UtilizationMetric
+	out.MetricsUtilization = in.MetricsUtilization
+	out.Prometheus = (*api.PrometheusUtilization)(unsafe.Pointer(in.Prometheus))
+	out.Trend = (*api.UsageTrend)(unsafe.Pointer(in.Trend))
+	out.CountTerminatingPods = (*bool)(unsafe.Pointer(in.CountTerminatingPods))
+	out.CountNominatedPods = in.CountNominatedPods
+	out.MinPodsOnSourceNode = in.MinPodsOnSourceNode
+	out.UnschedulableNodesAsSources = (*bool)(unsafe.Pointer(in.UnschedulableNodesAsSources))
+	return nil
+}
+
+// Convert_v1alpha2_NodeResourceUtilizationThresholds_To_api_NodeResourceUtilizationThresholds is an autogenerated conversion function.
+func Convert_v1alpha2_NodeResourceUtilizationThresholds_To_api_NodeResourceUtilizationThresholds(in *NodeResourceUtilizationThresholds, out *api.NodeResourceUtilizationThresholds, s conversion.Scope) error {
+	return autoConvert_v1alpha2_NodeResourceUtilizationThresholds_To_api_NodeResourceUtilizationThresholds(in, out, s)
+}
+
+func autoConvert_api_NodeResourceUtilizationThresholds_To_v1alpha2_NodeResourceUtilizationThresholds(in *api.NodeResourceUtilizationThresholds, out *NodeResourceUtilizationThresholds, s conversion.Scope) error {
+	out.Thresholds = *(*ResourceThresholds)(unsafe.Pointer(&in.Thresholds))
+	out.TargetThresholds = *(*ResourceThresholds)(unsafe.Pointer(&in.TargetThresholds))
+	out.NumberOfNodes = in.NumberOfNodes
+	out.WarningThresholds = *(*ResourceThresholds)(unsafe.Pointer(&in.WarningThresholds))
+	out.ExcludedContainers = *(*[]string)(unsafe.Pointer(&in.ExcludedContainers))
+	out.ThresholdsOperator = in.ThresholdsOperator
+	out.TieBreaker = in.TieBreaker
+	out.TieBreakerSeed = in.TieBreakerSeed
+	out.Hysteresis = Percentage(in.Hysteresis)
+	out.PriorityBands = *(*[]PriorityBand)(unsafe.Pointer(&in.PriorityBands))
+	out.DestinationScorer = in.DestinationScorer
+	out.ThresholdsUnits = in.ThresholdsUnits
+	out.BalancingDomain = in.BalancingDomain
+	out.TopologyKey = in.TopologyKey
+	out.MinFreeCapacityPercent = Percentage(in.MinFreeCapacityPercent)
+	out.RelieveResources = *(*[]corev1.ResourceName)(unsafe.Pointer(&in.RelieveResources))
+	out.AnnotateNodes = in.AnnotateNodes
+	out.ExcludeDaemonSetPods = in.ExcludeDaemonSetPods
+	out.Parallelism = in.Parallelism
+	out.UtilizationMetric = in.UtilizationMetric
+	out.MetricsUtilization = in.MetricsUtilization
+	out.Prometheus = (*PrometheusUtilization)(unsafe.Pointer(in.Prometheus))
+	out.Trend = (*UsageTrend)(unsafe.Pointer(in.Trend))
+	out.CountTerminatingPods = (*bool)(unsafe.Pointer(in.CountTerminatingPods))
+	out.CountNominatedPods = in.CountNominatedPods
+	out.MinPodsOnSourceNode = in.MinPodsOnSourceNode
+	out.UnschedulableNodesAsSources = (*bool)(unsafe.Pointer(in.UnschedulableNodesAsSources))
+	return nil
+}
+
+// Convert_api_NodeResourceUtilizationThresholds_To_v1alpha2_NodeResourceUtilizationThresholds is an autogenerated conversion function.
+func Convert_api_NodeResourceUtilizationThresholds_To_v1alpha2_NodeResourceUtilizationThresholds(in *api.NodeResourceUtilizationThresholds, out *NodeResourceUtilizationThresholds, s conversion.Scope) error {
+	return autoConvert_api_NodeResourceUtilizationThresholds_To_v1alpha2_NodeResourceUtilizationThresholds(in, out, s)
+}
+
+func autoConvert_v1alpha2_NotReadyNodes_To_api_NotReadyNodes(in *NotReadyNodes, out *api.NotReadyNodes, s conversion.Scope) error {
+	out.NotReadySeconds = (*uint)(unsafe.Pointer(in.NotReadySeconds))
+	out.ForceDeleteAfterSeconds = (*uint)(unsafe.Pointer(in.ForceDeleteAfterSeconds))
+	return nil
+}
+
+// Convert_v1alpha2_NotReadyNodes_To_api_NotReadyNodes is an autogenerated conversion function.
+func Convert_v1alpha2_NotReadyNodes_To_api_NotReadyNodes(in *NotReadyNodes, out *api.NotReadyNodes, s conversion.Scope) error {
+	return autoConvert_v1alpha2_NotReadyNodes_To_api_NotReadyNodes(in, out, s)
+}
+
+func autoConvert_api_NotReadyNodes_To_v1alpha2_NotReadyNodes(in *api.NotReadyNodes, out *NotReadyNodes, s conversion.Scope) error {
+	out.NotReadySeconds = (*uint)(unsafe.Pointer(in.NotReadySeconds))
+	out.ForceDeleteAfterSeconds = (*uint)(unsafe.Pointer(in.ForceDeleteAfterSeconds))
+	return nil
+}
+
+// Convert_api_NotReadyNodes_To_v1alpha2_NotReadyNodes is an autogenerated conversion function.
+func Convert_api_NotReadyNodes_To_v1alpha2_NotReadyNodes(in *api.NotReadyNodes, out *NotReadyNodes, s conversion.Scope) error {
+	return autoConvert_api_NotReadyNodes_To_v1alpha2_NotReadyNodes(in, out, s)
+}
+
+func autoConvert_v1alpha2_PodDensity_To_api_PodDensity(in *PodDensity, out *api.PodDensity, s conversion.Scope) error {
+	out.TopologyKey = in.TopologyKey
+	out.MaxPodsPerDomain = in.MaxPodsPerDomain
+	out.LabelSelector = (*v1.LabelSelector)(unsafe.Pointer(in.LabelSelector))
+	return nil
+}
+
+// Convert_v1alpha2_PodDensity_To_api_PodDensity is an autogenerated conversion function.
+func Convert_v1alpha2_PodDensity_To_api_PodDensity(in *PodDensity, out *api.PodDensity, s conversion.Scope) error {
+	return autoConvert_v1alpha2_PodDensity_To_api_PodDensity(in, out, s)
+}
+
+func autoConvert_api_PodDensity_To_v1alpha2_PodDensity(in *api.PodDensity, out *PodDensity, s conversion.Scope) error {
+	out.TopologyKey = in.TopologyKey
+	out.MaxPodsPerDomain = in.MaxPodsPerDomain
+	out.LabelSelector = (*v1.LabelSelector)(unsafe.Pointer(in.LabelSelector))
+	return nil
+}
+
+// Convert_api_PodDensity_To_v1alpha2_PodDensity is an autogenerated conversion function.
+func Convert_api_PodDensity_To_v1alpha2_PodDensity(in *api.PodDensity, out *PodDensity, s conversion.Scope) error {
+	return autoConvert_api_PodDensity_To_v1alpha2_PodDensity(in, out, s)
+}
+
+func autoConvert_v1alpha2_PodFilter_To_api_PodFilter(in *PodFilter, out *api.PodFilter, s conversion.Scope) error {
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	out.LabelSelector = (*v1.LabelSelector)(unsafe.Pointer(in.LabelSelector))
+	out.NamespaceSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NamespaceSelector))
+	out.MinPriority = (*int32)(unsafe.Pointer(in.MinPriority))
+	out.MaxPriority = (*int32)(unsafe.Pointer(in.MaxPriority))
+	out.AllOf = *(*[]api.PodFilter)(unsafe.Pointer(&in.AllOf))
+	out.AnyOf = *(*[]api.PodFilter)(unsafe.Pointer(&in.AnyOf))
+	out.NoneOf = *(*[]api.PodFilter)(unsafe.Pointer(&in.NoneOf))
+	return nil
+}
+
+// Convert_v1alpha2_PodFilter_To_api_PodFilter is an autogenerated conversion function.
+func Convert_v1alpha2_PodFilter_To_api_PodFilter(in *PodFilter, out *api.PodFilter, s conversion.Scope) error {
+	return autoConvert_v1alpha2_PodFilter_To_api_PodFilter(in, out, s)
+}
+
+func autoConvert_api_PodFilter_To_v1alpha2_PodFilter(in *api.PodFilter, out *PodFilter, s conversion.Scope) error {
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	out.LabelSelector = (*v1.LabelSelector)(unsafe.Pointer(in.LabelSelector))
+	out.NamespaceSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NamespaceSelector))
+	out.MinPriority = (*int32)(unsafe.Pointer(in.MinPriority))
+	out.MaxPriority = (*int32)(unsafe.Pointer(in.MaxPriority))
+	out.AllOf = *(*[]PodFilter)(unsafe.Pointer(&in.AllOf))
+	out.AnyOf = *(*[]PodFilter)(unsafe.Pointer(&in.AnyOf))
+	out.NoneOf = *(*[]PodFilter)(unsafe.Pointer(&in.NoneOf))
+	return nil
+}
+
+// Convert_api_PodFilter_To_v1alpha2_PodFilter is an autogenerated conversion function.
+func Convert_api_PodFilter_To_v1alpha2_PodFilter(in *api.PodFilter, out *PodFilter, s conversion.Scope) error {
+	return autoConvert_api_PodFilter_To_v1alpha2_PodFilter(in, out, s)
+}
+
+func autoConvert_v1alpha2_PodLifeTime_To_api_PodLifeTime(in *PodLifeTime, out *api.PodLifeTime, s conversion.Scope) error {
+	out.MaxPodLifeTimeSeconds = (*uint)(unsafe.Pointer(in.MaxPodLifeTimeSeconds))
+	out.PodStatusPhases = *(*[]string)(unsafe.Pointer(&in.PodStatusPhases))
+	return nil
+}
+
+// Convert_v1alpha2_PodLifeTime_To_api_PodLifeTime is an autogenerated conversion function.
+func Convert_v1alpha2_PodLifeTime_To_api_PodLifeTime(in *PodLifeTime, out *api.PodLifeTime, s conversion.Scope) error {
+	return autoConvert_v1alpha2_PodLifeTime_To_api_PodLifeTime(in, out, s)
+}
+
+func autoConvert_api_PodLifeTime_To_v1alpha2_PodLifeTime(in *api.PodLifeTime, out *PodLifeTime, s conversion.Scope) error {
+	out.MaxPodLifeTimeSeconds = (*uint)(unsafe.Pointer(in.MaxPodLifeTimeSeconds))
+	out.PodStatusPhases = *(*[]string)(unsafe.Pointer(&in.PodStatusPhases))
+	return nil
+}
+
+// Convert_api_PodLifeTime_To_v1alpha2_PodLifeTime is an autogenerated conversion function.
+func Convert_api_PodLifeTime_To_v1alpha2_PodLifeTime(in *api.PodLifeTime, out *PodLifeTime, s conversion.Scope) error {
+	return autoConvert_api_PodLifeTime_To_v1alpha2_PodLifeTime(in, out, s)
+}
+
+func autoConvert_v1alpha2_PodsHavingTooManyRestarts_To_api_PodsHavingTooManyRestarts(in *PodsHavingTooManyRestarts, out *api.PodsHavingTooManyRestarts, s conversion.Scope) error {
+	out.PodRestartThreshold = in.PodRestartThreshold
+	out.IncludingInitContainers = in.IncludingInitContainers
+	return nil
+}
+
+// Convert_v1alpha2_PodsHavingTooManyRestarts_To_api_PodsHavingTooManyRestarts is an autogenerated conversion function.
+func Convert_v1alpha2_PodsHavingTooManyRestarts_To_api_PodsHavingTooManyRestarts(in *PodsHavingTooManyRestarts, out *api.PodsHavingTooManyRestarts, s conversion.Scope) error {
+	return autoConvert_v1alpha2_PodsHavingTooManyRestarts_To_api_PodsHavingTooManyRestarts(in, out, s)
+}
+
+func autoConvert_api_PodsHavingTooManyRestarts_To_v1alpha2_PodsHavingTooManyRestarts(in *api.PodsHavingTooManyRestarts, out *PodsHavingTooManyRestarts, s conversion.Scope) error {
+	out.PodRestartThreshold = in.PodRestartThreshold
+	out.IncludingInitContainers = in.IncludingInitContainers
+	return nil
+}
+
+// Convert_api_PodsHavingTooManyRestarts_To_v1alpha2_PodsHavingTooManyRestarts is an autogenerated conversion function.
+func Convert_api_PodsHavingTooManyRestarts_To_v1alpha2_PodsHavingTooManyRestarts(in *api.PodsHavingTooManyRestarts, out *PodsHavingTooManyRestarts, s conversion.Scope) error {
+	return autoConvert_api_PodsHavingTooManyRestarts_To_v1alpha2_PodsHavingTooManyRestarts(in, out, s)
+}
+
+func autoConvert_v1alpha2_PolicyReports_To_api_PolicyReports(in *PolicyReports, out *api.PolicyReports, s conversion.Scope) error {
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_v1alpha2_PolicyReports_To_api_PolicyReports is an autogenerated conversion function.
+func Convert_v1alpha2_PolicyReports_To_api_PolicyReports(in *PolicyReports, out *api.PolicyReports, s conversion.Scope) error {
+	return autoConvert_v1alpha2_PolicyReports_To_api_PolicyReports(in, out, s)
+}
+
+func autoConvert_api_PolicyReports_To_v1alpha2_PolicyReports(in *api.PolicyReports, out *PolicyReports, s conversion.Scope) error {
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_api_PolicyReports_To_v1alpha2_PolicyReports is an autogenerated conversion function.
+func Convert_api_PolicyReports_To_v1alpha2_PolicyReports(in *api.PolicyReports, out *PolicyReports, s conversion.Scope) error {
+	return autoConvert_api_PolicyReports_To_v1alpha2_PolicyReports(in, out, s)
+}
+
+func autoConvert_v1alpha2_PriorityBand_To_api_PriorityBand(in *PriorityBand, out *api.PriorityBand, s conversion.Scope) error {
+	out.MinPriority = (*int32)(unsafe.Pointer(in.MinPriority))
+	out.MaxPriority = (*int32)(unsafe.Pointer(in.MaxPriority))
+	out.Thresholds = *(*api.ResourceThresholds)(unsafe.Pointer(&in.Thresholds))
+	out.TargetThresholds = *(*api.ResourceThresholds)(unsafe.Pointer(&in.TargetThresholds))
+	return nil
+}
+
+// Convert_v1alpha2_PriorityBand_To_api_PriorityBand is an autogenerated conversion function.
+func Convert_v1alpha2_PriorityBand_To_api_PriorityBand(in *PriorityBand, out *api.PriorityBand, s conversion.Scope) error {
+	return autoConvert_v1alpha2_PriorityBand_To_api_PriorityBand(in, out, s)
+}
+
+func autoConvert_api_PriorityBand_To_v1alpha2_PriorityBand(in *api.PriorityBand, out *PriorityBand, s conversion.Scope) error {
+	out.MinPriority = (*int32)(unsafe.Pointer(in.MinPriority))
+	out.MaxPriority = (*int32)(unsafe.Pointer(in.MaxPriority))
+	out.Thresholds = *(*ResourceThresholds)(unsafe.Pointer(&in.Thresholds))
+	out.TargetThresholds = *(*ResourceThresholds)(unsafe.Pointer(&in.TargetThresholds))
+	return nil
+}
+
+// Convert_api_PriorityBand_To_v1alpha2_PriorityBand is an autogenerated conversion function.
+func Convert_api_PriorityBand_To_v1alpha2_PriorityBand(in *api.PriorityBand, out *PriorityBand, s conversion.Scope) error {
+	return autoConvert_api_PriorityBand_To_v1alpha2_PriorityBand(in, out, s)
+}
+
+func autoConvert_v1alpha2_PrometheusUtilization_To_api_PrometheusUtilization(in *PrometheusUtilization, out *api.PrometheusUtilization, s conversion.Scope) error {
+	out.URL = in.URL
+	out.BearerTokenFile = in.BearerTokenFile
+	out.TimeoutSeconds = (*uint)(unsafe.Pointer(in.TimeoutSeconds))
+	out.WindowSeconds = (*uint)(unsafe.Pointer(in.WindowSeconds))
+	out.Aggregation = in.Aggregation
+	out.CPUQuery = in.CPUQuery
+	out.MemoryQuery = in.MemoryQuery
+	return nil
+}
+
+// Convert_v1alpha2_PrometheusUtilization_To_api_PrometheusUtilization is an autogenerated conversion function.
+func Convert_v1alpha2_PrometheusUtilization_To_api_PrometheusUtilization(in *PrometheusUtilization, out *api.PrometheusUtilization, s conversion.Scope) error {
+	return autoConvert_v1alpha2_PrometheusUtilization_To_api_PrometheusUtilization(in, out, s)
+}
+
+func autoConvert_api_PrometheusUtilization_To_v1alpha2_PrometheusUtilization(in *api.PrometheusUtilization, out *PrometheusUtilization, s conversion.Scope) error {
+	out.URL = in.URL
+	out.BearerTokenFile = in.BearerTokenFile
+	out.TimeoutSeconds = (*uint)(unsafe.Pointer(in.TimeoutSeconds))
+	out.WindowSeconds = (*uint)(unsafe.Pointer(in.WindowSeconds))
+	out.Aggregation = in.Aggregation
+	out.CPUQuery = in.CPUQuery
+	out.MemoryQuery = in.MemoryQuery
+	return nil
+}
+
+// Convert_api_PrometheusUtilization_To_v1alpha2_PrometheusUtilization is an autogenerated conversion function.
+func Convert_api_PrometheusUtilization_To_v1alpha2_PrometheusUtilization(in *api.PrometheusUtilization, out *PrometheusUtilization, s conversion.Scope) error {
+	return autoConvert_api_PrometheusUtilization_To_v1alpha2_PrometheusUtilization(in, out, s)
+}
+
+func autoConvert_v1alpha2_ProtectedPodCondition_To_api_ProtectedPodCondition(in *ProtectedPodCondition, out *api.ProtectedPodCondition, s conversion.Scope) error {
+	out.Type = corev1.PodConditionType(in.Type)
+	out.Status = corev1.ConditionStatus(in.Status)
+	return nil
+}
+
+// Convert_v1alpha2_ProtectedPodCondition_To_api_ProtectedPodCondition is an autogenerated conversion function.
+func Convert_v1alpha2_ProtectedPodCondition_To_api_ProtectedPodCondition(in *ProtectedPodCondition, out *api.ProtectedPodCondition, s conversion.Scope) error {
+	return autoConvert_v1alpha2_ProtectedPodCondition_To_api_ProtectedPodCondition(in, out, s)
+}
+
+func autoConvert_api_ProtectedPodCondition_To_v1alpha2_ProtectedPodCondition(in *api.ProtectedPodCondition, out *ProtectedPodCondition, s conversion.Scope) error {
+	out.Type = corev1.PodConditionType(in.Type)
+	out.Status = corev1.ConditionStatus(in.Status)
+	return nil
+}
+
+// Convert_api_ProtectedPodCondition_To_v1alpha2_ProtectedPodCondition is an autogenerated conversion function.
+func Convert_api_ProtectedPodCondition_To_v1alpha2_ProtectedPodCondition(in *api.ProtectedPodCondition, out *ProtectedPodCondition, s conversion.Scope) error {
+	return autoConvert_api_ProtectedPodCondition_To_v1alpha2_ProtectedPodCondition(in, out, s)
+}
+
+func autoConvert_v1alpha2_RemoteWrite_To_api_RemoteWrite(in *RemoteWrite, out *api.RemoteWrite, s conversion.Scope) error {
+	out.URL = in.URL
+	out.BearerTokenFile = in.BearerTokenFile
+	out.TimeoutSeconds = (*uint)(unsafe.Pointer(in.TimeoutSeconds))
+	return nil
+}
+
+// Convert_v1alpha2_RemoteWrite_To_api_RemoteWrite is an autogenerated conversion function.
+func Convert_v1alpha2_RemoteWrite_To_api_RemoteWrite(in *RemoteWrite, out *api.RemoteWrite, s conversion.Scope) error {
+	return autoConvert_v1alpha2_RemoteWrite_To_api_RemoteWrite(in, out, s)
+}
+
+func autoConvert_api_RemoteWrite_To_v1alpha2_RemoteWrite(in *api.RemoteWrite, out *RemoteWrite, s conversion.Scope) error {
+	out.URL = in.URL
+	out.BearerTokenFile = in.BearerTokenFile
+	out.TimeoutSeconds = (*uint)(unsafe.Pointer(in.TimeoutSeconds))
+	return nil
+}
+
+// Convert_api_RemoteWrite_To_v1alpha2_RemoteWrite is an autogenerated conversion function.
+func Convert_api_RemoteWrite_To_v1alpha2_RemoteWrite(in *api.RemoteWrite, out *RemoteWrite, s conversion.Scope) error {
+	return autoConvert_api_RemoteWrite_To_v1alpha2_RemoteWrite(in, out, s)
+}
+
+func autoConvert_v1alpha2_RemoveDuplicates_To_api_RemoveDuplicates(in *RemoveDuplicates, out *api.RemoveDuplicates, s conversion.Scope) error {
+	out.ExcludeOwnerKinds = *(*[]string)(unsafe.Pointer(&in.ExcludeOwnerKinds))
+	return nil
+}
+
+// Convert_v1alpha2_RemoveDuplicates_To_api_RemoveDuplicates is an autogenerated conversion function.
+func Convert_v1alpha2_RemoveDuplicates_To_api_RemoveDuplicates(in *RemoveDuplicates, out *api.RemoveDuplicates, s conversion.Scope) error {
+	return autoConvert_v1alpha2_RemoveDuplicates_To_api_RemoveDuplicates(in, out, s)
+}
+
+func autoConvert_api_RemoveDuplicates_To_v1alpha2_RemoveDuplicates(in *api.RemoveDuplicates, out *RemoveDuplicates, s conversion.Scope) error {
+	out.ExcludeOwnerKinds = *(*[]string)(unsafe.Pointer(&in.ExcludeOwnerKinds))
+	return nil
+}
+
+// Convert_api_RemoveDuplicates_To_v1alpha2_RemoveDuplicates is an autogenerated conversion function.
+func Convert_api_RemoveDuplicates_To_v1alpha2_RemoveDuplicates(in *api.RemoveDuplicates, out *RemoveDuplicates, s conversion.Scope) error {
+	return autoConvert_api_RemoveDuplicates_To_v1alpha2_RemoveDuplicates(in, out, s)
+}
+
+func autoConvert_v1alpha2_RestartChurn_To_api_RestartChurn(in *RestartChurn, out *api.RestartChurn, s conversion.Scope) error {
+	out.NodeRestartThreshold = in.NodeRestartThreshold
+	out.WindowSeconds = (*uint)(unsafe.Pointer(in.WindowSeconds))
+	out.IncludingInitContainers = in.IncludingInitContainers
+	out.MaxPodsToEvictPerNode = in.MaxPodsToEvictPerNode
+	return nil
+}
+
+// Convert_v1alpha2_RestartChurn_To_api_RestartChurn is an autogenerated conversion function.
+func Convert_v1alpha2_RestartChurn_To_api_RestartChurn(in *RestartChurn, out *api.RestartChurn, s conversion.Scope) error {
+	return autoConvert_v1alpha2_RestartChurn_To_api_RestartChurn(in, out, s)
+}
+
+func autoConvert_api_RestartChurn_To_v1alpha2_RestartChurn(in *api.RestartChurn, out *RestartChurn, s conversion.Scope) error {
+	out.NodeRestartThreshold = in.NodeRestartThreshold
+	out.WindowSeconds = (*uint)(unsafe.Pointer(in.WindowSeconds))
+	out.IncludingInitContainers = in.IncludingInitContainers
+	out.MaxPodsToEvictPerNode = in.MaxPodsToEvictPerNode
+	return nil
+}
+
+// Convert_api_RestartChurn_To_v1alpha2_RestartChurn is an autogenerated conversion function.
+func Convert_api_RestartChurn_To_v1alpha2_RestartChurn(in *api.RestartChurn, out *RestartChurn, s conversion.Scope) error {
+	return autoConvert_api_RestartChurn_To_v1alpha2_RestartChurn(in, out, s)
+}
+
+func autoConvert_v1alpha2_StrategyParameters_To_api_StrategyParameters(in *StrategyParameters, out *api.StrategyParameters, s conversion.Scope) error {
+	out.NodeResourceUtilizationThresholds = (*api.NodeResourceUtilizationThresholds)(unsafe.Pointer(in.NodeResourceUtilizationThresholds))
+	out.NodeAffinityType = *(*[]string)(unsafe.Pointer(&in.NodeAffinityType))
+	out.PodsHavingTooManyRestarts = (*api.PodsHavingTooManyRestarts)(unsafe.Pointer(in.PodsHavingTooManyRestarts))
+	out.PodLifeTime = (*api.PodLifeTime)(unsafe.Pointer(in.PodLifeTime))
+	out.RemoveDuplicates = (*api.RemoveDuplicates)(unsafe.Pointer(in.RemoveDuplicates))
+	out.FailedPods = (*api.FailedPods)(unsafe.Pointer(in.FailedPods))
+	out.AntiColocation = (*api.AntiColocation)(unsafe.Pointer(in.AntiColocation))
+	out.PodDensity = (*api.PodDensity)(unsafe.Pointer(in.PodDensity))
+	out.NodeProblems = (*api.NodeProblems)(unsafe.Pointer(in.NodeProblems))
+	out.LimitsOvercommit = (*api.LimitsOvercommit)(unsafe.Pointer(in.LimitsOvercommit))
+	out.FinishedJobPods = (*api.FinishedJobPods)(unsafe.Pointer(in.FinishedJobPods))
+	out.MemoryOverrun = (*api.MemoryOverrun)(unsafe.Pointer(in.MemoryOverrun))
+	out.NodeInterruption = (*api.NodeInterruption)(unsafe.Pointer(in.NodeInterruption))
+	out.StuckPods = (*api.StuckPods)(unsafe.Pointer(in.StuckPods))
+	out.ImageLocality = (*api.ImageLocality)(unsafe.Pointer(in.ImageLocality))
+	out.NotReadyNodes = (*api.NotReadyNodes)(unsafe.Pointer(in.NotReadyNodes))
+	out.GPUModelPreference = (*api.GPUModelPreference)(unsafe.Pointer(in.GPUModelPreference))
+	out.TerminatingPods = (*api.TerminatingPods)(unsafe.Pointer(in.TerminatingPods))
+	out.RestartChurn = (*api.RestartChurn)(unsafe.Pointer(in.RestartChurn))
+	out.IncludeSoftConstraints = in.IncludeSoftConstraints
+	out.MaxSkewReductionPerCycle = in.MaxSkewReductionPerCycle
+	out.TopologyBalanceDomains = *(*[]string)(unsafe.Pointer(&in.TopologyBalanceDomains))
+	out.Namespaces = (*api.Namespaces)(unsafe.Pointer(in.Namespaces))
+	out.NamespaceSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NamespaceSelector))
+	out.ThresholdPriority = (*int32)(unsafe.Pointer(in.ThresholdPriority))
+	out.ThresholdPriorityClassName = in.ThresholdPriorityClassName
+	out.LabelSelector = (*v1.LabelSelector)(unsafe.Pointer(in.LabelSelector))
+	out.NodeFit = in.NodeFit
+	out.IncludeCordonedNodes = (*bool)(unsafe.Pointer(in.IncludeCordonedNodes))
+	out.NodeRoles = *(*[]string)(unsafe.Pointer(&in.NodeRoles))
+	out.PluginArgs = (*runtime.RawExtension)(unsafe.Pointer(in.PluginArgs))
+	return nil
+}
+
+// Convert_v1alpha2_StrategyParameters_To_api_StrategyParameters is an autogenerated conversion function.
+func Convert_v1alpha2_StrategyParameters_To_api_StrategyParameters(in *StrategyParameters, out *api.StrategyParameters, s conversion.Scope) error {
+	return autoConvert_v1alpha2_StrategyParameters_To_api_StrategyParameters(in, out, s)
+}
+
+func autoConvert_api_StrategyParameters_To_v1alpha2_StrategyParameters(in *api.StrategyParameters, out *StrategyParameters, s conversion.Scope) error {
+	out.NodeResourceUtilizationThresholds = (*NodeResourceUtilizationThresholds)(unsafe.Pointer(in.NodeResourceUtilizationThresholds))
+	out.NodeAffinityType = *(*[]string)(unsafe.Pointer(&in.NodeAffinityType))
+	out.PodsHavingTooManyRestarts = (*PodsHavingTooManyRestarts)(unsafe.Pointer(in.PodsHavingTooManyRestarts))
+	out.PodLifeTime = (*PodLifeTime)(unsafe.Pointer(in.PodLifeTime))
+	out.RemoveDuplicates = (*RemoveDuplicates)(unsafe.Pointer(in.RemoveDuplicates))
+	out.FailedPods = (*FailedPods)(unsafe.Pointer(in.FailedPods))
+	out.AntiColocation = (*AntiColocation)(unsafe.Pointer(in.AntiColocation))
+	out.PodDensity = (*PodDensity)(unsafe.Pointer(in.PodDensity))
+	out.NodeProblems = (*NodeProblems)(unsafe.Pointer(in.NodeProblems))
+	out.LimitsOvercommit = (*LimitsOvercommit)(unsafe.Pointer(in.LimitsOvercommit))
+	out.FinishedJobPods = (*FinishedJobPods)(unsafe.Pointer(in.FinishedJobPods))
+	out.MemoryOverrun = (*MemoryOverrun)(unsafe.Pointer(in.MemoryOverrun))
+	out.NodeInterruption = (*NodeInterruption)(unsafe.Pointer(in.NodeInterruption))
+	out.StuckPods = (*StuckPods)(unsafe.Pointer(in.StuckPods))
+	out.ImageLocality = (*ImageLocality)(unsafe.Pointer(in.ImageLocality))
+	out.NotReadyNodes = (*NotReadyNodes)(unsafe.Pointer(in.NotReadyNodes))
+	out.GPUModelPreference = (*GPUModelPreference)(unsafe.Pointer(in.GPUModelPreference))
+	out.TerminatingPods = (*TerminatingPods)(unsafe.Pointer(in.TerminatingPods))
+	out.RestartChurn = (*RestartChurn)(unsafe.Pointer(in.RestartChurn))
+	out.IncludeSoftConstraints = in.IncludeSoftConstraints
+	out.MaxSkewReductionPerCycle = in.MaxSkewReductionPerCycle
+	out.TopologyBalanceDomains = *(*[]string)(unsafe.Pointer(&in.TopologyBalanceDomains))
+	out.Namespaces = (*Namespaces)(unsafe.Pointer(in.Namespaces))
+	out.NamespaceSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NamespaceSelector))
+	out.ThresholdPriority = (*int32)(unsafe.Pointer(in.ThresholdPriority))
+	out.ThresholdPriorityClassName = in.ThresholdPriorityClassName
+	out.LabelSelector = (*v1.LabelSelector)(unsafe.Pointer(in.LabelSelector))
+	out.NodeFit = in.NodeFit
+	out.IncludeCordonedNodes = (*bool)(unsafe.Pointer(in.IncludeCordonedNodes))
+	out.NodeRoles = *(*[]string)(unsafe.Pointer(&in.NodeRoles))
+	out.PluginArgs = (*runtime.RawExtension)(unsafe.Pointer(in.PluginArgs))
+	return nil
+}
+
+// Convert_api_StrategyParameters_To_v1alpha2_StrategyParameters is an autogenerated conversion function.
+func Convert_api_StrategyParameters_To_v1alpha2_StrategyParameters(in *api.StrategyParameters, out *StrategyParameters, s conversion.Scope) error {
+	return autoConvert_api_StrategyParameters_To_v1alpha2_StrategyParameters(in, out, s)
+}
+
+func autoConvert_v1alpha2_StuckPods_To_api_StuckPods(in *StuckPods, out *api.StuckPods, s conversion.Scope) error {
+	out.MaxStuckSeconds = (*uint)(unsafe.Pointer(in.MaxStuckSeconds))
+	out.Reasons = *(*[]string)(unsafe.Pointer(&in.Reasons))
+	return nil
+}
+
+// Convert_v1alpha2_StuckPods_To_api_StuckPods is an autogenerated conversion function.
+func Convert_v1alpha2_StuckPods_To_api_StuckPods(in *StuckPods, out *api.StuckPods, s conversion.Scope) error {
+	return autoConvert_v1alpha2_StuckPods_To_api_StuckPods(in, out, s)
+}
+
+func autoConvert_api_StuckPods_To_v1alpha2_StuckPods(in *api.StuckPods, out *StuckPods, s conversion.Scope) error {
+	out.MaxStuckSeconds = (*uint)(unsafe.Pointer(in.MaxStuckSeconds))
+	out.Reasons = *(*[]string)(unsafe.Pointer(&in.Reasons))
+	return nil
+}
+
+// Convert_api_StuckPods_To_v1alpha2_StuckPods is an autogenerated conversion function.
+func Convert_api_StuckPods_To_v1alpha2_StuckPods(in *api.StuckPods, out *StuckPods, s conversion.Scope) error {
+	return autoConvert_api_StuckPods_To_v1alpha2_StuckPods(in, out, s)
+}
+
+func autoConvert_v1alpha2_TerminatingPods_To_api_TerminatingPods(in *TerminatingPods, out *api.TerminatingPods, s conversion.Scope) error {
+	out.MaxTerminatingSeconds = (*uint)(unsafe.Pointer(in.MaxTerminatingSeconds))
+	out.ForceDelete = in.ForceDelete
+	return nil
+}
+
+// Convert_v1alpha2_TerminatingPods_To_api_TerminatingPods is an autogenerated conversion function.
+func Convert_v1alpha2_TerminatingPods_To_api_TerminatingPods(in *TerminatingPods, out *api.TerminatingPods, s conversion.Scope) error {
+	return autoConvert_v1alpha2_TerminatingPods_To_api_TerminatingPods(in, out, s)
+}
+
+func autoConvert_api_TerminatingPods_To_v1alpha2_TerminatingPods(in *api.TerminatingPods, out *TerminatingPods, s conversion.Scope) error {
+	out.MaxTerminatingSeconds = (*uint)(unsafe.Pointer(in.MaxTerminatingSeconds))
+	out.ForceDelete = in.ForceDelete
+	return nil
+}
+
+// Convert_api_TerminatingPods_To_v1alpha2_TerminatingPods is an autogenerated conversion function.
+func Convert_api_TerminatingPods_To_v1alpha2_TerminatingPods(in *api.TerminatingPods, out *TerminatingPods, s conversion.Scope) error {
+	return autoConvert_api_TerminatingPods_To_v1alpha2_TerminatingPods(in, out, s)
+}
+
+func autoConvert_v1alpha2_UsageTrend_To_api_UsageTrend(in *UsageTrend, out *api.UsageTrend, s conversion.Scope) error {
+	out.HorizonSeconds = in.HorizonSeconds
+	out.WindowSeconds = (*uint)(unsafe.Pointer(in.WindowSeconds))
+	out.MinSamples = (*uint)(unsafe.Pointer(in.MinSamples))
+	out.Resources = *(*[]corev1.ResourceName)(unsafe.Pointer(&in.Resources))
+	return nil
+}
+
+// Convert_v1alpha2_UsageTrend_To_api_UsageTrend is an autogenerated conversion function.
+func Convert_v1alpha2_UsageTrend_To_api_UsageTrend(in *UsageTrend, out *api.UsageTrend, s conversion.Scope) error {
+	return autoConvert_v1alpha2_UsageTrend_To_api_UsageTrend(in, out, s)
+}
+
+func autoConvert_api_UsageTrend_To_v1alpha2_UsageTrend(in *api.UsageTrend, out *UsageTrend, s conversion.Scope) error {
+	out.HorizonSeconds = in.HorizonSeconds
+	out.WindowSeconds = (*uint)(unsafe.Pointer(in.WindowSeconds))
+	out.MinSamples = (*uint)(unsafe.Pointer(in.MinSamples))
+	out.Resources = *(*[]corev1.ResourceName)(unsafe.Pointer(&in.Resources))
+	return nil
+}
+
+// Convert_api_UsageTrend_To_v1alpha2_UsageTrend is an autogenerated conversion function.
+func Convert_api_UsageTrend_To_v1alpha2_UsageTrend(in *api.UsageTrend, out *UsageTrend, s conversion.Scope) error {
+	return autoConvert_api_UsageTrend_To_v1alpha2_UsageTrend(in, out, s)
+}
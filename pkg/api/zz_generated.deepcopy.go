@@ -21,10 +21,102 @@ limitations under the License.
 package api
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AntiColocation) DeepCopyInto(out *AntiColocation) {
+	*out = *in
+	if in.Pairs != nil {
+		in, out := &in.Pairs, &out.Pairs
+		*out = make([]AntiColocationPair, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AntiColocation.
+func (in *AntiColocation) DeepCopy() *AntiColocation {
+	if in == nil {
+		return nil
+	}
+	out := new(AntiColocation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AntiColocationPair) DeepCopyInto(out *AntiColocationPair) {
+	*out = *in
+	if in.Keep != nil {
+		in, out := &in.Keep, &out.Keep
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Evict != nil {
+		in, out := &in.Evict, &out.Evict
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AntiColocationPair.
+func (in *AntiColocationPair) DeepCopy() *AntiColocationPair {
+	if in == nil {
+		return nil
+	}
+	out := new(AntiColocationPair)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CooldownPersistence) DeepCopyInto(out *CooldownPersistence) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CooldownPersistence.
+func (in *CooldownPersistence) DeepCopy() *CooldownPersistence {
+	if in == nil {
+		return nil
+	}
+	out := new(CooldownPersistence)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CycleAbort) DeepCopyInto(out *CycleAbort) {
+	*out = *in
+	if in.MinEvictionAttempts != nil {
+		in, out := &in.MinEvictionAttempts, &out.MinEvictionAttempts
+		*out = new(uint)
+		**out = **in
+	}
+	if in.MaxBackoffSeconds != nil {
+		in, out := &in.MaxBackoffSeconds, &out.MaxBackoffSeconds
+		*out = new(uint)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CycleAbort.
+func (in *CycleAbort) DeepCopy() *CycleAbort {
+	if in == nil {
+		return nil
+	}
+	out := new(CycleAbort)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DeschedulerPolicy) DeepCopyInto(out *DeschedulerPolicy) {
 	*out = *in
@@ -46,6 +138,11 @@ func (in *DeschedulerPolicy) DeepCopyInto(out *DeschedulerPolicy) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.EvictableEmptyDirSizeLimit != nil {
+		in, out := &in.EvictableEmptyDirSizeLimit, &out.EvictableEmptyDirSizeLimit
+		x := (*in).DeepCopy()
+		*out = &x
+	}
 	if in.EvictSystemCriticalPods != nil {
 		in, out := &in.EvictSystemCriticalPods, &out.EvictSystemCriticalPods
 		*out = new(bool)
@@ -56,113 +153,867 @@ func (in *DeschedulerPolicy) DeepCopyInto(out *DeschedulerPolicy) {
 		*out = new(bool)
 		**out = **in
 	}
-	if in.MaxNoOfPodsToEvictPerNode != nil {
-		in, out := &in.MaxNoOfPodsToEvictPerNode, &out.MaxNoOfPodsToEvictPerNode
-		*out = new(int)
+	if in.MaxNoOfPodsToEvictPerNode != nil {
+		in, out := &in.MaxNoOfPodsToEvictPerNode, &out.MaxNoOfPodsToEvictPerNode
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxPerOwnerPerNode != nil {
+		in, out := &in.MaxPerOwnerPerNode, &out.MaxPerOwnerPerNode
+		*out = new(int)
+		**out = **in
+	}
+	if in.HealthGates != nil {
+		in, out := &in.HealthGates, &out.HealthGates
+		*out = new(HealthGates)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReschedulingHints != nil {
+		in, out := &in.ReschedulingHints, &out.ReschedulingHints
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EvictionHistory != nil {
+		in, out := &in.EvictionHistory, &out.EvictionHistory
+		*out = new(EvictionHistory)
+		**out = **in
+	}
+	if in.PolicyReports != nil {
+		in, out := &in.PolicyReports, &out.PolicyReports
+		*out = new(PolicyReports)
+		**out = **in
+	}
+	if in.RemoteWrite != nil {
+		in, out := &in.RemoteWrite, &out.RemoteWrite
+		*out = new(RemoteWrite)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReplacementReadinessTimeoutSeconds != nil {
+		in, out := &in.ReplacementReadinessTimeoutSeconds, &out.ReplacementReadinessTimeoutSeconds
+		*out = new(uint)
+		**out = **in
+	}
+	if in.VolumeDetachTimeoutSeconds != nil {
+		in, out := &in.VolumeDetachTimeoutSeconds, &out.VolumeDetachTimeoutSeconds
+		*out = new(uint)
+		**out = **in
+	}
+	if in.PodEvictionCooldownSeconds != nil {
+		in, out := &in.PodEvictionCooldownSeconds, &out.PodEvictionCooldownSeconds
+		*out = new(uint)
+		**out = **in
+	}
+	if in.CooldownPersistence != nil {
+		in, out := &in.CooldownPersistence, &out.CooldownPersistence
+		*out = new(CooldownPersistence)
+		**out = **in
+	}
+	if in.ProtectedDeployments != nil {
+		in, out := &in.ProtectedDeployments, &out.ProtectedDeployments
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.InteractiveSessionLookbackSeconds != nil {
+		in, out := &in.InteractiveSessionLookbackSeconds, &out.InteractiveSessionLookbackSeconds
+		*out = new(uint)
+		**out = **in
+	}
+	if in.ProtectedPodConditions != nil {
+		in, out := &in.ProtectedPodConditions, &out.ProtectedPodConditions
+		*out = make([]ProtectedPodCondition, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeDeletionTrigger != nil {
+		in, out := &in.NodeDeletionTrigger, &out.NodeDeletionTrigger
+		*out = new(NodeDeletionTrigger)
+		**out = **in
+	}
+	if in.EvictionFilter != nil {
+		in, out := &in.EvictionFilter, &out.EvictionFilter
+		*out = new(PodFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SkipOwnersRollingOut != nil {
+		in, out := &in.SkipOwnersRollingOut, &out.SkipOwnersRollingOut
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NamespaceRules != nil {
+		in, out := &in.NamespaceRules, &out.NamespaceRules
+		*out = new(NamespaceRules)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EvictedPodAnnotations != nil {
+		in, out := &in.EvictedPodAnnotations, &out.EvictedPodAnnotations
+		*out = new(EvictedPodAnnotations)
+		**out = **in
+	}
+	if in.Profiles != nil {
+		in, out := &in.Profiles, &out.Profiles
+		*out = make([]DeschedulerProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CycleAbort != nil {
+		in, out := &in.CycleAbort, &out.CycleAbort
+		*out = new(CycleAbort)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EvictionLimits != nil {
+		in, out := &in.EvictionLimits, &out.EvictionLimits
+		*out = new(EvictionLimits)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeschedulerPolicy.
+func (in *DeschedulerPolicy) DeepCopy() *DeschedulerPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(DeschedulerPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DeschedulerPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeschedulerProfile) DeepCopyInto(out *DeschedulerProfile) {
+	*out = *in
+	if in.Strategies != nil {
+		in, out := &in.Strategies, &out.Strategies
+		*out = make(StrategyList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = new(string)
+		**out = **in
+	}
+	if in.MaxNoOfPodsToEvictPerNode != nil {
+		in, out := &in.MaxNoOfPodsToEvictPerNode, &out.MaxNoOfPodsToEvictPerNode
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxPerOwnerPerNode != nil {
+		in, out := &in.MaxPerOwnerPerNode, &out.MaxPerOwnerPerNode
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeschedulerProfile.
+func (in *DeschedulerProfile) DeepCopy() *DeschedulerProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(DeschedulerProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeschedulerStrategy) DeepCopyInto(out *DeschedulerStrategy) {
+	*out = *in
+	if in.LogVerbosity != nil {
+		in, out := &in.LogVerbosity, &out.LogVerbosity
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(uint)
+		**out = **in
+	}
+	if in.EvictionLimits != nil {
+		in, out := &in.EvictionLimits, &out.EvictionLimits
+		*out = new(EvictionLimits)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = new(StrategyParameters)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeschedulerStrategy.
+func (in *DeschedulerStrategy) DeepCopy() *DeschedulerStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(DeschedulerStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EvictedPodAnnotations) DeepCopyInto(out *EvictedPodAnnotations) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EvictedPodAnnotations.
+func (in *EvictedPodAnnotations) DeepCopy() *EvictedPodAnnotations {
+	if in == nil {
+		return nil
+	}
+	out := new(EvictedPodAnnotations)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EvictionHistory) DeepCopyInto(out *EvictionHistory) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EvictionHistory.
+func (in *EvictionHistory) DeepCopy() *EvictionHistory {
+	if in == nil {
+		return nil
+	}
+	out := new(EvictionHistory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EvictionLimits) DeepCopyInto(out *EvictionLimits) {
+	*out = *in
+	if in.MaxNoOfPodsToEvictPerCycle != nil {
+		in, out := &in.MaxNoOfPodsToEvictPerCycle, &out.MaxNoOfPodsToEvictPerCycle
+		*out = new(uint)
+		**out = **in
+	}
+	if in.MaxNoOfPodsToEvictPerNamespace != nil {
+		in, out := &in.MaxNoOfPodsToEvictPerNamespace, &out.MaxNoOfPodsToEvictPerNamespace
+		*out = new(uint)
+		**out = **in
+	}
+	if in.EvictionsPerSecond != nil {
+		in, out := &in.EvictionsPerSecond, &out.EvictionsPerSecond
+		*out = new(float64)
+		**out = **in
+	}
+	if in.EvictionBurst != nil {
+		in, out := &in.EvictionBurst, &out.EvictionBurst
+		*out = new(uint)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EvictionLimits.
+func (in *EvictionLimits) DeepCopy() *EvictionLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(EvictionLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailedPods) DeepCopyInto(out *FailedPods) {
+	*out = *in
+	if in.ExcludeOwnerKinds != nil {
+		in, out := &in.ExcludeOwnerKinds, &out.ExcludeOwnerKinds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MinPodLifetimeSeconds != nil {
+		in, out := &in.MinPodLifetimeSeconds, &out.MinPodLifetimeSeconds
+		*out = new(uint)
+		**out = **in
+	}
+	if in.Reasons != nil {
+		in, out := &in.Reasons, &out.Reasons
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedPods.
+func (in *FailedPods) DeepCopy() *FailedPods {
+	if in == nil {
+		return nil
+	}
+	out := new(FailedPods)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FinishedJobPods) DeepCopyInto(out *FinishedJobPods) {
+	*out = *in
+	if in.TTLSecondsAfterFinished != nil {
+		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
+		*out = new(uint)
+		**out = **in
+	}
+	if in.ExcludeOwnerKinds != nil {
+		in, out := &in.ExcludeOwnerKinds, &out.ExcludeOwnerKinds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FinishedJobPods.
+func (in *FinishedJobPods) DeepCopy() *FinishedJobPods {
+	if in == nil {
+		return nil
+	}
+	out := new(FinishedJobPods)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GPUModelPreference) DeepCopyInto(out *GPUModelPreference) {
+	*out = *in
+	if in.ModelPriorities != nil {
+		in, out := &in.ModelPriorities, &out.ModelPriorities
+		*out = make([]GPUModelPriority, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPUModelPreference.
+func (in *GPUModelPreference) DeepCopy() *GPUModelPreference {
+	if in == nil {
+		return nil
+	}
+	out := new(GPUModelPreference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GPUModelPriority) DeepCopyInto(out *GPUModelPriority) {
+	*out = *in
+	if in.PodSelector != nil {
+		in, out := &in.PodSelector, &out.PodSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Models != nil {
+		in, out := &in.Models, &out.Models
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPUModelPriority.
+func (in *GPUModelPriority) DeepCopy() *GPUModelPriority {
+	if in == nil {
+		return nil
+	}
+	out := new(GPUModelPriority)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthGates) DeepCopyInto(out *HealthGates) {
+	*out = *in
+	if in.MaxNotReadyNodesPercentage != nil {
+		in, out := &in.MaxNotReadyNodesPercentage, &out.MaxNotReadyNodesPercentage
+		*out = new(Percentage)
+		**out = **in
+	}
+	if in.MaxPendingPods != nil {
+		in, out := &in.MaxPendingPods, &out.MaxPendingPods
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxKubeletRestarts != nil {
+		in, out := &in.MaxKubeletRestarts, &out.MaxKubeletRestarts
+		*out = new(int)
+		**out = **in
+	}
+	if in.KubeletRestartsWindowSeconds != nil {
+		in, out := &in.KubeletRestartsWindowSeconds, &out.KubeletRestartsWindowSeconds
+		*out = new(uint)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthGates.
+func (in *HealthGates) DeepCopy() *HealthGates {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthGates)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageLocality) DeepCopyInto(out *ImageLocality) {
+	*out = *in
+	if in.MinImageSizeMB != nil {
+		in, out := &in.MinImageSizeMB, &out.MinImageSizeMB
+		*out = new(uint)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageLocality.
+func (in *ImageLocality) DeepCopy() *ImageLocality {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageLocality)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LimitsOvercommit) DeepCopyInto(out *LimitsOvercommit) {
+	*out = *in
+	if in.Thresholds != nil {
+		in, out := &in.Thresholds, &out.Thresholds
+		*out = make(ResourceThresholds, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LimitsOvercommit.
+func (in *LimitsOvercommit) DeepCopy() *LimitsOvercommit {
+	if in == nil {
+		return nil
+	}
+	out := new(LimitsOvercommit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemoryOverrun) DeepCopyInto(out *MemoryOverrun) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemoryOverrun.
+func (in *MemoryOverrun) DeepCopy() *MemoryOverrun {
+	if in == nil {
+		return nil
+	}
+	out := new(MemoryOverrun)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceRules) DeepCopyInto(out *NamespaceRules) {
+	*out = *in
+	if in.AllowedStrategies != nil {
+		in, out := &in.AllowedStrategies, &out.AllowedStrategies
+		*out = make([]StrategyName, len(*in))
+		copy(*out, *in)
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = new(Namespaces)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MinPodLifeTimeSeconds != nil {
+		in, out := &in.MinPodLifeTimeSeconds, &out.MinPodLifeTimeSeconds
+		*out = new(uint)
+		**out = **in
+	}
+	if in.MinPodRestartThreshold != nil {
+		in, out := &in.MinPodRestartThreshold, &out.MinPodRestartThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceRules.
+func (in *NamespaceRules) DeepCopy() *NamespaceRules {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceRules)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Namespaces) DeepCopyInto(out *Namespaces) {
+	*out = *in
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Namespaces.
+func (in *Namespaces) DeepCopy() *Namespaces {
+	if in == nil {
+		return nil
+	}
+	out := new(Namespaces)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeDeletionTrigger) DeepCopyInto(out *NodeDeletionTrigger) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeDeletionTrigger.
+func (in *NodeDeletionTrigger) DeepCopy() *NodeDeletionTrigger {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeDeletionTrigger)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeInterruption) DeepCopyInto(out *NodeInterruption) {
+	*out = *in
+	if in.Taints != nil {
+		in, out := &in.Taints, &out.Taints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeInterruption.
+func (in *NodeInterruption) DeepCopy() *NodeInterruption {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeInterruption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeProblems) DeepCopyInto(out *NodeProblems) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeProblems.
+func (in *NodeProblems) DeepCopy() *NodeProblems {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeProblems)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeResourceUtilizationThresholds) DeepCopyInto(out *NodeResourceUtilizationThresholds) {
+	*out = *in
+	if in.Thresholds != nil {
+		in, out := &in.Thresholds, &out.Thresholds
+		*out = make(ResourceThresholds, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.TargetThresholds != nil {
+		in, out := &in.TargetThresholds, &out.TargetThresholds
+		*out = make(ResourceThresholds, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.WarningThresholds != nil {
+		in, out := &in.WarningThresholds, &out.WarningThresholds
+		*out = make(ResourceThresholds, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ExcludedContainers != nil {
+		in, out := &in.ExcludedContainers, &out.ExcludedContainers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PriorityBands != nil {
+		in, out := &in.PriorityBands, &out.PriorityBands
+		*out = make([]PriorityBand, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RelieveResources != nil {
+		in, out := &in.RelieveResources, &out.RelieveResources
+		*out = make([]corev1.ResourceName, len(*in))
+		copy(*out, *in)
+	}
+	if in.Prometheus != nil {
+		in, out := &in.Prometheus, &out.Prometheus
+		*out = new(PrometheusUtilization)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Trend != nil {
+		in, out := &in.Trend, &out.Trend
+		*out = new(UsageTrend)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CountTerminatingPods != nil {
+		in, out := &in.CountTerminatingPods, &out.CountTerminatingPods
+		*out = new(bool)
+		**out = **in
+	}
+	if in.UnschedulableNodesAsSources != nil {
+		in, out := &in.UnschedulableNodesAsSources, &out.UnschedulableNodesAsSources
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeResourceUtilizationThresholds.
+func (in *NodeResourceUtilizationThresholds) DeepCopy() *NodeResourceUtilizationThresholds {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeResourceUtilizationThresholds)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotReadyNodes) DeepCopyInto(out *NotReadyNodes) {
+	*out = *in
+	if in.NotReadySeconds != nil {
+		in, out := &in.NotReadySeconds, &out.NotReadySeconds
+		*out = new(uint)
+		**out = **in
+	}
+	if in.ForceDeleteAfterSeconds != nil {
+		in, out := &in.ForceDeleteAfterSeconds, &out.ForceDeleteAfterSeconds
+		*out = new(uint)
 		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeschedulerPolicy.
-func (in *DeschedulerPolicy) DeepCopy() *DeschedulerPolicy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotReadyNodes.
+func (in *NotReadyNodes) DeepCopy() *NotReadyNodes {
 	if in == nil {
 		return nil
 	}
-	out := new(DeschedulerPolicy)
+	out := new(NotReadyNodes)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DeschedulerPolicy) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DeschedulerStrategy) DeepCopyInto(out *DeschedulerStrategy) {
+func (in *PodDensity) DeepCopyInto(out *PodDensity) {
 	*out = *in
-	if in.Params != nil {
-		in, out := &in.Params, &out.Params
-		*out = new(StrategyParameters)
+	if in.LabelSelector != nil {
+		in, out := &in.LabelSelector, &out.LabelSelector
+		*out = new(v1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeschedulerStrategy.
-func (in *DeschedulerStrategy) DeepCopy() *DeschedulerStrategy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodDensity.
+func (in *PodDensity) DeepCopy() *PodDensity {
 	if in == nil {
 		return nil
 	}
-	out := new(DeschedulerStrategy)
+	out := new(PodDensity)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FailedPods) DeepCopyInto(out *FailedPods) {
+func (in *PodFilter) DeepCopyInto(out *PodFilter) {
 	*out = *in
-	if in.ExcludeOwnerKinds != nil {
-		in, out := &in.ExcludeOwnerKinds, &out.ExcludeOwnerKinds
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.MinPodLifetimeSeconds != nil {
-		in, out := &in.MinPodLifetimeSeconds, &out.MinPodLifetimeSeconds
-		*out = new(uint)
+	if in.LabelSelector != nil {
+		in, out := &in.LabelSelector, &out.LabelSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MinPriority != nil {
+		in, out := &in.MinPriority, &out.MinPriority
+		*out = new(int32)
 		**out = **in
 	}
-	if in.Reasons != nil {
-		in, out := &in.Reasons, &out.Reasons
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.MaxPriority != nil {
+		in, out := &in.MaxPriority, &out.MaxPriority
+		*out = new(int32)
+		**out = **in
+	}
+	if in.AllOf != nil {
+		in, out := &in.AllOf, &out.AllOf
+		*out = make([]PodFilter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AnyOf != nil {
+		in, out := &in.AnyOf, &out.AnyOf
+		*out = make([]PodFilter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NoneOf != nil {
+		in, out := &in.NoneOf, &out.NoneOf
+		*out = make([]PodFilter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedPods.
-func (in *FailedPods) DeepCopy() *FailedPods {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodFilter.
+func (in *PodFilter) DeepCopy() *PodFilter {
 	if in == nil {
 		return nil
 	}
-	out := new(FailedPods)
+	out := new(PodFilter)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Namespaces) DeepCopyInto(out *Namespaces) {
+func (in *PodLifeTime) DeepCopyInto(out *PodLifeTime) {
 	*out = *in
-	if in.Include != nil {
-		in, out := &in.Include, &out.Include
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.MaxPodLifeTimeSeconds != nil {
+		in, out := &in.MaxPodLifeTimeSeconds, &out.MaxPodLifeTimeSeconds
+		*out = new(uint)
+		**out = **in
 	}
-	if in.Exclude != nil {
-		in, out := &in.Exclude, &out.Exclude
+	if in.PodStatusPhases != nil {
+		in, out := &in.PodStatusPhases, &out.PodStatusPhases
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Namespaces.
-func (in *Namespaces) DeepCopy() *Namespaces {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodLifeTime.
+func (in *PodLifeTime) DeepCopy() *PodLifeTime {
 	if in == nil {
 		return nil
 	}
-	out := new(Namespaces)
+	out := new(PodLifeTime)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeResourceUtilizationThresholds) DeepCopyInto(out *NodeResourceUtilizationThresholds) {
+func (in *PodsHavingTooManyRestarts) DeepCopyInto(out *PodsHavingTooManyRestarts) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodsHavingTooManyRestarts.
+func (in *PodsHavingTooManyRestarts) DeepCopy() *PodsHavingTooManyRestarts {
+	if in == nil {
+		return nil
+	}
+	out := new(PodsHavingTooManyRestarts)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyReports) DeepCopyInto(out *PolicyReports) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyReports.
+func (in *PolicyReports) DeepCopy() *PolicyReports {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyReports)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PriorityBand) DeepCopyInto(out *PriorityBand) {
 	*out = *in
+	if in.MinPriority != nil {
+		in, out := &in.MinPriority, &out.MinPriority
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxPriority != nil {
+		in, out := &in.MaxPriority, &out.MaxPriority
+		*out = new(int32)
+		**out = **in
+	}
 	if in.Thresholds != nil {
 		in, out := &in.Thresholds, &out.Thresholds
 		*out = make(ResourceThresholds, len(*in))
@@ -180,54 +1031,75 @@ func (in *NodeResourceUtilizationThresholds) DeepCopyInto(out *NodeResourceUtili
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeResourceUtilizationThresholds.
-func (in *NodeResourceUtilizationThresholds) DeepCopy() *NodeResourceUtilizationThresholds {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PriorityBand.
+func (in *PriorityBand) DeepCopy() *PriorityBand {
 	if in == nil {
 		return nil
 	}
-	out := new(NodeResourceUtilizationThresholds)
+	out := new(PriorityBand)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodLifeTime) DeepCopyInto(out *PodLifeTime) {
+func (in *PrometheusUtilization) DeepCopyInto(out *PrometheusUtilization) {
 	*out = *in
-	if in.MaxPodLifeTimeSeconds != nil {
-		in, out := &in.MaxPodLifeTimeSeconds, &out.MaxPodLifeTimeSeconds
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
 		*out = new(uint)
 		**out = **in
 	}
-	if in.PodStatusPhases != nil {
-		in, out := &in.PodStatusPhases, &out.PodStatusPhases
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.WindowSeconds != nil {
+		in, out := &in.WindowSeconds, &out.WindowSeconds
+		*out = new(uint)
+		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodLifeTime.
-func (in *PodLifeTime) DeepCopy() *PodLifeTime {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusUtilization.
+func (in *PrometheusUtilization) DeepCopy() *PrometheusUtilization {
 	if in == nil {
 		return nil
 	}
-	out := new(PodLifeTime)
+	out := new(PrometheusUtilization)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodsHavingTooManyRestarts) DeepCopyInto(out *PodsHavingTooManyRestarts) {
+func (in *ProtectedPodCondition) DeepCopyInto(out *ProtectedPodCondition) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodsHavingTooManyRestarts.
-func (in *PodsHavingTooManyRestarts) DeepCopy() *PodsHavingTooManyRestarts {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProtectedPodCondition.
+func (in *ProtectedPodCondition) DeepCopy() *ProtectedPodCondition {
 	if in == nil {
 		return nil
 	}
-	out := new(PodsHavingTooManyRestarts)
+	out := new(ProtectedPodCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteWrite) DeepCopyInto(out *RemoteWrite) {
+	*out = *in
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(uint)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteWrite.
+func (in *RemoteWrite) DeepCopy() *RemoteWrite {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteWrite)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -275,6 +1147,27 @@ func (in ResourceThresholds) DeepCopy() ResourceThresholds {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestartChurn) DeepCopyInto(out *RestartChurn) {
+	*out = *in
+	if in.WindowSeconds != nil {
+		in, out := &in.WindowSeconds, &out.WindowSeconds
+		*out = new(uint)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestartChurn.
+func (in *RestartChurn) DeepCopy() *RestartChurn {
+	if in == nil {
+		return nil
+	}
+	out := new(RestartChurn)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in StrategyList) DeepCopyInto(out *StrategyList) {
 	{
@@ -330,11 +1223,86 @@ func (in *StrategyParameters) DeepCopyInto(out *StrategyParameters) {
 		*out = new(FailedPods)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AntiColocation != nil {
+		in, out := &in.AntiColocation, &out.AntiColocation
+		*out = new(AntiColocation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodDensity != nil {
+		in, out := &in.PodDensity, &out.PodDensity
+		*out = new(PodDensity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeProblems != nil {
+		in, out := &in.NodeProblems, &out.NodeProblems
+		*out = new(NodeProblems)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LimitsOvercommit != nil {
+		in, out := &in.LimitsOvercommit, &out.LimitsOvercommit
+		*out = new(LimitsOvercommit)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FinishedJobPods != nil {
+		in, out := &in.FinishedJobPods, &out.FinishedJobPods
+		*out = new(FinishedJobPods)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MemoryOverrun != nil {
+		in, out := &in.MemoryOverrun, &out.MemoryOverrun
+		*out = new(MemoryOverrun)
+		**out = **in
+	}
+	if in.NodeInterruption != nil {
+		in, out := &in.NodeInterruption, &out.NodeInterruption
+		*out = new(NodeInterruption)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StuckPods != nil {
+		in, out := &in.StuckPods, &out.StuckPods
+		*out = new(StuckPods)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImageLocality != nil {
+		in, out := &in.ImageLocality, &out.ImageLocality
+		*out = new(ImageLocality)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NotReadyNodes != nil {
+		in, out := &in.NotReadyNodes, &out.NotReadyNodes
+		*out = new(NotReadyNodes)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GPUModelPreference != nil {
+		in, out := &in.GPUModelPreference, &out.GPUModelPreference
+		*out = new(GPUModelPreference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TerminatingPods != nil {
+		in, out := &in.TerminatingPods, &out.TerminatingPods
+		*out = new(TerminatingPods)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RestartChurn != nil {
+		in, out := &in.RestartChurn, &out.RestartChurn
+		*out = new(RestartChurn)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologyBalanceDomains != nil {
+		in, out := &in.TopologyBalanceDomains, &out.TopologyBalanceDomains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Namespaces != nil {
 		in, out := &in.Namespaces, &out.Namespaces
 		*out = new(Namespaces)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ThresholdPriority != nil {
 		in, out := &in.ThresholdPriority, &out.ThresholdPriority
 		*out = new(int32)
@@ -345,6 +1313,21 @@ func (in *StrategyParameters) DeepCopyInto(out *StrategyParameters) {
 		*out = new(v1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.IncludeCordonedNodes != nil {
+		in, out := &in.IncludeCordonedNodes, &out.IncludeCordonedNodes
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NodeRoles != nil {
+		in, out := &in.NodeRoles, &out.NodeRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PluginArgs != nil {
+		in, out := &in.PluginArgs, &out.PluginArgs
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -357,3 +1340,81 @@ func (in *StrategyParameters) DeepCopy() *StrategyParameters {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StuckPods) DeepCopyInto(out *StuckPods) {
+	*out = *in
+	if in.MaxStuckSeconds != nil {
+		in, out := &in.MaxStuckSeconds, &out.MaxStuckSeconds
+		*out = new(uint)
+		**out = **in
+	}
+	if in.Reasons != nil {
+		in, out := &in.Reasons, &out.Reasons
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StuckPods.
+func (in *StuckPods) DeepCopy() *StuckPods {
+	if in == nil {
+		return nil
+	}
+	out := new(StuckPods)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TerminatingPods) DeepCopyInto(out *TerminatingPods) {
+	*out = *in
+	if in.MaxTerminatingSeconds != nil {
+		in, out := &in.MaxTerminatingSeconds, &out.MaxTerminatingSeconds
+		*out = new(uint)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerminatingPods.
+func (in *TerminatingPods) DeepCopy() *TerminatingPods {
+	if in == nil {
+		return nil
+	}
+	out := new(TerminatingPods)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UsageTrend) DeepCopyInto(out *UsageTrend) {
+	*out = *in
+	if in.WindowSeconds != nil {
+		in, out := &in.WindowSeconds, &out.WindowSeconds
+		*out = new(uint)
+		**out = **in
+	}
+	if in.MinSamples != nil {
+		in, out := &in.MinSamples, &out.MinSamples
+		*out = new(uint)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]corev1.ResourceName, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UsageTrend.
+func (in *UsageTrend) DeepCopy() *UsageTrend {
+	if in == nil {
+		return nil
+	}
+	out := new(UsageTrend)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -38,6 +38,10 @@ type DeschedulerConfiguration struct {
 	// PolicyConfigFile is the filepath to the descheduler policy configuration.
 	PolicyConfigFile string
 
+	// PolicyResource is the name of the DeschedulerPolicy custom resource the policy is read from, instead of
+	// the policy config file. Changes of the resource are applied before the next descheduling cycle.
+	PolicyResource string
+
 	// Dry run
 	DryRun bool
 
@@ -53,6 +57,49 @@ type DeschedulerConfiguration struct {
 	// IgnorePVCPods sets whether PVC pods should be allowed to be evicted
 	IgnorePVCPods bool
 
+	// ExcludeVirtualNodes excludes kwok and virtual-kubelet nodes from being processed
+	ExcludeVirtualNodes bool
+
+	// PodLabelSelector restricts the pods listed by the descheduler to the ones matching the label selector
+	PodLabelSelector string
+
+	// PodFieldSelector restricts the pods listed by the descheduler to the ones matching the field selector
+	PodFieldSelector string
+
+	// LogSamplingInitial is the number of occurrences of a repetitive message logged per descheduling
+	// cycle before sampling it, sampling is disabled when 0
+	LogSamplingInitial int
+
+	// LogSamplingThereafter is the interval between two logged occurrences of a sampled message
+	LogSamplingThereafter int
+
+	// PolicyReload reloads the policy config file between descheduling cycles when it changes
+	// or the descheduler receives SIGHUP
+	PolicyReload bool
+
+	// PolicyOverrides are applied to the policy config file when it is loaded, in the path=value form,
+	// e.g. strategies.LowNodeUtilization.params.nodeResourceUtilizationThresholds.thresholds.cpu=20
+	PolicyOverrides []string
+
+	// UserAgent is the user agent of the requests to the API server, the client-go default when empty
+	UserAgent string
+
+	// ThrottlingRetries is the number of retries of requests rejected by API Priority and Fairness,
+	// backing off exponentially with jitter, 0 disables them
+	ThrottlingRetries int
+
+	// ReportFormat is the format, "json", "markdown" or "csv", of the summary of every descheduling cycle
+	// written to the standard output, no summary is written when empty
+	ReportFormat string
+
+	// ReconnectTimeout is how long a descheduling cycle interrupted by the loss of the connection to the API
+	// server waits for it to resume the cycle, within the descheduling interval. Disabled when 0.
+	ReconnectTimeout time.Duration
+
+	// Deterministic fixes the random seeds and processes nodes in name order, so runs against the same cluster
+	// state evict the same pods, e.g. in e2e tests and simulations
+	Deterministic bool
+
 	// Logging specifies the options of logging.
 	// Refer [Logs Options](https://github.com/kubernetes/component-base/blob/master/logs/options.go) for more information.
 	Logging componentbaseconfig.LoggingConfiguration
@@ -22,6 +22,7 @@ package v1alpha1
 
 import (
 	time "time"
+	unsafe "unsafe"
 
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
@@ -52,11 +53,24 @@ func autoConvert_v1alpha1_DeschedulerConfiguration_To_componentconfig_Deschedule
 	out.DeschedulingInterval = time.Duration(in.DeschedulingInterval)
 	out.KubeconfigFile = in.KubeconfigFile
 	out.PolicyConfigFile = in.PolicyConfigFile
+	out.PolicyResource = in.PolicyResource
 	out.DryRun = in.DryRun
 	out.NodeSelector = in.NodeSelector
 	out.MaxNoOfPodsToEvictPerNode = in.MaxNoOfPodsToEvictPerNode
 	out.EvictLocalStoragePods = in.EvictLocalStoragePods
 	out.IgnorePVCPods = in.IgnorePVCPods
+	out.ExcludeVirtualNodes = in.ExcludeVirtualNodes
+	out.PodLabelSelector = in.PodLabelSelector
+	out.PodFieldSelector = in.PodFieldSelector
+	out.LogSamplingInitial = in.LogSamplingInitial
+	out.LogSamplingThereafter = in.LogSamplingThereafter
+	out.PolicyReload = in.PolicyReload
+	out.PolicyOverrides = *(*[]string)(unsafe.Pointer(&in.PolicyOverrides))
+	out.UserAgent = in.UserAgent
+	out.ThrottlingRetries = in.ThrottlingRetries
+	out.ReportFormat = in.ReportFormat
+	out.ReconnectTimeout = time.Duration(in.ReconnectTimeout)
+	out.Deterministic = in.Deterministic
 	out.Logging = in.Logging
 	return nil
 }
@@ -70,11 +84,24 @@ func autoConvert_componentconfig_DeschedulerConfiguration_To_v1alpha1_Deschedule
 	out.DeschedulingInterval = time.Duration(in.DeschedulingInterval)
 	out.KubeconfigFile = in.KubeconfigFile
 	out.PolicyConfigFile = in.PolicyConfigFile
+	out.PolicyResource = in.PolicyResource
 	out.DryRun = in.DryRun
 	out.NodeSelector = in.NodeSelector
 	out.MaxNoOfPodsToEvictPerNode = in.MaxNoOfPodsToEvictPerNode
 	out.EvictLocalStoragePods = in.EvictLocalStoragePods
 	out.IgnorePVCPods = in.IgnorePVCPods
+	out.ExcludeVirtualNodes = in.ExcludeVirtualNodes
+	out.PodLabelSelector = in.PodLabelSelector
+	out.PodFieldSelector = in.PodFieldSelector
+	out.LogSamplingInitial = in.LogSamplingInitial
+	out.LogSamplingThereafter = in.LogSamplingThereafter
+	out.PolicyReload = in.PolicyReload
+	out.PolicyOverrides = *(*[]string)(unsafe.Pointer(&in.PolicyOverrides))
+	out.UserAgent = in.UserAgent
+	out.ThrottlingRetries = in.ThrottlingRetries
+	out.ReportFormat = in.ReportFormat
+	out.ReconnectTimeout = time.Duration(in.ReconnectTimeout)
+	out.Deterministic = in.Deterministic
 	out.Logging = in.Logging
 	return nil
 }
@@ -28,6 +28,11 @@ import (
 func (in *DeschedulerConfiguration) DeepCopyInto(out *DeschedulerConfiguration) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
+	if in.PolicyOverrides != nil {
+		in, out := &in.PolicyOverrides, &out.PolicyOverrides
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	out.Logging = in.Logging
 	return
 }
@@ -0,0 +1,167 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package balance moves resource usage from source nodes to destination nodes by evicting pods.
+// Strategies decide which nodes take part, which pods are candidates and how many resources the
+// destination nodes can take; the package runs the eviction loop and keeps the usages up to date.
+package balance
+
+import (
+	"context"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/logging"
+	"sigs.k8s.io/descheduler/pkg/utils"
+)
+
+// Source is a node pods are moved away from
+type Source struct {
+	Node *v1.Node
+	// Usage is the resource usage of the node, updated in place as pods are evicted
+	Usage map[v1.ResourceName]*resource.Quantity
+}
+
+// CandidateSource selects the pods to move away from source nodes
+type CandidateSource interface {
+	// Candidates returns the pods of the source node to evict, in eviction order
+	Candidates(source Source, capacity DestinationCapacity) []*v1.Pod
+	// Continue reports whether more pods should be moved away from the source node
+	Continue(source Source, capacity DestinationCapacity) bool
+}
+
+// DestinationCapacity tracks the resources destination nodes can still take
+type DestinationCapacity interface {
+	// Remaining returns the resources destination nodes can still take
+	Remaining() map[v1.ResourceName]*resource.Quantity
+	// Accepts reports whether the pod can be moved to the destination nodes
+	Accepts(pod *v1.Pod) bool
+	// Reserve takes the resources of a moved pod from the remaining capacity and returns them
+	Reserve(pod *v1.Pod) map[v1.ResourceName]resource.Quantity
+}
+
+// EvictionSink evicts pods from source nodes
+type EvictionSink interface {
+	// Evict evicts the pod from the node, it returns false when the pod was not evicted
+	// and an error when no more pods should be evicted
+	Evict(ctx context.Context, pod *v1.Pod, node *v1.Node) (bool, error)
+}
+
+// Balance evicts the candidate pods of every source node, in the given order, as long as the
+// candidate source asks for it and the destination nodes accept them. Resources of evicted pods
+// are taken from the destination capacity and subtracted from the source node usage.
+func Balance(ctx context.Context, sources []Source, candidates CandidateSource, capacity DestinationCapacity, sink EvictionSink) {
+	for _, source := range sources {
+		klog.V(3).InfoS("Evicting pods from node", "node", klog.KObj(source.Node), "usage", source.Usage)
+		pods := candidates.Candidates(source, capacity)
+		if len(pods) == 0 {
+			klog.V(1).InfoS("No removable pods on node, try next node", "node", klog.KObj(source.Node))
+			continue
+		}
+		evicted := evictPods(ctx, source, pods, candidates, capacity, sink)
+		klog.V(1).InfoS("Evicted pods from node", "node", klog.KObj(source.Node), "evictedPods", evicted, "usage", source.Usage)
+	}
+}
+
+// evictPods evicts the pods of a source node and returns the number of evicted pods
+func evictPods(ctx context.Context, source Source, pods []*v1.Pod, candidates CandidateSource, capacity DestinationCapacity, sink EvictionSink) int {
+	evicted := 0
+	if !candidates.Continue(source, capacity) {
+		return evicted
+	}
+	for _, pod := range pods {
+		if !capacity.Accepts(pod) {
+			logging.InfoS(klog.V(3), "Skipping eviction for pod, not accepted by destination nodes", "pod", klog.KObj(pod))
+			continue
+		}
+
+		success, err := sink.Evict(ctx, pod, source.Node)
+		if err != nil {
+			klog.ErrorS(err, "Error evicting pod", "pod", klog.KObj(pod))
+			break
+		}
+		if !success {
+			continue
+		}
+		evicted++
+		klog.V(3).InfoS("Evicted pods", "pod", klog.KObj(pod))
+
+		for name, quantity := range capacity.Reserve(pod) {
+			if usage, ok := source.Usage[name]; ok {
+				usage.Sub(quantity)
+			}
+		}
+		klog.V(3).InfoS("Updated node usage", "node", klog.KObj(source.Node), "usage", source.Usage, "remainingCapacity", capacity.Remaining())
+
+		// check if pods can be still evicted
+		if !candidates.Continue(source, capacity) {
+			break
+		}
+	}
+	return evicted
+}
+
+// ResourceCapacity is a DestinationCapacity accepting pods tolerating the taints of at least one
+// destination node. Remaining resources are only tracked, the CandidateSource decides when they
+// are exhausted.
+type ResourceCapacity struct {
+	remaining map[v1.ResourceName]*resource.Quantity
+	taints    map[string][]v1.Taint
+	requests  func(pod *v1.Pod, name v1.ResourceName) resource.Quantity
+}
+
+var _ DestinationCapacity = &ResourceCapacity{}
+
+// NewResourceCapacity returns a ResourceCapacity for the destination nodes, which can take the
+// remaining resources. Requests of moved pods are computed with the requests function, each pod
+// counts for one v1.ResourcePods.
+func NewResourceCapacity(
+	destinations []*v1.Node,
+	remaining map[v1.ResourceName]*resource.Quantity,
+	requests func(pod *v1.Pod, name v1.ResourceName) resource.Quantity,
+) *ResourceCapacity {
+	taints := make(map[string][]v1.Taint, len(destinations))
+	for _, node := range destinations {
+		taints[node.Name] = node.Spec.Taints
+	}
+	return &ResourceCapacity{remaining: remaining, taints: taints, requests: requests}
+}
+
+// Remaining returns the resources destination nodes can still take
+func (c *ResourceCapacity) Remaining() map[v1.ResourceName]*resource.Quantity {
+	return c.remaining
+}
+
+// Accepts reports whether the pod tolerates the taints of at least one destination node
+func (c *ResourceCapacity) Accepts(pod *v1.Pod) bool {
+	return utils.PodToleratesTaints(pod, c.taints)
+}
+
+// Reserve takes the requests of the pod from the remaining resources
+func (c *ResourceCapacity) Reserve(pod *v1.Pod) map[v1.ResourceName]resource.Quantity {
+	reserved := make(map[v1.ResourceName]resource.Quantity, len(c.remaining))
+	for name, remaining := range c.remaining {
+		quantity := *resource.NewQuantity(1, resource.DecimalSI)
+		if name != v1.ResourcePods {
+			quantity = c.requests(pod, name)
+		}
+		remaining.Sub(quantity)
+		reserved[name] = quantity
+	}
+	return reserved
+}
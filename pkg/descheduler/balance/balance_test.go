@@ -0,0 +1,205 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package balance
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"sigs.k8s.io/descheduler/test"
+)
+
+// fakeCandidates returns the pods of the source node and continues while the
+// node cpu usage is above the target
+type fakeCandidates struct {
+	pods      map[string][]*v1.Pod
+	targetCPU int64
+}
+
+func (c *fakeCandidates) Candidates(source Source, capacity DestinationCapacity) []*v1.Pod {
+	return c.pods[source.Node.Name]
+}
+
+func (c *fakeCandidates) Continue(source Source, capacity DestinationCapacity) bool {
+	return source.Usage[v1.ResourceCPU].MilliValue() > c.targetCPU && capacity.Remaining()[v1.ResourceCPU].MilliValue() > 0
+}
+
+// fakeSink records evicted pods, refuses pods listed in refused and fails on pods listed in failing
+type fakeSink struct {
+	evicted []string
+	refused map[string]bool
+	failing map[string]bool
+}
+
+func (s *fakeSink) Evict(ctx context.Context, pod *v1.Pod, node *v1.Node) (bool, error) {
+	if s.failing[pod.Name] {
+		return false, fmt.Errorf("eviction of %s failed", pod.Name)
+	}
+	if s.refused[pod.Name] {
+		return false, nil
+	}
+	s.evicted = append(s.evicted, pod.Name)
+	return true, nil
+}
+
+func podRequests(pod *v1.Pod, name v1.ResourceName) resource.Quantity {
+	return pod.Spec.Containers[0].Resources.Requests[name]
+}
+
+func TestBalance(t *testing.T) {
+	n1 := test.BuildTestNode("n1", 4000, 3000, 10, nil)
+	n2 := test.BuildTestNode("n2", 4000, 3000, 10, nil)
+	tainted := test.BuildTestNode("tainted", 4000, 3000, 10, func(node *v1.Node) {
+		node.Spec.Taints = []v1.Taint{{Key: "key", Value: "value", Effect: v1.TaintEffectNoSchedule}}
+	})
+
+	pods := func(names ...string) []*v1.Pod {
+		var pods []*v1.Pod
+		for _, name := range names {
+			pods = append(pods, test.BuildTestPod(name, 1000, 0, "n1", nil))
+		}
+		return pods
+	}
+
+	testCases := []struct {
+		name              string
+		destinations      []*v1.Node
+		remainingCPU      int64
+		pods              []*v1.Pod
+		sink              *fakeSink
+		expectedEvicted   []string
+		expectedUsageCPU  int64
+		expectedRemaining int64
+	}{
+		{
+			name:              "evict until the source node reaches the target",
+			destinations:      []*v1.Node{n2},
+			remainingCPU:      4000,
+			pods:              pods("p1", "p2", "p3", "p4"),
+			sink:              &fakeSink{},
+			expectedEvicted:   []string{"p1", "p2"},
+			expectedUsageCPU:  2000,
+			expectedRemaining: 2000,
+		},
+		{
+			name:              "evict until the destination nodes are full",
+			destinations:      []*v1.Node{n2},
+			remainingCPU:      1000,
+			pods:              pods("p1", "p2", "p3", "p4"),
+			sink:              &fakeSink{},
+			expectedEvicted:   []string{"p1"},
+			expectedUsageCPU:  3000,
+			expectedRemaining: 0,
+		},
+		{
+			name:              "pods refused by the sink are not accounted",
+			destinations:      []*v1.Node{n2},
+			remainingCPU:      4000,
+			pods:              pods("p1", "p2", "p3", "p4"),
+			sink:              &fakeSink{refused: map[string]bool{"p1": true}},
+			expectedEvicted:   []string{"p2", "p3"},
+			expectedUsageCPU:  2000,
+			expectedRemaining: 2000,
+		},
+		{
+			name:              "stop on eviction errors",
+			destinations:      []*v1.Node{n2},
+			remainingCPU:      4000,
+			pods:              pods("p1", "p2", "p3", "p4"),
+			sink:              &fakeSink{failing: map[string]bool{"p2": true}},
+			expectedEvicted:   []string{"p1"},
+			expectedUsageCPU:  3000,
+			expectedRemaining: 3000,
+		},
+		{
+			name:              "pods not tolerating taints of destination nodes are skipped",
+			destinations:      []*v1.Node{tainted},
+			remainingCPU:      4000,
+			pods:              pods("p1", "p2"),
+			sink:              &fakeSink{},
+			expectedEvicted:   nil,
+			expectedUsageCPU:  4000,
+			expectedRemaining: 4000,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			source := Source{
+				Node: n1,
+				Usage: map[v1.ResourceName]*resource.Quantity{
+					v1.ResourceCPU:  resource.NewMilliQuantity(4000, resource.DecimalSI),
+					v1.ResourcePods: resource.NewQuantity(int64(len(tc.pods)), resource.DecimalSI),
+				},
+			}
+			capacity := NewResourceCapacity(tc.destinations, map[v1.ResourceName]*resource.Quantity{
+				v1.ResourceCPU:  resource.NewMilliQuantity(tc.remainingCPU, resource.DecimalSI),
+				v1.ResourcePods: resource.NewQuantity(10, resource.DecimalSI),
+			}, podRequests)
+			candidates := &fakeCandidates{pods: map[string][]*v1.Pod{n1.Name: tc.pods}, targetCPU: 2000}
+
+			Balance(context.Background(), []Source{source}, candidates, capacity, tc.sink)
+
+			if !reflect.DeepEqual(tc.sink.evicted, tc.expectedEvicted) {
+				t.Errorf("Expected %v pods to be evicted, got %v", tc.expectedEvicted, tc.sink.evicted)
+			}
+			if usage := source.Usage[v1.ResourceCPU].MilliValue(); usage != tc.expectedUsageCPU {
+				t.Errorf("Expected source node cpu usage %v, got %v", tc.expectedUsageCPU, usage)
+			}
+			if remaining := capacity.Remaining()[v1.ResourceCPU].MilliValue(); remaining != tc.expectedRemaining {
+				t.Errorf("Expected remaining cpu %v, got %v", tc.expectedRemaining, remaining)
+			}
+			expectedPods := int64(len(tc.pods) - len(tc.expectedEvicted))
+			if usage := source.Usage[v1.ResourcePods].Value(); usage != expectedPods {
+				t.Errorf("Expected %v pods on the source node, got %v", expectedPods, usage)
+			}
+		})
+	}
+}
+
+func TestBalanceSkipsSourcesWithoutCandidates(t *testing.T) {
+	n1 := test.BuildTestNode("n1", 4000, 3000, 10, nil)
+	n2 := test.BuildTestNode("n2", 4000, 3000, 10, nil)
+	n3 := test.BuildTestNode("n3", 4000, 3000, 10, nil)
+
+	sources := []Source{
+		{Node: n1, Usage: map[v1.ResourceName]*resource.Quantity{v1.ResourceCPU: resource.NewMilliQuantity(4000, resource.DecimalSI)}},
+		{Node: n2, Usage: map[v1.ResourceName]*resource.Quantity{v1.ResourceCPU: resource.NewMilliQuantity(4000, resource.DecimalSI)}},
+	}
+	capacity := NewResourceCapacity([]*v1.Node{n3}, map[v1.ResourceName]*resource.Quantity{
+		v1.ResourceCPU: resource.NewMilliQuantity(4000, resource.DecimalSI),
+	}, podRequests)
+	candidates := &fakeCandidates{
+		pods:      map[string][]*v1.Pod{n2.Name: {test.BuildTestPod("p1", 1000, 0, n2.Name, nil)}},
+		targetCPU: 2000,
+	}
+	sink := &fakeSink{}
+
+	Balance(context.Background(), sources, candidates, capacity, sink)
+
+	if !reflect.DeepEqual(sink.evicted, []string{"p1"}) {
+		t.Errorf("Expected p1 to be evicted from n2, got %v", sink.evicted)
+	}
+	if usage := sources[1].Usage[v1.ResourceCPU].MilliValue(); usage != 3000 {
+		t.Errorf("Expected n2 cpu usage 3000, got %v", usage)
+	}
+}
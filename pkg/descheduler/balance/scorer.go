@@ -0,0 +1,129 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package balance
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// DestinationScorerLeastAllocated prefers the destination nodes with the lowest cpu and memory usage
+	DestinationScorerLeastAllocated = "LeastAllocated"
+	// DestinationScorerAnnotation reads the score of destination nodes from DestinationScoreAnnotationKey
+	DestinationScorerAnnotation = "Annotation"
+
+	// DestinationScoreAnnotationKey sets the score of a node, in percents, for the Annotation scorer.
+	// It allows operators to prefer nodes by cost or power efficiency, nodes without it score 100.
+	DestinationScoreAnnotationKey = "descheduler.alpha.kubernetes.io/destination-score"
+)
+
+// DestinationScorer scores destination nodes. The resources a destination node can still take are
+// weighted by its score, so less preferred nodes contribute less capacity and fewer pods are evicted
+// towards them. Nodes with a score of 0 are not used as destinations.
+type DestinationScorer interface {
+	// Score returns the score of the node with the given usage, between 0 and 1
+	Score(node *v1.Node, usage map[v1.ResourceName]*resource.Quantity) float64
+}
+
+// DestinationScorerFunc is a function implementing DestinationScorer
+type DestinationScorerFunc func(node *v1.Node, usage map[v1.ResourceName]*resource.Quantity) float64
+
+// Score calls the function
+func (f DestinationScorerFunc) Score(node *v1.Node, usage map[v1.ResourceName]*resource.Quantity) float64 {
+	return f(node, usage)
+}
+
+// destinationScorers are the scorers which can be configured by name
+var destinationScorers = map[string]DestinationScorer{
+	DestinationScorerLeastAllocated: DestinationScorerFunc(leastAllocatedScore),
+	DestinationScorerAnnotation:     DestinationScorerFunc(annotationScore),
+}
+
+// RegisterDestinationScorer makes a scorer configurable under the given name. It is meant to be
+// called from init functions of builds adding their own scorers, and overrides scorers of the same name.
+func RegisterDestinationScorer(name string, scorer DestinationScorer) {
+	destinationScorers[name] = scorer
+}
+
+// GetDestinationScorer returns the scorer registered under the name, nil for an empty name
+func GetDestinationScorer(name string) (DestinationScorer, error) {
+	if name == "" {
+		return nil, nil
+	}
+	scorer, ok := destinationScorers[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown destination scorer %q", name)
+	}
+	return scorer, nil
+}
+
+// ScoreDestination returns the score of the node clamped to [0, 1], 1 when no scorer is configured
+func ScoreDestination(scorer DestinationScorer, node *v1.Node, usage map[v1.ResourceName]*resource.Quantity) float64 {
+	if scorer == nil {
+		return 1
+	}
+	return math.Min(math.Max(scorer.Score(node, usage), 0), 1)
+}
+
+// WeightQuantity returns the quantity weighted by a score between 0 and 1
+func WeightQuantity(quantity resource.Quantity, score float64) resource.Quantity {
+	if score >= 1 {
+		return quantity
+	}
+	return *resource.NewMilliQuantity(int64(float64(quantity.MilliValue())*score), quantity.Format)
+}
+
+// leastAllocatedScore is the average share of cpu and memory left unused on the node
+func leastAllocatedScore(node *v1.Node, usage map[v1.ResourceName]*resource.Quantity) float64 {
+	allocatable := node.Status.Capacity
+	if len(node.Status.Allocatable) > 0 {
+		allocatable = node.Status.Allocatable
+	}
+	score, count := 0.0, 0
+	for _, name := range []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory} {
+		capacity, ok := allocatable[name]
+		if !ok || capacity.IsZero() || usage[name] == nil {
+			continue
+		}
+		score += 1 - float64(usage[name].MilliValue())/float64(capacity.MilliValue())
+		count++
+	}
+	if count == 0 {
+		return 1
+	}
+	return score / float64(count)
+}
+
+// annotationScore reads the score of the node, in percents, from its annotation
+func annotationScore(node *v1.Node, _ map[v1.ResourceName]*resource.Quantity) float64 {
+	value, ok := node.Annotations[DestinationScoreAnnotationKey]
+	if !ok {
+		return 1
+	}
+	score, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		klog.V(3).InfoS("Ignoring invalid destination score", "node", klog.KObj(node), "score", value)
+		return 1
+	}
+	return score / 100
+}
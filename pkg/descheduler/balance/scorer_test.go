@@ -0,0 +1,108 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package balance
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestDestinationScorers(t *testing.T) {
+	usage := map[v1.ResourceName]*resource.Quantity{
+		v1.ResourceCPU:    resource.NewMilliQuantity(500, resource.DecimalSI),
+		v1.ResourceMemory: resource.NewQuantity(1500, resource.BinarySI),
+	}
+	withScore := func(score string) func(node *v1.Node) {
+		return func(node *v1.Node) {
+			node.Annotations = map[string]string{DestinationScoreAnnotationKey: score}
+		}
+	}
+
+	tests := []struct {
+		name     string
+		scorer   string
+		node     *v1.Node
+		expected float64
+	}{
+		{
+			name:     "no scorer",
+			node:     test.BuildTestNode("n1", 2000, 3000, 10, nil),
+			expected: 1,
+		},
+		{
+			name:     "least allocated",
+			scorer:   DestinationScorerLeastAllocated,
+			node:     test.BuildTestNode("n1", 2000, 3000, 10, nil),
+			expected: 0.625,
+		},
+		{
+			name:     "annotation",
+			scorer:   DestinationScorerAnnotation,
+			node:     test.BuildTestNode("n1", 2000, 3000, 10, withScore("40")),
+			expected: 0.4,
+		},
+		{
+			name:     "missing annotation",
+			scorer:   DestinationScorerAnnotation,
+			node:     test.BuildTestNode("n1", 2000, 3000, 10, nil),
+			expected: 1,
+		},
+		{
+			name:     "invalid annotation",
+			scorer:   DestinationScorerAnnotation,
+			node:     test.BuildTestNode("n1", 2000, 3000, 10, withScore("high")),
+			expected: 1,
+		},
+		{
+			name:     "annotation is clamped",
+			scorer:   DestinationScorerAnnotation,
+			node:     test.BuildTestNode("n1", 2000, 3000, 10, withScore("150")),
+			expected: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			scorer, err := GetDestinationScorer(tc.scorer)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if score := ScoreDestination(scorer, tc.node, usage); score != tc.expected {
+				t.Errorf("Expected a score of %v, got %v", tc.expected, score)
+			}
+		})
+	}
+
+	if _, err := GetDestinationScorer("Unknown"); err == nil {
+		t.Errorf("Expected an error for an unknown scorer")
+	}
+}
+
+func TestWeightQuantity(t *testing.T) {
+	quantity := resource.MustParse("1")
+	weighted := WeightQuantity(quantity, 0.25)
+	if weighted.MilliValue() != 250 {
+		t.Errorf("Expected 250m, got %v", weighted.String())
+	}
+	if unchanged := WeightQuantity(quantity, 1); unchanged.Cmp(quantity) != 0 {
+		t.Errorf("Expected %v, got %v", quantity.String(), unchanged.String())
+	}
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/scheme"
+	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/nodeutilization"
+	"sigs.k8s.io/descheduler/pkg/utils"
+	"sigs.k8s.io/descheduler/pkg/version"
+)
+
+// Capabilities lists what this build of the descheduler supports, for automation to check the
+// compatibility of a policy before applying it
+type Capabilities struct {
+	Version string `json:"version"`
+	// Strategies are the names of the compiled-in strategies, built-in or plugins
+	Strategies []string `json:"strategies"`
+	// PolicyAPIVersions are the apiVersions policy files can be written in
+	PolicyAPIVersions []string `json:"policyAPIVersions"`
+	// FeatureGates are the features registered by the descheduler and whether they are enabled
+	FeatureGates []FeatureGate `json:"featureGates"`
+	// UsageProviders are the sources node utilization strategies can compute node usages from
+	UsageProviders []string `json:"usageProviders"`
+}
+
+// FeatureGate is the state of a feature
+type FeatureGate struct {
+	Name       string `json:"name"`
+	PreRelease string `json:"preRelease"`
+	Default    bool   `json:"default"`
+	Enabled    bool   `json:"enabled"`
+}
+
+// GetCapabilities returns the capabilities of this build of the descheduler
+func GetCapabilities() Capabilities {
+	capabilities := Capabilities{
+		Version:        version.Get().GitVersion,
+		UsageProviders: nodeutilization.UsageProviders,
+	}
+	for name := range strategyFunctions() {
+		capabilities.Strategies = append(capabilities.Strategies, string(name))
+	}
+	sort.Strings(capabilities.Strategies)
+	for _, groupVersion := range scheme.Scheme.PrioritizedVersionsForGroup(api.GroupName) {
+		if groupVersion.Version != runtime.APIVersionInternal {
+			capabilities.PolicyAPIVersions = append(capabilities.PolicyAPIVersions, groupVersion.String())
+		}
+	}
+	for name, spec := range utils.FeatureGates {
+		capabilities.FeatureGates = append(capabilities.FeatureGates, FeatureGate{
+			Name:       string(name),
+			PreRelease: string(spec.PreRelease),
+			Default:    spec.Default,
+			Enabled:    utilfeature.DefaultFeatureGate.Enabled(name),
+		})
+	}
+	sort.Slice(capabilities.FeatureGates, func(i, j int) bool {
+		return capabilities.FeatureGates[i].Name < capabilities.FeatureGates[j].Name
+	})
+	return capabilities
+}
+
+// CapabilitiesHandler serves the capabilities as JSON
+func CapabilitiesHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(GetCapabilities()); err != nil {
+			klog.ErrorS(err, "Unable to serve capabilities")
+		}
+	})
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+func TestCapabilitiesHandler(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	CapabilitiesHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/capabilities", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected status %v, got %v", http.StatusOK, recorder.Code)
+	}
+	if contentType := recorder.Header().Get("Content-Type"); contentType != "application/json" {
+		t.Errorf("Expected content type application/json, got %q", contentType)
+	}
+	capabilities := Capabilities{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &capabilities); err != nil {
+		t.Fatalf("Unable to decode capabilities: %v", err)
+	}
+
+	funcs := strategyFunctions()
+	if len(capabilities.Strategies) != len(funcs) {
+		t.Errorf("Expected %v strategies, got %v", len(funcs), capabilities.Strategies)
+	}
+	for _, name := range capabilities.Strategies {
+		if _, ok := funcs[api.StrategyName(name)]; !ok {
+			t.Errorf("Unexpected strategy %q", name)
+		}
+	}
+	if expected := []string{"descheduler/v1alpha1", "descheduler/v1alpha2"}; !reflect.DeepEqual(capabilities.PolicyAPIVersions, expected) {
+		t.Errorf("Expected policy API versions %v, got %v", expected, capabilities.PolicyAPIVersions)
+	}
+	expectedGates := []FeatureGate{{Name: "LocalStorageCapacityIsolation", PreRelease: "BETA", Default: true, Enabled: true}}
+	if !reflect.DeepEqual(capabilities.FeatureGates, expectedGates) {
+		t.Errorf("Expected feature gates %v, got %v", expectedGates, capabilities.FeatureGates)
+	}
+	if expected := []string{"Requests", "Limits", "MetricsServer", "Prometheus"}; !reflect.DeepEqual(capabilities.UsageProviders, expected) {
+		t.Errorf("Expected usage providers %v, got %v", expected, capabilities.UsageProviders)
+	}
+}
@@ -18,7 +18,9 @@ package client
 
 import (
 	"fmt"
+	"net/http"
 
+	"k8s.io/client-go/dynamic"
 	clientset "k8s.io/client-go/kubernetes"
 	// Ensure to load all auth plugins.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
@@ -26,7 +28,49 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 )
 
-func CreateClient(kubeconfig string) (clientset.Interface, error) {
+// Option customizes the configuration of the client
+type Option func(cfg *rest.Config)
+
+// WithUserAgent sets the user agent of the requests, e.g. to identify them in audit logs and API server metrics.
+// The default client-go user agent is kept when empty.
+func WithUserAgent(userAgent string) Option {
+	return func(cfg *rest.Config) {
+		if userAgent != "" {
+			cfg.UserAgent = userAgent
+		}
+	}
+}
+
+// WithThrottlingRetries retries requests rejected by API Priority and Fairness up to maxRetries
+// times, backing off exponentially with jitter. Retries are disabled when maxRetries is 0.
+func WithThrottlingRetries(maxRetries int) Option {
+	return func(cfg *rest.Config) {
+		if maxRetries > 0 {
+			cfg.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+				return &throttlingRetryRoundTripper{delegate: rt, maxRetries: maxRetries}
+			})
+		}
+	}
+}
+
+func CreateClient(kubeconfig string, opts ...Option) (clientset.Interface, error) {
+	cfg, err := createConfig(kubeconfig, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return clientset.NewForConfig(cfg)
+}
+
+// CreateDynamicClient creates a client for resources without typed clients, like custom resources
+func CreateDynamicClient(kubeconfig string, opts ...Option) (dynamic.Interface, error) {
+	cfg, err := createConfig(kubeconfig, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return dynamic.NewForConfig(cfg)
+}
+
+func createConfig(kubeconfig string, opts ...Option) (*rest.Config, error) {
 	var cfg *rest.Config
 	if len(kubeconfig) != 0 {
 		master, err := GetMasterFromKubeconfig(kubeconfig)
@@ -47,6 +91,22 @@ func CreateClient(kubeconfig string) (clientset.Interface, error) {
 		}
 	}
 
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg, nil
+}
+
+// CreateClientForContext creates a client for the given context of the kubeconfig file. The file is
+// looked up the way kubectl does (KUBECONFIG, then ~/.kube/config) when not given, and the current
+// context is used when kubeContext is empty.
+func CreateClientForContext(kubeconfig, kubeContext string) (clientset.Interface, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = kubeconfig
+	cfg, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{CurrentContext: kubeContext}).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("Unable to build config: %v", err)
+	}
 	return clientset.NewForConfig(cfg)
 }
 
@@ -0,0 +1,104 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/watch"
+	clientset "k8s.io/client-go/kubernetes"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// podSelectors are added to every list and watch of pods
+type podSelectors struct {
+	labelSelector string
+	fieldSelector string
+}
+
+// WithPodSelectors returns a client restricting every list and watch of pods to the pods matching
+// the label and field selectors, so pods the descheduler never processes are neither transferred
+// nor kept in memory. The client is returned unchanged when both selectors are empty.
+func WithPodSelectors(client clientset.Interface, labelSelector, fieldSelector string) (clientset.Interface, error) {
+	if labelSelector == "" && fieldSelector == "" {
+		return client, nil
+	}
+	if _, err := labels.Parse(labelSelector); err != nil {
+		return nil, fmt.Errorf("invalid pod label selector %q: %v", labelSelector, err)
+	}
+	if _, err := fields.ParseSelector(fieldSelector); err != nil {
+		return nil, fmt.Errorf("invalid pod field selector %q: %v", fieldSelector, err)
+	}
+	return &podSelectorClientset{
+		Interface: client,
+		selectors: podSelectors{labelSelector: labelSelector, fieldSelector: fieldSelector},
+	}, nil
+}
+
+type podSelectorClientset struct {
+	clientset.Interface
+	selectors podSelectors
+}
+
+func (c *podSelectorClientset) CoreV1() corev1.CoreV1Interface {
+	return &podSelectorCoreV1{CoreV1Interface: c.Interface.CoreV1(), selectors: c.selectors}
+}
+
+type podSelectorCoreV1 struct {
+	corev1.CoreV1Interface
+	selectors podSelectors
+}
+
+func (c *podSelectorCoreV1) Pods(namespace string) corev1.PodInterface {
+	return &podSelectorPods{PodInterface: c.CoreV1Interface.Pods(namespace), selectors: c.selectors}
+}
+
+type podSelectorPods struct {
+	corev1.PodInterface
+	selectors podSelectors
+}
+
+func (c *podSelectorPods) List(ctx context.Context, opts metav1.ListOptions) (*v1.PodList, error) {
+	return c.PodInterface.List(ctx, c.selectors.apply(opts))
+}
+
+func (c *podSelectorPods) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	return c.PodInterface.Watch(ctx, c.selectors.apply(opts))
+}
+
+// apply returns the list options with the selectors added to the requested ones
+func (s podSelectors) apply(opts metav1.ListOptions) metav1.ListOptions {
+	opts.LabelSelector = joinSelectors(opts.LabelSelector, s.labelSelector)
+	opts.FieldSelector = joinSelectors(opts.FieldSelector, s.fieldSelector)
+	return opts
+}
+
+// joinSelectors returns a selector matching both selectors, which are ANDed when joined with a comma
+func joinSelectors(a, b string) string {
+	if a == "" {
+		return b
+	}
+	if b == "" {
+		return a
+	}
+	return a + "," + b
+}
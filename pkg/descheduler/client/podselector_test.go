@@ -0,0 +1,101 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+)
+
+func TestWithPodSelectors(t *testing.T) {
+	testCases := []struct {
+		name          string
+		labelSelector string
+		fieldSelector string
+		listOptions   metav1.ListOptions
+		expectedLabel string
+		expectedField string
+		expectedError bool
+	}{
+		{
+			name:          "no selectors",
+			listOptions:   metav1.ListOptions{FieldSelector: "spec.nodeName=n1"},
+			expectedField: "spec.nodeName=n1",
+		},
+		{
+			name:          "selectors are added to the requested ones",
+			labelSelector: "app=web",
+			fieldSelector: "metadata.namespace!=kube-system",
+			listOptions:   metav1.ListOptions{LabelSelector: "tier=frontend", FieldSelector: "spec.nodeName=n1"},
+			expectedLabel: "app=web,tier=frontend",
+			expectedField: "metadata.namespace!=kube-system,spec.nodeName=n1",
+		},
+		{
+			name:          "selectors are used when none is requested",
+			fieldSelector: "metadata.namespace!=kube-system",
+			expectedField: "metadata.namespace!=kube-system",
+		},
+		{
+			name:          "invalid label selector",
+			labelSelector: "app in (web",
+			expectedError: true,
+		},
+		{
+			name:          "invalid field selector",
+			fieldSelector: "metadata.namespace",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := &fake.Clientset{}
+			var restrictions core.ListRestrictions
+			fakeClient.AddReactor("list", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				listAction := action.(core.ListActionImpl)
+				restrictions = listAction.GetListRestrictions()
+				return true, nil, nil
+			})
+
+			client, err := WithPodSelectors(fakeClient, tc.labelSelector, tc.fieldSelector)
+			if tc.expectedError {
+				if err == nil {
+					t.Fatalf("Expected an error for invalid selectors")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if _, err := client.CoreV1().Pods("").List(context.Background(), tc.listOptions); err != nil {
+				t.Fatalf("Unexpected error listing pods: %v", err)
+			}
+			if restrictions.Labels.String() != tc.expectedLabel {
+				t.Errorf("Expected label selector %q, got %q", tc.expectedLabel, restrictions.Labels.String())
+			}
+			if restrictions.Fields.String() != tc.expectedField {
+				t.Errorf("Expected field selector %q, got %q", tc.expectedField, restrictions.Fields.String())
+			}
+		})
+	}
+}
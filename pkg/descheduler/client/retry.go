@@ -0,0 +1,98 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+// throttlingRetryMaxDelay caps the delay between two retries of a throttled request, before jitter
+var throttlingRetryMaxDelay = 30 * time.Second
+
+// throttlingRetryRoundTripper retries requests rejected by API Priority and Fairness, which are 429
+// responses with a Retry-After header. Evictions refused because of a pod disruption budget are 429
+// responses without this header and are not retried. The delay starts at Retry-After and doubles with
+// every retry, with jitter, so throttled descheduling cycles do not hammer a busy API server in lockstep.
+type throttlingRetryRoundTripper struct {
+	delegate   http.RoundTripper
+	maxRetries int
+}
+
+func (rt *throttlingRetryRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := rt.delegate.RoundTrip(req)
+		if err != nil || resp.StatusCode != http.StatusTooManyRequests {
+			return resp, err
+		}
+		retryAfter, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+		if err != nil || retryAfter < 1 {
+			return resp, nil
+		}
+		if attempt == rt.maxRetries {
+			// client-go retries responses with a Retry-After header on its own, which would multiply the retries
+			resp.Header.Del("Retry-After")
+			return resp, nil
+		}
+		var body io.ReadCloser
+		if req.Body != nil {
+			if req.GetBody == nil {
+				return resp, nil
+			}
+			if body, err = req.GetBody(); err != nil {
+				return resp, nil
+			}
+		}
+		io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
+
+		delay := throttlingRetryDelay(time.Duration(retryAfter)*time.Second, attempt)
+		klog.V(3).InfoS("Request throttled by the API server, retrying", "method", req.Method, "path", req.URL.Path, "attempt", attempt+1, "delay", delay)
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+		req = req.Clone(req.Context())
+		req.Body = body
+	}
+}
+
+// throttlingRetryDelay doubles the Retry-After delay for every previous retry, up to
+// throttlingRetryMaxDelay, and adds up to 50% of jitter
+func throttlingRetryDelay(retryAfter time.Duration, attempt int) time.Duration {
+	delay := retryAfter
+	for i := 0; i < attempt && delay < throttlingRetryMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > throttlingRetryMaxDelay {
+		delay = throttlingRetryMaxDelay
+	}
+	return wait.Jitter(delay, 0.5)
+}
+
+func (rt *throttlingRetryRoundTripper) WrappedRoundTripper() http.RoundTripper {
+	return rt.delegate
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+)
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestThrottlingRetryRoundTripper(t *testing.T) {
+	defer func(maxDelay time.Duration) {
+		throttlingRetryMaxDelay = maxDelay
+	}(throttlingRetryMaxDelay)
+	throttlingRetryMaxDelay = time.Millisecond
+
+	tests := []struct {
+		name             string
+		rejections       int
+		retryAfter       string
+		maxRetries       int
+		expectedAttempts int
+		expectedStatus   int
+	}{
+		{
+			name:             "throttled request is retried until accepted",
+			rejections:       2,
+			retryAfter:       "1",
+			maxRetries:       3,
+			expectedAttempts: 3,
+			expectedStatus:   http.StatusCreated,
+		},
+		{
+			name:             "throttled request is retried up to the maximum number of retries",
+			rejections:       5,
+			retryAfter:       "1",
+			maxRetries:       3,
+			expectedAttempts: 4,
+			expectedStatus:   http.StatusTooManyRequests,
+		},
+		{
+			name:             "request rejected without Retry-After, e.g. by a pod disruption budget, is not retried",
+			rejections:       5,
+			maxRetries:       3,
+			expectedAttempts: 1,
+			expectedStatus:   http.StatusTooManyRequests,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			attempts := 0
+			delegate := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				attempts++
+				body, err := ioutil.ReadAll(req.Body)
+				if err != nil || string(body) != "eviction" {
+					t.Errorf("Expected the request body to be sent with every attempt, got %q (%v)", body, err)
+				}
+				resp := &http.Response{StatusCode: http.StatusCreated, Header: http.Header{}, Body: ioutil.NopCloser(&bytes.Buffer{})}
+				if attempts <= tc.rejections {
+					resp.StatusCode = http.StatusTooManyRequests
+					if tc.retryAfter != "" {
+						resp.Header.Set("Retry-After", tc.retryAfter)
+					}
+				}
+				return resp, nil
+			})
+
+			req, err := http.NewRequest(http.MethodPost, "https://localhost/api/v1/namespaces/default/pods/p1/eviction", bytes.NewBufferString("eviction"))
+			if err != nil {
+				t.Fatalf("Unable to create request: %v", err)
+			}
+			rt := &throttlingRetryRoundTripper{delegate: delegate, maxRetries: tc.maxRetries}
+			resp, err := rt.RoundTrip(req)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if attempts != tc.expectedAttempts {
+				t.Errorf("Expected %v attempts, got %v", tc.expectedAttempts, attempts)
+			}
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status %v, got %v", tc.expectedStatus, resp.StatusCode)
+			}
+			// client-go must not retry again once the retries are exhausted
+			if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+				t.Errorf("Expected no Retry-After header, got %q", retryAfter)
+			}
+		})
+	}
+}
+
+func TestThrottlingRetryDelay(t *testing.T) {
+	for attempt, expected := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 16 * time.Second, 30 * time.Second, 30 * time.Second} {
+		delay := throttlingRetryDelay(time.Second, attempt)
+		if delay < expected || delay > expected*3/2 {
+			t.Errorf("Expected a delay between %v and %v for attempt %v, got %v", expected, expected*3/2, attempt, delay)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	clientcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+)
+
+// abortEventNamespace is the namespace events of aborted cycles are emitted in
+const abortEventNamespace = "kube-system"
+
+// cycleBackoff returns the delay of the next descheduling cycle after the given number of consecutive aborted
+// cycles: the descheduling interval doubled for every aborted cycle, up to the maximum backoff
+func cycleBackoff(config *api.CycleAbort, interval time.Duration, abortedCycles int) time.Duration {
+	maxBackoff := time.Duration(evictions.DefaultMaxBackoffSeconds) * time.Second
+	if config != nil && config.MaxBackoffSeconds != nil {
+		maxBackoff = time.Duration(*config.MaxBackoffSeconds) * time.Second
+	}
+	backoff := interval
+	for i := 0; i < abortedCycles && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxBackoff {
+		return maxBackoff
+	}
+	return backoff
+}
+
+// recordAbortedCycle emits an event explaining why the descheduling cycle was aborted
+func recordAbortedCycle(client clientset.Interface, reason error) {
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartStructuredLogging(3)
+	eventBroadcaster.StartRecordingToSink(&clientcorev1.EventSinkImpl{Interface: client.CoreV1().Events(abortEventNamespace)})
+	r := eventBroadcaster.NewRecorder(clientgoscheme.Scheme, v1.EventSource{Component: "sigs.k8s.io.descheduler"})
+	r.Event(&v1.ObjectReference{Kind: "Namespace", APIVersion: "v1", Name: abortEventNamespace, Namespace: abortEventNamespace}, v1.EventTypeWarning, "DeschedulingAborted", fmt.Sprintf("descheduling cycle aborted: %v", reason))
+	klog.ErrorS(reason, "Too many evictions failed, aborting descheduling cycle")
+}
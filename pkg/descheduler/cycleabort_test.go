@@ -0,0 +1,100 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+
+	"sigs.k8s.io/descheduler/cmd/descheduler/app/options"
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestCycleAbort(t *testing.T) {
+	ctx := context.Background()
+	n1 := test.BuildTestNode("n1", 2000, 3000, 20, func(node *v1.Node) {
+		node.Spec.Taints = []v1.Taint{{Key: "key", Value: "value", Effect: v1.TaintEffectNoSchedule}}
+	})
+	n2 := test.BuildTestNode("n2", 2000, 3000, 20, nil)
+	objects := []runtime.Object{n1, n2}
+	for i := 0; i < 15; i++ {
+		objects = append(objects, test.BuildTestPod(fmt.Sprintf("p%d", i), 100, 0, n1.Name, test.SetRSOwnerRef))
+	}
+
+	client := fakeclientset.NewSimpleClientset(objects...)
+	attempts := 0
+	client.PrependReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "eviction" {
+			return false, nil, nil
+		}
+		attempts++
+		return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: "pods/eviction"}, "", fmt.Errorf("denied by webhook"))
+	})
+	dp := &api.DeschedulerPolicy{
+		Strategies: api.StrategyList{
+			"RemovePodsViolatingNodeTaints": api.DeschedulerStrategy{Enabled: true},
+		},
+		CycleAbort: &api.CycleAbort{MaxErrorRatio: 0.5},
+	}
+
+	rs, err := options.NewDeschedulerServer()
+	if err != nil {
+		t.Fatalf("Unable to initialize server: %v", err)
+	}
+	rs.Client = client
+	err = RunDeschedulerStrategies(ctx, rs, dp, "v1beta1", make(chan struct{}))
+	if err == nil || !strings.Contains(err.Error(), "descheduling cycle aborted: 10 of 10 eviction attempts failed") {
+		t.Fatalf("Expected the cycle to be aborted, got %v", err)
+	}
+	if attempts != 10 {
+		t.Errorf("Expected the evictions to stop after 10 attempts, got %v", attempts)
+	}
+}
+
+func TestCycleBackoff(t *testing.T) {
+	maxBackoffSeconds := uint(600)
+	config := &api.CycleAbort{MaxBackoffSeconds: &maxBackoffSeconds}
+	tests := []struct {
+		abortedCycles int
+		expected      time.Duration
+	}{
+		{abortedCycles: 1, expected: 2 * time.Minute},
+		{abortedCycles: 2, expected: 4 * time.Minute},
+		{abortedCycles: 3, expected: 8 * time.Minute},
+		{abortedCycles: 4, expected: 10 * time.Minute},
+		{abortedCycles: 100, expected: 10 * time.Minute},
+	}
+	for _, tc := range tests {
+		if actual := cycleBackoff(config, time.Minute, tc.abortedCycles); actual != tc.expected {
+			t.Errorf("Expected a backoff of %v after %v aborted cycles, got %v", tc.expected, tc.abortedCycles, actual)
+		}
+	}
+	if actual := cycleBackoff(&api.CycleAbort{}, time.Hour, 1); actual != time.Hour {
+		t.Errorf("Expected the default maximum backoff of an hour, got %v", actual)
+	}
+}
@@ -19,9 +19,18 @@ package descheduler
 import (
 	"context"
 	"fmt"
+	"math/rand"
+	"os"
 	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/nodeutilization"
+	"sort"
+	"sync"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/clock"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 
@@ -33,21 +42,44 @@ import (
 	"sigs.k8s.io/descheduler/pkg/descheduler/client"
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
 	eutils "sigs.k8s.io/descheduler/pkg/descheduler/evictions/utils"
+	"sigs.k8s.io/descheduler/pkg/descheduler/health"
+	"sigs.k8s.io/descheduler/pkg/descheduler/history"
+	"sigs.k8s.io/descheduler/pkg/descheduler/logging"
+	"sigs.k8s.io/descheduler/pkg/descheduler/namespacerules"
 	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+	"sigs.k8s.io/descheduler/pkg/descheduler/policyreport"
+	"sigs.k8s.io/descheduler/pkg/descheduler/remotewrite"
+	"sigs.k8s.io/descheduler/pkg/descheduler/report"
 	"sigs.k8s.io/descheduler/pkg/descheduler/strategies"
+	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/validation"
 )
 
 func Run(rs *options.DeschedulerServer) error {
 	metrics.Register()
 
 	ctx := context.Background()
-	rsclient, err := client.CreateClient(rs.KubeconfigFile)
+	rsclient, err := client.CreateClient(rs.KubeconfigFile, client.WithUserAgent(rs.UserAgent), client.WithThrottlingRetries(rs.ThrottlingRetries))
+	if err != nil {
+		return err
+	}
+	rs.Client, err = client.WithPodSelectors(rsclient, rs.PodLabelSelector, rs.PodFieldSelector)
+	if err != nil {
+		return err
+	}
+	rs.DynamicClient, err = client.CreateDynamicClient(rs.KubeconfigFile, client.WithUserAgent(rs.UserAgent), client.WithThrottlingRetries(rs.ThrottlingRetries))
 	if err != nil {
 		return err
 	}
-	rs.Client = rsclient
 
-	deschedulerPolicy, err := LoadPolicyConfig(rs.PolicyConfigFile)
+	var deschedulerPolicy *api.DeschedulerPolicy
+	if rs.PolicyResource != "" {
+		if rs.PolicyConfigFile != "" {
+			return fmt.Errorf("the policy config file and the policy resource are mutually exclusive")
+		}
+		deschedulerPolicy, err = LoadPolicyResource(ctx, rs.DynamicClient, rs.PolicyResource, rs.PolicyOverrides)
+	} else {
+		deschedulerPolicy, err = LoadPolicyConfig(rs.PolicyConfigFile, rs.PolicyOverrides)
+	}
 	if err != nil {
 		return err
 	}
@@ -64,98 +96,727 @@ func Run(rs *options.DeschedulerServer) error {
 	return RunDeschedulerStrategies(ctx, rs, deschedulerPolicy, evictionPolicyGroupVersion, stopChannel)
 }
 
-type strategyFunction func(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor)
+type strategyFunction func(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor) error
+
+// deterministicSeed seeds the random numbers of a descheduler running in deterministic mode
+const deterministicSeed = 1
+
+// strategyFuncs lists the strategies which can be enabled in the policy
+var strategyFuncs = map[api.StrategyName]strategyFunction{
+	"RemoveDuplicates":                            strategies.RemoveDuplicatePods,
+	"LowNodeUtilization":                          nodeutilization.LowNodeUtilization,
+	"HighNodeUtilization":                         nodeutilization.HighNodeUtilization,
+	"RemovePodsViolatingInterPodAntiAffinity":     strategies.RemovePodsViolatingInterPodAntiAffinity,
+	"RemovePodsViolatingNodeAffinity":             strategies.RemovePodsViolatingNodeAffinity,
+	"RemovePodsViolatingNodeTaints":               strategies.RemovePodsViolatingNodeTaints,
+	"RemovePodsHavingTooManyRestarts":             strategies.RemovePodsHavingTooManyRestarts,
+	"PodLifeTime":                                 strategies.PodLifeTime,
+	"RemovePodsViolatingTopologySpreadConstraint": strategies.RemovePodsViolatingTopologySpreadConstraint,
+	"RemoveFailedPods":                            strategies.RemoveFailedPods,
+	"RemovePodsViolatingAntiColocation":           strategies.RemovePodsViolatingAntiColocation,
+	"RemovePodsViolatingPodDensity":               strategies.RemovePodsViolatingPodDensity,
+	"RemovePodsFromNodesWithProblems":             strategies.RemovePodsFromNodesWithProblems,
+	"RemovePodsFromOvercommittedNodes":            strategies.RemovePodsFromOvercommittedNodes,
+	"RemoveFinishedJobPods":                       strategies.RemoveFinishedJobPods,
+	"RemovePodsExceedingMemoryRequests":           strategies.RemovePodsExceedingMemoryRequests,
+	"RemovePodsWithOutdatedPriority":              strategies.RemovePodsWithOutdatedPriority,
+	"RemovePodsFromInterruptedNodes":              strategies.RemovePodsFromInterruptedNodes,
+	"RemovePodsStuckInCreation":                   strategies.RemovePodsStuckInCreation,
+	"RemovePodsForImageLocality":                  strategies.RemovePodsForImageLocality,
+	"RemovePodsFromNotReadyNodes":                 strategies.RemovePodsFromNotReadyNodes,
+	"RemoveDuplicateJobIndexPods":                 strategies.RemoveDuplicateJobIndexPods,
+	"RemovePodsViolatingGPUModelPreference":       strategies.RemovePodsViolatingGPUModelPreference,
+	"RemovePodsStuckTerminating":                  strategies.RemovePodsStuckTerminating,
+	"RemovePodsFromNodesWithRestartChurn":         strategies.RemovePodsFromNodesWithRestartChurn,
+}
 
 func RunDeschedulerStrategies(ctx context.Context, rs *options.DeschedulerServer, deschedulerPolicy *api.DeschedulerPolicy, evictionPolicyGroupVersion string, stopChannel chan struct{}) error {
 	sharedInformerFactory := informers.NewSharedInformerFactory(rs.Client, 0)
 	nodeInformer := sharedInformerFactory.Core().V1().Nodes()
 
+	// node deletions only request cycles of a descheduler running periodically
+	var nodeDeletions *nodeDeletionTrigger
+	if rs.DeschedulingInterval.Seconds() != 0 {
+		nodeDeletions = newNodeDeletionTrigger(nodeInformer.Lister())
+		nodeInformer.Informer().AddEventHandler(nodeDeletions)
+	}
+
 	sharedInformerFactory.Start(stopChannel)
 	sharedInformerFactory.WaitForCacheSync(stopChannel)
 
-	strategyFuncs := map[api.StrategyName]strategyFunction{
-		"RemoveDuplicates":                            strategies.RemoveDuplicatePods,
-		"LowNodeUtilization":                          nodeutilization.LowNodeUtilization,
-		"HighNodeUtilization":                         nodeutilization.HighNodeUtilization,
-		"RemovePodsViolatingInterPodAntiAffinity":     strategies.RemovePodsViolatingInterPodAntiAffinity,
-		"RemovePodsViolatingNodeAffinity":             strategies.RemovePodsViolatingNodeAffinity,
-		"RemovePodsViolatingNodeTaints":               strategies.RemovePodsViolatingNodeTaints,
-		"RemovePodsHavingTooManyRestarts":             strategies.RemovePodsHavingTooManyRestarts,
-		"PodLifeTime":                                 strategies.PodLifeTime,
-		"RemovePodsViolatingTopologySpreadConstraint": strategies.RemovePodsViolatingTopologySpreadConstraint,
-		"RemoveFailedPods":                            strategies.RemoveFailedPods,
-	}
+	logging.SetSampling(rs.LogSamplingInitial, rs.LogSamplingThereafter)
 
-	nodeSelector := rs.NodeSelector
-	if deschedulerPolicy.NodeSelector != nil {
-		nodeSelector = *deschedulerPolicy.NodeSelector
+	clk := rs.Clock
+	if clk == nil {
+		clk = clock.RealClock{}
 	}
-
-	evictLocalStoragePods := rs.EvictLocalStoragePods
-	if deschedulerPolicy.EvictLocalStoragePods != nil {
-		evictLocalStoragePods = *deschedulerPolicy.EvictLocalStoragePods
+	if rs.Deterministic {
+		rand.Seed(deterministicSeed)
 	}
 
-	evictSystemCriticalPods := false
-	if deschedulerPolicy.EvictSystemCriticalPods != nil {
-		evictSystemCriticalPods = *deschedulerPolicy.EvictSystemCriticalPods
-		if evictSystemCriticalPods {
-			klog.V(1).InfoS("Warning: EvictSystemCriticalPods is set to True. This could cause eviction of Kubernetes system pods.")
+	var reloader policySource
+	// policyWatcher writes the outcome of the cycles to the status of the policy resource
+	var policyWatcher *policyResourceWatcher
+	if rs.PolicyResource != "" && rs.DynamicClient != nil {
+		policyWatcher = newPolicyResourceWatcher(rs.DynamicClient, rs.PolicyResource, rs.PolicyOverrides, deschedulerPolicy)
+		if err := policyWatcher.start(stopChannel); err != nil {
+			return err
+		}
+		reloader = policyWatcher
+	} else if rs.PolicyReload && rs.PolicyConfigFile != "" {
+		fileReloader := newPolicyReloader(rs.PolicyConfigFile, rs.PolicyOverrides, deschedulerPolicy)
+		if err := fileReloader.start(stopChannel); err != nil {
+			return err
 		}
+		reloader = fileReloader
 	}
 
-	ignorePvcPods := false
-	if deschedulerPolicy.IgnorePVCPods != nil {
-		ignorePvcPods = *deschedulerPolicy.IgnorePVCPods
-	}
+	ownPod := getOwnPod(ctx, rs.Client)
 
-	maxNoOfPodsToEvictPerNode := rs.MaxNoOfPodsToEvictPerNode
-	if deschedulerPolicy.MaxNoOfPodsToEvictPerNode != nil {
-		maxNoOfPodsToEvictPerNode = *deschedulerPolicy.MaxNoOfPodsToEvictPerNode
-	}
+	// the cooldown tracker outlives descheduling cycles, unless a reloaded policy changes the cooldown
+	var cooldown *evictions.CooldownTracker
+	var cooldownSeconds *uint
+	// cooldownRestored tells whether the stored evictions were restored in the current cooldown tracker
+	cooldownRestored := false
+	// strategyErrs holds the errors returned by the strategies during the last cycle
+	var strategyErrs []error
+	// abortedCycles counts the consecutive cycles aborted because of failed evictions, delaying the next cycles
+	// until backoffUntil
+	abortedCycles := 0
+	var backoffUntil time.Time
+
+	// cycleLock prevents cycles requested by node deletions from overlapping periodic cycles
+	var cycleLock sync.Mutex
+
+	// runCycle runs a descheduling cycle, running only the given strategy when set
+	runCycle := func(only api.StrategyName) {
+		cycleLock.Lock()
+		defer cycleLock.Unlock()
+		select {
+		case <-stopChannel:
+			return
+		default:
+		}
+
+		if reloader != nil {
+			deschedulerPolicy = reloader.Policy()
+		}
+		// a cycle which can not run with the policy is skipped, the next cycles run once the policy is fixed. Without
+		// interval there is no other cycle to wait for, the error is reported through the exit status.
+		skipCycle := func(err error, msg string) {
+			klog.ErrorS(err, msg+", skipping the descheduling cycle")
+			strategyErrs = []error{fmt.Errorf("descheduling cycle skipped: %w", err)}
+			if rs.DeschedulingInterval.Seconds() == 0 {
+				close(stopChannel)
+			}
+		}
+		if clk.Now().Before(backoffUntil) {
+			klog.V(1).InfoS("Backing off after aborted descheduling cycles, waiting for the next descheduling cycle", "abortedCycles", abortedCycles, "until", backoffUntil)
+			return
+		}
+		profiles := policyProfiles(deschedulerPolicy)
+		if only != "" && !strategyEnabled(profiles, only) {
+			klog.V(1).InfoS("Strategy is not enabled, waiting for the next descheduling cycle", "strategy", only)
+			return
+		}
+		if nodeDeletions != nil {
+			nodeDeletions.reset(deschedulerPolicy.NodeDeletionTrigger)
+		}
+
+		evictLocalStoragePods := rs.EvictLocalStoragePods
+		if deschedulerPolicy.EvictLocalStoragePods != nil {
+			evictLocalStoragePods = *deschedulerPolicy.EvictLocalStoragePods
+		}
+
+		evictSystemCriticalPods := false
+		if deschedulerPolicy.EvictSystemCriticalPods != nil {
+			evictSystemCriticalPods = *deschedulerPolicy.EvictSystemCriticalPods
+			if evictSystemCriticalPods {
+				klog.V(1).InfoS("Warning: EvictSystemCriticalPods is set to True. This could cause eviction of Kubernetes system pods.")
+			}
+		}
+
+		ignorePvcPods := false
+		if deschedulerPolicy.IgnorePVCPods != nil {
+			ignorePvcPods = *deschedulerPolicy.IgnorePVCPods
+		}
+
+		reschedulingHints := false
+		if deschedulerPolicy.ReschedulingHints != nil {
+			reschedulingHints = *deschedulerPolicy.ReschedulingHints
+		}
+
+		skipOwnersRollingOut := false
+		if deschedulerPolicy.SkipOwnersRollingOut != nil {
+			skipOwnersRollingOut = *deschedulerPolicy.SkipOwnersRollingOut
+		}
+
+		var replacementReadinessTimeout time.Duration
+		if deschedulerPolicy.ReplacementReadinessTimeoutSeconds != nil {
+			replacementReadinessTimeout = time.Duration(*deschedulerPolicy.ReplacementReadinessTimeoutSeconds) * time.Second
+		}
+
+		var volumeDetachTimeout time.Duration
+		if deschedulerPolicy.VolumeDetachTimeoutSeconds != nil {
+			volumeDetachTimeout = time.Duration(*deschedulerPolicy.VolumeDetachTimeoutSeconds) * time.Second
+		}
+
+		if !equalUintPtr(cooldownSeconds, deschedulerPolicy.PodEvictionCooldownSeconds) {
+			cooldownSeconds = deschedulerPolicy.PodEvictionCooldownSeconds
+			cooldown = nil
+			if cooldownSeconds != nil {
+				cooldown = evictions.NewCooldownTracker(time.Duration(*cooldownSeconds) * time.Second)
+			}
+			cooldownRestored = false
+		}
 
-	wait.Until(func() {
-		nodes, err := nodeutil.ReadyNodes(ctx, rs.Client, nodeInformer, nodeSelector)
+		if err := evictions.ValidateProtectedDeployments(deschedulerPolicy.ProtectedDeployments); err != nil {
+			skipCycle(err, "Invalid protected deployments")
+			return
+		}
+		if err := evictions.ValidateProtectedPodConditions(deschedulerPolicy.ProtectedPodConditions); err != nil {
+			skipCycle(err, "Invalid protected pod conditions")
+			return
+		}
+		if err := namespacerules.ValidateGuardrails(deschedulerPolicy.NamespaceRules); err != nil {
+			skipCycle(err, "Invalid namespace rules")
+			return
+		}
+		var interactiveSessionLookback time.Duration
+		if deschedulerPolicy.InteractiveSessionLookbackSeconds != nil {
+			interactiveSessionLookback = time.Duration(*deschedulerPolicy.InteractiveSessionLookbackSeconds) * time.Second
+		}
+		protected := evictions.NewProtectedPods(ownPod, deschedulerPolicy.ProtectedDeployments, interactiveSessionLookback, deschedulerPolicy.ProtectedPodConditions)
+		evictionFilter, err := evictions.NewPodFilter(deschedulerPolicy.EvictionFilter)
 		if err != nil {
-			klog.V(1).InfoS("Unable to get ready nodes", "err", err)
-			close(stopChannel)
+			skipCycle(err, "Invalid eviction filter")
+			return
+		}
+		namespaces := newNamespaceSelection(rs.Client)
+		if err := namespaces.selectEvictionFilterNamespaces(ctx, evictionFilter); err != nil {
+			skipCycle(err, "Unable to select the namespaces of the eviction filter")
+			return
+		}
+		errorBudget, err := evictions.NewErrorBudget(deschedulerPolicy.CycleAbort)
+		if err != nil {
+			skipCycle(err, "Invalid cycle abort")
+			return
+		}
+		// strategies in dry run mode have their own limiter, like their own evictors
+		evictionLimiter, err := evictions.NewEvictionLimiter("per descheduling cycle", deschedulerPolicy.EvictionLimits)
+		if err != nil {
+			skipCycle(err, "Invalid eviction limits")
 			return
 		}
+		dryRunEvictionLimiter, _ := evictions.NewEvictionLimiter("per descheduling cycle", deschedulerPolicy.EvictionLimits)
+		var cooldownStore *evictions.CooldownStore
+		if deschedulerPolicy.CooldownPersistence != nil && cooldown != nil {
+			cooldownStore, err = evictions.NewCooldownStore(rs.Client, deschedulerPolicy.CooldownPersistence)
+			if err != nil {
+				skipCycle(err, "Invalid cooldown persistence")
+				return
+			}
+			if !cooldownRestored {
+				// the stored evictions are not overwritten until they are restored
+				if err := cooldownStore.Restore(ctx, cooldown); err != nil {
+					klog.ErrorS(err, "Unable to restore the pod eviction cooldown")
+				} else {
+					cooldownRestored = true
+				}
+			}
+		}
 
-		if len(nodes) <= 1 {
+		if deschedulerPolicy.HealthGates != nil {
+			allNodes, err := nodeInformer.Lister().List(labels.Everything())
+			if err != nil {
+				klog.V(1).InfoS("Unable to list nodes", "err", err)
+				close(stopChannel)
+				return
+			}
+			if err := health.CheckClusterHealth(ctx, rs.Client, allNodes, deschedulerPolicy.HealthGates); err != nil {
+				health.RecordUnhealthyCluster(rs.Client, err)
+				// If there was no interval specified, there is no other cycle to wait for
+				if rs.DeschedulingInterval.Seconds() == 0 {
+					close(stopChannel)
+				}
+				return
+			}
+		}
+
+		// every profile runs against the nodes of its node selector, profiles with 0 or 1 node are skipped
+		var runs []*profileRun
+		for _, profile := range profiles {
+			nodeSelector := rs.NodeSelector
+			if profile.NodeSelector != nil {
+				nodeSelector = *profile.NodeSelector
+			}
+			nodes, err := nodeutil.ReadyNodes(ctx, rs.Client, nodeInformer, nodeSelector)
+			if err != nil {
+				klog.V(1).InfoS("Unable to get ready nodes", "err", err)
+				close(stopChannel)
+				return
+			}
+			// the node selector was already parsed by ReadyNodes
+			selector, _ := labels.Parse(nodeSelector)
+
+			if rs.ExcludeVirtualNodes {
+				nodes = excludeVirtualNodes(nodes)
+			}
+			if rs.Deterministic {
+				// the order of the nodes listed from the informer cache varies between runs
+				sort.Slice(nodes, func(i, j int) bool {
+					return nodes[i].Name < nodes[j].Name
+				})
+			}
+
+			if len(nodes) <= 1 {
+				klog.V(1).InfoS("The profile has 0 or 1 node meaning eviction causes service disruption or degradation. So skipping it..", "profile", profile.Name)
+				continue
+			}
+			runs = append(runs, &profileRun{profile: profile, nodes: nodes, nodeSelector: selector})
+		}
+
+		if len(runs) == 0 {
 			klog.V(1).InfoS("The cluster size is 0 or 1 meaning eviction causes service disruption or degradation. So aborting..")
 			close(stopChannel)
 			return
 		}
 
-		podEvictor := evictions.NewPodEvictor(
-			rs.Client,
-			evictionPolicyGroupVersion,
-			rs.DryRun,
-			maxNoOfPodsToEvictPerNode,
-			nodes,
-			evictLocalStoragePods,
-			evictSystemCriticalPods,
-			ignorePvcPods,
-		)
+		cycleStart := metav1.NewTime(clk.Now())
+		podAnnotations := evictions.NewPodAnnotations(deschedulerPolicy.EvictedPodAnnotations, cycleStart.Time)
+		// each profile has its own evictors, the evictions of a profile do not count against the limits of the others
+		newPodEvictor := func(run *profileRun, dryRun bool) *evictions.PodEvictor {
+			maxNoOfPodsToEvictPerNode := rs.MaxNoOfPodsToEvictPerNode
+			if run.profile.MaxNoOfPodsToEvictPerNode != nil {
+				maxNoOfPodsToEvictPerNode = *run.profile.MaxNoOfPodsToEvictPerNode
+			}
+			maxNoOfPodsToEvictPerOwnerPerNode := 0
+			if run.profile.MaxPerOwnerPerNode != nil {
+				maxNoOfPodsToEvictPerOwnerPerNode = *run.profile.MaxPerOwnerPerNode
+			}
+			podEvictor := evictions.NewPodEvictor(
+				rs.Client,
+				evictionPolicyGroupVersion,
+				dryRun,
+				maxNoOfPodsToEvictPerNode,
+				maxNoOfPodsToEvictPerOwnerPerNode,
+				run.nodes,
+				evictLocalStoragePods,
+				evictSystemCriticalPods,
+				ignorePvcPods,
+			)
+			podEvictor.SetReschedulingHints(reschedulingHints)
+			podEvictor.SetReplacementReadinessTimeout(replacementReadinessTimeout)
+			podEvictor.SetCooldown(cooldown)
+			podEvictor.SetVolumeDetachTimeout(volumeDetachTimeout)
+			podEvictor.SetProtectedPods(protected)
+			podEvictor.SetPodFilter(evictionFilter)
+			podEvictor.SetSkipOwnersRollingOut(skipOwnersRollingOut)
+			podEvictor.SetPodAnnotations(podAnnotations)
+			podEvictor.SetClock(clk)
+			podEvictor.SetEmptyDirSizeLimit(deschedulerPolicy.EvictableEmptyDirSizeLimit)
+			// strategies listing NotReady nodes only process the nodes of the profile
+			podEvictor.SetNodeScope(func(node *v1.Node) bool {
+				return run.nodeSelector.Matches(labels.Set(node.Labels)) && !(rs.ExcludeVirtualNodes && nodeutil.IsVirtualNode(node))
+			})
+			// the evictors of all profiles share the error budget and the eviction limits of the cycle
+			podEvictor.SetErrorBudget(errorBudget)
+			if dryRun && !rs.DryRun {
+				podEvictor.SetEvictionLimiter(dryRunEvictionLimiter)
+			} else {
+				podEvictor.SetEvictionLimiter(evictionLimiter)
+			}
+			return podEvictor
+		}
+		// strategies in dry run mode share a separate evictor per profile so their evictions
+		// do not count against the limits of the evicting strategies
+		for _, run := range runs {
+			run.podEvictor = newPodEvictor(run, rs.DryRun)
+		}
+		failedStrategies := map[api.StrategyName]error{}
+		strategyErrs = nil
+
+		// the strategies of the profiles run one profile after the other
+		type profileStrategy struct {
+			run  *profileRun
+			name api.StrategyName
+		}
+		var steps []profileStrategy
+		for _, run := range runs {
+			for _, name := range strategyOrder(run.profile.Strategies) {
+				steps = append(steps, profileStrategy{run: run, name: name})
+			}
+		}
 
-		for name, strategy := range deschedulerPolicy.Strategies {
-			if f, ok := strategyFuncs[name]; ok {
+		// the strategies already run and the evictors are kept when the cycle resumes after a lost connection, and the
+		// interrupted strategy skips the nodes it already visited
+		funcs := strategyFunctions()
+		var resumeDeadline time.Time
+		var checkpoint *evictions.NodeCheckpoint
+		resumed := false
+		interrupted := false
+		for i := 0; i < len(steps) && !interrupted; i++ {
+			if !resumed {
+				checkpoint = evictions.NewNodeCheckpoint()
+			}
+			resumed = false
+			run, name := steps[i].run, steps[i].name
+			if only != "" && name != only {
+				continue
+			}
+			strategy := run.profile.Strategies[name]
+			reportedName := profileStrategyName(deschedulerPolicy, run.profile.Name, name)
+			if f, ok := funcs[name]; ok {
 				if strategy.Enabled {
-					f(ctx, rs.Client, strategy, nodes, podEvictor)
+					evictor := run.podEvictor
+					if strategy.DryRun && !rs.DryRun {
+						if run.dryRunPodEvictor == nil {
+							run.dryRunPodEvictor = newPodEvictor(run, true)
+						}
+						evictor = run.dryRunPodEvictor
+					}
+					// the timeout bounds the evictions and the calls to the API server of the strategy
+					strategyCtx, cancel := ctx, func() {}
+					if strategy.TimeoutSeconds != nil && *strategy.TimeoutSeconds > 0 {
+						timeout := time.Duration(*strategy.TimeoutSeconds) * time.Second
+						evictor.SetDeadline(clk.Now().Add(timeout))
+						strategyCtx, cancel = context.WithTimeout(ctx, timeout)
+					}
+					strategyLimiter, err := evictions.NewEvictionLimiter(fmt.Sprintf("per descheduling cycle by strategy %s", reportedName), strategy.EvictionLimits)
+					if err != nil {
+						err = validation.NewStrategyError(validation.ErrorReasonInvalidParameters, "invalid eviction limits", err)
+					}
+					evictor.SetStrategyEvictionLimiter(strategyLimiter)
+					evictor.SetNodeCheckpoint(checkpoint)
+					var selected bool
+					if err == nil {
+						strategy, selected, err = namespaces.selectStrategyNamespaces(ctx, strategy)
+					}
+					if err == nil && !selected {
+						klog.V(1).InfoS("No namespace matches the namespace selector of the strategy, skipping it", "strategy", reportedName)
+					} else if err == nil {
+						logging.WithVerbosity(strategy.LogVerbosity, func() {
+							err = f(strategyCtx, rs.Client, strategy, strategyNodes(name, strategy, run.nodes), evictor)
+						})
+					}
+					if evictor.DeadlineExceeded() || (strategyCtx.Err() == context.DeadlineExceeded && ctx.Err() == nil) {
+						klog.V(1).InfoS("Strategy exceeded its timeout, no further pod was evicted by the strategy", "strategy", reportedName, "timeoutSeconds", *strategy.TimeoutSeconds)
+						// the calls interrupted by the timeout are not failures of the strategy
+						if strategyCtx.Err() != nil {
+							err = nil
+						}
+					}
+					cancel()
+					evictor.SetDeadline(time.Time{})
+					evictor.SetStrategyEvictionLimiter(nil)
+					evictor.SetNodeCheckpoint(nil)
+					if rs.ReconnectTimeout > 0 && !apiServerReachable(rs.Client) {
+						if resumeDeadline.IsZero() {
+							resumeDeadline = reconnectDeadline(clk.Now(), cycleStart.Time, rs.ReconnectTimeout, rs.DeschedulingInterval)
+						}
+						klog.V(1).InfoS("Lost the connection to the API server, waiting for it to resume the descheduling cycle", "strategy", reportedName, "deadline", resumeDeadline)
+						if waitForAPIServer(rs.Client, clk, resumeDeadline, stopChannel) {
+							klog.V(1).InfoS("Reconnected to the API server, resuming the descheduling cycle", "strategy", reportedName, "visitedNodes", checkpoint.Visited())
+							resumed = true
+							i--
+							continue
+						}
+						klog.V(1).InfoS("API server still unreachable, the remaining strategies are skipped until the next descheduling cycle", "strategy", reportedName)
+						interrupted = true
+						if err == nil {
+							err = validation.NewStrategyError(validation.ErrorReasonAPI, "lost the connection to the API server", nil)
+						}
+					}
+					if err != nil {
+						reason := validation.ReasonForError(err)
+						klog.ErrorS(err, "Strategy failed", "strategy", reportedName, "reason", reason)
+						metrics.StrategyErrors.With(map[string]string{"strategy": string(reportedName), "reason": string(reason)}).Inc()
+						failedStrategies[reportedName] = err
+						strategyErrs = append(strategyErrs, fmt.Errorf("strategy %s failed: %w", reportedName, err))
+					}
+					if errorBudget.Exhausted() {
+						klog.V(1).InfoS("Too many evictions failed, the remaining strategies are skipped until the next descheduling cycle", "strategy", reportedName)
+						interrupted = true
+					}
 				}
 			} else {
-				klog.ErrorS(fmt.Errorf("unknown strategy name"), "skipping strategy", "strategy", name)
+				klog.ErrorS(fmt.Errorf("unknown strategy name"), "skipping strategy", "strategy", reportedName)
+			}
+		}
+
+		// the next cycles are delayed exponentially while cycles are aborted, not to hammer the API server with
+		// evictions failing for the same reason
+		if errorBudget.Exhausted() {
+			abortErr := errorBudget.Error()
+			recordAbortedCycle(rs.Client, abortErr)
+			metrics.CyclesAborted.Inc()
+			strategyErrs = append(strategyErrs, fmt.Errorf("descheduling cycle aborted: %w", abortErr))
+			abortedCycles++
+			backoffUntil = clk.Now().Add(cycleBackoff(deschedulerPolicy.CycleAbort, rs.DeschedulingInterval, abortedCycles))
+		} else {
+			abortedCycles = 0
+		}
+
+		// strategies enabled by namespace owners run after the strategies of the policy, within the limits of its
+		// first profile
+		if deschedulerPolicy.NamespaceRules != nil && only == "" && !interrupted {
+			runNamespaceRules(ctx, rs, deschedulerPolicy.NamespaceRules, namespaces, runs[0].nodes, runs[0].podEvictor)
+		}
+
+		var evictedPods []evictions.EvictedPod
+		var nodeFitFailures []evictions.NodeFitFailure
+		var nodeUtilizations []evictions.NodeUtilization
+		totalEvicted, totalEvictedInDryRun := 0, 0
+		dryRunStrategies := false
+		for _, run := range runs {
+			totalEvicted += run.podEvictor.TotalEvicted()
+			evictedPods = append(evictedPods, run.podEvictor.EvictedPods()...)
+			nodeFitFailures = append(nodeFitFailures, run.podEvictor.NodeFitFailures()...)
+			nodeUtilizations = append(nodeUtilizations, run.podEvictor.NodeUtilizations()...)
+			if run.dryRunPodEvictor != nil {
+				dryRunStrategies = true
+				totalEvictedInDryRun += run.dryRunPodEvictor.TotalEvicted()
+				evictedPods = append(evictedPods, run.dryRunPodEvictor.EvictedPods()...)
+				nodeFitFailures = append(nodeFitFailures, run.dryRunPodEvictor.NodeFitFailures()...)
+				nodeUtilizations = append(nodeUtilizations, run.dryRunPodEvictor.NodeUtilizations()...)
 			}
 		}
 
-		klog.V(1).InfoS("Number of evicted pods", "totalEvicted", podEvictor.TotalEvicted())
+		if dryRunStrategies {
+			klog.V(1).InfoS("Number of pods evicted in dry run mode", "totalEvicted", totalEvictedInDryRun)
+		}
+		klog.V(1).InfoS("Number of evicted pods", "totalEvicted", totalEvicted)
+		logging.FlushSampling()
 
-		// If there was no interval specified, send a signal to the stopChannel to end the wait.Until loop after 1 iteration
+		var pdbImpacts []evictions.PDBImpact
+		if rs.DryRun || dryRunStrategies {
+			pdbImpacts, err = evictions.PDBImpacts(ctx, rs.Client, evictedPods)
+			if err != nil {
+				klog.ErrorS(err, "Unable to preview the impact of dry run evictions on pod disruption budgets")
+			}
+			logPDBImpacts(pdbImpacts)
+		}
+
+		cycle := history.NewCycle(cycleStart, evictedPods)
+		cycle.AddNodeFitFailures(nodeFitFailures)
+		cycle.AddPDBImpacts(pdbImpacts)
+		for _, step := range steps {
+			name := profileStrategyName(deschedulerPolicy, step.run.profile.Name, step.name)
+			if err, ok := failedStrategies[name]; ok {
+				cycle.AddStrategyError(name, err)
+			}
+		}
+		if cooldownStore != nil && cooldownRestored && !rs.DryRun {
+			if err := cooldownStore.Save(ctx, cooldown, clk.Now()); err != nil {
+				klog.ErrorS(err, "Unable to store the pod eviction cooldown")
+			}
+		}
+		if deschedulerPolicy.EvictionHistory != nil {
+			if err := history.NewStore(rs.Client, deschedulerPolicy.EvictionHistory).Record(ctx, cycle); err != nil {
+				klog.ErrorS(err, "Unable to record eviction history")
+			}
+		}
+		if deschedulerPolicy.PolicyReports != nil {
+			if rs.DynamicClient == nil {
+				klog.ErrorS(fmt.Errorf("no dynamic client"), "Unable to write policy reports")
+			} else if err := policyreport.NewWriter(rs.DynamicClient, deschedulerPolicy.PolicyReports).Write(ctx, cycle); err != nil {
+				klog.ErrorS(err, "Unable to write policy reports")
+			}
+		}
+		if deschedulerPolicy.RemoteWrite != nil {
+			if err := remotewrite.NewWriter(deschedulerPolicy.RemoteWrite).Write(ctx, cycleStart.Time, nodeUtilizations); err != nil {
+				klog.ErrorS(err, "Unable to remote write node utilizations")
+			}
+		}
+		if rs.ReportFormat != "" {
+			if err := report.Write(os.Stdout, rs.ReportFormat, report.New(cycle, nodeUtilizations)); err != nil {
+				klog.ErrorS(err, "Unable to write the descheduling cycle report")
+			}
+		}
+		if policyWatcher != nil {
+			if err := policyWatcher.recordCycle(ctx, clk.Now(), totalEvicted, strategyErrs); err != nil {
+				klog.ErrorS(err, "Unable to update the status of the policy resource", "resource", policyResourceName(rs.PolicyResource))
+			}
+		}
+
+		// If there was no interval specified, send a signal to the stopChannel to end the wait.BackoffUntil loop after 1 iteration
 		if rs.DeschedulingInterval.Seconds() == 0 {
 			close(stopChannel)
 		}
-	}, rs.DeschedulingInterval, stopChannel)
+	}
+
+	if nodeDeletions != nil {
+		go func() {
+			for {
+				select {
+				case <-nodeDeletions.requested:
+					runCycle(nodeDeletionStrategy)
+				case <-stopChannel:
+					return
+				}
+			}
+		}()
+	}
+	// cycles are scheduled with the clock, so a fake clock steps through descheduling intervals
+	wait.BackoffUntil(func() { runCycle("") }, wait.NewJitteredBackoffManager(rs.DeschedulingInterval, 0, clk), true, stopChannel)
 
+	// without interval, report the failed strategies of the single cycle through the exit status
+	if rs.DeschedulingInterval.Seconds() == 0 {
+		return utilerrors.NewAggregate(strategyErrs)
+	}
 	return nil
 }
+
+// getOwnPod returns the pod the descheduler runs in, given by the POD_NAME and POD_NAMESPACE environment
+// variables set through the downward API, or nil when they are not set
+func getOwnPod(ctx context.Context, client clientset.Interface) *v1.Pod {
+	name, namespace := os.Getenv("POD_NAME"), os.Getenv("POD_NAMESPACE")
+	if name == "" || namespace == "" {
+		klog.V(1).InfoS("POD_NAME and POD_NAMESPACE are not set, only the pods of protected deployments are protected from evictions")
+		return nil
+	}
+	pod, err := client.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		klog.ErrorS(err, "Unable to get the descheduler pod, the other pods of its owner are not protected from evictions", "pod", klog.KRef(namespace, name))
+		return &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+	}
+	return pod
+}
+
+// equalUintPtr checks if both pointers are nil or point to the same value
+func equalUintPtr(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// strategiesRunFirst lists strategies run before the others in every descheduling cycle,
+// so their evictions are not delayed nor prevented by the eviction limits
+var strategiesRunFirst = map[api.StrategyName]bool{
+	"RemovePodsFromInterruptedNodes": true,
+}
+
+// logPDBImpacts logs the pod disruption budget disruptions the evictions in dry run mode would consume,
+// evictions exceeding the allowed disruptions would be refused by the API server
+func logPDBImpacts(impacts []evictions.PDBImpact) {
+	for _, impact := range impacts {
+		keysAndValues := []interface{}{
+			"pdb", klog.KRef(impact.Namespace, impact.PDB), "ownerKind", impact.OwnerKind, "ownerName", impact.OwnerName,
+			"evictions", impact.Evictions, "pdbEvictions", impact.TotalEvictions, "disruptionsAllowed", impact.DisruptionsAllowed,
+			"currentHealthy", impact.CurrentHealthy, "desiredHealthy", impact.DesiredHealthy,
+		}
+		if impact.Exceeded() {
+			klog.InfoS("Warning: evictions in dry run mode exceed the disruptions allowed by the pod disruption budget", keysAndValues...)
+		} else {
+			klog.V(1).InfoS("Evictions in dry run mode consume disruptions of the pod disruption budget", keysAndValues...)
+		}
+	}
+}
+
+// runNamespaceRules runs the strategies enabled by the DeschedulingRule objects of namespace owners, each
+// restricted to the namespace of its rule. Failures of these strategies are logged, they do not fail the cycle.
+func runNamespaceRules(ctx context.Context, rs *options.DeschedulerServer, guardrails *api.NamespaceRules, namespaces *namespaceSelection, nodes []*v1.Node, podEvictor *evictions.PodEvictor) {
+	if rs.DynamicClient == nil {
+		klog.ErrorS(fmt.Errorf("no dynamic client"), "Unable to read descheduling rules")
+		return
+	}
+	ruleStrategies, err := namespacerules.List(ctx, rs.DynamicClient, guardrails)
+	if err != nil {
+		klog.ErrorS(err, "Unable to read descheduling rules")
+		return
+	}
+	funcs := strategyFunctions()
+	for _, rule := range ruleStrategies {
+		f, ok := funcs[rule.Name]
+		if !ok {
+			klog.ErrorS(fmt.Errorf("unknown strategy name"), "Skipping strategy of descheduling rule", "rule", rule.Rule, "strategy", rule.Name)
+			continue
+		}
+		strategy, selected, err := namespaces.selectStrategyNamespaces(ctx, rule.Strategy)
+		if err != nil {
+			klog.ErrorS(err, "Strategy of descheduling rule failed", "rule", rule.Rule, "strategy", rule.Name)
+			continue
+		}
+		if !selected {
+			klog.V(3).InfoS("Ignoring descheduling rule of a namespace not selected by the policy", "rule", rule.Rule, "strategy", rule.Name)
+			continue
+		}
+		klog.V(2).InfoS("Running strategy of descheduling rule", "rule", rule.Rule, "strategy", rule.Name)
+		if err := f(ctx, rs.Client, strategy, strategyNodes(rule.Name, strategy, nodes), podEvictor); err != nil {
+			klog.ErrorS(err, "Strategy of descheduling rule failed", "rule", rule.Rule, "strategy", rule.Name)
+		}
+	}
+}
+
+// strategyOrder returns the names of the strategies in the order they are run: strategies
+// run first, then the other strategies, both sorted by name
+func strategyOrder(strategies api.StrategyList) []api.StrategyName {
+	names := make([]api.StrategyName, 0, len(strategies))
+	for name := range strategies {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if strategiesRunFirst[names[i]] != strategiesRunFirst[names[j]] {
+			return strategiesRunFirst[names[i]]
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
+
+// strategiesIncludingCordonedNodes lists strategies processing cordoned nodes by default
+var strategiesIncludingCordonedNodes = map[api.StrategyName]bool{
+	"RemovePodsViolatingNodeTaints":   true,
+	"RemovePodsFromNodesWithProblems": true,
+	"RemovePodsFromInterruptedNodes":  true,
+}
+
+// strategyNodes returns the nodes a strategy is run against. Cordoned and out of service
+// nodes are neither source nor destination of evictions unless the strategy includes them
+// by default, to drain them through unschedulableNodesAsSources, or through its
+// includeCordonedNodes parameter. When the nodeRoles parameter is set, only nodes with any
+// of these roles are included.
+func strategyNodes(name api.StrategyName, strategy api.DeschedulerStrategy, nodes []*v1.Node) []*v1.Node {
+	includeCordonedNodes := strategiesIncludingCordonedNodes[name]
+	var nodeRoles []string
+	if strategy.Params != nil {
+		if thresholds := strategy.Params.NodeResourceUtilizationThresholds; thresholds != nil &&
+			thresholds.UnschedulableNodesAsSources != nil && *thresholds.UnschedulableNodesAsSources {
+			includeCordonedNodes = true
+		}
+		if strategy.Params.IncludeCordonedNodes != nil {
+			includeCordonedNodes = *strategy.Params.IncludeCordonedNodes
+		}
+		nodeRoles = strategy.Params.NodeRoles
+	}
+	if includeCordonedNodes && len(nodeRoles) == 0 {
+		return nodes
+	}
+	filteredNodes := make([]*v1.Node, 0, len(nodes))
+	for _, node := range nodes {
+		if !includeCordonedNodes && nodeutil.IsNodeCordoned(node) {
+			klog.V(3).InfoS("Excluding cordoned node", "node", klog.KObj(node), "strategy", name)
+			continue
+		}
+		if len(nodeRoles) > 0 && !nodeutil.NodeHasAnyRole(node, nodeRoles) {
+			klog.V(3).InfoS("Excluding node without any of the strategy roles", "node", klog.KObj(node), "strategy", name, "nodeRoles", nodeRoles)
+			continue
+		}
+		filteredNodes = append(filteredNodes, node)
+	}
+	return filteredNodes
+}
+
+// excludeVirtualNodes filters out nodes simulated by kwok or backed by virtual-kubelet
+func excludeVirtualNodes(nodes []*v1.Node) []*v1.Node {
+	filteredNodes := make([]*v1.Node, 0, len(nodes))
+	for _, node := range nodes {
+		if nodeutil.IsVirtualNode(node) {
+			klog.V(3).InfoS("Excluding virtual node", "node", klog.KObj(node))
+			continue
+		}
+		filteredNodes = append(filteredNodes, node)
+	}
+	return filteredNodes
+}
@@ -3,6 +3,7 @@ package descheduler
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -10,9 +11,13 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
+	clientset "k8s.io/client-go/kubernetes"
 	fakeclientset "k8s.io/client-go/kubernetes/fake"
 	"sigs.k8s.io/descheduler/cmd/descheduler/app/options"
 	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/descheduler/history"
+	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/validation"
 	"sigs.k8s.io/descheduler/test"
 )
 
@@ -44,8 +49,8 @@ func TestTaintsUpdated(t *testing.T) {
 	}
 	rs.Client = client
 	rs.DeschedulingInterval = 100 * time.Millisecond
+	// buffered so the goroutine returning after stopChannel is closed does not block
 	errChan := make(chan error, 1)
-	defer close(errChan)
 	go func() {
 		err := RunDeschedulerStrategies(ctx, rs, dp, "v1beta1", stopChannel)
 		errChan <- err
@@ -101,3 +106,256 @@ func TestTaintsUpdated(t *testing.T) {
 		t.Fatalf("Unable to evict pod, node taint did not get propagated to descheduler strategies")
 	}
 }
+
+func TestStrategyNodes(t *testing.T) {
+	n1 := test.BuildTestNode("n1", 2000, 3000, 10, func(node *v1.Node) {
+		node.Labels["node-role.kubernetes.io/infra"] = ""
+	})
+	n2 := test.BuildTestNode("n2", 2000, 3000, 10, func(node *v1.Node) {
+		node.Spec.Unschedulable = true
+	})
+	nodes := []*v1.Node{n1, n2}
+	include := true
+	exclude := false
+
+	tests := []struct {
+		description   string
+		name          api.StrategyName
+		params        *api.StrategyParameters
+		expectedNodes int
+	}{
+		{
+			description:   "cordoned nodes are excluded by default",
+			name:          "LowNodeUtilization",
+			expectedNodes: 1,
+		},
+		{
+			description:   "cordoned nodes are included through the strategy parameters",
+			name:          "LowNodeUtilization",
+			params:        &api.StrategyParameters{IncludeCordonedNodes: &include},
+			expectedNodes: 2,
+		},
+		{
+			description:   "taint violations are processed on cordoned nodes by default",
+			name:          "RemovePodsViolatingNodeTaints",
+			expectedNodes: 2,
+		},
+		{
+			description:   "cordoned nodes are excluded through the strategy parameters",
+			name:          "RemovePodsViolatingNodeTaints",
+			params:        &api.StrategyParameters{IncludeCordonedNodes: &exclude},
+			expectedNodes: 1,
+		},
+		{
+			description:   "nodes are restricted to the strategy roles",
+			name:          "LowNodeUtilization",
+			params:        &api.StrategyParameters{NodeRoles: []string{"infra"}},
+			expectedNodes: 1,
+		},
+		{
+			description:   "nodes without role label are workers",
+			name:          "RemovePodsViolatingNodeTaints",
+			params:        &api.StrategyParameters{NodeRoles: []string{"worker"}},
+			expectedNodes: 1,
+		},
+		{
+			description:   "cordoned nodes are excluded from the strategy roles",
+			name:          "LowNodeUtilization",
+			params:        &api.StrategyParameters{NodeRoles: []string{"worker"}},
+			expectedNodes: 0,
+		},
+		{
+			description: "cordoned nodes are included to be drained",
+			name:        "HighNodeUtilization",
+			params: &api.StrategyParameters{NodeResourceUtilizationThresholds: &api.NodeResourceUtilizationThresholds{
+				UnschedulableNodesAsSources: &include,
+			}},
+			expectedNodes: 2,
+		},
+		{
+			description: "cordoned nodes to be drained are excluded through the strategy parameters",
+			name:        "HighNodeUtilization",
+			params: &api.StrategyParameters{IncludeCordonedNodes: &exclude, NodeResourceUtilizationThresholds: &api.NodeResourceUtilizationThresholds{
+				UnschedulableNodesAsSources: &include,
+			}},
+			expectedNodes: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			actual := strategyNodes(tc.name, api.DeschedulerStrategy{Enabled: true, Params: tc.params}, nodes)
+			if len(actual) != tc.expectedNodes {
+				t.Errorf("Expected %v nodes, got %v", tc.expectedNodes, len(actual))
+			}
+		})
+	}
+}
+
+func TestStrategyDryRun(t *testing.T) {
+	ctx := context.Background()
+	n1 := test.BuildTestNode("n1", 2000, 3000, 10, func(node *v1.Node) {
+		node.Spec.Taints = []v1.Taint{{Key: "key", Value: "value", Effect: v1.TaintEffectNoSchedule}}
+	})
+	n2 := test.BuildTestNode("n2", 2000, 3000, 10, nil)
+	p1 := test.BuildTestPod("p1", 200, 0, n1.Name, test.SetRSOwnerRef)
+
+	client := fakeclientset.NewSimpleClientset(n1, n2, p1)
+	dp := &api.DeschedulerPolicy{
+		Strategies: api.StrategyList{
+			"RemovePodsViolatingNodeTaints": api.DeschedulerStrategy{
+				Enabled: true,
+				DryRun:  true,
+			},
+		},
+	}
+
+	rs, err := options.NewDeschedulerServer()
+	if err != nil {
+		t.Fatalf("Unable to initialize server: %v", err)
+	}
+	rs.Client = client
+	if err := RunDeschedulerStrategies(ctx, rs, dp, "v1beta1", make(chan struct{})); err != nil {
+		t.Fatalf("Unable to run descheduler strategies: %v", err)
+	}
+
+	for _, action := range client.Actions() {
+		if action.GetVerb() == "create" && action.GetSubresource() == "eviction" {
+			t.Errorf("Unexpected eviction of a pod by a strategy in dry run mode")
+		}
+	}
+}
+
+func TestStrategyErrors(t *testing.T) {
+	ctx := context.Background()
+	n1 := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	n2 := test.BuildTestNode("n2", 2000, 3000, 10, nil)
+
+	client := fakeclientset.NewSimpleClientset(n1, n2)
+	maxPodLifeTimeSeconds := uint(60)
+	dp := &api.DeschedulerPolicy{
+		Strategies: api.StrategyList{
+			"PodLifeTime": api.DeschedulerStrategy{
+				Enabled: true,
+				Params: &api.StrategyParameters{
+					PodLifeTime:                &api.PodLifeTime{MaxPodLifeTimeSeconds: &maxPodLifeTimeSeconds},
+					ThresholdPriorityClassName: "missing",
+				},
+			},
+			"RemovePodsViolatingNodeTaints": api.DeschedulerStrategy{
+				Enabled: true,
+			},
+		},
+		EvictionHistory: &api.EvictionHistory{},
+	}
+
+	rs, err := options.NewDeschedulerServer()
+	if err != nil {
+		t.Fatalf("Unable to initialize server: %v", err)
+	}
+	rs.Client = client
+	err = RunDeschedulerStrategies(ctx, rs, dp, "v1beta1", make(chan struct{}))
+	if err == nil || !strings.Contains(err.Error(), "strategy PodLifeTime failed") {
+		t.Fatalf("Expected the PodLifeTime failure to be returned, got %v", err)
+	}
+
+	cycles, err := history.NewStore(client, dp.EvictionHistory).Load(ctx)
+	if err != nil {
+		t.Fatalf("Unable to load eviction history: %v", err)
+	}
+	if len(cycles) != 1 || len(cycles[0].StrategyErrors) != 1 {
+		t.Fatalf("Expected a cycle with a single strategy error, got %#v", cycles)
+	}
+	if strategyErr := cycles[0].StrategyErrors[0]; strategyErr.Strategy != "PodLifeTime" || strategyErr.Reason != validation.ErrorReasonPriorityLookup {
+		t.Errorf("Expected a PriorityLookup error of PodLifeTime, got %#v", strategyErr)
+	}
+}
+
+func TestStrategyTimeout(t *testing.T) {
+	ctx := context.Background()
+	n1 := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	n2 := test.BuildTestNode("n2", 2000, 3000, 10, nil)
+
+	deadlines := map[api.StrategyName]bool{}
+	defer func(funcs map[api.StrategyName]strategyFunction) {
+		strategyFuncs = funcs
+	}(strategyFuncs)
+	strategyFuncs = map[api.StrategyName]strategyFunction{
+		// the strategy is blocked until its context is cancelled by the timeout
+		"PodLifeTime": func(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor) error {
+			_, deadlines["PodLifeTime"] = ctx.Deadline()
+			<-ctx.Done()
+			return ctx.Err()
+		},
+		"RemoveDuplicates": func(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor) error {
+			_, deadlines["RemoveDuplicates"] = ctx.Deadline()
+			return ctx.Err()
+		},
+	}
+
+	rs, err := options.NewDeschedulerServer()
+	if err != nil {
+		t.Fatalf("Unable to initialize server: %v", err)
+	}
+	rs.Client = fakeclientset.NewSimpleClientset(n1, n2)
+	timeoutSeconds := uint(1)
+	dp := &api.DeschedulerPolicy{Strategies: api.StrategyList{
+		"PodLifeTime":      api.DeschedulerStrategy{Enabled: true, TimeoutSeconds: &timeoutSeconds},
+		"RemoveDuplicates": api.DeschedulerStrategy{Enabled: true},
+	}}
+	if err := RunDeschedulerStrategies(ctx, rs, dp, "v1beta1", make(chan struct{})); err != nil {
+		t.Errorf("Expected the timeout not to fail the strategy, got %v", err)
+	}
+	if !reflect.DeepEqual(deadlines, map[api.StrategyName]bool{"PodLifeTime": true, "RemoveDuplicates": false}) {
+		t.Errorf("Expected only the context of the strategy with a timeout to have a deadline, got %v", deadlines)
+	}
+}
+
+func TestStrategyOrder(t *testing.T) {
+	strategies := api.StrategyList{
+		"RemoveDuplicates":               api.DeschedulerStrategy{Enabled: true},
+		"PodLifeTime":                    api.DeschedulerStrategy{Enabled: true},
+		"RemovePodsFromInterruptedNodes": api.DeschedulerStrategy{Enabled: true},
+		"LowNodeUtilization":             api.DeschedulerStrategy{Enabled: true},
+	}
+	expected := []api.StrategyName{"RemovePodsFromInterruptedNodes", "LowNodeUtilization", "PodLifeTime", "RemoveDuplicates"}
+	if actual := strategyOrder(strategies); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected strategies to run in order %v, got %v", expected, actual)
+	}
+}
+
+func TestInvalidPolicySkipsCycle(t *testing.T) {
+	ctx := context.Background()
+	n1 := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	n2 := test.BuildTestNode("n2", 2000, 3000, 10, nil)
+
+	ran := false
+	defer func(funcs map[api.StrategyName]strategyFunction) {
+		strategyFuncs = funcs
+	}(strategyFuncs)
+	strategyFuncs = map[api.StrategyName]strategyFunction{
+		"RemoveDuplicates": func(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor) error {
+			ran = true
+			return nil
+		},
+	}
+
+	rs, err := options.NewDeschedulerServer()
+	if err != nil {
+		t.Fatalf("Unable to initialize server: %v", err)
+	}
+	rs.Client = fakeclientset.NewSimpleClientset(n1, n2)
+	cooldownSeconds := uint(600)
+	dp := &api.DeschedulerPolicy{
+		PodEvictionCooldownSeconds: &cooldownSeconds,
+		CooldownPersistence:        &api.CooldownPersistence{Kind: "Secret"},
+		Strategies:                 api.StrategyList{"RemoveDuplicates": api.DeschedulerStrategy{Enabled: true}},
+	}
+	err = RunDeschedulerStrategies(ctx, rs, dp, "v1beta1", make(chan struct{}))
+	if err == nil || !strings.Contains(err.Error(), "descheduling cycle skipped") {
+		t.Errorf("Expected the skipped cycle to be reported, got %v", err)
+	}
+	if ran {
+		t.Errorf("Expected the strategies not to run with an invalid policy")
+	}
+}
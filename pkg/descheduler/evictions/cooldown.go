@@ -0,0 +1,134 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+)
+
+// cooldownKey identifies the pods of an owner created from the same template
+type cooldownKey struct {
+	namespace    string
+	kind         string
+	name         string
+	templateHash string
+}
+
+// CooldownTracker remembers when pods of an owner and template were last evicted, so the pods
+// replacing them are not evicted again within the cooldown, whichever strategy evicts them. It
+// is shared by the evictors of all descheduling cycles to stop strategies with conflicting goals
+// from moving the same workload back and forth.
+type CooldownTracker struct {
+	cooldown time.Duration
+
+	lock sync.Mutex
+	// evictedAt is the time of the last eviction of each owner and template
+	evictedAt map[cooldownKey]time.Time
+}
+
+// NewCooldownTracker returns a tracker refusing evictions of replacement pods within the cooldown
+func NewCooldownTracker(cooldown time.Duration) *CooldownTracker {
+	return &CooldownTracker{cooldown: cooldown, evictedAt: make(map[cooldownKey]time.Time)}
+}
+
+// cooldownKeys returns keys of all owners of the pod, with the hash of the pod template
+func cooldownKeys(pod *v1.Pod) []cooldownKey {
+	templateHash := pod.Labels[appsv1.DefaultDeploymentUniqueLabelKey]
+	if templateHash == "" {
+		templateHash = pod.Labels[appsv1.ControllerRevisionHashLabelKey]
+	}
+	var keys []cooldownKey
+	for _, ownerRef := range podutil.OwnerRef(pod) {
+		keys = append(keys, cooldownKey{namespace: pod.Namespace, kind: ownerRef.Kind, name: ownerRef.Name, templateHash: templateHash})
+	}
+	return keys
+}
+
+// Record records the eviction of the pod at the given time
+func (t *CooldownTracker) Record(pod *v1.Pod, at time.Time) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	for _, key := range cooldownKeys(pod) {
+		t.evictedAt[key] = at
+	}
+}
+
+// InCooldown reports whether the pod was created after the eviction of a pod of the same owner
+// and template, less than the cooldown ago
+func (t *CooldownTracker) InCooldown(pod *v1.Pod, now time.Time) bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	for key, evictedAt := range t.evictedAt {
+		if now.Sub(evictedAt) >= t.cooldown {
+			delete(t.evictedAt, key)
+		}
+	}
+	for _, key := range cooldownKeys(pod) {
+		evictedAt, ok := t.evictedAt[key]
+		// creation timestamps have a one second precision
+		if ok && !pod.CreationTimestamp.Time.Before(evictedAt.Truncate(time.Second)) {
+			return true
+		}
+	}
+	return false
+}
+
+// cooldownEntry is the last eviction of an owner and template, as persisted by a CooldownStore
+type cooldownEntry struct {
+	Namespace    string      `json:"namespace"`
+	Kind         string      `json:"kind"`
+	Name         string      `json:"name"`
+	TemplateHash string      `json:"templateHash,omitempty"`
+	EvictedAt    metav1.Time `json:"evictedAt"`
+}
+
+// entries returns the evictions still within the cooldown
+func (t *CooldownTracker) entries(now time.Time) []cooldownEntry {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	entries := []cooldownEntry{}
+	for key, evictedAt := range t.evictedAt {
+		if now.Sub(evictedAt) >= t.cooldown {
+			continue
+		}
+		entries = append(entries, cooldownEntry{Namespace: key.namespace, Kind: key.kind, Name: key.name, TemplateHash: key.templateHash, EvictedAt: metav1.NewTime(evictedAt)})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].EvictedAt.Before(&entries[j].EvictedAt)
+	})
+	return entries
+}
+
+// restore records the given evictions, unless more recent evictions of the same owner and template are known
+func (t *CooldownTracker) restore(entries []cooldownEntry) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	for _, entry := range entries {
+		key := cooldownKey{namespace: entry.Namespace, kind: entry.Kind, name: entry.Name, templateHash: entry.TemplateHash}
+		if evictedAt, ok := t.evictedAt[key]; !ok || evictedAt.Before(entry.EvictedAt.Time) {
+			t.evictedAt[key] = entry.EvictedAt.Time
+		}
+	}
+}
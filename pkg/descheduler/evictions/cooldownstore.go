@@ -0,0 +1,253 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+const (
+	// CooldownStoreConfigMap stores the cooldown in the data of a ConfigMap
+	CooldownStoreConfigMap = "ConfigMap"
+	// CooldownStoreLease stores the cooldown in an annotation of a Lease
+	CooldownStoreLease = "Lease"
+	// DefaultCooldownStoreNamespace is the namespace of the cooldown object when not configured
+	DefaultCooldownStoreNamespace = "kube-system"
+	// DefaultCooldownStoreName is the name of the cooldown object when not configured
+	DefaultCooldownStoreName = "descheduler-cooldown"
+
+	// cooldownDataKey is the ConfigMap data key holding the JSON encoded evictions
+	cooldownDataKey = "cooldown.json"
+	// cooldownAnnotationKey is the Lease annotation holding the JSON encoded evictions
+	cooldownAnnotationKey = "descheduler.alpha.kubernetes.io/cooldown"
+
+	// configMapCooldownMaxBytes bounds the encoded evictions below the 1MiB size limit of ConfigMaps
+	configMapCooldownMaxBytes = 1<<20 - 64<<10
+	// leaseCooldownMaxBytes bounds the encoded evictions below the 256KiB size limit of all the annotations
+	// of an object, leaving room for the other annotations of the Lease
+	leaseCooldownMaxBytes = 256<<10 - 16<<10
+)
+
+// cooldownBackend reads and writes the encoded evictions in a cluster object
+type cooldownBackend interface {
+	// load returns the encoded evictions, empty when none is stored
+	load(ctx context.Context) (string, error)
+	save(ctx context.Context, data string) error
+	// maxBytes is the size the encoded evictions must not exceed
+	maxBytes() int
+	// String describes the object, e.g. in logs
+	String() string
+}
+
+// CooldownStore persists the evictions a CooldownTracker tracks, so the cooldown of the owners
+// evicted before a restart of the descheduler still applies after it. Only the cooldown spans
+// descheduling cycles, the per node and per owner eviction limits start over with every cycle.
+type CooldownStore struct {
+	backend cooldownBackend
+}
+
+// trimmedCooldownStores are the objects whose evictions were already trimmed to fit, the descheduler
+// only reports it once per object as the cooldown usually keeps exceeding the object afterwards
+var trimmedCooldownStores = struct {
+	sync.Mutex
+	objects map[string]bool
+}{objects: map[string]bool{}}
+
+// NewCooldownStore returns a store for the given configuration, unset fields are defaulted
+func NewCooldownStore(client clientset.Interface, config *api.CooldownPersistence) (*CooldownStore, error) {
+	namespace, name := DefaultCooldownStoreNamespace, DefaultCooldownStoreName
+	if config.Namespace != "" {
+		namespace = config.Namespace
+	}
+	if config.Name != "" {
+		name = config.Name
+	}
+	switch config.Kind {
+	case "", CooldownStoreConfigMap:
+		return &CooldownStore{backend: &configMapCooldownBackend{client: client, namespace: namespace, name: name}}, nil
+	case CooldownStoreLease:
+		return &CooldownStore{backend: &leaseCooldownBackend{client: client, namespace: namespace, name: name}}, nil
+	default:
+		return nil, fmt.Errorf("cooldown persistence kind %q is not one of %s and %s", config.Kind, CooldownStoreConfigMap, CooldownStoreLease)
+	}
+}
+
+// Restore records the stored evictions in the tracker
+func (s *CooldownStore) Restore(ctx context.Context, tracker *CooldownTracker) error {
+	data, err := s.backend.load(ctx)
+	if err != nil || data == "" {
+		return err
+	}
+	var entries []cooldownEntry
+	if err := json.Unmarshal([]byte(data), &entries); err != nil {
+		return fmt.Errorf("unable to decode the stored cooldown: %v", err)
+	}
+	tracker.restore(entries)
+	return nil
+}
+
+// Save stores the evictions of the tracker still within the cooldown at the given time. When they
+// exceed the size of the object, the oldest evictions, whose cooldown expires first, are dropped.
+func (s *CooldownStore) Save(ctx context.Context, tracker *CooldownTracker, now time.Time) error {
+	entries := tracker.entries(now)
+	data, dropped, err := encodeCooldownEntries(entries, s.backend.maxBytes())
+	if err != nil {
+		return fmt.Errorf("unable to encode the cooldown: %v", err)
+	}
+	if dropped > 0 {
+		trimmedCooldownStores.Lock()
+		reported := trimmedCooldownStores.objects[s.backend.String()]
+		trimmedCooldownStores.objects[s.backend.String()] = true
+		trimmedCooldownStores.Unlock()
+		if !reported {
+			klog.ErrorS(nil, "Pod eviction cooldown exceeds the size of the object it is stored in, dropping the oldest evictions, "+
+				"the replacements of their pods can be evicted again after a restart", "object", s.backend, "dropped", dropped, "evictions", len(entries))
+		} else {
+			klog.V(2).InfoS("Dropped the oldest evictions of the pod eviction cooldown to fit the object it is stored in", "object", s.backend, "dropped", dropped, "evictions", len(entries))
+		}
+	}
+	return s.backend.save(ctx, string(data))
+}
+
+// encodeCooldownEntries encodes the entries, sorted from the oldest, within maxBytes, dropping the oldest
+// entries until they fit, and returns the number of dropped entries
+func encodeCooldownEntries(entries []cooldownEntry, maxBytes int) ([]byte, int, error) {
+	// the encoded list is the encoded entries separated by commas within brackets
+	sizes := make([]int, len(entries))
+	total := 1
+	for i := range entries {
+		data, err := json.Marshal(entries[i])
+		if err != nil {
+			return nil, 0, err
+		}
+		sizes[i] = len(data)
+		total += sizes[i] + 1
+	}
+	dropped := 0
+	for dropped < len(entries) && total > maxBytes {
+		total -= sizes[dropped] + 1
+		dropped++
+	}
+	data, err := json.Marshal(entries[dropped:])
+	return data, dropped, err
+}
+
+// configMapCooldownBackend stores the evictions in the data of a ConfigMap
+type configMapCooldownBackend struct {
+	client    clientset.Interface
+	namespace string
+	name      string
+}
+
+func (b *configMapCooldownBackend) load(ctx context.Context) (string, error) {
+	cm, err := b.client.CoreV1().ConfigMaps(b.namespace).Get(ctx, b.name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("unable to get cooldown ConfigMap %s/%s: %v", b.namespace, b.name, err)
+	}
+	return cm.Data[cooldownDataKey], nil
+}
+
+func (b *configMapCooldownBackend) save(ctx context.Context, data string) error {
+	cm, err := b.client.CoreV1().ConfigMaps(b.namespace).Get(ctx, b.name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		cm = &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: b.name, Namespace: b.namespace},
+			Data:       map[string]string{cooldownDataKey: data},
+		}
+		_, err = b.client.CoreV1().ConfigMaps(b.namespace).Create(ctx, cm, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("unable to get cooldown ConfigMap %s/%s: %v", b.namespace, b.name, err)
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[cooldownDataKey] = data
+	_, err = b.client.CoreV1().ConfigMaps(b.namespace).Update(ctx, cm, metav1.UpdateOptions{})
+	return err
+}
+
+func (b *configMapCooldownBackend) maxBytes() int {
+	return configMapCooldownMaxBytes
+}
+
+func (b *configMapCooldownBackend) String() string {
+	return "ConfigMap " + b.namespace + "/" + b.name
+}
+
+// leaseCooldownBackend stores the evictions in an annotation of a Lease
+type leaseCooldownBackend struct {
+	client    clientset.Interface
+	namespace string
+	name      string
+}
+
+func (b *leaseCooldownBackend) load(ctx context.Context) (string, error) {
+	lease, err := b.client.CoordinationV1().Leases(b.namespace).Get(ctx, b.name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("unable to get cooldown Lease %s/%s: %v", b.namespace, b.name, err)
+	}
+	return lease.Annotations[cooldownAnnotationKey], nil
+}
+
+func (b *leaseCooldownBackend) save(ctx context.Context, data string) error {
+	lease, err := b.client.CoordinationV1().Leases(b.namespace).Get(ctx, b.name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		lease = &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{Name: b.name, Namespace: b.namespace, Annotations: map[string]string{cooldownAnnotationKey: data}},
+		}
+		_, err = b.client.CoordinationV1().Leases(b.namespace).Create(ctx, lease, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("unable to get cooldown Lease %s/%s: %v", b.namespace, b.name, err)
+	}
+	if lease.Annotations == nil {
+		lease.Annotations = map[string]string{}
+	}
+	lease.Annotations[cooldownAnnotationKey] = data
+	_, err = b.client.CoordinationV1().Leases(b.namespace).Update(ctx, lease, metav1.UpdateOptions{})
+	return err
+}
+
+func (b *leaseCooldownBackend) maxBytes() int {
+	return leaseCooldownMaxBytes
+}
+
+func (b *leaseCooldownBackend) String() string {
+	return "Lease " + b.namespace + "/" + b.name
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+const (
+	// DefaultMinEvictionAttempts is the number of eviction attempts of a cycle before the ratio of failed
+	// attempts is checked when not configured
+	DefaultMinEvictionAttempts = 10
+	// DefaultMaxBackoffSeconds bounds the delay of the next cycle after aborted cycles when not configured
+	DefaultMaxBackoffSeconds = 3600
+)
+
+// ErrorBudget counts the eviction attempts of a descheduling cycle and their failures. It is shared by the
+// evictors of the cycle, which refuse evictions once the ratio of failed attempts exceeds the budget, so that
+// systemic problems like missing RBAC permissions or denying admission webhooks abort the cycle instead of
+// failing every eviction. Evictions in dry run mode are not counted.
+type ErrorBudget struct {
+	maxErrorRatio float64
+	minAttempts   int
+	attempts      int
+	failures      int
+}
+
+// NewErrorBudget returns the error budget of a cycle for the configuration, or nil when not configured
+func NewErrorBudget(config *api.CycleAbort) (*ErrorBudget, error) {
+	if config == nil {
+		return nil, nil
+	}
+	if config.MaxErrorRatio < 0 || config.MaxErrorRatio > 1 {
+		return nil, fmt.Errorf("maxErrorRatio must be between 0 and 1, got %v", config.MaxErrorRatio)
+	}
+	minAttempts := DefaultMinEvictionAttempts
+	if config.MinEvictionAttempts != nil {
+		minAttempts = int(*config.MinEvictionAttempts)
+	}
+	return &ErrorBudget{maxErrorRatio: config.MaxErrorRatio, minAttempts: minAttempts}, nil
+}
+
+// record counts an eviction attempt and whether it failed
+func (b *ErrorBudget) record(failed bool) {
+	if b == nil {
+		return
+	}
+	b.attempts++
+	if failed {
+		b.failures++
+	}
+}
+
+// Exhausted tells whether the ratio of failed eviction attempts exceeds the budget
+func (b *ErrorBudget) Exhausted() bool {
+	if b == nil || b.attempts == 0 || b.attempts < b.minAttempts {
+		return false
+	}
+	return float64(b.failures)/float64(b.attempts) > b.maxErrorRatio
+}
+
+// Error describes the exhausted budget
+func (b *ErrorBudget) Error() error {
+	return fmt.Errorf("%d of %d eviction attempts failed, more than the maximum error ratio %v", b.failures, b.attempts, b.maxErrorRatio)
+}
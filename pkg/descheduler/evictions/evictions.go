@@ -18,21 +18,29 @@ package evictions
 
 import (
 	"context"
+	goerrors "errors"
 	"fmt"
+	"net/http"
 	"strings"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 	policy "k8s.io/api/policy/v1beta1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/clock"
 	"k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	clientcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/descheduler/metrics"
+	"sigs.k8s.io/descheduler/pkg/descheduler/logging"
 	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
 	"sigs.k8s.io/descheduler/pkg/utils"
@@ -47,16 +55,102 @@ const (
 // nodePodEvictedCount keeps count of pods evicted on node
 type nodePodEvictedCount map[*v1.Node]int
 
+// ownerKey identifies pods sharing an owner on a node
+type ownerKey struct {
+	node      string
+	namespace string
+	kind      string
+	name      string
+	uid       types.UID
+}
+
+// ownerPodEvictedCount keeps count of pods evicted per owner on node
+type ownerPodEvictedCount map[ownerKey]int
+
 type PodEvictor struct {
-	client                  clientset.Interface
-	nodes                   []*v1.Node
-	policyGroupVersion      string
-	dryRun                  bool
-	maxPodsToEvictPerNode   int
-	nodepodCount            nodePodEvictedCount
-	evictLocalStoragePods   bool
-	evictSystemCriticalPods bool
-	ignorePvcPods           bool
+	client                clientset.Interface
+	nodes                 []*v1.Node
+	policyGroupVersion    string
+	dryRun                bool
+	maxPodsToEvictPerNode int
+	nodepodCount          nodePodEvictedCount
+	// maxPodsToEvictPerOwnerPerNode limits evictions of pods sharing an owner on a node
+	maxPodsToEvictPerOwnerPerNode int
+	ownerPodCount                 ownerPodEvictedCount
+	evictLocalStoragePods         bool
+	evictSystemCriticalPods       bool
+	ignorePvcPods                 bool
+	// reschedulingHints enables annotating owners of evicted pods with a ReschedulingHint
+	reschedulingHints bool
+	evictedPods       []EvictedPod
+	// replacementReadinessTimeout bounds the wait for a ready replacement of an owner's
+	// evicted pod before evicting another pod of the same owner, 0 disables waiting
+	replacementReadinessTimeout time.Duration
+	pendingReplacements         map[replacementKey]pendingReplacement
+	// volumeDetachTimeout bounds the wait for the ReadWriteOnce volumes of a pod evicted from a node to be
+	// detached before evicting another pod with such volumes from the node, 0 disables waiting
+	volumeDetachTimeout time.Duration
+	pendingDetaches     map[string]pendingDetach
+	// cooldown, when set, refuses evictions of pods replacing recently evicted pods
+	cooldown *CooldownTracker
+	// nodeFitFailures records pods not evicted because they fit no other node, once per pod
+	nodeFitFailures []NodeFitFailure
+	nodeFitFailed   map[string]bool
+	// nodeUtilizations records the node usages computed by the strategies balancing them
+	nodeUtilizations []NodeUtilization
+	// protected pods are never evicted
+	protected *ProtectedPods
+	// filter, when set, restricts evictions to the pods it matches
+	filter *PodFilter
+	// deadline, when set, is the end of the runtime budget of the running strategy
+	deadline time.Time
+	// errorBudget, when set, is the budget of failed evictions of the descheduling cycle
+	errorBudget *ErrorBudget
+	// limiter and strategyLimiter, when set, cap and pace the evictions of the descheduling cycle and of the
+	// running strategy
+	limiter         *EvictionLimiter
+	strategyLimiter *EvictionLimiter
+	// skipOwnersRollingOut refuses evictions of pods whose Deployment or StatefulSet is progressing a rollout
+	skipOwnersRollingOut bool
+	rollingOutOwners     map[replacementKey]bool
+	// podAnnotations, when set, configures the annotations describing the eviction set on pods before evicting them
+	podAnnotations *PodAnnotations
+	// emptyDirSizeLimit, when set, lets pods whose local storage only consists of emptyDir volumes with a sizeLimit
+	// up to it be evicted without evictLocalStoragePods
+	emptyDirSizeLimit *resource.Quantity
+	// clock provides the time of evictions, cooldowns and deadlines, so they can be reproduced with a fake clock
+	clock clock.Clock
+	// nodeScope, when set, restricts the nodes strategies listing nodes themselves may process
+	nodeScope func(node *v1.Node) bool
+	// checkpoint, when set, records the nodes visited by the running strategy
+	checkpoint *NodeCheckpoint
+}
+
+// EvictedPod records a successful eviction
+type EvictedPod struct {
+	Pod    *v1.Pod
+	Node   string
+	Reason EvictionReason
+	DryRun bool
+	Time   metav1.Time
+}
+
+// NodeFitFailure records a pod which was not evicted because it does not fit on any other node,
+// with a message counting the nodes rejected by each criterion
+type NodeFitFailure struct {
+	Pod     *v1.Pod
+	Message string
+	DryRun  bool
+	Time    metav1.Time
+}
+
+// NodeUtilization records the usage of a node, in percents of its allocatable resources, and how a strategy
+// balancing node usages classified the node
+type NodeUtilization struct {
+	Strategy       string
+	Node           string
+	Classification string
+	Usage          map[v1.ResourceName]float64
 }
 
 func NewPodEvictor(
@@ -64,6 +158,7 @@ func NewPodEvictor(
 	policyGroupVersion string,
 	dryRun bool,
 	maxPodsToEvictPerNode int,
+	maxPodsToEvictPerOwnerPerNode int,
 	nodes []*v1.Node,
 	evictLocalStoragePods bool,
 	evictSystemCriticalPods bool,
@@ -76,18 +171,180 @@ func NewPodEvictor(
 	}
 
 	return &PodEvictor{
-		client:                  client,
-		nodes:                   nodes,
-		policyGroupVersion:      policyGroupVersion,
-		dryRun:                  dryRun,
-		maxPodsToEvictPerNode:   maxPodsToEvictPerNode,
-		nodepodCount:            nodePodCount,
-		evictLocalStoragePods:   evictLocalStoragePods,
-		evictSystemCriticalPods: evictSystemCriticalPods,
-		ignorePvcPods:           ignorePvcPods,
+		client:                        client,
+		nodes:                         nodes,
+		policyGroupVersion:            policyGroupVersion,
+		dryRun:                        dryRun,
+		maxPodsToEvictPerNode:         maxPodsToEvictPerNode,
+		nodepodCount:                  nodePodCount,
+		maxPodsToEvictPerOwnerPerNode: maxPodsToEvictPerOwnerPerNode,
+		ownerPodCount:                 make(ownerPodEvictedCount),
+		evictLocalStoragePods:         evictLocalStoragePods,
+		evictSystemCriticalPods:       evictSystemCriticalPods,
+		ignorePvcPods:                 ignorePvcPods,
+		pendingReplacements:           make(map[replacementKey]pendingReplacement),
+		pendingDetaches:               make(map[string]pendingDetach),
+		rollingOutOwners:              make(map[replacementKey]bool),
+		clock:                         clock.RealClock{},
 	}
 }
 
+// DryRun tells whether pods are only evicted in dry run mode
+func (pe *PodEvictor) DryRun() bool {
+	return pe.dryRun
+}
+
+// SetReschedulingHints enables annotating the owners of evicted pods with a ReschedulingHint
+func (pe *PodEvictor) SetReschedulingHints(enabled bool) {
+	pe.reschedulingHints = enabled
+}
+
+// SetReplacementReadinessTimeout bounds the wait for a ready replacement of an evicted pod before evicting another
+// pod of the same owner, 0 disables waiting
+func (pe *PodEvictor) SetReplacementReadinessTimeout(timeout time.Duration) {
+	pe.replacementReadinessTimeout = timeout
+}
+
+// SetVolumeDetachTimeout bounds the wait for the ReadWriteOnce volumes of a pod evicted from a node to be detached
+// before evicting another pod with such volumes from the node, 0 disables waiting
+func (pe *PodEvictor) SetVolumeDetachTimeout(timeout time.Duration) {
+	pe.volumeDetachTimeout = timeout
+}
+
+// SetCooldown refuses evictions of pods replacing pods recently evicted, as recorded by the tracker, nil disables it
+func (pe *PodEvictor) SetCooldown(cooldown *CooldownTracker) {
+	pe.cooldown = cooldown
+}
+
+// SetProtectedPods sets the pods which are never evicted
+func (pe *PodEvictor) SetProtectedPods(protected *ProtectedPods) {
+	pe.protected = protected
+}
+
+// SetPodFilter restricts evictions to the pods the filter matches, nil removes the restriction
+func (pe *PodEvictor) SetPodFilter(filter *PodFilter) {
+	pe.filter = filter
+}
+
+// SetSkipOwnersRollingOut refuses evictions of pods whose Deployment or StatefulSet is progressing a rollout
+func (pe *PodEvictor) SetSkipOwnersRollingOut(skip bool) {
+	pe.skipOwnersRollingOut = skip
+}
+
+// SetPodAnnotations sets the annotations describing the eviction set on pods before evicting them, nil sets none
+func (pe *PodEvictor) SetPodAnnotations(annotations *PodAnnotations) {
+	pe.podAnnotations = annotations
+}
+
+// SetClock sets the clock providing the time of evictions, cooldowns and deadlines, the real clock by default
+func (pe *PodEvictor) SetClock(c clock.Clock) {
+	pe.clock = c
+}
+
+// Now returns the current time of the clock of the evictor, which strategies compare the age of pods with
+func (pe *PodEvictor) Now() time.Time {
+	if pe.clock == nil {
+		return time.Now()
+	}
+	return pe.clock.Now()
+}
+
+// SetEmptyDirSizeLimit lets pods whose local storage only consists of emptyDir volumes with a sizeLimit up to the
+// limit be evicted without evictLocalStoragePods, nil removes the limit
+func (pe *PodEvictor) SetEmptyDirSizeLimit(limit *resource.Quantity) {
+	pe.emptyDirSizeLimit = limit
+}
+
+// SetNodeScope restricts the nodes strategies list themselves, e.g. NotReady nodes which are never passed to
+// strategies, to the nodes the descheduler runs against. All nodes are in scope by default.
+func (pe *PodEvictor) SetNodeScope(inScope func(node *v1.Node) bool) {
+	pe.nodeScope = inScope
+}
+
+// InNodeScope tells whether the node is in the scope set by SetNodeScope
+func (pe *PodEvictor) InNodeScope(node *v1.Node) bool {
+	return pe.nodeScope == nil || pe.nodeScope(node)
+}
+
+// SetNodeCheckpoint sets the checkpoint recording the nodes visited by the running strategy, nil removes it
+func (pe *PodEvictor) SetNodeCheckpoint(checkpoint *NodeCheckpoint) {
+	pe.checkpoint = checkpoint
+}
+
+// VisitNode records the node in the checkpoint of the running strategy and tells whether the strategy should process
+// it, false when the strategy already visited it before being interrupted and resumed. Strategies processing nodes one
+// after the other call it before processing each node.
+func (pe *PodEvictor) VisitNode(node *v1.Node) bool {
+	return pe.checkpoint.visit(node)
+}
+
+// SetDeadline makes EvictPod refuse evictions once the deadline passed, until the next call.
+// The zero time removes the deadline.
+func (pe *PodEvictor) SetDeadline(deadline time.Time) {
+	pe.deadline = deadline
+}
+
+// DeadlineExceeded tells whether the deadline set by SetDeadline passed
+func (pe *PodEvictor) DeadlineExceeded() bool {
+	return !pe.deadline.IsZero() && pe.Now().After(pe.deadline)
+}
+
+// SetErrorBudget makes evictions count against the error budget of the descheduling cycle, shared with the
+// other evictors of the cycle, and be refused once the budget is exhausted
+func (pe *PodEvictor) SetErrorBudget(budget *ErrorBudget) {
+	pe.errorBudget = budget
+}
+
+// SetEvictionLimiter makes evictions count against the limits of the descheduling cycle, shared with the other
+// evictors of the cycle
+func (pe *PodEvictor) SetEvictionLimiter(limiter *EvictionLimiter) {
+	pe.limiter = limiter
+}
+
+// SetStrategyEvictionLimiter sets the limits of the evictions of the running strategy, nil removes them
+func (pe *PodEvictor) SetStrategyEvictionLimiter(limiter *EvictionLimiter) {
+	pe.strategyLimiter = limiter
+}
+
+// checkEvictionLimits returns an error once the limits of the cycle or the strategy let no further pod be evicted,
+// and false when the pod is refused because the limit of its namespace is reached
+func (pe *PodEvictor) checkEvictionLimits(pod *v1.Pod, metricLabels func(string) map[string]string) (bool, error) {
+	for _, limiter := range []*EvictionLimiter{pe.limiter, pe.strategyLimiter} {
+		if err := limiter.exceeded(); err != nil {
+			metrics.PodsEvicted.With(metricLabels("maximum number reached")).Inc()
+			return false, err
+		}
+		if limiter.namespaceExceeded(pod.Namespace) {
+			logging.InfoS(klog.V(2), "Maximum number of evicted pods per namespace reached, skipping pod", "pod", klog.KObj(pod), "limit", limiter.maxPerNamespace)
+			metrics.PodsEvicted.With(metricLabels("maximum number per namespace reached")).Inc()
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// waitForEvictionRate waits until the rates of the cycle and the strategy allow another eviction, within the
+// deadline of the strategy
+func (pe *PodEvictor) waitForEvictionRate(ctx context.Context) error {
+	if !pe.deadline.IsZero() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, pe.deadline)
+		defer cancel()
+	}
+	for _, limiter := range []*EvictionLimiter{pe.limiter, pe.strategyLimiter} {
+		if err := limiter.wait(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordEvictionLimits counts an eviction against the limits of the cycle and the strategy
+func (pe *PodEvictor) recordEvictionLimits(pod *v1.Pod) {
+	pe.limiter.record(pod.Namespace)
+	pe.strategyLimiter.record(pod.Namespace)
+}
+
 // NodeEvicted gives a number of pods evicted for node
 func (pe *PodEvictor) NodeEvicted(node *v1.Node) int {
 	return pe.nodepodCount[node]
@@ -102,42 +359,296 @@ func (pe *PodEvictor) TotalEvicted() int {
 	return total
 }
 
+// EvictedPods returns pods evicted so far, in the order of their eviction
+func (pe *PodEvictor) EvictedPods() []EvictedPod {
+	return pe.evictedPods
+}
+
+// NodeFitFailures returns pods not evicted so far because they do not fit on any other node
+func (pe *PodEvictor) NodeFitFailures() []NodeFitFailure {
+	return pe.nodeFitFailures
+}
+
+// NodeUtilizations returns the node usages recorded so far
+func (pe *PodEvictor) NodeUtilizations() []NodeUtilization {
+	return pe.nodeUtilizations
+}
+
+// RecordNodeUtilization records the usage and classification of a node computed by a strategy
+func (pe *PodEvictor) RecordNodeUtilization(strategy, node, classification string, usage map[v1.ResourceName]float64) {
+	pe.nodeUtilizations = append(pe.nodeUtilizations, NodeUtilization{
+		Strategy:       strategy,
+		Node:           node,
+		Classification: classification,
+		Usage:          usage,
+	})
+}
+
+// recordNodeFitFailure records that the pod is not evicted because it does not fit on any other node.
+// Outside of dry run mode, the reason is also reported through an event on the pod.
+func (pe *PodEvictor) recordNodeFitFailure(pod *v1.Pod, message string) {
+	key := pod.Namespace + "/" + pod.Name
+	if pe.nodeFitFailed[key] {
+		return
+	}
+	if pe.nodeFitFailed == nil {
+		pe.nodeFitFailed = make(map[string]bool)
+	}
+	pe.nodeFitFailed[key] = true
+	pe.nodeFitFailures = append(pe.nodeFitFailures, NodeFitFailure{Pod: pod, Message: message, DryRun: pe.dryRun, Time: metav1.NewTime(pe.Now())})
+	logging.InfoS(klog.V(2), "Pod does not fit on any other node, skipping pod", "pod", klog.KObj(pod), "reason", message)
+	if !pe.dryRun {
+		pe.recordEvent(pod, nil, "NotDescheduled", "pod not evicted by sigs.k8s.io/descheduler, it does not fit on any other node: %s", message)
+	}
+}
+
+// recordEvent records a normal event on the pod
+func (pe *PodEvictor) recordEvent(pod *v1.Pod, annotations map[string]string, reason, messageFmt string, args ...interface{}) {
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartStructuredLogging(3)
+	eventBroadcaster.StartRecordingToSink(&clientcorev1.EventSinkImpl{Interface: pe.client.CoreV1().Events(pod.Namespace)})
+	r := eventBroadcaster.NewRecorder(scheme.Scheme, v1.EventSource{Component: "sigs.k8s.io.descheduler"})
+	r.AnnotatedEventf(pod, annotations, v1.EventTypeNormal, reason, messageFmt, args...)
+}
+
 // EvictPod returns non-nil error only when evicting a pod on a node is not
-// possible (due to maxPodsToEvictPerNode constraint). Success is true when the pod
-// is evicted on the server side.
-func (pe *PodEvictor) EvictPod(ctx context.Context, pod *v1.Pod, node *v1.Node, strategy string, reasons ...string) (bool, error) {
-	reason := strategy
-	if len(reasons) > 0 {
-		reason += " (" + strings.Join(reasons, ", ") + ")"
+// possible (due to maxPodsToEvictPerNode constraint), the deadline of the strategy
+// passed or the descheduling cycle is aborted, so strategies stop selecting further
+// pods. Success is true when the pod is evicted on the server side. Pods whose owner
+// already reached the maxPodsToEvictPerOwnerPerNode constraint on the node, whose
+// owner has no ready replacement of a previously evicted pod within
+// replacementReadinessTimeout, which replace a pod evicted within the cooldown, or
+// whose ReadWriteOnce volumes would be attached while the ones of the pod previously
+// evicted from the node are not detached within volumeDetachTimeout, are skipped
+// without an error.
+// The reason is reported through the eviction event, logs and metrics, details are
+// free-form and only appended to the event message and logs.
+func (pe *PodEvictor) EvictPod(ctx context.Context, pod *v1.Pod, node *v1.Node, reason EvictionReason, details ...string) (bool, error) {
+	return pe.EvictPodWithHint(ctx, pod, node, reason, ReschedulingHint{}, details...)
+}
+
+// EvictPodWithHint evicts the pod like EvictPod. When rescheduling hints are enabled, owners
+// of the evicted pod are annotated with the hint computed by the strategy, completed with
+// the eviction reason and the node the pod was evicted from.
+func (pe *PodEvictor) EvictPodWithHint(ctx context.Context, pod *v1.Pod, node *v1.Node, reason EvictionReason, hint ReschedulingHint, details ...string) (bool, error) {
+	message := reason.String()
+	if len(details) > 0 {
+		message += " (" + strings.Join(details, ", ") + ")"
+	}
+	metricLabels := func(result string) map[string]string {
+		return map[string]string{"result": result, "strategy": reason.Strategy, "cause": string(reason.Cause), "namespace": pod.Namespace}
+	}
+	if pe.protected.IsProtected(pod) {
+		klog.V(1).InfoS("Pod is protected from evictions, skipping pod", "pod", klog.KObj(pod), "strategy", reason.Strategy)
+		metrics.PodsEvicted.With(metricLabels("protected")).Inc()
+		return false, nil
+	}
+	if pe.DeadlineExceeded() {
+		metrics.PodsEvicted.With(metricLabels("timeout")).Inc()
+		return false, fmt.Errorf("Timeout of strategy %s reached", reason.Strategy)
+	}
+	if pe.errorBudget.Exhausted() {
+		metrics.PodsEvicted.With(metricLabels("cycle aborted")).Inc()
+		return false, fmt.Errorf("Descheduling cycle aborted: %v", pe.errorBudget.Error())
 	}
 	if pe.maxPodsToEvictPerNode > 0 && pe.nodepodCount[node]+1 > pe.maxPodsToEvictPerNode {
-		metrics.PodsEvicted.With(map[string]string{"result": "maximum number reached", "strategy": strategy, "namespace": pod.Namespace}).Inc()
+		metrics.PodsEvicted.With(metricLabels("maximum number reached")).Inc()
 		return false, fmt.Errorf("Maximum number %v of evicted pods per %q node reached", pe.maxPodsToEvictPerNode, node.Name)
 	}
+	if ok, err := pe.checkEvictionLimits(pod, metricLabels); !ok {
+		return false, err
+	}
+	owners := ownerKeys(pod, node)
+	if pe.maxPodsToEvictPerOwnerPerNode > 0 {
+		for _, owner := range owners {
+			if pe.ownerPodCount[owner]+1 > pe.maxPodsToEvictPerOwnerPerNode {
+				logging.InfoS(klog.V(2), "Maximum number of evicted pods per owner reached on node, skipping pod", "pod", klog.KObj(pod), "node", klog.KObj(node), "limit", pe.maxPodsToEvictPerOwnerPerNode)
+				metrics.PodsEvicted.With(metricLabels("maximum number per owner reached")).Inc()
+				return false, nil
+			}
+		}
+	}
+	if pe.cooldown != nil && pe.cooldown.InCooldown(pod, pe.Now()) {
+		logging.InfoS(klog.V(2), "Pod replaces a recently evicted pod of the same template, skipping pod", "pod", klog.KObj(pod), "strategy", reason.Strategy)
+		metrics.PodsEvicted.With(metricLabels("in cooldown")).Inc()
+		return false, nil
+	}
+	if pe.skipOwnersRollingOut {
+		if kind, name, ok := pe.rollingOutOwner(ctx, pod); ok {
+			logging.InfoS(klog.V(2), "Owner of the pod is rolling out, skipping pod", "pod", klog.KObj(pod), "ownerKind", kind, "ownerName", name, "strategy", reason.Strategy)
+			metrics.PodsEvicted.With(metricLabels("owner rolling out")).Inc()
+			return false, nil
+		}
+	}
+	if !pe.dryRun && pe.replacementReadinessTimeout > 0 && !pe.waitForReplacements(ctx, pod) {
+		metrics.PodsEvicted.With(metricLabels("replacement not ready")).Inc()
+		return false, nil
+	}
+	var volumes sets.String
+	if !pe.dryRun && pe.volumeDetachTimeout > 0 {
+		volumes = pe.readWriteOnceVolumes(ctx, pod)
+		if volumes.Len() > 0 && !pe.waitForVolumeDetach(ctx, pod, node) {
+			metrics.PodsEvicted.With(metricLabels("volumes not detached")).Inc()
+			return false, nil
+		}
+	}
 
+	if !pe.dryRun {
+		if err := pe.waitForEvictionRate(ctx); err != nil {
+			metrics.PodsEvicted.With(metricLabels("rate limited")).Inc()
+			return false, fmt.Errorf("Unable to wait for the eviction rate of strategy %s: %v", reason.Strategy, err)
+		}
+	}
+
+	if !pe.dryRun && pe.podAnnotations != nil {
+		annotatePod(ctx, pe.client, pod, reason, message, pe.podAnnotations)
+	}
+
+	start := time.Now()
 	err := evictPod(ctx, pe.client, pod, pe.policyGroupVersion, pe.dryRun)
+	if !pe.dryRun {
+		result := "success"
+		if err != nil {
+			result = "error"
+			metrics.EvictionErrors.With(map[string]string{"strategy": reason.Strategy, "code": evictionErrorClass(err)}).Inc()
+		}
+		metrics.EvictionLatency.With(map[string]string{"strategy": reason.Strategy, "result": result}).Observe(time.Since(start).Seconds())
+		pe.errorBudget.record(err != nil)
+	}
 	if err != nil {
 		// err is used only for logging purposes
-		klog.ErrorS(err, "Error evicting pod", "pod", klog.KObj(pod), "reason", reason)
-		metrics.PodsEvicted.With(map[string]string{"result": "error", "strategy": strategy, "namespace": pod.Namespace}).Inc()
+		klog.ErrorS(err, "Error evicting pod", "pod", klog.KObj(pod), "strategy", reason.Strategy, "cause", reason.Cause, "details", details)
+		metrics.PodsEvicted.With(metricLabels("error")).Inc()
 		return false, nil
 	}
 
 	pe.nodepodCount[node]++
+	for _, owner := range owners {
+		pe.ownerPodCount[owner]++
+	}
+	pe.recordEvictionLimits(pod)
+	pe.evictedPods = append(pe.evictedPods, EvictedPod{Pod: pod, Node: node.Name, Reason: reason, DryRun: pe.dryRun, Time: metav1.NewTime(pe.Now())})
 	if pe.dryRun {
-		klog.V(1).InfoS("Evicted pod in dry run mode", "pod", klog.KObj(pod), "reason", reason)
+		klog.V(1).InfoS("Evicted pod in dry run mode", "pod", klog.KObj(pod), "strategy", reason.Strategy, "cause", reason.Cause, "details", details)
 	} else {
-		klog.V(1).InfoS("Evicted pod", "pod", klog.KObj(pod), "reason", reason)
-		eventBroadcaster := record.NewBroadcaster()
-		eventBroadcaster.StartStructuredLogging(3)
-		eventBroadcaster.StartRecordingToSink(&clientcorev1.EventSinkImpl{Interface: pe.client.CoreV1().Events(pod.Namespace)})
-		r := eventBroadcaster.NewRecorder(scheme.Scheme, v1.EventSource{Component: "sigs.k8s.io.descheduler"})
-		r.Event(pod, v1.EventTypeNormal, "Descheduled", fmt.Sprintf("pod evicted by sigs.k8s.io/descheduler%s", reason))
-		metrics.PodsEvicted.With(map[string]string{"result": "success", "strategy": strategy, "namespace": pod.Namespace}).Inc()
+		klog.V(1).InfoS("Evicted pod", "pod", klog.KObj(pod), "strategy", reason.Strategy, "cause", reason.Cause, "details", details)
+		pe.recordEvent(pod, reasonAnnotations(reason), "Descheduled", "pod evicted by sigs.k8s.io/descheduler: %s", message)
+		metrics.PodsEvicted.With(metricLabels("success")).Inc()
+		if pe.cooldown != nil {
+			pe.cooldown.Record(pod, pe.Now())
+		}
+		if pe.replacementReadinessTimeout > 0 {
+			for _, key := range replacementKeys(pod) {
+				pe.pendingReplacements[key] = newPendingReplacement(pod, pe.Now())
+			}
+		}
+		if volumes.Len() > 0 {
+			pe.pendingDetaches[node.Name] = pendingDetach{pod: pod.Name, volumes: volumes}
+		}
+		if pe.reschedulingHints {
+			hint.Strategy = reason.Strategy
+			hint.Cause = reason.Cause
+			hint.EvictedPod = pod.Name
+			hint.AvoidNodes = append(append([]string{}, hint.AvoidNodes...), node.Name)
+			hint.Timestamp = metav1.NewTime(pe.Now())
+			annotateOwners(ctx, pe.client, pod, hint)
+		}
 	}
 	return true, nil
 }
 
+// ForceDeletePod deletes the pod without grace period, bypassing the eviction API and the kubelet. It is meant
+// for pods stuck terminating on nodes which are not running anymore, whose containers can not be stopped.
+// Force deletions count as evictions: like EvictPod, it returns a non-nil error only when the deadline of the
+// strategy passed, the descheduling cycle is aborted or the maximum number of evictions, in total or from the node,
+// is reached, and skips pods whose owner reached maxPodsToEvictPerOwnerPerNode on the node. Success is true when
+// the pod is deleted.
+func (pe *PodEvictor) ForceDeletePod(ctx context.Context, pod *v1.Pod, node *v1.Node, reason EvictionReason, details ...string) (bool, error) {
+	message := reason.String()
+	if len(details) > 0 {
+		message += " (" + strings.Join(details, ", ") + ")"
+	}
+	metricLabels := func(result string) map[string]string {
+		return map[string]string{"result": result, "strategy": reason.Strategy, "cause": string(reason.Cause), "namespace": pod.Namespace}
+	}
+	if pe.protected.IsProtected(pod) {
+		klog.V(1).InfoS("Pod is protected from evictions, skipping pod", "pod", klog.KObj(pod), "strategy", reason.Strategy)
+		metrics.PodsEvicted.With(metricLabels("protected")).Inc()
+		return false, nil
+	}
+	if pe.DeadlineExceeded() {
+		metrics.PodsEvicted.With(metricLabels("timeout")).Inc()
+		return false, fmt.Errorf("Timeout of strategy %s reached", reason.Strategy)
+	}
+	if pe.errorBudget.Exhausted() {
+		metrics.PodsEvicted.With(metricLabels("cycle aborted")).Inc()
+		return false, fmt.Errorf("Descheduling cycle aborted: %v", pe.errorBudget.Error())
+	}
+	if pe.maxPodsToEvictPerNode > 0 && pe.nodepodCount[node]+1 > pe.maxPodsToEvictPerNode {
+		metrics.PodsEvicted.With(metricLabels("maximum number reached")).Inc()
+		return false, fmt.Errorf("Maximum number %v of evicted pods per %q node reached", pe.maxPodsToEvictPerNode, node.Name)
+	}
+	if ok, err := pe.checkEvictionLimits(pod, metricLabels); !ok {
+		return false, err
+	}
+	owners := ownerKeys(pod, node)
+	if pe.maxPodsToEvictPerOwnerPerNode > 0 {
+		for _, owner := range owners {
+			if pe.ownerPodCount[owner]+1 > pe.maxPodsToEvictPerOwnerPerNode {
+				logging.InfoS(klog.V(2), "Maximum number of evicted pods per owner reached on node, skipping pod", "pod", klog.KObj(pod), "node", klog.KObj(node), "limit", pe.maxPodsToEvictPerOwnerPerNode)
+				metrics.PodsEvicted.With(metricLabels("maximum number per owner reached")).Inc()
+				return false, nil
+			}
+		}
+	}
+
+	if !pe.dryRun {
+		if err := pe.waitForEvictionRate(ctx); err != nil {
+			metrics.PodsEvicted.With(metricLabels("rate limited")).Inc()
+			return false, fmt.Errorf("Unable to wait for the eviction rate of strategy %s: %v", reason.Strategy, err)
+		}
+		gracePeriodSeconds := int64(0)
+		err := pe.client.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{GracePeriodSeconds: &gracePeriodSeconds})
+		failed := err != nil && !apierrors.IsNotFound(err)
+		pe.errorBudget.record(failed)
+		if failed {
+			klog.ErrorS(err, "Error force deleting pod", "pod", klog.KObj(pod), "strategy", reason.Strategy, "cause", reason.Cause, "details", details)
+			metrics.PodsEvicted.With(metricLabels("error")).Inc()
+			return false, nil
+		}
+	}
+
+	pe.nodepodCount[node]++
+	for _, owner := range owners {
+		pe.ownerPodCount[owner]++
+	}
+	pe.recordEvictionLimits(pod)
+	pe.evictedPods = append(pe.evictedPods, EvictedPod{Pod: pod, Node: node.Name, Reason: reason, DryRun: pe.dryRun, Time: metav1.NewTime(pe.Now())})
+	if pe.dryRun {
+		klog.V(1).InfoS("Force deleted pod in dry run mode", "pod", klog.KObj(pod), "strategy", reason.Strategy, "cause", reason.Cause, "details", details)
+	} else {
+		klog.V(1).InfoS("Force deleted pod", "pod", klog.KObj(pod), "strategy", reason.Strategy, "cause", reason.Cause, "details", details)
+		pe.recordEvent(pod, reasonAnnotations(reason), "ForceDeleted", "pod force deleted by sigs.k8s.io/descheduler: %s", message)
+		metrics.PodsEvicted.With(metricLabels("force deleted")).Inc()
+	}
+	return true, nil
+}
+
+// ownerKeys returns keys of all owners of the pod on the given node
+func ownerKeys(pod *v1.Pod, node *v1.Node) []ownerKey {
+	var keys []ownerKey
+	for _, ownerRef := range podutil.OwnerRef(pod) {
+		keys = append(keys, ownerKey{
+			node:      node.Name,
+			namespace: pod.Namespace,
+			kind:      ownerRef.Kind,
+			name:      ownerRef.Name,
+			uid:       ownerRef.UID,
+		})
+	}
+	return keys
+}
+
 func evictPod(ctx context.Context, client clientset.Interface, pod *v1.Pod, policyGroupVersion string, dryRun bool) error {
 	if dryRun {
 		return nil
@@ -158,14 +669,36 @@ func evictPod(ctx context.Context, client clientset.Interface, pod *v1.Pod, poli
 	err := client.PolicyV1beta1().Evictions(eviction.Namespace).Evict(ctx, eviction)
 
 	if apierrors.IsTooManyRequests(err) {
-		return fmt.Errorf("error when evicting pod (ignoring) %q: %v", pod.Name, err)
+		return fmt.Errorf("error when evicting pod (ignoring) %q: %w", pod.Name, err)
 	}
 	if apierrors.IsNotFound(err) {
-		return fmt.Errorf("pod not found when evicting %q: %v", pod.Name, err)
+		return fmt.Errorf("pod not found when evicting %q: %w", pod.Name, err)
 	}
 	return err
 }
 
+// evictionErrorClass returns the class of the HTTP status of a failed eviction call: 429 when a pod disruption
+// budget blocks the eviction or the API server throttles it, 404 when the pod is already gone, 4xx or 5xx for
+// other statuses and unknown for errors without status, e.g. timeouts of the client
+func evictionErrorClass(err error) string {
+	status := apierrors.APIStatus(nil)
+	if !goerrors.As(err, &status) {
+		return "unknown"
+	}
+	switch code := status.Status().Code; {
+	case code == http.StatusTooManyRequests:
+		return "429"
+	case code == http.StatusNotFound:
+		return "404"
+	case code >= 400 && code < 500:
+		return "4xx"
+	case code >= 500:
+		return "5xx"
+	default:
+		return "unknown"
+	}
+}
+
 type Options struct {
 	priority      *int32
 	nodeFit       bool
@@ -201,8 +734,21 @@ func WithLabelSelector(labelSelector labels.Selector) func(opts *Options) {
 
 type constraint func(pod *v1.Pod) error
 
+// nodeFitError is returned by the node fit constraint
+type nodeFitError struct {
+	message string
+}
+
+func (e *nodeFitError) Error() string {
+	return "pod does not fit on any other node: " + e.message
+}
+
 type evictable struct {
 	constraints []constraint
+	// nodeFitFailed is called for pods which are not evictable only because they fit no other node
+	nodeFitFailed func(pod *v1.Pod, message string)
+	// protected pods are not evictable, even with the eviction annotation
+	protected *ProtectedPods
 }
 
 // Evictable provides an implementation of IsEvictable(IsEvictable(pod *v1.Pod) bool).
@@ -214,7 +760,7 @@ func (pe *PodEvictor) Evictable(opts ...func(opts *Options)) *evictable {
 		opt(options)
 	}
 
-	ev := &evictable{}
+	ev := &evictable{protected: pe.protected}
 	if !pe.evictSystemCriticalPods {
 		ev.constraints = append(ev.constraints, func(pod *v1.Pod) error {
 			// Moved from IsEvictable function to allow for disabling
@@ -235,7 +781,7 @@ func (pe *PodEvictor) Evictable(opts ...func(opts *Options)) *evictable {
 	}
 	if !pe.evictLocalStoragePods {
 		ev.constraints = append(ev.constraints, func(pod *v1.Pod) error {
-			if utils.IsPodWithLocalStorage(pod) {
+			if utils.IsPodWithProtectedLocalStorage(pod, pe.emptyDirSizeLimit) {
 				return fmt.Errorf("pod has local storage and descheduler is not configured with evictLocalStoragePods")
 			}
 			return nil
@@ -251,8 +797,17 @@ func (pe *PodEvictor) Evictable(opts ...func(opts *Options)) *evictable {
 	}
 	if options.nodeFit {
 		ev.constraints = append(ev.constraints, func(pod *v1.Pod) error {
-			if !nodeutil.PodFitsAnyOtherNode(pod, pe.nodes) {
-				return fmt.Errorf("pod does not fit on any other node because of nodeSelector(s), Taint(s), or nodes marked as unschedulable")
+			if fits, failures := nodeutil.PodFitsAnyOtherNodeWithFailures(pod, pe.nodes); !fits {
+				return &nodeFitError{message: failures.Message()}
+			}
+			return nil
+		})
+		ev.nodeFitFailed = pe.recordNodeFitFailure
+	}
+	if pe.filter != nil {
+		ev.constraints = append(ev.constraints, func(pod *v1.Pod) error {
+			if !pe.filter.Matches(pod) {
+				return fmt.Errorf("pod does not match the eviction filter of the policy")
 			}
 			return nil
 		})
@@ -271,6 +826,40 @@ func (pe *PodEvictor) Evictable(opts ...func(opts *Options)) *evictable {
 
 // IsEvictable decides when a pod is evictable
 func (ev *evictable) IsEvictable(pod *v1.Pod) bool {
+	if ev.protected.IsProtected(pod) {
+		logging.InfoS(klog.V(4), "Pod is protected from evictions", "pod", klog.KObj(pod))
+		return false
+	}
+
+	checkErrs := ev.failedChecks(pod)
+	if len(checkErrs) > 0 && !HaveEvictAnnotation(pod) {
+		logging.InfoS(klog.V(4), "Pod lacks an eviction annotation and fails the following checks", "pod", klog.KObj(pod), "checks", errors.NewAggregate(checkErrs).Error())
+		if nodeFitErr, ok := checkErrs[0].(*nodeFitError); ok && len(checkErrs) == 1 && ev.nodeFitFailed != nil {
+			ev.nodeFitFailed(pod, nodeFitErr.message)
+		}
+		return false
+	}
+
+	return true
+}
+
+// Explain returns why the pod is not evictable, nothing when it is
+func (ev *evictable) Explain(pod *v1.Pod) []string {
+	if ev.protected.IsProtected(pod) {
+		return []string{"pod is protected from evictions"}
+	}
+	if HaveEvictAnnotation(pod) {
+		return nil
+	}
+	var reasons []string
+	for _, err := range ev.failedChecks(pod) {
+		reasons = append(reasons, err.Error())
+	}
+	return reasons
+}
+
+// failedChecks returns the evictability checks the pod fails
+func (ev *evictable) failedChecks(pod *v1.Pod) []error {
 	checkErrs := []error{}
 
 	ownerRefList := podutil.OwnerRef(pod)
@@ -295,13 +884,7 @@ func (ev *evictable) IsEvictable(pod *v1.Pod) bool {
 			checkErrs = append(checkErrs, err)
 		}
 	}
-
-	if len(checkErrs) > 0 && !HaveEvictAnnotation(pod) {
-		klog.V(4).InfoS("Pod lacks an eviction annotation and fails the following checks", "pod", klog.KObj(pod), "checks", errors.NewAggregate(checkErrs).Error())
-		return false
-	}
-
-	return true
+	return checkErrs
 }
 
 // HaveEvictAnnotation checks if the pod have evict annotation
@@ -18,13 +18,26 @@ package evictions
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1beta1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/fake"
 	core "k8s.io/client-go/testing"
+	"sigs.k8s.io/descheduler/pkg/api"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
 	"sigs.k8s.io/descheduler/pkg/utils"
 	"sigs.k8s.io/descheduler/test"
@@ -69,6 +82,162 @@ func TestEvictPod(t *testing.T) {
 	}
 }
 
+func TestEvictPodMaxPerOwnerPerNode(t *testing.T) {
+	ctx := context.Background()
+	node1 := test.BuildTestNode("node1", 1000, 2000, 9, nil)
+	node2 := test.BuildTestNode("node2", 1000, 2000, 9, nil)
+
+	rsPod := func(name, nodeName string) *v1.Pod {
+		return test.BuildTestPod(name, 100, 0, nodeName, test.SetRSOwnerRef)
+	}
+	ssPod := func(name, nodeName string) *v1.Pod {
+		return test.BuildTestPod(name, 100, 0, nodeName, test.SetSSOwnerRef)
+	}
+
+	tests := []struct {
+		description        string
+		maxPerOwnerPerNode int
+		pods               []*v1.Pod
+		expectedEvicted    int
+	}{
+		{
+			description:     "no limit",
+			pods:            []*v1.Pod{rsPod("p1", "node1"), rsPod("p2", "node1"), rsPod("p3", "node1")},
+			expectedEvicted: 3,
+		},
+		{
+			description:        "limit reached for one owner on a node",
+			maxPerOwnerPerNode: 1,
+			pods:               []*v1.Pod{rsPod("p1", "node1"), rsPod("p2", "node1"), ssPod("p3", "node1"), ssPod("p4", "node1")},
+			expectedEvicted:    2,
+		},
+		{
+			description:        "limit is applied per node",
+			maxPerOwnerPerNode: 1,
+			pods:               []*v1.Pod{rsPod("p1", "node1"), rsPod("p2", "node1"), rsPod("p3", "node2")},
+			expectedEvicted:    2,
+		},
+		{
+			description:        "pods without owner are not limited",
+			maxPerOwnerPerNode: 1,
+			pods:               []*v1.Pod{test.BuildTestPod("p1", 100, 0, "node1", nil), test.BuildTestPod("p2", 100, 0, "node1", nil)},
+			expectedEvicted:    2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			fakeClient := &fake.Clientset{}
+			nodes := map[string]*v1.Node{node1.Name: node1, node2.Name: node2}
+			podEvictor := NewPodEvictor(fakeClient, "v1", false, 0, test.maxPerOwnerPerNode, []*v1.Node{node1, node2}, false, false, false)
+			for _, pod := range test.pods {
+				if _, err := podEvictor.EvictPod(ctx, pod, nodes[pod.Spec.NodeName], ReasonPodLifeTime); err != nil {
+					t.Fatalf("Unexpected error evicting pod %v: %v", pod.Name, err)
+				}
+			}
+			if evicted := podEvictor.TotalEvicted(); evicted != test.expectedEvicted {
+				t.Errorf("Expected %v pods to be evicted, got %v", test.expectedEvicted, evicted)
+			}
+		})
+	}
+}
+
+func TestForceDeletePodLimits(t *testing.T) {
+	ctx := context.Background()
+	node1 := test.BuildTestNode("node1", 1000, 2000, 9, nil)
+
+	rsPods := []*v1.Pod{
+		test.BuildTestPod("p1", 100, 0, node1.Name, test.SetRSOwnerRef),
+		test.BuildTestPod("p2", 100, 0, node1.Name, test.SetRSOwnerRef),
+		test.BuildTestPod("p3", 100, 0, node1.Name, test.SetRSOwnerRef),
+	}
+
+	tests := []struct {
+		description        string
+		maxPerNode         int
+		maxPerOwnerPerNode int
+		expectedDeleted    int
+		expectedError      bool
+	}{
+		{
+			description:     "no limit",
+			expectedDeleted: 3,
+		},
+		{
+			description:     "limit per node reached",
+			maxPerNode:      2,
+			expectedDeleted: 2,
+			expectedError:   true,
+		},
+		{
+			description:        "limit per owner reached on the node",
+			maxPerOwnerPerNode: 1,
+			expectedDeleted:    1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			fakeClient := &fake.Clientset{}
+			podEvictor := NewPodEvictor(fakeClient, "v1", false, test.maxPerNode, test.maxPerOwnerPerNode, []*v1.Node{node1}, false, false, false)
+			var err error
+			for _, pod := range rsPods {
+				if _, err = podEvictor.ForceDeletePod(ctx, pod, node1, ReasonRemovePodsFromNotReadyNodes); err != nil {
+					break
+				}
+			}
+			if (err != nil) != test.expectedError {
+				t.Errorf("Expected error: %v, got %v", test.expectedError, err)
+			}
+			if deleted := podEvictor.TotalEvicted(); deleted != test.expectedDeleted {
+				t.Errorf("Expected %v pods to be force deleted, got %v", test.expectedDeleted, deleted)
+			}
+		})
+	}
+}
+
+func TestEvictionReason(t *testing.T) {
+	reason := ReasonLowNodeUtilization
+	if got, expected := reason.String(), "LowNodeUtilization/NodeOverutilized"; got != expected {
+		t.Errorf("Expected reason %q, got %q", expected, got)
+	}
+	annotations := reasonAnnotations(reason)
+	if annotations[StrategyAnnotationKey] != "LowNodeUtilization" || annotations[EvictionCauseAnnotationKey] != "NodeOverutilized" {
+		t.Errorf("Unexpected event annotations %v", annotations)
+	}
+}
+
+func TestEvictPodWithReschedulingHint(t *testing.T) {
+	ctx := context.Background()
+	node1 := test.BuildTestNode("node1", 1000, 2000, 9, nil)
+	rs := &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "rs", Namespace: "default"}}
+	pod := test.BuildTestPod("p1", 100, 0, node1.Name, func(pod *v1.Pod) {
+		pod.OwnerReferences = []metav1.OwnerReference{{Kind: "ReplicaSet", APIVersion: "apps/v1", Name: rs.Name}}
+	})
+
+	fakeClient := fake.NewSimpleClientset(rs, pod)
+	podEvictor := NewPodEvictor(fakeClient, "v1", false, 0, 0, []*v1.Node{node1}, false, false, false)
+	podEvictor.SetReschedulingHints(true)
+	if _, err := podEvictor.EvictPodWithHint(ctx, pod, node1, ReasonLowNodeUtilization, ReschedulingHint{PreferredNodes: []string{"node2"}}); err != nil {
+		t.Fatalf("Unexpected error evicting pod: %v", err)
+	}
+
+	owner, err := fakeClient.AppsV1().ReplicaSets(rs.Namespace).Get(ctx, rs.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Unable to get owner: %v", err)
+	}
+	hint := ReschedulingHint{}
+	if err := json.Unmarshal([]byte(owner.Annotations[ReschedulingHintAnnotationKey]), &hint); err != nil {
+		t.Fatalf("Unable to decode rescheduling hint: %v", err)
+	}
+	if hint.Strategy != "LowNodeUtilization" || hint.Cause != CauseNodeOverutilized || hint.EvictedPod != pod.Name {
+		t.Errorf("Unexpected rescheduling hint reason %#v", hint)
+	}
+	if !reflect.DeepEqual(hint.AvoidNodes, []string{"node1"}) || !reflect.DeepEqual(hint.PreferredNodes, []string{"node2"}) {
+		t.Errorf("Unexpected rescheduling hint nodes %#v", hint)
+	}
+}
+
 func TestIsEvictable(t *testing.T) {
 	n1 := test.BuildTestNode("node1", 1000, 2000, 13, nil)
 	lowPriority := int32(800)
@@ -418,6 +587,7 @@ func TestIsEvictable(t *testing.T) {
 			evictLocalStoragePods:   test.evictLocalStoragePods,
 			evictSystemCriticalPods: test.evictSystemCriticalPods,
 			nodes:                   nodes,
+			client:                  fake.NewSimpleClientset(),
 		}
 
 		evictable := podEvictor.Evictable()
@@ -437,6 +607,77 @@ func TestIsEvictable(t *testing.T) {
 
 	}
 }
+func TestEvictableEmptyDirSizeLimit(t *testing.T) {
+	n1 := test.BuildTestNode("node1", 1000, 2000, 13, nil)
+	withVolumes := func(name string, volumes ...v1.VolumeSource) *v1.Pod {
+		return test.BuildTestPod(name, 400, 0, n1.Name, func(pod *v1.Pod) {
+			pod.ObjectMeta.OwnerReferences = test.GetReplicaSetOwnerRefList()
+			for i, source := range volumes {
+				pod.Spec.Volumes = append(pod.Spec.Volumes, v1.Volume{Name: fmt.Sprintf("volume-%d", i), VolumeSource: source})
+			}
+		})
+	}
+	emptyDir := func(sizeLimit string) v1.VolumeSource {
+		source := v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}
+		if sizeLimit != "" {
+			quantity := resource.MustParse(sizeLimit)
+			source.EmptyDir.SizeLimit = &quantity
+		}
+		return source
+	}
+	hostPath := v1.VolumeSource{HostPath: &v1.HostPathVolumeSource{Path: "/var/lib"}}
+	limit := resource.MustParse("100Mi")
+
+	tests := []struct {
+		description       string
+		pod               *v1.Pod
+		emptyDirSizeLimit *resource.Quantity
+		evictable         bool
+	}{
+		{
+			description: "small emptyDir protected without size limit",
+			pod:         withVolumes("p1", emptyDir("10Mi")),
+		},
+		{
+			description:       "emptyDir below the size limit",
+			pod:               withVolumes("p2", emptyDir("10Mi")),
+			emptyDirSizeLimit: &limit,
+			evictable:         true,
+		},
+		{
+			description:       "emptyDir at the size limit",
+			pod:               withVolumes("p3", emptyDir("100Mi"), emptyDir("50Mi")),
+			emptyDirSizeLimit: &limit,
+			evictable:         true,
+		},
+		{
+			description:       "emptyDir above the size limit",
+			pod:               withVolumes("p4", emptyDir("10Mi"), emptyDir("1Gi")),
+			emptyDirSizeLimit: &limit,
+		},
+		{
+			description:       "emptyDir without sizeLimit",
+			pod:               withVolumes("p5", emptyDir("")),
+			emptyDirSizeLimit: &limit,
+		},
+		{
+			description:       "hostPath volume",
+			pod:               withVolumes("p6", emptyDir("10Mi"), hostPath),
+			emptyDirSizeLimit: &limit,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			podEvictor := NewPodEvictor(nil, "v1", false, 0, 0, []*v1.Node{n1}, false, false, false)
+			podEvictor.SetEmptyDirSizeLimit(tc.emptyDirSizeLimit)
+			if evictable := podEvictor.Evictable().IsEvictable(tc.pod); evictable != tc.evictable {
+				t.Errorf("Expected pod to be evictable: %v, got %v", tc.evictable, evictable)
+			}
+		})
+	}
+}
+
 func TestPodTypes(t *testing.T) {
 	n1 := test.BuildTestNode("node1", 1000, 2000, 9, nil)
 	p1 := test.BuildTestPod("p1", 400, 0, n1.Name, nil)
@@ -481,3 +722,1113 @@ func TestPodTypes(t *testing.T) {
 	}
 
 }
+
+func TestEvictPodWaitingForReplacement(t *testing.T) {
+	ctx := context.Background()
+	replacementPollInterval = 10 * time.Millisecond
+	defer func() { replacementPollInterval = time.Second }()
+
+	node1 := test.BuildTestNode("node1", 1000, 2000, 9, nil)
+	ownedBy := func(name string) func(*v1.Pod) {
+		return func(pod *v1.Pod) {
+			pod.OwnerReferences = []metav1.OwnerReference{{Kind: "ReplicaSet", APIVersion: "apps/v1", Name: name}}
+			pod.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+		}
+	}
+	p1 := test.BuildTestPod("p1", 100, 0, node1.Name, ownedBy("rs"))
+	p2 := test.BuildTestPod("p2", 100, 0, node1.Name, ownedBy("rs"))
+	p3 := test.BuildTestPod("p3", 100, 0, node1.Name, ownedBy("other"))
+	replacement := test.BuildTestPod("p4", 100, 0, node1.Name, func(pod *v1.Pod) {
+		ownedBy("rs")(pod)
+		pod.CreationTimestamp = metav1.NewTime(time.Now().Add(time.Second))
+		pod.Status.Conditions = []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}}
+	})
+
+	fakeClient := fake.NewSimpleClientset(p1, p2, p3)
+	// the fake tracker would otherwise store evictions as pods
+	fakeClient.PrependReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		return action.GetSubresource() == "eviction", nil, nil
+	})
+	podEvictor := NewPodEvictor(fakeClient, "v1", false, 0, 0, []*v1.Node{node1}, false, false, false)
+	podEvictor.SetReplacementReadinessTimeout(50 * time.Millisecond)
+	for _, tc := range []struct {
+		pod             *v1.Pod
+		expectedSuccess bool
+	}{
+		{pod: p1, expectedSuccess: true},
+		// no ready replacement of p1
+		{pod: p2, expectedSuccess: false},
+		{pod: p3, expectedSuccess: true},
+	} {
+		success, err := podEvictor.EvictPod(ctx, tc.pod, node1, ReasonPodLifeTime)
+		if err != nil {
+			t.Fatalf("Unexpected error evicting pod %v: %v", tc.pod.Name, err)
+		}
+		if success != tc.expectedSuccess {
+			t.Errorf("Expected eviction of pod %v to be %v, got %v", tc.pod.Name, tc.expectedSuccess, success)
+		}
+	}
+
+	// pods of an owner are not evicted anymore once waiting for a replacement timed out
+	if _, err := fakeClient.CoreV1().Pods(replacement.Namespace).Create(ctx, replacement, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Unable to create replacement pod: %v", err)
+	}
+	if success, _ := podEvictor.EvictPod(ctx, p2, node1, ReasonPodLifeTime); success {
+		t.Errorf("Expected pod p2 not to be evicted after waiting for a replacement timed out")
+	}
+
+	podEvictor = NewPodEvictor(fakeClient, "v1", false, 0, 0, []*v1.Node{node1}, false, false, false)
+	podEvictor.SetReplacementReadinessTimeout(50 * time.Millisecond)
+	for _, pod := range []*v1.Pod{p1, p2} {
+		if success, err := podEvictor.EvictPod(ctx, pod, node1, ReasonPodLifeTime); err != nil || !success {
+			t.Errorf("Expected pod %v to be evicted, got %v: %v", pod.Name, success, err)
+		}
+	}
+}
+
+func TestEvictPodWaitingForReplacementBounds(t *testing.T) {
+	replacementPollInterval = 10 * time.Millisecond
+	defer func() { replacementPollInterval = time.Second }()
+
+	node1 := test.BuildTestNode("node1", 1000, 2000, 9, nil)
+	buildPod := func(name string) *v1.Pod {
+		return test.BuildTestPod(name, 100, 0, node1.Name, func(pod *v1.Pod) {
+			test.SetRSOwnerRef(pod)
+			pod.Labels = map[string]string{"app": "web", appsv1.ControllerRevisionHashLabelKey: name}
+		})
+	}
+	p1, p2 := buildPod("p1"), buildPod("p2")
+
+	tests := []struct {
+		description string
+		deadline    time.Duration
+		cancelled   bool
+	}{
+		{
+			description: "the wait ends with the deadline of the strategy",
+			deadline:    50 * time.Millisecond,
+		},
+		{
+			description: "the wait ends when the context is cancelled",
+			cancelled:   true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			fakeClient := fake.NewSimpleClientset(p1, p2)
+			fakeClient.PrependReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				return action.GetSubresource() == "eviction", nil, nil
+			})
+			var selectors []string
+			fakeClient.PrependReactor("list", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				selectors = append(selectors, action.(core.ListAction).GetListRestrictions().Labels.String())
+				return false, nil, nil
+			})
+			podEvictor := NewPodEvictor(fakeClient, "v1", false, 0, 0, []*v1.Node{node1}, false, false, false)
+			podEvictor.SetReplacementReadinessTimeout(time.Minute)
+			if success, err := podEvictor.EvictPod(context.Background(), p1, node1, ReasonPodLifeTime); err != nil || !success {
+				t.Fatalf("Expected pod p1 to be evicted, got %v: %v", success, err)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tc.deadline > 0 {
+				podEvictor.SetDeadline(time.Now().Add(tc.deadline))
+			}
+			if tc.cancelled {
+				cancel()
+			}
+			start := time.Now()
+			if success, _ := podEvictor.EvictPod(ctx, p2, node1, ReasonPodLifeTime); success {
+				t.Errorf("Expected pod p2 not to be evicted without a ready replacement of p1")
+			}
+			if elapsed := time.Since(start); elapsed > 5*time.Second {
+				t.Errorf("Expected the wait for a replacement to end early, took %v", elapsed)
+			}
+			for _, selector := range selectors {
+				if selector != "app=web" {
+					t.Errorf("Expected replacements to be listed with the labels of the evicted pod, got %q", selector)
+				}
+			}
+		})
+	}
+}
+
+func TestEvictPodWaitingForVolumeDetach(t *testing.T) {
+	ctx := context.Background()
+	volumeDetachPollInterval = 10 * time.Millisecond
+	defer func() { volumeDetachPollInterval = time.Second }()
+
+	node1 := test.BuildTestNode("node1", 1000, 2000, 9, nil)
+	node2 := test.BuildTestNode("node2", 1000, 2000, 9, nil)
+	buildClaim := func(name, volume string, mode v1.PersistentVolumeAccessMode) *v1.PersistentVolumeClaim {
+		return &v1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       v1.PersistentVolumeClaimSpec{AccessModes: []v1.PersistentVolumeAccessMode{mode}, VolumeName: volume},
+		}
+	}
+	buildPod := func(name, nodeName, claim string) *v1.Pod {
+		return test.BuildTestPod(name, 100, 0, nodeName, func(pod *v1.Pod) {
+			test.SetRSOwnerRef(pod)
+			if claim != "" {
+				pod.Spec.Volumes = []v1.Volume{{
+					Name:         "data",
+					VolumeSource: v1.VolumeSource{PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: claim}},
+				}}
+			}
+		})
+	}
+	pv1 := "pv1"
+	attachment := &storagev1.VolumeAttachment{
+		ObjectMeta: metav1.ObjectMeta{Name: "attachment1"},
+		Spec:       storagev1.VolumeAttachmentSpec{NodeName: node1.Name, Source: storagev1.VolumeAttachmentSource{PersistentVolumeName: &pv1}},
+	}
+
+	fakeClient := fake.NewSimpleClientset(
+		buildClaim("c1", "pv1", v1.ReadWriteOnce),
+		buildClaim("c2", "pv2", v1.ReadWriteOnce),
+		buildClaim("c3", "pv3", v1.ReadWriteMany),
+		attachment,
+	)
+	// the fake tracker would otherwise store evictions as pods
+	fakeClient.PrependReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		return action.GetSubresource() == "eviction", nil, nil
+	})
+	podEvictor := NewPodEvictor(fakeClient, "v1", false, 0, 0, []*v1.Node{node1, node2}, false, false, false)
+	podEvictor.SetVolumeDetachTimeout(50 * time.Millisecond)
+	for _, tc := range []struct {
+		pod             *v1.Pod
+		node            *v1.Node
+		expectedSuccess bool
+	}{
+		{pod: buildPod("p1", node1.Name, "c1"), node: node1, expectedSuccess: true},
+		// pv1 is still attached to node1
+		{pod: buildPod("p2", node1.Name, "c2"), node: node1, expectedSuccess: false},
+		// pods without ReadWriteOnce volumes and pods of other nodes do not wait
+		{pod: buildPod("p3", node1.Name, ""), node: node1, expectedSuccess: true},
+		{pod: buildPod("p4", node1.Name, "c3"), node: node1, expectedSuccess: true},
+		{pod: buildPod("p5", node2.Name, "c2"), node: node2, expectedSuccess: true},
+	} {
+		success, err := podEvictor.EvictPod(ctx, tc.pod, tc.node, ReasonPodLifeTime)
+		if err != nil {
+			t.Fatalf("Unexpected error evicting pod %v: %v", tc.pod.Name, err)
+		}
+		if success != tc.expectedSuccess {
+			t.Errorf("Expected eviction of pod %v to be %v, got %v", tc.pod.Name, tc.expectedSuccess, success)
+		}
+	}
+
+	// pods with ReadWriteOnce volumes are not evicted from the node anymore once waiting timed out
+	if err := fakeClient.StorageV1().VolumeAttachments().Delete(ctx, attachment.Name, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Unable to delete volume attachment: %v", err)
+	}
+	if success, _ := podEvictor.EvictPod(ctx, buildPod("p2", node1.Name, "c2"), node1, ReasonPodLifeTime); success {
+		t.Errorf("Expected pod p2 not to be evicted after waiting for volumes to be detached timed out")
+	}
+
+	podEvictor = NewPodEvictor(fakeClient, "v1", false, 0, 0, []*v1.Node{node1, node2}, false, false, false)
+	podEvictor.SetVolumeDetachTimeout(50 * time.Millisecond)
+	for _, pod := range []*v1.Pod{buildPod("p1", node1.Name, "c1"), buildPod("p2", node1.Name, "c2")} {
+		if success, err := podEvictor.EvictPod(ctx, pod, node1, ReasonPodLifeTime); err != nil || !success {
+			t.Errorf("Expected pod %v to be evicted, got %v: %v", pod.Name, success, err)
+		}
+	}
+
+	// the wait ends with the deadline of the strategy, before the timeout
+	if _, err := fakeClient.StorageV1().VolumeAttachments().Create(ctx, attachment, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Unable to create volume attachment: %v", err)
+	}
+	podEvictor = NewPodEvictor(fakeClient, "v1", false, 0, 0, []*v1.Node{node1, node2}, false, false, false)
+	podEvictor.SetVolumeDetachTimeout(time.Minute)
+	if success, err := podEvictor.EvictPod(ctx, buildPod("p1", node1.Name, "c1"), node1, ReasonPodLifeTime); err != nil || !success {
+		t.Fatalf("Expected pod p1 to be evicted, got %v: %v", success, err)
+	}
+	podEvictor.SetDeadline(time.Now().Add(50 * time.Millisecond))
+	start := time.Now()
+	if success, _ := podEvictor.EvictPod(ctx, buildPod("p2", node1.Name, "c2"), node1, ReasonPodLifeTime); success {
+		t.Errorf("Expected pod p2 not to be evicted while pv1 is attached")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected the wait for volumes to be detached to end with the deadline, took %v", elapsed)
+	}
+}
+
+func TestEvictPodCooldown(t *testing.T) {
+	ctx := context.Background()
+	node1 := test.BuildTestNode("node1", 1000, 2000, 9, nil)
+	buildPod := func(name, owner, templateHash string, age time.Duration) *v1.Pod {
+		return test.BuildTestPod(name, 100, 0, node1.Name, func(pod *v1.Pod) {
+			pod.OwnerReferences = []metav1.OwnerReference{{Kind: "ReplicaSet", APIVersion: "apps/v1", Name: owner}}
+			pod.Labels = map[string]string{appsv1.DefaultDeploymentUniqueLabelKey: templateHash}
+			pod.CreationTimestamp = metav1.NewTime(time.Now().Add(-age))
+		})
+	}
+
+	fakeClient := &fake.Clientset{}
+	cooldown := NewCooldownTracker(time.Hour)
+	podEvictor := NewPodEvictor(fakeClient, "v1", false, 0, 0, []*v1.Node{node1}, false, false, false)
+	podEvictor.SetCooldown(cooldown)
+	if success, err := podEvictor.EvictPod(ctx, buildPod("p1", "rs", "a", time.Hour), node1, ReasonLowNodeUtilization); err != nil || !success {
+		t.Fatalf("Expected pod p1 to be evicted, got %v: %v", success, err)
+	}
+
+	// the tracker is shared by the evictors of the following cycles and strategies
+	podEvictor = NewPodEvictor(fakeClient, "v1", false, 0, 0, []*v1.Node{node1}, false, false, false)
+	podEvictor.SetCooldown(cooldown)
+	for _, tc := range []struct {
+		pod             *v1.Pod
+		expectedSuccess bool
+	}{
+		// replacement of p1
+		{pod: buildPod("p2", "rs", "a", 0), expectedSuccess: false},
+		// created before the eviction of p1
+		{pod: buildPod("p3", "rs", "a", time.Hour), expectedSuccess: true},
+		// created from another template
+		{pod: buildPod("p4", "rs", "b", 0), expectedSuccess: true},
+		{pod: buildPod("p5", "other", "a", 0), expectedSuccess: true},
+	} {
+		success, err := podEvictor.EvictPod(ctx, tc.pod, node1, ReasonRemoveDuplicates)
+		if err != nil {
+			t.Fatalf("Unexpected error evicting pod %v: %v", tc.pod.Name, err)
+		}
+		if success != tc.expectedSuccess {
+			t.Errorf("Expected eviction of pod %v to be %v, got %v", tc.pod.Name, tc.expectedSuccess, success)
+		}
+	}
+
+	// evictions older than the cooldown are forgotten
+	expired := NewCooldownTracker(time.Hour)
+	expired.Record(buildPod("p1", "rs", "a", 3*time.Hour), time.Now().Add(-2*time.Hour))
+	if expired.InCooldown(buildPod("p2", "rs", "a", 90*time.Minute), time.Now()) {
+		t.Errorf("Expected the cooldown to have expired")
+	}
+}
+
+func TestCooldownStore(t *testing.T) {
+	ctx := context.Background()
+	buildPod := func(name, owner string, age time.Duration) *v1.Pod {
+		return test.BuildTestPod(name, 100, 0, "node1", func(pod *v1.Pod) {
+			pod.OwnerReferences = []metav1.OwnerReference{{Kind: "ReplicaSet", APIVersion: "apps/v1", Name: owner}}
+			pod.Labels = map[string]string{appsv1.DefaultDeploymentUniqueLabelKey: "a"}
+			pod.CreationTimestamp = metav1.NewTime(time.Now().Add(-age))
+		})
+	}
+
+	for _, kind := range []string{"", CooldownStoreConfigMap, CooldownStoreLease} {
+		t.Run("kind "+kind, func(t *testing.T) {
+			fakeClient := fake.NewSimpleClientset()
+			store, err := NewCooldownStore(fakeClient, &api.CooldownPersistence{Kind: kind, Name: "cooldown"})
+			if err != nil {
+				t.Fatalf("Unexpected error creating the store: %v", err)
+			}
+
+			// nothing is restored before the cooldown is stored
+			tracker := NewCooldownTracker(time.Hour)
+			if err := store.Restore(ctx, tracker); err != nil {
+				t.Fatalf("Unexpected error restoring an empty cooldown: %v", err)
+			}
+			tracker.Record(buildPod("p1", "rs1", time.Hour), time.Now().Add(-10*time.Minute))
+			tracker.Record(buildPod("p2", "rs2", 3*time.Hour), time.Now().Add(-2*time.Hour))
+			if err := store.Save(ctx, tracker, time.Now()); err != nil {
+				t.Fatalf("Unexpected error saving the cooldown: %v", err)
+			}
+
+			// a restarted descheduler starts with an empty tracker
+			restarted := NewCooldownTracker(time.Hour)
+			if err := store.Restore(ctx, restarted); err != nil {
+				t.Fatalf("Unexpected error restoring the cooldown: %v", err)
+			}
+			if !restarted.InCooldown(buildPod("p3", "rs1", 0), time.Now()) {
+				t.Errorf("Expected the replacement of p1 to be in cooldown after a restart")
+			}
+			if restarted.InCooldown(buildPod("p4", "rs2", 0), time.Now()) {
+				t.Errorf("Expected the expired eviction of p2 not to be stored")
+			}
+		})
+	}
+
+	if _, err := NewCooldownStore(fake.NewSimpleClientset(), &api.CooldownPersistence{Kind: "Secret"}); err == nil {
+		t.Errorf("Expected an error for an unknown kind")
+	}
+}
+
+func TestCooldownStoreSizeLimit(t *testing.T) {
+	ctx := context.Background()
+	buildPod := func(owner string) *v1.Pod {
+		return test.BuildTestPod("p", 100, 0, "node1", func(pod *v1.Pod) {
+			pod.OwnerReferences = []metav1.OwnerReference{{Kind: "ReplicaSet", APIVersion: "apps/v1", Name: owner}}
+			pod.Labels = map[string]string{appsv1.DefaultDeploymentUniqueLabelKey: "a"}
+			pod.CreationTimestamp = metav1.Now()
+		})
+	}
+	owner := func(i int) string {
+		return fmt.Sprintf("replicaset-with-a-rather-long-generated-name-%d", i)
+	}
+
+	fakeClient := fake.NewSimpleClientset()
+	store, err := NewCooldownStore(fakeClient, &api.CooldownPersistence{Kind: CooldownStoreLease, Name: "cooldown"})
+	if err != nil {
+		t.Fatalf("Unexpected error creating the store: %v", err)
+	}
+	// the evictions exceed the size limit of the annotations of the Lease, the first one being the oldest
+	tracker := NewCooldownTracker(time.Hour)
+	evictions := 4000
+	for i := 0; i < evictions; i++ {
+		tracker.Record(buildPod(owner(i)), time.Now().Add(-time.Duration(evictions-i)*time.Millisecond-time.Minute))
+	}
+	// the evictions are trimmed on every save, not only the first one
+	for i := 0; i < 2; i++ {
+		if err := store.Save(ctx, tracker, time.Now()); err != nil {
+			t.Fatalf("Unexpected error saving the cooldown: %v", err)
+		}
+	}
+	lease, err := fakeClient.CoordinationV1().Leases(DefaultCooldownStoreNamespace).Get(ctx, "cooldown", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Unable to get cooldown Lease: %v", err)
+	}
+	if size := len(lease.Annotations[cooldownAnnotationKey]); size == 0 || size > leaseCooldownMaxBytes {
+		t.Fatalf("Expected the stored cooldown to fit in %d bytes, got %d bytes", leaseCooldownMaxBytes, size)
+	}
+
+	restarted := NewCooldownTracker(time.Hour)
+	if err := store.Restore(ctx, restarted); err != nil {
+		t.Fatalf("Unexpected error restoring the cooldown: %v", err)
+	}
+	if !restarted.InCooldown(buildPod(owner(evictions-1)), time.Now()) {
+		t.Errorf("Expected the most recent eviction to be stored")
+	}
+	if restarted.InCooldown(buildPod(owner(0)), time.Now()) {
+		t.Errorf("Expected the oldest eviction to be dropped")
+	}
+}
+
+func TestEvictPodOwnerRollingOut(t *testing.T) {
+	ctx := context.Background()
+	node1 := test.BuildTestNode("node1", 1000, 2000, 9, nil)
+	ownedBy := func(kind, name string) func(*v1.Pod) {
+		return func(pod *v1.Pod) {
+			pod.OwnerReferences = []metav1.OwnerReference{{Kind: kind, APIVersion: "apps/v1", Name: name}}
+		}
+	}
+	deployment := func(name string, generation, observedGeneration int64, reason string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Generation: generation},
+			Status: appsv1.DeploymentStatus{
+				ObservedGeneration: observedGeneration,
+				Conditions:         []appsv1.DeploymentCondition{{Type: appsv1.DeploymentProgressing, Status: v1.ConditionTrue, Reason: reason}},
+			},
+		}
+	}
+	replicaSet := func(name, deployment string) *appsv1.ReplicaSet {
+		controller := true
+		return &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       "default",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", APIVersion: "apps/v1", Name: deployment, Controller: &controller}},
+		}}
+	}
+	replicas := int32(3)
+	statefulSet := func(name string, updatedReplicas int32) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       appsv1.StatefulSetSpec{Replicas: &replicas, UpdateStrategy: appsv1.StatefulSetUpdateStrategy{Type: appsv1.RollingUpdateStatefulSetStrategyType}},
+			Status:     appsv1.StatefulSetStatus{CurrentRevision: "a", UpdateRevision: "b", UpdatedReplicas: updatedReplicas},
+		}
+	}
+
+	fakeClient := fake.NewSimpleClientset(
+		deployment("stable", 2, 2, "NewReplicaSetAvailable"),
+		deployment("progressing", 2, 2, "ReplicaSetUpdated"),
+		deployment("updated", 3, 2, "NewReplicaSetAvailable"),
+		replicaSet("stable-1", "stable"),
+		replicaSet("progressing-1", "progressing"),
+		replicaSet("updated-1", "updated"),
+		statefulSet("rolling", 1),
+		statefulSet("rolled", 3),
+	)
+	// the fake tracker would otherwise store evictions as pods
+	fakeClient.PrependReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		return action.GetSubresource() == "eviction", nil, nil
+	})
+
+	for _, tc := range []struct {
+		pod             *v1.Pod
+		expectedSuccess bool
+	}{
+		{pod: test.BuildTestPod("p1", 100, 0, node1.Name, ownedBy("ReplicaSet", "stable-1")), expectedSuccess: true},
+		{pod: test.BuildTestPod("p2", 100, 0, node1.Name, ownedBy("ReplicaSet", "progressing-1")), expectedSuccess: false},
+		// the deployment controller did not observe the last spec yet
+		{pod: test.BuildTestPod("p3", 100, 0, node1.Name, ownedBy("ReplicaSet", "updated-1")), expectedSuccess: false},
+		{pod: test.BuildTestPod("p4", 100, 0, node1.Name, ownedBy("StatefulSet", "rolling")), expectedSuccess: false},
+		{pod: test.BuildTestPod("p5", 100, 0, node1.Name, ownedBy("StatefulSet", "rolled")), expectedSuccess: true},
+		// owners which can not be read are not rolling out
+		{pod: test.BuildTestPod("p6", 100, 0, node1.Name, ownedBy("ReplicaSet", "unknown")), expectedSuccess: true},
+	} {
+		podEvictor := NewPodEvictor(fakeClient, "v1", false, 0, 0, []*v1.Node{node1}, false, false, false)
+		podEvictor.SetSkipOwnersRollingOut(true)
+		success, err := podEvictor.EvictPod(ctx, tc.pod, node1, ReasonPodLifeTime)
+		if err != nil {
+			t.Fatalf("Unexpected error evicting pod %v: %v", tc.pod.Name, err)
+		}
+		if success != tc.expectedSuccess {
+			t.Errorf("Expected eviction of pod %v to be %v, got %v", tc.pod.Name, tc.expectedSuccess, success)
+		}
+	}
+
+	// pods of owners rolling out are evicted when the evictor does not skip them
+	podEvictor := NewPodEvictor(fakeClient, "v1", false, 0, 0, []*v1.Node{node1}, false, false, false)
+	if success, err := podEvictor.EvictPod(ctx, test.BuildTestPod("p2", 100, 0, node1.Name, ownedBy("ReplicaSet", "progressing-1")), node1, ReasonPodLifeTime); err != nil || !success {
+		t.Errorf("Expected pod p2 to be evicted, got %v: %v", success, err)
+	}
+}
+
+func TestEvictPodAnnotations(t *testing.T) {
+	ctx := context.Background()
+	node1 := test.BuildTestNode("node1", 1000, 2000, 9, nil)
+	cycleStart := time.Date(2021, 9, 1, 10, 0, 0, 0, time.UTC)
+
+	for _, tc := range []struct {
+		description         string
+		config              *api.EvictedPodAnnotations
+		dryRun              bool
+		expectedAnnotations map[string]string
+		expectedLabels      map[string]string
+	}{
+		{
+			description: "pods are not annotated by default",
+		},
+		{
+			description: "pods are annotated before being evicted",
+			config:      &api.EvictedPodAnnotations{},
+			expectedAnnotations: map[string]string{
+				StrategyAnnotationKey:       "PodLifeTime",
+				EvictionCauseAnnotationKey:  "PodLifeTimeExceeded",
+				EvictionCycleAnnotationKey:  "20210901T100000Z",
+				EvictionReasonAnnotationKey: ReasonPodLifeTime.String() + " (age=2h)",
+			},
+		},
+		{
+			description: "pods are labeled when configured",
+			config:      &api.EvictedPodAnnotations{Labels: true},
+			expectedAnnotations: map[string]string{
+				StrategyAnnotationKey:       "PodLifeTime",
+				EvictionCauseAnnotationKey:  "PodLifeTimeExceeded",
+				EvictionCycleAnnotationKey:  "20210901T100000Z",
+				EvictionReasonAnnotationKey: ReasonPodLifeTime.String() + " (age=2h)",
+			},
+			expectedLabels: map[string]string{
+				StrategyAnnotationKey:      "PodLifeTime",
+				EvictionCauseAnnotationKey: "PodLifeTimeExceeded",
+				EvictionCycleAnnotationKey: "20210901T100000Z",
+			},
+		},
+		{
+			description: "pods are not annotated in dry run mode",
+			config:      &api.EvictedPodAnnotations{Labels: true},
+			dryRun:      true,
+		},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			pod := test.BuildTestPod("p1", 100, 0, node1.Name, test.SetRSOwnerRef)
+			fakeClient := fake.NewSimpleClientset(pod)
+			// the fake tracker would otherwise store evictions as pods
+			fakeClient.PrependReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				return action.GetSubresource() == "eviction", nil, nil
+			})
+
+			podEvictor := NewPodEvictor(fakeClient, "v1", tc.dryRun, 0, 0, []*v1.Node{node1}, false, false, false)
+			podEvictor.SetPodAnnotations(NewPodAnnotations(tc.config, cycleStart))
+			if success, err := podEvictor.EvictPod(ctx, pod, node1, ReasonPodLifeTime, "age=2h"); err != nil || !success {
+				t.Fatalf("Expected pod p1 to be evicted, got %v: %v", success, err)
+			}
+
+			annotated, err := fakeClient.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Unable to get pod p1: %v", err)
+			}
+			annotations := map[string]string{}
+			for key, value := range annotated.Annotations {
+				if _, ok := pod.Annotations[key]; !ok {
+					annotations[key] = value
+				}
+			}
+			if len(annotations) > 0 || len(tc.expectedAnnotations) > 0 {
+				if !reflect.DeepEqual(annotations, tc.expectedAnnotations) {
+					t.Errorf("Expected annotations %v, got %v", tc.expectedAnnotations, annotations)
+				}
+			}
+			if len(annotated.Labels) > 0 || len(tc.expectedLabels) > 0 {
+				if !reflect.DeepEqual(annotated.Labels, tc.expectedLabels) {
+					t.Errorf("Expected labels %v, got %v", tc.expectedLabels, annotated.Labels)
+				}
+			}
+		})
+	}
+}
+
+func TestEvictPodDeadline(t *testing.T) {
+	ctx := context.Background()
+	node1 := test.BuildTestNode("node1", 1000, 2000, 9, nil)
+	buildPod := func(name string) *v1.Pod {
+		return test.BuildTestPod(name, 100, 0, node1.Name, test.SetRSOwnerRef)
+	}
+
+	podEvictor := NewPodEvictor(&fake.Clientset{}, "v1", false, 0, 0, []*v1.Node{node1}, false, false, false)
+	podEvictor.SetDeadline(time.Now().Add(time.Hour))
+	if success, err := podEvictor.EvictPod(ctx, buildPod("p1"), node1, ReasonPodLifeTime); err != nil || !success {
+		t.Fatalf("Expected pod p1 to be evicted before the deadline, got %v: %v", success, err)
+	}
+
+	podEvictor.SetDeadline(time.Now().Add(-time.Second))
+	if !podEvictor.DeadlineExceeded() {
+		t.Errorf("Expected the deadline to be exceeded")
+	}
+	if success, err := podEvictor.EvictPod(ctx, buildPod("p2"), node1, ReasonPodLifeTime); err == nil || success {
+		t.Errorf("Expected pod p2 not to be evicted after the deadline, got %v: %v", success, err)
+	}
+
+	// the next strategy runs without deadline
+	podEvictor.SetDeadline(time.Time{})
+	if success, err := podEvictor.EvictPod(ctx, buildPod("p3"), node1, ReasonPodLifeTime); err != nil || !success {
+		t.Errorf("Expected pod p3 to be evicted without deadline, got %v: %v", success, err)
+	}
+	if podEvictor.TotalEvicted() != 2 {
+		t.Errorf("Expected 2 evicted pods, got %v", podEvictor.TotalEvicted())
+	}
+}
+
+func TestEvictPodErrorBudget(t *testing.T) {
+	ctx := context.Background()
+	node1 := test.BuildTestNode("node1", 1000, 2000, 9, nil)
+	buildPod := func(name string) *v1.Pod {
+		return test.BuildTestPod(name, 100, 0, node1.Name, test.SetRSOwnerRef)
+	}
+	client := &fake.Clientset{}
+	client.AddReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		eviction := action.(core.CreateAction).GetObject().(*policy.Eviction)
+		if strings.HasPrefix(eviction.Name, "fail") {
+			return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: "pods/eviction"}, eviction.Name, fmt.Errorf("denied"))
+		}
+		return true, nil, nil
+	})
+
+	if budget, err := NewErrorBudget(nil); budget != nil || err != nil {
+		t.Errorf("Expected no budget without configuration, got %v: %v", budget, err)
+	}
+	if _, err := NewErrorBudget(&api.CycleAbort{MaxErrorRatio: 1.5}); err == nil {
+		t.Errorf("Expected an error for a ratio above 1")
+	}
+	minEvictionAttempts := uint(4)
+	budget, err := NewErrorBudget(&api.CycleAbort{MaxErrorRatio: 0.5, MinEvictionAttempts: &minEvictionAttempts})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	podEvictor := NewPodEvictor(client, "v1beta1", false, 0, 0, []*v1.Node{node1}, false, false, false)
+	podEvictor.SetErrorBudget(budget)
+	for _, name := range []string{"ok1", "fail1", "fail2"} {
+		if _, err := podEvictor.EvictPod(ctx, buildPod(name), node1, ReasonPodLifeTime); err != nil {
+			t.Fatalf("Unexpected error evicting pod %s: %v", name, err)
+		}
+	}
+	if budget.Exhausted() {
+		t.Fatalf("Expected the budget not to be checked before %v attempts", minEvictionAttempts)
+	}
+	if _, err := podEvictor.EvictPod(ctx, buildPod("fail3"), node1, ReasonPodLifeTime); err != nil {
+		t.Fatalf("Unexpected error evicting pod fail3: %v", err)
+	}
+	if !budget.Exhausted() {
+		t.Fatalf("Expected the budget to be exhausted after 3 failures out of 4 attempts")
+	}
+
+	// the budget is shared by the evictors of the cycle
+	otherPodEvictor := NewPodEvictor(client, "v1beta1", false, 0, 0, []*v1.Node{node1}, false, false, false)
+	otherPodEvictor.SetErrorBudget(budget)
+	for _, evictor := range []*PodEvictor{podEvictor, otherPodEvictor} {
+		if success, err := evictor.EvictPod(ctx, buildPod("ok2"), node1, ReasonPodLifeTime); err == nil || success {
+			t.Errorf("Expected pod ok2 not to be evicted once the cycle is aborted, got %v: %v", success, err)
+		}
+	}
+	if podEvictor.TotalEvicted() != 1 {
+		t.Errorf("Expected 1 evicted pod, got %v", podEvictor.TotalEvicted())
+	}
+}
+
+func TestEvictPodLimits(t *testing.T) {
+	ctx := context.Background()
+	node1 := test.BuildTestNode("node1", 1000, 2000, 9, nil)
+	buildPod := func(name, namespace string) *v1.Pod {
+		return test.BuildTestPod(name, 100, 0, node1.Name, func(pod *v1.Pod) {
+			test.SetRSOwnerRef(pod)
+			pod.Namespace = namespace
+		})
+	}
+	uintPtr := func(i uint) *uint { return &i }
+
+	if limiter, err := NewEvictionLimiter("per descheduling cycle", nil); limiter != nil || err != nil {
+		t.Errorf("Expected no limiter without configuration, got %v: %v", limiter, err)
+	}
+	zero := float64(0)
+	if _, err := NewEvictionLimiter("per descheduling cycle", &api.EvictionLimits{EvictionsPerSecond: &zero}); err == nil {
+		t.Errorf("Expected an error for a rate of 0 evictions per second")
+	}
+	cycleLimiter, err := NewEvictionLimiter("per descheduling cycle", &api.EvictionLimits{MaxNoOfPodsToEvictPerCycle: uintPtr(3)})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	strategyLimiter, err := NewEvictionLimiter("per descheduling cycle by strategy PodLifeTime", &api.EvictionLimits{MaxNoOfPodsToEvictPerNamespace: uintPtr(1)})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	podEvictor := NewPodEvictor(&fake.Clientset{}, "v1", false, 0, 0, []*v1.Node{node1}, false, false, false)
+	podEvictor.SetEvictionLimiter(cycleLimiter)
+	podEvictor.SetStrategyEvictionLimiter(strategyLimiter)
+	for _, tc := range []struct {
+		pod     *v1.Pod
+		evicted bool
+	}{
+		{pod: buildPod("p1", "team-a"), evicted: true},
+		{pod: buildPod("p2", "team-a"), evicted: false},
+		{pod: buildPod("p3", "team-b"), evicted: true},
+	} {
+		if evicted, err := podEvictor.EvictPod(ctx, tc.pod, node1, ReasonPodLifeTime); evicted != tc.evicted || err != nil {
+			t.Errorf("Expected pod %s to be evicted: %v, got %v: %v", tc.pod.Name, tc.evicted, evicted, err)
+		}
+	}
+
+	// the limits of the cycle are shared with the other evictors and outlive the strategy
+	podEvictor.SetStrategyEvictionLimiter(nil)
+	otherPodEvictor := NewPodEvictor(&fake.Clientset{}, "v1", false, 0, 0, []*v1.Node{node1}, false, false, false)
+	otherPodEvictor.SetEvictionLimiter(cycleLimiter)
+	if evicted, err := podEvictor.EvictPod(ctx, buildPod("p4", "team-a"), node1, ReasonPodLifeTime); !evicted || err != nil {
+		t.Errorf("Expected pod p4 to be evicted once the strategy limits are removed, got %v: %v", evicted, err)
+	}
+	if evicted, err := otherPodEvictor.EvictPod(ctx, buildPod("p5", "team-c"), node1, ReasonPodLifeTime); evicted || err == nil {
+		t.Errorf("Expected pod p5 not to be evicted once the maximum number of evicted pods of the cycle is reached, got %v: %v", evicted, err)
+	}
+
+	// evictions are paced by the rate limiter, the strategy deadline bounds the wait
+	rate := float64(1)
+	rateLimiter, err := NewEvictionLimiter("per descheduling cycle", &api.EvictionLimits{EvictionsPerSecond: &rate})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	pacedPodEvictor := NewPodEvictor(&fake.Clientset{}, "v1", false, 0, 0, []*v1.Node{node1}, false, false, false)
+	pacedPodEvictor.SetEvictionLimiter(rateLimiter)
+	if evicted, err := pacedPodEvictor.EvictPod(ctx, buildPod("p6", "team-a"), node1, ReasonPodLifeTime); !evicted || err != nil {
+		t.Errorf("Expected pod p6 to be evicted within the burst, got %v: %v", evicted, err)
+	}
+	pacedPodEvictor.SetDeadline(time.Now().Add(100 * time.Millisecond))
+	if evicted, err := pacedPodEvictor.EvictPod(ctx, buildPod("p7", "team-a"), node1, ReasonPodLifeTime); evicted || err == nil {
+		t.Errorf("Expected pod p7 not to be evicted before the deadline of the strategy, got %v: %v", evicted, err)
+	}
+}
+
+func TestPDBImpacts(t *testing.T) {
+	ctx := context.Background()
+	buildPDB := func(name string, selector *metav1.LabelSelector, disruptionsAllowed int32) *policy.PodDisruptionBudget {
+		return &policy.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       policy.PodDisruptionBudgetSpec{Selector: selector},
+			Status:     policy.PodDisruptionBudgetStatus{CurrentHealthy: 3, DesiredHealthy: 2, DisruptionsAllowed: disruptionsAllowed},
+		}
+	}
+	buildEvicted := func(name, owner, app string, dryRun bool) EvictedPod {
+		pod := test.BuildTestPod(name, 100, 0, "node1", func(pod *v1.Pod) {
+			pod.OwnerReferences = []metav1.OwnerReference{{Kind: "ReplicaSet", Name: owner}}
+			pod.Labels = map[string]string{"app": app}
+		})
+		return EvictedPod{Pod: pod, Node: "node1", DryRun: dryRun}
+	}
+
+	client := fake.NewSimpleClientset(
+		buildPDB("web", &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}, 1),
+		buildPDB("db", &metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}}, 1),
+		buildPDB("empty", &metav1.LabelSelector{}, 0),
+	)
+	evictedPods := []EvictedPod{
+		buildEvicted("p1", "web-a", "web", true),
+		buildEvicted("p2", "web-a", "web", true),
+		buildEvicted("p3", "web-b", "web", true),
+		buildEvicted("p4", "db", "db", true),
+		// evicted for real, already accounted for by the PDB status
+		buildEvicted("p5", "db", "db", false),
+	}
+
+	impacts, err := PDBImpacts(ctx, client, evictedPods)
+	if err != nil {
+		t.Fatalf("Unable to preview PDB impacts: %v", err)
+	}
+	expected := []PDBImpact{
+		{Namespace: "default", PDB: "db", OwnerKind: "ReplicaSet", OwnerName: "db", CurrentHealthy: 3, DesiredHealthy: 2, DisruptionsAllowed: 1, Evictions: 1, TotalEvictions: 1},
+		{Namespace: "default", PDB: "web", OwnerKind: "ReplicaSet", OwnerName: "web-a", CurrentHealthy: 3, DesiredHealthy: 2, DisruptionsAllowed: 1, Evictions: 2, TotalEvictions: 3},
+		{Namespace: "default", PDB: "web", OwnerKind: "ReplicaSet", OwnerName: "web-b", CurrentHealthy: 3, DesiredHealthy: 2, DisruptionsAllowed: 1, Evictions: 1, TotalEvictions: 3},
+	}
+	if !reflect.DeepEqual(impacts, expected) {
+		t.Fatalf("Expected PDB impacts %#v, got %#v", expected, impacts)
+	}
+	if impacts[0].Exceeded() || !impacts[1].Exceeded() {
+		t.Errorf("Expected only the web PDB to be exceeded")
+	}
+}
+
+func TestNodeFitFailures(t *testing.T) {
+	node1 := test.BuildTestNode("node1", 1000, 2000, 9, nil)
+	node2 := test.BuildTestNode("node2", 1000, 2000, 9, test.SetNodeUnschedulable)
+	p1 := test.BuildTestPod("p1", 100, 0, node1.Name, test.SetRSOwnerRef)
+	p2 := test.BuildTestPod("p2", 100, 0, node1.Name, test.SetDSOwnerRef)
+	p3 := test.BuildTestPod("p3", 100, 0, node1.Name, func(pod *v1.Pod) {
+		test.SetRSOwnerRef(pod)
+		pod.Annotations = map[string]string{evictPodAnnotationKey: "true"}
+	})
+
+	podEvictor := NewPodEvictor(&fake.Clientset{}, "v1", true, 0, 0, []*v1.Node{node1, node2}, false, false, false)
+	evictable := podEvictor.Evictable(WithNodeFit(true))
+	for _, pod := range []*v1.Pod{p1, p1, p2, p3} {
+		evictable.IsEvictable(pod)
+	}
+
+	// p1 is recorded once, p2 is not evictable as a DaemonSet pod anyway and p3 is evictable through its annotation
+	failures := podEvictor.NodeFitFailures()
+	if len(failures) != 1 || failures[0].Pod.Name != "p1" || !failures[0].DryRun {
+		t.Fatalf("Expected a single node fit failure of p1 in dry run mode, got %#v", failures)
+	}
+	if expected := "0/1 nodes are available: 1 node(s) were unschedulable."; failures[0].Message != expected {
+		t.Errorf("Expected message %q, got %q", expected, failures[0].Message)
+	}
+}
+
+func TestProtectedPods(t *testing.T) {
+	node1 := test.BuildTestNode("node1", 1000, 2000, 9, nil)
+	node2 := test.BuildTestNode("node2", 1000, 2000, 9, nil)
+	buildPod := func(name, namespace, replicaSet, hash string) *v1.Pod {
+		return test.BuildTestPod(name, 100, 0, node1.Name, func(pod *v1.Pod) {
+			pod.Namespace = namespace
+			pod.Labels = map[string]string{appsv1.DefaultDeploymentUniqueLabelKey: hash}
+			pod.Annotations = map[string]string{evictPodAnnotationKey: "true"}
+			controller := true
+			pod.ObjectMeta.OwnerReferences = []metav1.OwnerReference{
+				{Kind: "ReplicaSet", APIVersion: "apps/v1", Name: replicaSet, UID: types.UID(replicaSet), Controller: &controller},
+			}
+		})
+	}
+	withInteractiveSession := func(pod *v1.Pod, age time.Duration) *v1.Pod {
+		pod.Annotations[InteractiveSessionAnnotationKey] = time.Now().Add(age).Format(time.RFC3339)
+		return pod
+	}
+	withCondition := func(pod *v1.Pod, conditionType v1.PodConditionType, status v1.ConditionStatus) *v1.Pod {
+		pod.Status.Conditions = append(pod.Status.Conditions, v1.PodCondition{Type: conditionType, Status: status})
+		return pod
+	}
+	ownPod := buildPod("descheduler-1", "kube-system", "descheduler-5f9c", "5f9c")
+	protected := NewProtectedPods(ownPod, []string{"monitoring/prometheus"}, time.Hour, []api.ProtectedPodCondition{
+		{Type: "backup-in-progress"},
+		{Type: "example.com/drained", Status: v1.ConditionFalse},
+	})
+
+	tests := []struct {
+		description string
+		pod         *v1.Pod
+		protected   bool
+	}{
+		{
+			description: "Pod of the descheduler",
+			pod:         ownPod,
+			protected:   true,
+		},
+		{
+			description: "Other replica of the descheduler",
+			pod:         buildPod("descheduler-2", "kube-system", "descheduler-5f9c", "5f9c"),
+			protected:   true,
+		},
+		{
+			description: "Replica of another revision of the descheduler deployment",
+			pod:         buildPod("descheduler-3", "kube-system", "descheduler-7b8d", "7b8d"),
+			protected:   true,
+		},
+		{
+			description: "Pod of a protected deployment",
+			pod:         buildPod("prometheus-1", "monitoring", "prometheus-6c4f", "6c4f"),
+			protected:   true,
+		},
+		{
+			description: "Pod of a deployment with the same name in another namespace",
+			pod:         buildPod("prometheus-1", "default", "prometheus-6c4f", "6c4f"),
+		},
+		{
+			description: "Pod of another deployment",
+			pod:         buildPod("web-1", "kube-system", "web-8a2e", "8a2e"),
+		},
+		{
+			description: "Pod with a recent interactive session",
+			pod:         withInteractiveSession(buildPod("web-2", "default", "web-8a2e", "8a2e"), -10*time.Minute),
+			protected:   true,
+		},
+		{
+			description: "Pod with an interactive session older than the lookback window",
+			pod:         withInteractiveSession(buildPod("web-3", "default", "web-8a2e", "8a2e"), -2*time.Hour),
+		},
+		{
+			description: "Pod with a protected condition",
+			pod:         withCondition(buildPod("db-1", "default", "db-3d1b", "3d1b"), "backup-in-progress", v1.ConditionTrue),
+			protected:   true,
+		},
+		{
+			description: "Pod with a protected condition of another status",
+			pod:         withCondition(buildPod("db-2", "default", "db-3d1b", "3d1b"), "backup-in-progress", v1.ConditionFalse),
+		},
+		{
+			description: "Pod with a protected condition of the configured status",
+			pod:         withCondition(buildPod("db-3", "default", "db-3d1b", "3d1b"), "example.com/drained", v1.ConditionFalse),
+			protected:   true,
+		},
+	}
+
+	podEvictor := NewPodEvictor(&fake.Clientset{}, "v1", true, 0, 0, []*v1.Node{node1, node2}, false, false, false)
+	podEvictor.SetProtectedPods(protected)
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			if got := protected.IsProtected(tc.pod); got != tc.protected {
+				t.Errorf("Expected pod to be protected: %v, got %v", tc.protected, got)
+			}
+			// protected pods are not evictable despite their eviction annotation
+			if evictable := podEvictor.Evictable().IsEvictable(tc.pod); evictable == tc.protected {
+				t.Errorf("Expected pod to be evictable: %v, got %v", !tc.protected, evictable)
+			}
+			if reasons := podEvictor.Evictable().Explain(tc.pod); (len(reasons) > 0) != tc.protected {
+				t.Errorf("Expected pod to be explained as protected: %v, got %v", tc.protected, reasons)
+			}
+			evicted, err := podEvictor.EvictPod(context.Background(), tc.pod, node1, ReasonRemoveDuplicates)
+			if err != nil {
+				t.Fatalf("Unexpected error evicting pod: %v", err)
+			}
+			if evicted == tc.protected {
+				t.Errorf("Expected pod to be evicted: %v, got %v", !tc.protected, evicted)
+			}
+		})
+	}
+}
+
+func TestValidateProtectedDeployments(t *testing.T) {
+	if err := ValidateProtectedDeployments([]string{"kube-system/descheduler", "monitoring/prometheus"}); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	for _, invalid := range []string{"descheduler", "kube-system/descheduler/1", "Kube-System/descheduler", "/descheduler"} {
+		if err := ValidateProtectedDeployments([]string{invalid}); err == nil {
+			t.Errorf("Expected an error for %q", invalid)
+		}
+	}
+}
+
+func TestValidateProtectedPodConditions(t *testing.T) {
+	if err := ValidateProtectedPodConditions([]api.ProtectedPodCondition{{Type: "backup-in-progress"}, {Type: "example.com/drained", Status: v1.ConditionFalse}}); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	for _, invalid := range []api.ProtectedPodCondition{{Status: v1.ConditionTrue}, {Type: "backup-in-progress", Status: "Yes"}} {
+		if err := ValidateProtectedPodConditions([]api.ProtectedPodCondition{invalid}); err == nil {
+			t.Errorf("Expected protected pod condition %+v to be invalid", invalid)
+		}
+	}
+}
+
+func TestEvictionErrorClass(t *testing.T) {
+	ctx := context.Background()
+	pod := test.BuildTestPod("p1", 400, 0, "node1", nil)
+	podResource := schema.GroupResource{Resource: "pods"}
+	tests := []struct {
+		description string
+		err         error
+		expected    string
+	}{
+		{
+			description: "Eviction blocked by a pod disruption budget",
+			err:         apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 10),
+			expected:    "429",
+		},
+		{
+			description: "Pod already deleted",
+			err:         apierrors.NewNotFound(podResource, pod.Name),
+			expected:    "404",
+		},
+		{
+			description: "Eviction forbidden",
+			err:         apierrors.NewForbidden(podResource, pod.Name, fmt.Errorf("forbidden")),
+			expected:    "4xx",
+		},
+		{
+			description: "API server failure",
+			err:         apierrors.NewInternalError(fmt.Errorf("etcd unavailable")),
+			expected:    "5xx",
+		},
+		{
+			description: "Error without status",
+			err:         fmt.Errorf("connection refused"),
+			expected:    "unknown",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			fakeClient := &fake.Clientset{}
+			fakeClient.Fake.AddReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				return true, nil, tc.err
+			})
+			err := evictPod(ctx, fakeClient, pod, "policy/v1beta1", false)
+			if class := evictionErrorClass(err); class != tc.expected {
+				t.Errorf("Expected the eviction error %v to be classified as %q, got %q", err, tc.expected, class)
+			}
+		})
+	}
+}
+
+func TestPodFilter(t *testing.T) {
+	node1 := test.BuildTestNode("node1", 1000, 2000, 9, nil)
+	buildPod := func(name, namespace string, priority int32, podLabels map[string]string) *v1.Pod {
+		return test.BuildTestPod(name, 100, 0, node1.Name, func(pod *v1.Pod) {
+			test.SetRSOwnerRef(pod)
+			pod.Namespace = namespace
+			pod.Labels = podLabels
+			pod.Spec.Priority = &priority
+		})
+	}
+	teamSelector := func(team string) *metav1.LabelSelector {
+		return &metav1.LabelSelector{MatchLabels: map[string]string{"team": team}}
+	}
+	maxPriority := int32(1000)
+	filter := &api.PodFilter{
+		MaxPriority: &maxPriority,
+		AnyOf: []api.PodFilter{
+			{LabelSelector: teamSelector("a")},
+			{LabelSelector: teamSelector("b")},
+			{Namespaces: []string{"batch"}},
+		},
+		NoneOf: []api.PodFilter{
+			{LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "critical"}}},
+		},
+	}
+
+	tests := []struct {
+		description string
+		pod         *v1.Pod
+		evictable   bool
+	}{
+		{
+			description: "Pod of one of the teams",
+			pod:         buildPod("p1", "default", 0, map[string]string{"team": "a"}),
+			evictable:   true,
+		},
+		{
+			description: "Pod of the other team",
+			pod:         buildPod("p2", "default", 0, map[string]string{"team": "b"}),
+			evictable:   true,
+		},
+		{
+			description: "Pod of another team",
+			pod:         buildPod("p3", "default", 0, map[string]string{"team": "c"}),
+			evictable:   false,
+		},
+		{
+			description: "Pod of another team in an included namespace",
+			pod:         buildPod("p4", "batch", 0, map[string]string{"team": "c"}),
+			evictable:   true,
+		},
+		{
+			description: "Critical pod of one of the teams",
+			pod:         buildPod("p5", "default", 0, map[string]string{"team": "a", "tier": "critical"}),
+			evictable:   false,
+		},
+		{
+			description: "Pod of one of the teams above the maximum priority",
+			pod:         buildPod("p6", "default", 2000, map[string]string{"team": "a"}),
+			evictable:   false,
+		},
+	}
+
+	compiled, err := NewPodFilter(filter)
+	if err != nil {
+		t.Fatalf("Unexpected error compiling the filter: %v", err)
+	}
+	podEvictor := NewPodEvictor(nil, "v1", false, 0, 0, []*v1.Node{node1}, false, false, false)
+	podEvictor.SetPodFilter(compiled)
+	evictable := podEvictor.Evictable()
+	for _, tc := range tests {
+		if evictable.IsEvictable(tc.pod) != tc.evictable {
+			t.Errorf("Test %q failed, expected the pod to be evictable: %v", tc.description, tc.evictable)
+		}
+	}
+
+	if f, err := NewPodFilter(nil); f != nil || err != nil || !f.Matches(tests[2].pod) {
+		t.Errorf("Expected no filter to match every pod, got %v, %v", f, err)
+	}
+	minPriority := int32(2000)
+	for _, invalid := range []*api.PodFilter{
+		{AllOf: []api.PodFilter{{LabelSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "team", Operator: "Unknown"}}}}}},
+		{MinPriority: &minPriority, MaxPriority: &maxPriority},
+	} {
+		if _, err := NewPodFilter(invalid); err == nil {
+			t.Errorf("Expected the filter %+v to be refused", invalid)
+		}
+	}
+}
+
+func TestPodFilterNamespaceSelector(t *testing.T) {
+	filter, err := NewPodFilter(&api.PodFilter{
+		NoneOf: []api.PodFilter{
+			{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "protected"}}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error compiling the filter: %v", err)
+	}
+	if !filter.HasNamespaceSelector() {
+		t.Fatalf("Expected the nested namespace selector to be reported")
+	}
+	filter.SelectNamespaces([]v1.Namespace{
+		{ObjectMeta: metav1.ObjectMeta{Name: "tenant-a", Labels: map[string]string{"tenant": "protected"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "tenant-b", Labels: map[string]string{"tenant": "regular"}}},
+	})
+	for namespace, expected := range map[string]bool{"tenant-a": false, "tenant-b": true, "unknown": true} {
+		pod := test.BuildTestPod("p1", 100, 0, "node1", func(pod *v1.Pod) {
+			pod.Namespace = namespace
+		})
+		if filter.Matches(pod) != expected {
+			t.Errorf("Expected the filter to match the pod of namespace %q: %v", namespace, expected)
+		}
+	}
+
+	if _, err := NewPodFilter(&api.PodFilter{NamespaceSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "tenant", Operator: "Unknown"}}}}); err == nil {
+		t.Errorf("Expected an invalid namespace selector to be refused")
+	}
+}
+
+func TestVisitNode(t *testing.T) {
+	node1 := test.BuildTestNode("node1", 2000, 3000, 10, nil)
+	node2 := test.BuildTestNode("node2", 2000, 3000, 10, nil)
+	podEvictor := NewPodEvictor(fake.NewSimpleClientset(), "v1", false, 0, 0, []*v1.Node{node1, node2}, false, false, false)
+
+	if !podEvictor.VisitNode(node1) || !podEvictor.VisitNode(node1) {
+		t.Errorf("Expected nodes to be visited again without checkpoint")
+	}
+
+	checkpoint := NewNodeCheckpoint()
+	podEvictor.SetNodeCheckpoint(checkpoint)
+	if !podEvictor.VisitNode(node1) {
+		t.Errorf("Expected node1 to be visited a first time")
+	}
+	if podEvictor.VisitNode(node1) {
+		t.Errorf("Expected node1 not to be visited twice")
+	}
+	if !podEvictor.VisitNode(node2) {
+		t.Errorf("Expected node2 to be visited a first time")
+	}
+	if checkpoint.Visited() != 2 {
+		t.Errorf("Expected 2 visited nodes, got %v", checkpoint.Visited())
+	}
+
+	podEvictor.SetNodeCheckpoint(nil)
+	if !podEvictor.VisitNode(node1) {
+		t.Errorf("Expected nodes to be visited again once the checkpoint is removed")
+	}
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+// PodFilter is the compiled form of the eviction filter of the policy, restricting evictions to the pods it matches
+type PodFilter struct {
+	namespaces        sets.String
+	namespaceSelector labels.Selector
+	// selectedNamespaces are the namespaces matching namespaceSelector, set by SelectNamespaces
+	selectedNamespaces sets.String
+	labelSelector      labels.Selector
+	minPriority   *int32
+	maxPriority   *int32
+	allOf         []*PodFilter
+	anyOf         []*PodFilter
+	noneOf        []*PodFilter
+}
+
+// NewPodFilter compiles the given filter, returning nil when no filter is configured
+func NewPodFilter(filter *api.PodFilter) (*PodFilter, error) {
+	if filter == nil {
+		return nil, nil
+	}
+	f := &PodFilter{
+		minPriority: filter.MinPriority,
+		maxPriority: filter.MaxPriority,
+	}
+	if len(filter.Namespaces) > 0 {
+		f.namespaces = sets.NewString(filter.Namespaces...)
+	}
+	if filter.LabelSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(filter.LabelSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid labelSelector: %v", err)
+		}
+		f.labelSelector = selector
+	}
+	if filter.NamespaceSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(filter.NamespaceSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid namespaceSelector: %v", err)
+		}
+		f.namespaceSelector = selector
+	}
+	if f.minPriority != nil && f.maxPriority != nil && *f.minPriority > *f.maxPriority {
+		return nil, fmt.Errorf("minPriority %v is greater than maxPriority %v", *f.minPriority, *f.maxPriority)
+	}
+
+	var err error
+	if f.allOf, err = newPodFilters("allOf", filter.AllOf); err != nil {
+		return nil, err
+	}
+	if f.anyOf, err = newPodFilters("anyOf", filter.AnyOf); err != nil {
+		return nil, err
+	}
+	if f.noneOf, err = newPodFilters("noneOf", filter.NoneOf); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func newPodFilters(field string, filters []api.PodFilter) ([]*PodFilter, error) {
+	var compiled []*PodFilter
+	for i := range filters {
+		f, err := NewPodFilter(&filters[i])
+		if err != nil {
+			return nil, fmt.Errorf("%s[%d]: %v", field, i, err)
+		}
+		compiled = append(compiled, f)
+	}
+	return compiled, nil
+}
+
+// HasNamespaceSelector tells whether the filter or one of its nested filters selects namespaces by labels,
+// requiring SelectNamespaces before matching pods
+func (f *PodFilter) HasNamespaceSelector() bool {
+	if f == nil {
+		return false
+	}
+	if f.namespaceSelector != nil {
+		return true
+	}
+	for _, filters := range [][]*PodFilter{f.allOf, f.anyOf, f.noneOf} {
+		for _, filter := range filters {
+			if filter.HasNamespaceSelector() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// SelectNamespaces resolves the namespace selectors of the filter and its nested filters against the given
+// namespaces, pods of other namespaces do not match a namespace selector
+func (f *PodFilter) SelectNamespaces(namespaces []v1.Namespace) {
+	if f == nil {
+		return
+	}
+	if f.namespaceSelector != nil {
+		f.selectedNamespaces = sets.NewString()
+		for _, namespace := range namespaces {
+			if f.namespaceSelector.Matches(labels.Set(namespace.Labels)) {
+				f.selectedNamespaces.Insert(namespace.Name)
+			}
+		}
+	}
+	for _, filters := range [][]*PodFilter{f.allOf, f.anyOf, f.noneOf} {
+		for _, filter := range filters {
+			filter.SelectNamespaces(namespaces)
+		}
+	}
+}
+
+// Matches checks if the pod matches every condition of the filter, a nil filter matches every pod
+func (f *PodFilter) Matches(pod *v1.Pod) bool {
+	if f == nil {
+		return true
+	}
+	if f.namespaces != nil && !f.namespaces.Has(pod.Namespace) {
+		return false
+	}
+	if f.namespaceSelector != nil && !f.selectedNamespaces.Has(pod.Namespace) {
+		return false
+	}
+	if f.labelSelector != nil && !f.labelSelector.Matches(labels.Set(pod.Labels)) {
+		return false
+	}
+	var priority int32
+	if pod.Spec.Priority != nil {
+		priority = *pod.Spec.Priority
+	}
+	if f.minPriority != nil && priority < *f.minPriority || f.maxPriority != nil && priority > *f.maxPriority {
+		return false
+	}
+	for _, filter := range f.allOf {
+		if !filter.Matches(pod) {
+			return false
+		}
+	}
+	if len(f.anyOf) > 0 {
+		matched := false
+		for _, filter := range f.anyOf {
+			if filter.Matches(pod) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	for _, filter := range f.noneOf {
+		if filter.Matches(pod) {
+			return false
+		}
+	}
+	return true
+}
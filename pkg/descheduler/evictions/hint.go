@@ -0,0 +1,88 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+)
+
+// ReschedulingHintAnnotationKey is set on owners of evicted pods to a JSON encoded ReschedulingHint.
+// It can be consumed by a mutating webhook or a scheduler plugin when replacement pods are created.
+const ReschedulingHintAnnotationKey = "descheduler.alpha.kubernetes.io/rescheduling-hint"
+
+// ReschedulingHint describes where replacements of an evicted pod should be scheduled.
+// Strategies may fill the preferred nodes and zones, the remaining fields are set by the PodEvictor.
+type ReschedulingHint struct {
+	Strategy       string        `json:"strategy"`
+	Cause          EvictionCause `json:"cause"`
+	EvictedPod     string        `json:"evictedPod"`
+	AvoidNodes     []string      `json:"avoidNodes,omitempty"`
+	PreferredNodes []string      `json:"preferredNodes,omitempty"`
+	PreferredZones []string      `json:"preferredZones,omitempty"`
+	Timestamp      metav1.Time   `json:"timestamp"`
+}
+
+// annotateOwners sets the rescheduling hint annotation on all owners of the pod.
+// Failures are only logged as the pod is already evicted.
+func annotateOwners(ctx context.Context, client clientset.Interface, pod *v1.Pod, hint ReschedulingHint) {
+	value, err := json.Marshal(hint)
+	if err != nil {
+		klog.ErrorS(err, "Unable to encode rescheduling hint", "pod", klog.KObj(pod))
+		return
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{ReschedulingHintAnnotationKey: string(value)},
+		},
+	})
+	if err != nil {
+		klog.ErrorS(err, "Unable to encode rescheduling hint patch", "pod", klog.KObj(pod))
+		return
+	}
+	for _, ownerRef := range podutil.OwnerRef(pod) {
+		if err := patchOwner(ctx, client, pod.Namespace, ownerRef, patch); err != nil {
+			klog.ErrorS(err, "Unable to annotate owner with rescheduling hint", "pod", klog.KObj(pod), "kind", ownerRef.Kind, "owner", ownerRef.Name)
+		}
+	}
+}
+
+func patchOwner(ctx context.Context, client clientset.Interface, namespace string, ownerRef metav1.OwnerReference, patch []byte) error {
+	var err error
+	switch ownerRef.Kind {
+	case "ReplicaSet":
+		_, err = client.AppsV1().ReplicaSets(namespace).Patch(ctx, ownerRef.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	case "StatefulSet":
+		_, err = client.AppsV1().StatefulSets(namespace).Patch(ctx, ownerRef.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	case "ReplicationController":
+		_, err = client.CoreV1().ReplicationControllers(namespace).Patch(ctx, ownerRef.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	case "Job":
+		_, err = client.BatchV1().Jobs(namespace).Patch(ctx, ownerRef.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	default:
+		return fmt.Errorf("unsupported owner kind %q", ownerRef.Kind)
+	}
+	return err
+}
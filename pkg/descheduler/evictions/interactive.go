@@ -0,0 +1,42 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+)
+
+// InteractiveSessionAnnotationKey records, in RFC 3339 format, the last time an interactive session (exec, attach
+// or port-forward) was opened to the pod, e.g. by the descheduler webhook or a tool processing audit logs
+const InteractiveSessionAnnotationKey = "descheduler.alpha.kubernetes.io/last-interactive-session"
+
+// hasRecentInteractiveSession checks if an interactive session was opened to the pod within the lookback window
+func hasRecentInteractiveSession(pod *v1.Pod, lookback time.Duration, now time.Time) bool {
+	value, ok := pod.Annotations[InteractiveSessionAnnotationKey]
+	if !ok || lookback <= 0 {
+		return false
+	}
+	last, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		klog.V(3).InfoS("Invalid interactive session annotation, ignoring it", "pod", klog.KObj(pod), "value", value)
+		return false
+	}
+	return now.Sub(last) < lookback
+}
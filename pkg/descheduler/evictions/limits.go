@@ -0,0 +1,101 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/client-go/util/flowcontrol"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+// DefaultEvictionBurst is the number of evictions allowed at once above the rate when not configured
+const DefaultEvictionBurst = 1
+
+// EvictionLimiter counts the evictions of a descheduling cycle, or of a strategy during a cycle, refusing them once
+// the maximum number of evictions, in total or in the namespace of the pod, is reached, and paces them with a token
+// bucket. Evictions in dry run mode are counted but not paced.
+type EvictionLimiter struct {
+	// scope describes the evictions counted by the limiter in errors, e.g. "per descheduling cycle"
+	scope           string
+	maxPerCycle     int
+	maxPerNamespace int
+	rateLimiter     flowcontrol.RateLimiter
+	evicted         int
+	namespaceCount  map[string]int
+}
+
+// NewEvictionLimiter returns the limiter of the evictions described by the scope for the configuration, or nil when
+// not configured
+func NewEvictionLimiter(scope string, config *api.EvictionLimits) (*EvictionLimiter, error) {
+	if config == nil {
+		return nil, nil
+	}
+	l := &EvictionLimiter{scope: scope, namespaceCount: map[string]int{}}
+	if config.MaxNoOfPodsToEvictPerCycle != nil {
+		l.maxPerCycle = int(*config.MaxNoOfPodsToEvictPerCycle)
+	}
+	if config.MaxNoOfPodsToEvictPerNamespace != nil {
+		l.maxPerNamespace = int(*config.MaxNoOfPodsToEvictPerNamespace)
+	}
+	if config.EvictionsPerSecond != nil {
+		if *config.EvictionsPerSecond <= 0 {
+			return nil, fmt.Errorf("evictionsPerSecond must be positive, got %v", *config.EvictionsPerSecond)
+		}
+		burst := DefaultEvictionBurst
+		if config.EvictionBurst != nil {
+			if *config.EvictionBurst == 0 {
+				return nil, fmt.Errorf("evictionBurst must be positive")
+			}
+			burst = int(*config.EvictionBurst)
+		}
+		l.rateLimiter = flowcontrol.NewTokenBucketRateLimiter(float32(*config.EvictionsPerSecond), burst)
+	}
+	return l, nil
+}
+
+// exceeded returns an error once the maximum number of evictions is reached
+func (l *EvictionLimiter) exceeded() error {
+	if l == nil || l.maxPerCycle == 0 || l.evicted < l.maxPerCycle {
+		return nil
+	}
+	return fmt.Errorf("Maximum number %v of evicted pods %s reached", l.maxPerCycle, l.scope)
+}
+
+// namespaceExceeded tells whether the maximum number of evictions in the namespace is reached
+func (l *EvictionLimiter) namespaceExceeded(namespace string) bool {
+	return l != nil && l.maxPerNamespace > 0 && l.namespaceCount[namespace] >= l.maxPerNamespace
+}
+
+// wait blocks until the rate of evictions allows another one
+func (l *EvictionLimiter) wait(ctx context.Context) error {
+	if l == nil || l.rateLimiter == nil {
+		return nil
+	}
+	return l.rateLimiter.Wait(ctx)
+}
+
+// record counts an eviction in the namespace
+func (l *EvictionLimiter) record(namespace string) {
+	if l == nil {
+		return
+	}
+	l.evicted++
+	l.namespaceCount[namespace]++
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+// NodeCheckpoint records the nodes a strategy processing nodes one after the other visited during a descheduling
+// cycle. When the strategy is interrupted by a lost connection to the API server and resumed, the nodes it already
+// visited are skipped so that they are not processed twice within the cycle. The pods of the node being processed
+// when the connection was lost are left to the next cycle.
+type NodeCheckpoint struct {
+	visited map[string]bool
+}
+
+// NewNodeCheckpoint returns a checkpoint without visited nodes
+func NewNodeCheckpoint() *NodeCheckpoint {
+	return &NodeCheckpoint{visited: map[string]bool{}}
+}
+
+// visit records the node and tells whether it was not visited yet, a nil checkpoint lets all nodes be visited
+func (c *NodeCheckpoint) visit(node *v1.Node) bool {
+	if c == nil {
+		return true
+	}
+	if c.visited[node.Name] {
+		return false
+	}
+	c.visited[node.Name] = true
+	return true
+}
+
+// Visited returns the number of visited nodes
+func (c *NodeCheckpoint) Visited() int {
+	if c == nil {
+		return 0
+	}
+	return len(c.visited)
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"context"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	clientset "k8s.io/client-go/kubernetes"
+
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+)
+
+// PDBImpact describes the disruptions of a PodDisruptionBudget the pods of an owner
+// evicted in dry run mode would consume
+type PDBImpact struct {
+	Namespace string
+	PDB       string
+	OwnerKind string
+	OwnerName string
+	// CurrentHealthy, DesiredHealthy and DisruptionsAllowed are the current status of the PDB
+	CurrentHealthy     int32
+	DesiredHealthy     int32
+	DisruptionsAllowed int32
+	// Evictions is the number of evicted pods of the owner covered by the PDB
+	Evictions int
+	// TotalEvictions is the number of evicted pods covered by the PDB, for all owners
+	TotalEvictions int
+}
+
+// Exceeded tells whether the evictions covered by the PDB exceed its allowed disruptions,
+// in which case some of them would be refused by the API server
+func (i PDBImpact) Exceeded() bool {
+	return int32(i.TotalEvictions) > i.DisruptionsAllowed
+}
+
+// PDBImpacts previews the disruptions of PodDisruptionBudgets consumed by the pods evicted
+// in dry run mode, per PDB and owner. PDBs without selector or with an empty selector
+// cover no pod, like the policy/v1beta1 API.
+func PDBImpacts(ctx context.Context, client clientset.Interface, evictedPods []EvictedPod) ([]PDBImpact, error) {
+	namespaces := sets.NewString()
+	for _, evicted := range evictedPods {
+		if evicted.DryRun {
+			namespaces.Insert(evicted.Pod.Namespace)
+		}
+	}
+
+	var impacts []PDBImpact
+	for _, namespace := range namespaces.List() {
+		pdbs, err := client.PolicyV1beta1().PodDisruptionBudgets(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, pdb := range pdbs.Items {
+			if pdb.Spec.Selector == nil {
+				continue
+			}
+			selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+			if err != nil || selector.Empty() {
+				continue
+			}
+
+			byOwner := map[PDBImpact]int{}
+			total := 0
+			for _, evicted := range evictedPods {
+				if !evicted.DryRun || evicted.Pod.Namespace != namespace || !selector.Matches(labels.Set(evicted.Pod.Labels)) {
+					continue
+				}
+				impact := PDBImpact{
+					Namespace:          namespace,
+					PDB:                pdb.Name,
+					CurrentHealthy:     pdb.Status.CurrentHealthy,
+					DesiredHealthy:     pdb.Status.DesiredHealthy,
+					DisruptionsAllowed: pdb.Status.DisruptionsAllowed,
+				}
+				if ownerRefs := podutil.OwnerRef(evicted.Pod); len(ownerRefs) > 0 {
+					impact.OwnerKind = ownerRefs[0].Kind
+					impact.OwnerName = ownerRefs[0].Name
+				}
+				byOwner[impact]++
+				total++
+			}
+			for impact, evictions := range byOwner {
+				impact.Evictions = evictions
+				impact.TotalEvictions = total
+				impacts = append(impacts, impact)
+			}
+		}
+	}
+
+	sort.Slice(impacts, func(i, j int) bool {
+		if impacts[i].Namespace != impacts[j].Namespace {
+			return impacts[i].Namespace < impacts[j].Namespace
+		}
+		if impacts[i].PDB != impacts[j].PDB {
+			return impacts[i].PDB < impacts[j].PDB
+		}
+		if impacts[i].OwnerKind != impacts[j].OwnerKind {
+			return impacts[i].OwnerKind < impacts[j].OwnerKind
+		}
+		return impacts[i].OwnerName < impacts[j].OwnerName
+	})
+	return impacts, nil
+}
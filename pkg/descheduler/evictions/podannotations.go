@@ -0,0 +1,87 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+const (
+	// EvictionCycleAnnotationKey is set on evicted pods, when configured, to the descheduling cycle evicting them
+	EvictionCycleAnnotationKey = "descheduler.alpha.kubernetes.io/eviction-cycle"
+	// EvictionReasonAnnotationKey is set on evicted pods, when configured, to the human readable reason of the
+	// eviction, the same as the message of the eviction event
+	EvictionReasonAnnotationKey = "descheduler.alpha.kubernetes.io/eviction-reason"
+
+	// cycleFormat formats the start of a descheduling cycle as a valid label value
+	cycleFormat = "20060102T150405Z"
+)
+
+// PodAnnotations configures the annotations set on pods right before they are evicted
+type PodAnnotations struct {
+	// Cycle identifies the descheduling cycle evicting the pods
+	Cycle string
+	// Labels also sets the strategy, the cause and the cycle as labels
+	Labels bool
+}
+
+// NewPodAnnotations returns the annotations to set on the pods evicted by the cycle started at cycleStart,
+// nil when the policy does not configure any
+func NewPodAnnotations(config *api.EvictedPodAnnotations, cycleStart time.Time) *PodAnnotations {
+	if config == nil {
+		return nil
+	}
+	return &PodAnnotations{Cycle: cycleStart.UTC().Format(cycleFormat), Labels: config.Labels}
+}
+
+// annotatePod sets the annotations describing the eviction on the pod. Failures are only logged
+// as the eviction is not worth refusing because the workload can not be told why it is shut down.
+func annotatePod(ctx context.Context, client clientset.Interface, pod *v1.Pod, reason EvictionReason, message string, annotations *PodAnnotations) {
+	metadata := map[string]interface{}{
+		"annotations": map[string]string{
+			StrategyAnnotationKey:       reason.Strategy,
+			EvictionCauseAnnotationKey:  string(reason.Cause),
+			EvictionCycleAnnotationKey:  annotations.Cycle,
+			EvictionReasonAnnotationKey: message,
+		},
+	}
+	if annotations.Labels {
+		metadata["labels"] = map[string]string{
+			StrategyAnnotationKey:      reason.Strategy,
+			EvictionCauseAnnotationKey: string(reason.Cause),
+			EvictionCycleAnnotationKey: annotations.Cycle,
+		}
+	}
+	patch, err := json.Marshal(map[string]interface{}{"metadata": metadata})
+	if err != nil {
+		klog.ErrorS(err, "Unable to encode eviction annotations patch", "pod", klog.KObj(pod))
+		return
+	}
+	if _, err := client.CoreV1().Pods(pod.Namespace).Patch(ctx, pod.Name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		klog.ErrorS(err, "Unable to annotate pod with the eviction reason", "pod", klog.KObj(pod))
+	}
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+// ProtectedPods identifies the pods which are not evicted, whatever the strategy and the
+// eviction annotation: the pod of the descheduler, the other pods of its owner, e.g. the leader
+// when running several replicas, the pods of the protected deployments, the pods having a
+// protected condition and, for a while, the pods with a recent interactive session
+type ProtectedPods struct {
+	ownPod *v1.Pod
+	// deployments are the protected deployments, as namespace/name
+	deployments sets.String
+	// interactiveSessionLookback protects pods with an interactive session opened within this duration, 0 disables it
+	interactiveSessionLookback time.Duration
+	// conditions are the pod conditions protecting pods
+	conditions []api.ProtectedPodCondition
+}
+
+// NewProtectedPods protects the given pod of the descheduler, which may be nil when it does not
+// run in a pod, together with the pods of the given deployments, as namespace/name, the pods
+// with an interactive session opened within the lookback window and the pods having any of the
+// given conditions
+func NewProtectedPods(ownPod *v1.Pod, deployments []string, interactiveSessionLookback time.Duration, conditions []api.ProtectedPodCondition) *ProtectedPods {
+	p := &ProtectedPods{
+		ownPod:                     ownPod,
+		deployments:                sets.NewString(deployments...),
+		interactiveSessionLookback: interactiveSessionLookback,
+		conditions:                 conditions,
+	}
+	if ownPod != nil {
+		if name := deploymentName(ownPod); name != "" {
+			p.deployments.Insert(ownPod.Namespace + "/" + name)
+		}
+	}
+	return p
+}
+
+// IsProtected checks if the pod must not be evicted
+func (p *ProtectedPods) IsProtected(pod *v1.Pod) bool {
+	if p == nil {
+		return false
+	}
+	if own := p.ownPod; own != nil && pod.Namespace == own.Namespace {
+		if pod.Name == own.Name {
+			return true
+		}
+		ownController := metav1.GetControllerOf(own)
+		if controller := metav1.GetControllerOf(pod); ownController != nil && controller != nil && controller.UID == ownController.UID {
+			return true
+		}
+	}
+	if name := deploymentName(pod); name != "" && p.deployments.Has(pod.Namespace+"/"+name) {
+		return true
+	}
+	if hasProtectedCondition(pod, p.conditions) {
+		return true
+	}
+	return hasRecentInteractiveSession(pod, p.interactiveSessionLookback, time.Now())
+}
+
+// deploymentName returns the name of the deployment owning the pod through its replica set,
+// derived from the name of the replica set and the pod template hash
+func deploymentName(pod *v1.Pod) string {
+	controller := metav1.GetControllerOf(pod)
+	if controller == nil || controller.Kind != "ReplicaSet" {
+		return ""
+	}
+	hash := pod.Labels[appsv1.DefaultDeploymentUniqueLabelKey]
+	if hash == "" || !strings.HasSuffix(controller.Name, "-"+hash) {
+		return ""
+	}
+	return strings.TrimSuffix(controller.Name, "-"+hash)
+}
+
+// ValidateProtectedDeployments checks the protected deployments are given as namespace/name
+func ValidateProtectedDeployments(deployments []string) error {
+	for _, deployment := range deployments {
+		parts := strings.Split(deployment, "/")
+		if len(parts) != 2 {
+			return fmt.Errorf("protected deployment %q is not formatted as namespace/name", deployment)
+		}
+		errs := append(utilvalidation.IsDNS1123Label(parts[0]), utilvalidation.IsDNS1123Subdomain(parts[1])...)
+		if len(errs) > 0 {
+			return fmt.Errorf("protected deployment %q is not valid: %v", deployment, errs)
+		}
+	}
+	return nil
+}
+
+// hasProtectedCondition checks if the pod has any of the protected conditions
+func hasProtectedCondition(pod *v1.Pod, conditions []api.ProtectedPodCondition) bool {
+	for _, protected := range conditions {
+		status := protected.Status
+		if status == "" {
+			status = v1.ConditionTrue
+		}
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == protected.Type && condition.Status == status {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ValidateProtectedPodConditions checks the protected pod conditions have a type and a valid status
+func ValidateProtectedPodConditions(conditions []api.ProtectedPodCondition) error {
+	for _, condition := range conditions {
+		if condition.Type == "" {
+			return fmt.Errorf("protected pod condition has no type")
+		}
+		switch condition.Status {
+		case "", v1.ConditionTrue, v1.ConditionFalse, v1.ConditionUnknown:
+		default:
+			return fmt.Errorf("protected pod condition %q has invalid status %q", condition.Type, condition.Status)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+const (
+	// StrategyAnnotationKey is set on eviction events, and evicted pods when configured, to the name of the
+	// evicting strategy
+	StrategyAnnotationKey = "descheduler.alpha.kubernetes.io/strategy"
+	// EvictionCauseAnnotationKey is set on eviction events, and evicted pods when configured, to the cause of
+	// the eviction
+	EvictionCauseAnnotationKey = "descheduler.alpha.kubernetes.io/eviction-cause"
+)
+
+// EvictionCause is a machine-readable cause of an eviction.
+// Its values are part of the descheduler API and must not change.
+type EvictionCause string
+
+const (
+	CauseDuplicatePod                     EvictionCause = "DuplicatePod"
+	CauseNodeOverutilized                 EvictionCause = "NodeOverutilized"
+	CauseNodeUnderutilized                EvictionCause = "NodeUnderutilized"
+	CauseInterPodAntiAffinityViolated     EvictionCause = "InterPodAntiAffinityViolated"
+	CauseNodeAffinityViolated             EvictionCause = "NodeAffinityViolated"
+	CauseNodeTaintNotTolerated            EvictionCause = "NodeTaintNotTolerated"
+	CauseTooManyRestarts                  EvictionCause = "TooManyRestarts"
+	CausePodLifeTimeExceeded              EvictionCause = "PodLifeTimeExceeded"
+	CauseTopologySpreadConstraintViolated EvictionCause = "TopologySpreadConstraintViolated"
+	CausePodFailed                        EvictionCause = "PodFailed"
+	CauseAntiColocationViolated           EvictionCause = "AntiColocationViolated"
+	CausePodDensityExceeded               EvictionCause = "PodDensityExceeded"
+	CauseNodeProblemDetected              EvictionCause = "NodeProblemDetected"
+	CauseNodeOvercommitted                EvictionCause = "NodeOvercommitted"
+	CauseFinishedJobExpired               EvictionCause = "FinishedJobExpired"
+	CauseMemoryRequestsExceeded           EvictionCause = "MemoryRequestsExceeded"
+	CausePriorityOutdated                 EvictionCause = "PriorityOutdated"
+	CauseNodeInterrupted                  EvictionCause = "NodeInterrupted"
+	CauseContainerCreationStuck           EvictionCause = "ContainerCreationStuck"
+	CauseImageNotShared                   EvictionCause = "ImageNotShared"
+	CauseNodeNotReady                     EvictionCause = "NodeNotReady"
+	CauseJobIndexDuplicated               EvictionCause = "JobIndexDuplicated"
+	CauseGPUModelNotPreferred             EvictionCause = "GPUModelNotPreferred"
+	CausePodStuckTerminating              EvictionCause = "PodStuckTerminating"
+	CauseNodeRestartChurn                 EvictionCause = "NodeRestartChurn"
+)
+
+// EvictionReason identifies the strategy evicting a pod and the cause of the eviction.
+// It is reported through events, logs and metrics labels.
+type EvictionReason struct {
+	// Strategy is the name of the strategy as configured in the policy
+	Strategy string
+	Cause    EvictionCause
+}
+
+// String returns the reason in the "<strategy>/<cause>" form
+func (r EvictionReason) String() string {
+	return r.Strategy + "/" + string(r.Cause)
+}
+
+var (
+	ReasonRemoveDuplicates                            = EvictionReason{Strategy: "RemoveDuplicates", Cause: CauseDuplicatePod}
+	ReasonLowNodeUtilization                          = EvictionReason{Strategy: "LowNodeUtilization", Cause: CauseNodeOverutilized}
+	ReasonHighNodeUtilization                         = EvictionReason{Strategy: "HighNodeUtilization", Cause: CauseNodeUnderutilized}
+	ReasonRemovePodsViolatingInterPodAntiAffinity     = EvictionReason{Strategy: "RemovePodsViolatingInterPodAntiAffinity", Cause: CauseInterPodAntiAffinityViolated}
+	ReasonRemovePodsViolatingNodeAffinity             = EvictionReason{Strategy: "RemovePodsViolatingNodeAffinity", Cause: CauseNodeAffinityViolated}
+	ReasonRemovePodsViolatingNodeTaints               = EvictionReason{Strategy: "RemovePodsViolatingNodeTaints", Cause: CauseNodeTaintNotTolerated}
+	ReasonRemovePodsHavingTooManyRestarts             = EvictionReason{Strategy: "RemovePodsHavingTooManyRestarts", Cause: CauseTooManyRestarts}
+	ReasonPodLifeTime                                 = EvictionReason{Strategy: "PodLifeTime", Cause: CausePodLifeTimeExceeded}
+	ReasonRemovePodsViolatingTopologySpreadConstraint = EvictionReason{Strategy: "RemovePodsViolatingTopologySpreadConstraint", Cause: CauseTopologySpreadConstraintViolated}
+	ReasonRemoveFailedPods                            = EvictionReason{Strategy: "RemoveFailedPods", Cause: CausePodFailed}
+	ReasonRemovePodsViolatingAntiColocation           = EvictionReason{Strategy: "RemovePodsViolatingAntiColocation", Cause: CauseAntiColocationViolated}
+	ReasonRemovePodsViolatingPodDensity               = EvictionReason{Strategy: "RemovePodsViolatingPodDensity", Cause: CausePodDensityExceeded}
+	ReasonRemovePodsFromNodesWithProblems             = EvictionReason{Strategy: "RemovePodsFromNodesWithProblems", Cause: CauseNodeProblemDetected}
+	ReasonRemovePodsFromOvercommittedNodes            = EvictionReason{Strategy: "RemovePodsFromOvercommittedNodes", Cause: CauseNodeOvercommitted}
+	ReasonRemoveFinishedJobPods                       = EvictionReason{Strategy: "RemoveFinishedJobPods", Cause: CauseFinishedJobExpired}
+	ReasonRemovePodsExceedingMemoryRequests           = EvictionReason{Strategy: "RemovePodsExceedingMemoryRequests", Cause: CauseMemoryRequestsExceeded}
+	ReasonRemovePodsWithOutdatedPriority              = EvictionReason{Strategy: "RemovePodsWithOutdatedPriority", Cause: CausePriorityOutdated}
+	ReasonRemovePodsFromInterruptedNodes              = EvictionReason{Strategy: "RemovePodsFromInterruptedNodes", Cause: CauseNodeInterrupted}
+	ReasonRemovePodsStuckInCreation                   = EvictionReason{Strategy: "RemovePodsStuckInCreation", Cause: CauseContainerCreationStuck}
+	ReasonRemovePodsForImageLocality                  = EvictionReason{Strategy: "RemovePodsForImageLocality", Cause: CauseImageNotShared}
+	ReasonRemovePodsFromNotReadyNodes                 = EvictionReason{Strategy: "RemovePodsFromNotReadyNodes", Cause: CauseNodeNotReady}
+	ReasonRemoveDuplicateJobIndexPods                 = EvictionReason{Strategy: "RemoveDuplicateJobIndexPods", Cause: CauseJobIndexDuplicated}
+	ReasonRemovePodsViolatingGPUModelPreference       = EvictionReason{Strategy: "RemovePodsViolatingGPUModelPreference", Cause: CauseGPUModelNotPreferred}
+	ReasonRemovePodsStuckTerminating                  = EvictionReason{Strategy: "RemovePodsStuckTerminating", Cause: CausePodStuckTerminating}
+	ReasonRemovePodsFromNodesWithRestartChurn         = EvictionReason{Strategy: "RemovePodsFromNodesWithRestartChurn", Cause: CauseNodeRestartChurn}
+)
+
+// reasonAnnotations returns the annotations describing the reason on eviction events
+func reasonAnnotations(reason EvictionReason) map[string]string {
+	return map[string]string{
+		StrategyAnnotationKey:      reason.Strategy,
+		EvictionCauseAnnotationKey: string(reason.Cause),
+	}
+}
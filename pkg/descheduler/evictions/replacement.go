@@ -0,0 +1,150 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"context"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+)
+
+// replacementPollInterval is the interval between two checks for a ready replacement
+var replacementPollInterval = time.Second
+
+// replacementKey identifies an owner of evicted pods across the cluster
+type replacementKey struct {
+	namespace string
+	kind      string
+	name      string
+}
+
+// replicaLabels are set on pods with values specific to the pod or the revision of its owner, replacements do not
+// necessarily share them
+var replicaLabels = []string{appsv1.ControllerRevisionHashLabelKey, appsv1.StatefulSetPodNameLabel}
+
+// pendingReplacement describes the last evicted pod of an owner
+type pendingReplacement struct {
+	pod       string
+	evictedAt time.Time
+	// selector matches the labels replacements share with the evicted pod
+	selector labels.Selector
+	// timedOut is set once waiting for a replacement failed, further pods of the owner are not evicted
+	timedOut bool
+}
+
+// replacementKeys returns keys of all owners of the pod
+func replacementKeys(pod *v1.Pod) []replacementKey {
+	var keys []replacementKey
+	for _, ownerRef := range podutil.OwnerRef(pod) {
+		keys = append(keys, replacementKey{namespace: pod.Namespace, kind: ownerRef.Kind, name: ownerRef.Name})
+	}
+	return keys
+}
+
+// newPendingReplacement describes the pod evicted at the given time
+func newPendingReplacement(pod *v1.Pod, evictedAt time.Time) pendingReplacement {
+	set := labels.Set{}
+	for key, value := range pod.Labels {
+		set[key] = value
+	}
+	for _, key := range replicaLabels {
+		delete(set, key)
+	}
+	return pendingReplacement{pod: pod.Name, evictedAt: evictedAt, selector: labels.SelectorFromSet(set)}
+}
+
+// poll checks the condition every interval until it is met, and returns false when it is not met within the timeout,
+// the deadline of the strategy, or before the context is cancelled
+func (pe *PodEvictor) poll(ctx context.Context, interval, timeout time.Duration, condition func(ctx context.Context) bool) bool {
+	if !pe.deadline.IsZero() {
+		if remaining := pe.deadline.Sub(pe.Now()); remaining < timeout {
+			timeout = remaining
+		}
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return wait.PollImmediateUntil(interval, func() (bool, error) {
+		return condition(ctx), nil
+	}, ctx.Done()) == nil
+}
+
+// waitForReplacements waits, up to replacementReadinessTimeout and within the deadline of the
+// strategy, for every owner of the pod with a previously evicted pod to run a new ready replica.
+// It returns false when any owner has no ready replacement in time.
+func (pe *PodEvictor) waitForReplacements(ctx context.Context, pod *v1.Pod) bool {
+	for _, key := range replacementKeys(pod) {
+		pending, ok := pe.pendingReplacements[key]
+		if !ok {
+			continue
+		}
+		if pending.timedOut {
+			return false
+		}
+		klog.V(2).InfoS("Waiting for a ready replacement of the previously evicted pod", "pod", klog.KObj(pod), "evictedPod", klog.KRef(key.namespace, pending.pod), "timeout", pe.replacementReadinessTimeout)
+		ready := pe.poll(ctx, replacementPollInterval, pe.replacementReadinessTimeout, func(ctx context.Context) bool {
+			return pe.hasReadyReplacement(ctx, key, pending)
+		})
+		if !ready {
+			klog.V(1).InfoS("No ready replacement of the previously evicted pod in time, skipping further pods of the owner", "pod", klog.KObj(pod), "evictedPod", klog.KRef(key.namespace, pending.pod), "ownerKind", key.kind, "ownerName", key.name)
+			pending.timedOut = true
+			pe.pendingReplacements[key] = pending
+			return false
+		}
+		delete(pe.pendingReplacements, key)
+	}
+	return true
+}
+
+// hasReadyReplacement checks whether a ready pod of the owner was created after the eviction
+func (pe *PodEvictor) hasReadyReplacement(ctx context.Context, key replacementKey, pending pendingReplacement) bool {
+	pods, err := pe.client.CoreV1().Pods(key.namespace).List(ctx, metav1.ListOptions{LabelSelector: pending.selector.String()})
+	if err != nil {
+		klog.ErrorS(err, "Unable to list pods to find replacements", "namespace", key.namespace)
+		return false
+	}
+	// creation timestamps have a one second precision
+	evictedAt := pending.evictedAt.Truncate(time.Second)
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Name == pending.pod || pod.DeletionTimestamp != nil || pod.CreationTimestamp.Time.Before(evictedAt) || !isPodReady(pod) {
+			continue
+		}
+		for _, k := range replacementKeys(pod) {
+			if k == key {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isPodReady(pod *v1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodReady {
+			return condition.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
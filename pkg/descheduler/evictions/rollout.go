@@ -0,0 +1,130 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+)
+
+// newReplicaSetAvailableReason is the reason of the Progressing condition of a deployment once its rollout completed
+const newReplicaSetAvailableReason = "NewReplicaSetAvailable"
+
+// rollingOutOwner returns the kind and name of the Deployment or StatefulSet owning the pod, through its
+// replica set for deployments, when it is progressing a rollout. The status of an owner is looked up once
+// per evictor, i.e. per descheduling cycle, and an owner whose status can not be read is not rolling out.
+func (pe *PodEvictor) rollingOutOwner(ctx context.Context, pod *v1.Pod) (string, string, bool) {
+	for _, ownerRef := range podutil.OwnerRef(pod) {
+		var key replacementKey
+		switch ownerRef.Kind {
+		case "ReplicaSet":
+			// the deployment is read from the owner references of the replica set rather than guessed from its name
+			rs, err := pe.client.AppsV1().ReplicaSets(pod.Namespace).Get(ctx, ownerRef.Name, metav1.GetOptions{})
+			if err != nil {
+				klog.V(3).InfoS("Unable to get the replica set of the pod", "pod", klog.KObj(pod), "replicaSet", ownerRef.Name, "err", err)
+				continue
+			}
+			controller := metav1.GetControllerOf(rs)
+			if controller == nil || controller.Kind != "Deployment" {
+				continue
+			}
+			key = replacementKey{namespace: pod.Namespace, kind: controller.Kind, name: controller.Name}
+		case "StatefulSet":
+			key = replacementKey{namespace: pod.Namespace, kind: ownerRef.Kind, name: ownerRef.Name}
+		default:
+			continue
+		}
+
+		rollingOut, ok := pe.rollingOutOwners[key]
+		if !ok {
+			rollingOut = pe.isRollingOut(ctx, key)
+			pe.rollingOutOwners[key] = rollingOut
+		}
+		if rollingOut {
+			return key.kind, key.name, true
+		}
+	}
+	return "", "", false
+}
+
+// isRollingOut reads the status of the deployment or stateful set
+func (pe *PodEvictor) isRollingOut(ctx context.Context, key replacementKey) bool {
+	switch key.kind {
+	case "Deployment":
+		deployment, err := pe.client.AppsV1().Deployments(key.namespace).Get(ctx, key.name, metav1.GetOptions{})
+		if err != nil {
+			klog.V(3).InfoS("Unable to get the deployment of the pod", "deployment", klog.KRef(key.namespace, key.name), "err", err)
+			return false
+		}
+		return deploymentRollingOut(deployment)
+	case "StatefulSet":
+		statefulSet, err := pe.client.AppsV1().StatefulSets(key.namespace).Get(ctx, key.name, metav1.GetOptions{})
+		if err != nil {
+			klog.V(3).InfoS("Unable to get the stateful set of the pod", "statefulSet", klog.KRef(key.namespace, key.name), "err", err)
+			return false
+		}
+		return statefulSetRollingOut(statefulSet)
+	}
+	return false
+}
+
+// deploymentRollingOut checks whether the deployment controller did not observe the last spec yet or reports
+// the deployment progressing without its new replica set being available. Paused deployments and deployments
+// which exceeded their progress deadline are not rolling out.
+func deploymentRollingOut(deployment *appsv1.Deployment) bool {
+	if deployment.Spec.Paused {
+		return false
+	}
+	if deployment.Status.ObservedGeneration < deployment.Generation {
+		return true
+	}
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing {
+			return condition.Status == v1.ConditionTrue && condition.Reason != newReplicaSetAvailableReason
+		}
+	}
+	return false
+}
+
+// statefulSetRollingOut checks whether the stateful set controller did not observe the last spec yet or did not
+// update all the replicas above the partition to the update revision. Stateful sets updated on delete are only
+// rolled out when their pods are deleted, they are never rolling out.
+func statefulSetRollingOut(statefulSet *appsv1.StatefulSet) bool {
+	if statefulSet.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType {
+		return false
+	}
+	if statefulSet.Status.ObservedGeneration < statefulSet.Generation {
+		return true
+	}
+	if statefulSet.Status.UpdateRevision == "" || statefulSet.Status.CurrentRevision == statefulSet.Status.UpdateRevision {
+		return false
+	}
+	replicas := int32(1)
+	if statefulSet.Spec.Replicas != nil {
+		replicas = *statefulSet.Spec.Replicas
+	}
+	if rollingUpdate := statefulSet.Spec.UpdateStrategy.RollingUpdate; rollingUpdate != nil && rollingUpdate.Partition != nil {
+		replicas -= *rollingUpdate.Partition
+	}
+	return statefulSet.Status.UpdatedReplicas < replicas
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"context"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+)
+
+// volumeDetachPollInterval is the interval between two checks for detached volumes
+var volumeDetachPollInterval = time.Second
+
+// pendingDetach describes the ReadWriteOnce volumes of the last evicted pod of a node
+type pendingDetach struct {
+	pod     string
+	volumes sets.String
+	// timedOut is set once waiting for the volumes failed, further pods with such volumes are not evicted from the node
+	timedOut bool
+}
+
+// readWriteOnceVolumes returns the names of the persistent volumes bound to the ReadWriteOnce claims of the pod
+func (pe *PodEvictor) readWriteOnceVolumes(ctx context.Context, pod *v1.Pod) sets.String {
+	volumes := sets.NewString()
+	for _, volume := range pod.Spec.Volumes {
+		if volume.PersistentVolumeClaim == nil {
+			continue
+		}
+		pvc, err := pe.client.CoreV1().PersistentVolumeClaims(pod.Namespace).Get(ctx, volume.PersistentVolumeClaim.ClaimName, metav1.GetOptions{})
+		if err != nil {
+			klog.ErrorS(err, "Unable to get persistent volume claim of pod", "pod", klog.KObj(pod), "claim", volume.PersistentVolumeClaim.ClaimName)
+			continue
+		}
+		if pvc.Spec.VolumeName == "" {
+			continue
+		}
+		for _, mode := range pvc.Spec.AccessModes {
+			if mode == v1.ReadWriteOnce {
+				volumes.Insert(pvc.Spec.VolumeName)
+				break
+			}
+		}
+	}
+	return volumes
+}
+
+// waitForVolumeDetach waits, up to volumeDetachTimeout and within the deadline of the strategy, for the
+// volumes of the pod previously evicted from the node to be detached. It returns false when they are still
+// attached to the node in time.
+func (pe *PodEvictor) waitForVolumeDetach(ctx context.Context, pod *v1.Pod, node *v1.Node) bool {
+	pending, ok := pe.pendingDetaches[node.Name]
+	if !ok {
+		return true
+	}
+	if pending.timedOut {
+		return false
+	}
+	klog.V(2).InfoS("Waiting for the volumes of the previously evicted pod to be detached", "pod", klog.KObj(pod), "node", klog.KObj(node), "evictedPod", pending.pod, "timeout", pe.volumeDetachTimeout)
+	detached := pe.poll(ctx, volumeDetachPollInterval, pe.volumeDetachTimeout, func(ctx context.Context) bool {
+		return pe.areVolumesDetached(ctx, node, pending.volumes)
+	})
+	if !detached {
+		klog.V(1).InfoS("Volumes of the previously evicted pod not detached in time, skipping further pods with ReadWriteOnce volumes on the node", "pod", klog.KObj(pod), "node", klog.KObj(node), "evictedPod", pending.pod)
+		pending.timedOut = true
+		pe.pendingDetaches[node.Name] = pending
+		return false
+	}
+	delete(pe.pendingDetaches, node.Name)
+	return true
+}
+
+// areVolumesDetached checks whether no volume attachment of the node references the volumes
+func (pe *PodEvictor) areVolumesDetached(ctx context.Context, node *v1.Node, volumes sets.String) bool {
+	attachments, err := pe.client.StorageV1().VolumeAttachments().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		klog.ErrorS(err, "Unable to list volume attachments")
+		return false
+	}
+	for _, attachment := range attachments.Items {
+		if attachment.Spec.NodeName != node.Name || attachment.Spec.Source.PersistentVolumeName == nil {
+			continue
+		}
+		if volumes.Has(*attachment.Spec.Source.PersistentVolumeName) {
+			return false
+		}
+	}
+	return true
+}
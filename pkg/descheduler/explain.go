@@ -0,0 +1,210 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	clientset "k8s.io/client-go/kubernetes"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/validation"
+)
+
+// StrategyExplanation tells if a strategy would evict a pod and why
+type StrategyExplanation struct {
+	// Strategy is the name of the strategy, prefixed by the name of its profile for policies with profiles
+	Strategy api.StrategyName
+	Evicted  bool
+	// Reasons are the cause of the eviction, or the reasons the pod is not evicted
+	Reasons []string
+}
+
+// Explain runs the strategies enabled in the profiles of the policy in dry run mode against the live cluster and
+// explains, for each of them, if the given pod would be evicted. Every strategy runs with its own
+// evictor so that the evictions of a strategy do not prevent those of the next ones, and without
+// the per node limits of the policy. Namespace rules are not explained.
+func Explain(ctx context.Context, client clientset.Interface, policy *api.DeschedulerPolicy, evictionPolicyGroupVersion, namespace, name string) ([]StrategyExplanation, error) {
+	pod, err := client.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	stopChannel := make(chan struct{})
+	defer close(stopChannel)
+	sharedInformerFactory := informers.NewSharedInformerFactory(client, 0)
+	nodeInformer := sharedInformerFactory.Core().V1().Nodes()
+	nodeInformer.Informer()
+	sharedInformerFactory.Start(stopChannel)
+	sharedInformerFactory.WaitForCacheSync(stopChannel)
+
+	if err := evictions.ValidateProtectedDeployments(policy.ProtectedDeployments); err != nil {
+		return nil, err
+	}
+	if err := evictions.ValidateProtectedPodConditions(policy.ProtectedPodConditions); err != nil {
+		return nil, err
+	}
+	var interactiveSessionLookback time.Duration
+	if policy.InteractiveSessionLookbackSeconds != nil {
+		interactiveSessionLookback = time.Duration(*policy.InteractiveSessionLookbackSeconds) * time.Second
+	}
+	protected := evictions.NewProtectedPods(getOwnPod(ctx, client), policy.ProtectedDeployments, interactiveSessionLookback, policy.ProtectedPodConditions)
+	evictionFilter, err := evictions.NewPodFilter(policy.EvictionFilter)
+	if err != nil {
+		return nil, err
+	}
+	namespaces := newNamespaceSelection(client)
+	if err := namespaces.selectEvictionFilterNamespaces(ctx, evictionFilter); err != nil {
+		return nil, err
+	}
+
+	newPodEvictor := func(nodes []*v1.Node) *evictions.PodEvictor {
+		podEvictor := evictions.NewPodEvictor(
+			client,
+			evictionPolicyGroupVersion,
+			true,
+			0,
+			0,
+			nodes,
+			policy.EvictLocalStoragePods != nil && *policy.EvictLocalStoragePods,
+			policy.EvictSystemCriticalPods != nil && *policy.EvictSystemCriticalPods,
+			policy.IgnorePVCPods != nil && *policy.IgnorePVCPods,
+		)
+		podEvictor.SetProtectedPods(protected)
+		podEvictor.SetPodFilter(evictionFilter)
+		podEvictor.SetSkipOwnersRollingOut(policy.SkipOwnersRollingOut != nil && *policy.SkipOwnersRollingOut)
+		podEvictor.SetEmptyDirSizeLimit(policy.EvictableEmptyDirSizeLimit)
+		return podEvictor
+	}
+
+	funcs := strategyFunctions()
+	var explanations []StrategyExplanation
+	for _, profile := range policyProfiles(policy) {
+		nodeSelector := ""
+		if profile.NodeSelector != nil {
+			nodeSelector = *profile.NodeSelector
+		}
+		nodes, err := nodeutil.ReadyNodes(ctx, client, nodeInformer, nodeSelector)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range strategyOrder(profile.Strategies) {
+			strategy := profile.Strategies[name]
+			f, ok := funcs[name]
+			if !ok || !strategy.Enabled {
+				continue
+			}
+			explanation := StrategyExplanation{Strategy: profileStrategyName(policy, profile.Name, name)}
+			strategy, selected, err := namespaces.selectStrategyNamespaces(ctx, strategy)
+			if err != nil {
+				explanation.Reasons = append(explanation.Reasons, fmt.Sprintf("strategy failed: %v", err))
+				explanations = append(explanations, explanation)
+				continue
+			}
+			if !selected {
+				explanation.Reasons = append(explanation.Reasons, "no namespace matches the namespace selector of the strategy")
+				explanations = append(explanations, explanation)
+				continue
+			}
+			podEvictor := newPodEvictor(nodes)
+			if err := f(ctx, client, strategy, strategyNodes(name, strategy, nodes), podEvictor); err != nil {
+				explanation.Reasons = append(explanation.Reasons, fmt.Sprintf("strategy failed: %v", err))
+			}
+			explainPod(ctx, client, pod, name, strategy, nodes, podEvictor, &explanation)
+			explanations = append(explanations, explanation)
+		}
+	}
+	return explanations, nil
+}
+
+// explainPod fills the explanation with the eviction of the pod by the strategy which just ran
+// with the given evictor, or the reasons it was not evicted
+func explainPod(ctx context.Context, client clientset.Interface, pod *v1.Pod, name api.StrategyName, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor, explanation *StrategyExplanation) {
+	for _, evicted := range podEvictor.EvictedPods() {
+		if evicted.Pod.UID == pod.UID {
+			explanation.Evicted = true
+			explanation.Reasons = append(explanation.Reasons, evicted.Reason.String())
+			return
+		}
+	}
+	for _, failure := range podEvictor.NodeFitFailures() {
+		if failure.Pod.UID == pod.UID {
+			explanation.Reasons = append(explanation.Reasons, failure.Message)
+			return
+		}
+	}
+
+	processed := false
+	for _, node := range strategyNodes(name, strategy, nodes) {
+		if node.Name == pod.Spec.NodeName {
+			processed = true
+			break
+		}
+	}
+	if !processed {
+		explanation.Reasons = append(explanation.Reasons, fmt.Sprintf("node %q is not processed by the strategy", pod.Spec.NodeName))
+		return
+	}
+
+	params, err := validation.ValidateAndParseStrategyParams(ctx, client, strategy.Params)
+	if err != nil {
+		explanation.Reasons = append(explanation.Reasons, fmt.Sprintf("invalid strategy parameters: %v", err))
+		return
+	}
+	if (params.IncludedNamespaces.Len() > 0 && !params.IncludedNamespaces.Has(pod.Namespace)) || params.ExcludedNamespaces.Has(pod.Namespace) {
+		explanation.Reasons = append(explanation.Reasons, fmt.Sprintf("namespace %q is excluded by the strategy parameters", pod.Namespace))
+		return
+	}
+	evictable := podEvictor.Evictable(
+		evictions.WithPriorityThreshold(params.ThresholdPriority),
+		evictions.WithNodeFit(params.NodeFit),
+		evictions.WithLabelSelector(params.LabelSelector),
+	)
+	if reasons := evictable.Explain(pod); len(reasons) > 0 {
+		explanation.Reasons = append(explanation.Reasons, reasons...)
+		return
+	}
+	explanation.Reasons = append(explanation.Reasons, "pod is evictable but not selected by the strategy")
+}
+
+// PrintExplanations writes the explanations as a table
+func PrintExplanations(w io.Writer, pod string, explanations []StrategyExplanation) error {
+	if len(explanations) == 0 {
+		fmt.Fprintf(w, "No strategy is enabled, pod %s would not be evicted\n", pod)
+		return nil
+	}
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "STRATEGY\tRESULT\tREASON")
+	for _, explanation := range explanations {
+		result := "not evicted"
+		if explanation.Evicted {
+			result = "evicted"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", explanation.Strategy, result, strings.Join(explanation.Reasons, "; "))
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,114 @@
+package descheduler
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/utils"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestExplain(t *testing.T) {
+	ctx := context.Background()
+	n1 := test.BuildTestNode("n1", 2000, 3000, 10, func(node *v1.Node) {
+		node.Spec.Taints = []v1.Taint{{Key: "key", Value: "value", Effect: v1.TaintEffectNoSchedule}}
+	})
+	n2 := test.BuildTestNode("n2", 2000, 3000, 10, nil)
+
+	p1 := test.BuildTestPod("p1", 100, 0, n1.Name, test.SetRSOwnerRef)
+	p1.UID = types.UID("p1")
+	p2 := test.BuildTestPod("p2", 100, 0, n1.Name, nil)
+	p2.UID = types.UID("p2")
+	p3 := test.BuildTestPod("p3", 100, 0, n2.Name, test.SetRSOwnerRef)
+	p3.UID = types.UID("p3")
+	p4 := test.BuildTestPod("p4", 100, 0, n1.Name, test.SetRSOwnerRef)
+	p4.UID = types.UID("p4")
+	priority := utils.SystemCriticalPriority
+	p4.Spec.Priority = &priority
+
+	policy := &api.DeschedulerPolicy{
+		Strategies: api.StrategyList{
+			"RemovePodsViolatingNodeTaints": api.DeschedulerStrategy{
+				Enabled: true,
+			},
+			"PodLifeTime": api.DeschedulerStrategy{
+				Enabled: false,
+			},
+		},
+	}
+
+	tests := []struct {
+		description string
+		pod         *v1.Pod
+		evicted     bool
+		reasons     []string
+	}{
+		{
+			description: "pod evicted by the strategy",
+			pod:         p1,
+			evicted:     true,
+			reasons:     []string{"RemovePodsViolatingNodeTaints/NodeTaintNotTolerated"},
+		},
+		{
+			description: "pod failing evictability checks",
+			pod:         p2,
+			reasons:     []string{"pod does not have any ownerrefs"},
+		},
+		{
+			description: "evictable pod not selected by the strategy",
+			pod:         p3,
+			reasons:     []string{"pod is evictable but not selected by the strategy"},
+		},
+		{
+			description: "system critical pod",
+			pod:         p4,
+			reasons:     []string{"pod has system critical priority", "pod has higher priority than specified priority class threshold"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			client := fakeclientset.NewSimpleClientset(n1, n2, p1, p2, p3, p4)
+			explanations, err := Explain(ctx, client, policy, "v1", tc.pod.Namespace, tc.pod.Name)
+			if err != nil {
+				t.Fatalf("Unable to explain the pod: %v", err)
+			}
+			expected := []StrategyExplanation{{Strategy: "RemovePodsViolatingNodeTaints", Evicted: tc.evicted, Reasons: tc.reasons}}
+			if !reflect.DeepEqual(explanations, expected) {
+				t.Errorf("Expected explanations %+v, got %+v", expected, explanations)
+			}
+			pod, err := client.CoreV1().Pods(tc.pod.Namespace).Get(ctx, tc.pod.Name, metav1.GetOptions{})
+			if err != nil || pod == nil {
+				t.Errorf("Expected the pod not to be evicted while explaining it, got %v", err)
+			}
+		})
+	}
+}
+
+func TestPrintExplanations(t *testing.T) {
+	var out bytes.Buffer
+	explanations := []StrategyExplanation{
+		{Strategy: "PodLifeTime", Evicted: true, Reasons: []string{"PodLifeTime/PodLifeTimeExceeded"}},
+		{Strategy: "RemoveDuplicates", Reasons: []string{"pod is protected from evictions"}},
+	}
+	if err := PrintExplanations(&out, "default/p1", explanations); err != nil {
+		t.Fatalf("Unable to print explanations: %v", err)
+	}
+	expected := strings.Join([]string{
+		"STRATEGY          RESULT       REASON",
+		"PodLifeTime       evicted      PodLifeTime/PodLifeTimeExceeded",
+		"RemoveDuplicates  not evicted  pod is protected from evictions",
+		"",
+	}, "\n")
+	if out.String() != expected {
+		t.Errorf("Expected output\n%s\ngot\n%s", expected, out.String())
+	}
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package health
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	clientcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+)
+
+const (
+	// DefaultKubeletRestartsWindowSeconds is used when KubeletRestartsWindowSeconds is not set
+	DefaultKubeletRestartsWindowSeconds = 600
+
+	// kubeletStartingReason is the reason of the event emitted by kubelet when it starts
+	kubeletStartingReason = "Starting"
+
+	// eventNamespace is the namespace the descheduler runs in by default
+	eventNamespace = "kube-system"
+
+	// defaultLeaseDurationSeconds is the lease duration of the kube-scheduler leader election by default
+	defaultLeaseDurationSeconds = 15
+)
+
+// CheckClusterHealth returns an error describing the first exceeded health gate.
+// Nodes are all nodes of the cluster, regardless of their readiness.
+func CheckClusterHealth(ctx context.Context, client clientset.Interface, nodes []*v1.Node, gates *api.HealthGates) error {
+	if gates == nil {
+		return nil
+	}
+
+	if gates.MaxNotReadyNodesPercentage != nil && len(nodes) > 0 {
+		notReady := 0
+		for _, node := range nodes {
+			if !nodeutil.IsReady(node) {
+				notReady++
+			}
+		}
+		percentage := api.Percentage(100 * float64(notReady) / float64(len(nodes)))
+		if percentage > *gates.MaxNotReadyNodesPercentage {
+			return fmt.Errorf("%v%% of nodes are not ready, more than the allowed %v%%", percentage, *gates.MaxNotReadyNodesPercentage)
+		}
+	}
+
+	if gates.MaxPendingPods != nil {
+		pods, err := client.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+			FieldSelector: fields.OneTermEqualSelector("status.phase", string(v1.PodPending)).String(),
+		})
+		if err != nil {
+			return fmt.Errorf("unable to list pending pods: %v", err)
+		}
+		if len(pods.Items) > *gates.MaxPendingPods {
+			return fmt.Errorf("%v pods are pending, more than the allowed %v", len(pods.Items), *gates.MaxPendingPods)
+		}
+	}
+
+	if gates.MaxKubeletRestarts != nil {
+		window := time.Duration(DefaultKubeletRestartsWindowSeconds) * time.Second
+		if gates.KubeletRestartsWindowSeconds != nil {
+			window = time.Duration(*gates.KubeletRestartsWindowSeconds) * time.Second
+		}
+		restarts, err := countKubeletRestarts(ctx, client, time.Now().Add(-window))
+		if err != nil {
+			return err
+		}
+		if restarts > *gates.MaxKubeletRestarts {
+			return fmt.Errorf("%v kubelet restarts during the last %v, more than the allowed %v", restarts, window, *gates.MaxKubeletRestarts)
+		}
+	}
+
+	if gates.SchedulerLease != "" {
+		if err := checkSchedulerLease(ctx, client, gates.SchedulerLease, time.Now()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkSchedulerLease checks the leader election lease of the scheduler is held, its holder having renewed it
+// within its duration. Evicting pods while no scheduler is up would leave them pending until it is back.
+func checkSchedulerLease(ctx context.Context, client clientset.Interface, key string, now time.Time) error {
+	namespace, name := eventNamespace, key
+	if parts := strings.SplitN(key, "/", 2); len(parts) == 2 {
+		namespace, name = parts[0], parts[1]
+	}
+	lease, err := client.CoordinationV1().Leases(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("unable to get scheduler lease %s/%s: %v", namespace, name, err)
+	}
+	if lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity == "" || lease.Spec.RenewTime == nil {
+		return fmt.Errorf("scheduler lease %s/%s is not held", namespace, name)
+	}
+	duration := time.Duration(defaultLeaseDurationSeconds) * time.Second
+	if lease.Spec.LeaseDurationSeconds != nil {
+		duration = time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second
+	}
+	if expiry := lease.Spec.RenewTime.Add(duration); now.After(expiry) {
+		return fmt.Errorf("scheduler lease %s/%s held by %s expired %v ago", namespace, name, *lease.Spec.HolderIdentity, now.Sub(expiry).Round(time.Second))
+	}
+	return nil
+}
+
+// countKubeletRestarts counts kubelet starts reported through node events since the given time
+func countKubeletRestarts(ctx context.Context, client clientset.Interface, since time.Time) (int, error) {
+	events, err := client.CoreV1().Events(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.SelectorFromSet(fields.Set{
+			"involvedObject.kind": "Node",
+			"reason":              kubeletStartingReason,
+		}).String(),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("unable to list node events: %v", err)
+	}
+
+	restarts := 0
+	for _, event := range events.Items {
+		if event.Source.Component != "kubelet" || event.LastTimestamp.Time.Before(since) {
+			continue
+		}
+		if event.Count > 1 {
+			restarts += int(event.Count)
+		} else {
+			restarts++
+		}
+	}
+	return restarts, nil
+}
+
+// RecordUnhealthyCluster emits an event explaining why the descheduling cycle was skipped
+func RecordUnhealthyCluster(client clientset.Interface, reason error) {
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartStructuredLogging(3)
+	eventBroadcaster.StartRecordingToSink(&clientcorev1.EventSinkImpl{Interface: client.CoreV1().Events(eventNamespace)})
+	r := eventBroadcaster.NewRecorder(scheme.Scheme, v1.EventSource{Component: "sigs.k8s.io.descheduler"})
+	r.Event(&v1.ObjectReference{Kind: "Namespace", APIVersion: "v1", Name: eventNamespace, Namespace: eventNamespace}, v1.EventTypeWarning, "DeschedulingHalted", fmt.Sprintf("descheduling cycle skipped, cluster is unhealthy: %v", reason))
+	klog.V(1).InfoS("Cluster is unhealthy, skipping descheduling cycle", "reason", reason)
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package health
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestCheckClusterHealth(t *testing.T) {
+	ctx := context.Background()
+
+	readyNode := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	notReadyNode := test.BuildTestNode("n2", 2000, 3000, 10, func(node *v1.Node) {
+		node.Status.Conditions = []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionFalse}}
+	})
+
+	pendingPod := func(name string) runtime.Object {
+		return test.BuildTestPod(name, 100, 0, "", func(pod *v1.Pod) {
+			pod.Status.Phase = v1.PodPending
+		})
+	}
+	kubeletStarting := func(name, component string, age time.Duration, count int32) runtime.Object {
+		return &v1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: "default"},
+			InvolvedObject: v1.ObjectReference{Kind: "Node", Name: name},
+			Reason:         "Starting",
+			Source:         v1.EventSource{Component: component},
+			LastTimestamp:  metav1.NewTime(time.Now().Add(-age)),
+			Count:          count,
+		}
+	}
+
+	schedulerLease := func(namespace string, age time.Duration) runtime.Object {
+		holder := "master-1"
+		duration := int32(15)
+		renewTime := metav1.NewMicroTime(time.Now().Add(-age))
+		return &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{Name: "kube-scheduler", Namespace: namespace},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       &holder,
+				LeaseDurationSeconds: &duration,
+				RenewTime:            &renewTime,
+			},
+		}
+	}
+
+	percentage := func(p api.Percentage) *api.Percentage { return &p }
+	intPtr := func(i int) *int { return &i }
+	uintPtr := func(i uint) *uint { return &i }
+
+	tests := []struct {
+		description string
+		nodes       []*v1.Node
+		objects     []runtime.Object
+		gates       *api.HealthGates
+		expectedErr error
+	}{
+		{
+			description: "no health gates",
+			nodes:       []*v1.Node{notReadyNode},
+		},
+		{
+			description: "not ready nodes under limit",
+			nodes:       []*v1.Node{readyNode, notReadyNode},
+			gates:       &api.HealthGates{MaxNotReadyNodesPercentage: percentage(50)},
+		},
+		{
+			description: "not ready nodes above limit",
+			nodes:       []*v1.Node{readyNode, notReadyNode},
+			gates:       &api.HealthGates{MaxNotReadyNodesPercentage: percentage(20)},
+			expectedErr: fmt.Errorf("50%% of nodes are not ready, more than the allowed 20%%"),
+		},
+		{
+			description: "pending pods above limit",
+			nodes:       []*v1.Node{readyNode},
+			objects:     []runtime.Object{pendingPod("p1"), pendingPod("p2")},
+			gates:       &api.HealthGates{MaxPendingPods: intPtr(1)},
+			expectedErr: fmt.Errorf("2 pods are pending, more than the allowed 1"),
+		},
+		{
+			description: "pending pods under limit",
+			nodes:       []*v1.Node{readyNode},
+			objects:     []runtime.Object{pendingPod("p1")},
+			gates:       &api.HealthGates{MaxPendingPods: intPtr(1)},
+		},
+		{
+			description: "kubelet restarts above limit",
+			nodes:       []*v1.Node{readyNode},
+			objects: []runtime.Object{
+				kubeletStarting("n1", "kubelet", time.Minute, 2),
+				kubeletStarting("n2", "kubelet", time.Minute, 1),
+			},
+			gates:       &api.HealthGates{MaxKubeletRestarts: intPtr(2)},
+			expectedErr: fmt.Errorf("3 kubelet restarts during the last 10m0s, more than the allowed 2"),
+		},
+		{
+			description: "old kubelet restarts and other components are ignored",
+			nodes:       []*v1.Node{readyNode},
+			objects: []runtime.Object{
+				kubeletStarting("n1", "kubelet", time.Hour, 1),
+				kubeletStarting("n2", "kube-proxy", time.Minute, 1),
+				kubeletStarting("n3", "kubelet", time.Minute, 1),
+			},
+			gates: &api.HealthGates{MaxKubeletRestarts: intPtr(1), KubeletRestartsWindowSeconds: uintPtr(1800)},
+		},
+		{
+			description: "scheduler lease renewed",
+			nodes:       []*v1.Node{readyNode},
+			objects:     []runtime.Object{schedulerLease("kube-system", 5*time.Second)},
+			gates:       &api.HealthGates{SchedulerLease: "kube-scheduler"},
+		},
+		{
+			description: "scheduler lease expired",
+			nodes:       []*v1.Node{readyNode},
+			objects:     []runtime.Object{schedulerLease("scheduling", 2*time.Minute)},
+			gates:       &api.HealthGates{SchedulerLease: "scheduling/kube-scheduler"},
+			expectedErr: fmt.Errorf("scheduler lease scheduling/kube-scheduler held by master-1 expired 1m45s ago"),
+		},
+		{
+			description: "scheduler lease not found",
+			nodes:       []*v1.Node{readyNode},
+			gates:       &api.HealthGates{SchedulerLease: "kube-scheduler"},
+			expectedErr: fmt.Errorf(`unable to get scheduler lease kube-system/kube-scheduler: leases.coordination.k8s.io "kube-scheduler" not found`),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			fakeClient := fake.NewSimpleClientset(tc.objects...)
+			err := CheckClusterHealth(ctx, fakeClient, tc.nodes, tc.gates)
+			if err == nil || tc.expectedErr == nil {
+				if err != tc.expectedErr {
+					t.Errorf("Expected %v, got %v", tc.expectedErr, err)
+				}
+			} else if err.Error() != tc.expectedErr.Error() {
+				t.Errorf("Expected %v, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
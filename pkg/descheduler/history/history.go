@@ -0,0 +1,454 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package history keeps the evictions of the last descheduling cycles, together with
+// the pods replacing the evicted ones, in a ConfigMap used as a ring buffer.
+package history
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/validation"
+)
+
+const (
+	// DefaultNamespace is the namespace of the history ConfigMap when not configured
+	DefaultNamespace = "kube-system"
+	// DefaultName is the name of the history ConfigMap when not configured
+	DefaultName = "descheduler-history"
+	// DefaultMaxCycles is the number of retained cycles when not configured
+	DefaultMaxCycles = 10
+
+	// historyKey is the ConfigMap data key holding the JSON encoded cycles
+	historyKey = "history.json"
+	// maxBytes bounds the encoded cycles below the 1MiB size limit of objects, leaving room for the metadata
+	maxBytes = 1<<20 - 64<<10
+)
+
+// Cycle lists the evictions of a descheduling cycle
+type Cycle struct {
+	Start     metav1.Time `json:"start"`
+	Evictions []Eviction  `json:"evictions"`
+	// NodeFitFailures lists the pods not evicted because they do not fit on any other node
+	NodeFitFailures []NodeFitFailure `json:"nodeFitFailures,omitempty"`
+	// StrategyErrors lists the strategies which failed during the cycle
+	StrategyErrors []StrategyError `json:"strategyErrors,omitempty"`
+	// PDBImpacts previews the PodDisruptionBudget disruptions the evictions in dry run mode would consume
+	PDBImpacts []PDBImpact `json:"pdbImpacts,omitempty"`
+	// Truncated counts the records of the cycle dropped to keep the history within the size limit of ConfigMaps
+	Truncated int `json:"truncated,omitempty"`
+}
+
+// Eviction describes an evicted pod and, once known, the pod replacing it
+type Eviction struct {
+	Namespace string                  `json:"namespace"`
+	Pod       string                  `json:"pod"`
+	Node      string                  `json:"node"`
+	Strategy  string                  `json:"strategy"`
+	Cause     evictions.EvictionCause `json:"cause"`
+	DryRun    bool                    `json:"dryRun,omitempty"`
+	EvictedAt metav1.Time             `json:"evictedAt"`
+	OwnerKind string                  `json:"ownerKind,omitempty"`
+	OwnerName string                  `json:"ownerName,omitempty"`
+	OwnerUID  types.UID               `json:"ownerUID,omitempty"`
+	// ReplacementPod is the first pod of the same owner created after the eviction
+	ReplacementPod string `json:"replacementPod,omitempty"`
+	// ReplacementNode is the node the replacement pod was scheduled to
+	ReplacementNode string `json:"replacementNode,omitempty"`
+}
+
+// NodeFitFailure describes a pod not evicted because it does not fit on any other node
+type NodeFitFailure struct {
+	Namespace string `json:"namespace"`
+	Pod       string `json:"pod"`
+	Node      string `json:"node"`
+	// Message counts the nodes rejected by each criterion, like the scheduler's unschedulable message
+	Message string `json:"message"`
+	DryRun  bool   `json:"dryRun,omitempty"`
+}
+
+// StrategyError describes a strategy which failed during a descheduling cycle
+type StrategyError struct {
+	Strategy string                 `json:"strategy"`
+	Reason   validation.ErrorReason `json:"reason"`
+	Message  string                 `json:"message"`
+}
+
+// PDBImpact describes the disruptions of a PodDisruptionBudget the pods of an owner evicted
+// in dry run mode would consume
+type PDBImpact struct {
+	Namespace          string `json:"namespace"`
+	PDB                string `json:"pdb"`
+	OwnerKind          string `json:"ownerKind,omitempty"`
+	OwnerName          string `json:"ownerName,omitempty"`
+	CurrentHealthy     int32  `json:"currentHealthy"`
+	DesiredHealthy     int32  `json:"desiredHealthy"`
+	DisruptionsAllowed int32  `json:"disruptionsAllowed"`
+	Evictions          int    `json:"evictions"`
+	TotalEvictions     int    `json:"totalEvictions"`
+	// Exceeded is set when the evictions covered by the PDB exceed its allowed disruptions
+	Exceeded bool `json:"exceeded,omitempty"`
+}
+
+// NewCycle converts pods evicted during a descheduling cycle
+func NewCycle(start metav1.Time, evictedPods []evictions.EvictedPod) Cycle {
+	cycle := Cycle{Start: start, Evictions: []Eviction{}}
+	for _, evicted := range evictedPods {
+		eviction := Eviction{
+			Namespace: evicted.Pod.Namespace,
+			Pod:       evicted.Pod.Name,
+			Node:      evicted.Node,
+			Strategy:  evicted.Reason.Strategy,
+			Cause:     evicted.Reason.Cause,
+			DryRun:    evicted.DryRun,
+			EvictedAt: evicted.Time,
+		}
+		if ownerRefs := podutil.OwnerRef(evicted.Pod); len(ownerRefs) > 0 {
+			eviction.OwnerKind = ownerRefs[0].Kind
+			eviction.OwnerName = ownerRefs[0].Name
+			eviction.OwnerUID = ownerRefs[0].UID
+		}
+		cycle.Evictions = append(cycle.Evictions, eviction)
+	}
+	return cycle
+}
+
+// AddNodeFitFailures converts pods not evicted during the cycle because they do not fit on any other node
+func (c *Cycle) AddNodeFitFailures(failures []evictions.NodeFitFailure) {
+	for _, failure := range failures {
+		c.NodeFitFailures = append(c.NodeFitFailures, NodeFitFailure{
+			Namespace: failure.Pod.Namespace,
+			Pod:       failure.Pod.Name,
+			Node:      failure.Pod.Spec.NodeName,
+			Message:   failure.Message,
+			DryRun:    failure.DryRun,
+		})
+	}
+}
+
+// AddStrategyError records the error returned by a strategy during the cycle
+func (c *Cycle) AddStrategyError(strategy api.StrategyName, err error) {
+	c.StrategyErrors = append(c.StrategyErrors, StrategyError{
+		Strategy: string(strategy),
+		Reason:   validation.ReasonForError(err),
+		Message:  err.Error(),
+	})
+}
+
+// AddPDBImpacts converts the PodDisruptionBudget disruptions previewed for the evictions in dry run mode
+func (c *Cycle) AddPDBImpacts(impacts []evictions.PDBImpact) {
+	for _, impact := range impacts {
+		c.PDBImpacts = append(c.PDBImpacts, PDBImpact{
+			Namespace:          impact.Namespace,
+			PDB:                impact.PDB,
+			OwnerKind:          impact.OwnerKind,
+			OwnerName:          impact.OwnerName,
+			CurrentHealthy:     impact.CurrentHealthy,
+			DesiredHealthy:     impact.DesiredHealthy,
+			DisruptionsAllowed: impact.DisruptionsAllowed,
+			Evictions:          impact.Evictions,
+			TotalEvictions:     impact.TotalEvictions,
+			Exceeded:           impact.Exceeded(),
+		})
+	}
+}
+
+// Store reads and writes the history ConfigMap
+type Store struct {
+	client    clientset.Interface
+	namespace string
+	name      string
+	maxCycles int
+	maxBytes  int
+}
+
+// NewStore returns a Store for the given configuration, unset fields are defaulted
+func NewStore(client clientset.Interface, config *api.EvictionHistory) *Store {
+	store := &Store{client: client, namespace: DefaultNamespace, name: DefaultName, maxCycles: DefaultMaxCycles, maxBytes: maxBytes}
+	if config != nil {
+		if config.Namespace != "" {
+			store.namespace = config.Namespace
+		}
+		if config.Name != "" {
+			store.name = config.Name
+		}
+		if config.MaxCycles > 0 {
+			store.maxCycles = config.MaxCycles
+		}
+	}
+	return store
+}
+
+// Load returns the stored cycles, oldest first
+func (s *Store) Load(ctx context.Context) ([]Cycle, error) {
+	cm, err := s.client.CoreV1().ConfigMaps(s.namespace).Get(ctx, s.name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to get history ConfigMap %s/%s: %v", s.namespace, s.name, err)
+	}
+	var cycles []Cycle
+	if data, ok := cm.Data[historyKey]; ok {
+		if err := json.Unmarshal([]byte(data), &cycles); err != nil {
+			return nil, fmt.Errorf("unable to decode history ConfigMap %s/%s: %v", s.namespace, s.name, err)
+		}
+	}
+	return cycles, nil
+}
+
+// Record resolves the replacements of previously evicted pods, appends the cycle
+// and drops the oldest cycles above the configured maximum. The oldest cycles are
+// also dropped, and the records of the cycle truncated if it is too large on its own,
+// to keep the history within the size limit of ConfigMaps.
+func (s *Store) Record(ctx context.Context, cycle Cycle) error {
+	cycles, err := s.Load(ctx)
+	if err != nil {
+		return err
+	}
+	resolveReplacements(ctx, s.client, cycles)
+	cycles = append(cycles, cycle)
+	if len(cycles) > s.maxCycles {
+		cycles = cycles[len(cycles)-s.maxCycles:]
+	}
+
+	data, err := encodeCycles(cycles, s.maxBytes)
+	if err != nil {
+		return fmt.Errorf("unable to encode history: %v", err)
+	}
+	cm, err := s.client.CoreV1().ConfigMaps(s.namespace).Get(ctx, s.name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		cm = &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: s.name, Namespace: s.namespace},
+			Data:       map[string]string{historyKey: string(data)},
+		}
+		_, err = s.client.CoreV1().ConfigMaps(s.namespace).Create(ctx, cm, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("unable to get history ConfigMap %s/%s: %v", s.namespace, s.name, err)
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[historyKey] = string(data)
+	_, err = s.client.CoreV1().ConfigMaps(s.namespace).Update(ctx, cm, metav1.UpdateOptions{})
+	return err
+}
+
+// encodeCycles encodes the cycles within maxBytes, dropping the oldest cycles, then truncating
+// the records of the last cycle until they fit
+func encodeCycles(cycles []Cycle, maxBytes int) ([]byte, error) {
+	// the encoded list is the encoded cycles separated by commas within brackets
+	sizes := make([]int, len(cycles))
+	total := 1
+	for i := range cycles {
+		data, err := json.Marshal(cycles[i])
+		if err != nil {
+			return nil, err
+		}
+		sizes[i] = len(data)
+		total += sizes[i] + 1
+	}
+	for len(cycles) > 1 && total > maxBytes {
+		total -= sizes[0] + 1
+		cycles, sizes = cycles[1:], sizes[1:]
+	}
+	if total <= maxBytes {
+		return json.Marshal(cycles)
+	}
+
+	last := cycles[len(cycles)-1]
+	for {
+		data, err := json.Marshal([]Cycle{last})
+		if err != nil || len(data) <= maxBytes {
+			return data, err
+		}
+		records := len(last.Evictions) + len(last.NodeFitFailures) + len(last.PDBImpacts)
+		if records == 0 {
+			return nil, fmt.Errorf("cycle exceeds %d bytes without records", maxBytes)
+		}
+		// halve every list, the dropped records are counted
+		last.Truncated += records - len(last.Evictions)/2 - len(last.NodeFitFailures)/2 - len(last.PDBImpacts)/2
+		last.Evictions = last.Evictions[:len(last.Evictions)/2]
+		last.NodeFitFailures = last.NodeFitFailures[:len(last.NodeFitFailures)/2]
+		last.PDBImpacts = last.PDBImpacts[:len(last.PDBImpacts)/2]
+	}
+}
+
+// resolveReplacements looks for the pods replacing evicted pods whose replacement is not known yet.
+// A replacement is the oldest pod of the same owner created after the eviction and scheduled to a node.
+func resolveReplacements(ctx context.Context, client clientset.Interface, cycles []Cycle) {
+	podsByNamespace := map[string][]v1.Pod{}
+	// pods already known as replacements, keyed by namespace/name
+	used := map[string]bool{}
+	for _, cycle := range cycles {
+		for _, eviction := range cycle.Evictions {
+			if eviction.ReplacementPod != "" {
+				used[eviction.Namespace+"/"+eviction.ReplacementPod] = true
+			}
+		}
+	}
+	for i := range cycles {
+		for j := range cycles[i].Evictions {
+			eviction := &cycles[i].Evictions[j]
+			if eviction.DryRun || eviction.OwnerUID == "" || eviction.ReplacementPod != "" {
+				continue
+			}
+			pods, ok := podsByNamespace[eviction.Namespace]
+			if !ok {
+				podList, err := client.CoreV1().Pods(eviction.Namespace).List(ctx, metav1.ListOptions{})
+				if err != nil {
+					klog.ErrorS(err, "Unable to list pods to resolve replacements of evicted pods", "namespace", eviction.Namespace)
+					continue
+				}
+				pods = podList.Items
+				podsByNamespace[eviction.Namespace] = pods
+			}
+
+			var replacement *v1.Pod
+			for k := range pods {
+				pod := &pods[k]
+				if used[pod.Namespace+"/"+pod.Name] || pod.Name == eviction.Pod || pod.Spec.NodeName == "" || pod.CreationTimestamp.Before(&eviction.EvictedAt) {
+					continue
+				}
+				if !hasOwner(pod, eviction.OwnerUID) {
+					continue
+				}
+				if replacement == nil || pod.CreationTimestamp.Before(&replacement.CreationTimestamp) {
+					replacement = pod
+				}
+			}
+			if replacement != nil {
+				used[replacement.Namespace+"/"+replacement.Name] = true
+				eviction.ReplacementPod = replacement.Name
+				eviction.ReplacementNode = replacement.Spec.NodeName
+			}
+		}
+	}
+}
+
+func hasOwner(pod *v1.Pod, uid types.UID) bool {
+	for _, ownerRef := range podutil.OwnerRef(pod) {
+		if ownerRef.UID == uid {
+			return true
+		}
+	}
+	return false
+}
+
+// Print writes the evictions of the given cycles as a table, most recent cycle first, followed
+// by the pods not evicted because they do not fit on any other node, the PodDisruptionBudgets
+// affected by evictions in dry run mode, the failed strategies and the truncated records, if any
+func Print(w io.Writer, cycles []Cycle) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "CYCLE\tNAMESPACE\tPOD\tNODE\tSTRATEGY\tCAUSE\tDRY RUN\tREPLACEMENT\tREPLACEMENT NODE")
+	nodeFitFailures, pdbImpacts, strategyErrors, truncated := 0, 0, 0, 0
+	for i := len(cycles) - 1; i >= 0; i-- {
+		start := cycles[i].Start.UTC().Format(time.RFC3339)
+		for _, eviction := range cycles[i].Evictions {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%t\t%s\t%s\n", start, eviction.Namespace, eviction.Pod, eviction.Node,
+				eviction.Strategy, eviction.Cause, eviction.DryRun, valueOrNone(eviction.ReplacementPod), valueOrNone(eviction.ReplacementNode))
+		}
+		nodeFitFailures += len(cycles[i].NodeFitFailures)
+		pdbImpacts += len(cycles[i].PDBImpacts)
+		strategyErrors += len(cycles[i].StrategyErrors)
+		truncated += cycles[i].Truncated
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if nodeFitFailures > 0 {
+		fmt.Fprintln(tw)
+		fmt.Fprintln(tw, "CYCLE\tNAMESPACE\tPOD NOT EVICTED\tNODE\tDRY RUN\tREASON")
+		for i := len(cycles) - 1; i >= 0; i-- {
+			start := cycles[i].Start.UTC().Format(time.RFC3339)
+			for _, failure := range cycles[i].NodeFitFailures {
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%t\t%s\n", start, failure.Namespace, failure.Pod, failure.Node, failure.DryRun, failure.Message)
+			}
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+
+	if pdbImpacts > 0 {
+		fmt.Fprintln(tw)
+		fmt.Fprintln(tw, "CYCLE\tNAMESPACE\tPDB\tOWNER\tDRY RUN EVICTIONS\tPDB EVICTIONS\tDISRUPTIONS ALLOWED\tHEALTHY\tEXCEEDED")
+		for i := len(cycles) - 1; i >= 0; i-- {
+			start := cycles[i].Start.UTC().Format(time.RFC3339)
+			for _, impact := range cycles[i].PDBImpacts {
+				owner := "<none>"
+				if impact.OwnerKind != "" {
+					owner = impact.OwnerKind + "/" + impact.OwnerName
+				}
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%d\t%d\t%d\t%d/%d\t%t\n", start, impact.Namespace, impact.PDB, owner,
+					impact.Evictions, impact.TotalEvictions, impact.DisruptionsAllowed, impact.CurrentHealthy, impact.DesiredHealthy, impact.Exceeded)
+			}
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+
+	if strategyErrors > 0 {
+		fmt.Fprintln(tw)
+		fmt.Fprintln(tw, "CYCLE\tFAILED STRATEGY\tREASON\tMESSAGE")
+		for i := len(cycles) - 1; i >= 0; i-- {
+			start := cycles[i].Start.UTC().Format(time.RFC3339)
+			for _, strategyErr := range cycles[i].StrategyErrors {
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", start, strategyErr.Strategy, strategyErr.Reason, strategyErr.Message)
+			}
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+
+	if truncated > 0 {
+		fmt.Fprintln(tw)
+		fmt.Fprintln(tw, "CYCLE\tTRUNCATED RECORDS")
+		for i := len(cycles) - 1; i >= 0; i-- {
+			if cycles[i].Truncated > 0 {
+				fmt.Fprintf(tw, "%s\t%d\n", cycles[i].Start.UTC().Format(time.RFC3339), cycles[i].Truncated)
+			}
+		}
+	}
+	return tw.Flush()
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return "<none>"
+	}
+	return value
+}
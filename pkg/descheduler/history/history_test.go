@@ -0,0 +1,185 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package history
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestRecord(t *testing.T) {
+	ctx := context.Background()
+	evictedAt := metav1.NewTime(time.Now().Add(-time.Minute))
+
+	setOwner := func(pod *v1.Pod) {
+		pod.OwnerReferences = []metav1.OwnerReference{{Kind: "ReplicaSet", APIVersion: "apps/v1", Name: "rs", UID: "rs-uid"}}
+	}
+	evicted := test.BuildTestPod("p1", 100, 0, "n1", setOwner)
+	replacement := test.BuildTestPod("p2", 100, 0, "n2", func(pod *v1.Pod) {
+		setOwner(pod)
+		pod.CreationTimestamp = metav1.Now()
+	})
+	older := test.BuildTestPod("p3", 100, 0, "n3", func(pod *v1.Pod) {
+		setOwner(pod)
+		pod.CreationTimestamp = metav1.NewTime(evictedAt.Add(-time.Hour))
+	})
+
+	fakeClient := fake.NewSimpleClientset(replacement, older)
+	store := NewStore(fakeClient, &api.EvictionHistory{MaxCycles: 2})
+
+	cycle := NewCycle(evictedAt, []evictions.EvictedPod{
+		{Pod: evicted, Node: "n1", Reason: evictions.ReasonLowNodeUtilization, Time: evictedAt},
+		{Pod: evicted, Node: "n1", Reason: evictions.ReasonPodLifeTime, DryRun: true, Time: evictedAt},
+	})
+	for i := 0; i < 3; i++ {
+		if err := store.Record(ctx, cycle); err != nil {
+			t.Fatalf("Unable to record cycle: %v", err)
+		}
+		cycle = NewCycle(metav1.Now(), nil)
+	}
+
+	cycles, err := store.Load(ctx)
+	if err != nil {
+		t.Fatalf("Unable to load history: %v", err)
+	}
+	if len(cycles) != 2 {
+		t.Fatalf("Expected 2 cycles to be retained, got %v", len(cycles))
+	}
+	// the first cycle was resolved by the second record, then dropped by the third one
+	if len(cycles[0].Evictions) != 0 || len(cycles[1].Evictions) != 0 {
+		t.Errorf("Expected the oldest cycle to be dropped, got %#v", cycles)
+	}
+
+	fakeClient = fake.NewSimpleClientset(replacement, older)
+	store = NewStore(fakeClient, nil)
+	cycle = NewCycle(evictedAt, []evictions.EvictedPod{
+		{Pod: evicted, Node: "n1", Reason: evictions.ReasonLowNodeUtilization, Time: evictedAt},
+		{Pod: evicted, Node: "n1", Reason: evictions.ReasonPodLifeTime, DryRun: true, Time: evictedAt},
+	})
+	cycle.AddNodeFitFailures([]evictions.NodeFitFailure{
+		{Pod: older, Message: "0/2 nodes are available: 2 node(s) were unschedulable.", DryRun: true},
+	})
+	if err := store.Record(ctx, cycle); err != nil {
+		t.Fatalf("Unable to record cycle: %v", err)
+	}
+	if err := store.Record(ctx, NewCycle(metav1.Now(), nil)); err != nil {
+		t.Fatalf("Unable to record cycle: %v", err)
+	}
+	cycles, err = store.Load(ctx)
+	if err != nil {
+		t.Fatalf("Unable to load history: %v", err)
+	}
+	if len(cycles) != 2 || len(cycles[0].Evictions) != 2 {
+		t.Fatalf("Unexpected history %#v", cycles)
+	}
+	if eviction := cycles[0].Evictions[0]; eviction.ReplacementPod != "p2" || eviction.ReplacementNode != "n2" {
+		t.Errorf("Expected p2 on n2 to replace the evicted pod, got %q on %q", eviction.ReplacementPod, eviction.ReplacementNode)
+	}
+	if eviction := cycles[0].Evictions[1]; eviction.ReplacementPod != "" {
+		t.Errorf("Expected no replacement for a pod evicted in dry run mode, got %q", eviction.ReplacementPod)
+	}
+
+	out := &bytes.Buffer{}
+	if err := Print(out, cycles); err != nil {
+		t.Fatalf("Unable to print history: %v", err)
+	}
+	for _, expected := range []string{"REPLACEMENT NODE", "LowNodeUtilization", "NodeOverutilized", "p2", "<none>",
+		"POD NOT EVICTED", "2 node(s) were unschedulable"} {
+		if !strings.Contains(out.String(), expected) {
+			t.Errorf("Expected %q in the printed history:\n%s", expected, out.String())
+		}
+	}
+}
+
+func TestRecordSizeLimit(t *testing.T) {
+	ctx := context.Background()
+	evictedAt := metav1.NewTime(time.Now().Add(-time.Minute))
+	largeCycle := func(count int) Cycle {
+		var evictedPods []evictions.EvictedPod
+		for i := 0; i < count; i++ {
+			pod := test.BuildTestPod(fmt.Sprintf("pod-with-a-rather-long-generated-name-%d", i), 100, 0, "n1", nil)
+			evictedPods = append(evictedPods, evictions.EvictedPod{Pod: pod, Node: "n1", Reason: evictions.ReasonLowNodeUtilization, DryRun: true, Time: evictedAt})
+		}
+		return NewCycle(evictedAt, evictedPods)
+	}
+	storedSize := func(client *fake.Clientset) int {
+		cm, err := client.CoreV1().ConfigMaps(DefaultNamespace).Get(ctx, DefaultName, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("Unable to get history ConfigMap: %v", err)
+		}
+		// the size limit of ConfigMaps applies to their data
+		size := 0
+		for key, value := range cm.Data {
+			size += len(key) + len(value)
+		}
+		return size
+	}
+
+	fakeClient := fake.NewSimpleClientset()
+	store := NewStore(fakeClient, nil)
+	// every cycle takes more than half of the size limit
+	for i := 0; i < 3; i++ {
+		if err := store.Record(ctx, largeCycle(3000)); err != nil {
+			t.Fatalf("Unable to record cycle: %v", err)
+		}
+		if size := storedSize(fakeClient); size >= 1<<20 {
+			t.Fatalf("Expected the data of the history ConfigMap to stay under 1MiB, got %d bytes", size)
+		}
+	}
+	cycles, err := store.Load(ctx)
+	if err != nil {
+		t.Fatalf("Unable to load history: %v", err)
+	}
+	if len(cycles) != 1 || len(cycles[0].Evictions) != 3000 || cycles[0].Truncated != 0 {
+		t.Fatalf("Expected only the last cycle to be retained in full, got %d cycles", len(cycles))
+	}
+
+	// the cycle alone exceeds the size limit
+	if err := store.Record(ctx, largeCycle(20000)); err != nil {
+		t.Fatalf("Unable to record cycle: %v", err)
+	}
+	if size := storedSize(fakeClient); size >= 1<<20 {
+		t.Fatalf("Expected the data of the history ConfigMap to stay under 1MiB, got %d bytes", size)
+	}
+	cycles, err = store.Load(ctx)
+	if err != nil {
+		t.Fatalf("Unable to load history: %v", err)
+	}
+	if len(cycles) != 1 || cycles[0].Truncated == 0 || len(cycles[0].Evictions)+cycles[0].Truncated != 20000 {
+		t.Fatalf("Expected the records of the last cycle to be truncated and counted, got %d cycles", len(cycles))
+	}
+
+	out := &bytes.Buffer{}
+	if err := Print(out, cycles); err != nil {
+		t.Fatalf("Unable to print history: %v", err)
+	}
+	if !strings.Contains(out.String(), "TRUNCATED RECORDS") {
+		t.Errorf("Expected the truncated records in the printed history:\n%s", out.String())
+	}
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package logging adjusts the logs of the descheduler: verbosity overrides while a strategy runs
+// and sampling of messages repeated for every pod or node.
+package logging
+
+import (
+	"flag"
+	"strconv"
+	"sync"
+
+	"k8s.io/klog/v2"
+)
+
+// WithVerbosity runs f with the klog verbosity set to level and restores the verbosity afterwards.
+// Strategies run one at a time, so the override only applies to the logs of a single strategy.
+// f is run with the current verbosity when level is nil or the klog flags are not registered.
+func WithVerbosity(level *int32, f func()) {
+	verbosity := flag.CommandLine.Lookup("v")
+	if level == nil || verbosity == nil {
+		f()
+		return
+	}
+	previous := verbosity.Value.String()
+	if err := verbosity.Value.Set(strconv.Itoa(int(*level))); err != nil {
+		klog.ErrorS(err, "Unable to set the log verbosity", "verbosity", *level)
+		f()
+		return
+	}
+	defer func() {
+		if err := verbosity.Value.Set(previous); err != nil {
+			klog.ErrorS(err, "Unable to restore the log verbosity", "verbosity", previous)
+		}
+	}()
+	f()
+}
+
+// sampler counts the occurrences of sampled messages within a descheduling cycle
+type sampler struct {
+	lock sync.Mutex
+	// initial is the number of occurrences of a message logged before sampling, 0 disables sampling
+	initial int
+	// thereafter is the interval between two logged occurrences once sampling
+	thereafter int
+	counts     map[string]int
+}
+
+var messages = &sampler{counts: make(map[string]int)}
+
+// SetSampling configures sampling: the first initial occurrences of a message within a descheduling
+// cycle are logged, then one every thereafter occurrences. Sampling is disabled when initial is 0,
+// no occurrence is logged after the initial ones when thereafter is 0.
+func SetSampling(initial, thereafter int) {
+	messages.lock.Lock()
+	defer messages.lock.Unlock()
+	messages.initial = initial
+	messages.thereafter = thereafter
+}
+
+// Sampled counts an occurrence of the message and reports whether it should be logged
+func Sampled(msg string) bool {
+	messages.lock.Lock()
+	defer messages.lock.Unlock()
+	if messages.initial <= 0 {
+		return true
+	}
+	messages.counts[msg]++
+	count := messages.counts[msg]
+	if count <= messages.initial {
+		return true
+	}
+	return messages.thereafter > 0 && (count-messages.initial)%messages.thereafter == 0
+}
+
+// InfoS logs the message like v.InfoS when it is sampled. Use it for messages logged for every pod
+// or node, so they can be sampled on large clusters.
+func InfoS(v klog.Verbose, msg string, keysAndValues ...interface{}) {
+	if v.Enabled() && Sampled(msg) {
+		klog.InfoSDepth(1, msg, keysAndValues...)
+	}
+}
+
+// FlushSampling logs how many occurrences of each sampled message were dropped during the
+// descheduling cycle and resets the counts. It is called at the end of every cycle.
+func FlushSampling() {
+	messages.lock.Lock()
+	defer messages.lock.Unlock()
+	for msg, count := range messages.counts {
+		if dropped := count - logged(count, messages.initial, messages.thereafter); dropped > 0 {
+			klog.V(1).InfoS("Dropped repetitive log messages", "message", msg, "occurrences", count, "dropped", dropped)
+		}
+	}
+	messages.counts = make(map[string]int)
+}
+
+// logged returns how many of count occurrences were logged
+func logged(count, initial, thereafter int) int {
+	if count <= initial {
+		return count
+	}
+	if thereafter <= 0 {
+		return initial
+	}
+	return initial + (count-initial)/thereafter
+}
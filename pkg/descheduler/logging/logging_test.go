@@ -0,0 +1,101 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import (
+	"flag"
+	"testing"
+
+	"k8s.io/klog/v2"
+)
+
+func TestSampled(t *testing.T) {
+	tests := []struct {
+		name        string
+		initial     int
+		thereafter  int
+		occurrences int
+		expected    int
+	}{
+		{
+			name:        "sampling disabled",
+			occurrences: 10,
+			expected:    10,
+		},
+		{
+			name:        "initial occurrences then one every thereafter",
+			initial:     3,
+			thereafter:  4,
+			occurrences: 15,
+			expected:    6,
+		},
+		{
+			name:        "only initial occurrences",
+			initial:     2,
+			occurrences: 10,
+			expected:    2,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			SetSampling(tc.initial, tc.thereafter)
+			defer SetSampling(0, 0)
+			defer FlushSampling()
+
+			logged := 0
+			for i := 0; i < tc.occurrences; i++ {
+				if Sampled("message") {
+					logged++
+				}
+			}
+			if logged != tc.expected {
+				t.Errorf("Expected %v logged occurrences, got %v", tc.expected, logged)
+			}
+			// other messages are counted separately
+			if !Sampled("other message") {
+				t.Errorf("Expected the first occurrence of another message to be logged")
+			}
+		})
+	}
+}
+
+func TestWithVerbosity(t *testing.T) {
+	if flag.CommandLine.Lookup("v") == nil {
+		klog.InitFlags(nil)
+	}
+	verbosity := flag.CommandLine.Lookup("v").Value
+	if err := verbosity.Set("2"); err != nil {
+		t.Fatalf("Unable to set the verbosity: %v", err)
+	}
+
+	level := int32(5)
+	WithVerbosity(&level, func() {
+		if !klog.V(5).Enabled() {
+			t.Errorf("Expected verbosity 5 while running the strategy")
+		}
+	})
+	if klog.V(3).Enabled() || !klog.V(2).Enabled() {
+		t.Errorf("Expected verbosity 2 to be restored, got %v", verbosity.String())
+	}
+
+	WithVerbosity(nil, func() {
+		if klog.V(3).Enabled() {
+			t.Errorf("Expected the verbosity not to change")
+		}
+	})
+}
@@ -0,0 +1,214 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package namespacerules reads the DeschedulingRule objects namespace owners create to opt the pods of
+// their namespace into strategies, and turns them into strategies restricted to these namespaces, within
+// the guardrails of the policy.
+package namespacerules
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/api/v1alpha1"
+)
+
+const (
+	podLifeTime     = api.StrategyName("PodLifeTime")
+	tooManyRestarts = api.StrategyName("RemovePodsHavingTooManyRestarts")
+)
+
+var (
+	// Resource is the resource of the namespaced DeschedulingRule custom resource definition
+	Resource = schema.GroupVersionResource{Group: "descheduler.sigs.k8s.io", Version: "v1alpha1", Resource: "deschedulingrules"}
+
+	// DefaultAllowedStrategies are the strategies rules can enable when the policy does not restrict them
+	DefaultAllowedStrategies = []api.StrategyName{podLifeTime, tooManyRestarts}
+)
+
+// DeschedulingRule opts the pods of its namespace into strategies
+type DeschedulingRule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              DeschedulingRuleSpec `json:"spec"`
+}
+
+// DeschedulingRuleSpec enables PodLifeTime when podLifeTime is set and RemovePodsHavingTooManyRestarts when
+// podsHavingTooManyRestarts is set, both with the same parameters as in the policy
+type DeschedulingRuleSpec struct {
+	// LabelSelector restricts the rule to the matching pods of the namespace
+	LabelSelector             *metav1.LabelSelector               `json:"labelSelector,omitempty"`
+	PodLifeTime               *v1alpha1.PodLifeTime               `json:"podLifeTime,omitempty"`
+	PodsHavingTooManyRestarts *v1alpha1.PodsHavingTooManyRestarts `json:"podsHavingTooManyRestarts,omitempty"`
+}
+
+// Strategy is a strategy enabled by a rule, restricted to the namespace of the rule
+type Strategy struct {
+	// Rule is the rule enabling the strategy, as namespace/name
+	Rule     string
+	Name     api.StrategyName
+	Strategy api.DeschedulerStrategy
+}
+
+// List reads the rules of all namespaces and returns the strategies they enable within the guardrails
+func List(ctx context.Context, client dynamic.Interface, guardrails *api.NamespaceRules) ([]Strategy, error) {
+	list, err := client.Resource(Resource).Namespace(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list descheduling rules: %v", err)
+	}
+	rules := make([]DeschedulingRule, 0, len(list.Items))
+	for _, item := range list.Items {
+		var rule DeschedulingRule
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, &rule); err != nil {
+			klog.ErrorS(err, "Skipping invalid descheduling rule", "rule", klog.KRef(item.GetNamespace(), item.GetName()))
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	return Strategies(rules, guardrails), nil
+}
+
+// Strategies returns the strategies enabled by the rules, ordered by rule. Rules of namespaces the guardrails
+// exclude are ignored, the namespace selector of the guardrails is left to resolve to the strategies, strategies the guardrails do not allow or with parameters below their minimum are
+// skipped with an error logged.
+func Strategies(rules []DeschedulingRule, guardrails *api.NamespaceRules) []Strategy {
+	allowed := sets.NewString()
+	for _, name := range DefaultAllowedStrategies {
+		allowed.Insert(string(name))
+	}
+	if len(guardrails.AllowedStrategies) > 0 {
+		allowed = sets.NewString()
+		for _, name := range guardrails.AllowedStrategies {
+			allowed.Insert(string(name))
+		}
+	}
+
+	sort.Slice(rules, func(i, j int) bool {
+		if rules[i].Namespace != rules[j].Namespace {
+			return rules[i].Namespace < rules[j].Namespace
+		}
+		return rules[i].Name < rules[j].Name
+	})
+
+	var strategies []Strategy
+	for _, rule := range rules {
+		if !namespaceAllowed(rule.Namespace, guardrails.Namespaces) {
+			klog.V(3).InfoS("Ignoring descheduling rule of a namespace not allowed by the policy", "rule", klog.KObj(&rule))
+			continue
+		}
+		params := func() *api.StrategyParameters {
+			return &api.StrategyParameters{
+				Namespaces:        &api.Namespaces{Include: []string{rule.Namespace}},
+				NamespaceSelector: guardrails.NamespaceSelector,
+				LabelSelector:     rule.Spec.LabelSelector,
+			}
+		}
+		add := func(name api.StrategyName, params *api.StrategyParameters) {
+			strategies = append(strategies, Strategy{
+				Rule:     rule.Namespace + "/" + rule.Name,
+				Name:     name,
+				Strategy: api.DeschedulerStrategy{Enabled: true, Params: params},
+			})
+		}
+
+		if spec := rule.Spec.PodLifeTime; spec != nil {
+			if err := checkPodLifeTime(spec, allowed, guardrails); err != nil {
+				klog.ErrorS(err, "Skipping strategy of descheduling rule", "rule", klog.KObj(&rule), "strategy", podLifeTime)
+			} else {
+				p := params()
+				p.PodLifeTime = &api.PodLifeTime{}
+				v1alpha1.Convert_v1alpha1_PodLifeTime_To_api_PodLifeTime(spec, p.PodLifeTime, nil)
+				add(podLifeTime, p)
+			}
+		}
+		if spec := rule.Spec.PodsHavingTooManyRestarts; spec != nil {
+			if err := checkTooManyRestarts(spec, allowed, guardrails); err != nil {
+				klog.ErrorS(err, "Skipping strategy of descheduling rule", "rule", klog.KObj(&rule), "strategy", tooManyRestarts)
+			} else {
+				p := params()
+				p.PodsHavingTooManyRestarts = &api.PodsHavingTooManyRestarts{}
+				v1alpha1.Convert_v1alpha1_PodsHavingTooManyRestarts_To_api_PodsHavingTooManyRestarts(spec, p.PodsHavingTooManyRestarts, nil)
+				add(tooManyRestarts, p)
+			}
+		}
+	}
+	return strategies
+}
+
+func checkPodLifeTime(spec *v1alpha1.PodLifeTime, allowed sets.String, guardrails *api.NamespaceRules) error {
+	if !allowed.Has(string(podLifeTime)) {
+		return fmt.Errorf("strategy is not allowed by the policy")
+	}
+	if spec.MaxPodLifeTimeSeconds == nil {
+		return fmt.Errorf("maxPodLifeTimeSeconds not set")
+	}
+	if guardrails.MinPodLifeTimeSeconds != nil && *spec.MaxPodLifeTimeSeconds < *guardrails.MinPodLifeTimeSeconds {
+		return fmt.Errorf("maxPodLifeTimeSeconds %v is below the minimum %v of the policy", *spec.MaxPodLifeTimeSeconds, *guardrails.MinPodLifeTimeSeconds)
+	}
+	return nil
+}
+
+func checkTooManyRestarts(spec *v1alpha1.PodsHavingTooManyRestarts, allowed sets.String, guardrails *api.NamespaceRules) error {
+	if !allowed.Has(string(tooManyRestarts)) {
+		return fmt.Errorf("strategy is not allowed by the policy")
+	}
+	if guardrails.MinPodRestartThreshold != nil && spec.PodRestartThreshold < *guardrails.MinPodRestartThreshold {
+		return fmt.Errorf("podRestartThreshold %v is below the minimum %v of the policy", spec.PodRestartThreshold, *guardrails.MinPodRestartThreshold)
+	}
+	return nil
+}
+
+// namespaceAllowed checks the namespace is included, or not excluded, by the namespaces of the guardrails
+func namespaceAllowed(namespace string, namespaces *api.Namespaces) bool {
+	if namespaces == nil {
+		return true
+	}
+	if len(namespaces.Include) > 0 {
+		return sets.NewString(namespaces.Include...).Has(namespace)
+	}
+	return !sets.NewString(namespaces.Exclude...).Has(namespace)
+}
+
+// ValidateGuardrails checks the guardrails only allow the strategies rules can enable, do not both
+// include and exclude namespaces and have a valid namespace selector
+func ValidateGuardrails(guardrails *api.NamespaceRules) error {
+	if guardrails == nil {
+		return nil
+	}
+	for _, name := range guardrails.AllowedStrategies {
+		if name != podLifeTime && name != tooManyRestarts {
+			return fmt.Errorf("strategy %q can not be enabled by descheduling rules, only %v can", name, DefaultAllowedStrategies)
+		}
+	}
+	if guardrails.Namespaces != nil && len(guardrails.Namespaces.Include) > 0 && len(guardrails.Namespaces.Exclude) > 0 {
+		return fmt.Errorf("only one of Include/Exclude namespaces can be set")
+	}
+	if guardrails.NamespaceSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(guardrails.NamespaceSelector); err != nil {
+			return fmt.Errorf("invalid namespaceSelector: %v", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namespacerules
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/api/v1alpha1"
+)
+
+func TestStrategies(t *testing.T) {
+	hour := uint(3600)
+	minute := uint(60)
+	rule := func(namespace, name string, spec DeschedulingRuleSpec) DeschedulingRule {
+		return DeschedulingRule{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}, Spec: spec}
+	}
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "batch"}}
+	minRestarts := int32(5)
+
+	tests := []struct {
+		description string
+		rules       []DeschedulingRule
+		guardrails  *api.NamespaceRules
+		expected    []Strategy
+	}{
+		{
+			description: "strategies are restricted to the namespace of their rule",
+			rules: []DeschedulingRule{
+				rule("team-b", "restarts", DeschedulingRuleSpec{PodsHavingTooManyRestarts: &v1alpha1.PodsHavingTooManyRestarts{PodRestartThreshold: 10}}),
+				rule("team-a", "lifetime", DeschedulingRuleSpec{LabelSelector: selector, PodLifeTime: &v1alpha1.PodLifeTime{MaxPodLifeTimeSeconds: &hour}}),
+			},
+			guardrails: &api.NamespaceRules{},
+			expected: []Strategy{
+				{Rule: "team-a/lifetime", Name: "PodLifeTime", Strategy: api.DeschedulerStrategy{Enabled: true, Params: &api.StrategyParameters{
+					Namespaces:    &api.Namespaces{Include: []string{"team-a"}},
+					LabelSelector: selector,
+					PodLifeTime:   &api.PodLifeTime{MaxPodLifeTimeSeconds: &hour},
+				}}},
+				{Rule: "team-b/restarts", Name: "RemovePodsHavingTooManyRestarts", Strategy: api.DeschedulerStrategy{Enabled: true, Params: &api.StrategyParameters{
+					Namespaces:                &api.Namespaces{Include: []string{"team-b"}},
+					PodsHavingTooManyRestarts: &api.PodsHavingTooManyRestarts{PodRestartThreshold: 10},
+				}}},
+			},
+		},
+		{
+			description: "strategies breaking the guardrails and rules of excluded namespaces are skipped",
+			rules: []DeschedulingRule{
+				rule("team-a", "short-lifetime", DeschedulingRuleSpec{PodLifeTime: &v1alpha1.PodLifeTime{MaxPodLifeTimeSeconds: &minute}}),
+				rule("team-a", "no-lifetime", DeschedulingRuleSpec{PodLifeTime: &v1alpha1.PodLifeTime{}}),
+				rule("team-a", "restarts", DeschedulingRuleSpec{PodsHavingTooManyRestarts: &v1alpha1.PodsHavingTooManyRestarts{PodRestartThreshold: 3}}),
+				rule("kube-system", "lifetime", DeschedulingRuleSpec{PodLifeTime: &v1alpha1.PodLifeTime{MaxPodLifeTimeSeconds: &hour}}),
+				rule("team-b", "both", DeschedulingRuleSpec{
+					PodLifeTime:               &v1alpha1.PodLifeTime{MaxPodLifeTimeSeconds: &hour},
+					PodsHavingTooManyRestarts: &v1alpha1.PodsHavingTooManyRestarts{PodRestartThreshold: 10},
+				}),
+			},
+			guardrails: &api.NamespaceRules{
+				Namespaces:             &api.Namespaces{Exclude: []string{"kube-system"}},
+				MinPodLifeTimeSeconds:  &hour,
+				MinPodRestartThreshold: &minRestarts,
+				AllowedStrategies:      []api.StrategyName{"PodLifeTime"},
+			},
+			expected: []Strategy{
+				{Rule: "team-b/both", Name: "PodLifeTime", Strategy: api.DeschedulerStrategy{Enabled: true, Params: &api.StrategyParameters{
+					Namespaces:  &api.Namespaces{Include: []string{"team-b"}},
+					PodLifeTime: &api.PodLifeTime{MaxPodLifeTimeSeconds: &hour},
+				}}},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			strategies := Strategies(tc.rules, tc.guardrails)
+			if !reflect.DeepEqual(strategies, tc.expected) {
+				t.Errorf("Expected strategies %+v, got %+v", tc.expected, strategies)
+			}
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	ctx := context.Background()
+	rule := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "descheduler.sigs.k8s.io/v1alpha1",
+		"kind":       "DeschedulingRule",
+		"metadata":   map[string]interface{}{"namespace": "team-a", "name": "restarts"},
+		"spec": map[string]interface{}{
+			"podsHavingTooManyRestarts": map[string]interface{}{"podRestartThreshold": int64(10), "includingInitContainers": true},
+		},
+	}}
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		Resource: "DeschedulingRuleList",
+	}, rule)
+
+	strategies, err := List(ctx, client, &api.NamespaceRules{})
+	if err != nil {
+		t.Fatalf("Unexpected error listing rules: %v", err)
+	}
+	if len(strategies) != 1 || strategies[0].Name != "RemovePodsHavingTooManyRestarts" ||
+		!reflect.DeepEqual(strategies[0].Strategy.Params.PodsHavingTooManyRestarts, &api.PodsHavingTooManyRestarts{PodRestartThreshold: 10, IncludingInitContainers: true}) {
+		t.Errorf("Expected the rule to enable RemovePodsHavingTooManyRestarts, got %+v", strategies)
+	}
+}
+
+func TestValidateGuardrails(t *testing.T) {
+	if err := ValidateGuardrails(&api.NamespaceRules{AllowedStrategies: []api.StrategyName{"PodLifeTime"}}); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if err := ValidateGuardrails(&api.NamespaceRules{AllowedStrategies: []api.StrategyName{"LowNodeUtilization"}}); err == nil {
+		t.Errorf("Expected an error for a strategy rules can not enable")
+	}
+	if err := ValidateGuardrails(&api.NamespaceRules{Namespaces: &api.Namespaces{Include: []string{"a"}, Exclude: []string{"b"}}}); err == nil {
+		t.Errorf("Expected an error for namespaces both included and excluded")
+	}
+	invalidSelector := &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "tenant", Operator: "Unknown"}}}
+	if err := ValidateGuardrails(&api.NamespaceRules{NamespaceSelector: invalidSelector}); err == nil {
+		t.Errorf("Expected an error for an invalid namespace selector")
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	clientset "k8s.io/client-go/kubernetes"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/validation"
+)
+
+// namespaceSelection lists the namespaces at most once per descheduling cycle, when a namespace selector
+// needs their labels, so namespaces created or labeled between cycles are selected by the next one
+type namespaceSelection struct {
+	client     clientset.Interface
+	namespaces []v1.Namespace
+	listed     bool
+}
+
+func newNamespaceSelection(client clientset.Interface) *namespaceSelection {
+	return &namespaceSelection{client: client}
+}
+
+func (s *namespaceSelection) list(ctx context.Context) ([]v1.Namespace, error) {
+	if !s.listed {
+		list, err := s.client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("unable to list namespaces: %v", err)
+		}
+		s.namespaces = list.Items
+		s.listed = true
+	}
+	return s.namespaces, nil
+}
+
+// selected returns the names of the namespaces matching the selector
+func (s *namespaceSelection) selected(ctx context.Context, namespaceSelector *metav1.LabelSelector) (sets.String, error) {
+	selector, err := metav1.LabelSelectorAsSelector(namespaceSelector)
+	if err != nil {
+		return nil, validation.NewStrategyError(validation.ErrorReasonInvalidParameters, "invalid namespaceSelector", err)
+	}
+	namespaces, err := s.list(ctx)
+	if err != nil {
+		return nil, validation.NewStrategyError(validation.ErrorReasonAPI, "unable to select namespaces", err)
+	}
+	selected := sets.NewString()
+	for _, namespace := range namespaces {
+		if selector.Matches(labels.Set(namespace.Labels)) {
+			selected.Insert(namespace.Name)
+		}
+	}
+	return selected, nil
+}
+
+// selectEvictionFilterNamespaces resolves the namespace selectors of the eviction filter
+func (s *namespaceSelection) selectEvictionFilterNamespaces(ctx context.Context, filter *evictions.PodFilter) error {
+	if !filter.HasNamespaceSelector() {
+		return nil
+	}
+	namespaces, err := s.list(ctx)
+	if err != nil {
+		return err
+	}
+	filter.SelectNamespaces(namespaces)
+	return nil
+}
+
+// selectStrategyNamespaces resolves the namespace selector of the strategy into the namespaces it includes, the
+// selected namespaces which are included, or not excluded, by its namespaces. The strategies only handle the
+// resolved namespaces, and false is returned when no namespace is selected, as an empty include list would
+// include every namespace.
+func (s *namespaceSelection) selectStrategyNamespaces(ctx context.Context, strategy api.DeschedulerStrategy) (api.DeschedulerStrategy, bool, error) {
+	if strategy.Params == nil || strategy.Params.NamespaceSelector == nil {
+		return strategy, true, nil
+	}
+	selected, err := s.selected(ctx, strategy.Params.NamespaceSelector)
+	if err != nil {
+		return strategy, false, err
+	}
+	if namespaces := strategy.Params.Namespaces; namespaces != nil {
+		if len(namespaces.Include) > 0 {
+			selected = selected.Intersection(sets.NewString(namespaces.Include...))
+		}
+		selected = selected.Difference(sets.NewString(namespaces.Exclude...))
+	}
+	if selected.Len() == 0 {
+		return strategy, false, nil
+	}
+
+	params := *strategy.Params
+	params.Namespaces = &api.Namespaces{Include: selected.List()}
+	params.NamespaceSelector = nil
+	strategy.Params = &params
+	return strategy, true, nil
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+func TestSelectStrategyNamespaces(t *testing.T) {
+	namespace := func(name, tenant string) *v1.Namespace {
+		return &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"tenant": tenant}}}
+	}
+	client := fake.NewSimpleClientset(
+		namespace("tenant-a", "true"),
+		namespace("tenant-b", "true"),
+		namespace("tenant-c", "true"),
+		namespace("kube-system", "false"),
+	)
+	tenants := &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "true"}}
+
+	tests := []struct {
+		description string
+		params      *api.StrategyParameters
+		selected    bool
+		expected    *api.Namespaces
+	}{
+		{
+			description: "no namespace selector",
+			params:      &api.StrategyParameters{Namespaces: &api.Namespaces{Exclude: []string{"kube-system"}}},
+			selected:    true,
+			expected:    &api.Namespaces{Exclude: []string{"kube-system"}},
+		},
+		{
+			description: "namespace selector",
+			params:      &api.StrategyParameters{NamespaceSelector: tenants},
+			selected:    true,
+			expected:    &api.Namespaces{Include: []string{"tenant-a", "tenant-b", "tenant-c"}},
+		},
+		{
+			description: "namespace selector and included namespaces",
+			params:      &api.StrategyParameters{NamespaceSelector: tenants, Namespaces: &api.Namespaces{Include: []string{"tenant-b", "kube-system"}}},
+			selected:    true,
+			expected:    &api.Namespaces{Include: []string{"tenant-b"}},
+		},
+		{
+			description: "namespace selector and excluded namespaces",
+			params:      &api.StrategyParameters{NamespaceSelector: tenants, Namespaces: &api.Namespaces{Exclude: []string{"tenant-b"}}},
+			selected:    true,
+			expected:    &api.Namespaces{Include: []string{"tenant-a", "tenant-c"}},
+		},
+		{
+			description: "no namespace selected",
+			params:      &api.StrategyParameters{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "unknown"}}},
+			selected:    false,
+		},
+	}
+
+	namespaces := newNamespaceSelection(client)
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			strategy := api.DeschedulerStrategy{Enabled: true, Params: tc.params}
+			resolved, selected, err := namespaces.selectStrategyNamespaces(context.Background(), strategy)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if selected != tc.selected {
+				t.Fatalf("Expected the strategy to select namespaces: %v", tc.selected)
+			}
+			if !selected {
+				return
+			}
+			if resolved.Params.NamespaceSelector != nil {
+				t.Errorf("Expected the namespace selector to be resolved")
+			}
+			if !reflect.DeepEqual(resolved.Params.Namespaces, tc.expected) {
+				t.Errorf("Expected namespaces %+v, got %+v", tc.expected, resolved.Params.Namespaces)
+			}
+		})
+	}
+	if tests[1].params.Namespaces != nil {
+		t.Errorf("Expected the parameters of the policy to be left unchanged")
+	}
+
+	invalid := api.DeschedulerStrategy{Params: &api.StrategyParameters{NamespaceSelector: &metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "tenant", Operator: "Unknown"}},
+	}}}
+	if _, _, err := namespaces.selectStrategyNamespaces(context.Background(), invalid); err == nil {
+		t.Errorf("Expected an invalid namespace selector to be refused")
+	}
+}
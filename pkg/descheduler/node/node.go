@@ -18,6 +18,9 @@ package node
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -28,6 +31,21 @@ import (
 	"sigs.k8s.io/descheduler/pkg/utils"
 )
 
+const (
+	// kwokNodeAnnotationKey is set on nodes managed by kwok
+	kwokNodeAnnotationKey = "kwok.x-k8s.io/node"
+	// nodeTypeLabelKey is set to the provider type on kwok and virtual-kubelet nodes
+	nodeTypeLabelKey = "type"
+	// outOfServiceTaintKey is set on nodes which are shut down and whose pods are to be moved
+	outOfServiceTaintKey = "node.kubernetes.io/out-of-service"
+	// nodeRoleLabelPrefix prefixes the label keys of node roles, e.g. node-role.kubernetes.io/infra
+	nodeRoleLabelPrefix = "node-role.kubernetes.io/"
+	// legacyNodeRoleLabelKey is set to the node role by older installers
+	legacyNodeRoleLabelKey = "kubernetes.io/role"
+	// NodeRoleWorker is the role of nodes without any role label
+	NodeRoleWorker = "worker"
+)
+
 // ReadyNodes returns ready nodes irrespective of whether they are
 // schedulable or not.
 func ReadyNodes(ctx context.Context, client clientset.Interface, nodeInformer coreinformers.NodeInformer, nodeSelector string) ([]*v1.Node, error) {
@@ -69,6 +87,62 @@ func ReadyNodes(ctx context.Context, client clientset.Interface, nodeInformer co
 	return readyNodes, nil
 }
 
+// IsVirtualNode checks if the node is simulated by kwok or backed by a virtual-kubelet provider.
+func IsVirtualNode(node *v1.Node) bool {
+	if _, ok := node.Annotations[kwokNodeAnnotationKey]; ok {
+		return true
+	}
+	switch node.Labels[nodeTypeLabelKey] {
+	case "kwok", "virtual-kubelet":
+		return true
+	}
+	return false
+}
+
+// NodeRoles returns the roles of the node from its node-role.kubernetes.io/<role> and kubernetes.io/role labels.
+// Nodes without any role label are workers, as most installers do not label them.
+func NodeRoles(node *v1.Node) []string {
+	var roles []string
+	for key := range node.Labels {
+		if strings.HasPrefix(key, nodeRoleLabelPrefix) && len(key) > len(nodeRoleLabelPrefix) {
+			roles = append(roles, strings.TrimPrefix(key, nodeRoleLabelPrefix))
+		}
+	}
+	if role := node.Labels[legacyNodeRoleLabelKey]; role != "" {
+		roles = append(roles, role)
+	}
+	if len(roles) == 0 {
+		return []string{NodeRoleWorker}
+	}
+	sort.Strings(roles)
+	return roles
+}
+
+// NodeHasAnyRole checks if the node has any of the given roles
+func NodeHasAnyRole(node *v1.Node, roles []string) bool {
+	for _, nodeRole := range NodeRoles(node) {
+		for _, role := range roles {
+			if nodeRole == role {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsNodeCordoned checks if the node is cordoned or marked out of service, e.g. while being drained.
+func IsNodeCordoned(node *v1.Node) bool {
+	if node.Spec.Unschedulable {
+		return true
+	}
+	for _, taint := range node.Spec.Taints {
+		if taint.Key == outOfServiceTaintKey {
+			return true
+		}
+	}
+	return false
+}
+
 // IsReady checks if the descheduler could run against given node.
 func IsReady(node *v1.Node) bool {
 	for i := range node.Status.Conditions {
@@ -96,11 +170,47 @@ func IsReady(node *v1.Node) bool {
 	return true
 }
 
+// Reasons a pod does not fit on a node, worded like the scheduler's
+const (
+	NodeFitReasonNodeSelector     = "node(s) didn't match Pod's node affinity/selector"
+	NodeFitReasonTaints           = "node(s) had taint(s) that the pod didn't tolerate"
+	NodeFitReasonEphemeralStorage = "Insufficient ephemeral-storage"
+	NodeFitReasonUnschedulable    = "node(s) were unschedulable"
+)
+
+// NodeFitFailures counts, by reason, the nodes a pod does not fit on
+type NodeFitFailures map[string]int
+
+// Message describes the failures like the scheduler describes unschedulable pods,
+// e.g. "0/3 nodes are available: 1 Insufficient ephemeral-storage, 2 node(s) were unschedulable."
+func (f NodeFitFailures) Message() string {
+	total := 0
+	reasons := make([]string, 0, len(f))
+	for reason, count := range f {
+		total += count
+		reasons = append(reasons, fmt.Sprintf("%d %s", count, reason))
+	}
+	sort.Strings(reasons)
+	if len(reasons) == 0 {
+		return "0/0 nodes are available."
+	}
+	return fmt.Sprintf("0/%d nodes are available: %s.", total, strings.Join(reasons, ", "))
+}
+
 // PodFitsAnyOtherNode checks if the given pod fits any of the given nodes, besides the node
 // the pod is already running on. The node fit is based on multiple criteria, like, pod node selector
-// matching the node label (including affinity), the taints on the node, and the node being schedulable or not.
+// matching the node label (including affinity), the taints on the node, the ephemeral storage
+// allocatable on the node, and the node being schedulable or not.
 func PodFitsAnyOtherNode(pod *v1.Pod, nodes []*v1.Node) bool {
+	fits, _ := PodFitsAnyOtherNodeWithFailures(pod, nodes)
+	return fits
+}
 
+// PodFitsAnyOtherNodeWithFailures checks if the given pod fits any of the given nodes like
+// PodFitsAnyOtherNode. When it does not, the first failing criterion of every other node is
+// counted, so callers can explain why the pod cannot be moved.
+func PodFitsAnyOtherNodeWithFailures(pod *v1.Pod, nodes []*v1.Node) (bool, NodeFitFailures) {
+	failures := NodeFitFailures{}
 	for _, node := range nodes {
 		// Skip node pod is already on
 		if node.Name == pod.Spec.NodeName {
@@ -109,6 +219,7 @@ func PodFitsAnyOtherNode(pod *v1.Pod, nodes []*v1.Node) bool {
 		// Check node selector and required affinity
 		ok, err := utils.PodMatchNodeSelector(pod, node)
 		if err != nil || !ok {
+			failures[NodeFitReasonNodeSelector]++
 			continue
 		}
 		// Check taints (we only care about NoSchedule and NoExecute taints)
@@ -116,15 +227,22 @@ func PodFitsAnyOtherNode(pod *v1.Pod, nodes []*v1.Node) bool {
 			return taint.Effect == v1.TaintEffectNoSchedule || taint.Effect == v1.TaintEffectNoExecute
 		})
 		if !ok {
+			failures[NodeFitReasonTaints]++
+			continue
+		}
+		// Check ephemeral storage
+		if !utils.PodFitsEphemeralStorage(pod, node) {
+			failures[NodeFitReasonEphemeralStorage]++
 			continue
 		}
 		// Check if node is schedulable
 		if !IsNodeUnschedulable(node) {
 			klog.V(2).InfoS("Pod can possibly be scheduled on a different node", "pod", klog.KObj(pod), "node", klog.KObj(node))
-			return true
+			return true, nil
 		}
+		failures[NodeFitReasonUnschedulable]++
 	}
-	return false
+	return false, failures
 }
 
 // IsNodeUnschedulable checks if the node is unschedulable. This is a helper function to check only in case of
@@ -18,6 +18,7 @@ package node
 
 import (
 	"context"
+	"reflect"
 	"testing"
 
 	v1 "k8s.io/api/core/v1"
@@ -111,6 +112,118 @@ func TestIsNodeUnschedulable(t *testing.T) {
 
 }
 
+func TestIsVirtualNode(t *testing.T) {
+	tests := []struct {
+		description string
+		node        *v1.Node
+		isVirtual   bool
+	}{
+		{
+			description: "Regular node",
+			node:        test.BuildTestNode("node1", 1000, 2000, 9, nil),
+			isVirtual:   false,
+		},
+		{
+			description: "Node annotated by kwok",
+			node: test.BuildTestNode("node2", 1000, 2000, 9, func(node *v1.Node) {
+				node.Annotations = map[string]string{"kwok.x-k8s.io/node": "fake"}
+			}),
+			isVirtual: true,
+		},
+		{
+			description: "Node labeled as kwok",
+			node: test.BuildTestNode("node3", 1000, 2000, 9, func(node *v1.Node) {
+				node.Labels["type"] = "kwok"
+			}),
+			isVirtual: true,
+		},
+		{
+			description: "Node labeled as virtual-kubelet",
+			node: test.BuildTestNode("node4", 1000, 2000, 9, func(node *v1.Node) {
+				node.Labels["type"] = "virtual-kubelet"
+			}),
+			isVirtual: true,
+		},
+	}
+	for _, test := range tests {
+		if actual := IsVirtualNode(test.node); actual != test.isVirtual {
+			t.Errorf("Test %#v failed, expected %v, got %v", test.description, test.isVirtual, actual)
+		}
+	}
+}
+
+func TestNodeRoles(t *testing.T) {
+	tests := []struct {
+		description string
+		labels      map[string]string
+		roles       []string
+	}{
+		{
+			description: "Node without role label",
+			roles:       []string{"worker"},
+		},
+		{
+			description: "Node with role labels",
+			labels:      map[string]string{"node-role.kubernetes.io/worker": "", "node-role.kubernetes.io/infra": "true"},
+			roles:       []string{"infra", "worker"},
+		},
+		{
+			description: "Node with legacy role label",
+			labels:      map[string]string{"kubernetes.io/role": "master"},
+			roles:       []string{"master"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			node := test.BuildTestNode("node1", 1000, 2000, 9, func(node *v1.Node) {
+				for key, value := range tc.labels {
+					node.Labels[key] = value
+				}
+			})
+			if roles := NodeRoles(node); !reflect.DeepEqual(roles, tc.roles) {
+				t.Errorf("Expected roles %v, got %v", tc.roles, roles)
+			}
+			if !NodeHasAnyRole(node, append([]string{"other"}, tc.roles[0])) {
+				t.Errorf("Expected node to have role %v", tc.roles[0])
+			}
+		})
+	}
+}
+
+func TestIsNodeCordoned(t *testing.T) {
+	tests := []struct {
+		description string
+		node        *v1.Node
+		isCordoned  bool
+	}{
+		{
+			description: "Schedulable node",
+			node:        test.BuildTestNode("node1", 1000, 2000, 9, nil),
+			isCordoned:  false,
+		},
+		{
+			description: "Unschedulable node",
+			node: test.BuildTestNode("node2", 1000, 2000, 9, func(node *v1.Node) {
+				node.Spec.Unschedulable = true
+			}),
+			isCordoned: true,
+		},
+		{
+			description: "Node tainted out of service",
+			node: test.BuildTestNode("node3", 1000, 2000, 9, func(node *v1.Node) {
+				node.Spec.Taints = []v1.Taint{{Key: "node.kubernetes.io/out-of-service", Value: "nodeshutdown", Effect: v1.TaintEffectNoExecute}}
+			}),
+			isCordoned: true,
+		},
+	}
+	for _, test := range tests {
+		if actual := IsNodeCordoned(test.node); actual != test.isCordoned {
+			t.Errorf("Test %#v failed, expected %v, got %v", test.description, test.isCordoned, actual)
+		}
+	}
+}
+
 func TestPodFitsCurrentNode(t *testing.T) {
 
 	nodeLabelKey := "kubernetes.io/desiredNode"
@@ -424,6 +537,38 @@ func TestPodFitsAnyOtherNode(t *testing.T) {
 	}
 }
 
+func TestPodFitsAnyOtherNodeWithFailures(t *testing.T) {
+	nodeLabelKey := "kubernetes.io/desiredNode"
+	nodeLabelValue := "yes"
+	pod := createPodManifest("stagingNode", nodeLabelKey, nodeLabelValue)
+	labels := map[string]string{nodeLabelKey: nodeLabelValue}
+	nodes := []*v1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "stagingNode", Labels: labels}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "unlabeled"}},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "tainted", Labels: labels},
+			Spec:       v1.NodeSpec{Taints: []v1.Taint{{Key: "hardware", Value: "gpu", Effect: v1.TaintEffectNoSchedule}}},
+		},
+		{ObjectMeta: metav1.ObjectMeta{Name: "cordoned1", Labels: labels}, Spec: v1.NodeSpec{Unschedulable: true}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "cordoned2", Labels: labels}, Spec: v1.NodeSpec{Unschedulable: true}},
+	}
+
+	fits, failures := PodFitsAnyOtherNodeWithFailures(pod, nodes)
+	if fits {
+		t.Fatalf("Expected the pod not to fit on any other node")
+	}
+	expected := "0/4 nodes are available: 1 node(s) didn't match Pod's node affinity/selector, " +
+		"1 node(s) had taint(s) that the pod didn't tolerate, 2 node(s) were unschedulable."
+	if message := failures.Message(); message != expected {
+		t.Errorf("Expected message %q, got %q", expected, message)
+	}
+
+	nodes = append(nodes, &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "available", Labels: labels}})
+	if fits, failures := PodFitsAnyOtherNodeWithFailures(pod, nodes); !fits || failures != nil {
+		t.Errorf("Expected the pod to fit on node available, got %v", failures)
+	}
+}
+
 func createPodManifest(nodeName string, nodeSelectorKey string, nodeSelectorValue string) *v1.Pod {
 	return (&v1.Pod{
 		Spec: v1.PodSpec{
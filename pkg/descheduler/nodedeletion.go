@@ -0,0 +1,131 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+// nodeDeletionStrategy is the strategy run by the cycles requested by the node deletion trigger
+const nodeDeletionStrategy api.StrategyName = "LowNodeUtilization"
+
+// nodeDeletionTrigger requests an immediate descheduling cycle once the nodes deleted since the
+// last cycle held more than the configured percentage of the allocatable cpu or memory of the cluster
+type nodeDeletionTrigger struct {
+	lister corelisters.NodeLister
+
+	lock   sync.Mutex
+	config *api.NodeDeletionTrigger
+	// deleted sums the allocatable resources of the nodes deleted since the last cycle
+	deleted   v1.ResourceList
+	requested chan struct{}
+}
+
+func newNodeDeletionTrigger(lister corelisters.NodeLister) *nodeDeletionTrigger {
+	return &nodeDeletionTrigger{
+		lister:    lister,
+		deleted:   v1.ResourceList{},
+		requested: make(chan struct{}, 1),
+	}
+}
+
+// reset forgets the nodes deleted before the cycle starting, and applies the configuration of its policy
+func (t *nodeDeletionTrigger) reset(config *api.NodeDeletionTrigger) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.config = config
+	t.deleted = v1.ResourceList{}
+}
+
+// OnAdd and OnUpdate implement cache.ResourceEventHandler, only deletions are relevant
+func (t *nodeDeletionTrigger) OnAdd(obj interface{})               {}
+func (t *nodeDeletionTrigger) OnUpdate(oldObj, newObj interface{}) {}
+
+// OnDelete accounts for the allocatable resources of the deleted node and requests a cycle
+// once the threshold is exceeded
+func (t *nodeDeletionTrigger) OnDelete(obj interface{}) {
+	node, ok := obj.(*v1.Node)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			return
+		}
+		if node, ok = tombstone.Obj.(*v1.Node); !ok {
+			return
+		}
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.config == nil {
+		return
+	}
+	for _, name := range []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory} {
+		if quantity, ok := node.Status.Allocatable[name]; ok {
+			deleted := t.deleted[name]
+			deleted.Add(quantity)
+			t.deleted[name] = deleted
+		}
+	}
+
+	nodes, err := t.lister.List(labels.Everything())
+	if err != nil {
+		klog.ErrorS(err, "Unable to list nodes")
+		return
+	}
+	remaining := v1.ResourceList{}
+	for _, node := range nodes {
+		for _, name := range []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory} {
+			if quantity, ok := node.Status.Allocatable[name]; ok {
+				total := remaining[name]
+				total.Add(quantity)
+				remaining[name] = total
+			}
+		}
+	}
+
+	for _, name := range []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory} {
+		percentage := deletedPercentage(t.deleted[name], remaining[name])
+		if percentage > float64(t.config.CapacityPercentage) {
+			klog.V(1).InfoS("Deleted nodes exceed the capacity threshold, requesting a descheduling cycle", "node", klog.KObj(node),
+				"resource", name, "percentage", percentage, "threshold", t.config.CapacityPercentage, "strategy", nodeDeletionStrategy)
+			select {
+			case t.requested <- struct{}{}:
+			default:
+			}
+			return
+		}
+	}
+}
+
+// deletedPercentage returns the percentage of the capacity of the cluster before the deletions which was deleted
+func deletedPercentage(deleted, remaining resource.Quantity) float64 {
+	total := deleted.MilliValue() + remaining.MilliValue()
+	if total == 0 {
+		return 0
+	}
+	return float64(deleted.MilliValue()) * 100 / float64(total)
+}
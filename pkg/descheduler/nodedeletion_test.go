@@ -0,0 +1,79 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestNodeDeletionTrigger(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	var nodes []*v1.Node
+	for _, name := range []string{"n1", "n2", "n3", "n4", "n5"} {
+		node := test.BuildTestNode(name, 2000, 4000, 10, nil)
+		nodes = append(nodes, node)
+		if err := indexer.Add(node); err != nil {
+			t.Fatalf("Unable to add node: %v", err)
+		}
+	}
+	deleteNode := func(trigger *nodeDeletionTrigger, obj interface{}, node *v1.Node) {
+		if err := indexer.Delete(node); err != nil {
+			t.Fatalf("Unable to delete node: %v", err)
+		}
+		trigger.OnDelete(obj)
+	}
+	isRequested := func(trigger *nodeDeletionTrigger) bool {
+		select {
+		case <-trigger.requested:
+			return true
+		default:
+			return false
+		}
+	}
+
+	trigger := newNodeDeletionTrigger(corelisters.NewNodeLister(indexer))
+	deleteNode(trigger, nodes[0], nodes[0])
+	if isRequested(trigger) {
+		t.Errorf("Expected no cycle to be requested without configuration")
+	}
+
+	trigger.reset(&api.NodeDeletionTrigger{CapacityPercentage: 30})
+	// 1 of the 4 remaining nodes, 25% of the capacity
+	deleteNode(trigger, nodes[1], nodes[1])
+	if isRequested(trigger) {
+		t.Errorf("Expected no cycle to be requested under the threshold")
+	}
+	// deletions accumulate until the next cycle, 2 of 4 nodes
+	deleteNode(trigger, cache.DeletedFinalStateUnknown{Key: nodes[2].Name, Obj: nodes[2]}, nodes[2])
+	if !isRequested(trigger) {
+		t.Errorf("Expected a cycle to be requested once the threshold is exceeded")
+	}
+
+	// 1 of the 2 remaining nodes, but the threshold is not exceeded anymore after a cycle
+	trigger.reset(&api.NodeDeletionTrigger{CapacityPercentage: 60})
+	deleteNode(trigger, nodes[3], nodes[3])
+	if isRequested(trigger) {
+		t.Errorf("Expected deletions before the cycle to be forgotten")
+	}
+}
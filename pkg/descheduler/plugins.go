@@ -0,0 +1,86 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	clientset "k8s.io/client-go/kubernetes"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/validation"
+	"sigs.k8s.io/descheduler/pkg/framework"
+)
+
+// registeredPlugins returns the plugins registered in the framework
+var registeredPlugins = framework.RegisteredPlugins
+
+// strategyFunctions returns the built-in strategies and the plugins registered in the framework, which take
+// their name. Built-in strategies take precedence over plugins of the same name.
+func strategyFunctions() map[api.StrategyName]strategyFunction {
+	plugins := registeredPlugins()
+	funcs := make(map[api.StrategyName]strategyFunction, len(strategyFuncs)+len(plugins))
+	for name, builder := range plugins {
+		funcs[api.StrategyName(name)] = pluginStrategy(name, builder)
+	}
+	for name, f := range strategyFuncs {
+		funcs[name] = f
+	}
+	return funcs
+}
+
+// frameworkHandle gives plugins access to the client and evictor the strategy runs with
+type frameworkHandle struct {
+	client     clientset.Interface
+	podEvictor *evictions.PodEvictor
+}
+
+func (h *frameworkHandle) ClientSet() clientset.Interface {
+	return h.client
+}
+
+func (h *frameworkHandle) Evictor() *evictions.PodEvictor {
+	return h.podEvictor
+}
+
+// pluginStrategy runs a plugin as a strategy: the plugin is built for the configuration of the strategy, then
+// its Deschedule and Balance extension points are called in this order
+func pluginStrategy(name string, builder framework.PluginBuilder) strategyFunction {
+	return func(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor) error {
+		plugin, err := builder(strategy, &frameworkHandle{client: client, podEvictor: podEvictor})
+		if err != nil {
+			return validation.NewStrategyError(validation.ErrorReasonInvalidParameters, fmt.Sprintf("unable to build plugin %s", name), err)
+		}
+		deschedulePlugin, isDeschedule := plugin.(framework.DeschedulePlugin)
+		balancePlugin, isBalance := plugin.(framework.BalancePlugin)
+		if !isDeschedule && !isBalance {
+			return fmt.Errorf("plugin %s implements no extension point", name)
+		}
+		if isDeschedule {
+			if err := deschedulePlugin.Deschedule(ctx, nodes); err != nil {
+				return err
+			}
+		}
+		if isBalance {
+			return balancePlugin.Balance(ctx, nodes)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/framework"
+	"sigs.k8s.io/descheduler/test"
+)
+
+type testPluginArgs struct {
+	Label string `json:"label"`
+}
+
+// testPlugin records the calls of the extension points of the plugins embedding it
+type testPlugin struct {
+	args   testPluginArgs
+	handle framework.Handle
+	calls  *[]string
+}
+
+func (p *testPlugin) Name() string {
+	return "TestPlugin"
+}
+
+func (p *testPlugin) record(extensionPoint string, nodes []*v1.Node) error {
+	*p.calls = append(*p.calls, fmt.Sprintf("%s %s %d", extensionPoint, p.args.Label, len(nodes)))
+	if p.handle.ClientSet() == nil || p.handle.Evictor() == nil {
+		return fmt.Errorf("no client or evictor")
+	}
+	return nil
+}
+
+type testDeschedulePlugin struct {
+	*testPlugin
+}
+
+func (p *testDeschedulePlugin) Deschedule(ctx context.Context, nodes []*v1.Node) error {
+	return p.record("Deschedule", nodes)
+}
+
+type testBalancePlugin struct {
+	*testPlugin
+}
+
+func (p *testBalancePlugin) Balance(ctx context.Context, nodes []*v1.Node) error {
+	return p.record("Balance", nodes)
+}
+
+type testDescheduleBalancePlugin struct {
+	*testPlugin
+}
+
+func (p *testDescheduleBalancePlugin) Deschedule(ctx context.Context, nodes []*v1.Node) error {
+	return p.record("Deschedule", nodes)
+}
+
+func (p *testDescheduleBalancePlugin) Balance(ctx context.Context, nodes []*v1.Node) error {
+	return p.record("Balance", nodes)
+}
+
+func TestPluginStrategies(t *testing.T) {
+	var calls []string
+	builder := func(kind string) framework.PluginBuilder {
+		return func(strategy api.DeschedulerStrategy, handle framework.Handle) (framework.Plugin, error) {
+			plugin := &testPlugin{handle: handle, calls: &calls}
+			if err := framework.DecodeArgs(strategy, &plugin.args); err != nil {
+				return nil, err
+			}
+			switch kind {
+			case "deschedule":
+				return &testDeschedulePlugin{plugin}, nil
+			case "balance":
+				return &testBalancePlugin{plugin}, nil
+			case "both":
+				return &testDescheduleBalancePlugin{plugin}, nil
+			}
+			return plugin, nil
+		}
+	}
+	defer func(original func() framework.Registry) {
+		registeredPlugins = original
+	}(registeredPlugins)
+	registeredPlugins = func() framework.Registry {
+		return framework.Registry{
+			"DeschedulePlugin":  builder("deschedule"),
+			"BalancePlugin":     builder("balance"),
+			"BothPlugin":        builder("both"),
+			"NoExtensionPlugin": builder("none"),
+			"InvalidArgsPlugin": builder("deschedule"),
+			"RemoveFailedPods":  builder("deschedule"),
+		}
+	}
+
+	funcs := strategyFunctions()
+	if len(funcs) != len(strategyFuncs)+5 {
+		t.Errorf("Expected the built-in strategies and 5 plugins, got %v strategies", len(funcs))
+	}
+	if reflect.ValueOf(funcs["RemoveFailedPods"]).Pointer() != reflect.ValueOf(strategyFuncs["RemoveFailedPods"]).Pointer() {
+		t.Errorf("Expected the built-in strategy to take precedence over the plugin of the same name")
+	}
+
+	n1 := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	n2 := test.BuildTestNode("n2", 2000, 3000, 10, nil)
+	nodes := []*v1.Node{n1, n2}
+	client := fakeclientset.NewSimpleClientset(n1, n2)
+	podEvictor := evictions.NewPodEvictor(client, policyv1.SchemeGroupVersion.String(), false, 0, 0, nodes, false, false, false)
+	strategy := api.DeschedulerStrategy{
+		Enabled: true,
+		Params:  &api.StrategyParameters{PluginArgs: &runtime.RawExtension{Raw: []byte(`{"label":"web"}`)}},
+	}
+
+	tests := []struct {
+		name          string
+		strategy      api.DeschedulerStrategy
+		expectedCalls []string
+		expectedErr   string
+	}{
+		{
+			name:          "DeschedulePlugin",
+			strategy:      strategy,
+			expectedCalls: []string{"Deschedule web 2"},
+		},
+		{
+			name:          "BalancePlugin",
+			strategy:      strategy,
+			expectedCalls: []string{"Balance web 2"},
+		},
+		{
+			name:          "BothPlugin",
+			strategy:      api.DeschedulerStrategy{Enabled: true},
+			expectedCalls: []string{"Deschedule  2", "Balance  2"},
+		},
+		{
+			name:        "NoExtensionPlugin",
+			strategy:    strategy,
+			expectedErr: "plugin NoExtensionPlugin implements no extension point",
+		},
+		{
+			name: "InvalidArgsPlugin",
+			strategy: api.DeschedulerStrategy{
+				Enabled: true,
+				Params:  &api.StrategyParameters{PluginArgs: &runtime.RawExtension{Raw: []byte(`{"lable":"web"}`)}},
+			},
+			expectedErr: `unable to build plugin InvalidArgsPlugin: unable to decode pluginArgs: json: unknown field "lable"`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			calls = nil
+			err := funcs[api.StrategyName(tc.name)](context.Background(), client, tc.strategy, nodes, podEvictor)
+			if tc.expectedErr == "" && err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if tc.expectedErr != "" && (err == nil || err.Error() != tc.expectedErr) {
+				t.Fatalf("Expected error %q, got %v", tc.expectedErr, err)
+			}
+			if !reflect.DeepEqual(calls, tc.expectedCalls) {
+				t.Errorf("Expected calls %v, got %v", tc.expectedCalls, calls)
+			}
+		})
+	}
+
+	if _, err := StrategySchema("BothPlugin"); err != nil {
+		t.Errorf("Unexpected error getting the schema of a plugin: %v", err)
+	}
+}
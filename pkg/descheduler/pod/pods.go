@@ -19,14 +19,20 @@ package pod
 import (
 	"context"
 	"sort"
+	"strconv"
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
 	"sigs.k8s.io/descheduler/pkg/utils"
 )
 
+// EvictionCostAnnotationKey lets workload owners declare the relative cost of evicting a pod,
+// pods with a lower cost are evicted first. The value is an integer, 0 when not set.
+const EvictionCostAnnotationKey = "descheduler.alpha.kubernetes.io/eviction-cost"
+
 type Options struct {
 	filter             func(pod *v1.Pod) bool
 	includedNamespaces []string
@@ -124,6 +130,7 @@ func ListPodsOnANodeWithFieldSelector(
 				pods = append(pods, &podList.Items[i])
 			}
 		}
+		SortPodsBasedOnEvictionCost(pods)
 		return pods, nil
 	}
 
@@ -161,6 +168,7 @@ func ListPodsOnANodeWithFieldSelector(
 		}
 		pods = append(pods, &podList.Items[i])
 	}
+	SortPodsBasedOnEvictionCost(pods)
 	return pods, nil
 }
 
@@ -193,14 +201,46 @@ func SortPodsBasedOnPriorityLowToHigh(pods []*v1.Pod) {
 			return false
 		}
 		if (pods[j].Spec.Priority == nil && pods[i].Spec.Priority == nil) || (*pods[i].Spec.Priority == *pods[j].Spec.Priority) {
-			if IsBestEffortPod(pods[i]) {
-				return true
-			}
-			if IsBurstablePod(pods[i]) && IsGuaranteedPod(pods[j]) {
-				return true
+			// pods of the same priority are ordered by eviction cost, then by QoS class
+			if costI, costJ := GetEvictionCost(pods[i]), GetEvictionCost(pods[j]); costI != costJ {
+				return costI < costJ
 			}
-			return false
+			return qosRank(pods[i]) < qosRank(pods[j])
 		}
 		return *pods[i].Spec.Priority < *pods[j].Spec.Priority
 	})
 }
+
+// qosRank orders QoS classes from the first to the last to evict
+func qosRank(pod *v1.Pod) int {
+	if IsBestEffortPod(pod) {
+		return 0
+	}
+	if IsBurstablePod(pod) {
+		return 1
+	}
+	return 2
+}
+
+// GetEvictionCost returns the eviction cost declared through the EvictionCostAnnotationKey
+// annotation, 0 when the annotation is not set or invalid
+func GetEvictionCost(pod *v1.Pod) int32 {
+	value, ok := pod.Annotations[EvictionCostAnnotationKey]
+	if !ok {
+		return 0
+	}
+	cost, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		klog.V(3).InfoS("Ignoring invalid eviction cost", "pod", klog.KObj(pod), "evictionCost", value)
+		return 0
+	}
+	return int32(cost)
+}
+
+// SortPodsBasedOnEvictionCost sorts pods from the lowest to the highest eviction cost,
+// keeping the order of pods with the same cost
+func SortPodsBasedOnEvictionCost(pods []*v1.Pod) {
+	sort.SliceStable(pods, func(i, j int) bool {
+		return GetEvictionCost(pods[i]) < GetEvictionCost(pods[j])
+	})
+}
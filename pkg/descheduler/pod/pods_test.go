@@ -142,3 +142,47 @@ func TestSortPodsBasedOnPriorityLowToHigh(t *testing.T) {
 		t.Errorf("Expected last pod in sorted list to be %v which of highest priority and guaranteed but got %v", p4, podList[len(podList)-1])
 	}
 }
+
+func TestSortPodsBasedOnEvictionCost(t *testing.T) {
+	n1 := test.BuildTestNode("n1", 4000, 3000, 9, nil)
+	setCost := func(cost string) func(pod *v1.Pod) {
+		return func(pod *v1.Pod) {
+			test.SetPodPriority(pod, highPriority)
+			test.MakeBestEffortPod(pod)
+			pod.Annotations = map[string]string{EvictionCostAnnotationKey: cost}
+		}
+	}
+
+	p1 := test.BuildTestPod("p1", 400, 0, n1.Name, setCost("100"))
+	p2 := test.BuildTestPod("p2", 400, 0, n1.Name, setCost("-10"))
+	p3 := test.BuildTestPod("p3", 400, 0, n1.Name, setCost("invalid"))
+	p4 := test.BuildTestPod("p4", 400, 0, n1.Name, func(pod *v1.Pod) {
+		test.SetPodPriority(pod, highPriority)
+		test.MakeBestEffortPod(pod)
+	})
+	// a lower priority always goes first, whatever the cost
+	p5 := test.BuildTestPod("p5", 400, 0, n1.Name, func(pod *v1.Pod) {
+		test.SetPodPriority(pod, lowPriority)
+		pod.Annotations = map[string]string{EvictionCostAnnotationKey: "1000"}
+	})
+
+	podList := []*v1.Pod{p1, p2, p3, p4}
+	SortPodsBasedOnEvictionCost(podList)
+	if expected := []*v1.Pod{p2, p3, p4, p1}; !reflect.DeepEqual(podList, expected) {
+		t.Errorf("Expected pods sorted by eviction cost %v, got %v", podNames(expected), podNames(podList))
+	}
+
+	podList = []*v1.Pod{p1, p2, p5}
+	SortPodsBasedOnPriorityLowToHigh(podList)
+	if expected := []*v1.Pod{p5, p2, p1}; !reflect.DeepEqual(podList, expected) {
+		t.Errorf("Expected pods sorted by priority then eviction cost %v, got %v", podNames(expected), podNames(podList))
+	}
+}
+
+func podNames(pods []*v1.Pod) []string {
+	var names []string
+	for _, pod := range pods {
+		names = append(names, pod.Name)
+	}
+	return names
+}
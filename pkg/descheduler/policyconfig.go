@@ -20,16 +20,23 @@ import (
 	"fmt"
 	"io/ioutil"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
 
 	"sigs.k8s.io/descheduler/pkg/api"
 	"sigs.k8s.io/descheduler/pkg/api/v1alpha1"
+	"sigs.k8s.io/descheduler/pkg/api/v1alpha2"
 	"sigs.k8s.io/descheduler/pkg/descheduler/scheme"
 )
 
-func LoadPolicyConfig(policyConfigFile string) (*api.DeschedulerPolicy, error) {
+// LoadPolicyConfig reads the policy config file, applying the overrides in the path=value form to it
+func LoadPolicyConfig(policyConfigFile string, overrides []string) (*api.DeschedulerPolicy, error) {
 	if policyConfigFile == "" {
+		if len(overrides) > 0 {
+			return nil, fmt.Errorf("policy overrides require a policy config file")
+		}
 		klog.V(1).InfoS("Policy config file not specified")
 		return nil, nil
 	}
@@ -39,17 +46,58 @@ func LoadPolicyConfig(policyConfigFile string) (*api.DeschedulerPolicy, error) {
 		return nil, fmt.Errorf("failed to read policy config file %q: %+v", policyConfigFile, err)
 	}
 
-	versionedPolicy := &v1alpha1.DeschedulerPolicy{}
+	return decodePolicyConfig(policyConfigFile, policy, overrides)
+}
+
+// decodePolicyConfig decodes the content of a policy config file, with the overrides applied, into the internal policy version
+func decodePolicyConfig(policyConfigFile string, policy []byte, overrides []string) (*api.DeschedulerPolicy, error) {
+	policy, err := applyPolicyOverrides(policy, overrides)
+	if err != nil {
+		return nil, fmt.Errorf("failed applying overrides to descheduler's policy config %q: %v", policyConfigFile, err)
+	}
+	apiVersion, err := policyAPIVersion(policy)
+	if err != nil {
+		return nil, fmt.Errorf("failed decoding descheduler's policy config %q: %v", policyConfigFile, err)
+	}
+	var versionedPolicy runtime.Object
+	groupVersion := v1alpha1.SchemeGroupVersion
+	switch apiVersion {
+	case v1alpha2.SchemeGroupVersion.String():
+		versionedPolicy = &v1alpha2.DeschedulerPolicy{}
+		groupVersion = v1alpha2.SchemeGroupVersion
+	default:
+		versionedPolicy = &v1alpha1.DeschedulerPolicy{}
+	}
 
-	decoder := scheme.Codecs.UniversalDecoder(v1alpha1.SchemeGroupVersion)
+	decoder := scheme.Codecs.UniversalDecoder(groupVersion)
 	if err := runtime.DecodeInto(decoder, policy, versionedPolicy); err != nil {
 		return nil, fmt.Errorf("failed decoding descheduler's policy config %q: %v", policyConfigFile, err)
 	}
+	// the decoder ignores unknown fields, typos like treshold are caught by the schema of the policy
+	if err := validatePolicySchema(policy); err != nil {
+		return nil, fmt.Errorf("invalid descheduler's policy config %q: %v", policyConfigFile, err)
+	}
 
 	internalPolicy := &api.DeschedulerPolicy{}
 	if err := scheme.Scheme.Convert(versionedPolicy, internalPolicy, nil); err != nil {
 		return nil, fmt.Errorf("failed converting versioned policy to internal policy version: %v", err)
 	}
+	if err := validateProfiles(internalPolicy); err != nil {
+		return nil, fmt.Errorf("invalid descheduler's policy config %q: %v", policyConfigFile, err)
+	}
 
 	return internalPolicy, nil
 }
+
+// policyAPIVersion returns the apiVersion of the policy document, policies without apiVersion are decoded
+// as v1alpha1 policies
+func policyAPIVersion(policy []byte) (string, error) {
+	typeMeta := &metav1.TypeMeta{}
+	if err := yaml.Unmarshal(policy, typeMeta); err != nil {
+		return "", err
+	}
+	if typeMeta.APIVersion == "" {
+		return v1alpha1.SchemeGroupVersion.String(), nil
+	}
+	return typeMeta.APIVersion, nil
+}
@@ -0,0 +1,241 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	v1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/descheduler/history"
+	"sigs.k8s.io/descheduler/pkg/descheduler/strategies"
+	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/nodeutilization"
+	"sigs.k8s.io/descheduler/pkg/utils"
+)
+
+// DefaultedPolicy returns a copy of the policy with the values the strategies use when
+// a parameter is not set filled in. When no policy is given, all strategies are listed
+// disabled. Values defaulted from command line flags (nodeSelector, evictLocalStoragePods
+// and maxNoOfPodsToEvictPerNode) are left unset.
+func DefaultedPolicy(policy *api.DeschedulerPolicy) *api.DeschedulerPolicy {
+	var defaulted *api.DeschedulerPolicy
+	if policy == nil {
+		defaulted = &api.DeschedulerPolicy{Strategies: api.StrategyList{}}
+		for name := range strategyFunctions() {
+			defaulted.Strategies[name] = api.DeschedulerStrategy{}
+		}
+	} else {
+		defaulted = policy.DeepCopy()
+	}
+
+	falseValue := false
+	if defaulted.EvictSystemCriticalPods == nil {
+		defaulted.EvictSystemCriticalPods = &falseValue
+	}
+	if defaulted.IgnorePVCPods == nil {
+		defaulted.IgnorePVCPods = &falseValue
+	}
+	if defaulted.ReschedulingHints == nil {
+		defaulted.ReschedulingHints = &falseValue
+	}
+	if defaulted.EvictionHistory != nil {
+		if defaulted.EvictionHistory.Namespace == "" {
+			defaulted.EvictionHistory.Namespace = history.DefaultNamespace
+		}
+		if defaulted.EvictionHistory.Name == "" {
+			defaulted.EvictionHistory.Name = history.DefaultName
+		}
+		if defaulted.EvictionHistory.MaxCycles <= 0 {
+			defaulted.EvictionHistory.MaxCycles = history.DefaultMaxCycles
+		}
+	}
+
+	if defaulted.CooldownPersistence != nil {
+		if defaulted.CooldownPersistence.Kind == "" {
+			defaulted.CooldownPersistence.Kind = evictions.CooldownStoreConfigMap
+		}
+		if defaulted.CooldownPersistence.Namespace == "" {
+			defaulted.CooldownPersistence.Namespace = evictions.DefaultCooldownStoreNamespace
+		}
+		if defaulted.CooldownPersistence.Name == "" {
+			defaulted.CooldownPersistence.Name = evictions.DefaultCooldownStoreName
+		}
+	}
+
+	if defaulted.CycleAbort != nil {
+		if defaulted.CycleAbort.MinEvictionAttempts == nil {
+			minEvictionAttempts := uint(evictions.DefaultMinEvictionAttempts)
+			defaulted.CycleAbort.MinEvictionAttempts = &minEvictionAttempts
+		}
+		if defaulted.CycleAbort.MaxBackoffSeconds == nil {
+			maxBackoffSeconds := uint(evictions.DefaultMaxBackoffSeconds)
+			defaulted.CycleAbort.MaxBackoffSeconds = &maxBackoffSeconds
+		}
+	}
+
+	setDefaultEvictionLimits(defaulted.EvictionLimits)
+
+	setDefaultStrategies(defaulted.Strategies)
+	for _, profile := range defaulted.Profiles {
+		setDefaultStrategies(profile.Strategies)
+	}
+
+	return defaulted
+}
+
+// setDefaultStrategies fills in the parameters of the known strategies of the list
+func setDefaultStrategies(strategyList api.StrategyList) {
+	funcs := strategyFunctions()
+	for name, strategy := range strategyList {
+		if _, ok := funcs[name]; !ok {
+			continue
+		}
+		if strategy.Params == nil {
+			strategy.Params = &api.StrategyParameters{}
+		}
+		setDefaultStrategyParams(name, strategy.Params)
+		setDefaultEvictionLimits(strategy.EvictionLimits)
+		strategyList[name] = strategy
+	}
+}
+
+// setDefaultEvictionLimits fills in the burst of paced evictions
+func setDefaultEvictionLimits(limits *api.EvictionLimits) {
+	if limits != nil && limits.EvictionsPerSecond != nil && limits.EvictionBurst == nil {
+		evictionBurst := uint(evictions.DefaultEvictionBurst)
+		limits.EvictionBurst = &evictionBurst
+	}
+}
+
+// setDefaultStrategyParams fills in the parameters the strategy defaults when not set
+func setDefaultStrategyParams(name api.StrategyName, params *api.StrategyParameters) {
+	if params.IncludeCordonedNodes == nil {
+		includeCordonedNodes := strategiesIncludingCordonedNodes[name]
+		params.IncludeCordonedNodes = &includeCordonedNodes
+	}
+	if params.ThresholdPriority == nil && params.ThresholdPriorityClassName == "" {
+		thresholdPriority := utils.SystemCriticalPriority
+		params.ThresholdPriority = &thresholdPriority
+	}
+
+	switch name {
+	case "LowNodeUtilization":
+		if thresholds := params.NodeResourceUtilizationThresholds; thresholds != nil {
+			for _, resourceName := range []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory, v1.ResourcePods} {
+				if _, ok := thresholds.Thresholds[resourceName]; !ok && thresholds.Thresholds != nil {
+					thresholds.Thresholds[resourceName] = nodeutilization.MaxResourcePercentage
+					if thresholds.TargetThresholds != nil {
+						thresholds.TargetThresholds[resourceName] = nodeutilization.MaxResourcePercentage
+					}
+				}
+			}
+			for i := range thresholds.PriorityBands {
+				band := &thresholds.PriorityBands[i]
+				for _, resourceName := range []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory, v1.ResourcePods} {
+					if _, ok := band.Thresholds[resourceName]; !ok && band.Thresholds != nil {
+						band.Thresholds[resourceName] = nodeutilization.MaxResourcePercentage
+						if band.TargetThresholds != nil {
+							band.TargetThresholds[resourceName] = nodeutilization.MaxResourcePercentage
+						}
+					}
+				}
+			}
+			if thresholds.ThresholdsOperator == "" {
+				thresholds.ThresholdsOperator = nodeutilization.ThresholdsOperatorOr
+			}
+			if thresholds.TieBreaker == "" {
+				thresholds.TieBreaker = nodeutilization.TieBreakerName
+			}
+		}
+	case "HighNodeUtilization":
+		if thresholds := params.NodeResourceUtilizationThresholds; thresholds != nil {
+			// targetThresholds are always 100 and can not be configured
+			for _, resourceName := range []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory, v1.ResourcePods} {
+				if _, ok := thresholds.Thresholds[resourceName]; !ok && thresholds.Thresholds != nil {
+					thresholds.Thresholds[resourceName] = nodeutilization.MaxResourcePercentage
+				}
+			}
+			if thresholds.TieBreaker == "" {
+				thresholds.TieBreaker = nodeutilization.TieBreakerName
+			}
+		}
+	case "RemovePodsFromNodesWithProblems":
+		if params.NodeProblems == nil {
+			params.NodeProblems = &api.NodeProblems{}
+		}
+		if len(params.NodeProblems.Conditions) == 0 {
+			params.NodeProblems.Conditions = append([]string{}, strategies.DefaultNodeProblemConditions...)
+		}
+	case "RemovePodsFromInterruptedNodes":
+		if params.NodeInterruption == nil {
+			params.NodeInterruption = &api.NodeInterruption{}
+		}
+		if len(params.NodeInterruption.Taints) == 0 && len(params.NodeInterruption.Labels) == 0 {
+			params.NodeInterruption.Taints = append([]string{}, strategies.DefaultNodeInterruptionTaints...)
+		}
+	case "RemovePodsStuckInCreation":
+		if params.StuckPods == nil {
+			params.StuckPods = &api.StuckPods{}
+		}
+		if params.StuckPods.MaxStuckSeconds == nil {
+			maxStuckSeconds := uint(strategies.DefaultMaxStuckSeconds)
+			params.StuckPods.MaxStuckSeconds = &maxStuckSeconds
+		}
+		if len(params.StuckPods.Reasons) == 0 {
+			params.StuckPods.Reasons = append([]string{}, strategies.DefaultStuckPodReasons...)
+		}
+	case "RemovePodsForImageLocality":
+		if params.ImageLocality == nil {
+			params.ImageLocality = &api.ImageLocality{}
+		}
+		if params.ImageLocality.MinImageSizeMB == nil {
+			minImageSizeMB := uint(strategies.DefaultMinImageSizeMB)
+			params.ImageLocality.MinImageSizeMB = &minImageSizeMB
+		}
+	case "RemovePodsFromNotReadyNodes":
+		if params.NotReadyNodes == nil {
+			params.NotReadyNodes = &api.NotReadyNodes{}
+		}
+		if params.NotReadyNodes.NotReadySeconds == nil {
+			notReadySeconds := uint(strategies.DefaultNotReadySeconds)
+			params.NotReadyNodes.NotReadySeconds = &notReadySeconds
+		}
+	case "RemovePodsViolatingGPUModelPreference":
+		if params.GPUModelPreference == nil {
+			params.GPUModelPreference = &api.GPUModelPreference{}
+		}
+		if params.GPUModelPreference.ModelLabel == "" {
+			params.GPUModelPreference.ModelLabel = strategies.DefaultGPUModelLabel
+		}
+		if params.GPUModelPreference.ResourceName == "" {
+			params.GPUModelPreference.ResourceName = strategies.DefaultGPUResourceName
+		}
+	case "RemovePodsStuckTerminating":
+		if params.TerminatingPods == nil {
+			params.TerminatingPods = &api.TerminatingPods{}
+		}
+		if params.TerminatingPods.MaxTerminatingSeconds == nil {
+			maxTerminatingSeconds := uint(strategies.DefaultMaxTerminatingSeconds)
+			params.TerminatingPods.MaxTerminatingSeconds = &maxTerminatingSeconds
+		}
+	case "RemovePodsFromNodesWithRestartChurn":
+		if params.RestartChurn != nil && params.RestartChurn.WindowSeconds == nil {
+			windowSeconds := uint(strategies.DefaultRestartChurnWindowSeconds)
+			params.RestartChurn.WindowSeconds = &windowSeconds
+		}
+	}
+}
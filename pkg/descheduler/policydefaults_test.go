@@ -0,0 +1,70 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+func TestDefaultedPolicy(t *testing.T) {
+	defaulted := DefaultedPolicy(nil)
+	if len(defaulted.Strategies) != len(strategyFuncs) {
+		t.Errorf("Expected all %v strategies to be listed, got %v", len(strategyFuncs), len(defaulted.Strategies))
+	}
+	for name, strategy := range defaulted.Strategies {
+		if strategy.Enabled {
+			t.Errorf("Expected strategy %v to be disabled", name)
+		}
+		if strategy.Params == nil || strategy.Params.IncludeCordonedNodes == nil || strategy.Params.ThresholdPriority == nil {
+			t.Errorf("Expected parameters of strategy %v to be defaulted, got %#v", name, strategy.Params)
+		}
+	}
+	if !*defaulted.Strategies["RemovePodsViolatingNodeTaints"].Params.IncludeCordonedNodes {
+		t.Errorf("Expected RemovePodsViolatingNodeTaints to include cordoned nodes")
+	}
+
+	policy := &api.DeschedulerPolicy{
+		Strategies: api.StrategyList{
+			"HighNodeUtilization": api.DeschedulerStrategy{
+				Enabled: true,
+				Params: &api.StrategyParameters{
+					NodeResourceUtilizationThresholds: &api.NodeResourceUtilizationThresholds{
+						Thresholds: api.ResourceThresholds{v1.ResourceCPU: 20},
+					},
+					ThresholdPriorityClassName: "high",
+				},
+			},
+		},
+	}
+	defaulted = DefaultedPolicy(policy)
+	params := defaulted.Strategies["HighNodeUtilization"].Params
+	expectedThresholds := api.ResourceThresholds{v1.ResourceCPU: 20, v1.ResourceMemory: 100, v1.ResourcePods: 100}
+	if !reflect.DeepEqual(params.NodeResourceUtilizationThresholds.Thresholds, expectedThresholds) {
+		t.Errorf("Expected thresholds %v, got %v", expectedThresholds, params.NodeResourceUtilizationThresholds.Thresholds)
+	}
+	if params.ThresholdPriority != nil {
+		t.Errorf("Expected thresholdPriority not to be set along thresholdPriorityClassName")
+	}
+	if len(policy.Strategies["HighNodeUtilization"].Params.NodeResourceUtilizationThresholds.Thresholds) != 1 {
+		t.Errorf("Expected the given policy not to be modified")
+	}
+}
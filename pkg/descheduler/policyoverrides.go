@@ -0,0 +1,90 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// applyPolicyOverrides sets the values of the overrides, in the path=value form, in the policy document before it
+// is decoded, e.g. strategies.LowNodeUtilization.params.nodeResourceUtilizationThresholds.thresholds.cpu=20.
+// Path elements are separated by dots, a dot within a key being escaped with a backslash (e.g. nvidia\.com/gpu),
+// missing objects are created and values are parsed as YAML, so numbers, booleans and lists keep their type.
+func applyPolicyOverrides(policy []byte, overrides []string) ([]byte, error) {
+	if len(overrides) == 0 {
+		return policy, nil
+	}
+	document := map[string]interface{}{}
+	if err := yaml.Unmarshal(policy, &document); err != nil {
+		return nil, fmt.Errorf("unable to parse policy: %v", err)
+	}
+	if document == nil {
+		document = map[string]interface{}{}
+	}
+
+	for _, override := range overrides {
+		parts := strings.SplitN(override, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid override %q, expected path=value", override)
+		}
+		path, rawValue := parts[0], parts[1]
+		var value interface{}
+		if err := yaml.Unmarshal([]byte(rawValue), &value); err != nil {
+			return nil, fmt.Errorf("invalid value of override %q: %v", override, err)
+		}
+
+		keys := splitOverridePath(path)
+		parent := document
+		for i, key := range keys[:len(keys)-1] {
+			child, ok := parent[key]
+			if !ok || child == nil {
+				child = map[string]interface{}{}
+				parent[key] = child
+			}
+			childMap, ok := child.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("invalid override %q, %s is not an object", override, strings.Join(keys[:i+1], "."))
+			}
+			parent = childMap
+		}
+		parent[keys[len(keys)-1]] = value
+	}
+
+	return yaml.Marshal(document)
+}
+
+// splitOverridePath splits the path of an override on the dots which are not escaped
+func splitOverridePath(path string) []string {
+	var keys []string
+	var key strings.Builder
+	for i := 0; i < len(path); i++ {
+		switch {
+		case path[i] == '\\' && i+1 < len(path) && path[i+1] == '.':
+			key.WriteByte('.')
+			i++
+		case path[i] == '.':
+			keys = append(keys, key.String())
+			key.Reset()
+		default:
+			key.WriteByte(path[i])
+		}
+	}
+	return append(keys, key.String())
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+func TestPolicyOverrides(t *testing.T) {
+	policy := []byte(`apiVersion: "descheduler/v1alpha1"
+kind: "DeschedulerPolicy"
+strategies:
+  "LowNodeUtilization":
+     enabled: true
+     params:
+       nodeResourceUtilizationThresholds:
+         thresholds:
+           "cpu": 20
+           "memory": 20
+         targetThresholds:
+           "cpu": 50
+           "memory": 50
+`)
+
+	decoded, err := decodePolicyConfig("policy.yaml", policy, []string{
+		"strategies.LowNodeUtilization.params.nodeResourceUtilizationThresholds.thresholds.cpu=30",
+		`strategies.LowNodeUtilization.params.nodeResourceUtilizationThresholds.thresholds.nvidia\.com/gpu=10`,
+		"strategies.LowNodeUtilization.params.nodeResourceUtilizationThresholds.excludedContainers=[istio-proxy]",
+		"strategies.PodLifeTime.enabled=true",
+		"strategies.PodLifeTime.params.podLifeTime.maxPodLifeTimeSeconds=3600",
+		"maxNoOfPodsToEvictPerNode=5",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error decoding policy: %v", err)
+	}
+
+	thresholds := decoded.Strategies["LowNodeUtilization"].Params.NodeResourceUtilizationThresholds
+	expectedThresholds := api.ResourceThresholds{v1.ResourceCPU: 30, v1.ResourceMemory: 20, "nvidia.com/gpu": 10}
+	if !reflect.DeepEqual(thresholds.Thresholds, expectedThresholds) {
+		t.Errorf("Expected thresholds %v, got %v", expectedThresholds, thresholds.Thresholds)
+	}
+	if !reflect.DeepEqual(thresholds.ExcludedContainers, []string{"istio-proxy"}) {
+		t.Errorf("Expected excluded containers [istio-proxy], got %v", thresholds.ExcludedContainers)
+	}
+	podLifeTime := decoded.Strategies["PodLifeTime"]
+	if !podLifeTime.Enabled || podLifeTime.Params == nil || podLifeTime.Params.PodLifeTime == nil ||
+		podLifeTime.Params.PodLifeTime.MaxPodLifeTimeSeconds == nil || *podLifeTime.Params.PodLifeTime.MaxPodLifeTimeSeconds != 3600 {
+		t.Errorf("Expected PodLifeTime to be enabled with a maximum pod life time of 3600 seconds, got %+v", podLifeTime)
+	}
+	if decoded.MaxNoOfPodsToEvictPerNode == nil || *decoded.MaxNoOfPodsToEvictPerNode != 5 {
+		t.Errorf("Expected a maximum of 5 pods evicted per node, got %v", decoded.MaxNoOfPodsToEvictPerNode)
+	}
+
+	for _, override := range []string{
+		"strategies.LowNodeUtilization.enabled",
+		"=true",
+		"strategies.LowNodeUtilization.enabled.value=true",
+	} {
+		if _, err := decodePolicyConfig("policy.yaml", policy, []string{override}); err == nil {
+			t.Errorf("Expected override %q to be refused", override)
+		}
+	}
+}
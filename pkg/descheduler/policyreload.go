@@ -0,0 +1,169 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/fsnotify/fsnotify"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/descheduler/namespacerules"
+)
+
+// policyReloader reloads the policy config file when it changes or the descheduler receives SIGHUP.
+// Reloads are applied between descheduling cycles, and an invalid policy is ignored so the
+// descheduler keeps running with the last valid one.
+type policyReloader struct {
+	file      string
+	overrides []string
+	content   []byte
+	policy    *api.DeschedulerPolicy
+	requested chan struct{}
+}
+
+func newPolicyReloader(file string, overrides []string, policy *api.DeschedulerPolicy) *policyReloader {
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		klog.ErrorS(err, "Unable to read policy config file", "file", file)
+	}
+	return &policyReloader{
+		file:      file,
+		overrides: overrides,
+		content:   content,
+		policy:    policy,
+		requested: make(chan struct{}, 1),
+	}
+}
+
+// start watches the directory of the policy config file, as ConfigMap volumes replace files
+// by swapping symlinks, and listens for SIGHUP until the stop channel is closed
+func (r *policyReloader) start(stopChannel <-chan struct{}) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("unable to watch policy config file %q: %v", r.file, err)
+	}
+	if err := watcher.Add(filepath.Dir(r.file)); err != nil {
+		watcher.Close()
+		return fmt.Errorf("unable to watch policy config file %q: %v", r.file, err)
+	}
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+
+	go func() {
+		defer watcher.Close()
+		defer signal.Stop(signals)
+		for {
+			select {
+			case <-watcher.Events:
+				r.request()
+			case err := <-watcher.Errors:
+				klog.ErrorS(err, "Error watching policy config file", "file", r.file)
+			case <-signals:
+				klog.V(1).InfoS("Received SIGHUP, reloading policy config file", "file", r.file)
+				r.request()
+			case <-stopChannel:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// request schedules a reload before the next descheduling cycle
+func (r *policyReloader) request() {
+	select {
+	case r.requested <- struct{}{}:
+	default:
+	}
+}
+
+// Policy returns the policy to run the next descheduling cycle with, reloading it if requested
+func (r *policyReloader) Policy() *api.DeschedulerPolicy {
+	select {
+	case <-r.requested:
+	default:
+		return r.policy
+	}
+
+	content, err := ioutil.ReadFile(r.file)
+	if err != nil {
+		klog.ErrorS(err, "Unable to read policy config file, keeping the current policy", "file", r.file)
+		return r.policy
+	}
+	if bytes.Equal(content, r.content) {
+		return r.policy
+	}
+	policy, err := decodePolicyConfig(r.file, content, r.overrides)
+	if err == nil {
+		err = validatePolicy(policy)
+	}
+	if err != nil {
+		klog.ErrorS(err, "Invalid policy config file, keeping the current policy", "file", r.file)
+		return r.policy
+	}
+
+	klog.V(1).InfoS("Reloaded policy config file", "file", r.file)
+	r.content = content
+	r.policy = policy
+	return policy
+}
+
+// validatePolicy checks the profiles of a reloaded policy only enable known strategies, protects valid deployments,
+// filters evictions with a valid filter and sets valid namespace rules, cycle abort and eviction limits
+func validatePolicy(policy *api.DeschedulerPolicy) error {
+	funcs := strategyFunctions()
+	for _, profile := range policyProfiles(policy) {
+		for name := range profile.Strategies {
+			if _, ok := funcs[name]; !ok {
+				return fmt.Errorf("unknown strategy name %q", name)
+			}
+			if _, err := evictions.NewEvictionLimiter("", profile.Strategies[name].EvictionLimits); err != nil {
+				return fmt.Errorf("invalid eviction limits of strategy %q: %v", name, err)
+			}
+		}
+	}
+	if _, err := evictions.NewPodFilter(policy.EvictionFilter); err != nil {
+		return fmt.Errorf("invalid eviction filter: %v", err)
+	}
+	if err := namespacerules.ValidateGuardrails(policy.NamespaceRules); err != nil {
+		return fmt.Errorf("invalid namespace rules: %v", err)
+	}
+	if _, err := evictions.NewErrorBudget(policy.CycleAbort); err != nil {
+		return fmt.Errorf("invalid cycle abort: %v", err)
+	}
+	if _, err := evictions.NewEvictionLimiter("", policy.EvictionLimits); err != nil {
+		return fmt.Errorf("invalid eviction limits: %v", err)
+	}
+	if err := evictions.ValidateProtectedPodConditions(policy.ProtectedPodConditions); err != nil {
+		return fmt.Errorf("invalid protected pod conditions: %v", err)
+	}
+	if policy.CooldownPersistence != nil {
+		if _, err := evictions.NewCooldownStore(nil, policy.CooldownPersistence); err != nil {
+			return fmt.Errorf("invalid cooldown persistence: %v", err)
+		}
+	}
+	return evictions.ValidateProtectedDeployments(policy.ProtectedDeployments)
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestPolicyReloader(t *testing.T) {
+	dir, err := ioutil.TempDir("", "policy")
+	if err != nil {
+		t.Fatalf("Unable to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "policy.yaml")
+	writePolicy := func(content string) {
+		if err := ioutil.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatalf("Unable to write policy config file: %v", err)
+		}
+	}
+	writePolicy(`apiVersion: "descheduler/v1alpha1"
+kind: "DeschedulerPolicy"
+strategies:
+  "RemoveDuplicates":
+     enabled: true
+`)
+	policy, err := LoadPolicyConfig(file, nil)
+	if err != nil {
+		t.Fatalf("Unable to load policy config file: %v", err)
+	}
+
+	reloader := newPolicyReloader(file, nil, policy)
+	stopChannel := make(chan struct{})
+	defer close(stopChannel)
+	if err := reloader.start(stopChannel); err != nil {
+		t.Fatalf("Unable to start policy reloader: %v", err)
+	}
+	if reloader.Policy() != policy {
+		t.Errorf("Expected the policy to be kept until the file changes")
+	}
+
+	writePolicy(`apiVersion: "descheduler/v1alpha1"
+kind: "DeschedulerPolicy"
+strategies:
+  "RemoveDuplicates":
+     enabled: false
+  "PodLifeTime":
+     enabled: true
+`)
+	// wait for the watcher to request a reload
+	deadline := time.Now().Add(5 * time.Second)
+	for len(reloader.requested) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	reloaded := reloader.Policy()
+	if reloaded == policy {
+		t.Fatalf("Expected the policy to be reloaded after the file changed")
+	}
+	if reloaded.Strategies["RemoveDuplicates"].Enabled || !reloaded.Strategies["PodLifeTime"].Enabled {
+		t.Errorf("Expected the reloaded policy to enable PodLifeTime only, got %#v", reloaded.Strategies)
+	}
+
+	for _, invalid := range []string{
+		"strategies: [",
+		`apiVersion: "descheduler/v1alpha1"
+kind: "DeschedulerPolicy"
+strategies:
+  "RemoveEverything":
+     enabled: true
+`,
+		`apiVersion: "descheduler/v1alpha1"
+kind: "DeschedulerPolicy"
+podEvictionCooldownSeconds: 600
+cooldownPersistence:
+  kind: Secret
+strategies:
+  "RemoveDuplicates":
+     enabled: true
+`,
+	} {
+		writePolicy(invalid)
+		reloader.request()
+		if reloader.Policy() != reloaded {
+			t.Errorf("Expected the invalid policy %q to be ignored", invalid)
+		}
+	}
+}
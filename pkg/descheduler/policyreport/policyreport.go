@@ -0,0 +1,286 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package policyreport exports the findings and evictions of descheduling cycles as wgpolicyk8s.io
+// PolicyReport and ClusterPolicyReport objects, as shown by Policy Reporter and Kyverno dashboards.
+package policyreport
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/history"
+)
+
+const (
+	// DefaultName is the name of the reports when not configured
+	DefaultName = "descheduler"
+
+	// Source identifies the descheduler as the producer of the results
+	Source = "descheduler"
+
+	managedByLabel = "app.kubernetes.io/managed-by"
+
+	// results of the wgpolicyk8s.io API
+	resultFail  = "fail"
+	resultWarn  = "warn"
+	resultSkip  = "skip"
+	resultError = "error"
+)
+
+var (
+	policyReportResource        = schema.GroupVersionResource{Group: "wgpolicyk8s.io", Version: "v1alpha2", Resource: "policyreports"}
+	clusterPolicyReportResource = schema.GroupVersionResource{Group: "wgpolicyk8s.io", Version: "v1alpha2", Resource: "clusterpolicyreports"}
+)
+
+// Report is the content shared by PolicyReport and ClusterPolicyReport objects
+type Report struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Summary           Summary  `json:"summary"`
+	Results           []Result `json:"results,omitempty"`
+}
+
+// Summary counts the results of a report by result
+type Summary struct {
+	Pass  int `json:"pass"`
+	Fail  int `json:"fail"`
+	Warn  int `json:"warn"`
+	Error int `json:"error"`
+	Skip  int `json:"skip"`
+}
+
+// Result is a finding or an action of a strategy
+type Result struct {
+	Policy     string            `json:"policy"`
+	Rule       string            `json:"rule,omitempty"`
+	Category   string            `json:"category,omitempty"`
+	Result     string            `json:"result"`
+	Message    string            `json:"message,omitempty"`
+	Resources  []ResourceRef     `json:"resources,omitempty"`
+	Source     string            `json:"source"`
+	Timestamp  metav1.Timestamp  `json:"timestamp"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+// ResourceRef references the pod a result is about
+type ResourceRef struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+}
+
+// Build converts a descheduling cycle to a PolicyReport per namespace with evictions or node fit failures and
+// a ClusterPolicyReport with the strategy errors. Pods evicted in dry run mode are warnings, evicted pods failures
+// of the descheduling policy and pods not evicted because they do not fit on any other node are skipped.
+func Build(name string, cycle history.Cycle) (map[string]*Report, *Report) {
+	timestamp := metav1.Timestamp{Seconds: cycle.Start.Unix(), Nanos: int32(cycle.Start.Nanosecond())}
+	reports := map[string]*Report{}
+	namespaceReport := func(namespace string) *Report {
+		if reports[namespace] == nil {
+			reports[namespace] = newReport("PolicyReport", namespace, name)
+		}
+		return reports[namespace]
+	}
+
+	for _, eviction := range cycle.Evictions {
+		result := Result{
+			Policy:    name,
+			Rule:      eviction.Strategy,
+			Category:  "descheduling",
+			Result:    resultFail,
+			Message:   fmt.Sprintf("pod evicted from node %s: %s/%s", eviction.Node, eviction.Strategy, eviction.Cause),
+			Resources: []ResourceRef{podRef(eviction.Namespace, eviction.Pod)},
+			Source:    Source,
+			Timestamp: metav1.Timestamp{Seconds: eviction.EvictedAt.Unix(), Nanos: int32(eviction.EvictedAt.Nanosecond())},
+			Properties: map[string]string{
+				"node":  eviction.Node,
+				"cause": string(eviction.Cause),
+			},
+		}
+		if eviction.OwnerKind != "" {
+			result.Properties["owner"] = eviction.OwnerKind + "/" + eviction.OwnerName
+		}
+		if eviction.DryRun {
+			result.Result = resultWarn
+			result.Message = fmt.Sprintf("pod would be evicted from node %s: %s/%s", eviction.Node, eviction.Strategy, eviction.Cause)
+			result.Properties["dryRun"] = "true"
+		}
+		namespaceReport(eviction.Namespace).add(result)
+	}
+
+	for _, failure := range cycle.NodeFitFailures {
+		result := Result{
+			Policy:     name,
+			Rule:       "NodeFit",
+			Category:   "descheduling",
+			Result:     resultSkip,
+			Message:    "pod not evicted as it does not fit on any other node: " + failure.Message,
+			Resources:  []ResourceRef{podRef(failure.Namespace, failure.Pod)},
+			Source:     Source,
+			Timestamp:  timestamp,
+			Properties: map[string]string{"node": failure.Node},
+		}
+		if failure.DryRun {
+			result.Properties["dryRun"] = "true"
+		}
+		namespaceReport(failure.Namespace).add(result)
+	}
+
+	clusterReport := newReport("ClusterPolicyReport", "", name)
+	for _, strategyError := range cycle.StrategyErrors {
+		clusterReport.add(Result{
+			Policy:     name,
+			Rule:       strategyError.Strategy,
+			Category:   "descheduling",
+			Result:     resultError,
+			Message:    strategyError.Message,
+			Source:     Source,
+			Timestamp:  timestamp,
+			Properties: map[string]string{"reason": string(strategyError.Reason)},
+		})
+	}
+	return reports, clusterReport
+}
+
+func newReport(kind, namespace, name string) *Report {
+	return &Report{
+		TypeMeta: metav1.TypeMeta{APIVersion: "wgpolicyk8s.io/v1alpha2", Kind: kind},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    map[string]string{managedByLabel: Source},
+		},
+	}
+}
+
+func (r *Report) add(result Result) {
+	r.Results = append(r.Results, result)
+	switch result.Result {
+	case resultFail:
+		r.Summary.Fail++
+	case resultWarn:
+		r.Summary.Warn++
+	case resultSkip:
+		r.Summary.Skip++
+	case resultError:
+		r.Summary.Error++
+	default:
+		r.Summary.Pass++
+	}
+}
+
+func podRef(namespace, name string) ResourceRef {
+	return ResourceRef{APIVersion: "v1", Kind: "Pod", Namespace: namespace, Name: name}
+}
+
+// Writer replaces the reports of the previous descheduling cycle with the reports of the last one
+type Writer struct {
+	client dynamic.Interface
+	name   string
+}
+
+// NewWriter returns a Writer for the given configuration, unset fields are defaulted
+func NewWriter(client dynamic.Interface, config *api.PolicyReports) *Writer {
+	writer := &Writer{client: client, name: DefaultName}
+	if config != nil && config.Name != "" {
+		writer.name = config.Name
+	}
+	return writer
+}
+
+// Write creates or updates the reports of the cycle, and deletes the reports of the namespaces
+// without results anymore
+func (w *Writer) Write(ctx context.Context, cycle history.Cycle) error {
+	reports, clusterReport := Build(w.name, cycle)
+
+	namespaces := make([]string, 0, len(reports))
+	for namespace := range reports {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+	for _, namespace := range namespaces {
+		if err := w.apply(ctx, w.client.Resource(policyReportResource).Namespace(namespace), reports[namespace]); err != nil {
+			return err
+		}
+	}
+	if err := w.apply(ctx, w.client.Resource(clusterPolicyReportResource), clusterReport); err != nil {
+		return err
+	}
+
+	existing, err := w.client.Resource(policyReportResource).Namespace(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		LabelSelector: managedByLabel + "=" + Source,
+		FieldSelector: "metadata.name=" + w.name,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to list policy reports: %v", err)
+	}
+	for _, item := range existing.Items {
+		if _, ok := reports[item.GetNamespace()]; ok || item.GetName() != w.name {
+			continue
+		}
+		klog.V(3).InfoS("Deleting policy report without results", "namespace", item.GetNamespace(), "name", item.GetName())
+		err := w.client.Resource(policyReportResource).Namespace(item.GetNamespace()).Delete(ctx, item.GetName(), metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("unable to delete policy report %s/%s: %v", item.GetNamespace(), item.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// apply creates the report, or replaces the results of the existing one
+func (w *Writer) apply(ctx context.Context, client dynamic.ResourceInterface, report *Report) error {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(report)
+	if err != nil {
+		return fmt.Errorf("unable to convert %s %s: %v", report.Kind, report.Name, err)
+	}
+	obj := &unstructured.Unstructured{Object: content}
+
+	current, err := client.Get(ctx, report.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		_, err = client.Create(ctx, obj, metav1.CreateOptions{})
+		if err != nil {
+			return fmt.Errorf("unable to create %s %s: %v", report.Kind, reportKey(report), err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("unable to get %s %s: %v", report.Kind, reportKey(report), err)
+	}
+	obj.SetResourceVersion(current.GetResourceVersion())
+	if _, err := client.Update(ctx, obj, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("unable to update %s %s: %v", report.Kind, reportKey(report), err)
+	}
+	return nil
+}
+
+func reportKey(report *Report) string {
+	if report.Namespace == "" {
+		return report.Name
+	}
+	return report.Namespace + "/" + report.Name
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policyreport
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/descheduler/history"
+	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/validation"
+)
+
+func TestWrite(t *testing.T) {
+	ctx := context.Background()
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		policyReportResource:        "PolicyReportList",
+		clusterPolicyReportResource: "ClusterPolicyReportList",
+	})
+	writer := NewWriter(client, nil)
+
+	now := metav1.NewTime(time.Now())
+	cycle := history.Cycle{
+		Start: now,
+		Evictions: []history.Eviction{
+			{Namespace: "team-a", Pod: "p1", Node: "n1", Strategy: "LowNodeUtilization", Cause: evictions.CauseNodeOverutilized, EvictedAt: now, OwnerKind: "ReplicaSet", OwnerName: "rs1"},
+			{Namespace: "team-a", Pod: "p2", Node: "n1", Strategy: "PodLifeTime", Cause: evictions.CausePodLifeTimeExceeded, DryRun: true, EvictedAt: now},
+			{Namespace: "team-b", Pod: "p3", Node: "n2", Strategy: "LowNodeUtilization", Cause: evictions.CauseNodeOverutilized, EvictedAt: now},
+		},
+		NodeFitFailures: []history.NodeFitFailure{
+			{Namespace: "team-b", Pod: "p4", Node: "n2", Message: "0/2 nodes are available"},
+		},
+		StrategyErrors: []history.StrategyError{
+			{Strategy: "RemoveDuplicates", Reason: validation.ErrorReasonInvalidParameters, Message: "invalid parameters"},
+		},
+	}
+	if err := writer.Write(ctx, cycle); err != nil {
+		t.Fatalf("Unexpected error writing reports: %v", err)
+	}
+
+	expectedSummaries := map[string]map[string]interface{}{
+		"team-a": {"pass": int64(0), "fail": int64(1), "warn": int64(1), "error": int64(0), "skip": int64(0)},
+		"team-b": {"pass": int64(0), "fail": int64(1), "warn": int64(0), "error": int64(0), "skip": int64(1)},
+	}
+	for namespace, expected := range expectedSummaries {
+		report, err := client.Resource(policyReportResource).Namespace(namespace).Get(ctx, DefaultName, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("Expected a policy report in namespace %s: %v", namespace, err)
+		}
+		if summary := report.Object["summary"].(map[string]interface{}); !reflect.DeepEqual(summary, expected) {
+			t.Errorf("Expected summary %v in namespace %s, got %v", expected, namespace, summary)
+		}
+	}
+	clusterReport, err := client.Resource(clusterPolicyReportResource).Get(ctx, DefaultName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected a cluster policy report: %v", err)
+	}
+	results := clusterReport.Object["results"].([]interface{})
+	if len(results) != 1 || results[0].(map[string]interface{})["rule"] != "RemoveDuplicates" {
+		t.Errorf("Expected the cluster policy report to hold the strategy error, got %v", results)
+	}
+
+	// the next cycle only evicts pods of team-b, the report of team-a is deleted
+	cycle = history.Cycle{
+		Start:     metav1.NewTime(time.Now()),
+		Evictions: cycle.Evictions[2:],
+	}
+	if err := writer.Write(ctx, cycle); err != nil {
+		t.Fatalf("Unexpected error writing reports: %v", err)
+	}
+	if _, err := client.Resource(policyReportResource).Namespace("team-a").Get(ctx, DefaultName, metav1.GetOptions{}); err == nil {
+		t.Errorf("Expected the policy report of namespace team-a to be deleted")
+	}
+	report, err := client.Resource(policyReportResource).Namespace("team-b").Get(ctx, DefaultName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected a policy report in namespace team-b: %v", err)
+	}
+	if results := report.Object["results"].([]interface{}); len(results) != 1 {
+		t.Errorf("Expected the policy report of namespace team-b to be replaced, got %v", results)
+	}
+	clusterReport, err = client.Resource(clusterPolicyReportResource).Get(ctx, DefaultName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected a cluster policy report: %v", err)
+	}
+	if _, ok := clusterReport.Object["results"]; ok {
+		t.Errorf("Expected the cluster policy report to be emptied, got %v", clusterReport.Object["results"])
+	}
+}
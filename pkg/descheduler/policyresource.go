@@ -0,0 +1,296 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/api/v1alpha2"
+)
+
+// PolicyResource is the resource of the cluster-scoped DeschedulerPolicy custom resource definition
+var PolicyResource = schema.GroupVersionResource{Group: "descheduler.sigs.k8s.io", Version: "v1alpha1", Resource: "deschedulerpolicies"}
+
+// Condition types of the status of the DeschedulerPolicy custom resource
+const (
+	// PolicyConditionReady tells whether the latest version of the resource is the applied policy
+	PolicyConditionReady = "Ready"
+	// PolicyConditionLastRunSucceeded tells whether all strategies of the last descheduling cycle succeeded
+	PolicyConditionLastRunSucceeded = "LastRunSucceeded"
+	// PolicyConditionEvictionsThisRun tells whether the last descheduling cycle evicted pods
+	PolicyConditionEvictionsThisRun = "EvictionsThisRun"
+)
+
+// policyResourceStatus is the status of the DeschedulerPolicy custom resource
+type policyResourceStatus struct {
+	Conditions       []metav1.Condition `json:"conditions,omitempty"`
+	EvictionsThisRun int64              `json:"evictionsThisRun"`
+	LastRunTime      *metav1.Time       `json:"lastRunTime,omitempty"`
+}
+
+// policySource provides the policy to run the next descheduling cycle with
+type policySource interface {
+	Policy() *api.DeschedulerPolicy
+}
+
+// LoadPolicyResource reads the policy from the spec of the named DeschedulerPolicy custom resource
+func LoadPolicyResource(ctx context.Context, client dynamic.Interface, name string, overrides []string) (*api.DeschedulerPolicy, error) {
+	obj, err := client.Resource(PolicyResource).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get policy resource %q: %v", name, err)
+	}
+	content, err := policyResourceDocument(obj)
+	if err != nil {
+		return nil, fmt.Errorf("invalid policy resource %q: %v", name, err)
+	}
+	return decodePolicyConfig(policyResourceName(name), content, overrides)
+}
+
+func policyResourceName(name string) string {
+	return PolicyResource.Resource + "/" + name
+}
+
+// policyResourceDocument turns the spec of a DeschedulerPolicy custom resource into a v1alpha2 policy document
+func policyResourceDocument(obj *unstructured.Unstructured) ([]byte, error) {
+	spec, found, err := unstructured.NestedMap(obj.Object, "spec")
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("spec is missing")
+	}
+	spec["apiVersion"] = v1alpha2.SchemeGroupVersion.String()
+	spec["kind"] = "DeschedulerPolicy"
+	return json.Marshal(spec)
+}
+
+// policyResourceWatcher watches the DeschedulerPolicy custom resource the policy is read from. Changes are
+// applied between descheduling cycles, and an invalid or deleted resource is ignored so the descheduler keeps
+// running with the last valid policy.
+type policyResourceWatcher struct {
+	client    dynamic.Interface
+	name      string
+	overrides []string
+
+	lock    sync.Mutex
+	latest  *unstructured.Unstructured
+	content []byte
+	policy  *api.DeschedulerPolicy
+	// generation is the generation of the resource the policy was loaded from
+	generation int64
+	// invalid is the error of the latest version of the resource when it could not be applied
+	invalid error
+}
+
+func newPolicyResourceWatcher(client dynamic.Interface, name string, overrides []string, policy *api.DeschedulerPolicy) *policyResourceWatcher {
+	return &policyResourceWatcher{
+		client:    client,
+		name:      name,
+		overrides: overrides,
+		policy:    policy,
+	}
+}
+
+// start watches the resource until the stop channel is closed, once its current version is known
+func (w *policyResourceWatcher) start(stopChannel <-chan struct{}) error {
+	selector := fields.OneTermEqualSelector("metadata.name", w.name).String()
+	resource := w.client.Resource(PolicyResource)
+	lw := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			options.FieldSelector = selector
+			return resource.List(context.TODO(), options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector = selector
+			return resource.Watch(context.TODO(), options)
+		},
+	}
+	informer := cache.NewSharedIndexInformer(lw, &unstructured.Unstructured{}, 0, cache.Indexers{})
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: w.update,
+		UpdateFunc: func(_, obj interface{}) {
+			w.update(obj)
+		},
+		DeleteFunc: func(interface{}) {
+			klog.ErrorS(nil, "Policy resource deleted, keeping the current policy", "resource", policyResourceName(w.name))
+		},
+	})
+	go informer.Run(stopChannel)
+	if !cache.WaitForCacheSync(stopChannel, informer.HasSynced) {
+		return fmt.Errorf("unable to watch policy resource %q", w.name)
+	}
+
+	// the version of the resource at startup is the one the current policy was loaded from
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.latest != nil {
+		w.content, _ = policyResourceDocument(w.latest)
+		w.generation = w.latest.GetGeneration()
+		w.latest = nil
+	}
+	return nil
+}
+
+// update records the latest version of the resource, applied before the next descheduling cycle
+func (w *policyResourceWatcher) update(obj interface{}) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok || u.GetName() != w.name {
+		return
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.latest = u
+}
+
+// Policy returns the policy to run the next descheduling cycle with, decoding the latest version of the resource
+// if it changed
+func (w *policyResourceWatcher) Policy() *api.DeschedulerPolicy {
+	w.lock.Lock()
+	latest := w.latest
+	w.latest = nil
+	w.lock.Unlock()
+	if latest == nil {
+		return w.policy
+	}
+
+	name := policyResourceName(w.name)
+	content, err := policyResourceDocument(latest)
+	if err != nil {
+		klog.ErrorS(err, "Invalid policy resource, keeping the current policy", "resource", name)
+		w.setInvalid(err)
+		return w.policy
+	}
+	if bytes.Equal(content, w.content) {
+		w.setApplied(latest.GetGeneration())
+		return w.policy
+	}
+	policy, err := decodePolicyConfig(name, content, w.overrides)
+	if err == nil {
+		err = validatePolicy(policy)
+	}
+	if err != nil {
+		klog.ErrorS(err, "Invalid policy resource, keeping the current policy", "resource", name)
+		w.setInvalid(err)
+		return w.policy
+	}
+
+	klog.V(1).InfoS("Reloaded policy resource", "resource", name)
+	w.content = content
+	w.policy = policy
+	w.setApplied(latest.GetGeneration())
+	return policy
+}
+
+func (w *policyResourceWatcher) setInvalid(err error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.invalid = err
+}
+
+func (w *policyResourceWatcher) setApplied(generation int64) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.generation = generation
+	w.invalid = nil
+}
+
+// recordCycle writes the outcome of a descheduling cycle to the status conditions of the resource: whether its
+// latest version is the applied policy, whether all strategies succeeded and how many pods were evicted
+func (w *policyResourceWatcher) recordCycle(ctx context.Context, now time.Time, evicted int, errs []error) error {
+	w.lock.Lock()
+	generation, invalid := w.generation, w.invalid
+	w.lock.Unlock()
+
+	ready := metav1.Condition{
+		Type:    PolicyConditionReady,
+		Status:  metav1.ConditionTrue,
+		Reason:  "PolicyApplied",
+		Message: "The policy of the resource is applied",
+	}
+	if invalid != nil {
+		ready.Status = metav1.ConditionFalse
+		ready.Reason = "InvalidPolicy"
+		ready.Message = fmt.Sprintf("The latest version of the resource is invalid, the last valid policy is applied: %v", invalid)
+	}
+	lastRun := metav1.Condition{
+		Type:    PolicyConditionLastRunSucceeded,
+		Status:  metav1.ConditionTrue,
+		Reason:  "StrategiesSucceeded",
+		Message: "All strategies of the last descheduling cycle succeeded",
+	}
+	if len(errs) > 0 {
+		lastRun.Status = metav1.ConditionFalse
+		lastRun.Reason = "StrategiesFailed"
+		lastRun.Message = utilerrors.NewAggregate(errs).Error()
+	}
+	evictions := metav1.Condition{
+		Type:    PolicyConditionEvictionsThisRun,
+		Status:  metav1.ConditionFalse,
+		Reason:  "NoPodsEvicted",
+		Message: fmt.Sprintf("%d pods evicted by the last descheduling cycle", evicted),
+	}
+	if evicted > 0 {
+		evictions.Status = metav1.ConditionTrue
+		evictions.Reason = "PodsEvicted"
+	}
+
+	// a conflicting update is logged, the status is written again after the next cycle
+	resource := w.client.Resource(PolicyResource)
+	obj, err := resource.Get(ctx, w.name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	status := policyResourceStatus{}
+	if current, found, _ := unstructured.NestedMap(obj.Object, "status"); found {
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(current, &status); err != nil {
+			return err
+		}
+	}
+	for _, condition := range []metav1.Condition{ready, lastRun, evictions} {
+		condition.ObservedGeneration = generation
+		condition.LastTransitionTime = metav1.NewTime(now)
+		meta.SetStatusCondition(&status.Conditions, condition)
+	}
+	status.EvictionsThisRun = int64(evicted)
+	lastRunTime := metav1.NewTime(now)
+	status.LastRunTime = &lastRunTime
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&status)
+	if err != nil {
+		return err
+	}
+	obj.Object["status"] = content
+	_, err = resource.UpdateStatus(ctx, obj, metav1.UpdateOptions{})
+	return err
+}
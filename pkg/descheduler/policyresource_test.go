@@ -0,0 +1,197 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func policyResourceObject(strategies map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "descheduler.sigs.k8s.io/v1alpha1",
+		"kind":       "DeschedulerPolicy",
+		"metadata":   map[string]interface{}{"name": "cluster"},
+		"spec": map[string]interface{}{
+			"profiles": []interface{}{
+				map[string]interface{}{"name": "default", "strategies": strategies},
+			},
+		},
+	}}
+}
+
+func TestPolicyResourceWatcher(t *testing.T) {
+	ctx := context.Background()
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		PolicyResource: "DeschedulerPolicyList",
+	}, policyResourceObject(map[string]interface{}{
+		"RemoveDuplicates": map[string]interface{}{"enabled": true},
+	}))
+
+	policy, err := LoadPolicyResource(ctx, client, "cluster", nil)
+	if err != nil {
+		t.Fatalf("Unable to load policy resource: %v", err)
+	}
+	if !policy.Profiles[0].Strategies["RemoveDuplicates"].Enabled {
+		t.Fatalf("Expected the policy resource to enable RemoveDuplicates, got %#v", policy.Profiles)
+	}
+	if _, err := LoadPolicyResource(ctx, client, "missing", nil); err == nil {
+		t.Errorf("Expected an error loading a missing policy resource")
+	}
+
+	watcher := newPolicyResourceWatcher(client, "cluster", nil, policy)
+	stopChannel := make(chan struct{})
+	defer close(stopChannel)
+	if err := watcher.start(stopChannel); err != nil {
+		t.Fatalf("Unable to start policy resource watcher: %v", err)
+	}
+	if watcher.Policy() != policy {
+		t.Errorf("Expected the policy to be kept until the resource changes")
+	}
+
+	update := func(obj *unstructured.Unstructured) {
+		if _, err := client.Resource(PolicyResource).Update(ctx, obj, metav1.UpdateOptions{}); err != nil {
+			t.Fatalf("Unable to update policy resource: %v", err)
+		}
+		// wait for the watcher to receive the update
+		deadline := time.Now().Add(5 * time.Second)
+		for time.Now().Before(deadline) {
+			watcher.lock.Lock()
+			received := watcher.latest != nil
+			watcher.lock.Unlock()
+			if received {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Fatalf("Expected the watcher to receive the update of the policy resource")
+	}
+
+	update(policyResourceObject(map[string]interface{}{
+		"RemoveDuplicates": map[string]interface{}{"enabled": false},
+		"PodLifeTime":      map[string]interface{}{"enabled": true},
+	}))
+	reloaded := watcher.Policy()
+	if reloaded == policy {
+		t.Fatalf("Expected the policy to be reloaded after the resource changed")
+	}
+	strategies := reloaded.Profiles[0].Strategies
+	if strategies["RemoveDuplicates"].Enabled || !strategies["PodLifeTime"].Enabled {
+		t.Errorf("Expected the reloaded policy to enable PodLifeTime only, got %#v", strategies)
+	}
+
+	update(policyResourceObject(map[string]interface{}{
+		"RemoveEverything": map[string]interface{}{"enabled": true},
+	}))
+	if watcher.Policy() != reloaded {
+		t.Errorf("Expected the invalid policy resource to be ignored")
+	}
+}
+
+func TestPolicyResourceStatus(t *testing.T) {
+	ctx := context.Background()
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		PolicyResource: "DeschedulerPolicyList",
+	}, policyResourceObject(map[string]interface{}{
+		"RemoveDuplicates": map[string]interface{}{"enabled": true},
+	}))
+	policy, err := LoadPolicyResource(ctx, client, "cluster", nil)
+	if err != nil {
+		t.Fatalf("Unable to load policy resource: %v", err)
+	}
+	watcher := newPolicyResourceWatcher(client, "cluster", nil, policy)
+
+	status := func() policyResourceStatus {
+		obj, err := client.Resource(PolicyResource).Get(ctx, "cluster", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("Unable to get policy resource: %v", err)
+		}
+		content, _, _ := unstructured.NestedMap(obj.Object, "status")
+		status := policyResourceStatus{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(content, &status); err != nil {
+			t.Fatalf("Unable to decode the status of the policy resource: %v", err)
+		}
+		return status
+	}
+	expectCondition := func(status policyResourceStatus, conditionType string, expected metav1.ConditionStatus, reason string) {
+		condition := meta.FindStatusCondition(status.Conditions, conditionType)
+		if condition == nil {
+			t.Fatalf("Expected condition %s, got %#v", conditionType, status.Conditions)
+		}
+		if condition.Status != expected || condition.Reason != reason {
+			t.Errorf("Expected condition %s to be %s with reason %s, got %s with reason %s", conditionType, expected, reason, condition.Status, condition.Reason)
+		}
+	}
+
+	firstRun := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+	if err := watcher.recordCycle(ctx, firstRun, 3, nil); err != nil {
+		t.Fatalf("Unable to record the descheduling cycle: %v", err)
+	}
+	first := status()
+	expectCondition(first, PolicyConditionReady, metav1.ConditionTrue, "PolicyApplied")
+	expectCondition(first, PolicyConditionLastRunSucceeded, metav1.ConditionTrue, "StrategiesSucceeded")
+	expectCondition(first, PolicyConditionEvictionsThisRun, metav1.ConditionTrue, "PodsEvicted")
+	if first.EvictionsThisRun != 3 {
+		t.Errorf("Expected 3 evictions this run, got %d", first.EvictionsThisRun)
+	}
+	if first.LastRunTime == nil || !first.LastRunTime.Time.Equal(firstRun) {
+		t.Errorf("Expected the last run time to be %v, got %v", firstRun, first.LastRunTime)
+	}
+
+	// an invalid version of the resource is reported, while the last valid policy stays applied
+	watcher.update(policyResourceObject(map[string]interface{}{
+		"RemoveEverything": map[string]interface{}{"enabled": true},
+	}))
+	if watcher.Policy() != policy {
+		t.Fatalf("Expected the invalid policy resource to be ignored")
+	}
+	secondRun := firstRun.Add(time.Hour)
+	if err := watcher.recordCycle(ctx, secondRun, 0, []error{fmt.Errorf("strategy default/RemoveDuplicates failed")}); err != nil {
+		t.Fatalf("Unable to record the descheduling cycle: %v", err)
+	}
+	second := status()
+	expectCondition(second, PolicyConditionReady, metav1.ConditionFalse, "InvalidPolicy")
+	expectCondition(second, PolicyConditionLastRunSucceeded, metav1.ConditionFalse, "StrategiesFailed")
+	expectCondition(second, PolicyConditionEvictionsThisRun, metav1.ConditionFalse, "NoPodsEvicted")
+	if second.EvictionsThisRun != 0 {
+		t.Errorf("Expected no evictions this run, got %d", second.EvictionsThisRun)
+	}
+	if condition := meta.FindStatusCondition(second.Conditions, PolicyConditionLastRunSucceeded); condition.Message != "strategy default/RemoveDuplicates failed" {
+		t.Errorf("Expected the failed strategy in the condition message, got %q", condition.Message)
+	}
+
+	// fixing the resource makes it ready again
+	watcher.update(policyResourceObject(map[string]interface{}{
+		"PodLifeTime": map[string]interface{}{"enabled": true},
+	}))
+	if watcher.Policy() == policy {
+		t.Fatalf("Expected the policy to be reloaded after the resource was fixed")
+	}
+	if err := watcher.recordCycle(ctx, secondRun.Add(time.Hour), 0, nil); err != nil {
+		t.Fatalf("Unable to record the descheduling cycle: %v", err)
+	}
+	expectCondition(status(), PolicyConditionReady, metav1.ConditionTrue, "PolicyApplied")
+}
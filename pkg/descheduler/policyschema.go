@@ -0,0 +1,280 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/api/v1alpha1"
+	"sigs.k8s.io/descheduler/pkg/api/v1alpha2"
+)
+
+// jsonSchemaDraft is the JSON Schema version of the generated schemas
+const jsonSchemaDraft = "http://json-schema.org/draft-07/schema#"
+
+// JSONSchema is the subset of JSON Schema describing the policy types
+type JSONSchema struct {
+	Schema      string                 `json:"$schema,omitempty"`
+	Title       string                 `json:"title,omitempty"`
+	Ref         string                 `json:"$ref,omitempty"`
+	Type        string                 `json:"type,omitempty"`
+	Properties  map[string]*JSONSchema `json:"properties,omitempty"`
+	Definitions map[string]*JSONSchema `json:"definitions,omitempty"`
+	// AdditionalProperties is false for structs, which only accept their properties, and the schema
+	// of the values of maps
+	AdditionalProperties interface{} `json:"additionalProperties,omitempty"`
+	Items                *JSONSchema `json:"items,omitempty"`
+	Minimum              *float64    `json:"minimum,omitempty"`
+	// AnyOf lists the schemas a value matches at least one of
+	AnyOf []*JSONSchema `json:"anyOf,omitempty"`
+}
+
+// PolicySchema returns the JSON Schema of the DeschedulerPolicy of the given apiVersion, only accepting
+// the known strategies and, like the policy config file is decoded, no unknown field
+func PolicySchema(apiVersion string) (*JSONSchema, error) {
+	g := &schemaGenerator{definitions: map[string]*JSONSchema{}}
+	var schema *JSONSchema
+	switch apiVersion {
+	case v1alpha1.SchemeGroupVersion.String():
+		schema = g.structSchema(reflect.TypeOf(v1alpha1.DeschedulerPolicy{}))
+		g.restrictStrategies(schema.Properties["strategies"], reflect.TypeOf(v1alpha1.DeschedulerStrategy{}))
+	case v1alpha2.SchemeGroupVersion.String():
+		schema = g.structSchema(reflect.TypeOf(v1alpha2.DeschedulerPolicy{}))
+		profileType := reflect.TypeOf(v1alpha2.DeschedulerProfile{})
+		g.schemaForType(profileType)
+		g.restrictStrategies(g.definitions[profileType.Name()].Properties["strategies"], reflect.TypeOf(v1alpha2.DeschedulerStrategy{}))
+	default:
+		return nil, fmt.Errorf("unknown policy apiVersion %q", apiVersion)
+	}
+	schema.Schema = jsonSchemaDraft
+	schema.Title = "DeschedulerPolicy"
+	schema.Definitions = g.definitions
+	return schema, nil
+}
+
+// restrictStrategies makes the schema of a list of strategies only accept the known strategies
+func (g *schemaGenerator) restrictStrategies(strategies *JSONSchema, strategyType reflect.Type) {
+	strategies.Properties = map[string]*JSONSchema{}
+	for name := range strategyFunctions() {
+		strategies.Properties[string(name)] = g.schemaForType(strategyType)
+	}
+	strategies.AdditionalProperties = false
+}
+
+// StrategySchema returns the JSON Schema of the configuration of the strategy in the policy
+func StrategySchema(name api.StrategyName) (*JSONSchema, error) {
+	if _, ok := strategyFunctions()[name]; !ok {
+		return nil, fmt.Errorf("unknown strategy name %q", name)
+	}
+	g := &schemaGenerator{definitions: map[string]*JSONSchema{}}
+	schema := g.structSchema(reflect.TypeOf(v1alpha1.DeschedulerStrategy{}))
+	schema.Schema = jsonSchemaDraft
+	schema.Title = string(name)
+	schema.Definitions = g.definitions
+	return schema, nil
+}
+
+// schemaGenerator derives schemas from types, the structs referenced by other types being
+// defined once, which allows recursive types like PodFilter
+type schemaGenerator struct {
+	definitions map[string]*JSONSchema
+}
+
+// schemaForType derives the schema of a type from its JSON encoding: structs are objects with a property per
+// field with a json tag, maps are objects, slices are arrays and pointers have the schema of the type they point to
+func (g *schemaGenerator) schemaForType(t reflect.Type) *JSONSchema {
+	// quantities are encoded as strings, e.g. 100Mi, or as plain numbers
+	if t == reflect.TypeOf(resource.Quantity{}) {
+		return &JSONSchema{AnyOf: []*JSONSchema{{Type: "string"}, {Type: "number"}}}
+	}
+	// plugin arguments are objects the plugins decode themselves
+	if t == reflect.TypeOf(runtime.RawExtension{}) {
+		return &JSONSchema{Type: "object"}
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		return g.schemaForType(t.Elem())
+	case reflect.Bool:
+		return &JSONSchema{Type: "boolean"}
+	case reflect.String:
+		return &JSONSchema{Type: "string"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &JSONSchema{Type: "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		minimum := float64(0)
+		return &JSONSchema{Type: "integer", Minimum: &minimum}
+	case reflect.Float32, reflect.Float64:
+		return &JSONSchema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &JSONSchema{Type: "array", Items: g.schemaForType(t.Elem())}
+	case reflect.Map:
+		return &JSONSchema{Type: "object", AdditionalProperties: g.schemaForType(t.Elem())}
+	case reflect.Struct:
+		if _, ok := g.definitions[t.Name()]; !ok {
+			// defined before its fields so references to itself are not followed
+			g.definitions[t.Name()] = nil
+			g.definitions[t.Name()] = g.structSchema(t)
+		}
+		return &JSONSchema{Ref: "#/definitions/" + t.Name()}
+	}
+	return &JSONSchema{}
+}
+
+// structSchema returns the schema of the struct, the fields of inlined structs, e.g. metav1.TypeMeta,
+// being properties of the struct itself
+func (g *schemaGenerator) structSchema(t reflect.Type) *JSONSchema {
+	schema := &JSONSchema{Type: "object", Properties: map[string]*JSONSchema{}, AdditionalProperties: false}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" || field.PkgPath != "" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if name == "" {
+			if field.Anonymous || strings.Contains(tag, ",inline") {
+				for property, propertySchema := range g.structSchema(field.Type).Properties {
+					schema.Properties[property] = propertySchema
+				}
+				continue
+			}
+			name = field.Name
+		}
+		schema.Properties[name] = g.schemaForType(field.Type)
+	}
+	return schema
+}
+
+// validatePolicySchema checks the policy document only sets known properties with values of the expected type,
+// returning the errors of all the invalid values with their path in the document
+func validatePolicySchema(policy []byte) error {
+	var document interface{}
+	if err := yaml.Unmarshal(policy, &document); err != nil {
+		return err
+	}
+	apiVersion, err := policyAPIVersion(policy)
+	if err != nil {
+		return err
+	}
+	schema, err := PolicySchema(apiVersion)
+	if err != nil {
+		return err
+	}
+	return utilerrors.NewAggregate(schema.validate(schema.Definitions, "", document))
+}
+
+// validate checks the value against the schema, resolving references in the definitions
+func (s *JSONSchema) validate(definitions map[string]*JSONSchema, path string, value interface{}) []error {
+	// null values are decoded as zero values, whatever the type
+	if value == nil {
+		return nil
+	}
+	if s.Ref != "" {
+		return definitions[strings.TrimPrefix(s.Ref, "#/definitions/")].validate(definitions, path, value)
+	}
+	if len(s.AnyOf) > 0 {
+		types := make([]string, 0, len(s.AnyOf))
+		for _, schema := range s.AnyOf {
+			if len(schema.validate(definitions, path, value)) == 0 {
+				return nil
+			}
+			types = append(types, schema.Type)
+		}
+		return []error{fmt.Errorf("%s: expected %s, got %v", pathOrRoot(path), strings.Join(types, " or "), value)}
+	}
+	invalidType := func() []error {
+		return []error{fmt.Errorf("%s: expected %s, got %v", pathOrRoot(path), s.Type, value)}
+	}
+
+	switch s.Type {
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return invalidType()
+		}
+		keys := make([]string, 0, len(object))
+		for key := range object {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		var errs []error
+		for _, key := range keys {
+			property, ok := s.Properties[key]
+			if !ok {
+				additional, isSchema := s.AdditionalProperties.(*JSONSchema)
+				if !isSchema {
+					errs = append(errs, fmt.Errorf("%s: unknown field %q", pathOrRoot(path), key))
+					continue
+				}
+				property = additional
+			}
+			errs = append(errs, property.validate(definitions, joinPath(path, key), object[key])...)
+		}
+		return errs
+	case "array":
+		array, ok := value.([]interface{})
+		if !ok {
+			return invalidType()
+		}
+		var errs []error
+		for i, item := range array {
+			errs = append(errs, s.Items.validate(definitions, fmt.Sprintf("%s[%d]", path, i), item)...)
+		}
+		return errs
+	case "string":
+		if _, ok := value.(string); !ok {
+			return invalidType()
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return invalidType()
+		}
+	case "number", "integer":
+		number, ok := value.(float64)
+		if !ok || (s.Type == "integer" && number != math.Trunc(number)) {
+			return invalidType()
+		}
+		if s.Minimum != nil && number < *s.Minimum {
+			return []error{fmt.Errorf("%s: expected a value of at least %v, got %v", pathOrRoot(path), *s.Minimum, number)}
+		}
+	}
+	return nil
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func pathOrRoot(path string) string {
+	if path == "" {
+		return "policy"
+	}
+	return path
+}
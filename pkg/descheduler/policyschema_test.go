@@ -0,0 +1,155 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPolicySchemaValidation(t *testing.T) {
+	tests := []struct {
+		description    string
+		policy         string
+		expectedErrors []string
+	}{
+		{
+			description: "valid policy",
+			policy: `apiVersion: "descheduler/v1alpha1"
+kind: "DeschedulerPolicy"
+maxNoOfPodsToEvictPerNode: 5
+evictableEmptyDirSizeLimit: 100Mi
+evictionFilter:
+  anyOf:
+  - namespaces: ["default"]
+  - allOf:
+    - labelSelector:
+        matchLabels:
+          app: web
+strategies:
+  "LowNodeUtilization":
+     enabled: true
+     params:
+       nodeResourceUtilizationThresholds:
+         thresholds:
+           "cpu": 20
+           "nvidia.com/gpu": 10
+         targetThresholds:
+           "cpu": 50.5
+`,
+		},
+		{
+			description: "unknown fields and strategies",
+			policy: `apiVersion: "descheduler/v1alpha1"
+kind: "DeschedulerPolicy"
+maxNoOfPodToEvictPerNode: 5
+strategies:
+  "LowNodeUtilisation":
+     enabled: true
+  "LowNodeUtilization":
+     enabled: true
+     params:
+       nodeResourceUtilizationThresholds:
+         treshold:
+           "cpu": 20
+`,
+			expectedErrors: []string{
+				`policy: unknown field "maxNoOfPodToEvictPerNode"`,
+				`strategies: unknown field "LowNodeUtilisation"`,
+				`strategies.LowNodeUtilization.params.nodeResourceUtilizationThresholds: unknown field "treshold"`,
+			},
+		},
+		{
+			description: "values of the wrong type",
+			policy: `apiVersion: "descheduler/v1alpha1"
+kind: "DeschedulerPolicy"
+evictableEmptyDirSizeLimit: [100Mi]
+strategies:
+  "PodLifeTime":
+     enabled: true
+     params:
+       podLifeTime:
+         maxPodLifeTimeSeconds: -1
+       namespaces:
+         include: default
+`,
+			expectedErrors: []string{
+				"evictableEmptyDirSizeLimit: expected string or number, got [100Mi]",
+				"strategies.PodLifeTime.params.namespaces.include: expected array, got default",
+				"strategies.PodLifeTime.params.podLifeTime.maxPodLifeTimeSeconds: expected a value of at least 0, got -1",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			err := validatePolicySchema([]byte(tc.policy))
+			if len(tc.expectedErrors) == 0 {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Expected errors %v, got none", tc.expectedErrors)
+			}
+			for _, expected := range tc.expectedErrors {
+				if !strings.Contains(err.Error(), expected) {
+					t.Errorf("Expected error %q, got %v", expected, err)
+				}
+			}
+		})
+	}
+}
+
+func TestPolicySchemaExamples(t *testing.T) {
+	examples, err := filepath.Glob("../../examples/*.y*ml")
+	if err != nil || len(examples) == 0 {
+		t.Fatalf("Unable to find the example policies: %v", err)
+	}
+	for _, example := range examples {
+		if _, err := LoadPolicyConfig(example, nil); err != nil {
+			t.Errorf("Unexpected error loading example policy %s: %v", example, err)
+		}
+	}
+}
+
+func TestPolicySchemaIsJSON(t *testing.T) {
+	policySchema, err := PolicySchema("descheduler/v1alpha1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	data, err := json.Marshal(policySchema)
+	if err != nil {
+		t.Fatalf("Unable to encode the policy schema: %v", err)
+	}
+	var schema map[string]interface{}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatalf("Unable to decode the policy schema: %v", err)
+	}
+	definitions := schema["definitions"].(map[string]interface{})
+	for _, name := range []string{"DeschedulerStrategy", "StrategyParameters", "PodFilter", "LabelSelector"} {
+		if _, ok := definitions[name]; !ok {
+			t.Errorf("Expected a definition of %s", name)
+		}
+	}
+	if _, err := StrategySchema("Unknown"); err == nil {
+		t.Errorf("Expected an error for an unknown strategy")
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+)
+
+// policyProfiles returns the profiles of the policy, or for policies without profiles the default profile
+// formed by the top level strategies, node selector and eviction limits
+func policyProfiles(policy *api.DeschedulerPolicy) []api.DeschedulerProfile {
+	if len(policy.Profiles) > 0 {
+		return policy.Profiles
+	}
+	return []api.DeschedulerProfile{{
+		Name:                      api.DefaultProfileName,
+		Strategies:                policy.Strategies,
+		NodeSelector:              policy.NodeSelector,
+		MaxNoOfPodsToEvictPerNode: policy.MaxNoOfPodsToEvictPerNode,
+		MaxPerOwnerPerNode:        policy.MaxPerOwnerPerNode,
+	}}
+}
+
+// validateProfiles checks the profiles have unique names, which do not contain the separator of the profile
+// and strategy names, and are not combined with top level strategies
+func validateProfiles(policy *api.DeschedulerPolicy) error {
+	if len(policy.Profiles) == 0 {
+		return nil
+	}
+	if len(policy.Strategies) > 0 {
+		return fmt.Errorf("strategies can not be combined with profiles")
+	}
+	names := map[string]bool{}
+	for i, profile := range policy.Profiles {
+		if profile.Name == "" {
+			return fmt.Errorf("profiles[%d] has no name", i)
+		}
+		if strings.Contains(profile.Name, "/") {
+			return fmt.Errorf("profile name %q must not contain /", profile.Name)
+		}
+		if names[profile.Name] {
+			return fmt.Errorf("profile name %q is not unique", profile.Name)
+		}
+		names[profile.Name] = true
+	}
+	return nil
+}
+
+// profileStrategyName returns the name the strategy of the profile is reported under: the name of the strategy
+// prefixed by the name of the profile, or the name of the strategy alone for policies without profiles
+func profileStrategyName(policy *api.DeschedulerPolicy, profile string, name api.StrategyName) api.StrategyName {
+	if len(policy.Profiles) == 0 {
+		return name
+	}
+	return api.StrategyName(profile + "/" + string(name))
+}
+
+// strategyEnabled tells whether any of the profiles enables the strategy
+func strategyEnabled(profiles []api.DeschedulerProfile, name api.StrategyName) bool {
+	for _, profile := range profiles {
+		if profile.Strategies[name].Enabled {
+			return true
+		}
+	}
+	return false
+}
+
+// profileRun holds the nodes and evictors a profile runs with during a descheduling cycle
+type profileRun struct {
+	profile api.DeschedulerProfile
+	nodes   []*v1.Node
+	// nodeSelector selects the nodes of the profile, ready or not
+	nodeSelector labels.Selector
+	podEvictor   *evictions.PodEvictor
+	// dryRunPodEvictor is shared by the strategies of the profile in dry run mode, created when first needed
+	dryRunPodEvictor *evictions.PodEvictor
+}
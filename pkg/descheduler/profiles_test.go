@@ -0,0 +1,227 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+
+	"sigs.k8s.io/descheduler/cmd/descheduler/app/options"
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/api/v1alpha1"
+	"sigs.k8s.io/descheduler/pkg/api/v1alpha2"
+	"sigs.k8s.io/descheduler/pkg/descheduler/scheme"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestDecodeProfilesPolicy(t *testing.T) {
+	policy := `apiVersion: "descheduler/v1alpha2"
+kind: "DeschedulerPolicy"
+evictLocalStoragePods: true
+profiles:
+- name: gpu
+  nodeSelector: "accelerator=gpu"
+  maxNoOfPodsToEvictPerNode: 1
+  strategies:
+    "RemovePodsViolatingGPUModelPreference":
+      enabled: true
+- name: general
+  maxPerOwnerPerNode: 2
+  strategies:
+    "RemoveDuplicates":
+      enabled: true
+`
+	decoded, err := decodePolicyConfig("policy.yaml", []byte(policy), nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(decoded.Strategies) != 0 || decoded.EvictLocalStoragePods == nil || !*decoded.EvictLocalStoragePods {
+		t.Errorf("Expected the policy settings and no top level strategy, got %#v", decoded)
+	}
+	if len(decoded.Profiles) != 2 {
+		t.Fatalf("Expected 2 profiles, got %#v", decoded.Profiles)
+	}
+	gpu, general := decoded.Profiles[0], decoded.Profiles[1]
+	if gpu.Name != "gpu" || *gpu.NodeSelector != "accelerator=gpu" || *gpu.MaxNoOfPodsToEvictPerNode != 1 ||
+		!gpu.Strategies["RemovePodsViolatingGPUModelPreference"].Enabled {
+		t.Errorf("Unexpected gpu profile %#v", gpu)
+	}
+	if general.Name != "general" || general.NodeSelector != nil || *general.MaxPerOwnerPerNode != 2 ||
+		!general.Strategies["RemoveDuplicates"].Enabled {
+		t.Errorf("Unexpected general profile %#v", general)
+	}
+
+	invalidPolicies := map[string]string{
+		`profiles[0].strategies: unknown field "RemoveEverything"`: `apiVersion: "descheduler/v1alpha2"
+kind: "DeschedulerPolicy"
+profiles:
+- name: default
+  strategies:
+    "RemoveEverything":
+      enabled: true
+`,
+		`policy: unknown field "strategies"`: `apiVersion: "descheduler/v1alpha2"
+kind: "DeschedulerPolicy"
+strategies:
+  "RemoveDuplicates":
+    enabled: true
+`,
+		`profile name "default" is not unique`: `apiVersion: "descheduler/v1alpha2"
+kind: "DeschedulerPolicy"
+profiles:
+- name: default
+- name: default
+`,
+		`profiles[0] has no name`: `apiVersion: "descheduler/v1alpha2"
+kind: "DeschedulerPolicy"
+profiles:
+- strategies:
+    "RemoveDuplicates":
+      enabled: true
+`,
+	}
+	for expected, policy := range invalidPolicies {
+		if _, err := decodePolicyConfig("policy.yaml", []byte(policy), nil); err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected error %q, got %v", expected, err)
+		}
+	}
+}
+
+func TestProfilesConversion(t *testing.T) {
+	nodeSelector := "zone=a"
+	maxPods := 3
+	policy := &api.DeschedulerPolicy{
+		Strategies:                api.StrategyList{"RemoveDuplicates": api.DeschedulerStrategy{Enabled: true}},
+		NodeSelector:              &nodeSelector,
+		MaxNoOfPodsToEvictPerNode: &maxPods,
+	}
+
+	versioned := &v1alpha2.DeschedulerPolicy{}
+	if err := scheme.Scheme.Convert(policy, versioned, nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []v1alpha2.DeschedulerProfile{{
+		Name:                      api.DefaultProfileName,
+		Strategies:                v1alpha2.StrategyList{"RemoveDuplicates": v1alpha2.DeschedulerStrategy{Enabled: true}},
+		NodeSelector:              &nodeSelector,
+		MaxNoOfPodsToEvictPerNode: &maxPods,
+	}}
+	if !reflect.DeepEqual(versioned.Profiles, expected) {
+		t.Fatalf("Expected the default profile %#v, got %#v", expected, versioned.Profiles)
+	}
+
+	converted := &api.DeschedulerPolicy{}
+	if err := scheme.Scheme.Convert(versioned, converted, nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	v1alpha1Policy := &v1alpha1.DeschedulerPolicy{}
+	if err := scheme.Scheme.Convert(converted, v1alpha1Policy, nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !v1alpha1Policy.Strategies["RemoveDuplicates"].Enabled || *v1alpha1Policy.NodeSelector != nodeSelector ||
+		*v1alpha1Policy.MaxNoOfPodsToEvictPerNode != maxPods {
+		t.Errorf("Expected the single profile to be flattened, got %#v", v1alpha1Policy)
+	}
+
+	converted.Profiles = append(converted.Profiles, api.DeschedulerProfile{Name: "other"})
+	if err := scheme.Scheme.Convert(converted, &v1alpha1.DeschedulerPolicy{}, nil); err == nil {
+		t.Errorf("Expected policies with 2 profiles not to be converted to v1alpha1")
+	}
+}
+
+func TestProfilesRun(t *testing.T) {
+	ctx := context.Background()
+	taint := []v1.Taint{{Key: "key", Value: "value", Effect: v1.TaintEffectNoSchedule}}
+	zone := func(name string, tainted bool) *v1.Node {
+		return test.BuildTestNode(name, 2000, 3000, 10, func(node *v1.Node) {
+			node.Labels["zone"] = name[:1]
+			if tainted {
+				node.Spec.Taints = taint
+			}
+		})
+	}
+	nodes := []*v1.Node{zone("a1", true), zone("a2", false), zone("b1", true), zone("b2", false)}
+	var pods []*v1.Pod
+	for _, name := range []string{"a1-p1", "a1-p2", "b1-p1", "b1-p2"} {
+		pods = append(pods, test.BuildTestPod(name, 100, 0, name[:2], test.SetRSOwnerRef))
+	}
+
+	client := fakeclientset.NewSimpleClientset()
+	for _, node := range nodes {
+		client.Tracker().Add(node)
+	}
+	client.PrependReactor("list", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		fieldSelector := action.(core.ListAction).GetListRestrictions().Fields
+		list := &v1.PodList{}
+		for _, pod := range pods {
+			if fieldSelector.Matches(fields.Set{"spec.nodeName": pod.Spec.NodeName, "status.phase": string(pod.Status.Phase)}) {
+				list.Items = append(list.Items, *pod)
+			}
+		}
+		return true, list, nil
+	})
+	var evicted []string
+	client.PrependReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		if eviction, ok := action.(core.CreateAction).GetObject().(*v1beta1.Eviction); ok {
+			evicted = append(evicted, eviction.Name)
+			return true, nil, nil
+		}
+		return false, nil, nil
+	})
+
+	selectorA, selectorB := "zone=a", "zone=b"
+	one := 1
+	dp := &api.DeschedulerPolicy{
+		Profiles: []api.DeschedulerProfile{
+			{
+				Name:                      "a",
+				NodeSelector:              &selectorA,
+				MaxNoOfPodsToEvictPerNode: &one,
+				Strategies:                api.StrategyList{"RemovePodsViolatingNodeTaints": api.DeschedulerStrategy{Enabled: true}},
+			},
+			{
+				Name:         "b",
+				NodeSelector: &selectorB,
+				Strategies:   api.StrategyList{"RemovePodsViolatingNodeTaints": api.DeschedulerStrategy{Enabled: true}},
+			},
+		},
+	}
+
+	rs, err := options.NewDeschedulerServer()
+	if err != nil {
+		t.Fatalf("Unable to initialize server: %v", err)
+	}
+	rs.Client = client
+	if err := RunDeschedulerStrategies(ctx, rs, dp, "v1beta1", make(chan struct{})); err != nil {
+		t.Fatalf("Unable to run descheduler strategies: %v", err)
+	}
+
+	sort.Strings(evicted)
+	if expected := []string{"a1-p1", "b1-p1", "b1-p2"}; !reflect.DeepEqual(evicted, expected) {
+		t.Errorf("Expected evictions %v within the limits of each profile, got %v", expected, evicted)
+	}
+}
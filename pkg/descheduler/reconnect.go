@@ -0,0 +1,61 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clientset "k8s.io/client-go/kubernetes"
+)
+
+// reconnectPollInterval is the period the API server is probed at while the connection is lost
+var reconnectPollInterval = 5 * time.Second
+
+// apiServerReachable tells whether the API server answers. Strategies log most API errors and go on with the
+// next node, so a strategy which ran while the API server did not answer may have skipped nodes.
+var apiServerReachable = func(client clientset.Interface) bool {
+	_, err := client.Discovery().ServerVersion()
+	return err == nil
+}
+
+// reconnectDeadline returns until when a descheduling cycle started at cycleStart, and interrupted now, waits for
+// the API server: the reconnect timeout, without running into the next cycle
+func reconnectDeadline(now, cycleStart time.Time, timeout, interval time.Duration) time.Time {
+	deadline := now.Add(timeout)
+	if interval > 0 && cycleStart.Add(interval).Before(deadline) {
+		deadline = cycleStart.Add(interval)
+	}
+	return deadline
+}
+
+// waitForAPIServer probes the API server until it answers again. It returns false when the deadline of the clock
+// passes, or the descheduler stops, first.
+func waitForAPIServer(client clientset.Interface, clk clock.Clock, deadline time.Time, stopChannel <-chan struct{}) bool {
+	err := wait.PollImmediateUntil(reconnectPollInterval, func() (bool, error) {
+		if apiServerReachable(client) {
+			return true, nil
+		}
+		if !clk.Now().Before(deadline) {
+			return false, fmt.Errorf("API server unreachable until %v", deadline)
+		}
+		return false, nil
+	}, stopChannel)
+	return err == nil
+}
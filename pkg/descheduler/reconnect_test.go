@@ -0,0 +1,183 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/descheduler/cmd/descheduler/app/options"
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestReconnectDeadline(t *testing.T) {
+	cycleStart := time.Date(2021, 9, 1, 10, 0, 0, 0, time.UTC)
+	now := cycleStart.Add(time.Minute)
+	if deadline := reconnectDeadline(now, cycleStart, 2*time.Minute, 0); !deadline.Equal(now.Add(2 * time.Minute)) {
+		t.Errorf("Expected the reconnect timeout to bound the wait without interval, got %v", deadline)
+	}
+	if deadline := reconnectDeadline(now, cycleStart, 2*time.Minute, 5*time.Minute); !deadline.Equal(now.Add(2 * time.Minute)) {
+		t.Errorf("Expected the reconnect timeout to bound the wait within the interval, got %v", deadline)
+	}
+	if deadline := reconnectDeadline(now, cycleStart, 10*time.Minute, 5*time.Minute); !deadline.Equal(cycleStart.Add(5 * time.Minute)) {
+		t.Errorf("Expected the next cycle to bound the wait, got %v", deadline)
+	}
+}
+
+func TestResumeAfterLostConnection(t *testing.T) {
+	ctx := context.Background()
+	n1 := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	n2 := test.BuildTestNode("n2", 2000, 3000, 10, nil)
+
+	var run []api.StrategyName
+	fakeStrategy := func(name api.StrategyName) strategyFunction {
+		return func(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor) error {
+			run = append(run, name)
+			return nil
+		}
+	}
+	defer func(funcs map[api.StrategyName]strategyFunction, reachable func(clientset.Interface) bool, interval time.Duration) {
+		strategyFuncs, apiServerReachable, reconnectPollInterval = funcs, reachable, interval
+	}(strategyFuncs, apiServerReachable, reconnectPollInterval)
+	strategyFuncs = map[api.StrategyName]strategyFunction{"PodLifeTime": fakeStrategy("PodLifeTime"), "RemoveDuplicates": fakeStrategy("RemoveDuplicates")}
+	reconnectPollInterval = time.Millisecond
+
+	tests := []struct {
+		description string
+		// reachable answers the probes of the API server in turn, the last answer is repeated
+		reachable        []bool
+		reconnectTimeout time.Duration
+		expectedRun      []api.StrategyName
+		expectedErr      string
+	}{
+		{
+			description: "cycles are not resumed by default",
+			reachable:   []bool{false},
+			expectedRun: []api.StrategyName{"PodLifeTime", "RemoveDuplicates"},
+		},
+		{
+			description:      "the interrupted strategy runs again once reconnected",
+			reachable:        []bool{false, false, true},
+			reconnectTimeout: time.Minute,
+			expectedRun:      []api.StrategyName{"PodLifeTime", "PodLifeTime", "RemoveDuplicates"},
+		},
+		{
+			description:      "the remaining strategies are skipped when the connection is not restored in time",
+			reachable:        []bool{false},
+			reconnectTimeout: 10 * time.Millisecond,
+			expectedRun:      []api.StrategyName{"PodLifeTime"},
+			expectedErr:      "strategy PodLifeTime failed: lost the connection to the API server",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			run = nil
+			probes := 0
+			apiServerReachable = func(clientset.Interface) bool {
+				answer := tc.reachable[len(tc.reachable)-1]
+				if probes < len(tc.reachable) {
+					answer = tc.reachable[probes]
+				}
+				probes++
+				return answer
+			}
+
+			rs, err := options.NewDeschedulerServer()
+			if err != nil {
+				t.Fatalf("Unable to initialize server: %v", err)
+			}
+			rs.Client = fakeclientset.NewSimpleClientset(n1, n2)
+			rs.ReconnectTimeout = tc.reconnectTimeout
+			dp := &api.DeschedulerPolicy{Strategies: api.StrategyList{
+				"PodLifeTime":      api.DeschedulerStrategy{Enabled: true},
+				"RemoveDuplicates": api.DeschedulerStrategy{Enabled: true},
+			}}
+			err = RunDeschedulerStrategies(ctx, rs, dp, "v1beta1", make(chan struct{}))
+			if tc.expectedErr == "" && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+			if tc.expectedErr != "" && (err == nil || !strings.Contains(err.Error(), tc.expectedErr)) {
+				t.Errorf("Expected error %q, got %v", tc.expectedErr, err)
+			}
+			if !reflect.DeepEqual(run, tc.expectedRun) {
+				t.Errorf("Expected strategies %v to run, got %v", tc.expectedRun, run)
+			}
+		})
+	}
+}
+
+func TestResumeSkipsVisitedNodes(t *testing.T) {
+	ctx := context.Background()
+	nodes := []*v1.Node{
+		test.BuildTestNode("n1", 2000, 3000, 10, nil),
+		test.BuildTestNode("n2", 2000, 3000, 10, nil),
+		test.BuildTestNode("n3", 2000, 3000, 10, nil),
+	}
+
+	// the connection is lost once while the strategy processes the second node, and restored at the second probe
+	connected, disconnected := true, false
+	var visited []string
+	disconnectingStrategy := func(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor) error {
+		for _, node := range nodes {
+			if !podEvictor.VisitNode(node) {
+				continue
+			}
+			visited = append(visited, node.Name)
+			if node.Name == "n2" && !disconnected {
+				connected, disconnected = false, true
+				return fmt.Errorf("unable to list pods of node %v: connection refused", node.Name)
+			}
+		}
+		return nil
+	}
+	defer func(funcs map[api.StrategyName]strategyFunction, reachable func(clientset.Interface) bool, interval time.Duration) {
+		strategyFuncs, apiServerReachable, reconnectPollInterval = funcs, reachable, interval
+	}(strategyFuncs, apiServerReachable, reconnectPollInterval)
+	strategyFuncs = map[api.StrategyName]strategyFunction{"PodLifeTime": disconnectingStrategy}
+	reconnectPollInterval = time.Millisecond
+	apiServerReachable = func(clientset.Interface) bool {
+		reachable := connected
+		connected = true
+		return reachable
+	}
+
+	rs, err := options.NewDeschedulerServer()
+	if err != nil {
+		t.Fatalf("Unable to initialize server: %v", err)
+	}
+	rs.Client = fakeclientset.NewSimpleClientset(nodes[0], nodes[1], nodes[2])
+	rs.Deterministic = true
+	rs.ReconnectTimeout = time.Minute
+	dp := &api.DeschedulerPolicy{Strategies: api.StrategyList{"PodLifeTime": api.DeschedulerStrategy{Enabled: true}}}
+	if err := RunDeschedulerStrategies(ctx, rs, dp, "v1beta1", make(chan struct{})); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if expected := []string{"n1", "n2", "n3"}; !reflect.DeepEqual(visited, expected) {
+		t.Errorf("Expected nodes %v to be visited once each, got %v", expected, visited)
+	}
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package remotewrite sends the node usages computed during a descheduling cycle to a Prometheus
+// remote write endpoint, so their history is kept without recording rules on per-node requests.
+package remotewrite
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang/snappy"
+	"google.golang.org/protobuf/encoding/protowire"
+	v1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+)
+
+const (
+	// DefaultTimeoutSeconds bounds the duration of a request when not configured
+	DefaultTimeoutSeconds = 30
+
+	// UtilizationMetric is the usage of a resource of a node, in percents of its allocatable quantity
+	UtilizationMetric = "descheduler_node_utilization_percent"
+	// ClassificationMetric is 1 for the classification of a node
+	ClassificationMetric = "descheduler_node_classification"
+)
+
+// Writer sends node usages to a remote write endpoint
+type Writer struct {
+	client          *http.Client
+	url             string
+	bearerTokenFile string
+}
+
+// NewWriter returns a Writer for the given configuration, unset fields are defaulted
+func NewWriter(config *api.RemoteWrite) *Writer {
+	timeoutSeconds := uint(DefaultTimeoutSeconds)
+	if config.TimeoutSeconds != nil && *config.TimeoutSeconds > 0 {
+		timeoutSeconds = *config.TimeoutSeconds
+	}
+	return &Writer{
+		client:          &http.Client{Timeout: time.Duration(timeoutSeconds) * time.Second},
+		url:             config.URL,
+		bearerTokenFile: config.BearerTokenFile,
+	}
+}
+
+// Write sends one sample per node, strategy and resource of the usages, and one sample per node and strategy
+// of the classifications, all with the given timestamp
+func (w *Writer) Write(ctx context.Context, timestamp time.Time, utilizations []evictions.NodeUtilization) error {
+	if len(utilizations) == 0 {
+		return nil
+	}
+	body := snappy.Encode(nil, encodeWriteRequest(timestamp, utilizations))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("unable to create remote write request: %v", err)
+	}
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+	if w.bearerTokenFile != "" {
+		token, err := ioutil.ReadFile(w.bearerTokenFile)
+		if err != nil {
+			return fmt.Errorf("unable to read bearer token file %q: %v", w.bearerTokenFile, err)
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("unable to send node usages to %s: %v", w.url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		message, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("remote write endpoint %s answered %s: %s", w.url, resp.Status, strings.TrimSpace(string(message)))
+	}
+	return nil
+}
+
+type label struct {
+	name, value string
+}
+
+// encodeWriteRequest encodes the usages as a prometheus.WriteRequest protobuf message:
+// WriteRequest{repeated TimeSeries timeseries = 1}, TimeSeries{repeated Label labels = 1; repeated Sample samples = 2},
+// Label{string name = 1; string value = 2} and Sample{double value = 1; int64 timestamp = 2}
+func encodeWriteRequest(timestamp time.Time, utilizations []evictions.NodeUtilization) []byte {
+	milliseconds := timestamp.UnixNano() / int64(time.Millisecond)
+	var request []byte
+	appendSeries := func(value float64, labels ...label) {
+		sort.Slice(labels, func(i, j int) bool { return labels[i].name < labels[j].name })
+		var series []byte
+		for _, l := range labels {
+			var encoded []byte
+			encoded = protowire.AppendTag(encoded, 1, protowire.BytesType)
+			encoded = protowire.AppendString(encoded, l.name)
+			encoded = protowire.AppendTag(encoded, 2, protowire.BytesType)
+			encoded = protowire.AppendString(encoded, l.value)
+			series = protowire.AppendTag(series, 1, protowire.BytesType)
+			series = protowire.AppendBytes(series, encoded)
+		}
+		var sample []byte
+		sample = protowire.AppendTag(sample, 1, protowire.Fixed64Type)
+		sample = protowire.AppendFixed64(sample, math.Float64bits(value))
+		sample = protowire.AppendTag(sample, 2, protowire.VarintType)
+		sample = protowire.AppendVarint(sample, uint64(milliseconds))
+		series = protowire.AppendTag(series, 2, protowire.BytesType)
+		series = protowire.AppendBytes(series, sample)
+
+		request = protowire.AppendTag(request, 1, protowire.BytesType)
+		request = protowire.AppendBytes(request, series)
+	}
+
+	for _, utilization := range utilizations {
+		resourceNames := make([]string, 0, len(utilization.Usage))
+		for name := range utilization.Usage {
+			resourceNames = append(resourceNames, string(name))
+		}
+		sort.Strings(resourceNames)
+		for _, name := range resourceNames {
+			appendSeries(utilization.Usage[v1.ResourceName(name)],
+				label{"__name__", UtilizationMetric},
+				label{"strategy", utilization.Strategy},
+				label{"node", utilization.Node},
+				label{"resource", name},
+			)
+		}
+		appendSeries(1,
+			label{"__name__", ClassificationMetric},
+			label{"strategy", utilization.Strategy},
+			label{"node", utilization.Node},
+			label{"classification", utilization.Classification},
+		)
+	}
+	return request
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remotewrite
+
+import (
+	"context"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	"google.golang.org/protobuf/encoding/protowire"
+	v1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+)
+
+// decodedSeries is a time series of a WriteRequest with a single sample, its labels in the name=value form
+type decodedSeries struct {
+	labels    []string
+	value     float64
+	timestamp int64
+}
+
+// decodeWriteRequest decodes the fields of a WriteRequest the writer encodes
+func decodeWriteRequest(t *testing.T, request []byte) []decodedSeries {
+	// fields returns the fields of a message by number, in order
+	fields := func(message []byte) map[protowire.Number][][]byte {
+		decoded := map[protowire.Number][][]byte{}
+		for len(message) > 0 {
+			number, typ, n := protowire.ConsumeTag(message)
+			if n < 0 {
+				t.Fatalf("Invalid tag: %v", protowire.ParseError(n))
+			}
+			message = message[n:]
+			var value []byte
+			switch typ {
+			case protowire.BytesType:
+				value, n = protowire.ConsumeBytes(message)
+			case protowire.Fixed64Type:
+				n = 8
+				value = message[:n]
+			case protowire.VarintType:
+				_, n = protowire.ConsumeVarint(message)
+				value = message[:n]
+			default:
+				t.Fatalf("Unexpected wire type %v", typ)
+			}
+			if n < 0 {
+				t.Fatalf("Invalid field %v: %v", number, protowire.ParseError(n))
+			}
+			decoded[number] = append(decoded[number], value)
+			message = message[n:]
+		}
+		return decoded
+	}
+
+	var series []decodedSeries
+	for _, encodedSeries := range fields(request)[1] {
+		seriesFields := fields(encodedSeries)
+		var decoded decodedSeries
+		for _, encodedLabel := range seriesFields[1] {
+			labelFields := fields(encodedLabel)
+			decoded.labels = append(decoded.labels, string(labelFields[1][0])+"="+string(labelFields[2][0]))
+		}
+		sampleFields := fields(seriesFields[2][0])
+		bits, _ := protowire.ConsumeFixed64(sampleFields[1][0])
+		decoded.value = math.Float64frombits(bits)
+		timestamp, _ := protowire.ConsumeVarint(sampleFields[2][0])
+		decoded.timestamp = int64(timestamp)
+		series = append(series, decoded)
+	}
+	return series
+}
+
+func TestWrite(t *testing.T) {
+	var body []byte
+	var headers http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header
+		compressed, _ := ioutil.ReadAll(r.Body)
+		var err error
+		if body, err = snappy.Decode(nil, compressed); err != nil {
+			t.Errorf("Unable to decompress request: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	timestamp := time.Unix(1600000000, 0)
+	utilizations := []evictions.NodeUtilization{
+		{Strategy: "LowNodeUtilization", Node: "n1", Classification: "Overutilized", Usage: map[v1.ResourceName]float64{v1.ResourceMemory: 85, v1.ResourceCPU: 90}},
+		{Strategy: "LowNodeUtilization", Node: "n2", Classification: "Underutilized", Usage: map[v1.ResourceName]float64{v1.ResourceCPU: 10}},
+	}
+	if err := NewWriter(&api.RemoteWrite{URL: server.URL}).Write(context.Background(), timestamp, utilizations); err != nil {
+		t.Fatalf("Unexpected error writing node utilizations: %v", err)
+	}
+
+	if headers.Get("Content-Encoding") != "snappy" || headers.Get("X-Prometheus-Remote-Write-Version") != "0.1.0" {
+		t.Errorf("Expected remote write headers, got %v", headers)
+	}
+	expected := []decodedSeries{
+		{labels: []string{"__name__=" + UtilizationMetric, "node=n1", "resource=cpu", "strategy=LowNodeUtilization"}, value: 90},
+		{labels: []string{"__name__=" + UtilizationMetric, "node=n1", "resource=memory", "strategy=LowNodeUtilization"}, value: 85},
+		{labels: []string{"__name__=" + ClassificationMetric, "classification=Overutilized", "node=n1", "strategy=LowNodeUtilization"}, value: 1},
+		{labels: []string{"__name__=" + UtilizationMetric, "node=n2", "resource=cpu", "strategy=LowNodeUtilization"}, value: 10},
+		{labels: []string{"__name__=" + ClassificationMetric, "classification=Underutilized", "node=n2", "strategy=LowNodeUtilization"}, value: 1},
+	}
+	for i := range expected {
+		expected[i].timestamp = 1600000000000
+	}
+	if series := decodeWriteRequest(t, body); !reflect.DeepEqual(series, expected) {
+		t.Errorf("Expected series %v, got %v", expected, series)
+	}
+}
+
+func TestWriteError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "out of order sample", http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	utilizations := []evictions.NodeUtilization{{Strategy: "HighNodeUtilization", Node: "n1", Classification: "Underutilized"}}
+	err := NewWriter(&api.RemoteWrite{URL: server.URL}).Write(context.Background(), time.Now(), utilizations)
+	if err == nil || !strings.Contains(err.Error(), "out of order sample") {
+		t.Errorf("Expected the error of the endpoint to be returned, got %v", err)
+	}
+}
@@ -0,0 +1,249 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package report summarizes a descheduling cycle, the pods evicted or, in dry run mode, the pods which
+// would have been evicted by every strategy and the node usages computed by the strategies, in a format
+// meant to be read by humans or pasted into change requests.
+package report
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/descheduler/history"
+)
+
+const (
+	FormatJSON     = "json"
+	FormatMarkdown = "markdown"
+	FormatCSV      = "csv"
+)
+
+// Report is the summary of a descheduling cycle
+type Report struct {
+	history.Cycle
+	// NodeUtilizations are the node usages, in percents of the allocatable resources, and classifications
+	// computed by the strategies
+	NodeUtilizations []NodeUtilization `json:"nodeUtilizations,omitempty"`
+}
+
+// NodeUtilization is the usage and classification of a node computed by a strategy
+type NodeUtilization struct {
+	Strategy       string             `json:"strategy"`
+	Node           string             `json:"node"`
+	Classification string             `json:"classification"`
+	Usage          map[string]float64 `json:"usage"`
+}
+
+// New returns the report of the cycle with the node usages computed during the cycle
+func New(cycle history.Cycle, utilizations []evictions.NodeUtilization) Report {
+	report := Report{Cycle: cycle}
+	for _, utilization := range utilizations {
+		usage := map[string]float64{}
+		for name, value := range utilization.Usage {
+			usage[string(name)] = value
+		}
+		report.NodeUtilizations = append(report.NodeUtilizations, NodeUtilization{
+			Strategy:       utilization.Strategy,
+			Node:           utilization.Node,
+			Classification: utilization.Classification,
+			Usage:          usage,
+		})
+	}
+	return report
+}
+
+// ValidateFormat checks the format is one of the supported report formats, or empty
+func ValidateFormat(format string) error {
+	switch format {
+	case "", FormatJSON, FormatMarkdown, FormatCSV:
+		return nil
+	default:
+		return fmt.Errorf("report format %q is not one of %q, %q, %q", format, FormatJSON, FormatMarkdown, FormatCSV)
+	}
+}
+
+// Write writes the report in the given format
+func Write(w io.Writer, format string, report Report) error {
+	switch format {
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	case FormatMarkdown:
+		return writeMarkdown(w, report)
+	case FormatCSV:
+		return writeCSV(w, report)
+	default:
+		return ValidateFormat(format)
+	}
+}
+
+// writeMarkdown writes a table of the evictions of every strategy and a table of the node usages computed by every
+// strategy, followed by the pods not evicted because they do not fit on any other node and the failed strategies
+func writeMarkdown(w io.Writer, report Report) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Descheduling cycle %s\n", report.Start.UTC().Format(time.RFC3339))
+
+	// strategies are listed in the order they ran
+	var strategies []string
+	byStrategy := map[string][]history.Eviction{}
+	for _, eviction := range report.Evictions {
+		if _, ok := byStrategy[eviction.Strategy]; !ok {
+			strategies = append(strategies, eviction.Strategy)
+		}
+		byStrategy[eviction.Strategy] = append(byStrategy[eviction.Strategy], eviction)
+	}
+	fmt.Fprintf(&b, "\n## Evicted pods\n")
+	if len(report.Evictions) == 0 {
+		fmt.Fprintf(&b, "\nNo pod evicted.\n")
+	}
+	for _, strategy := range strategies {
+		fmt.Fprintf(&b, "\n### %s\n\n", strategy)
+		fmt.Fprintf(&b, "| Namespace | Pod | Node | Owner | Cause | Dry run |\n")
+		fmt.Fprintf(&b, "|-----------|-----|------|-------|-------|---------|\n")
+		for _, eviction := range byStrategy[strategy] {
+			fmt.Fprintf(&b, "| %s | %s | %s | %s | %s | %t |\n", eviction.Namespace, eviction.Pod, eviction.Node,
+				owner(eviction.OwnerKind, eviction.OwnerName), eviction.Cause, eviction.DryRun)
+		}
+	}
+
+	if len(report.NodeUtilizations) > 0 {
+		fmt.Fprintf(&b, "\n## Node utilization\n")
+		for _, strategy := range utilizationStrategies(report.NodeUtilizations) {
+			resources := utilizationResources(report.NodeUtilizations, strategy)
+			fmt.Fprintf(&b, "\n### %s\n\n", strategy)
+			fmt.Fprintf(&b, "| Node | Classification |")
+			for _, resource := range resources {
+				fmt.Fprintf(&b, " %s %% |", resource)
+			}
+			fmt.Fprintf(&b, "\n|------|----------------|%s\n", strings.Repeat("------|", len(resources)))
+			for _, utilization := range report.NodeUtilizations {
+				if utilization.Strategy != strategy {
+					continue
+				}
+				fmt.Fprintf(&b, "| %s | %s |", utilization.Node, utilization.Classification)
+				for _, resource := range resources {
+					fmt.Fprintf(&b, " %s |", formatPercentage(utilization.Usage, resource))
+				}
+				fmt.Fprintf(&b, "\n")
+			}
+		}
+	}
+
+	if len(report.NodeFitFailures) > 0 {
+		fmt.Fprintf(&b, "\n## Pods not evicted\n\n")
+		fmt.Fprintf(&b, "| Namespace | Pod | Node | Dry run | Reason |\n")
+		fmt.Fprintf(&b, "|-----------|-----|------|---------|--------|\n")
+		for _, failure := range report.NodeFitFailures {
+			fmt.Fprintf(&b, "| %s | %s | %s | %t | %s |\n", failure.Namespace, failure.Pod, failure.Node, failure.DryRun, failure.Message)
+		}
+	}
+
+	if len(report.StrategyErrors) > 0 {
+		fmt.Fprintf(&b, "\n## Failed strategies\n\n")
+		fmt.Fprintf(&b, "| Strategy | Reason | Message |\n")
+		fmt.Fprintf(&b, "|----------|--------|---------|\n")
+		for _, strategyErr := range report.StrategyErrors {
+			fmt.Fprintf(&b, "| %s | %s | %s |\n", strategyErr.Strategy, strategyErr.Reason, strings.ReplaceAll(strategyErr.Message, "|", "\\|"))
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeCSV writes the evictions and, after an empty line, the node usages, both with a header
+func writeCSV(w io.Writer, report Report) error {
+	cw := csv.NewWriter(w)
+	start := report.Start.UTC().Format(time.RFC3339)
+	cw.Write([]string{"cycle", "strategy", "namespace", "pod", "node", "ownerKind", "ownerName", "cause", "dryRun"})
+	for _, eviction := range report.Evictions {
+		cw.Write([]string{start, eviction.Strategy, eviction.Namespace, eviction.Pod, eviction.Node,
+			eviction.OwnerKind, eviction.OwnerName, string(eviction.Cause), strconv.FormatBool(eviction.DryRun)})
+	}
+
+	if len(report.NodeUtilizations) > 0 {
+		resources := utilizationResources(report.NodeUtilizations, "")
+		cw.Write(nil)
+		cw.Write(append([]string{"cycle", "strategy", "node", "classification"}, resources...))
+		for _, utilization := range report.NodeUtilizations {
+			record := []string{start, utilization.Strategy, utilization.Node, utilization.Classification}
+			for _, resource := range resources {
+				record = append(record, formatPercentage(utilization.Usage, resource))
+			}
+			cw.Write(record)
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// utilizationStrategies returns the strategies which computed node usages, in the order they ran
+func utilizationStrategies(utilizations []NodeUtilization) []string {
+	var strategies []string
+	seen := map[string]bool{}
+	for _, utilization := range utilizations {
+		if !seen[utilization.Strategy] {
+			seen[utilization.Strategy] = true
+			strategies = append(strategies, utilization.Strategy)
+		}
+	}
+	return strategies
+}
+
+// utilizationResources returns the sorted resources of the node usages computed by the strategy, or by all
+// strategies when empty
+func utilizationResources(utilizations []NodeUtilization, strategy string) []string {
+	resources := map[string]bool{}
+	for _, utilization := range utilizations {
+		if strategy != "" && utilization.Strategy != strategy {
+			continue
+		}
+		for resource := range utilization.Usage {
+			resources[resource] = true
+		}
+	}
+	names := make([]string, 0, len(resources))
+	for resource := range resources {
+		names = append(names, resource)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func formatPercentage(usage map[string]float64, resource string) string {
+	value, ok := usage[resource]
+	if !ok {
+		return ""
+	}
+	return strconv.FormatFloat(value, 'f', 1, 64)
+}
+
+func owner(kind, name string) string {
+	if kind == "" {
+		return "<none>"
+	}
+	return kind + "/" + name
+}
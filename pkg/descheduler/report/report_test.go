@@ -0,0 +1,129 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/descheduler/history"
+)
+
+func testReport() Report {
+	start := metav1.NewTime(time.Date(2021, 9, 1, 10, 0, 0, 0, time.UTC))
+	cycle := history.Cycle{
+		Start: start,
+		Evictions: []history.Eviction{
+			{Namespace: "default", Pod: "web-1", Node: "n1", Strategy: "LowNodeUtilization", Cause: evictions.CauseNodeOverutilized, DryRun: true, OwnerKind: "ReplicaSet", OwnerName: "web"},
+			{Namespace: "default", Pod: "batch-1", Node: "n2", Strategy: "PodLifeTime", Cause: evictions.CausePodLifeTimeExceeded, DryRun: true},
+			{Namespace: "default", Pod: "web-2", Node: "n1", Strategy: "LowNodeUtilization", Cause: evictions.CauseNodeOverutilized, DryRun: true, OwnerKind: "ReplicaSet", OwnerName: "web"},
+		},
+	}
+	return New(cycle, []evictions.NodeUtilization{
+		{Strategy: "LowNodeUtilization", Node: "n1", Classification: "Overutilized", Usage: map[v1.ResourceName]float64{v1.ResourceCPU: 85, v1.ResourceMemory: 60.25}},
+		{Strategy: "LowNodeUtilization", Node: "n3", Classification: "Underutilized", Usage: map[v1.ResourceName]float64{v1.ResourceCPU: 10, v1.ResourceMemory: 5}},
+	})
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	var b bytes.Buffer
+	if err := Write(&b, FormatMarkdown, testReport()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := `# Descheduling cycle 2021-09-01T10:00:00Z
+
+## Evicted pods
+
+### LowNodeUtilization
+
+| Namespace | Pod | Node | Owner | Cause | Dry run |
+|-----------|-----|------|-------|-------|---------|
+| default | web-1 | n1 | ReplicaSet/web | NodeOverutilized | true |
+| default | web-2 | n1 | ReplicaSet/web | NodeOverutilized | true |
+
+### PodLifeTime
+
+| Namespace | Pod | Node | Owner | Cause | Dry run |
+|-----------|-----|------|-------|-------|---------|
+| default | batch-1 | n2 | <none> | PodLifeTimeExceeded | true |
+
+## Node utilization
+
+### LowNodeUtilization
+
+| Node | Classification | cpu % | memory % |
+|------|----------------|------|------|
+| n1 | Overutilized | 85.0 | 60.2 |
+| n3 | Underutilized | 10.0 | 5.0 |
+`
+	if b.String() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, b.String())
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var b bytes.Buffer
+	if err := Write(&b, FormatCSV, testReport()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := `cycle,strategy,namespace,pod,node,ownerKind,ownerName,cause,dryRun
+2021-09-01T10:00:00Z,LowNodeUtilization,default,web-1,n1,ReplicaSet,web,NodeOverutilized,true
+2021-09-01T10:00:00Z,PodLifeTime,default,batch-1,n2,,,PodLifeTimeExceeded,true
+2021-09-01T10:00:00Z,LowNodeUtilization,default,web-2,n1,ReplicaSet,web,NodeOverutilized,true
+
+cycle,strategy,node,classification,cpu,memory
+2021-09-01T10:00:00Z,LowNodeUtilization,n1,Overutilized,85.0,60.2
+2021-09-01T10:00:00Z,LowNodeUtilization,n3,Underutilized,10.0,5.0
+`
+	if b.String() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, b.String())
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var b bytes.Buffer
+	if err := Write(&b, FormatJSON, testReport()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(b.Bytes(), &decoded); err != nil {
+		t.Fatalf("Unable to decode the report: %v", err)
+	}
+	if evicted := decoded["evictions"].([]interface{}); len(evicted) != 3 {
+		t.Errorf("Expected 3 evictions, got %v", evicted)
+	}
+	if utilizations := decoded["nodeUtilizations"].([]interface{}); len(utilizations) != 2 {
+		t.Errorf("Expected 2 node utilizations, got %v", utilizations)
+	}
+}
+
+func TestValidateFormat(t *testing.T) {
+	for _, format := range []string{"", FormatJSON, FormatMarkdown, FormatCSV} {
+		if err := ValidateFormat(format); err != nil {
+			t.Errorf("Unexpected error for format %q: %v", format, err)
+		}
+	}
+	if err := ValidateFormat("yaml"); err == nil {
+		t.Errorf("Expected an error for an unknown format")
+	}
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategies
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/validation"
+)
+
+type antiColocationPair struct {
+	keep, evict labels.Selector
+}
+
+// validatedAntiColocationStrategyParams contains validated strategy parameters
+type validatedAntiColocationStrategyParams struct {
+	validation.ValidatedStrategyParams
+	pairs []antiColocationPair
+}
+
+// RemovePodsViolatingAntiColocation evicts pods which share a node with pods they compete with.
+// Each configured pair consists of two label selectors, pods matching the "evict" selector
+// are evicted from every node which also runs a pod matching the "keep" selector.
+func RemovePodsViolatingAntiColocation(
+	ctx context.Context,
+	client clientset.Interface,
+	strategy api.DeschedulerStrategy,
+	nodes []*v1.Node,
+	podEvictor *evictions.PodEvictor,
+) error {
+	strategyParams, err := validateAndParseAntiColocationParams(ctx, client, strategy.Params)
+	if err != nil {
+		return validation.NewStrategyError(validation.ErrorReasonInvalidParameters, "invalid RemovePodsViolatingAntiColocation parameters", err)
+	}
+
+	evictable := podEvictor.Evictable(
+		evictions.WithPriorityThreshold(strategyParams.ThresholdPriority),
+		evictions.WithNodeFit(strategyParams.NodeFit),
+		evictions.WithLabelSelector(strategyParams.LabelSelector),
+	)
+
+	for _, node := range nodes {
+		if !podEvictor.VisitNode(node) {
+			continue
+		}
+		klog.V(1).InfoS("Processing node", "node", klog.KObj(node))
+		// Pods to keep are looked up among all pods of the node, a pod protected
+		// from eviction still competes with the pods scheduled next to it.
+		pods, err := podutil.ListPodsOnANode(ctx, client, node)
+		if err != nil {
+			klog.ErrorS(err, "Error listing pods on node", "node", klog.KObj(node))
+			continue
+		}
+
+		for _, pod := range podsViolatingAntiColocation(pods, strategyParams.pairs) {
+			if (len(strategyParams.IncludedNamespaces) > 0 && !strategyParams.IncludedNamespaces.Has(pod.Namespace)) ||
+				(len(strategyParams.ExcludedNamespaces) > 0 && strategyParams.ExcludedNamespaces.Has(pod.Namespace)) {
+				continue
+			}
+			if !evictable.IsEvictable(pod) {
+				continue
+			}
+			if _, err := podEvictor.EvictPod(ctx, pod, node, evictions.ReasonRemovePodsViolatingAntiColocation); err != nil {
+				klog.ErrorS(err, "Error evicting pod", "pod", klog.KObj(pod))
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// podsViolatingAntiColocation returns pods matching the evict selector of a pair
+// whose keep selector matches another pod from the same list.
+func podsViolatingAntiColocation(pods []*v1.Pod, pairs []antiColocationPair) []*v1.Pod {
+	var violatingPods []*v1.Pod
+	seen := make(map[*v1.Pod]struct{})
+	for _, pair := range pairs {
+		for _, pod := range pods {
+			if _, ok := seen[pod]; ok || !pair.evict.Matches(labels.Set(pod.Labels)) {
+				continue
+			}
+			for _, competitor := range pods {
+				if competitor == pod || competitor.DeletionTimestamp != nil {
+					continue
+				}
+				if pair.keep.Matches(labels.Set(competitor.Labels)) {
+					klog.V(3).InfoS("Pod shares node with competing pod", "pod", klog.KObj(pod), "competitor", klog.KObj(competitor))
+					violatingPods = append(violatingPods, pod)
+					seen[pod] = struct{}{}
+					break
+				}
+			}
+		}
+	}
+	return violatingPods
+}
+
+func validateAndParseAntiColocationParams(
+	ctx context.Context,
+	client clientset.Interface,
+	params *api.StrategyParameters,
+) (*validatedAntiColocationStrategyParams, error) {
+	if params == nil || params.AntiColocation == nil || len(params.AntiColocation.Pairs) == 0 {
+		return nil, fmt.Errorf("AntiColocation pairs not set")
+	}
+
+	strategyParams, err := validation.ValidateAndParseStrategyParams(ctx, client, params)
+	if err != nil {
+		return nil, err
+	}
+
+	pairs := make([]antiColocationPair, 0, len(params.AntiColocation.Pairs))
+	for i, pair := range params.AntiColocation.Pairs {
+		if pair.Keep == nil || pair.Evict == nil {
+			return nil, fmt.Errorf("both keep and evict selectors of pair %d must be set", i)
+		}
+		keep, err := metav1.LabelSelectorAsSelector(pair.Keep)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse keep selector of pair %d: %v", i, err)
+		}
+		evict, err := metav1.LabelSelectorAsSelector(pair.Evict)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse evict selector of pair %d: %v", i, err)
+		}
+		if keep.Empty() || evict.Empty() {
+			return nil, fmt.Errorf("keep and evict selectors of pair %d can not be empty", i)
+		}
+		pairs = append(pairs, antiColocationPair{keep: keep, evict: evict})
+	}
+
+	return &validatedAntiColocationStrategyParams{
+		ValidatedStrategyParams: *strategyParams,
+		pairs:                   pairs,
+	}, nil
+}
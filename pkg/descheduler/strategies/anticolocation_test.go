@@ -0,0 +1,215 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategies
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestRemovePodsViolatingAntiColocation(t *testing.T) {
+	ctx := context.Background()
+
+	node1 := test.BuildTestNode("node1", 2000, 3000, 10, nil)
+	node2 := test.BuildTestNode("node2", 2000, 3000, 10, func(node *v1.Node) {
+		node.Spec.Unschedulable = true
+	})
+
+	buildPod := func(name string, labels map[string]string, apply func(*v1.Pod)) *v1.Pod {
+		return test.BuildTestPod(name, 100, 0, node1.Name, func(pod *v1.Pod) {
+			test.SetRSOwnerRef(pod)
+			pod.Labels = labels
+			if apply != nil {
+				apply(pod)
+			}
+		})
+	}
+
+	database := buildPod("database", map[string]string{"app": "database"}, nil)
+	criticalDatabase := buildPod("database", map[string]string{"app": "database"}, func(pod *v1.Pod) {
+		test.SetPodPriority(pod, 2000000000)
+	})
+	terminatingDatabase := buildPod("database", map[string]string{"app": "database"}, func(pod *v1.Pod) {
+		now := metav1.Now()
+		pod.DeletionTimestamp = &now
+	})
+	batch1 := buildPod("batch1", map[string]string{"workload": "batch"}, nil)
+	batch2 := buildPod("batch2", map[string]string{"workload": "batch"}, nil)
+	batchOtherNamespace := buildPod("batch3", map[string]string{"workload": "batch"}, func(pod *v1.Pod) {
+		pod.Namespace = "other"
+	})
+	web := buildPod("web", map[string]string{"app": "web"}, nil)
+
+	databaseBatchPair := api.AntiColocationPair{
+		Keep:  &metav1.LabelSelector{MatchLabels: map[string]string{"app": "database"}},
+		Evict: &metav1.LabelSelector{MatchLabels: map[string]string{"workload": "batch"}},
+	}
+
+	createStrategy := func(pairs []api.AntiColocationPair, namespaces *api.Namespaces, nodeFit bool) api.DeschedulerStrategy {
+		return api.DeschedulerStrategy{
+			Enabled: true,
+			Params: &api.StrategyParameters{
+				AntiColocation: &api.AntiColocation{Pairs: pairs},
+				Namespaces:     namespaces,
+				NodeFit:        nodeFit,
+			},
+		}
+	}
+
+	tests := []struct {
+		description             string
+		pods                    []*v1.Pod
+		nodes                   []*v1.Node
+		strategy                api.DeschedulerStrategy
+		maxPodsToEvictPerNode   int
+		expectedEvictedPodCount int
+	}{
+		{
+			description:             "Evict pods matching the evict selector next to a pod matching the keep selector",
+			pods:                    []*v1.Pod{database, batch1, batch2, web},
+			nodes:                   []*v1.Node{node1},
+			strategy:                createStrategy([]api.AntiColocationPair{databaseBatchPair}, nil, false),
+			expectedEvictedPodCount: 2,
+		},
+		{
+			description:             "No pod matching the keep selector, no evictions",
+			pods:                    []*v1.Pod{batch1, batch2, web},
+			nodes:                   []*v1.Node{node1},
+			strategy:                createStrategy([]api.AntiColocationPair{databaseBatchPair}, nil, false),
+			expectedEvictedPodCount: 0,
+		},
+		{
+			description:             "Non evictable pod matching the keep selector still triggers evictions",
+			pods:                    []*v1.Pod{criticalDatabase, batch1},
+			nodes:                   []*v1.Node{node1},
+			strategy:                createStrategy([]api.AntiColocationPair{databaseBatchPair}, nil, false),
+			expectedEvictedPodCount: 1,
+		},
+		{
+			description:             "Terminating pod matching the keep selector is ignored",
+			pods:                    []*v1.Pod{terminatingDatabase, batch1},
+			nodes:                   []*v1.Node{node1},
+			strategy:                createStrategy([]api.AntiColocationPair{databaseBatchPair}, nil, false),
+			expectedEvictedPodCount: 0,
+		},
+		{
+			description:             "Only pods from included namespaces are evicted",
+			pods:                    []*v1.Pod{database, batch1, batchOtherNamespace},
+			nodes:                   []*v1.Node{node1},
+			strategy:                createStrategy([]api.AntiColocationPair{databaseBatchPair}, &api.Namespaces{Include: []string{"other"}}, false),
+			expectedEvictedPodCount: 1,
+		},
+		{
+			description:             "Pod matching both selectors of a pair is not evicted because of itself",
+			pods:                    []*v1.Pod{buildPod("both", map[string]string{"app": "database", "workload": "batch"}, nil)},
+			nodes:                   []*v1.Node{node1},
+			strategy:                createStrategy([]api.AntiColocationPair{databaseBatchPair}, nil, false),
+			expectedEvictedPodCount: 0,
+		},
+		{
+			description: "Pod violating multiple pairs is evicted once",
+			pods:        []*v1.Pod{database, web, batch1},
+			nodes:       []*v1.Node{node1},
+			strategy: createStrategy([]api.AntiColocationPair{
+				databaseBatchPair,
+				{
+					Keep:  &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+					Evict: &metav1.LabelSelector{MatchLabels: map[string]string{"workload": "batch"}},
+				},
+			}, nil, false),
+			expectedEvictedPodCount: 1,
+		},
+		{
+			description:             "Evictions are limited by maxPodsToEvictPerNode",
+			pods:                    []*v1.Pod{database, batch1, batch2},
+			nodes:                   []*v1.Node{node1},
+			strategy:                createStrategy([]api.AntiColocationPair{databaseBatchPair}, nil, false),
+			maxPodsToEvictPerNode:   1,
+			expectedEvictedPodCount: 1,
+		},
+		{
+			description:             "Pods are not evicted when no other node fits, nodeFit=true",
+			pods:                    []*v1.Pod{database, batch1, batch2},
+			nodes:                   []*v1.Node{node1, node2},
+			strategy:                createStrategy([]api.AntiColocationPair{databaseBatchPair}, nil, true),
+			expectedEvictedPodCount: 0,
+		},
+		{
+			description:             "Pair without evict selector is rejected",
+			pods:                    []*v1.Pod{database, batch1},
+			nodes:                   []*v1.Node{node1},
+			strategy:                createStrategy([]api.AntiColocationPair{{Keep: databaseBatchPair.Keep}}, nil, false),
+			expectedEvictedPodCount: 0,
+		},
+		{
+			description:             "Pair with empty keep selector is rejected",
+			pods:                    []*v1.Pod{database, batch1},
+			nodes:                   []*v1.Node{node1},
+			strategy:                createStrategy([]api.AntiColocationPair{{Keep: &metav1.LabelSelector{}, Evict: databaseBatchPair.Evict}}, nil, false),
+			expectedEvictedPodCount: 0,
+		},
+		{
+			description:             "No pairs configured",
+			pods:                    []*v1.Pod{database, batch1},
+			nodes:                   []*v1.Node{node1},
+			strategy:                createStrategy(nil, nil, false),
+			expectedEvictedPodCount: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			pods := make([]v1.Pod, 0, len(tc.pods))
+			for _, pod := range tc.pods {
+				pods = append(pods, *pod)
+			}
+
+			fakeClient := &fake.Clientset{}
+			fakeClient.Fake.AddReactor("list", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				return true, &v1.PodList{Items: pods}, nil
+			})
+
+			podEvictor := evictions.NewPodEvictor(
+				fakeClient,
+				policyv1.SchemeGroupVersion.String(),
+				false,
+				tc.maxPodsToEvictPerNode,
+				0,
+				tc.nodes,
+				false,
+				false,
+				false,
+			)
+
+			RemovePodsViolatingAntiColocation(ctx, fakeClient, tc.strategy, []*v1.Node{node1}, podEvictor)
+			if actualEvictedPodCount := podEvictor.TotalEvicted(); actualEvictedPodCount != tc.expectedEvictedPodCount {
+				t.Errorf("Expected %v pod evictions, but got %v pod evictions", tc.expectedEvictedPodCount, actualEvictedPodCount)
+			}
+		})
+	}
+}
@@ -33,6 +33,7 @@ import (
 	"sigs.k8s.io/descheduler/pkg/api"
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/validation"
 	"sigs.k8s.io/descheduler/pkg/utils"
 )
 
@@ -66,15 +67,13 @@ func RemoveDuplicatePods(
 	strategy api.DeschedulerStrategy,
 	nodes []*v1.Node,
 	podEvictor *evictions.PodEvictor,
-) {
+) error {
 	if err := validateRemoveDuplicatePodsParams(strategy.Params); err != nil {
-		klog.ErrorS(err, "Invalid RemoveDuplicatePods parameters")
-		return
+		return validation.NewStrategyError(validation.ErrorReasonInvalidParameters, "invalid RemoveDuplicatePods parameters", err)
 	}
 	thresholdPriority, err := utils.GetPriorityFromStrategyParams(ctx, client, strategy.Params)
 	if err != nil {
-		klog.ErrorS(err, "Failed to get threshold priority from strategy's params")
-		return
+		return validation.NewStrategyError(validation.ErrorReasonPriorityLookup, "failed to get threshold priority from strategy's params", err)
 	}
 
 	var includedNamespaces, excludedNamespaces []string
@@ -96,6 +95,9 @@ func RemoveDuplicatePods(
 	nodeMap := make(map[string]*v1.Node)
 
 	for _, node := range nodes {
+		if !podEvictor.VisitNode(node) {
+			continue
+		}
 		klog.V(1).InfoS("Processing node", "node", klog.KObj(node))
 		pods, err := podutil.ListPodsOnANode(ctx,
 			client,
@@ -205,7 +207,7 @@ func RemoveDuplicatePods(
 				// It's assumed all duplicated pods are in the same priority class
 				// TODO(jchaloup): check if the pod has a different node to lend to
 				for _, pod := range pods[upperAvg-1:] {
-					if _, err := podEvictor.EvictPod(ctx, pod, nodeMap[nodeName], "RemoveDuplicatePods"); err != nil {
+					if _, err := podEvictor.EvictPod(ctx, pod, nodeMap[nodeName], evictions.ReasonRemoveDuplicates); err != nil {
 						klog.ErrorS(err, "Error evicting pod", "pod", klog.KObj(pod))
 						break
 					}
@@ -213,6 +215,7 @@ func RemoveDuplicatePods(
 			}
 		}
 	}
+	return nil
 }
 
 func getNodeAffinityNodeSelector(pod *v1.Pod) *v1.NodeSelector {
@@ -288,6 +288,7 @@ func TestFindDuplicatePods(t *testing.T) {
 				"v1",
 				false,
 				testCase.maxPodsToEvictPerNode,
+				0,
 				testCase.nodes,
 				false,
 				false,
@@ -696,6 +697,7 @@ func TestRemoveDuplicatesUniformly(t *testing.T) {
 				policyv1.SchemeGroupVersion.String(),
 				false,
 				testCase.maxPodsToEvictPerNode,
+				0,
 				testCase.nodes,
 				false,
 				false,
@@ -3,6 +3,7 @@ package strategies
 import (
 	"context"
 	"fmt"
+	"time"
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	v1 "k8s.io/api/core/v1"
@@ -33,11 +34,10 @@ func RemoveFailedPods(
 	strategy api.DeschedulerStrategy,
 	nodes []*v1.Node,
 	podEvictor *evictions.PodEvictor,
-) {
+) error {
 	strategyParams, err := validateAndParseRemoveFailedPodsParams(ctx, client, strategy.Params)
 	if err != nil {
-		klog.ErrorS(err, "Invalid RemoveFailedPods parameters")
-		return
+		return validation.NewStrategyError(validation.ErrorReasonInvalidParameters, "invalid RemoveFailedPods parameters", err)
 	}
 
 	evictable := podEvictor.Evictable(
@@ -52,6 +52,9 @@ func RemoveFailedPods(
 	}
 
 	for _, node := range nodes {
+		if !podEvictor.VisitNode(node) {
+			continue
+		}
 		klog.V(1).InfoS("Processing node", "node", klog.KObj(node))
 		fieldSelectorString := "spec.nodeName=" + node.Name + ",status.phase=" + string(v1.PodFailed)
 
@@ -71,17 +74,18 @@ func RemoveFailedPods(
 		}
 
 		for i, pod := range pods {
-			if err = validateFailedPodShouldEvict(pod, *strategyParams); err != nil {
+			if err = validateFailedPodShouldEvict(pod, *strategyParams, podEvictor.Now()); err != nil {
 				klog.V(4).InfoS(fmt.Sprintf("ignoring pod for eviction due to: %s", err.Error()), "pod", klog.KObj(pod))
 				continue
 			}
 
-			if _, err = podEvictor.EvictPod(ctx, pods[i], node, "FailedPod"); err != nil {
+			if _, err = podEvictor.EvictPod(ctx, pods[i], node, evictions.ReasonRemoveFailedPods); err != nil {
 				klog.ErrorS(err, "Error evicting pod", "pod", klog.KObj(pod))
 				break
 			}
 		}
 	}
+	return nil
 }
 
 func validateAndParseRemoveFailedPodsParams(
@@ -121,11 +125,11 @@ func validateAndParseRemoveFailedPodsParams(
 
 // validateFailedPodShouldEvict looks at strategy params settings to see if the Pod
 // should be evicted given the params in the PodFailed policy.
-func validateFailedPodShouldEvict(pod *v1.Pod, strategyParams validatedFailedPodsStrategyParams) error {
+func validateFailedPodShouldEvict(pod *v1.Pod, strategyParams validatedFailedPodsStrategyParams, now time.Time) error {
 	var errs []error
 
 	if strategyParams.minPodLifetimeSeconds != nil {
-		podAgeSeconds := uint(metav1.Now().Sub(pod.GetCreationTimestamp().Local()).Seconds())
+		podAgeSeconds := uint(now.Sub(pod.GetCreationTimestamp().Local()).Seconds())
 		if podAgeSeconds < *strategyParams.minPodLifetimeSeconds {
 			errs = append(errs, fmt.Errorf("pod does not exceed the min age seconds of %d", *strategyParams.minPodLifetimeSeconds))
 		}
@@ -210,6 +210,7 @@ func TestRemoveFailedPods(t *testing.T) {
 			policyv1.SchemeGroupVersion.String(),
 			false,
 			100,
+			0,
 			tc.nodes,
 			false,
 			false,
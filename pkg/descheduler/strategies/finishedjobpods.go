@@ -0,0 +1,187 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategies
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/descheduler/logging"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/validation"
+)
+
+// validatedFinishedJobPodsStrategyParams contains validated strategy parameters
+type validatedFinishedJobPodsStrategyParams struct {
+	validation.ValidatedStrategyParams
+	ttl               time.Duration
+	excludeOwnerKinds sets.String
+}
+
+// RemoveFinishedJobPods removes the pods of completed or failed Jobs once the Job finished for longer than
+// the configured TTL. It cleans up the pods left behind when the TTL controller is not enabled or the Jobs
+// do not set a TTL, as these pods are otherwise counted by strategies relying on the number of pods.
+func RemoveFinishedJobPods(
+	ctx context.Context,
+	client clientset.Interface,
+	strategy api.DeschedulerStrategy,
+	nodes []*v1.Node,
+	podEvictor *evictions.PodEvictor,
+) error {
+	strategyParams, err := validateAndParseFinishedJobPodsParams(ctx, client, strategy.Params)
+	if err != nil {
+		return validation.NewStrategyError(validation.ErrorReasonInvalidParameters, "invalid RemoveFinishedJobPods parameters", err)
+	}
+
+	evictable := podEvictor.Evictable(
+		evictions.WithPriorityThreshold(strategyParams.ThresholdPriority),
+		evictions.WithNodeFit(strategyParams.NodeFit),
+		evictions.WithLabelSelector(strategyParams.LabelSelector),
+	)
+
+	filter := func(pod *v1.Pod) bool {
+		if pod.Status.Phase != v1.PodSucceeded && pod.Status.Phase != v1.PodFailed {
+			return false
+		}
+		return jobOwnerRef(pod) != nil && evictable.IsEvictable(pod)
+	}
+
+	// jobs caches the Jobs, by namespace and name, shared by the pods of all nodes
+	jobs := map[string]*batchv1.Job{}
+	now := podEvictor.Now()
+	for _, node := range nodes {
+		if !podEvictor.VisitNode(node) {
+			continue
+		}
+		klog.V(1).InfoS("Processing node", "node", klog.KObj(node))
+		pods, err := podutil.ListPodsOnANodeWithFieldSelector(
+			ctx,
+			client,
+			node,
+			"spec.nodeName="+node.Name,
+			podutil.WithFilter(filter),
+			podutil.WithNamespaces(strategyParams.IncludedNamespaces.UnsortedList()),
+			podutil.WithoutNamespaces(strategyParams.ExcludedNamespaces.UnsortedList()),
+			podutil.WithLabelSelector(strategy.Params.LabelSelector),
+		)
+		if err != nil {
+			klog.ErrorS(err, "Error listing a nodes pods", "node", klog.KObj(node))
+			continue
+		}
+
+		for _, pod := range pods {
+			ownerRef := jobOwnerRef(pod)
+			key := pod.Namespace + "/" + ownerRef.Name
+			job, ok := jobs[key]
+			if !ok {
+				job, err = client.BatchV1().Jobs(pod.Namespace).Get(ctx, ownerRef.Name, metav1.GetOptions{})
+				if err != nil {
+					if !apierrors.IsNotFound(err) {
+						klog.ErrorS(err, "Error getting the job of a pod", "pod", klog.KObj(pod), "job", ownerRef.Name)
+						continue
+					}
+					job = nil
+				}
+				jobs[key] = job
+			}
+			if job == nil || job.UID != ownerRef.UID {
+				// pods of deleted jobs are left to the garbage collector
+				logging.InfoS(klog.V(4), "Ignoring pod whose job no longer exists", "pod", klog.KObj(pod))
+				continue
+			}
+			if err := validateFinishedJobPodShouldEvict(job, *strategyParams, now); err != nil {
+				klog.V(4).InfoS(fmt.Sprintf("ignoring pod for eviction due to: %s", err.Error()), "pod", klog.KObj(pod))
+				continue
+			}
+
+			if _, err = podEvictor.EvictPod(ctx, pod, node, evictions.ReasonRemoveFinishedJobPods); err != nil {
+				klog.ErrorS(err, "Error evicting pod", "pod", klog.KObj(pod))
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// jobOwnerRef returns the Job owning the pod, nil for pods not owned by a Job
+func jobOwnerRef(pod *v1.Pod) *metav1.OwnerReference {
+	for i, ownerRef := range pod.OwnerReferences {
+		if ownerRef.Kind == "Job" {
+			return &pod.OwnerReferences[i]
+		}
+	}
+	return nil
+}
+
+// jobFinishTime returns when the Job completed or failed, false for Jobs which are not finished
+func jobFinishTime(job *batchv1.Job) (time.Time, bool) {
+	for _, condition := range job.Status.Conditions {
+		if (condition.Type == batchv1.JobComplete || condition.Type == batchv1.JobFailed) && condition.Status == v1.ConditionTrue {
+			return condition.LastTransitionTime.Time, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// validateFinishedJobPodShouldEvict checks whether the pods of the Job have outlived the TTL
+func validateFinishedJobPodShouldEvict(job *batchv1.Job, strategyParams validatedFinishedJobPodsStrategyParams, now time.Time) error {
+	for _, ownerRef := range job.OwnerReferences {
+		if strategyParams.excludeOwnerKinds.Has(ownerRef.Kind) {
+			return fmt.Errorf("job's owner kind of %s is excluded", ownerRef.Kind)
+		}
+	}
+	finishTime, finished := jobFinishTime(job)
+	if !finished {
+		return fmt.Errorf("job %s is not finished", job.Name)
+	}
+	if now.Sub(finishTime) < strategyParams.ttl {
+		return fmt.Errorf("job %s finished less than %v ago", job.Name, strategyParams.ttl)
+	}
+	return nil
+}
+
+func validateAndParseFinishedJobPodsParams(
+	ctx context.Context,
+	client clientset.Interface,
+	params *api.StrategyParameters,
+) (*validatedFinishedJobPodsStrategyParams, error) {
+	if params == nil || params.FinishedJobPods == nil || params.FinishedJobPods.TTLSecondsAfterFinished == nil {
+		return nil, fmt.Errorf("finishedJobPods ttlSecondsAfterFinished not set")
+	}
+
+	strategyParams, err := validation.ValidateAndParseStrategyParams(ctx, client, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &validatedFinishedJobPodsStrategyParams{
+		ValidatedStrategyParams: *strategyParams,
+		ttl:                     time.Duration(*params.FinishedJobPods.TTLSecondsAfterFinished) * time.Second,
+		excludeOwnerKinds:       sets.NewString(params.FinishedJobPods.ExcludeOwnerKinds...),
+	}, nil
+}
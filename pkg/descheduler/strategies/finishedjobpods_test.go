@@ -0,0 +1,173 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategies
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestRemoveFinishedJobPods(t *testing.T) {
+	ctx := context.Background()
+
+	node := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	buildJob := func(name string, conditionType batchv1.JobConditionType, finishedAgo time.Duration, ownerKind string) *batchv1.Job {
+		job := &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", UID: types.UID(name)},
+		}
+		if conditionType != "" {
+			job.Status.Conditions = []batchv1.JobCondition{{
+				Type:               conditionType,
+				Status:             v1.ConditionTrue,
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-finishedAgo)),
+			}}
+		}
+		if ownerKind != "" {
+			job.OwnerReferences = []metav1.OwnerReference{{Kind: ownerKind, Name: name + "-owner"}}
+		}
+		return job
+	}
+	jobs := []*batchv1.Job{
+		buildJob("completed", batchv1.JobComplete, 2*time.Hour, ""),
+		buildJob("failed-cronjob", batchv1.JobFailed, 2*time.Hour, "CronJob"),
+		buildJob("recently-completed", batchv1.JobComplete, 10*time.Minute, ""),
+		buildJob("running", "", 0, ""),
+	}
+	buildPod := func(name, jobName string, phase v1.PodPhase) *v1.Pod {
+		return test.BuildTestPod(name, 100, 0, node.Name, func(pod *v1.Pod) {
+			pod.Status.Phase = phase
+			pod.OwnerReferences = []metav1.OwnerReference{{Kind: "Job", Name: jobName, UID: types.UID(jobName)}}
+		})
+	}
+	pods := []*v1.Pod{
+		buildPod("p1", "completed", v1.PodSucceeded),
+		buildPod("p2", "failed-cronjob", v1.PodFailed),
+		buildPod("p3", "recently-completed", v1.PodSucceeded),
+		buildPod("p4", "running", v1.PodFailed),
+		buildPod("p5", "running", v1.PodRunning),
+		buildPod("p6", "deleted", v1.PodSucceeded),
+		test.BuildTestPod("p7", 100, 0, node.Name, func(pod *v1.Pod) {
+			test.SetRSOwnerRef(pod)
+			pod.Status.Phase = v1.PodSucceeded
+		}),
+	}
+
+	oneHour := uint(3600)
+	zero := uint(0)
+	tests := []struct {
+		description     string
+		params          *api.StrategyParameters
+		expectedEvicted []string
+	}{
+		{
+			description:     "Pods of jobs finished for longer than the TTL are evicted",
+			params:          &api.StrategyParameters{FinishedJobPods: &api.FinishedJobPods{TTLSecondsAfterFinished: &oneHour}},
+			expectedEvicted: []string{"p1", "p2"},
+		},
+		{
+			description:     "Pods of all finished jobs are evicted with a zero TTL",
+			params:          &api.StrategyParameters{FinishedJobPods: &api.FinishedJobPods{TTLSecondsAfterFinished: &zero}},
+			expectedEvicted: []string{"p1", "p2", "p3"},
+		},
+		{
+			description: "Jobs with an excluded owner kind are not processed",
+			params: &api.StrategyParameters{FinishedJobPods: &api.FinishedJobPods{
+				TTLSecondsAfterFinished: &oneHour,
+				ExcludeOwnerKinds:       []string{"CronJob"},
+			}},
+			expectedEvicted: []string{"p1"},
+		},
+		{
+			description: "Namespaces not included are not processed",
+			params: &api.StrategyParameters{
+				FinishedJobPods: &api.FinishedJobPods{TTLSecondsAfterFinished: &oneHour},
+				Namespaces:      &api.Namespaces{Exclude: []string{"default"}},
+			},
+		},
+		{
+			description: "TTL is required",
+			params:      &api.StrategyParameters{FinishedJobPods: &api.FinishedJobPods{}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			fakeClient := &fake.Clientset{}
+			fakeClient.Fake.AddReactor("list", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				fieldSelector := action.(core.ListAction).GetListRestrictions().Fields
+				podList := &v1.PodList{}
+				for _, pod := range pods {
+					if fieldSelector.Matches(fields.Set{"spec.nodeName": pod.Spec.NodeName, "metadata.namespace": pod.Namespace}) {
+						podList.Items = append(podList.Items, *pod)
+					}
+				}
+				return true, podList, nil
+			})
+			fakeClient.Fake.AddReactor("get", "jobs", func(action core.Action) (bool, runtime.Object, error) {
+				name := action.(core.GetAction).GetName()
+				for _, job := range jobs {
+					if job.Name == name {
+						return true, job, nil
+					}
+				}
+				return true, nil, apierrors.NewNotFound(batchv1.Resource("jobs"), name)
+			})
+			var evicted []string
+			fakeClient.Fake.AddReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() == "eviction" {
+					evicted = append(evicted, action.(core.CreateAction).GetObject().(metav1.Object).GetName())
+				}
+				return true, nil, nil
+			})
+
+			podEvictor := evictions.NewPodEvictor(
+				fakeClient,
+				policyv1.SchemeGroupVersion.String(),
+				false,
+				0,
+				0,
+				[]*v1.Node{node},
+				false,
+				false,
+				false,
+			)
+
+			strategy := api.DeschedulerStrategy{Enabled: true, Params: tc.params}
+			RemoveFinishedJobPods(ctx, fakeClient, strategy, []*v1.Node{node}, podEvictor)
+			if !reflect.DeepEqual(evicted, tc.expectedEvicted) {
+				t.Errorf("Expected %v pods to be evicted, got %v", tc.expectedEvicted, evicted)
+			}
+		})
+	}
+}
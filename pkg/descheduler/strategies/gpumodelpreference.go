@@ -0,0 +1,251 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategies
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/component-helpers/scheduling/corev1"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/validation"
+	"sigs.k8s.io/descheduler/pkg/utils"
+)
+
+const (
+	// DefaultGPUModelLabel is the node label of the GPU model set by the NVIDIA GPU feature discovery
+	DefaultGPUModelLabel = "nvidia.com/gpu.product"
+	// DefaultGPUResourceName is the extended resource of NVIDIA GPUs
+	DefaultGPUResourceName = "nvidia.com/gpu"
+)
+
+// gpuModelPriority ranks models for the pods its selector matches
+type gpuModelPriority struct {
+	selector labels.Selector
+	models   []string
+}
+
+// validatedGPUModelPreferenceStrategyParams contains validated strategy parameters
+type validatedGPUModelPreferenceStrategyParams struct {
+	validation.ValidatedStrategyParams
+	modelLabel   string
+	resourceName v1.ResourceName
+	priorities   []gpuModelPriority
+}
+
+// RemovePodsViolatingGPUModelPreference evicts GPU pods running on nodes of a GPU model they rank lower than the
+// model of another node with enough free GPUs for them. Models are ranked by the configured model priorities of the
+// pod, or by the weights of its preferred node affinity terms on the model label. Pods ranking no model are ignored.
+func RemovePodsViolatingGPUModelPreference(
+	ctx context.Context,
+	client clientset.Interface,
+	strategy api.DeschedulerStrategy,
+	nodes []*v1.Node,
+	podEvictor *evictions.PodEvictor,
+) error {
+	strategyParams, err := validateAndParseGPUModelPreferenceParams(ctx, client, strategy.Params)
+	if err != nil {
+		return validation.NewStrategyError(validation.ErrorReasonInvalidParameters, "invalid RemovePodsViolatingGPUModelPreference parameters", err)
+	}
+
+	evictable := podEvictor.Evictable(
+		evictions.WithPriorityThreshold(strategyParams.ThresholdPriority),
+		evictions.WithNodeFit(strategyParams.NodeFit),
+		evictions.WithLabelSelector(strategyParams.LabelSelector),
+	)
+
+	// free GPUs are the allocatable GPUs of the node not requested by its pods, all pods count
+	podsOnNodes := map[string][]*v1.Pod{}
+	freeGPUs := map[string]int64{}
+	for _, node := range nodes {
+		pods, err := podutil.ListPodsOnANode(ctx, client, node)
+		if err != nil {
+			klog.ErrorS(err, "Error listing pods on node", "node", klog.KObj(node))
+			continue
+		}
+		podsOnNodes[node.Name] = pods
+		allocatable := node.Status.Allocatable[strategyParams.resourceName]
+		freeGPUs[node.Name] = allocatable.Value()
+		for _, pod := range pods {
+			freeGPUs[node.Name] -= gpuRequest(pod, strategyParams.resourceName)
+		}
+	}
+
+	for _, node := range nodes {
+		if !podEvictor.VisitNode(node) {
+			continue
+		}
+		klog.V(1).InfoS("Processing node", "node", klog.KObj(node))
+		for _, pod := range podsOnNodes[node.Name] {
+			if !strategyParams.IncludedNamespaces.Has(pod.Namespace) && strategyParams.IncludedNamespaces.Len() > 0 ||
+				strategyParams.ExcludedNamespaces.Has(pod.Namespace) {
+				continue
+			}
+			request := gpuRequest(pod, strategyParams.resourceName)
+			if request == 0 {
+				continue
+			}
+			rank, ok := gpuModelRanker(pod, strategyParams)
+			if !ok {
+				continue
+			}
+			current := rank(node)
+
+			var destinations []*v1.Node
+			for _, destination := range nodes {
+				if destination.Name != node.Name && rank(destination) > current && freeGPUs[destination.Name] >= request {
+					destinations = append(destinations, destination)
+				}
+			}
+			if len(destinations) == 0 || !evictable.IsEvictable(pod) {
+				continue
+			}
+			sort.SliceStable(destinations, func(i, j int) bool {
+				return rank(destinations[i]) > rank(destinations[j])
+			})
+			var destination *v1.Node
+			for _, candidate := range destinations {
+				if nodeutil.PodFitsAnyOtherNode(pod, []*v1.Node{candidate}) {
+					destination = candidate
+					break
+				}
+			}
+			if destination == nil {
+				klog.V(2).InfoS("Pod does not fit any node of a preferred GPU model", "pod", klog.KObj(pod))
+				continue
+			}
+
+			detail := fmt.Sprintf("model=%s preferredModel=%s", node.Labels[strategyParams.modelLabel], destination.Labels[strategyParams.modelLabel])
+			if _, err := podEvictor.EvictPod(ctx, pod, node, evictions.ReasonRemovePodsViolatingGPUModelPreference, detail); err != nil {
+				klog.ErrorS(err, "Error evicting pod", "pod", klog.KObj(pod))
+				break
+			}
+			// the replacement is expected on the destination, its GPUs are no longer free for the next pods
+			freeGPUs[destination.Name] -= request
+			freeGPUs[node.Name] += request
+		}
+	}
+	return nil
+}
+
+// gpuRequest returns the number of GPUs the pod requests
+func gpuRequest(pod *v1.Pod, resourceName v1.ResourceName) int64 {
+	request := utils.GetResourceRequestQuantity(pod, resourceName)
+	return request.Value()
+}
+
+// gpuModelRanker returns the function ranking nodes for the pod, higher is preferred, from the first model priority
+// selecting the pod, or else from its preferred node affinity terms on the model label. It returns false when the
+// pod ranks no model.
+func gpuModelRanker(pod *v1.Pod, params *validatedGPUModelPreferenceStrategyParams) (func(node *v1.Node) int, bool) {
+	for _, priority := range params.priorities {
+		if !priority.selector.Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		models := priority.models
+		return func(node *v1.Node) int {
+			model, ok := node.Labels[params.modelLabel]
+			if !ok {
+				return 0
+			}
+			for i, preferred := range models {
+				if model == preferred {
+					return len(models) - i
+				}
+			}
+			return 0
+		}, true
+	}
+
+	if pod.Spec.Affinity == nil || pod.Spec.Affinity.NodeAffinity == nil {
+		return nil, false
+	}
+	var terms []v1.PreferredSchedulingTerm
+	for _, term := range pod.Spec.Affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution {
+		for _, requirement := range term.Preference.MatchExpressions {
+			if requirement.Key == params.modelLabel {
+				terms = append(terms, term)
+				break
+			}
+		}
+	}
+	if len(terms) == 0 {
+		return nil, false
+	}
+	return func(node *v1.Node) int {
+		rank := 0
+		for _, term := range terms {
+			matches, err := corev1.MatchNodeSelectorTerms(node, &v1.NodeSelector{NodeSelectorTerms: []v1.NodeSelectorTerm{term.Preference}})
+			if err != nil {
+				klog.ErrorS(err, "Error matching preferred node affinity term", "pod", klog.KObj(pod))
+				continue
+			}
+			if matches {
+				rank += int(term.Weight)
+			}
+		}
+		return rank
+	}, true
+}
+
+func validateAndParseGPUModelPreferenceParams(
+	ctx context.Context,
+	client clientset.Interface,
+	params *api.StrategyParameters,
+) (*validatedGPUModelPreferenceStrategyParams, error) {
+	validated := &validatedGPUModelPreferenceStrategyParams{
+		modelLabel:   DefaultGPUModelLabel,
+		resourceName: DefaultGPUResourceName,
+	}
+	if params != nil && params.GPUModelPreference != nil {
+		if params.GPUModelPreference.ModelLabel != "" {
+			validated.modelLabel = params.GPUModelPreference.ModelLabel
+		}
+		if params.GPUModelPreference.ResourceName != "" {
+			validated.resourceName = v1.ResourceName(params.GPUModelPreference.ResourceName)
+		}
+		for i, priority := range params.GPUModelPreference.ModelPriorities {
+			if len(priority.Models) == 0 {
+				return nil, fmt.Errorf("modelPriorities[%d] lists no model", i)
+			}
+			selector := labels.Everything()
+			if priority.PodSelector != nil {
+				var err error
+				if selector, err = metav1.LabelSelectorAsSelector(priority.PodSelector); err != nil {
+					return nil, fmt.Errorf("modelPriorities[%d] has an invalid podSelector: %v", i, err)
+				}
+			}
+			validated.priorities = append(validated.priorities, gpuModelPriority{selector: selector, models: priority.Models})
+		}
+	}
+	strategyParams, err := validation.ValidateAndParseStrategyParams(ctx, client, params)
+	if err != nil {
+		return nil, err
+	}
+	validated.ValidatedStrategyParams = *strategyParams
+	return validated, nil
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategies
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestRemovePodsViolatingGPUModelPreference(t *testing.T) {
+	ctx := context.Background()
+
+	buildGPUNode := func(name, model string, gpus int64) *v1.Node {
+		return test.BuildTestNode(name, 2000, 3000, 10, func(node *v1.Node) {
+			node.Labels = map[string]string{DefaultGPUModelLabel: model}
+			node.Status.Allocatable[DefaultGPUResourceName] = *resource.NewQuantity(gpus, resource.DecimalSI)
+		})
+	}
+	a100 := buildGPUNode("a100", "NVIDIA-A100-SXM4-40GB", 4)
+	t4 := buildGPUNode("t4", "Tesla-T4", 2)
+
+	buildPod := func(name, nodeName string, gpus int64, workload string, apply func(pod *v1.Pod)) *v1.Pod {
+		return test.BuildTestPod(name, 100, 0, nodeName, func(pod *v1.Pod) {
+			test.SetRSOwnerRef(pod)
+			pod.Labels = map[string]string{"workload": workload}
+			if gpus > 0 {
+				pod.Spec.Containers[0].Resources.Requests[DefaultGPUResourceName] = *resource.NewQuantity(gpus, resource.DecimalSI)
+			}
+			if apply != nil {
+				apply(pod)
+			}
+		})
+	}
+	preferT4 := func(pod *v1.Pod) {
+		pod.Spec.Affinity = &v1.Affinity{NodeAffinity: &v1.NodeAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []v1.PreferredSchedulingTerm{{
+				Weight: 10,
+				Preference: v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{{
+					Key:      DefaultGPUModelLabel,
+					Operator: v1.NodeSelectorOpIn,
+					Values:   []string{"Tesla-T4"},
+				}}},
+			}},
+		}}
+	}
+	inferenceOnT4 := &api.StrategyParameters{GPUModelPreference: &api.GPUModelPreference{
+		ModelPriorities: []api.GPUModelPriority{
+			{
+				PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"workload": "inference"}},
+				Models:      []string{"Tesla-T4", "NVIDIA-A100-SXM4-40GB"},
+			},
+		},
+	}}
+
+	tests := []struct {
+		description     string
+		pods            []*v1.Pod
+		params          *api.StrategyParameters
+		expectedEvicted []string
+	}{
+		{
+			description: "Inference pod on an A100 is moved to a T4 with free GPUs",
+			pods: []*v1.Pod{
+				buildPod("p1", a100.Name, 1, "inference", nil),
+				buildPod("p2", a100.Name, 1, "training", nil),
+			},
+			params:          inferenceOnT4,
+			expectedEvicted: []string{"p1"},
+		},
+		{
+			description: "Inference pod on its preferred model is kept",
+			pods: []*v1.Pod{
+				buildPod("p1", t4.Name, 1, "inference", nil),
+			},
+			params: inferenceOnT4,
+		},
+		{
+			description: "Pods without GPUs are kept",
+			pods: []*v1.Pod{
+				buildPod("p1", a100.Name, 0, "inference", nil),
+			},
+			params: inferenceOnT4,
+		},
+		{
+			description: "Pods are only moved while the preferred model has free GPUs",
+			pods: []*v1.Pod{
+				buildPod("p1", a100.Name, 1, "inference", nil),
+				buildPod("p2", a100.Name, 1, "inference", nil),
+				buildPod("p3", a100.Name, 1, "inference", nil),
+				buildPod("p4", t4.Name, 1, "training", nil),
+			},
+			params:          inferenceOnT4,
+			expectedEvicted: []string{"p1"},
+		},
+		{
+			description: "Pods requesting more GPUs than free on the preferred model are kept",
+			pods: []*v1.Pod{
+				buildPod("p1", a100.Name, 3, "inference", nil),
+			},
+			params: inferenceOnT4,
+		},
+		{
+			description: "Pods are ranked by their preferred node affinity terms on the model label",
+			pods: []*v1.Pod{
+				buildPod("p1", a100.Name, 1, "training", preferT4),
+				buildPod("p2", a100.Name, 1, "training", nil),
+			},
+			expectedEvicted: []string{"p1"},
+		},
+		{
+			description: "Model priorities take precedence over preferred node affinity terms",
+			pods: []*v1.Pod{
+				buildPod("p1", a100.Name, 1, "inference", preferT4),
+			},
+			params: &api.StrategyParameters{GPUModelPreference: &api.GPUModelPreference{
+				ModelPriorities: []api.GPUModelPriority{{Models: []string{"NVIDIA-A100-SXM4-40GB"}}},
+			}},
+		},
+		{
+			description: "Model priorities without models are refused",
+			pods: []*v1.Pod{
+				buildPod("p1", a100.Name, 1, "inference", nil),
+			},
+			params: &api.StrategyParameters{GPUModelPreference: &api.GPUModelPreference{
+				ModelPriorities: []api.GPUModelPriority{{}},
+			}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			nodes := []*v1.Node{a100, t4}
+			fakeClient := &fake.Clientset{}
+			fakeClient.Fake.AddReactor("list", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				fieldSelector := action.(core.ListAction).GetListRestrictions().Fields
+				podList := &v1.PodList{}
+				for _, pod := range tc.pods {
+					if fieldSelector.Matches(fields.Set{"spec.nodeName": pod.Spec.NodeName, "status.phase": string(pod.Status.Phase)}) {
+						podList.Items = append(podList.Items, *pod)
+					}
+				}
+				return true, podList, nil
+			})
+			var evicted []string
+			fakeClient.Fake.AddReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() == "eviction" {
+					evicted = append(evicted, action.(core.CreateAction).GetObject().(metav1.Object).GetName())
+				}
+				return true, nil, nil
+			})
+
+			podEvictor := evictions.NewPodEvictor(
+				fakeClient,
+				policyv1.SchemeGroupVersion.String(),
+				false,
+				0,
+				0,
+				nodes,
+				false,
+				false,
+				false,
+			)
+
+			strategy := api.DeschedulerStrategy{Enabled: true, Params: tc.params}
+			RemovePodsViolatingGPUModelPreference(ctx, fakeClient, strategy, nodes, podEvictor)
+			if !reflect.DeepEqual(evicted, tc.expectedEvicted) {
+				t.Errorf("Expected %v pods to be evicted, got %v", tc.expectedEvicted, evicted)
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategies
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/validation"
+)
+
+// DefaultMinImageSizeMB is the size from which images are considered heavy when not configured
+const DefaultMinImageSizeMB = 1000
+
+// validatedImageLocalityStrategyParams contains validated strategy parameters
+type validatedImageLocalityStrategyParams struct {
+	validation.ValidatedStrategyParams
+	minImageSizeBytes int64
+}
+
+// RemovePodsForImageLocality consolidates pods of image-heavy workloads onto nodes already caching their images.
+// A pod is evicted when it is the only pod of its node using its heavy images, and fits another node where
+// the images are cached and used by running pods. The node can then garbage collect the images, and the
+// replacement pod lands on a node which does not have to pull them.
+func RemovePodsForImageLocality(
+	ctx context.Context,
+	client clientset.Interface,
+	strategy api.DeschedulerStrategy,
+	nodes []*v1.Node,
+	podEvictor *evictions.PodEvictor,
+) error {
+	strategyParams, err := validateAndParseImageLocalityParams(ctx, client, strategy.Params)
+	if err != nil {
+		return validation.NewStrategyError(validation.ErrorReasonInvalidParameters, "invalid RemovePodsForImageLocality parameters", err)
+	}
+
+	evictable := podEvictor.Evictable(
+		evictions.WithPriorityThreshold(strategyParams.ThresholdPriority),
+		evictions.WithNodeFit(strategyParams.NodeFit),
+		evictions.WithLabelSelector(strategyParams.LabelSelector),
+	)
+
+	// all pods count as users of the images cached by their node, only the selected ones can be evicted
+	podsOnNodes := map[string][]*v1.Pod{}
+	imageUsers := map[string]map[string]int{}
+	for _, node := range nodes {
+		pods, err := podutil.ListPodsOnANode(ctx, client, node)
+		if err != nil {
+			klog.ErrorS(err, "Error listing pods on node", "node", klog.KObj(node))
+			continue
+		}
+		podsOnNodes[node.Name] = pods
+		imageUsers[node.Name] = map[string]int{}
+		for _, pod := range pods {
+			for _, image := range heavyImages(pod, node, strategyParams.minImageSizeBytes) {
+				imageUsers[node.Name][image]++
+			}
+		}
+	}
+
+	for _, node := range nodes {
+		if !podEvictor.VisitNode(node) {
+			continue
+		}
+		klog.V(1).InfoS("Processing node", "node", klog.KObj(node))
+		for _, pod := range podsOnNodes[node.Name] {
+			if !strategyParams.IncludedNamespaces.Has(pod.Namespace) && strategyParams.IncludedNamespaces.Len() > 0 ||
+				strategyParams.ExcludedNamespaces.Has(pod.Namespace) || !evictable.IsEvictable(pod) {
+				continue
+			}
+			images := heavyImages(pod, node, strategyParams.minImageSizeBytes)
+			if len(images) == 0 || !isOnlyImageUser(imageUsers[node.Name], images) {
+				continue
+			}
+
+			var destinations []*v1.Node
+			for _, destination := range nodes {
+				if destination.Name != node.Name && isImageUsed(imageUsers[destination.Name], images) {
+					destinations = append(destinations, destination)
+				}
+			}
+			if !nodeutil.PodFitsAnyOtherNode(pod, destinations) {
+				klog.V(2).InfoS("Pod does not fit any node caching its images", "pod", klog.KObj(pod), "images", images)
+				continue
+			}
+
+			if _, err := podEvictor.EvictPod(ctx, pod, node, evictions.ReasonRemovePodsForImageLocality, "images="+strings.Join(images, " ")); err != nil {
+				klog.ErrorS(err, "Error evicting pod", "pod", klog.KObj(pod))
+				break
+			}
+			for _, image := range images {
+				imageUsers[node.Name][image]--
+			}
+		}
+	}
+	return nil
+}
+
+// heavyImages returns the normalized images of the containers of the pod which the node caches
+// with a size of at least minSizeBytes
+func heavyImages(pod *v1.Pod, node *v1.Node, minSizeBytes int64) []string {
+	var images []string
+	seen := map[string]bool{}
+	for _, container := range pod.Spec.Containers {
+		image := normalizeImageName(container.Image)
+		if seen[image] {
+			continue
+		}
+		seen[image] = true
+		for _, cached := range node.Status.Images {
+			if cached.SizeBytes < minSizeBytes {
+				continue
+			}
+			for _, name := range cached.Names {
+				if normalizeImageName(name) == image {
+					images = append(images, image)
+					break
+				}
+			}
+		}
+	}
+	return images
+}
+
+// isOnlyImageUser checks if a single pod of the node, the one being checked, uses every one of the images
+func isOnlyImageUser(users map[string]int, images []string) bool {
+	for _, image := range images {
+		if users[image] != 1 {
+			return false
+		}
+	}
+	return true
+}
+
+// isImageUsed checks if every one of the images is cached by the node and used by its pods
+func isImageUsed(users map[string]int, images []string) bool {
+	for _, image := range images {
+		if users[image] == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeImageName strips the default registry of an image reference and adds the default tag
+// when the reference has neither a tag nor a digest, so pod and node image names can be compared
+func normalizeImageName(name string) string {
+	name = strings.TrimPrefix(name, "docker.io/")
+	name = strings.TrimPrefix(name, "library/")
+	if !strings.Contains(name, "@") && !strings.Contains(name[strings.LastIndex(name, "/")+1:], ":") {
+		name += ":latest"
+	}
+	return name
+}
+
+func validateAndParseImageLocalityParams(
+	ctx context.Context,
+	client clientset.Interface,
+	params *api.StrategyParameters,
+) (*validatedImageLocalityStrategyParams, error) {
+	minImageSizeMB := uint(DefaultMinImageSizeMB)
+	if params != nil && params.ImageLocality != nil && params.ImageLocality.MinImageSizeMB != nil {
+		minImageSizeMB = *params.ImageLocality.MinImageSizeMB
+	}
+	if minImageSizeMB == 0 {
+		return nil, fmt.Errorf("minImageSizeMB must be greater than 0")
+	}
+
+	strategyParams, err := validation.ValidateAndParseStrategyParams(ctx, client, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &validatedImageLocalityStrategyParams{
+		ValidatedStrategyParams: *strategyParams,
+		minImageSizeBytes:       int64(minImageSizeMB) * 1024 * 1024,
+	}, nil
+}
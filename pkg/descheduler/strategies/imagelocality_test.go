@@ -0,0 +1,194 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategies
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestRemovePodsForImageLocality(t *testing.T) {
+	ctx := context.Background()
+
+	const mb = 1024 * 1024
+	withImages := func(images ...v1.ContainerImage) func(node *v1.Node) {
+		return func(node *v1.Node) {
+			node.Status.Images = images
+		}
+	}
+	heavy := v1.ContainerImage{Names: []string{"docker.io/library/trainer:v1", "docker.io/library/trainer@sha256:1234"}, SizeBytes: 2000 * mb}
+	light := v1.ContainerImage{Names: []string{"docker.io/library/nginx:latest"}, SizeBytes: 100 * mb}
+	node1 := test.BuildTestNode("n1", 2000, 3000, 10, withImages(heavy, light))
+	node2 := test.BuildTestNode("n2", 2000, 3000, 10, withImages(heavy))
+	node3 := test.BuildTestNode("n3", 2000, 3000, 10, withImages(heavy, light))
+
+	buildPod := func(name, nodeName, image string) *v1.Pod {
+		return test.BuildTestPod(name, 100, 0, nodeName, func(pod *v1.Pod) {
+			test.SetRSOwnerRef(pod)
+			pod.Spec.Containers[0].Image = image
+		})
+	}
+
+	minImageSizeMB := uint(3000)
+	zero := uint(0)
+	tests := []struct {
+		description     string
+		pods            []*v1.Pod
+		nodes           []*v1.Node
+		params          *api.StrategyParameters
+		expectedEvicted []string
+	}{
+		{
+			description: "Pod alone using a heavy image is moved to a node where the image is used",
+			pods: []*v1.Pod{
+				buildPod("p1", node1.Name, "trainer:v1"),
+				buildPod("p2", node2.Name, "trainer:v1"),
+				buildPod("p3", node2.Name, "trainer:v1"),
+			},
+			nodes:           []*v1.Node{node1, node2},
+			expectedEvicted: []string{"p1"},
+		},
+		{
+			description: "Pods sharing a heavy image on their node are kept",
+			pods: []*v1.Pod{
+				buildPod("p1", node1.Name, "trainer:v1"),
+				buildPod("p2", node1.Name, "docker.io/library/trainer:v1"),
+				buildPod("p3", node2.Name, "trainer:v1"),
+			},
+			nodes:           []*v1.Node{node1, node2},
+			expectedEvicted: []string{"p3"},
+		},
+		{
+			description: "Pods alone using a heavy image on every node are consolidated on a single node",
+			pods: []*v1.Pod{
+				buildPod("p1", node1.Name, "trainer:v1"),
+				buildPod("p2", node2.Name, "trainer:v1"),
+			},
+			nodes:           []*v1.Node{node1, node2},
+			expectedEvicted: []string{"p1"},
+		},
+		{
+			description: "Pods alone using a heavy image are moved to nodes where it remains used",
+			pods: []*v1.Pod{
+				buildPod("p1", node1.Name, "trainer:v1"),
+				buildPod("p2", node2.Name, "trainer:v1"),
+				buildPod("p3", node3.Name, "trainer:v1"),
+				buildPod("p4", node3.Name, "trainer:v1"),
+			},
+			nodes:           []*v1.Node{node1, node2, node3},
+			expectedEvicted: []string{"p1", "p2"},
+		},
+		{
+			description: "Pods using light images are kept",
+			pods: []*v1.Pod{
+				buildPod("p1", node1.Name, "nginx"),
+				buildPod("p2", node3.Name, "nginx"),
+				buildPod("p3", node3.Name, "nginx"),
+			},
+			nodes: []*v1.Node{node1, node3},
+		},
+		{
+			description: "Images smaller than minImageSizeMB are not heavy",
+			pods: []*v1.Pod{
+				buildPod("p1", node1.Name, "trainer:v1"),
+				buildPod("p2", node2.Name, "trainer:v1"),
+				buildPod("p3", node2.Name, "trainer:v1"),
+			},
+			nodes:  []*v1.Node{node1, node2},
+			params: &api.StrategyParameters{ImageLocality: &api.ImageLocality{MinImageSizeMB: &minImageSizeMB}},
+		},
+		{
+			description: "A minImageSizeMB of 0 is refused",
+			pods: []*v1.Pod{
+				buildPod("p1", node1.Name, "trainer:v1"),
+				buildPod("p2", node2.Name, "trainer:v1"),
+				buildPod("p3", node2.Name, "trainer:v1"),
+			},
+			nodes:  []*v1.Node{node1, node2},
+			params: &api.StrategyParameters{ImageLocality: &api.ImageLocality{MinImageSizeMB: &zero}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			fakeClient := &fake.Clientset{}
+			fakeClient.Fake.AddReactor("list", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				fieldSelector := action.(core.ListAction).GetListRestrictions().Fields
+				podList := &v1.PodList{}
+				for _, pod := range tc.pods {
+					if fieldSelector.Matches(fields.Set{"spec.nodeName": pod.Spec.NodeName, "status.phase": string(pod.Status.Phase)}) {
+						podList.Items = append(podList.Items, *pod)
+					}
+				}
+				return true, podList, nil
+			})
+			var evicted []string
+			fakeClient.Fake.AddReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() == "eviction" {
+					evicted = append(evicted, action.(core.CreateAction).GetObject().(metav1.Object).GetName())
+				}
+				return true, nil, nil
+			})
+
+			podEvictor := evictions.NewPodEvictor(
+				fakeClient,
+				policyv1.SchemeGroupVersion.String(),
+				false,
+				0,
+				0,
+				tc.nodes,
+				false,
+				false,
+				false,
+			)
+
+			strategy := api.DeschedulerStrategy{Enabled: true, Params: tc.params}
+			RemovePodsForImageLocality(ctx, fakeClient, strategy, tc.nodes, podEvictor)
+			if !reflect.DeepEqual(evicted, tc.expectedEvicted) {
+				t.Errorf("Expected %v pods to be evicted, got %v", tc.expectedEvicted, evicted)
+			}
+		})
+	}
+}
+
+func TestNormalizeImageName(t *testing.T) {
+	for name, expected := range map[string]string{
+		"nginx":                              "nginx:latest",
+		"docker.io/library/nginx:1.21":       "nginx:1.21",
+		"docker.io/bitnami/redis":            "bitnami/redis:latest",
+		"registry:5000/team/app":             "registry:5000/team/app:latest",
+		"registry:5000/team/app:v2":          "registry:5000/team/app:v2",
+		"quay.io/team/app@sha256:abcdef0123": "quay.io/team/app@sha256:abcdef0123",
+	} {
+		if normalized := normalizeImageName(name); normalized != expected {
+			t.Errorf("Expected %q to be normalized to %q, got %q", name, expected, normalized)
+		}
+	}
+}
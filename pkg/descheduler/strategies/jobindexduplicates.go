@@ -0,0 +1,125 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategies
+
+import (
+	"context"
+	"sort"
+
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/validation"
+)
+
+// jobIndexKey identifies a completion index of an Indexed Job
+type jobIndexKey struct {
+	job   types.UID
+	index string
+}
+
+// jobIndexPod is a pod running a completion index, with the node it runs on
+type jobIndexPod struct {
+	pod  *v1.Pod
+	node *v1.Node
+}
+
+// RemoveDuplicateJobIndexPods evicts the pods of Indexed Jobs running the same completion index as an older pod
+// of the Job, which happens when the Job controller races with itself, e.g. during a leader change. Only the oldest
+// pod of every index is kept, the younger ones duplicate its work and hold capacity. As duplicates may run on
+// different nodes, the pods of all nodes are grouped before any eviction.
+func RemoveDuplicateJobIndexPods(
+	ctx context.Context,
+	client clientset.Interface,
+	strategy api.DeschedulerStrategy,
+	nodes []*v1.Node,
+	podEvictor *evictions.PodEvictor,
+) error {
+	strategyParams, err := validation.ValidateAndParseStrategyParams(ctx, client, strategy.Params)
+	if err != nil {
+		return validation.NewStrategyError(validation.ErrorReasonInvalidParameters, "invalid RemoveDuplicateJobIndexPods parameters", err)
+	}
+
+	evictable := podEvictor.Evictable(
+		evictions.WithPriorityThreshold(strategyParams.ThresholdPriority),
+		evictions.WithNodeFit(strategyParams.NodeFit),
+		evictions.WithLabelSelector(strategyParams.LabelSelector),
+	)
+
+	// every pod of an index is grouped, evictable or not, so the oldest pod is kept whichever it is
+	indexes := map[jobIndexKey][]jobIndexPod{}
+	var keys []jobIndexKey
+	for _, node := range nodes {
+		if !podEvictor.VisitNode(node) {
+			continue
+		}
+		klog.V(1).InfoS("Processing node", "node", klog.KObj(node))
+		pods, err := podutil.ListPodsOnANode(
+			ctx,
+			client,
+			node,
+			podutil.WithFilter(func(pod *v1.Pod) bool {
+				return pod.DeletionTimestamp == nil && jobOwnerRef(pod) != nil && pod.Annotations[batchv1.JobCompletionIndexAnnotation] != ""
+			}),
+			podutil.WithNamespaces(strategyParams.IncludedNamespaces.UnsortedList()),
+			podutil.WithoutNamespaces(strategyParams.ExcludedNamespaces.UnsortedList()),
+		)
+		if err != nil {
+			klog.ErrorS(err, "Error listing a nodes pods", "node", klog.KObj(node))
+			continue
+		}
+		for _, pod := range pods {
+			key := jobIndexKey{job: jobOwnerRef(pod).UID, index: pod.Annotations[batchv1.JobCompletionIndexAnnotation]}
+			if _, ok := indexes[key]; !ok {
+				keys = append(keys, key)
+			}
+			indexes[key] = append(indexes[key], jobIndexPod{pod: pod, node: node})
+		}
+	}
+
+	for _, key := range keys {
+		pods := indexes[key]
+		if len(pods) < 2 {
+			continue
+		}
+		sort.Slice(pods, func(i, j int) bool {
+			if !pods[i].pod.CreationTimestamp.Equal(&pods[j].pod.CreationTimestamp) {
+				return pods[i].pod.CreationTimestamp.Before(&pods[j].pod.CreationTimestamp)
+			}
+			return pods[i].pod.Name < pods[j].pod.Name
+		})
+		oldest := pods[0].pod
+		klog.V(2).InfoS("Found pods running the same completion index", "job", jobOwnerRef(oldest).Name, "namespace", oldest.Namespace, "index", key.index, "pods", len(pods))
+		for _, duplicate := range pods[1:] {
+			if !evictable.IsEvictable(duplicate.pod) {
+				continue
+			}
+			if _, err := podEvictor.EvictPod(ctx, duplicate.pod, duplicate.node, evictions.ReasonRemoveDuplicateJobIndexPods,
+				"completionIndex="+key.index, "oldestPod="+oldest.Name); err != nil {
+				klog.ErrorS(err, "Error evicting pod", "pod", klog.KObj(duplicate.pod))
+				return nil
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategies
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestRemoveDuplicateJobIndexPods(t *testing.T) {
+	ctx := context.Background()
+
+	n1 := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	n2 := test.BuildTestNode("n2", 2000, 3000, 10, nil)
+	nodes := []*v1.Node{n1, n2}
+	now := time.Now()
+	buildPod := func(name, node, jobName, index string, age time.Duration, apply func(pod *v1.Pod)) *v1.Pod {
+		return test.BuildTestPod(name, 100, 0, node, func(pod *v1.Pod) {
+			pod.CreationTimestamp = metav1.NewTime(now.Add(-age))
+			pod.OwnerReferences = []metav1.OwnerReference{{Kind: "Job", Name: jobName, UID: types.UID(jobName)}}
+			if index != "" {
+				pod.Annotations = map[string]string{batchv1.JobCompletionIndexAnnotation: index}
+			}
+			if apply != nil {
+				apply(pod)
+			}
+		})
+	}
+	pods := []*v1.Pod{
+		// index 0 of job a runs three times on two nodes, the oldest pod is kept
+		buildPod("a-0-young", n1.Name, "a", "0", time.Minute, nil),
+		buildPod("a-0-old", n2.Name, "a", "0", time.Hour, nil),
+		buildPod("a-0-younger", n2.Name, "a", "0", time.Second, nil),
+		buildPod("a-1", n1.Name, "a", "1", time.Hour, nil),
+		// the same index of another job is not a duplicate
+		buildPod("b-1", n1.Name, "b", "1", time.Minute, nil),
+		// pods of NonIndexed jobs have no completion index
+		buildPod("c-x", n1.Name, "c", "", time.Hour, nil),
+		buildPod("c-y", n1.Name, "c", "", time.Minute, nil),
+		// terminating pods are already going away
+		buildPod("d-0-old", n1.Name, "d", "0", time.Hour, nil),
+		buildPod("d-0-terminating", n2.Name, "d", "0", time.Minute, func(pod *v1.Pod) {
+			deletionTimestamp := metav1.NewTime(now)
+			pod.DeletionTimestamp = &deletionTimestamp
+		}),
+		// duplicates of a pod which is not evictable are still evicted
+		buildPod("e-0-old", n1.Name, "e", "0", time.Hour, func(pod *v1.Pod) {
+			pod.Spec.Volumes = []v1.Volume{{Name: "scratch", VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}}}
+		}),
+		buildPod("e-0-young", n2.Name, "e", "0", time.Minute, nil),
+	}
+
+	tests := []struct {
+		description     string
+		params          *api.StrategyParameters
+		expectedEvicted []string
+	}{
+		{
+			description:     "Younger pods running the completion index of an older pod are evicted",
+			expectedEvicted: []string{"a-0-young", "a-0-younger", "e-0-young"},
+		},
+		{
+			description:     "Namespaces not included are not processed",
+			params:          &api.StrategyParameters{Namespaces: &api.Namespaces{Exclude: []string{"default"}}},
+			expectedEvicted: nil,
+		},
+		{
+			description:     "Pods not matching the label selector are not evicted",
+			params:          &api.StrategyParameters{LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"job": "none"}}},
+			expectedEvicted: nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			fakeClient := &fake.Clientset{}
+			fakeClient.Fake.AddReactor("list", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				fieldSelector := action.(core.ListAction).GetListRestrictions().Fields
+				podList := &v1.PodList{}
+				for _, pod := range pods {
+					if fieldSelector.Matches(fields.Set{"spec.nodeName": pod.Spec.NodeName, "status.phase": string(pod.Status.Phase), "metadata.namespace": pod.Namespace}) {
+						podList.Items = append(podList.Items, *pod)
+					}
+				}
+				return true, podList, nil
+			})
+			var evicted []string
+			fakeClient.Fake.AddReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() == "eviction" {
+					evicted = append(evicted, action.(core.CreateAction).GetObject().(metav1.Object).GetName())
+				}
+				return true, nil, nil
+			})
+
+			podEvictor := evictions.NewPodEvictor(
+				fakeClient,
+				policyv1.SchemeGroupVersion.String(),
+				false,
+				0,
+				0,
+				nodes,
+				false,
+				false,
+				false,
+			)
+
+			strategy := api.DeschedulerStrategy{Enabled: true, Params: tc.params}
+			RemoveDuplicateJobIndexPods(ctx, fakeClient, strategy, nodes, podEvictor)
+			sort.Strings(evicted)
+			if !reflect.DeepEqual(evicted, tc.expectedEvicted) {
+				t.Errorf("Expected %v pods to be evicted, got %v", tc.expectedEvicted, evicted)
+			}
+		})
+	}
+}
@@ -0,0 +1,279 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategies
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/validation"
+	"sigs.k8s.io/descheduler/pkg/utils"
+)
+
+// validatedMemoryOverrunStrategyParams contains validated strategy parameters
+type validatedMemoryOverrunStrategyParams struct {
+	validation.ValidatedStrategyParams
+	excessPercentage      float64
+	duration              time.Duration
+	nodeUsageThreshold    api.Percentage
+	maxPodsToEvictPerNode int
+}
+
+// listPodMetrics lists the memory usage of all pods, by namespace and name, from the metrics API
+var listPodMetrics = func(ctx context.Context, client clientset.Interface) (map[string]resource.Quantity, error) {
+	data, err := client.Discovery().RESTClient().Get().AbsPath("/apis/metrics.k8s.io/v1beta1/pods").DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list pod metrics: %v", err)
+	}
+	podMetrics := &metricsv1beta1.PodMetricsList{}
+	if err := json.Unmarshal(data, podMetrics); err != nil {
+		return nil, fmt.Errorf("unable to decode pod metrics: %v", err)
+	}
+	usage := make(map[string]resource.Quantity, len(podMetrics.Items))
+	for _, metrics := range podMetrics.Items {
+		memory := resource.Quantity{}
+		for _, container := range metrics.Containers {
+			memory.Add(container.Usage[v1.ResourceMemory])
+		}
+		usage[metrics.Namespace+"/"+metrics.Name] = memory
+	}
+	return usage, nil
+}
+
+// overrunTracker remembers since when pods exceed their memory requests. Usage metrics are
+// instantaneous, so overruns are tracked across descheduling cycles.
+type overrunTracker struct {
+	lock  sync.Mutex
+	since map[types.UID]time.Time
+}
+
+var memoryOverruns = &overrunTracker{since: make(map[types.UID]time.Time)}
+
+// update records the pods currently exceeding their requests and returns since when each of them
+// does. Pods which are not exceeding their requests anymore are forgotten.
+func (t *overrunTracker) update(exceeding []*v1.Pod, now time.Time) map[types.UID]time.Time {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	since := make(map[types.UID]time.Time, len(exceeding))
+	for _, pod := range exceeding {
+		if first, ok := t.since[pod.UID]; ok {
+			since[pod.UID] = first
+		} else {
+			since[pod.UID] = now
+		}
+	}
+	t.since = since
+	return since
+}
+
+// RemovePodsExceedingMemoryRequests evicts pods whose memory usage, as reported by the metrics API,
+// exceeds their memory requests by more than the configured percentage for the configured duration,
+// from nodes under memory pressure. Such pods are chronically under-requesting memory, evicting them
+// lets the scheduler place them again with the memory they actually use available. Offenders are
+// evicted by priority, lowest first, and by excess, largest first.
+func RemovePodsExceedingMemoryRequests(
+	ctx context.Context,
+	client clientset.Interface,
+	strategy api.DeschedulerStrategy,
+	nodes []*v1.Node,
+	podEvictor *evictions.PodEvictor,
+) error {
+	strategyParams, err := validateAndParseMemoryOverrunParams(ctx, client, strategy.Params)
+	if err != nil {
+		return validation.NewStrategyError(validation.ErrorReasonInvalidParameters, "invalid RemovePodsExceedingMemoryRequests parameters", err)
+	}
+
+	usage, err := listPodMetrics(ctx, client)
+	if err != nil {
+		return validation.NewStrategyError(validation.ErrorReasonAPI, "unable to get the memory usage of pods", err)
+	}
+
+	evictable := podEvictor.Evictable(
+		evictions.WithPriorityThreshold(strategyParams.ThresholdPriority),
+		evictions.WithNodeFit(strategyParams.NodeFit),
+		evictions.WithLabelSelector(strategyParams.LabelSelector),
+	)
+
+	now := podEvictor.Now()
+	var exceeding []*v1.Pod
+	podsByNode := make(map[*v1.Node][]*v1.Pod, len(nodes))
+	for _, node := range nodes {
+		pods, err := podutil.ListPodsOnANode(ctx, client, node)
+		if err != nil {
+			klog.ErrorS(err, "Error listing pods on node", "node", klog.KObj(node))
+			continue
+		}
+		podsByNode[node] = pods
+		for _, pod := range pods {
+			if memoryExcess(pod, usage) > strategyParams.excessPercentage {
+				exceeding = append(exceeding, pod)
+			}
+		}
+	}
+	since := memoryOverruns.update(exceeding, now)
+
+	for _, node := range nodes {
+		pods, ok := podsByNode[node]
+		if !ok || !isNodeUnderMemoryPressure(node, pods, usage, strategyParams.nodeUsageThreshold) {
+			continue
+		}
+
+		var offenders []*v1.Pod
+		for _, pod := range pods {
+			first, ok := since[pod.UID]
+			if !ok || now.Sub(first) < strategyParams.duration {
+				continue
+			}
+			if (len(strategyParams.IncludedNamespaces) > 0 && !strategyParams.IncludedNamespaces.Has(pod.Namespace)) ||
+				(len(strategyParams.ExcludedNamespaces) > 0 && strategyParams.ExcludedNamespaces.Has(pod.Namespace)) {
+				continue
+			}
+			if evictable.IsEvictable(pod) {
+				offenders = append(offenders, pod)
+			}
+		}
+		if len(offenders) == 0 {
+			continue
+		}
+		klog.V(1).InfoS("Node under memory pressure runs pods exceeding their memory requests", "node", klog.KObj(node), "pods", len(offenders))
+		sortPodsByMemoryExcess(offenders, usage)
+
+		evicted := 0
+		for _, pod := range offenders {
+			if strategyParams.maxPodsToEvictPerNode > 0 && evicted >= strategyParams.maxPodsToEvictPerNode {
+				break
+			}
+			success, err := podEvictor.EvictPod(ctx, pod, node, evictions.ReasonRemovePodsExceedingMemoryRequests,
+				fmt.Sprintf("memory usage %.0f%% above requests", memoryExcess(pod, usage)))
+			if err != nil {
+				klog.ErrorS(err, "Error evicting pod", "pod", klog.KObj(pod))
+				break
+			}
+			if success {
+				evicted++
+			}
+		}
+	}
+	return nil
+}
+
+// memoryExcess returns by how many percents the memory usage of the pod exceeds its memory requests.
+// Usage without requests is an infinite excess, pods without usage metrics do not exceed their requests.
+func memoryExcess(pod *v1.Pod, usage map[string]resource.Quantity) float64 {
+	memory, ok := usage[pod.Namespace+"/"+pod.Name]
+	if !ok || memory.IsZero() {
+		return 0
+	}
+	requests, _ := utils.PodRequestsAndLimits(pod)
+	request := requests[v1.ResourceMemory]
+	if request.IsZero() {
+		return math.Inf(1)
+	}
+	return (float64(memory.Value())/float64(request.Value()) - 1) * 100
+}
+
+// isNodeUnderMemoryPressure checks whether the node reports memory pressure or, when threshold is
+// set, whether the memory usage of its pods exceeds threshold percents of its allocatable memory
+func isNodeUnderMemoryPressure(node *v1.Node, pods []*v1.Pod, usage map[string]resource.Quantity, threshold api.Percentage) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == v1.NodeMemoryPressure && condition.Status == v1.ConditionTrue {
+			return true
+		}
+	}
+	if threshold <= 0 {
+		return false
+	}
+	allocatable := node.Status.Capacity
+	if len(node.Status.Allocatable) > 0 {
+		allocatable = node.Status.Allocatable
+	}
+	capacity := allocatable[v1.ResourceMemory]
+	if capacity.IsZero() {
+		return false
+	}
+	total := int64(0)
+	for _, pod := range pods {
+		memory := usage[pod.Namespace+"/"+pod.Name]
+		total += memory.Value()
+	}
+	return float64(total) > float64(threshold)*float64(capacity.Value())*0.01
+}
+
+// sortPodsByMemoryExcess sorts pods by priority, lowest first, then by memory excess, largest first
+func sortPodsByMemoryExcess(pods []*v1.Pod, usage map[string]resource.Quantity) {
+	excess := make(map[*v1.Pod]float64, len(pods))
+	for _, pod := range pods {
+		excess[pod] = memoryExcess(pod, usage)
+	}
+	sort.SliceStable(pods, func(i, j int) bool {
+		pi, pj := int32(0), int32(0)
+		if pods[i].Spec.Priority != nil {
+			pi = *pods[i].Spec.Priority
+		}
+		if pods[j].Spec.Priority != nil {
+			pj = *pods[j].Spec.Priority
+		}
+		if pi != pj {
+			return pi < pj
+		}
+		return excess[pods[i]] > excess[pods[j]]
+	})
+}
+
+func validateAndParseMemoryOverrunParams(
+	ctx context.Context,
+	client clientset.Interface,
+	params *api.StrategyParameters,
+) (*validatedMemoryOverrunStrategyParams, error) {
+	if params == nil || params.MemoryOverrun == nil {
+		return nil, fmt.Errorf("memoryOverrun not set")
+	}
+	if params.MemoryOverrun.ExcessPercentage < 0 {
+		return nil, fmt.Errorf("excessPercentage must not be negative")
+	}
+	if params.MemoryOverrun.NodeUsageThreshold < 0 || params.MemoryOverrun.NodeUsageThreshold > 100 {
+		return nil, fmt.Errorf("nodeUsageThreshold not in [0, 100] range")
+	}
+
+	strategyParams, err := validation.ValidateAndParseStrategyParams(ctx, client, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &validatedMemoryOverrunStrategyParams{
+		ValidatedStrategyParams: *strategyParams,
+		excessPercentage:        float64(params.MemoryOverrun.ExcessPercentage),
+		duration:                time.Duration(params.MemoryOverrun.DurationSeconds) * time.Second,
+		nodeUsageThreshold:      params.MemoryOverrun.NodeUsageThreshold,
+		maxPodsToEvictPerNode:   params.MemoryOverrun.MaxPodsToEvictPerNode,
+	}, nil
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategies
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestRemovePodsExceedingMemoryRequests(t *testing.T) {
+	ctx := context.Background()
+
+	pressured := test.BuildTestNode("n1", 2000, 10000, 10, func(node *v1.Node) {
+		node.Status.Conditions = []v1.NodeCondition{{Type: v1.NodeMemoryPressure, Status: v1.ConditionTrue}}
+	})
+	relaxed := test.BuildTestNode("n2", 2000, 10000, 10, nil)
+
+	buildPod := func(name string, memory int64, nodeName string, priority int32) *v1.Pod {
+		return test.BuildTestPod(name, 100, memory, nodeName, func(pod *v1.Pod) {
+			pod.UID = types.UID(name)
+			test.SetRSOwnerRef(pod)
+			test.SetPodPriority(pod, priority)
+		})
+	}
+	pods := []*v1.Pod{
+		// 200% above requests
+		buildPod("p1", 1000, pressured.Name, 100),
+		// 50% above requests
+		buildPod("p2", 1000, pressured.Name, 0),
+		// within requests
+		buildPod("p3", 1000, pressured.Name, 0),
+		// 200% above requests, on a node without memory pressure
+		buildPod("p4", 1000, relaxed.Name, 0),
+		// no memory requests
+		buildPod("p5", -1, pressured.Name, 100),
+	}
+	usage := map[string]resource.Quantity{
+		"default/p1": *resource.NewQuantity(3000, resource.DecimalSI),
+		"default/p2": *resource.NewQuantity(1500, resource.DecimalSI),
+		"default/p3": *resource.NewQuantity(800, resource.DecimalSI),
+		"default/p4": *resource.NewQuantity(3000, resource.DecimalSI),
+		"default/p5": *resource.NewQuantity(500, resource.DecimalSI),
+	}
+
+	tests := []struct {
+		description     string
+		params          *api.MemoryOverrun
+		exceedingSince  time.Duration
+		expectedEvicted []string
+	}{
+		{
+			description:     "Pods exceeding their requests on pressured nodes are evicted, lowest priority and largest excess first",
+			params:          &api.MemoryOverrun{ExcessPercentage: 20},
+			expectedEvicted: []string{"p2", "p5", "p1"},
+		},
+		{
+			description:     "Only pods exceeding their requests by more than the excess percentage are evicted",
+			params:          &api.MemoryOverrun{ExcessPercentage: 100},
+			expectedEvicted: []string{"p5", "p1"},
+		},
+		{
+			description:     "Evictions are limited per node",
+			params:          &api.MemoryOverrun{ExcessPercentage: 20, MaxPodsToEvictPerNode: 1},
+			expectedEvicted: []string{"p2"},
+		},
+		{
+			description: "Pods exceeding their requests for less than the duration are not evicted",
+			params:      &api.MemoryOverrun{ExcessPercentage: 20, DurationSeconds: 600},
+		},
+		{
+			description:     "Pods exceeding their requests for longer than the duration are evicted",
+			params:          &api.MemoryOverrun{ExcessPercentage: 20, DurationSeconds: 600},
+			exceedingSince:  time.Hour,
+			expectedEvicted: []string{"p2", "p5", "p1"},
+		},
+		{
+			description:     "Nodes whose pods use more than the node usage threshold are pressured",
+			params:          &api.MemoryOverrun{ExcessPercentage: 20, NodeUsageThreshold: 25},
+			expectedEvicted: []string{"p2", "p5", "p1", "p4"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			listPodMetrics = func(context.Context, clientset.Interface) (map[string]resource.Quantity, error) {
+				return usage, nil
+			}
+			memoryOverruns.since = make(map[types.UID]time.Time)
+			if tc.exceedingSince > 0 {
+				for _, pod := range pods {
+					memoryOverruns.since[pod.UID] = time.Now().Add(-tc.exceedingSince)
+				}
+			}
+
+			fakeClient := &fake.Clientset{}
+			fakeClient.Fake.AddReactor("list", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				fieldSelector := action.(core.ListAction).GetListRestrictions().Fields
+				podList := &v1.PodList{}
+				for _, pod := range pods {
+					if fieldSelector.Matches(fields.Set{"spec.nodeName": pod.Spec.NodeName, "status.phase": string(pod.Status.Phase)}) {
+						podList.Items = append(podList.Items, *pod)
+					}
+				}
+				return true, podList, nil
+			})
+			var evicted []string
+			fakeClient.Fake.AddReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() == "eviction" {
+					evicted = append(evicted, action.(core.CreateAction).GetObject().(metav1.Object).GetName())
+				}
+				return true, nil, nil
+			})
+
+			nodes := []*v1.Node{pressured, relaxed}
+			podEvictor := evictions.NewPodEvictor(
+				fakeClient,
+				policyv1.SchemeGroupVersion.String(),
+				false,
+				0,
+				0,
+				nodes,
+				false,
+				false,
+				false,
+			)
+
+			strategy := api.DeschedulerStrategy{Enabled: true, Params: &api.StrategyParameters{MemoryOverrun: tc.params}}
+			RemovePodsExceedingMemoryRequests(ctx, fakeClient, strategy, nodes, podEvictor)
+			if !reflect.DeepEqual(evicted, tc.expectedEvicted) {
+				t.Errorf("Expected %v pods to be evicted, got %v", tc.expectedEvicted, evicted)
+			}
+		})
+	}
+}
@@ -28,9 +28,16 @@ import (
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
 	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/validation"
 	"sigs.k8s.io/descheduler/pkg/utils"
 )
 
+const (
+	nodeAffinityTypeRequired = "requiredDuringSchedulingIgnoredDuringExecution"
+	// nodeAffinityTypeNodeSelector checks pods by their plain spec.nodeSelector, even without node affinity
+	nodeAffinityTypeNodeSelector = "nodeSelector"
+)
+
 func validatePodsViolatingNodeAffinityParams(params *api.StrategyParameters) error {
 	if params == nil || len(params.NodeAffinityType) == 0 {
 		return fmt.Errorf("NodeAffinityType is empty")
@@ -47,21 +54,13 @@ func validatePodsViolatingNodeAffinityParams(params *api.StrategyParameters) err
 }
 
 // RemovePodsViolatingNodeAffinity evicts pods on nodes which violate node affinity
-func RemovePodsViolatingNodeAffinity(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor) {
+func RemovePodsViolatingNodeAffinity(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor) error {
 	if err := validatePodsViolatingNodeAffinityParams(strategy.Params); err != nil {
-		klog.ErrorS(err, "Invalid RemovePodsViolatingNodeAffinity parameters")
-		return
+		return validation.NewStrategyError(validation.ErrorReasonInvalidParameters, "invalid RemovePodsViolatingNodeAffinity parameters", err)
 	}
 	thresholdPriority, err := utils.GetPriorityFromStrategyParams(ctx, client, strategy.Params)
 	if err != nil {
-		klog.ErrorS(err, "Failed to get threshold priority from strategy's params")
-		return
-	}
-
-	var includedNamespaces, excludedNamespaces []string
-	if strategy.Params.Namespaces != nil {
-		includedNamespaces = strategy.Params.Namespaces.Include
-		excludedNamespaces = strategy.Params.Namespaces.Exclude
+		return validation.NewStrategyError(validation.ErrorReasonPriorityLookup, "failed to get threshold priority from strategy's params", err)
 	}
 
 	nodeFit := false
@@ -71,43 +70,82 @@ func RemovePodsViolatingNodeAffinity(ctx context.Context, client clientset.Inter
 
 	evictable := podEvictor.Evictable(evictions.WithPriorityThreshold(thresholdPriority), evictions.WithNodeFit(nodeFit))
 
+	requiredAffinityChecked := false
+	for _, nodeAffinity := range strategy.Params.NodeAffinityType {
+		if nodeAffinity == nodeAffinityTypeRequired {
+			requiredAffinityChecked = true
+		}
+	}
+
 	for _, nodeAffinity := range strategy.Params.NodeAffinityType {
 		klog.V(2).InfoS("Executing for nodeAffinityType", "nodeAffinity", nodeAffinity)
 
 		switch nodeAffinity {
-		case "requiredDuringSchedulingIgnoredDuringExecution":
-			for _, node := range nodes {
-				klog.V(1).InfoS("Processing node", "node", klog.KObj(node))
-
-				pods, err := podutil.ListPodsOnANode(
-					ctx,
-					client,
-					node,
-					podutil.WithFilter(func(pod *v1.Pod) bool {
-						return evictable.IsEvictable(pod) &&
-							!nodeutil.PodFitsCurrentNode(pod, node) &&
-							nodeutil.PodFitsAnyNode(pod, nodes)
-					}),
-					podutil.WithNamespaces(includedNamespaces),
-					podutil.WithoutNamespaces(excludedNamespaces),
-					podutil.WithLabelSelector(strategy.Params.LabelSelector),
-				)
-				if err != nil {
-					klog.ErrorS(err, "Failed to get pods", "node", klog.KObj(node))
-				}
-
-				for _, pod := range pods {
-					if pod.Spec.Affinity != nil && pod.Spec.Affinity.NodeAffinity != nil && pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution != nil {
-						klog.V(1).InfoS("Evicting pod", "pod", klog.KObj(pod))
-						if _, err := podEvictor.EvictPod(ctx, pod, node, "NodeAffinity"); err != nil {
-							klog.ErrorS(err, "Error evicting pod")
-							break
-						}
-					}
-				}
-			}
+		case nodeAffinityTypeRequired:
+			evictPodsViolatingNodeAffinity(ctx, client, strategy.Params, nodes, podEvictor, evictable.IsEvictable, hasRequiredNodeAffinity)
+		case nodeAffinityTypeNodeSelector:
+			// pods with a required node affinity were already checked, including their nodeSelector
+			evictPodsViolatingNodeAffinity(ctx, client, strategy.Params, nodes, podEvictor, evictable.IsEvictable, func(pod *v1.Pod) bool {
+				return len(pod.Spec.NodeSelector) > 0 && !(requiredAffinityChecked && hasRequiredNodeAffinity(pod))
+			})
 		default:
 			klog.ErrorS(nil, "Invalid nodeAffinityType", "nodeAffinity", nodeAffinity)
 		}
 	}
+	return nil
+}
+
+// evictPodsViolatingNodeAffinity evicts the pods selected by checked which do not fit their current node anymore,
+// but fit another one
+func evictPodsViolatingNodeAffinity(
+	ctx context.Context,
+	client clientset.Interface,
+	params *api.StrategyParameters,
+	nodes []*v1.Node,
+	podEvictor *evictions.PodEvictor,
+	isEvictable func(pod *v1.Pod) bool,
+	checked func(pod *v1.Pod) bool,
+) {
+	var includedNamespaces, excludedNamespaces []string
+	if params.Namespaces != nil {
+		includedNamespaces = params.Namespaces.Include
+		excludedNamespaces = params.Namespaces.Exclude
+	}
+
+	for _, node := range nodes {
+		if !podEvictor.VisitNode(node) {
+			continue
+		}
+		klog.V(1).InfoS("Processing node", "node", klog.KObj(node))
+
+		pods, err := podutil.ListPodsOnANode(
+			ctx,
+			client,
+			node,
+			podutil.WithFilter(func(pod *v1.Pod) bool {
+				return checked(pod) &&
+					isEvictable(pod) &&
+					!nodeutil.PodFitsCurrentNode(pod, node) &&
+					nodeutil.PodFitsAnyNode(pod, nodes)
+			}),
+			podutil.WithNamespaces(includedNamespaces),
+			podutil.WithoutNamespaces(excludedNamespaces),
+			podutil.WithLabelSelector(params.LabelSelector),
+		)
+		if err != nil {
+			klog.ErrorS(err, "Failed to get pods", "node", klog.KObj(node))
+		}
+
+		for _, pod := range pods {
+			klog.V(1).InfoS("Evicting pod", "pod", klog.KObj(pod))
+			if _, err := podEvictor.EvictPod(ctx, pod, node, evictions.ReasonRemovePodsViolatingNodeAffinity); err != nil {
+				klog.ErrorS(err, "Error evicting pod")
+				break
+			}
+		}
+	}
+}
+
+func hasRequiredNodeAffinity(pod *v1.Pod) bool {
+	return pod.Spec.Affinity != nil && pod.Spec.Affinity.NodeAffinity != nil && pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution != nil
 }
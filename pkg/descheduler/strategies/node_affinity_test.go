@@ -51,6 +51,25 @@ func TestRemovePodsViolatingNodeAffinity(t *testing.T) {
 		},
 	}
 
+	nodeSelectorStrategy := api.DeschedulerStrategy{
+		Enabled: true,
+		Params: &api.StrategyParameters{
+			NodeAffinityType: []string{
+				"nodeSelector",
+			},
+		},
+	}
+
+	requiredDuringSchedulingIgnoredDuringExecutionAndNodeSelectorStrategy := api.DeschedulerStrategy{
+		Enabled: true,
+		Params: &api.StrategyParameters{
+			NodeAffinityType: []string{
+				"requiredDuringSchedulingIgnoredDuringExecution",
+				"nodeSelector",
+			},
+		},
+	}
+
 	nodeLabelKey := "kubernetes.io/desiredNode"
 	nodeLabelValue := "yes"
 	nodeWithLabels := test.BuildTestNode("nodeWithLabels", 2000, 3000, 10, nil)
@@ -84,15 +103,20 @@ func TestRemovePodsViolatingNodeAffinity(t *testing.T) {
 			},
 		}
 
+		podWithNodeSelector := test.BuildTestPod("podWithNodeSelector", 100, 0, node.Name, nil)
+		podWithNodeSelector.Spec.NodeSelector = map[string]string{nodeLabelKey: nodeLabelValue}
+
 		pod1 := test.BuildTestPod("pod1", 100, 0, node.Name, nil)
 		pod2 := test.BuildTestPod("pod2", 100, 0, node.Name, nil)
 
 		podWithNodeAffinity.ObjectMeta.OwnerReferences = test.GetNormalPodOwnerRefList()
+		podWithNodeSelector.ObjectMeta.OwnerReferences = test.GetNormalPodOwnerRefList()
 		pod1.ObjectMeta.OwnerReferences = test.GetNormalPodOwnerRefList()
 		pod2.ObjectMeta.OwnerReferences = test.GetNormalPodOwnerRefList()
 
 		return []v1.Pod{
 			*podWithNodeAffinity,
+			*podWithNodeSelector,
 			*pod1,
 			*pod2,
 		}
@@ -161,6 +185,30 @@ func TestRemovePodsViolatingNodeAffinity(t *testing.T) {
 			nodes:                   []*v1.Node{nodeWithLabels, unschedulableNodeWithLabels},
 			maxPodsToEvictPerNode:   1,
 		},
+		{
+			description:             "Pod with a nodeSelector is scheduled on node without matching labels, another schedulable node available, should be evicted",
+			expectedEvictedPodCount: 1,
+			strategy:                nodeSelectorStrategy,
+			pods:                    addPodsToNode(nodeWithoutLabels),
+			nodes:                   []*v1.Node{nodeWithoutLabels, nodeWithLabels},
+			maxPodsToEvictPerNode:   0,
+		},
+		{
+			description:             "Pod with a nodeSelector is correctly scheduled on node, no eviction expected",
+			expectedEvictedPodCount: 0,
+			strategy:                nodeSelectorStrategy,
+			pods:                    addPodsToNode(nodeWithLabels),
+			nodes:                   []*v1.Node{nodeWithLabels},
+			maxPodsToEvictPerNode:   0,
+		},
+		{
+			description:             "Pods with node affinity and with a nodeSelector are scheduled on node without matching labels, both should be evicted",
+			expectedEvictedPodCount: 2,
+			strategy:                requiredDuringSchedulingIgnoredDuringExecutionAndNodeSelectorStrategy,
+			pods:                    addPodsToNode(nodeWithoutLabels),
+			nodes:                   []*v1.Node{nodeWithoutLabels, nodeWithLabels},
+			maxPodsToEvictPerNode:   0,
+		},
 	}
 
 	for _, tc := range tests {
@@ -175,6 +223,7 @@ func TestRemovePodsViolatingNodeAffinity(t *testing.T) {
 			policyv1.SchemeGroupVersion.String(),
 			false,
 			tc.maxPodsToEvictPerNode,
+			0,
 			tc.nodes,
 			false,
 			false,
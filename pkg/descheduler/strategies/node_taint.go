@@ -23,6 +23,7 @@ import (
 	"sigs.k8s.io/descheduler/pkg/api"
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/validation"
 	"sigs.k8s.io/descheduler/pkg/utils"
 
 	v1 "k8s.io/api/core/v1"
@@ -48,10 +49,9 @@ func validateRemovePodsViolatingNodeTaintsParams(params *api.StrategyParameters)
 }
 
 // RemovePodsViolatingNodeTaints evicts pods on the node which violate NoSchedule Taints on nodes
-func RemovePodsViolatingNodeTaints(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor) {
+func RemovePodsViolatingNodeTaints(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor) error {
 	if err := validateRemovePodsViolatingNodeTaintsParams(strategy.Params); err != nil {
-		klog.ErrorS(err, "Invalid RemovePodsViolatingNodeTaints parameters")
-		return
+		return validation.NewStrategyError(validation.ErrorReasonInvalidParameters, "invalid RemovePodsViolatingNodeTaints parameters", err)
 	}
 
 	var includedNamespaces, excludedNamespaces []string
@@ -66,8 +66,7 @@ func RemovePodsViolatingNodeTaints(ctx context.Context, client clientset.Interfa
 
 	thresholdPriority, err := utils.GetPriorityFromStrategyParams(ctx, client, strategy.Params)
 	if err != nil {
-		klog.ErrorS(err, "Failed to get threshold priority from strategy's params")
-		return
+		return validation.NewStrategyError(validation.ErrorReasonPriorityLookup, "failed to get threshold priority from strategy's params", err)
 	}
 
 	nodeFit := false
@@ -78,6 +77,9 @@ func RemovePodsViolatingNodeTaints(ctx context.Context, client clientset.Interfa
 	evictable := podEvictor.Evictable(evictions.WithPriorityThreshold(thresholdPriority), evictions.WithNodeFit(nodeFit))
 
 	for _, node := range nodes {
+		if !podEvictor.VisitNode(node) {
+			continue
+		}
 		klog.V(1).InfoS("Processing node", "node", klog.KObj(node))
 		pods, err := podutil.ListPodsOnANode(
 			ctx,
@@ -90,7 +92,7 @@ func RemovePodsViolatingNodeTaints(ctx context.Context, client clientset.Interfa
 		)
 		if err != nil {
 			//no pods evicted as error encountered retrieving evictable Pods
-			return
+			return nil
 		}
 		totalPods := len(pods)
 		for i := 0; i < totalPods; i++ {
@@ -100,11 +102,12 @@ func RemovePodsViolatingNodeTaints(ctx context.Context, client clientset.Interfa
 				func(taint *v1.Taint) bool { return taint.Effect == v1.TaintEffectNoSchedule },
 			) {
 				klog.V(2).InfoS("Not all taints with NoSchedule effect are tolerated after update for pod on node", "pod", klog.KObj(pods[i]), "node", klog.KObj(node))
-				if _, err := podEvictor.EvictPod(ctx, pods[i], node, "NodeTaint"); err != nil {
+				if _, err := podEvictor.EvictPod(ctx, pods[i], node, evictions.ReasonRemovePodsViolatingNodeTaints); err != nil {
 					klog.ErrorS(err, "Error evicting pod")
 					break
 				}
 			}
 		}
 	}
+	return nil
 }
@@ -235,6 +235,7 @@ func TestDeletePodsViolatingNodeTaints(t *testing.T) {
 			policyv1.SchemeGroupVersion.String(),
 			false,
 			tc.maxPodsToEvictPerNode,
+			0,
 			tc.nodes,
 			tc.evictLocalStoragePods,
 			tc.evictSystemCriticalPods,
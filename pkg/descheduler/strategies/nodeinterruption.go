@@ -0,0 +1,150 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategies
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/validation"
+)
+
+// DefaultNodeInterruptionTaints are the taints set on nodes about to be interrupted by the
+// AWS Node Termination Handler and by GKE before a preemptible or spot node is terminated
+var DefaultNodeInterruptionTaints = []string{
+	"aws-node-termination-handler/spot-itn",
+	"aws-node-termination-handler/asg-lifecycle-termination",
+	"aws-node-termination-handler/scheduled-maintenance",
+	"aws-node-termination-handler/rebalance-recommendation",
+	"cloud.google.com/impending-node-termination",
+}
+
+// validatedNodeInterruptionStrategyParams contains validated strategy parameters
+type validatedNodeInterruptionStrategyParams struct {
+	validation.ValidatedStrategyParams
+	taints sets.String
+	labels sets.String
+}
+
+// RemovePodsFromInterruptedNodes evicts pods from nodes with any of the configured taints or labels,
+// signaling that the node is about to be interrupted, so they are rescheduled before the node goes away.
+// The strategy runs before the other strategies of the descheduling cycle.
+func RemovePodsFromInterruptedNodes(
+	ctx context.Context,
+	client clientset.Interface,
+	strategy api.DeschedulerStrategy,
+	nodes []*v1.Node,
+	podEvictor *evictions.PodEvictor,
+) error {
+	strategyParams, err := validateAndParseNodeInterruptionParams(ctx, client, strategy.Params)
+	if err != nil {
+		return validation.NewStrategyError(validation.ErrorReasonInvalidParameters, "invalid RemovePodsFromInterruptedNodes parameters", err)
+	}
+
+	evictable := podEvictor.Evictable(
+		evictions.WithPriorityThreshold(strategyParams.ThresholdPriority),
+		evictions.WithNodeFit(strategyParams.NodeFit),
+		evictions.WithLabelSelector(strategyParams.LabelSelector),
+	)
+
+	for _, node := range nodes {
+		signal := nodeInterruptionSignal(node, strategyParams.taints, strategyParams.labels)
+		if signal == "" {
+			continue
+		}
+		if !podEvictor.VisitNode(node) {
+			continue
+		}
+		klog.V(1).InfoS("Processing node about to be interrupted", "node", klog.KObj(node), "signal", signal)
+		pods, err := podutil.ListPodsOnANode(
+			ctx,
+			client,
+			node,
+			podutil.WithFilter(evictable.IsEvictable),
+			podutil.WithNamespaces(strategyParams.IncludedNamespaces.UnsortedList()),
+			podutil.WithoutNamespaces(strategyParams.ExcludedNamespaces.UnsortedList()),
+		)
+		if err != nil {
+			klog.ErrorS(err, "Error listing pods on node", "node", klog.KObj(node))
+			continue
+		}
+		// evict pods with higher priority first, they are the most important to reschedule in time
+		podutil.SortPodsBasedOnPriorityLowToHigh(pods)
+		for i := len(pods) - 1; i >= 0; i-- {
+			if _, err := podEvictor.EvictPod(ctx, pods[i], node, evictions.ReasonRemovePodsFromInterruptedNodes, "signal="+signal); err != nil {
+				klog.ErrorS(err, "Error evicting pod")
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// nodeInterruptionSignal returns the first of the given taint or label keys set on the node
+func nodeInterruptionSignal(node *v1.Node, taints, labels sets.String) string {
+	for _, taint := range node.Spec.Taints {
+		if taints.Has(taint.Key) {
+			return "taint " + taint.Key
+		}
+	}
+	for key := range node.Labels {
+		if labels.Has(key) {
+			return "label " + key
+		}
+	}
+	return ""
+}
+
+func validateAndParseNodeInterruptionParams(
+	ctx context.Context,
+	client clientset.Interface,
+	params *api.StrategyParameters,
+) (*validatedNodeInterruptionStrategyParams, error) {
+	taints := sets.NewString(DefaultNodeInterruptionTaints...)
+	labels := sets.NewString()
+	if params != nil && params.NodeInterruption != nil {
+		if len(params.NodeInterruption.Taints) > 0 || len(params.NodeInterruption.Labels) > 0 {
+			taints = sets.NewString(params.NodeInterruption.Taints...)
+			labels = sets.NewString(params.NodeInterruption.Labels...)
+		}
+		for _, key := range append(taints.List(), labels.List()...) {
+			if errs := utilvalidation.IsQualifiedName(key); len(errs) > 0 {
+				return nil, fmt.Errorf("%q is not a valid taint or label key: %v", key, errs)
+			}
+		}
+	}
+
+	strategyParams, err := validation.ValidateAndParseStrategyParams(ctx, client, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &validatedNodeInterruptionStrategyParams{
+		ValidatedStrategyParams: *strategyParams,
+		taints:                  taints,
+		labels:                  labels,
+	}, nil
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategies
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestRemovePodsFromInterruptedNodes(t *testing.T) {
+	ctx := context.Background()
+
+	spotNode := test.BuildTestNode("spot", 2000, 3000, 10, func(node *v1.Node) {
+		node.Spec.Taints = []v1.Taint{{Key: "aws-node-termination-handler/spot-itn", Value: "1", Effect: v1.TaintEffectNoSchedule}}
+	})
+	labeledNode := test.BuildTestNode("labeled", 2000, 3000, 10, func(node *v1.Node) {
+		node.Labels = map[string]string{"example.com/interrupted": "true"}
+	})
+	healthyNode := test.BuildTestNode("healthy", 2000, 3000, 10, nil)
+
+	buildPod := func(name, nodeName string, priority int32) *v1.Pod {
+		return test.BuildTestPod(name, 100, 0, nodeName, func(pod *v1.Pod) {
+			test.SetRSOwnerRef(pod)
+			test.SetPodPriority(pod, priority)
+		})
+	}
+	pods := []*v1.Pod{
+		buildPod("p1", spotNode.Name, 0),
+		buildPod("p2", spotNode.Name, 100),
+		buildPod("p3", labeledNode.Name, 0),
+		buildPod("p4", healthyNode.Name, 0),
+	}
+
+	tests := []struct {
+		description     string
+		params          *api.StrategyParameters
+		expectedEvicted []string
+	}{
+		{
+			description:     "Pods are evicted from nodes with a default interruption taint, highest priority first",
+			expectedEvicted: []string{"p2", "p1"},
+		},
+		{
+			description:     "Configured labels replace the default taints",
+			params:          &api.StrategyParameters{NodeInterruption: &api.NodeInterruption{Labels: []string{"example.com/interrupted"}}},
+			expectedEvicted: []string{"p3"},
+		},
+		{
+			description: "Invalid keys are refused",
+			params:      &api.StrategyParameters{NodeInterruption: &api.NodeInterruption{Taints: []string{"not a key"}}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			fakeClient := &fake.Clientset{}
+			fakeClient.Fake.AddReactor("list", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				fieldSelector := action.(core.ListAction).GetListRestrictions().Fields
+				podList := &v1.PodList{}
+				for _, pod := range pods {
+					if fieldSelector.Matches(fields.Set{"spec.nodeName": pod.Spec.NodeName, "status.phase": string(pod.Status.Phase)}) {
+						podList.Items = append(podList.Items, *pod)
+					}
+				}
+				return true, podList, nil
+			})
+			var evicted []string
+			fakeClient.Fake.AddReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() == "eviction" {
+					evicted = append(evicted, action.(core.CreateAction).GetObject().(metav1.Object).GetName())
+				}
+				return true, nil, nil
+			})
+
+			nodes := []*v1.Node{spotNode, labeledNode, healthyNode}
+			podEvictor := evictions.NewPodEvictor(
+				fakeClient,
+				policyv1.SchemeGroupVersion.String(),
+				false,
+				0,
+				0,
+				nodes,
+				false,
+				false,
+				false,
+			)
+
+			strategy := api.DeschedulerStrategy{Enabled: true, Params: tc.params}
+			RemovePodsFromInterruptedNodes(ctx, fakeClient, strategy, nodes, podEvictor)
+			if !reflect.DeepEqual(evicted, tc.expectedEvicted) {
+				t.Errorf("Expected %v pods to be evicted, got %v", tc.expectedEvicted, evicted)
+			}
+		})
+	}
+}
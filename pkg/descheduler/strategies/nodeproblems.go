@@ -0,0 +1,148 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategies
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/validation"
+)
+
+// DefaultNodeProblemConditions are the permanent problems reported by the default
+// Node Problem Detector configuration
+var DefaultNodeProblemConditions = []string{"KernelDeadlock", "ReadonlyFilesystem"}
+
+// validatedNodeProblemsStrategyParams contains validated strategy parameters
+type validatedNodeProblemsStrategyParams struct {
+	validation.ValidatedStrategyParams
+	conditions             sets.String
+	maxPodsToEvictPerCycle int
+}
+
+// RemovePodsFromNodesWithProblems evicts pods from nodes reporting any of the configured
+// conditions with status True. Such conditions are usually set by the Node Problem Detector
+// on nodes with degraded hardware or kernel issues.
+func RemovePodsFromNodesWithProblems(
+	ctx context.Context,
+	client clientset.Interface,
+	strategy api.DeschedulerStrategy,
+	nodes []*v1.Node,
+	podEvictor *evictions.PodEvictor,
+) error {
+	strategyParams, err := validateAndParseNodeProblemsParams(ctx, client, strategy.Params)
+	if err != nil {
+		return validation.NewStrategyError(validation.ErrorReasonInvalidParameters, "invalid RemovePodsFromNodesWithProblems parameters", err)
+	}
+
+	evictable := podEvictor.Evictable(
+		evictions.WithPriorityThreshold(strategyParams.ThresholdPriority),
+		evictions.WithNodeFit(strategyParams.NodeFit),
+		evictions.WithLabelSelector(strategyParams.LabelSelector),
+	)
+
+	evicted := 0
+	for _, node := range nodes {
+		condition := nodeProblemCondition(node, strategyParams.conditions)
+		if condition == "" {
+			continue
+		}
+		if !podEvictor.VisitNode(node) {
+			continue
+		}
+		klog.V(1).InfoS("Processing node reporting a problem", "node", klog.KObj(node), "condition", condition)
+		pods, err := podutil.ListPodsOnANode(
+			ctx,
+			client,
+			node,
+			podutil.WithFilter(evictable.IsEvictable),
+			podutil.WithNamespaces(strategyParams.IncludedNamespaces.UnsortedList()),
+			podutil.WithoutNamespaces(strategyParams.ExcludedNamespaces.UnsortedList()),
+		)
+		if err != nil {
+			klog.ErrorS(err, "Error listing pods on node", "node", klog.KObj(node))
+			continue
+		}
+		// evict pods with lower priority first
+		podutil.SortPodsBasedOnPriorityLowToHigh(pods)
+		for _, pod := range pods {
+			if strategyParams.maxPodsToEvictPerCycle > 0 && evicted >= strategyParams.maxPodsToEvictPerCycle {
+				klog.V(1).InfoS("Maximum number of pods evicted from nodes with problems in this cycle reached", "maxPodsToEvictPerCycle", strategyParams.maxPodsToEvictPerCycle)
+				return nil
+			}
+			success, err := podEvictor.EvictPod(ctx, pod, node, evictions.ReasonRemovePodsFromNodesWithProblems, "condition="+condition)
+			if err != nil {
+				klog.ErrorS(err, "Error evicting pod")
+				break
+			}
+			if success {
+				evicted++
+			}
+		}
+	}
+	return nil
+}
+
+// nodeProblemCondition returns the first of the given conditions the node reports with status True
+func nodeProblemCondition(node *v1.Node, conditions sets.String) string {
+	for _, condition := range node.Status.Conditions {
+		if condition.Status == v1.ConditionTrue && conditions.Has(string(condition.Type)) {
+			return string(condition.Type)
+		}
+	}
+	return ""
+}
+
+func validateAndParseNodeProblemsParams(
+	ctx context.Context,
+	client clientset.Interface,
+	params *api.StrategyParameters,
+) (*validatedNodeProblemsStrategyParams, error) {
+	conditions := sets.NewString(DefaultNodeProblemConditions...)
+	maxPodsToEvictPerCycle := 0
+	if params != nil && params.NodeProblems != nil {
+		if len(params.NodeProblems.Conditions) > 0 {
+			conditions = sets.NewString(params.NodeProblems.Conditions...)
+		}
+		if conditions.Has(string(v1.NodeReady)) {
+			return nil, fmt.Errorf("the %s condition can not be used as a node problem", v1.NodeReady)
+		}
+		if params.NodeProblems.MaxPodsToEvictPerCycle < 0 {
+			return nil, fmt.Errorf("maxPodsToEvictPerCycle can not be negative")
+		}
+		maxPodsToEvictPerCycle = params.NodeProblems.MaxPodsToEvictPerCycle
+	}
+
+	strategyParams, err := validation.ValidateAndParseStrategyParams(ctx, client, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &validatedNodeProblemsStrategyParams{
+		ValidatedStrategyParams: *strategyParams,
+		conditions:              conditions,
+		maxPodsToEvictPerCycle:  maxPodsToEvictPerCycle,
+	}, nil
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategies
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestRemovePodsFromNodesWithProblems(t *testing.T) {
+	ctx := context.Background()
+
+	problemNode := func(name string, conditionType v1.NodeConditionType, status v1.ConditionStatus) *v1.Node {
+		return test.BuildTestNode(name, 2000, 3000, 10, func(node *v1.Node) {
+			node.Status.Conditions = append(node.Status.Conditions, v1.NodeCondition{Type: conditionType, Status: status})
+		})
+	}
+	healthyNode := test.BuildTestNode("healthy", 2000, 3000, 10, nil)
+	deadlockNode := problemNode("deadlock", "KernelDeadlock", v1.ConditionTrue)
+	recoveredNode := problemNode("recovered", "KernelDeadlock", v1.ConditionFalse)
+	badDiskNode := problemNode("baddisk", "BadDisk", v1.ConditionTrue)
+
+	pods := []*v1.Pod{
+		test.BuildTestPod("p1", 100, 0, healthyNode.Name, test.SetRSOwnerRef),
+		test.BuildTestPod("p2", 100, 0, deadlockNode.Name, test.SetRSOwnerRef),
+		test.BuildTestPod("p3", 100, 0, deadlockNode.Name, test.SetRSOwnerRef),
+		test.BuildTestPod("p4", 100, 0, deadlockNode.Name, test.SetDSOwnerRef),
+		test.BuildTestPod("p5", 100, 0, recoveredNode.Name, test.SetRSOwnerRef),
+		test.BuildTestPod("p6", 100, 0, badDiskNode.Name, test.SetRSOwnerRef),
+	}
+
+	tests := []struct {
+		description             string
+		nodes                   []*v1.Node
+		params                  *api.StrategyParameters
+		expectedEvictedPodCount int
+	}{
+		{
+			description:             "Default conditions, evictable pods on nodes with problems are evicted",
+			nodes:                   []*v1.Node{healthyNode, deadlockNode, recoveredNode, badDiskNode},
+			expectedEvictedPodCount: 2,
+		},
+		{
+			description: "Custom conditions",
+			nodes:       []*v1.Node{healthyNode, deadlockNode, recoveredNode, badDiskNode},
+			params: &api.StrategyParameters{
+				NodeProblems: &api.NodeProblems{Conditions: []string{"BadDisk"}},
+			},
+			expectedEvictedPodCount: 1,
+		},
+		{
+			description: "Evictions are limited per cycle",
+			nodes:       []*v1.Node{healthyNode, deadlockNode, recoveredNode, badDiskNode},
+			params: &api.StrategyParameters{
+				NodeProblems: &api.NodeProblems{Conditions: []string{"KernelDeadlock", "BadDisk"}, MaxPodsToEvictPerCycle: 2},
+			},
+			expectedEvictedPodCount: 2,
+		},
+		{
+			description: "Ready condition is rejected",
+			nodes:       []*v1.Node{healthyNode, deadlockNode},
+			params: &api.StrategyParameters{
+				NodeProblems: &api.NodeProblems{Conditions: []string{"KernelDeadlock", string(v1.NodeReady)}},
+			},
+			expectedEvictedPodCount: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			fakeClient := &fake.Clientset{}
+			fakeClient.Fake.AddReactor("list", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				fieldSelector := action.(core.ListAction).GetListRestrictions().Fields
+				podList := &v1.PodList{}
+				for _, pod := range pods {
+					if fieldSelector.Matches(fields.Set{"spec.nodeName": pod.Spec.NodeName, "status.phase": string(pod.Status.Phase)}) {
+						podList.Items = append(podList.Items, *pod)
+					}
+				}
+				return true, podList, nil
+			})
+
+			podEvictor := evictions.NewPodEvictor(
+				fakeClient,
+				policyv1.SchemeGroupVersion.String(),
+				false,
+				0,
+				0,
+				tc.nodes,
+				false,
+				false,
+				false,
+			)
+
+			strategy := api.DeschedulerStrategy{Enabled: true, Params: tc.params}
+			RemovePodsFromNodesWithProblems(ctx, fakeClient, strategy, tc.nodes, podEvictor)
+			if actualEvictedPodCount := podEvictor.TotalEvicted(); actualEvictedPodCount != tc.expectedEvictedPodCount {
+				t.Errorf("Expected %v pod evictions, but got %v pod evictions", tc.expectedEvictedPodCount, actualEvictedPodCount)
+			}
+		})
+	}
+}
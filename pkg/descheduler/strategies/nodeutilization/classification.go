@@ -0,0 +1,106 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeutilization
+
+import (
+	"context"
+	"encoding/json"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+)
+
+// NodeClassificationAnnotationPrefix prefixes the name of the strategy in the key of the annotation
+// recording how the strategy classifies a node, set to a JSON encoded NodeClassificationRecord
+const NodeClassificationAnnotationPrefix = "node-utilization.descheduler.alpha.kubernetes.io/"
+
+// NodeClassification is the utilization class of a node
+type NodeClassification string
+
+const (
+	NodeUnderutilized         NodeClassification = "Underutilized"
+	NodeOverutilized          NodeClassification = "Overutilized"
+	NodeAppropriatelyUtilized NodeClassification = "AppropriatelyUtilized"
+)
+
+// NodeClassificationRecord is the classification of a node and the time the node was first classified so
+type NodeClassificationRecord struct {
+	Classification NodeClassification `json:"classification"`
+	Since          metav1.Time        `json:"since"`
+}
+
+// nodeClassifications returns the classification of the nodes by name
+func nodeClassifications(nodeUsages, lowNodes, highNodes []NodeUsage) map[string]NodeClassification {
+	classifications := make(map[string]NodeClassification, len(nodeUsages))
+	for _, nodeUsage := range nodeUsages {
+		classifications[nodeUsage.node.Name] = NodeAppropriatelyUtilized
+	}
+	for _, nodeUsage := range lowNodes {
+		classifications[nodeUsage.node.Name] = NodeUnderutilized
+	}
+	for _, nodeUsage := range highNodes {
+		classifications[nodeUsage.node.Name] = NodeOverutilized
+	}
+	return classifications
+}
+
+// recordNodeUtilizations records the usage and classification of the nodes through the pod evictor,
+// e.g. to export them after the descheduling cycle
+func recordNodeUtilizations(podEvictor *evictions.PodEvictor, strategy string, nodeUsages, lowNodes, highNodes []NodeUsage) {
+	classifications := nodeClassifications(nodeUsages, lowNodes, highNodes)
+	for _, nodeUsage := range nodeUsages {
+		podEvictor.RecordNodeUtilization(strategy, nodeUsage.node.Name, string(classifications[nodeUsage.node.Name]), resourceUsagePercentages(nodeUsage))
+	}
+}
+
+// annotateNodeClassifications records the classification of the nodes in their annotation. Nodes are
+// only patched when their classification changed, failures are only logged.
+func annotateNodeClassifications(ctx context.Context, client clientset.Interface, strategy string, nodeUsages, lowNodes, highNodes []NodeUsage) {
+	classifications := nodeClassifications(nodeUsages, lowNodes, highNodes)
+	key := NodeClassificationAnnotationPrefix + strategy
+	for _, nodeUsage := range nodeUsages {
+		node := nodeUsage.node
+		classification := classifications[node.Name]
+		var record NodeClassificationRecord
+		if value, ok := node.Annotations[key]; ok && json.Unmarshal([]byte(value), &record) == nil && record.Classification == classification {
+			continue
+		}
+		value, err := json.Marshal(NodeClassificationRecord{Classification: classification, Since: metav1.Now()})
+		if err != nil {
+			klog.ErrorS(err, "Unable to encode node classification", "node", klog.KObj(node))
+			continue
+		}
+		patch, err := json.Marshal(map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"annotations": map[string]string{key: string(value)},
+			},
+		})
+		if err != nil {
+			klog.ErrorS(err, "Unable to encode node classification patch", "node", klog.KObj(node))
+			continue
+		}
+		if _, err := client.CoreV1().Nodes().Patch(ctx, node.Name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+			klog.ErrorS(err, "Unable to annotate node with its classification", "node", klog.KObj(node), "classification", classification)
+			continue
+		}
+		klog.V(3).InfoS("Annotated node with its classification", "node", klog.KObj(node), "strategy", strategy, "classification", classification)
+	}
+}
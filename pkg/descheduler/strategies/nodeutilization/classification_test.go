@@ -0,0 +1,80 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeutilization
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestAnnotateNodeClassifications(t *testing.T) {
+	ctx := context.Background()
+	key := NodeClassificationAnnotationPrefix + "LowNodeUtilization"
+
+	since := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+	annotated := func(classification NodeClassification) func(node *v1.Node) {
+		return func(node *v1.Node) {
+			value, _ := json.Marshal(NodeClassificationRecord{Classification: classification, Since: since})
+			node.Annotations = map[string]string{key: string(value)}
+		}
+	}
+	n1 := test.BuildTestNode("n1", 1000, 1000, 10, nil)
+	n2 := test.BuildTestNode("n2", 1000, 1000, 10, annotated(NodeOverutilized))
+	n3 := test.BuildTestNode("n3", 1000, 1000, 10, annotated(NodeUnderutilized))
+	nodeUsages := []NodeUsage{{node: n1}, {node: n2}, {node: n3}}
+
+	fakeClient := fake.NewSimpleClientset(n1, n2, n3)
+	var patched []string
+	fakeClient.PrependReactor("patch", "nodes", func(action core.Action) (bool, runtime.Object, error) {
+		patched = append(patched, action.(core.PatchAction).GetName())
+		return false, nil, nil
+	})
+
+	// n1 is not annotated yet, n2 keeps its classification and n3 becomes overutilized
+	annotateNodeClassifications(ctx, fakeClient, "LowNodeUtilization", nodeUsages, []NodeUsage{{node: n1}}, []NodeUsage{{node: n2}, {node: n3}})
+
+	if len(patched) != 2 || patched[0] != "n1" || patched[1] != "n3" {
+		t.Errorf("Expected nodes n1 and n3 to be patched, got %v", patched)
+	}
+	expected := map[string]NodeClassification{"n1": NodeUnderutilized, "n2": NodeOverutilized, "n3": NodeOverutilized}
+	for name, classification := range expected {
+		node, err := fakeClient.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("Unable to get node %v: %v", name, err)
+		}
+		var record NodeClassificationRecord
+		if err := json.Unmarshal([]byte(node.Annotations[key]), &record); err != nil {
+			t.Fatalf("Unable to decode the classification of node %v: %v", name, err)
+		}
+		if record.Classification != classification {
+			t.Errorf("Expected node %v to be classified %v, got %v", name, classification, record.Classification)
+		}
+		if name == "n2" && !record.Since.Equal(&since) {
+			t.Errorf("Expected the classification of node n2 to be kept since %v, got %v", since, record.Since)
+		}
+	}
+}
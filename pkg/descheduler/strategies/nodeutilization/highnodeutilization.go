@@ -25,18 +25,20 @@ import (
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/balance"
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
 	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/validation"
 	"sigs.k8s.io/descheduler/pkg/utils"
 )
 
 // HighNodeUtilization evicts pods from under utilized nodes so that scheduler can schedule according to its strategy.
 // Note that CPU/Memory requests are used to calculate nodes' utilization and not the actual resource usage.
-func HighNodeUtilization(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor) {
+func HighNodeUtilization(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor) error {
 	if err := validateNodeUtilizationParams(strategy.Params); err != nil {
-		klog.ErrorS(err, "Invalid HighNodeUtilization parameters")
-		return
+		return validation.NewStrategyError(validation.ErrorReasonInvalidParameters, "invalid HighNodeUtilization parameters", err)
 	}
+	strategy.Params = convertFractionalThresholds(strategy.Params, "HighNodeUtilization")
 
 	nodeFit := false
 	if strategy.Params != nil {
@@ -45,25 +47,63 @@ func HighNodeUtilization(ctx context.Context, client clientset.Interface, strate
 
 	thresholdPriority, err := utils.GetPriorityFromStrategyParams(ctx, client, strategy.Params)
 	if err != nil {
-		klog.ErrorS(err, "Failed to get threshold priority from strategy's params")
-		return
+		return validation.NewStrategyError(validation.ErrorReasonPriorityLookup, "failed to get threshold priority from strategy's params", err)
 	}
 
-	thresholds := strategy.Params.NodeResourceUtilizationThresholds.Thresholds
+	// thresholds are copied as they get defaulted below, the policy is shared by all cycles
+	thresholds := strategy.Params.NodeResourceUtilizationThresholds.Thresholds.DeepCopy()
 	targetThresholds := strategy.Params.NodeResourceUtilizationThresholds.TargetThresholds
 	if err := validateHighUtilizationStrategyConfig(thresholds, targetThresholds); err != nil {
-		klog.ErrorS(err, "HighNodeUtilization config is not valid")
-		return
+		return validation.NewStrategyError(validation.ErrorReasonInvalidParameters, "HighNodeUtilization config is not valid", err)
 	}
-	targetThresholds = make(api.ResourceThresholds)
+	warningThresholds := strategy.Params.NodeResourceUtilizationThresholds.WarningThresholds
+	if err := validateWarningThresholds(warningThresholds, thresholds); err != nil {
+		return validation.NewStrategyError(validation.ErrorReasonInvalidParameters, "HighNodeUtilization config is not valid", err)
+	}
+	evictable := podEvictor.Evictable(evictions.WithPriorityThreshold(thresholdPriority), evictions.WithNodeFit(nodeFit))
+	podResources := newPodResources(ctx, client, strategy.Params.NodeResourceUtilizationThresholds)
 
+	domainKey := balancingDomain(strategy.Params.NodeResourceUtilizationThresholds)
+	for _, domain := range groupNodesByDomain(nodes, domainKey) {
+		if domainKey != "" {
+			klog.V(1).InfoS("Balancing nodes of domain", "balancingDomain", domainKey, "domain", domain.name, "nodes", len(domain.nodes))
+		}
+		balanceHighNodeUtilization(ctx, client, strategy.Params, domain.nodes, podEvictor, evictable.IsEvictable, podResources, thresholds.DeepCopy(), warningThresholds)
+	}
+	return nil
+}
+
+// balanceHighNodeUtilization moves pods from underutilized nodes to the other nodes with the given thresholds
+func balanceHighNodeUtilization(
+	ctx context.Context,
+	client clientset.Interface,
+	params *api.StrategyParameters,
+	nodes []*v1.Node,
+	podEvictor *evictions.PodEvictor,
+	isEvictable func(pod *v1.Pod) bool,
+	podResources podResources,
+	thresholds, warningThresholds api.ResourceThresholds,
+) {
+	targetThresholds := make(api.ResourceThresholds)
+
+	configuredResourceNames := getResourceNames(thresholds)
 	setDefaultForThresholds(thresholds, targetThresholds)
 	resourceNames := getResourceNames(targetThresholds)
+	hysteresis := params.NodeResourceUtilizationThresholds.Hysteresis
 
+	usageFilter := usagePodFilter(params.NodeResourceUtilizationThresholds, nil)
+	nodeUsage := getNodeUsage(ctx, client, nodes, thresholds, targetThresholds, resourceNames, podResources, params.NodeResourceUtilizationThresholds.Parallelism, usageFilter,
+		params.NodeResourceUtilizationThresholds.CountNominatedPods)
+	reportWarningThresholds(nodeUsage, warningThresholds, "HighNodeUtilization", isBelowWarningThresholds)
+
+	unschedulableNodesAsSources := params.NodeResourceUtilizationThresholds.UnschedulableNodesAsSources
 	sourceNodes, highNodes := classifyNodes(
-		getNodeUsage(ctx, client, nodes, thresholds, targetThresholds, resourceNames),
+		nodeUsage,
 		func(node *v1.Node, usage NodeUsage) bool {
-			return isNodeWithLowUtilization(usage)
+			if unschedulableNodesAsSources != nil && nodeutil.IsNodeUnschedulable(node) {
+				return *unschedulableNodesAsSources
+			}
+			return isNodeWithLowUtilization(applyHysteresis(usage, hysteresis, configuredResourceNames))
 		},
 		func(node *v1.Node, usage NodeUsage) bool {
 			if nodeutil.IsNodeUnschedulable(node) {
@@ -72,6 +112,10 @@ func HighNodeUtilization(ctx context.Context, client clientset.Interface, strate
 			}
 			return !isNodeWithLowUtilization(usage)
 		})
+	recordNodeUtilizations(podEvictor, "HighNodeUtilization", nodeUsage, sourceNodes, nil)
+	if params.NodeResourceUtilizationThresholds.AnnotateNodes && !podEvictor.DryRun() {
+		annotateNodeClassifications(ctx, client, "HighNodeUtilization", nodeUsage, sourceNodes, nil)
+	}
 
 	// log message in one line
 	keysAndValues := []interface{}{
@@ -88,12 +132,16 @@ func HighNodeUtilization(ctx context.Context, client clientset.Interface, strate
 	klog.V(1).InfoS("Criteria for a node below target utilization", keysAndValues...)
 	klog.V(1).InfoS("Number of underutilized nodes", "totalNumber", len(sourceNodes))
 
+	if minPods := params.NodeResourceUtilizationThresholds.MinPodsOnSourceNode; minPods > 0 {
+		sourceNodes = nodesWithMinPods(sourceNodes, minPods)
+	}
+
 	if len(sourceNodes) == 0 {
 		klog.V(1).InfoS("No node is underutilized, nothing to do here, you might tune your thresholds further")
 		return
 	}
-	if len(sourceNodes) <= strategy.Params.NodeResourceUtilizationThresholds.NumberOfNodes {
-		klog.V(1).InfoS("Number of nodes underutilized is less or equal than NumberOfNodes, nothing to do here", "underutilizedNodes", len(sourceNodes), "numberOfNodes", strategy.Params.NodeResourceUtilizationThresholds.NumberOfNodes)
+	if len(sourceNodes) <= params.NodeResourceUtilizationThresholds.NumberOfNodes {
+		klog.V(1).InfoS("Number of nodes underutilized is less or equal than NumberOfNodes, nothing to do here", "underutilizedNodes", len(sourceNodes), "numberOfNodes", params.NodeResourceUtilizationThresholds.NumberOfNodes)
 		return
 	}
 	if len(sourceNodes) == len(nodes) {
@@ -105,7 +153,14 @@ func HighNodeUtilization(ctx context.Context, client clientset.Interface, strate
 		return
 	}
 
-	evictable := podEvictor.Evictable(evictions.WithPriorityThreshold(thresholdPriority), evictions.WithNodeFit(nodeFit))
+	tieBreaker := newNodeTieBreaker(params.NodeResourceUtilizationThresholds.TieBreaker, params.NodeResourceUtilizationThresholds.TieBreakerSeed, nodes)
+	if minFreeCapacityPercent := params.NodeResourceUtilizationThresholds.MinFreeCapacityPercent; minFreeCapacityPercent > 0 {
+		sourceNodes = drainableNodes(nodeUsage, sourceNodes, resourceNames, minFreeCapacityPercent, tieBreaker)
+		if len(sourceNodes) == 0 {
+			klog.V(1).InfoS("Draining any underutilized node would leave less free capacity than minFreeCapacityPercent, nothing to do here", "minFreeCapacityPercent", minFreeCapacityPercent)
+			return
+		}
+	}
 
 	// stop if the total available usage has dropped to zero - no more pods can be scheduled
 	continueEvictionCond := func(nodeUsage NodeUsage, totalAvailableUsage map[v1.ResourceName]*resource.Quantity) bool {
@@ -117,16 +172,77 @@ func HighNodeUtilization(ctx context.Context, client clientset.Interface, strate
 
 		return true
 	}
+	// the scorer name is checked by validateNodeUtilizationParams
+	scorer, _ := balance.GetDestinationScorer(params.NodeResourceUtilizationThresholds.DestinationScorer)
 	evictPodsFromSourceNodes(
 		ctx,
 		sourceNodes,
 		highNodes,
 		podEvictor,
-		evictable.IsEvictable,
+		isEvictable,
 		resourceNames,
-		"HighNodeUtilization",
-		continueEvictionCond)
+		evictions.ReasonHighNodeUtilization,
+		continueEvictionCond,
+		nil,
+		tieBreaker,
+		podResources,
+		scorer)
+}
 
+// drainableNodes returns the first source nodes, in the order they are drained, whose pods can all be moved while the
+// schedulable nodes left keep at least minFreeCapacityPercent of their capacity free for every resource
+func drainableNodes(nodeUsages, sourceNodes []NodeUsage, resourceNames []v1.ResourceName, minFreeCapacityPercent api.Percentage, tieBreaker nodeTieBreaker) []NodeUsage {
+	// pods of all nodes are kept, drained nodes only remove their capacity
+	totalUsage := map[v1.ResourceName]float64{}
+	capacity := map[v1.ResourceName]float64{}
+	for _, nodeUsage := range nodeUsages {
+		nodeCapacity := nodeUsage.node.Status.Capacity
+		if len(nodeUsage.node.Status.Allocatable) > 0 {
+			nodeCapacity = nodeUsage.node.Status.Allocatable
+		}
+		for _, name := range resourceNames {
+			totalUsage[name] += nodeUsage.usage[name].AsApproximateFloat64()
+			if !nodeutil.IsNodeUnschedulable(nodeUsage.node) {
+				quantity := nodeCapacity[name]
+				capacity[name] += quantity.AsApproximateFloat64()
+			}
+		}
+	}
+
+	sorted := append([]NodeUsage{}, sourceNodes...)
+	sortNodesByUsage(sorted, tieBreaker)
+	for i, nodeUsage := range sorted {
+		if nodeutil.IsNodeUnschedulable(nodeUsage.node) {
+			continue
+		}
+		nodeCapacity := nodeUsage.node.Status.Capacity
+		if len(nodeUsage.node.Status.Allocatable) > 0 {
+			nodeCapacity = nodeUsage.node.Status.Allocatable
+		}
+		for _, name := range resourceNames {
+			quantity := nodeCapacity[name]
+			remaining := capacity[name] - quantity.AsApproximateFloat64()
+			if remaining <= 0 || (remaining-totalUsage[name])*100 < float64(minFreeCapacityPercent)*remaining {
+				klog.V(1).InfoS("Draining the node would leave less free capacity than minFreeCapacityPercent, not draining further nodes", "node", klog.KObj(nodeUsage.node), "resource", name, "minFreeCapacityPercent", minFreeCapacityPercent)
+				return sorted[:i]
+			}
+			capacity[name] = remaining
+		}
+	}
+	return sorted
+}
+
+// nodesWithMinPods returns the source nodes running at least minPods pods counted in their usage
+func nodesWithMinPods(sourceNodes []NodeUsage, minPods int) []NodeUsage {
+	var nodes []NodeUsage
+	for _, nodeUsage := range sourceNodes {
+		if len(nodeUsage.allPods) < minPods {
+			klog.V(2).InfoS("Underutilized node runs fewer pods than minPodsOnSourceNode, not draining it", "node", klog.KObj(nodeUsage.node), "pods", len(nodeUsage.allPods), "minPodsOnSourceNode", minPods)
+			continue
+		}
+		nodes = append(nodes, nodeUsage)
+	}
+	return nodes
 }
 
 func validateHighUtilizationStrategyConfig(thresholds, targetThresholds api.ResourceThresholds) error {
@@ -19,12 +19,15 @@ package nodeutilization
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"sort"
 	"strings"
 	"testing"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/api/policy/v1beta1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
 	core "k8s.io/client-go/testing"
@@ -42,6 +45,7 @@ func TestHighNodeUtilization(t *testing.T) {
 
 	nodeSelectorKey := "datacenter"
 	nodeSelectorValue := "west"
+	var gi int64 = 1024 * 1024 * 1024
 
 	testCases := []struct {
 		name                  string
@@ -407,6 +411,67 @@ func TestHighNodeUtilization(t *testing.T) {
 			expectedPodsEvicted:   2,
 			evictedPods:           []string{"p1", "p2"},
 		},
+		{
+			name: "with ephemeral storage",
+			thresholds: api.ResourceThresholds{
+				v1.ResourceCPU:              20,
+				v1.ResourceEphemeralStorage: 40,
+			},
+			nodes: map[string]*v1.Node{
+				n1NodeName: test.BuildTestNode(n1NodeName, 4000, 3000, 10, func(node *v1.Node) {
+					test.SetNodeExtendedResource(node, v1.ResourceEphemeralStorage, 8*gi)
+				}),
+				n2NodeName: test.BuildTestNode(n2NodeName, 4000, 3000, 10, func(node *v1.Node) {
+					test.SetNodeExtendedResource(node, v1.ResourceEphemeralStorage, 8*gi)
+				}),
+				n3NodeName: test.BuildTestNode(n3NodeName, 4000, 3000, 10, test.SetNodeUnschedulable),
+			},
+			pods: map[string]*v1.PodList{
+				n1NodeName: {
+					Items: []v1.Pod{
+						*test.BuildTestPod("p1", 100, 0, n1NodeName, func(pod *v1.Pod) {
+							test.SetRSOwnerRef(pod)
+							test.SetPodExtendedResourceRequest(pod, v1.ResourceEphemeralStorage, gi)
+						}),
+						*test.BuildTestPod("p2", 100, 0, n1NodeName, func(pod *v1.Pod) {
+							test.SetRSOwnerRef(pod)
+							test.SetPodExtendedResourceRequest(pod, v1.ResourceEphemeralStorage, gi)
+						}),
+						// These won't be evicted
+						*test.BuildTestPod("p7", 100, 0, n1NodeName, func(pod *v1.Pod) {
+							test.SetDSOwnerRef(pod)
+							test.SetPodExtendedResourceRequest(pod, v1.ResourceEphemeralStorage, gi)
+						}),
+					},
+				},
+				n2NodeName: {
+					Items: []v1.Pod{
+						*test.BuildTestPod("p3", 500, 0, n2NodeName, func(pod *v1.Pod) {
+							test.SetRSOwnerRef(pod)
+							test.SetPodExtendedResourceRequest(pod, v1.ResourceEphemeralStorage, gi)
+						}),
+						*test.BuildTestPod("p4", 500, 0, n2NodeName, func(pod *v1.Pod) {
+							test.SetRSOwnerRef(pod)
+							test.SetPodExtendedResourceRequest(pod, v1.ResourceEphemeralStorage, gi)
+						}),
+						*test.BuildTestPod("p5", 500, 0, n2NodeName, func(pod *v1.Pod) {
+							test.SetRSOwnerRef(pod)
+							test.SetPodExtendedResourceRequest(pod, v1.ResourceEphemeralStorage, gi)
+						}),
+						*test.BuildTestPod("p6", 500, 0, n2NodeName, func(pod *v1.Pod) {
+							test.SetRSOwnerRef(pod)
+							test.SetPodExtendedResourceRequest(pod, v1.ResourceEphemeralStorage, gi)
+						}),
+					},
+				},
+				n3NodeName: {
+					Items: []v1.Pod{},
+				},
+			},
+			maxPodsToEvictPerNode: 0,
+			expectedPodsEvicted:   2,
+			evictedPods:           []string{"p1", "p2"},
+		},
 		{
 			name: "with extended resource in some of nodes",
 			thresholds: api.ResourceThresholds{
@@ -578,6 +643,7 @@ func TestHighNodeUtilization(t *testing.T) {
 				"v1",
 				false,
 				test.maxPodsToEvictPerNode,
+				0,
 				nodes,
 				false,
 				false,
@@ -768,6 +834,7 @@ func TestHighNodeUtilizationWithTaints(t *testing.T) {
 				"policy/v1",
 				false,
 				item.evictionsExpected,
+				0,
 				item.nodes,
 				false,
 				false,
@@ -782,3 +849,270 @@ func TestHighNodeUtilizationWithTaints(t *testing.T) {
 		})
 	}
 }
+
+func TestHighNodeUtilizationWithMinFreeCapacity(t *testing.T) {
+	ctx := context.Background()
+
+	n1 := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	n2 := test.BuildTestNode("n2", 2000, 3000, 10, nil)
+	n3 := test.BuildTestNode("n3", 2000, 3000, 10, nil)
+	nodes := []*v1.Node{n1, n2, n3}
+	// n1 and n2 are underutilized, the pods of all nodes request 30% of the cluster cpu
+	pods := []*v1.Pod{
+		test.BuildTestPod("p1", 300, 0, n1.Name, test.SetRSOwnerRef),
+		test.BuildTestPod("p2", 300, 0, n2.Name, test.SetRSOwnerRef),
+		test.BuildTestPod("p3", 1200, 0, n3.Name, test.SetRSOwnerRef),
+	}
+
+	tests := []struct {
+		name                   string
+		minFreeCapacityPercent api.Percentage
+		expectedEvicted        []string
+	}{
+		{
+			name:            "no guardrail, both underutilized nodes are drained",
+			expectedEvicted: []string{"p1", "p2"},
+		},
+		{
+			name:                   "draining a single node keeps 55% of the cpu free",
+			minFreeCapacityPercent: 40,
+			expectedEvicted:        []string{"p1"},
+		},
+		{
+			name:                   "draining any node leaves less free cpu than the guardrail",
+			minFreeCapacityPercent: 60,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := &fake.Clientset{}
+			fakeClient.Fake.AddReactor("list", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				fieldSelector := action.(core.ListAction).GetListRestrictions().Fields
+				podList := &v1.PodList{}
+				for _, pod := range pods {
+					if fieldSelector.Matches(fields.Set{"spec.nodeName": pod.Spec.NodeName, "status.phase": string(pod.Status.Phase)}) {
+						podList.Items = append(podList.Items, *pod.DeepCopy())
+					}
+				}
+				return true, podList, nil
+			})
+			var evicted []string
+			fakeClient.Fake.AddReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() == "eviction" {
+					evicted = append(evicted, action.(core.CreateAction).GetObject().(*v1beta1.Eviction).Name)
+				}
+				return true, nil, nil
+			})
+
+			podEvictor := evictions.NewPodEvictor(
+				fakeClient,
+				"v1",
+				false,
+				0,
+				0,
+				nodes,
+				false,
+				false,
+				false,
+			)
+
+			strategy := api.DeschedulerStrategy{
+				Enabled: true,
+				Params: &api.StrategyParameters{
+					NodeResourceUtilizationThresholds: &api.NodeResourceUtilizationThresholds{
+						Thresholds:             api.ResourceThresholds{v1.ResourceCPU: 20},
+						MinFreeCapacityPercent: tc.minFreeCapacityPercent,
+					},
+				},
+			}
+			HighNodeUtilization(ctx, fakeClient, strategy, nodes, podEvictor)
+
+			sort.Strings(evicted)
+			if !reflect.DeepEqual(evicted, tc.expectedEvicted) {
+				t.Errorf("Expected %v pods to be evicted, got %v", tc.expectedEvicted, evicted)
+			}
+		})
+	}
+}
+
+func TestHighNodeUtilizationWithMinPodsOnSourceNode(t *testing.T) {
+	ctx := context.Background()
+
+	n1 := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	n2 := test.BuildTestNode("n2", 2000, 3000, 10, nil)
+	n3 := test.BuildTestNode("n3", 2000, 3000, 10, nil)
+	nodes := []*v1.Node{n1, n2, n3}
+	// n1 and n2 are underutilized, n1 only runs a single large pod
+	pods := []*v1.Pod{
+		test.BuildTestPod("p1", 350, 0, n1.Name, test.SetRSOwnerRef),
+		test.BuildTestPod("p2", 150, 0, n2.Name, test.SetRSOwnerRef),
+		test.BuildTestPod("p3", 150, 0, n2.Name, test.SetRSOwnerRef),
+		test.BuildTestPod("p4", 1200, 0, n3.Name, test.SetRSOwnerRef),
+	}
+
+	tests := []struct {
+		name                string
+		minPodsOnSourceNode int
+		expectedEvicted     []string
+	}{
+		{
+			name:            "both underutilized nodes are drained by default",
+			expectedEvicted: []string{"p1", "p2", "p3"},
+		},
+		{
+			name:                "nodes running fewer pods than the minimum are not drained",
+			minPodsOnSourceNode: 2,
+			expectedEvicted:     []string{"p2", "p3"},
+		},
+		{
+			name:                "no node runs enough pods",
+			minPodsOnSourceNode: 3,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := &fake.Clientset{}
+			fakeClient.Fake.AddReactor("list", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				fieldSelector := action.(core.ListAction).GetListRestrictions().Fields
+				podList := &v1.PodList{}
+				for _, pod := range pods {
+					if fieldSelector.Matches(fields.Set{"spec.nodeName": pod.Spec.NodeName, "status.phase": string(pod.Status.Phase)}) {
+						podList.Items = append(podList.Items, *pod.DeepCopy())
+					}
+				}
+				return true, podList, nil
+			})
+			var evicted []string
+			fakeClient.Fake.AddReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() == "eviction" {
+					evicted = append(evicted, action.(core.CreateAction).GetObject().(*v1beta1.Eviction).Name)
+				}
+				return true, nil, nil
+			})
+
+			podEvictor := evictions.NewPodEvictor(
+				fakeClient,
+				"v1",
+				false,
+				0,
+				0,
+				nodes,
+				false,
+				false,
+				false,
+			)
+
+			strategy := api.DeschedulerStrategy{
+				Enabled: true,
+				Params: &api.StrategyParameters{
+					NodeResourceUtilizationThresholds: &api.NodeResourceUtilizationThresholds{
+						Thresholds:          api.ResourceThresholds{v1.ResourceCPU: 20},
+						MinPodsOnSourceNode: tc.minPodsOnSourceNode,
+					},
+				},
+			}
+			HighNodeUtilization(ctx, fakeClient, strategy, nodes, podEvictor)
+
+			sort.Strings(evicted)
+			if !reflect.DeepEqual(evicted, tc.expectedEvicted) {
+				t.Errorf("Expected %v pods to be evicted, got %v", tc.expectedEvicted, evicted)
+			}
+		})
+	}
+}
+
+func TestHighNodeUtilizationWithUnschedulableNodesAsSources(t *testing.T) {
+	ctx := context.Background()
+
+	cordon := func(node *v1.Node) {
+		node.Spec.Unschedulable = true
+	}
+	n1 := test.BuildTestNode("n1", 2000, 3000, 10, cordon)
+	n2 := test.BuildTestNode("n2", 2000, 3000, 10, nil)
+	n3 := test.BuildTestNode("n3", 2000, 3000, 10, nil)
+	n4 := test.BuildTestNode("n4", 2000, 3000, 10, cordon)
+	nodes := []*v1.Node{n1, n2, n3, n4}
+	// n1 and n2 are underutilized, n1 and n4 are cordoned
+	pods := []*v1.Pod{
+		test.BuildTestPod("p1", 300, 0, n1.Name, test.SetRSOwnerRef),
+		test.BuildTestPod("p2", 300, 0, n2.Name, test.SetRSOwnerRef),
+		test.BuildTestPod("p3", 600, 0, n3.Name, test.SetRSOwnerRef),
+		test.BuildTestPod("p4", 700, 0, n4.Name, test.SetRSOwnerRef),
+	}
+	drain := true
+	keep := false
+
+	tests := []struct {
+		name                        string
+		unschedulableNodesAsSources *bool
+		expectedEvicted             []string
+	}{
+		{
+			name:            "unschedulable nodes are classified by usage by default",
+			expectedEvicted: []string{"p1", "p2"},
+		},
+		{
+			name:                        "unschedulable nodes are drained whatever their usage",
+			unschedulableNodesAsSources: &drain,
+			expectedEvicted:             []string{"p1", "p2", "p4"},
+		},
+		{
+			name:                        "unschedulable nodes are never drained",
+			unschedulableNodesAsSources: &keep,
+			expectedEvicted:             []string{"p2"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := &fake.Clientset{}
+			fakeClient.Fake.AddReactor("list", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				fieldSelector := action.(core.ListAction).GetListRestrictions().Fields
+				podList := &v1.PodList{}
+				for _, pod := range pods {
+					if fieldSelector.Matches(fields.Set{"spec.nodeName": pod.Spec.NodeName, "status.phase": string(pod.Status.Phase)}) {
+						podList.Items = append(podList.Items, *pod.DeepCopy())
+					}
+				}
+				return true, podList, nil
+			})
+			var evicted []string
+			fakeClient.Fake.AddReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() == "eviction" {
+					evicted = append(evicted, action.(core.CreateAction).GetObject().(*v1beta1.Eviction).Name)
+				}
+				return true, nil, nil
+			})
+
+			podEvictor := evictions.NewPodEvictor(
+				fakeClient,
+				"v1",
+				false,
+				0,
+				0,
+				nodes,
+				false,
+				false,
+				false,
+			)
+
+			strategy := api.DeschedulerStrategy{
+				Enabled: true,
+				Params: &api.StrategyParameters{
+					NodeResourceUtilizationThresholds: &api.NodeResourceUtilizationThresholds{
+						Thresholds:                  api.ResourceThresholds{v1.ResourceCPU: 20},
+						UnschedulableNodesAsSources: tc.unschedulableNodesAsSources,
+					},
+				},
+			}
+			HighNodeUtilization(ctx, fakeClient, strategy, nodes, podEvictor)
+
+			sort.Strings(evicted)
+			if !reflect.DeepEqual(evicted, tc.expectedEvicted) {
+				t.Errorf("Expected %v pods to be evicted, got %v", tc.expectedEvicted, evicted)
+			}
+		})
+	}
+}
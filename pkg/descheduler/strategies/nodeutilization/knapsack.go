@@ -0,0 +1,157 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeutilization
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/balance"
+)
+
+const (
+	// maxKnapsackCandidates bounds the number of pods the exact search is run on
+	maxKnapsackCandidates = 16
+	// maxKnapsackEvaluations bounds the number of pod sets evaluated by the exact search
+	maxKnapsackEvaluations = 100000
+)
+
+// podSelector reorders the removable pods of a node so the pods to evict come first.
+// Pods are passed sorted by priority, lowest first.
+type podSelector func(pods []*v1.Pod, nodeUsage NodeUsage, capacity balance.DestinationCapacity) []*v1.Pod
+
+// knapsackPodSelector returns a podSelector looking for the smallest set of pods whose eviction
+// makes the node no longer overutilized across all resources at once, without moving more than
+// the destination nodes can take. Among sets of the same size, pods with lower priority are preferred.
+// The selected pods are moved in front of the others so the eviction loop can still fall back
+// to the remaining pods when an eviction fails. When no set is found within the search bounds,
+// the pods are returned unchanged.
+func knapsackPodSelector(isNodeOverutilized func(NodeUsage) bool, podResources podResources) podSelector {
+	return func(pods []*v1.Pod, nodeUsage NodeUsage, capacity balance.DestinationCapacity) []*v1.Pod {
+		// pods not accepted by the destination nodes are not evicted
+		var candidates []int
+		for i, pod := range pods {
+			if len(candidates) == maxKnapsackCandidates {
+				break
+			}
+			if capacity.Accepts(pod) {
+				candidates = append(candidates, i)
+			}
+		}
+
+		resourceNames := make([]v1.ResourceName, 0, len(nodeUsage.usage))
+		for name := range nodeUsage.usage {
+			resourceNames = append(resourceNames, name)
+		}
+		requests := make([]map[v1.ResourceName]int64, len(candidates))
+		for i, podIndex := range candidates {
+			pod := pods[podIndex]
+			requests[i] = make(map[v1.ResourceName]int64, len(resourceNames))
+			for _, name := range resourceNames {
+				if name == v1.ResourcePods {
+					requests[i][name] = resource.NewQuantity(1, resource.DecimalSI).MilliValue()
+					continue
+				}
+				quantity := podResources.quantity(pod, name)
+				requests[i][name] = quantity.MilliValue()
+			}
+		}
+
+		// fits checks the selected pods can be moved and bring the node under its target thresholds
+		fits := func(selected []int) bool {
+			removed := make(map[v1.ResourceName]int64, len(resourceNames))
+			for _, i := range selected {
+				for name, value := range requests[i] {
+					removed[name] += value
+				}
+			}
+			for name, available := range capacity.Remaining() {
+				if removed[name] > available.MilliValue() {
+					return false
+				}
+			}
+			remaining := NodeUsage{
+				node:                  nodeUsage.node,
+				usage:                 make(map[v1.ResourceName]*resource.Quantity, len(resourceNames)),
+				highResourceThreshold: nodeUsage.highResourceThreshold,
+				lowResourceThreshold:  nodeUsage.lowResourceThreshold,
+			}
+			for _, name := range resourceNames {
+				remaining.usage[name] = resource.NewMilliQuantity(nodeUsage.usage[name].MilliValue()-removed[name], resource.DecimalSI)
+			}
+			return !isNodeOverutilized(remaining)
+		}
+
+		evaluations := 0
+		var search func(selected []int, next, size int) []int
+		// search enumerates sets of the given size in lexicographic order, i.e. lower priority pods first
+		search = func(selected []int, next, size int) []int {
+			if len(selected) == size {
+				evaluations++
+				if fits(selected) {
+					return selected
+				}
+				return nil
+			}
+			for i := next; i <= len(candidates)-(size-len(selected)); i++ {
+				if evaluations >= maxKnapsackEvaluations {
+					return nil
+				}
+				if found := search(append(selected, i), i+1, size); found != nil {
+					return found
+				}
+			}
+			return nil
+		}
+
+		for size := 1; size <= len(candidates); size++ {
+			selected := search(make([]int, 0, size), 0, size)
+			if selected != nil {
+				klog.V(3).InfoS("Selected pods to evict across all resources", "node", klog.KObj(nodeUsage.node), "pods", len(selected))
+				podIndexes := make([]int, 0, len(selected))
+				for _, i := range selected {
+					podIndexes = append(podIndexes, candidates[i])
+				}
+				return prioritizePods(pods, podIndexes)
+			}
+			if evaluations >= maxKnapsackEvaluations {
+				break
+			}
+		}
+		klog.V(3).InfoS("No set of pods brings the node under target utilization, evicting pods by priority", "node", klog.KObj(nodeUsage.node))
+		return pods
+	}
+}
+
+// prioritizePods moves the pods at the selected indexes in front of the others, keeping their order
+func prioritizePods(pods []*v1.Pod, selected []int) []*v1.Pod {
+	isSelected := make(map[int]bool, len(selected))
+	for _, i := range selected {
+		isSelected[i] = true
+	}
+	ordered := make([]*v1.Pod, 0, len(pods))
+	for _, i := range selected {
+		ordered = append(ordered, pods[i])
+	}
+	for i, pod := range pods {
+		if !isSelected[i] {
+			ordered = append(ordered, pod)
+		}
+	}
+	return ordered
+}
@@ -19,6 +19,7 @@ package nodeutilization
 import (
 	"context"
 	"fmt"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -26,23 +27,24 @@ import (
 	"k8s.io/klog/v2"
 
 	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/balance"
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
 	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/validation"
 	"sigs.k8s.io/descheduler/pkg/utils"
 )
 
 // LowNodeUtilization evicts pods from overutilized nodes to underutilized nodes. Note that CPU/Memory requests are used
 // to calculate nodes' utilization and not the actual resource usage.
-func LowNodeUtilization(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor) {
+func LowNodeUtilization(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor) error {
 	// TODO: May be create a struct for the strategy as well, so that we don't have to pass along the all the params?
 	if err := validateNodeUtilizationParams(strategy.Params); err != nil {
-		klog.ErrorS(err, "Invalid LowNodeUtilization parameters")
-		return
+		return validation.NewStrategyError(validation.ErrorReasonInvalidParameters, "invalid LowNodeUtilization parameters", err)
 	}
+	strategy.Params = convertFractionalThresholds(strategy.Params, "LowNodeUtilization")
 	thresholdPriority, err := utils.GetPriorityFromStrategyParams(ctx, client, strategy.Params)
 	if err != nil {
-		klog.ErrorS(err, "Failed to get threshold priority from strategy's params")
-		return
+		return validation.NewStrategyError(validation.ErrorReasonPriorityLookup, "failed to get threshold priority from strategy's params", err)
 	}
 
 	nodeFit := false
@@ -50,11 +52,81 @@ func LowNodeUtilization(ctx context.Context, client clientset.Interface, strateg
 		nodeFit = strategy.Params.NodeFit
 	}
 
-	thresholds := strategy.Params.NodeResourceUtilizationThresholds.Thresholds
-	targetThresholds := strategy.Params.NodeResourceUtilizationThresholds.TargetThresholds
+	// thresholds are copied as they get defaulted below, the policy is shared by all cycles
+	thresholds := strategy.Params.NodeResourceUtilizationThresholds.Thresholds.DeepCopy()
+	targetThresholds := strategy.Params.NodeResourceUtilizationThresholds.TargetThresholds.DeepCopy()
 	if err := validateLowUtilizationStrategyConfig(thresholds, targetThresholds); err != nil {
-		klog.ErrorS(err, "LowNodeUtilization config is not valid")
-		return
+		return validation.NewStrategyError(validation.ErrorReasonInvalidParameters, "LowNodeUtilization config is not valid", err)
+	}
+	warningThresholds := strategy.Params.NodeResourceUtilizationThresholds.WarningThresholds
+	if err := validateWarningThresholds(warningThresholds, thresholds); err != nil {
+		return validation.NewStrategyError(validation.ErrorReasonInvalidParameters, "LowNodeUtilization config is not valid", err)
+	}
+	thresholdsOperator := strategy.Params.NodeResourceUtilizationThresholds.ThresholdsOperator
+	if err := validateThresholdsOperator(thresholdsOperator); err != nil {
+		return validation.NewStrategyError(validation.ErrorReasonInvalidParameters, "LowNodeUtilization config is not valid", err)
+	}
+	if err := validateRelieveResources(strategy.Params.NodeResourceUtilizationThresholds.RelieveResources, thresholds); err != nil {
+		return validation.NewStrategyError(validation.ErrorReasonInvalidParameters, "LowNodeUtilization config is not valid", err)
+	}
+	priorityBands := strategy.Params.NodeResourceUtilizationThresholds.PriorityBands
+	for i, band := range priorityBands {
+		if err := validatePriorityBand(band); err != nil {
+			return validation.NewStrategyError(validation.ErrorReasonInvalidParameters, fmt.Sprintf("LowNodeUtilization priority band %d is not valid", i), err)
+		}
+	}
+
+	evictable := podEvictor.Evictable(evictions.WithPriorityThreshold(thresholdPriority), evictions.WithNodeFit(nodeFit))
+	podResources := newPodResources(ctx, client, strategy.Params.NodeResourceUtilizationThresholds)
+
+	domainKey := balancingDomain(strategy.Params.NodeResourceUtilizationThresholds)
+	for _, domain := range groupNodesByDomain(nodes, domainKey) {
+		if domainKey != "" {
+			klog.V(1).InfoS("Balancing nodes of domain", "balancingDomain", domainKey, "domain", domain.name, "nodes", len(domain.nodes))
+		}
+		balanceLowNodeUtilization(ctx, client, strategy.Params, domain.nodes, podEvictor, evictable.IsEvictable, podResources,
+			thresholds.DeepCopy(), targetThresholds.DeepCopy(), warningThresholds, nil)
+		for _, band := range priorityBands {
+			klog.V(1).InfoS("Balancing pods of priority band", "minPriority", band.MinPriority, "maxPriority", band.MaxPriority)
+			balanceLowNodeUtilization(ctx, client, strategy.Params, domain.nodes, podEvictor, evictable.IsEvictable, podResources,
+				band.Thresholds.DeepCopy(), band.TargetThresholds.DeepCopy(), nil, func(pod *v1.Pod) bool {
+					return isPodInPriorityBand(pod, band)
+				})
+		}
+	}
+
+	klog.V(1).InfoS("Total number of pods evicted", "evictedPods", podEvictor.TotalEvicted())
+	return nil
+}
+
+// balanceLowNodeUtilization moves pods from overutilized to underutilized nodes with the given thresholds.
+// When bandFilter is set, node usages only count the pods it accepts, and only these pods are evicted.
+func balanceLowNodeUtilization(
+	ctx context.Context,
+	client clientset.Interface,
+	params *api.StrategyParameters,
+	nodes []*v1.Node,
+	podEvictor *evictions.PodEvictor,
+	isEvictable func(pod *v1.Pod) bool,
+	podResources podResources,
+	thresholds, targetThresholds, warningThresholds api.ResourceThresholds,
+	bandFilter func(pod *v1.Pod) bool,
+) {
+	thresholdsOperator := params.NodeResourceUtilizationThresholds.ThresholdsOperator
+	// only resources configured by the user are taken into account when all of them have to be overutilized
+	configuredResourceNames := getResourceNames(thresholds)
+	isNodeOverutilized := isNodeAboveTargetUtilization
+	if thresholdsOperator == ThresholdsOperatorAnd {
+		isNodeOverutilized = func(usage NodeUsage) bool {
+			return isNodeAboveTargetUtilizationForAll(usage, configuredResourceNames)
+		}
+	}
+	// overutilized nodes are only relieved until they are below the target of the resources to relieve
+	isNodeToRelieve := isNodeOverutilized
+	if relieveResources := params.NodeResourceUtilizationThresholds.RelieveResources; len(relieveResources) > 0 {
+		isNodeToRelieve = func(usage NodeUsage) bool {
+			return isNodeAboveTargetUtilizationForAny(usage, relieveResources)
+		}
 	}
 	// check if Pods/CPU/Mem are set, if not, set them to 100
 	if _, ok := thresholds[v1.ResourcePods]; !ok {
@@ -70,21 +142,53 @@ func LowNodeUtilization(ctx context.Context, client clientset.Interface, strateg
 		targetThresholds[v1.ResourceMemory] = MaxResourcePercentage
 	}
 	resourceNames := getResourceNames(thresholds)
+	hysteresis := params.NodeResourceUtilizationThresholds.Hysteresis
+
+	usageFilter := usagePodFilter(params.NodeResourceUtilizationThresholds, bandFilter)
+	nodeUsage := getNodeUsage(ctx, client, nodes, thresholds, targetThresholds, resourceNames, podResources, params.NodeResourceUtilizationThresholds.Parallelism, usageFilter,
+		params.NodeResourceUtilizationThresholds.CountNominatedPods)
+	reportWarningThresholds(nodeUsage, warningThresholds, "LowNodeUtilization", isAboveWarningThresholds)
+
+	// nodes whose usage is projected above targetThresholds by the trend of their actual usage are soon overutilized,
+	// and relieved until their projected usage is below targetThresholds
+	var projectedIncreases map[string]map[v1.ResourceName]*resource.Quantity
+	if trend := params.NodeResourceUtilizationThresholds.Trend; trend != nil && bandFilter == nil && podResources.usage != nil {
+		projectedIncreases = usageTrends.record(time.Now(), nodeUsage, trend)
+		relieve := isNodeToRelieve
+		isNodeToRelieve = func(usage NodeUsage) bool {
+			return relieve(projectUsage(usage, projectedIncreases[usage.node.Name]))
+		}
+	}
 
 	lowNodes, sourceNodes := classifyNodes(
-		getNodeUsage(ctx, client, nodes, thresholds, targetThresholds, resourceNames),
+		nodeUsage,
 		// The node has to be schedulable (to be able to move workload there)
 		func(node *v1.Node, usage NodeUsage) bool {
 			if nodeutil.IsNodeUnschedulable(node) {
 				klog.V(2).InfoS("Node is unschedulable, thus not considered as underutilized", "node", klog.KObj(node))
 				return false
 			}
-			return isNodeWithLowUtilization(usage)
+			return isNodeWithLowUtilization(applyHysteresis(usage, hysteresis, configuredResourceNames))
 		},
 		func(node *v1.Node, usage NodeUsage) bool {
-			return isNodeAboveTargetUtilization(usage)
+			if isNodeOverutilized(applyHysteresis(usage, hysteresis, configuredResourceNames)) {
+				return true
+			}
+			increase := projectedIncreases[node.Name]
+			if len(increase) > 0 && isNodeOverutilized(applyHysteresis(projectUsage(usage, increase), hysteresis, configuredResourceNames)) {
+				klog.V(1).InfoS("Node is soon overutilized, its usage trend is projected above target thresholds", "node", klog.KObj(node), "increase", increase)
+				return true
+			}
+			return false
 		},
 	)
+	// priority bands do not classify nodes by their whole usage
+	if bandFilter == nil {
+		recordNodeUtilizations(podEvictor, "LowNodeUtilization", nodeUsage, lowNodes, sourceNodes)
+	}
+	if params.NodeResourceUtilizationThresholds.AnnotateNodes && bandFilter == nil && !podEvictor.DryRun() {
+		annotateNodeClassifications(ctx, client, "LowNodeUtilization", nodeUsage, lowNodes, sourceNodes)
+	}
 
 	// log message in one line
 	keysAndValues := []interface{}{
@@ -119,8 +223,8 @@ func LowNodeUtilization(ctx context.Context, client clientset.Interface, strateg
 		return
 	}
 
-	if len(lowNodes) <= strategy.Params.NodeResourceUtilizationThresholds.NumberOfNodes {
-		klog.V(1).InfoS("Number of nodes underutilized is less or equal than NumberOfNodes, nothing to do here", "underutilizedNodes", len(lowNodes), "numberOfNodes", strategy.Params.NodeResourceUtilizationThresholds.NumberOfNodes)
+	if len(lowNodes) <= params.NodeResourceUtilizationThresholds.NumberOfNodes {
+		klog.V(1).InfoS("Number of nodes underutilized is less or equal than NumberOfNodes, nothing to do here", "underutilizedNodes", len(lowNodes), "numberOfNodes", params.NodeResourceUtilizationThresholds.NumberOfNodes)
 		return
 	}
 
@@ -134,11 +238,16 @@ func LowNodeUtilization(ctx context.Context, client clientset.Interface, strateg
 		return
 	}
 
-	evictable := podEvictor.Evictable(evictions.WithPriorityThreshold(thresholdPriority), evictions.WithNodeFit(nodeFit))
+	podFilter := isEvictable
+	if bandFilter != nil {
+		podFilter = func(pod *v1.Pod) bool {
+			return bandFilter(pod) && isEvictable(pod)
+		}
+	}
 
 	// stop if node utilization drops below target threshold or any of required capacity (cpu, memory, pods) is moved
 	continueEvictionCond := func(nodeUsage NodeUsage, totalAvailableUsage map[v1.ResourceName]*resource.Quantity) bool {
-		if !isNodeAboveTargetUtilization(nodeUsage) {
+		if !isNodeToRelieve(nodeUsage) {
 			return false
 		}
 		for name := range totalAvailableUsage {
@@ -150,17 +259,21 @@ func LowNodeUtilization(ctx context.Context, client clientset.Interface, strateg
 		return true
 	}
 
+	// the scorer name is checked by validateNodeUtilizationParams
+	scorer, _ := balance.GetDestinationScorer(params.NodeResourceUtilizationThresholds.DestinationScorer)
 	evictPodsFromSourceNodes(
 		ctx,
 		sourceNodes,
 		lowNodes,
 		podEvictor,
-		evictable.IsEvictable,
+		podFilter,
 		resourceNames,
-		"LowNodeUtilization",
-		continueEvictionCond)
-
-	klog.V(1).InfoS("Total number of pods evicted", "evictedPods", podEvictor.TotalEvicted())
+		evictions.ReasonLowNodeUtilization,
+		continueEvictionCond,
+		knapsackPodSelector(isNodeToRelieve, podResources),
+		newNodeTieBreaker(params.NodeResourceUtilizationThresholds.TieBreaker, params.NodeResourceUtilizationThresholds.TieBreakerSeed, nodes),
+		podResources,
+		scorer)
 }
 
 // validateLowUtilizationStrategyConfig checks if the strategy's config is valid
@@ -186,3 +299,42 @@ func validateLowUtilizationStrategyConfig(thresholds, targetThresholds api.Resou
 	}
 	return nil
 }
+
+// validateRelieveResources checks the resources to relieve are configured in thresholds
+func validateRelieveResources(relieveResources []v1.ResourceName, thresholds api.ResourceThresholds) error {
+	for _, name := range relieveResources {
+		if _, ok := thresholds[name]; !ok {
+			return fmt.Errorf("%v resource to relieve is not configured in thresholds", name)
+		}
+	}
+	return nil
+}
+
+// validatePriorityBand checks if the priority band bounds and thresholds are valid
+func validatePriorityBand(band api.PriorityBand) error {
+	if band.MinPriority == nil && band.MaxPriority == nil {
+		return fmt.Errorf("priority band must set minPriority or maxPriority")
+	}
+	if band.MinPriority != nil && band.MaxPriority != nil && *band.MinPriority > *band.MaxPriority {
+		return fmt.Errorf("priority band minPriority %v is greater than maxPriority %v", *band.MinPriority, *band.MaxPriority)
+	}
+	if err := validateLowUtilizationStrategyConfig(band.Thresholds, band.TargetThresholds); err != nil {
+		return fmt.Errorf("priority band config is not valid: %v", err)
+	}
+	return nil
+}
+
+// isPodInPriorityBand checks if the pod priority is within the band bounds, pods without priority have a priority of 0
+func isPodInPriorityBand(pod *v1.Pod, band api.PriorityBand) bool {
+	var priority int32
+	if pod.Spec.Priority != nil {
+		priority = *pod.Spec.Priority
+	}
+	if band.MinPriority != nil && priority < *band.MinPriority {
+		return false
+	}
+	if band.MaxPriority != nil && priority > *band.MaxPriority {
+		return false
+	}
+	return true
+}
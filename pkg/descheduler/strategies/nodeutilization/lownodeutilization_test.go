@@ -26,10 +26,14 @@ import (
 	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/api/policy/v1beta1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
+	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
 	core "k8s.io/client-go/testing"
 	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/balance"
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
 	"sigs.k8s.io/descheduler/pkg/utils"
 	"sigs.k8s.io/descheduler/test"
@@ -41,6 +45,8 @@ func TestLowNodeUtilization(t *testing.T) {
 	n2NodeName := "n2"
 	n3NodeName := "n3"
 
+	var gi int64 = 1024 * 1024 * 1024
+
 	nodeSelectorKey := "datacenter"
 	nodeSelectorValue := "west"
 	notMatchingNodeSelectorValue := "east"
@@ -48,6 +54,7 @@ func TestLowNodeUtilization(t *testing.T) {
 	testCases := []struct {
 		name                         string
 		thresholds, targetThresholds api.ResourceThresholds
+		thresholdsOperator           string
 		nodes                        map[string]*v1.Node
 		pods                         map[string]*v1.PodList
 		maxPodsToEvictPerNode        int
@@ -473,6 +480,56 @@ func TestLowNodeUtilization(t *testing.T) {
 			// 4 pods available for eviction based on v1.ResourcePods, only 3 pods can be evicted before extended resource is depleted
 			expectedPodsEvicted: 3,
 		},
+		{
+			name: "with ephemeral storage",
+			thresholds: api.ResourceThresholds{
+				v1.ResourceEphemeralStorage: 30,
+			},
+			targetThresholds: api.ResourceThresholds{
+				v1.ResourceEphemeralStorage: 50,
+			},
+			nodes: map[string]*v1.Node{
+				n1NodeName: test.BuildTestNode(n1NodeName, 4000, 3000, 10, func(node *v1.Node) {
+					test.SetNodeExtendedResource(node, v1.ResourceEphemeralStorage, 10*gi)
+				}),
+				n2NodeName: test.BuildTestNode(n2NodeName, 4000, 3000, 10, func(node *v1.Node) {
+					test.SetNodeExtendedResource(node, v1.ResourceEphemeralStorage, 10*gi)
+				}),
+			},
+			pods: map[string]*v1.PodList{
+				n1NodeName: {
+					Items: []v1.Pod{
+						*test.BuildTestPod("p1", 0, 0, n1NodeName, func(pod *v1.Pod) {
+							test.SetRSOwnerRef(pod)
+							test.SetPodExtendedResourceRequest(pod, v1.ResourceEphemeralStorage, 2*gi)
+						}),
+						*test.BuildTestPod("p2", 0, 0, n1NodeName, func(pod *v1.Pod) {
+							test.SetRSOwnerRef(pod)
+							test.SetPodExtendedResourceRequest(pod, v1.ResourceEphemeralStorage, 2*gi)
+						}),
+						*test.BuildTestPod("p3", 0, 0, n1NodeName, func(pod *v1.Pod) {
+							test.SetRSOwnerRef(pod)
+							test.SetPodExtendedResourceRequest(pod, v1.ResourceEphemeralStorage, 2*gi)
+						}),
+						*test.BuildTestPod("p4", 0, 0, n1NodeName, func(pod *v1.Pod) {
+							test.SetRSOwnerRef(pod)
+							test.SetPodExtendedResourceRequest(pod, v1.ResourceEphemeralStorage, 2*gi)
+						}),
+					},
+				},
+				n2NodeName: {
+					Items: []v1.Pod{
+						*test.BuildTestPod("p5", 0, 0, n2NodeName, func(pod *v1.Pod) {
+							test.SetRSOwnerRef(pod)
+							test.SetPodExtendedResourceRequest(pod, v1.ResourceEphemeralStorage, 1*gi)
+						}),
+					},
+				},
+			},
+			maxPodsToEvictPerNode: 0,
+			// evicting 2 pods brings the ephemeral storage usage of n1 to 40%
+			expectedPodsEvicted: 2,
+		},
 		{
 			name: "with extended resource in some of nodes",
 			thresholds: api.ResourceThresholds{
@@ -732,6 +789,124 @@ func TestLowNodeUtilization(t *testing.T) {
 			maxPodsToEvictPerNode: 0,
 			expectedPodsEvicted:   3,
 		},
+		{
+			name: "thresholdsOperator And, only cpu above target threshold",
+			thresholds: api.ResourceThresholds{
+				v1.ResourceCPU:  30,
+				v1.ResourcePods: 30,
+			},
+			targetThresholds: api.ResourceThresholds{
+				v1.ResourceCPU:  50,
+				v1.ResourcePods: 50,
+			},
+			thresholdsOperator: ThresholdsOperatorAnd,
+			nodes: map[string]*v1.Node{
+				n1NodeName: test.BuildTestNode(n1NodeName, 4000, 3000, 10, nil),
+				n2NodeName: test.BuildTestNode(n2NodeName, 4000, 3000, 10, nil),
+			},
+			pods: map[string]*v1.PodList{
+				n1NodeName: {
+					Items: []v1.Pod{
+						*test.BuildTestPod("p1", 800, 0, n1NodeName, test.SetRSOwnerRef),
+						*test.BuildTestPod("p2", 800, 0, n1NodeName, test.SetRSOwnerRef),
+						*test.BuildTestPod("p3", 800, 0, n1NodeName, test.SetRSOwnerRef),
+					},
+				},
+				n2NodeName: {
+					Items: []v1.Pod{
+						*test.BuildTestPod("p4", 400, 0, n2NodeName, test.SetRSOwnerRef),
+					},
+				},
+			},
+			maxPodsToEvictPerNode: 0,
+			expectedPodsEvicted:   0,
+		},
+		{
+			name: "thresholdsOperator And, all resources above target thresholds",
+			thresholds: api.ResourceThresholds{
+				v1.ResourceCPU:  30,
+				v1.ResourcePods: 30,
+			},
+			targetThresholds: api.ResourceThresholds{
+				v1.ResourceCPU:  50,
+				v1.ResourcePods: 50,
+			},
+			thresholdsOperator: ThresholdsOperatorAnd,
+			nodes: map[string]*v1.Node{
+				n1NodeName: test.BuildTestNode(n1NodeName, 4000, 3000, 10, nil),
+				n2NodeName: test.BuildTestNode(n2NodeName, 4000, 3000, 10, nil),
+			},
+			pods: map[string]*v1.PodList{
+				n1NodeName: {
+					Items: []v1.Pod{
+						*test.BuildTestPod("p1", 400, 0, n1NodeName, test.SetRSOwnerRef),
+						*test.BuildTestPod("p2", 400, 0, n1NodeName, test.SetRSOwnerRef),
+						*test.BuildTestPod("p3", 400, 0, n1NodeName, test.SetRSOwnerRef),
+						*test.BuildTestPod("p4", 400, 0, n1NodeName, test.SetRSOwnerRef),
+						*test.BuildTestPod("p5", 400, 0, n1NodeName, test.SetRSOwnerRef),
+						*test.BuildTestPod("p6", 400, 0, n1NodeName, test.SetRSOwnerRef),
+					},
+				},
+				n2NodeName: {
+					Items: []v1.Pod{
+						*test.BuildTestPod("p7", 400, 0, n2NodeName, test.SetRSOwnerRef),
+					},
+				},
+			},
+			maxPodsToEvictPerNode: 0,
+			// the node stops being overutilized once a single resource drops to its target threshold
+			expectedPodsEvicted: 1,
+		},
+		{
+			name: "evict the smallest set of pods bringing all resources under target thresholds",
+			thresholds: api.ResourceThresholds{
+				v1.ResourceCPU:  30,
+				v1.ResourcePods: 30,
+			},
+			targetThresholds: api.ResourceThresholds{
+				v1.ResourceCPU:  50,
+				v1.ResourcePods: 50,
+			},
+			nodes: map[string]*v1.Node{
+				n1NodeName: test.BuildTestNode(n1NodeName, 4000, 3000, 10, nil),
+				n2NodeName: test.BuildTestNode(n2NodeName, 8000, 3000, 10, nil),
+			},
+			pods: map[string]*v1.PodList{
+				n1NodeName: {
+					Items: []v1.Pod{
+						// evicting pods by priority would evict the four low priority pods
+						*test.BuildTestPod("p1", 200, 0, n1NodeName, func(pod *v1.Pod) {
+							test.SetRSOwnerRef(pod)
+							test.SetPodPriority(pod, lowPriority)
+						}),
+						*test.BuildTestPod("p2", 200, 0, n1NodeName, func(pod *v1.Pod) {
+							test.SetRSOwnerRef(pod)
+							test.SetPodPriority(pod, lowPriority)
+						}),
+						*test.BuildTestPod("p3", 200, 0, n1NodeName, func(pod *v1.Pod) {
+							test.SetRSOwnerRef(pod)
+							test.SetPodPriority(pod, lowPriority)
+						}),
+						*test.BuildTestPod("p4", 200, 0, n1NodeName, func(pod *v1.Pod) {
+							test.SetRSOwnerRef(pod)
+							test.SetPodPriority(pod, lowPriority)
+						}),
+						*test.BuildTestPod("p5", 2000, 0, n1NodeName, func(pod *v1.Pod) {
+							test.SetRSOwnerRef(pod)
+							test.SetPodPriority(pod, highPriority)
+						}),
+					},
+				},
+				n2NodeName: {
+					Items: []v1.Pod{
+						*test.BuildTestPod("p6", 400, 0, n2NodeName, test.SetRSOwnerRef),
+					},
+				},
+			},
+			maxPodsToEvictPerNode: 0,
+			expectedPodsEvicted:   1,
+			evictedPods:           []string{"p5"},
+		},
 	}
 
 	for _, test := range testCases {
@@ -789,6 +964,7 @@ func TestLowNodeUtilization(t *testing.T) {
 				policyv1.SchemeGroupVersion.String(),
 				false,
 				test.maxPodsToEvictPerNode,
+				0,
 				nodes,
 				false,
 				false,
@@ -799,8 +975,9 @@ func TestLowNodeUtilization(t *testing.T) {
 				Enabled: true,
 				Params: &api.StrategyParameters{
 					NodeResourceUtilizationThresholds: &api.NodeResourceUtilizationThresholds{
-						Thresholds:       test.thresholds,
-						TargetThresholds: test.targetThresholds,
+						Thresholds:         test.thresholds,
+						TargetThresholds:   test.targetThresholds,
+						ThresholdsOperator: test.thresholdsOperator,
 					},
 					NodeFit: true,
 				},
@@ -1089,6 +1266,7 @@ func TestLowNodeUtilizationWithTaints(t *testing.T) {
 				policyv1.SchemeGroupVersion.String(),
 				false,
 				item.evictionsExpected,
+				0,
 				item.nodes,
 				false,
 				false,
@@ -1103,3 +1281,807 @@ func TestLowNodeUtilizationWithTaints(t *testing.T) {
 		})
 	}
 }
+
+func TestLowNodeUtilizationWithPriorityBands(t *testing.T) {
+	ctx := context.Background()
+	highPriority, lowPriority := int32(1000), int32(999)
+
+	n1 := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	n2 := test.BuildTestNode("n2", 2000, 3000, 10, nil)
+	nodes := []*v1.Node{n1, n2}
+	buildPod := func(name, nodeName string, priority int32) *v1.Pod {
+		return test.BuildTestPod(name, 300, 0, nodeName, func(pod *v1.Pod) {
+			test.SetRSOwnerRef(pod)
+			test.SetPodPriority(pod, priority)
+		})
+	}
+	pods := []*v1.Pod{
+		// n1 runs 60% of high priority and 30% of low priority cpu requests
+		buildPod("high-1", n1.Name, highPriority),
+		buildPod("high-2", n1.Name, highPriority),
+		buildPod("high-3", n1.Name, highPriority),
+		buildPod("high-4", n1.Name, highPriority),
+		buildPod("low-1", n1.Name, lowPriority),
+		buildPod("low-2", n1.Name, lowPriority),
+		// n2 runs 75% of low priority cpu requests
+		buildPod("low-3", n2.Name, lowPriority),
+		buildPod("low-4", n2.Name, lowPriority),
+		buildPod("low-5", n2.Name, lowPriority),
+		buildPod("low-6", n2.Name, lowPriority),
+		buildPod("low-7", n2.Name, lowPriority),
+	}
+
+	tests := []struct {
+		name           string
+		priorityBands  []api.PriorityBand
+		expectedPrefix string
+		expectedCount  int
+	}{
+		{
+			name: "no priority band, both nodes are overutilized",
+		},
+		{
+			name: "high priority band",
+			priorityBands: []api.PriorityBand{{
+				MinPriority:      &highPriority,
+				Thresholds:       api.ResourceThresholds{v1.ResourceCPU: 20},
+				TargetThresholds: api.ResourceThresholds{v1.ResourceCPU: 50},
+			}},
+			expectedPrefix: "high-",
+			expectedCount:  1,
+		},
+		{
+			name: "low priority band",
+			priorityBands: []api.PriorityBand{{
+				MaxPriority:      &lowPriority,
+				Thresholds:       api.ResourceThresholds{v1.ResourceCPU: 40},
+				TargetThresholds: api.ResourceThresholds{v1.ResourceCPU: 50},
+			}},
+			expectedPrefix: "low-",
+			expectedCount:  2,
+		},
+		{
+			name: "invalid priority band",
+			priorityBands: []api.PriorityBand{{
+				MinPriority:      &highPriority,
+				MaxPriority:      &lowPriority,
+				Thresholds:       api.ResourceThresholds{v1.ResourceCPU: 20},
+				TargetThresholds: api.ResourceThresholds{v1.ResourceCPU: 50},
+			}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := &fake.Clientset{}
+			fakeClient.Fake.AddReactor("list", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				fieldSelector := action.(core.ListAction).GetListRestrictions().Fields
+				podList := &v1.PodList{}
+				for _, pod := range pods {
+					if fieldSelector.Matches(fields.Set{"spec.nodeName": pod.Spec.NodeName, "status.phase": string(pod.Status.Phase)}) {
+						podList.Items = append(podList.Items, *pod.DeepCopy())
+					}
+				}
+				return true, podList, nil
+			})
+			var evicted []string
+			fakeClient.Fake.AddReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() == "eviction" {
+					evicted = append(evicted, action.(core.CreateAction).GetObject().(*v1beta1.Eviction).Name)
+				}
+				return true, nil, nil
+			})
+
+			podEvictor := evictions.NewPodEvictor(
+				fakeClient,
+				policyv1.SchemeGroupVersion.String(),
+				false,
+				0,
+				0,
+				nodes,
+				false,
+				false,
+				false,
+			)
+
+			strategy := api.DeschedulerStrategy{
+				Enabled: true,
+				Params: &api.StrategyParameters{
+					NodeResourceUtilizationThresholds: &api.NodeResourceUtilizationThresholds{
+						// no node is overutilized when all pods are counted
+						Thresholds:       api.ResourceThresholds{v1.ResourceCPU: 0},
+						TargetThresholds: api.ResourceThresholds{v1.ResourceCPU: 100},
+						PriorityBands:    tc.priorityBands,
+					},
+				},
+			}
+			LowNodeUtilization(ctx, fakeClient, strategy, nodes, podEvictor)
+
+			if len(evicted) != tc.expectedCount {
+				t.Fatalf("Expected %v evictions, got %v", tc.expectedCount, evicted)
+			}
+			for _, name := range evicted {
+				if !strings.HasPrefix(name, tc.expectedPrefix) {
+					t.Errorf("Expected only pods of the priority band to be evicted, got %v", name)
+				}
+			}
+		})
+	}
+}
+
+func TestLowNodeUtilizationWithDestinationScorer(t *testing.T) {
+	ctx := context.Background()
+
+	n1 := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	n2 := test.BuildTestNode("n2", 2000, 3000, 10, nil)
+	n3 := test.BuildTestNode("n3", 2000, 3000, 10, nil)
+	nodes := []*v1.Node{n1, n2, n3}
+	var pods []*v1.Pod
+	// n1 runs 90% of cpu requests, n2 and n3 are empty
+	for i := 0; i < 6; i++ {
+		pods = append(pods, test.BuildTestPod(fmt.Sprintf("p%d", i), 300, 0, n1.Name, test.SetRSOwnerRef))
+	}
+
+	tests := []struct {
+		name          string
+		scorer        string
+		annotations   map[string]string
+		expectedCount int
+	}{
+		{
+			name:          "no scorer, the capacity of both destinations is used",
+			expectedCount: 3,
+		},
+		{
+			name:          "annotation scorer, half the capacity of a single destination is used",
+			scorer:        balance.DestinationScorerAnnotation,
+			annotations:   map[string]string{n2.Name: "0", n3.Name: "50"},
+			expectedCount: 2,
+		},
+		{
+			name:          "annotation scorer, no destination",
+			scorer:        balance.DestinationScorerAnnotation,
+			annotations:   map[string]string{n2.Name: "0", n3.Name: "0"},
+			expectedCount: 0,
+		},
+		{
+			name:          "least allocated scorer on empty destinations",
+			scorer:        balance.DestinationScorerLeastAllocated,
+			expectedCount: 3,
+		},
+		{
+			name:   "unknown scorer",
+			scorer: "Unknown",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			scoredNodes := make([]*v1.Node, 0, len(nodes))
+			for _, node := range nodes {
+				node = node.DeepCopy()
+				if score, ok := tc.annotations[node.Name]; ok {
+					node.Annotations = map[string]string{balance.DestinationScoreAnnotationKey: score}
+				}
+				scoredNodes = append(scoredNodes, node)
+			}
+
+			fakeClient := &fake.Clientset{}
+			fakeClient.Fake.AddReactor("list", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				fieldSelector := action.(core.ListAction).GetListRestrictions().Fields
+				podList := &v1.PodList{}
+				for _, pod := range pods {
+					if fieldSelector.Matches(fields.Set{"spec.nodeName": pod.Spec.NodeName, "status.phase": string(pod.Status.Phase)}) {
+						podList.Items = append(podList.Items, *pod.DeepCopy())
+					}
+				}
+				return true, podList, nil
+			})
+			var evicted []string
+			fakeClient.Fake.AddReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() == "eviction" {
+					evicted = append(evicted, action.(core.CreateAction).GetObject().(*v1beta1.Eviction).Name)
+				}
+				return true, nil, nil
+			})
+
+			podEvictor := evictions.NewPodEvictor(
+				fakeClient,
+				policyv1.SchemeGroupVersion.String(),
+				false,
+				0,
+				0,
+				scoredNodes,
+				false,
+				false,
+				false,
+			)
+
+			strategy := api.DeschedulerStrategy{
+				Enabled: true,
+				Params: &api.StrategyParameters{
+					NodeResourceUtilizationThresholds: &api.NodeResourceUtilizationThresholds{
+						Thresholds:        api.ResourceThresholds{v1.ResourceCPU: 20},
+						TargetThresholds:  api.ResourceThresholds{v1.ResourceCPU: 50},
+						DestinationScorer: tc.scorer,
+					},
+				},
+			}
+			LowNodeUtilization(ctx, fakeClient, strategy, scoredNodes, podEvictor)
+
+			if len(evicted) != tc.expectedCount {
+				t.Errorf("Expected %v evictions, got %v", tc.expectedCount, evicted)
+			}
+		})
+	}
+}
+
+func TestLowNodeUtilizationWithBalancingDomain(t *testing.T) {
+	ctx := context.Background()
+
+	withZone := func(zone string) func(node *v1.Node) {
+		return func(node *v1.Node) {
+			node.Labels = map[string]string{"topology.kubernetes.io/zone": zone}
+		}
+	}
+	// zone a has no underutilized node, zone b has one
+	n1 := test.BuildTestNode("n1", 2000, 3000, 10, withZone("a"))
+	n2 := test.BuildTestNode("n2", 2000, 3000, 10, withZone("a"))
+	n3 := test.BuildTestNode("n3", 2000, 3000, 10, withZone("b"))
+	n4 := test.BuildTestNode("n4", 2000, 3000, 10, withZone("b"))
+	nodes := []*v1.Node{n1, n2, n3, n4}
+	var pods []*v1.Pod
+	// n1 and n3 run 90% of cpu requests, n2 80% and n4 is empty
+	for i := 0; i < 6; i++ {
+		pods = append(pods, test.BuildTestPod(fmt.Sprintf("a-%d", i), 300, 0, n1.Name, test.SetRSOwnerRef))
+		pods = append(pods, test.BuildTestPod(fmt.Sprintf("b-%d", i), 300, 0, n3.Name, test.SetRSOwnerRef))
+	}
+	for i := 0; i < 4; i++ {
+		pods = append(pods, test.BuildTestPod(fmt.Sprintf("a-big-%d", i), 400, 0, n2.Name, test.SetRSOwnerRef))
+	}
+
+	tests := []struct {
+		name                   string
+		balancingDomain        string
+		topologyKey            string
+		expectedZoneAEvictions bool
+	}{
+		{
+			name:                   "no balancing domain, pods of zone a are moved to zone b",
+			expectedZoneAEvictions: true,
+		},
+		{
+			name:            "zone balancing domain, only pods of zone b are moved",
+			balancingDomain: "topology.kubernetes.io/zone",
+		},
+		{
+			name:        "zone topology key, only pods of zone b are moved",
+			topologyKey: "topology.kubernetes.io/zone",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := &fake.Clientset{}
+			fakeClient.Fake.AddReactor("list", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				fieldSelector := action.(core.ListAction).GetListRestrictions().Fields
+				podList := &v1.PodList{}
+				for _, pod := range pods {
+					if fieldSelector.Matches(fields.Set{"spec.nodeName": pod.Spec.NodeName, "status.phase": string(pod.Status.Phase)}) {
+						podList.Items = append(podList.Items, *pod.DeepCopy())
+					}
+				}
+				return true, podList, nil
+			})
+			var evicted []string
+			fakeClient.Fake.AddReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() == "eviction" {
+					evicted = append(evicted, action.(core.CreateAction).GetObject().(*v1beta1.Eviction).Name)
+				}
+				return true, nil, nil
+			})
+
+			podEvictor := evictions.NewPodEvictor(
+				fakeClient,
+				policyv1.SchemeGroupVersion.String(),
+				false,
+				0,
+				0,
+				nodes,
+				false,
+				false,
+				false,
+			)
+
+			strategy := api.DeschedulerStrategy{
+				Enabled: true,
+				Params: &api.StrategyParameters{
+					NodeResourceUtilizationThresholds: &api.NodeResourceUtilizationThresholds{
+						Thresholds:       api.ResourceThresholds{v1.ResourceCPU: 20},
+						TargetThresholds: api.ResourceThresholds{v1.ResourceCPU: 50},
+						BalancingDomain:  tc.balancingDomain,
+						TopologyKey:      tc.topologyKey,
+					},
+				},
+			}
+			LowNodeUtilization(ctx, fakeClient, strategy, nodes, podEvictor)
+
+			if len(evicted) == 0 {
+				t.Fatalf("Expected pods to be evicted")
+			}
+			zoneAEvictions := false
+			for _, name := range evicted {
+				if strings.HasPrefix(name, "a-") {
+					zoneAEvictions = true
+				}
+			}
+			if zoneAEvictions != tc.expectedZoneAEvictions {
+				t.Errorf("Expected pods of zone a to be evicted: %v, got %v", tc.expectedZoneAEvictions, evicted)
+			}
+		})
+	}
+}
+
+func TestLowNodeUtilizationWithRelieveResources(t *testing.T) {
+	ctx := context.Background()
+
+	n1 := test.BuildTestNode("n1", 4000, 4000, 10, nil)
+	n2 := test.BuildTestNode("n2", 4000, 4000, 10, nil)
+	nodes := []*v1.Node{n1, n2}
+
+	tests := []struct {
+		name             string
+		podMemory        int64
+		relieveResources []v1.ResourceName
+		expectedEvicted  int
+	}{
+		{
+			name:            "all resources relieved, pods are evicted until cpu is below target",
+			podMemory:       600,
+			expectedEvicted: 2,
+		},
+		{
+			name:             "memory relieved, pods are evicted until memory is below target",
+			podMemory:        600,
+			relieveResources: []v1.ResourceName{v1.ResourceMemory},
+			expectedEvicted:  1,
+		},
+		{
+			name:             "memory relieved, no pod is evicted from a node overutilized on cpu only",
+			podMemory:        400,
+			relieveResources: []v1.ResourceName{v1.ResourceMemory},
+			expectedEvicted:  0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// n1 runs 90% of cpu requests, n2 is empty
+			var pods []*v1.Pod
+			for i := 0; i < 4; i++ {
+				pods = append(pods, test.BuildTestPod(fmt.Sprintf("p%d", i), 900, tc.podMemory, n1.Name, test.SetRSOwnerRef))
+			}
+
+			fakeClient := &fake.Clientset{}
+			fakeClient.Fake.AddReactor("list", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				fieldSelector := action.(core.ListAction).GetListRestrictions().Fields
+				podList := &v1.PodList{}
+				for _, pod := range pods {
+					if fieldSelector.Matches(fields.Set{"spec.nodeName": pod.Spec.NodeName, "status.phase": string(pod.Status.Phase)}) {
+						podList.Items = append(podList.Items, *pod.DeepCopy())
+					}
+				}
+				return true, podList, nil
+			})
+			var evicted []string
+			fakeClient.Fake.AddReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() == "eviction" {
+					evicted = append(evicted, action.(core.CreateAction).GetObject().(*v1beta1.Eviction).Name)
+				}
+				return true, nil, nil
+			})
+
+			podEvictor := evictions.NewPodEvictor(
+				fakeClient,
+				policyv1.SchemeGroupVersion.String(),
+				false,
+				0,
+				0,
+				nodes,
+				false,
+				false,
+				false,
+			)
+
+			strategy := api.DeschedulerStrategy{
+				Enabled: true,
+				Params: &api.StrategyParameters{
+					NodeResourceUtilizationThresholds: &api.NodeResourceUtilizationThresholds{
+						Thresholds:       api.ResourceThresholds{v1.ResourceCPU: 20, v1.ResourceMemory: 20},
+						TargetThresholds: api.ResourceThresholds{v1.ResourceCPU: 50, v1.ResourceMemory: 50},
+						RelieveResources: tc.relieveResources,
+					},
+				},
+			}
+			LowNodeUtilization(ctx, fakeClient, strategy, nodes, podEvictor)
+
+			if len(evicted) != tc.expectedEvicted {
+				t.Errorf("Expected %v pods to be evicted, got %v", tc.expectedEvicted, evicted)
+			}
+		})
+	}
+}
+
+func TestValidateRelieveResources(t *testing.T) {
+	thresholds := api.ResourceThresholds{v1.ResourceCPU: 20, v1.ResourceMemory: 20}
+	if err := validateRelieveResources([]v1.ResourceName{v1.ResourceMemory}, thresholds); err != nil {
+		t.Errorf("Unexpected error for a configured resource: %v", err)
+	}
+	if err := validateRelieveResources([]v1.ResourceName{v1.ResourcePods}, thresholds); err == nil {
+		t.Errorf("Expected an error for a resource not configured in thresholds")
+	}
+}
+
+func TestLowNodeUtilizationExcludingDaemonSetPods(t *testing.T) {
+	ctx := context.Background()
+
+	n1 := test.BuildTestNode("n1", 4000, 4000, 10, nil)
+	n2 := test.BuildTestNode("n2", 4000, 4000, 10, nil)
+	nodes := []*v1.Node{n1, n2}
+	// n1 runs 45% of cpu requests in ReplicaSet pods and 50% in DaemonSet pods, n2 is empty
+	pods := []*v1.Pod{
+		test.BuildTestPod("p1", 900, 0, n1.Name, test.SetRSOwnerRef),
+		test.BuildTestPod("p2", 900, 0, n1.Name, test.SetRSOwnerRef),
+		test.BuildTestPod("ds1", 1000, 0, n1.Name, test.SetDSOwnerRef),
+		test.BuildTestPod("ds2", 1000, 0, n1.Name, test.SetDSOwnerRef),
+	}
+
+	tests := []struct {
+		name                 string
+		excludeDaemonSetPods bool
+		expectedEvicted      int
+	}{
+		{
+			name:            "DaemonSet pods counted, the node is overutilized",
+			expectedEvicted: 2,
+		},
+		{
+			name:                 "DaemonSet pods excluded, the node is appropriately utilized",
+			excludeDaemonSetPods: true,
+			expectedEvicted:      0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := &fake.Clientset{}
+			fakeClient.Fake.AddReactor("list", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				fieldSelector := action.(core.ListAction).GetListRestrictions().Fields
+				podList := &v1.PodList{}
+				for _, pod := range pods {
+					if fieldSelector.Matches(fields.Set{"spec.nodeName": pod.Spec.NodeName, "status.phase": string(pod.Status.Phase)}) {
+						podList.Items = append(podList.Items, *pod.DeepCopy())
+					}
+				}
+				return true, podList, nil
+			})
+			var evicted []string
+			fakeClient.Fake.AddReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() == "eviction" {
+					evicted = append(evicted, action.(core.CreateAction).GetObject().(*v1beta1.Eviction).Name)
+				}
+				return true, nil, nil
+			})
+
+			podEvictor := evictions.NewPodEvictor(
+				fakeClient,
+				policyv1.SchemeGroupVersion.String(),
+				false,
+				0,
+				0,
+				nodes,
+				false,
+				false,
+				false,
+			)
+
+			strategy := api.DeschedulerStrategy{
+				Enabled: true,
+				Params: &api.StrategyParameters{
+					NodeResourceUtilizationThresholds: &api.NodeResourceUtilizationThresholds{
+						Thresholds:           api.ResourceThresholds{v1.ResourceCPU: 20},
+						TargetThresholds:     api.ResourceThresholds{v1.ResourceCPU: 50},
+						ExcludeDaemonSetPods: tc.excludeDaemonSetPods,
+					},
+				},
+			}
+			LowNodeUtilization(ctx, fakeClient, strategy, nodes, podEvictor)
+
+			if len(evicted) != tc.expectedEvicted {
+				t.Errorf("Expected %v pods to be evicted, got %v", tc.expectedEvicted, evicted)
+			}
+		})
+	}
+}
+
+func TestLowNodeUtilizationCountingTerminatingPods(t *testing.T) {
+	ctx := context.Background()
+
+	n1 := test.BuildTestNode("n1", 4000, 4000, 10, nil)
+	n2 := test.BuildTestNode("n2", 4000, 4000, 10, nil)
+	nodes := []*v1.Node{n1, n2}
+	terminating := func(pod *v1.Pod) {
+		test.SetRSOwnerRef(pod)
+		deletionTimestamp := metav1.Now()
+		pod.DeletionTimestamp = &deletionTimestamp
+	}
+	// n1 runs 45% of cpu requests in running pods and 50% in terminating pods, n2 is empty
+	pods := []*v1.Pod{
+		test.BuildTestPod("p1", 900, 0, n1.Name, test.SetRSOwnerRef),
+		test.BuildTestPod("p2", 900, 0, n1.Name, test.SetRSOwnerRef),
+		test.BuildTestPod("t1", 1000, 0, n1.Name, terminating),
+		test.BuildTestPod("t2", 1000, 0, n1.Name, terminating),
+	}
+
+	countTerminatingPods := false
+	tests := []struct {
+		name                 string
+		countTerminatingPods *bool
+		expectedEvicted      int
+	}{
+		{
+			name:            "Terminating pods counted by default, the node is overutilized",
+			expectedEvicted: 2,
+		},
+		{
+			name:                 "Terminating pods not counted, the node is appropriately utilized",
+			countTerminatingPods: &countTerminatingPods,
+			expectedEvicted:      0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := &fake.Clientset{}
+			fakeClient.Fake.AddReactor("list", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				fieldSelector := action.(core.ListAction).GetListRestrictions().Fields
+				podList := &v1.PodList{}
+				for _, pod := range pods {
+					if fieldSelector.Matches(fields.Set{"spec.nodeName": pod.Spec.NodeName, "status.phase": string(pod.Status.Phase)}) {
+						podList.Items = append(podList.Items, *pod.DeepCopy())
+					}
+				}
+				return true, podList, nil
+			})
+			var evicted []string
+			fakeClient.Fake.AddReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() == "eviction" {
+					evicted = append(evicted, action.(core.CreateAction).GetObject().(*v1beta1.Eviction).Name)
+				}
+				return true, nil, nil
+			})
+
+			podEvictor := evictions.NewPodEvictor(
+				fakeClient,
+				policyv1.SchemeGroupVersion.String(),
+				false,
+				0,
+				0,
+				nodes,
+				false,
+				false,
+				false,
+			)
+
+			strategy := api.DeschedulerStrategy{
+				Enabled: true,
+				Params: &api.StrategyParameters{
+					NodeResourceUtilizationThresholds: &api.NodeResourceUtilizationThresholds{
+						Thresholds:           api.ResourceThresholds{v1.ResourceCPU: 20},
+						TargetThresholds:     api.ResourceThresholds{v1.ResourceCPU: 50},
+						CountTerminatingPods: tc.countTerminatingPods,
+					},
+				},
+			}
+			LowNodeUtilization(ctx, fakeClient, strategy, nodes, podEvictor)
+
+			if len(evicted) != tc.expectedEvicted {
+				t.Errorf("Expected %v pods to be evicted, got %v", tc.expectedEvicted, evicted)
+			}
+		})
+	}
+}
+
+func TestLowNodeUtilizationCountingNominatedPods(t *testing.T) {
+	ctx := context.Background()
+
+	n1 := test.BuildTestNode("n1", 4000, 4000, 10, nil)
+	n2 := test.BuildTestNode("n2", 4000, 4000, 10, nil)
+	nodes := []*v1.Node{n1, n2}
+	// n1 runs 90% of cpu requests, n2 is empty but a pending pod requesting 75% of its cpu is nominated to it
+	pods := []*v1.Pod{
+		test.BuildTestPod("p1", 900, 0, n1.Name, test.SetRSOwnerRef),
+		test.BuildTestPod("p2", 900, 0, n1.Name, test.SetRSOwnerRef),
+		test.BuildTestPod("p3", 900, 0, n1.Name, test.SetRSOwnerRef),
+		test.BuildTestPod("p4", 900, 0, n1.Name, test.SetRSOwnerRef),
+		test.BuildTestPod("nominated", 3000, 0, "", func(pod *v1.Pod) {
+			pod.Status.Phase = v1.PodPending
+			pod.Status.NominatedNodeName = n2.Name
+		}),
+	}
+
+	tests := []struct {
+		name               string
+		countNominatedPods bool
+		expectedEvicted    int
+	}{
+		{
+			name:            "Nominated pods not counted by default, n2 is underutilized",
+			expectedEvicted: 2,
+		},
+		{
+			name:               "Nominated pods counted, n2 is appropriately utilized",
+			countNominatedPods: true,
+			expectedEvicted:    0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := &fake.Clientset{}
+			fakeClient.Fake.AddReactor("list", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				fieldSelector := action.(core.ListAction).GetListRestrictions().Fields
+				podList := &v1.PodList{}
+				for _, pod := range pods {
+					if fieldSelector.Matches(fields.Set{"spec.nodeName": pod.Spec.NodeName, "status.phase": string(pod.Status.Phase)}) {
+						podList.Items = append(podList.Items, *pod.DeepCopy())
+					}
+				}
+				return true, podList, nil
+			})
+			var evicted []string
+			fakeClient.Fake.AddReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() == "eviction" {
+					evicted = append(evicted, action.(core.CreateAction).GetObject().(*v1beta1.Eviction).Name)
+				}
+				return true, nil, nil
+			})
+
+			podEvictor := evictions.NewPodEvictor(
+				fakeClient,
+				policyv1.SchemeGroupVersion.String(),
+				false,
+				0,
+				0,
+				nodes,
+				false,
+				false,
+				false,
+			)
+
+			strategy := api.DeschedulerStrategy{
+				Enabled: true,
+				Params: &api.StrategyParameters{
+					NodeResourceUtilizationThresholds: &api.NodeResourceUtilizationThresholds{
+						Thresholds:         api.ResourceThresholds{v1.ResourceCPU: 20},
+						TargetThresholds:   api.ResourceThresholds{v1.ResourceCPU: 50},
+						CountNominatedPods: tc.countNominatedPods,
+					},
+				},
+			}
+			LowNodeUtilization(ctx, fakeClient, strategy, nodes, podEvictor)
+
+			if len(evicted) != tc.expectedEvicted {
+				t.Errorf("Expected %v pods to be evicted, got %v", tc.expectedEvicted, evicted)
+			}
+		})
+	}
+}
+
+func TestLowNodeUtilizationWithMetricsUtilization(t *testing.T) {
+	ctx := context.Background()
+
+	n1 := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	n2 := test.BuildTestNode("n2", 2000, 3000, 10, nil)
+	nodes := []*v1.Node{n1, n2}
+	// n1 runs 40% of cpu requests but uses 75% of its cpu, n2 is underutilized either way. Without memory
+	// metrics, pods count their memory requests
+	pods := []*v1.Pod{
+		test.BuildTestPod("p1", 400, 0, n1.Name, func(pod *v1.Pod) {
+			test.SetRSOwnerRef(pod)
+			priority := int32(100)
+			pod.Spec.Priority = &priority
+		}),
+		test.BuildTestPod("p2", 400, 0, n1.Name, test.SetRSOwnerRef),
+		test.BuildTestPod("p3", 100, 0, n2.Name, test.SetRSOwnerRef),
+	}
+	usage := map[string]v1.ResourceList{
+		"default/p1": {v1.ResourceCPU: resource.MustParse("900m")},
+		"default/p2": {v1.ResourceCPU: resource.MustParse("600m")},
+		"default/p3": {v1.ResourceCPU: resource.MustParse("50m")},
+	}
+
+	tests := []struct {
+		name               string
+		metricsUtilization bool
+		metricsErr         error
+		expectedEvicted    []string
+	}{
+		{
+			name: "Node usages computed from requests by default",
+		},
+		{
+			name:               "Node usages computed from actual usage",
+			metricsUtilization: true,
+			expectedEvicted:    []string{"p2"},
+		},
+		{
+			name:               "Node usages computed from requests when metrics are not available",
+			metricsUtilization: true,
+			metricsErr:         fmt.Errorf("the server could not find the requested resource"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func(original func(context.Context, clientset.Interface, []string) (map[string]v1.ResourceList, error)) {
+				listPodUsage = original
+			}(listPodUsage)
+			listPodUsage = func(context.Context, clientset.Interface, []string) (map[string]v1.ResourceList, error) {
+				if tc.metricsErr != nil {
+					return nil, tc.metricsErr
+				}
+				return usage, nil
+			}
+
+			fakeClient := &fake.Clientset{}
+			fakeClient.Fake.AddReactor("list", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				fieldSelector := action.(core.ListAction).GetListRestrictions().Fields
+				podList := &v1.PodList{}
+				for _, pod := range pods {
+					if fieldSelector.Matches(fields.Set{"spec.nodeName": pod.Spec.NodeName, "status.phase": string(pod.Status.Phase)}) {
+						podList.Items = append(podList.Items, *pod.DeepCopy())
+					}
+				}
+				return true, podList, nil
+			})
+			var evicted []string
+			fakeClient.Fake.AddReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() == "eviction" {
+					evicted = append(evicted, action.(core.CreateAction).GetObject().(*v1beta1.Eviction).Name)
+				}
+				return true, nil, nil
+			})
+
+			podEvictor := evictions.NewPodEvictor(
+				fakeClient,
+				policyv1.SchemeGroupVersion.String(),
+				false,
+				0,
+				0,
+				nodes,
+				false,
+				false,
+				false,
+			)
+
+			strategy := api.DeschedulerStrategy{
+				Enabled: true,
+				Params: &api.StrategyParameters{
+					NodeResourceUtilizationThresholds: &api.NodeResourceUtilizationThresholds{
+						Thresholds:         api.ResourceThresholds{v1.ResourceCPU: 30},
+						TargetThresholds:   api.ResourceThresholds{v1.ResourceCPU: 50},
+						MetricsUtilization: tc.metricsUtilization,
+					},
+				},
+			}
+			if err := LowNodeUtilization(ctx, fakeClient, strategy, nodes, podEvictor); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if strings.Join(evicted, ",") != strings.Join(tc.expectedEvicted, ",") {
+				t.Errorf("Expected %v pods to be evicted, got %v", tc.expectedEvicted, evicted)
+			}
+		})
+	}
+}
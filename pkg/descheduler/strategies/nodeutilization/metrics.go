@@ -0,0 +1,89 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeutilization
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	clientset "k8s.io/client-go/kubernetes"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/utils"
+)
+
+// UsageProviders are the sources node usages can be computed from: the requests or limits of pods,
+// their current usage from the metrics API, or their average usage from Prometheus
+var UsageProviders = []string{UtilizationMetricRequests, UtilizationMetricLimits, "MetricsServer", "Prometheus"}
+
+// usageProvider reads the actual CPU and memory usage of pods, by namespace and name
+type usageProvider interface {
+	podUsage(ctx context.Context) (map[string]v1.ResourceList, error)
+}
+
+// newUsageProvider returns the usage provider configured by the parameters, or nil when pods are
+// accounted for by their requests or limits
+func newUsageProvider(client clientset.Interface, params *api.NodeResourceUtilizationThresholds) usageProvider {
+	switch {
+	case params.Prometheus != nil:
+		return newPrometheusProvider(params.Prometheus, params.ExcludedContainers)
+	case params.MetricsUtilization:
+		return &metricsServerProvider{client: client, excludedContainers: params.ExcludedContainers}
+	}
+	return nil
+}
+
+// metricsServerProvider reads the current usage of pods from the metrics API
+type metricsServerProvider struct {
+	client             clientset.Interface
+	excludedContainers []string
+}
+
+func (p *metricsServerProvider) podUsage(ctx context.Context) (map[string]v1.ResourceList, error) {
+	return listPodUsage(ctx, p.client, p.excludedContainers)
+}
+
+// listPodUsage lists the CPU and memory usage of all pods, by namespace and name, from the metrics API.
+// The usage of the excluded containers is not counted.
+var listPodUsage = func(ctx context.Context, client clientset.Interface, excludedContainers []string) (map[string]v1.ResourceList, error) {
+	data, err := client.Discovery().RESTClient().Get().AbsPath("/apis/metrics.k8s.io/v1beta1/pods").DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list pod metrics: %v", err)
+	}
+	podMetrics := &metricsv1beta1.PodMetricsList{}
+	if err := json.Unmarshal(data, podMetrics); err != nil {
+		return nil, fmt.Errorf("unable to decode pod metrics: %v", err)
+	}
+	usage := make(map[string]v1.ResourceList, len(podMetrics.Items))
+	for _, metrics := range podMetrics.Items {
+		cpu := resource.Quantity{Format: resource.DecimalSI}
+		memory := resource.Quantity{Format: resource.BinarySI}
+		for _, container := range metrics.Containers {
+			if utils.IsContainerExcluded(container.Name, excludedContainers) {
+				continue
+			}
+			cpu.Add(container.Usage[v1.ResourceCPU])
+			memory.Add(container.Usage[v1.ResourceMemory])
+		}
+		usage[metrics.Namespace+"/"+metrics.Name] = v1.ResourceList{v1.ResourceCPU: cpu, v1.ResourceMemory: memory}
+	}
+	return usage, nil
+}
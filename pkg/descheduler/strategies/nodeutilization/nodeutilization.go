@@ -21,13 +21,24 @@ import (
 	"fmt"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
 	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
+	"math"
+	"math/rand"
+	"path"
+	"sigs.k8s.io/descheduler/metrics"
 	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/balance"
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
 	"sigs.k8s.io/descheduler/pkg/utils"
 	"sort"
+	"strings"
+	"sync"
+	"time"
 )
 
 // NodeUsage stores a node's info, pods on it, thresholds and its resource usage
@@ -40,6 +51,43 @@ type NodeUsage struct {
 	highResourceThreshold map[v1.ResourceName]*resource.Quantity
 }
 
+// podResources computes the quantities pods are accounted for in node usages
+type podResources struct {
+	excludedContainers []string
+	limits             bool
+	// usage is the actual CPU and memory usage of pods, by namespace and name, read from the usage provider
+	usage map[string]v1.ResourceList
+}
+
+// newPodResources reads the actual usage of pods from the usage provider configured by the parameters,
+// pods are accounted for by their requests when the provider is not available
+func newPodResources(ctx context.Context, client clientset.Interface, params *api.NodeResourceUtilizationThresholds) podResources {
+	r := podResources{
+		excludedContainers: params.ExcludedContainers,
+		limits:             params.UtilizationMetric == UtilizationMetricLimits,
+	}
+	if provider := newUsageProvider(client, params); provider != nil {
+		usage, err := provider.podUsage(ctx)
+		if err != nil {
+			klog.ErrorS(err, "Node usages are computed from the requests of pods, actual usage is not available")
+		} else {
+			r.usage = usage
+		}
+	}
+	return r
+}
+
+// quantity returns the actual usage, the request, or the limit, of the pod for the resource
+func (r podResources) quantity(pod *v1.Pod, name v1.ResourceName) resource.Quantity {
+	if usage, ok := r.usage[pod.Namespace+"/"+pod.Name][name]; ok {
+		return usage
+	}
+	if r.limits {
+		return utils.GetResourceLimitQuantityExcludingContainers(pod, name, r.excludedContainers)
+	}
+	return utils.GetResourceRequestQuantityExcludingContainers(pod, name, r.excludedContainers)
+}
+
 type continueEvictionCond func(nodeUsage NodeUsage, totalAvailableUsage map[v1.ResourceName]*resource.Quantity) bool
 
 // NodePodsMap is a set of (node, pods) pairs
@@ -50,6 +98,31 @@ const (
 	MinResourcePercentage = 0
 	// MaxResourcePercentage is the maximum value of a resource's percentage
 	MaxResourcePercentage = 100
+
+	// ThresholdsOperatorOr considers a node overutilized when any resource is above its target threshold
+	ThresholdsOperatorOr = "Or"
+	// ThresholdsOperatorAnd considers a node overutilized when all resources are above their target thresholds
+	ThresholdsOperatorAnd = "And"
+
+	// TieBreakerName orders nodes with the same utilization by name
+	TieBreakerName = "Name"
+	// TieBreakerCreationTimestamp orders nodes with the same utilization from the oldest to the newest
+	TieBreakerCreationTimestamp = "CreationTimestamp"
+	// TieBreakerRandom orders nodes with the same utilization randomly, reproducibly for a given seed
+	TieBreakerRandom = "Random"
+
+	// DefaultParallelism is the number of nodes whose usage is computed concurrently when not configured
+	DefaultParallelism = 16
+
+	// UtilizationMetricRequests computes node usages from the requests of their pods
+	UtilizationMetricRequests = "Requests"
+	// UtilizationMetricLimits computes node usages from the limits of their pods
+	UtilizationMetricLimits = "Limits"
+
+	// ThresholdsUnitsStrict refuses thresholds which look like fractions of the node capacity
+	ThresholdsUnitsStrict = "Strict"
+	// ThresholdsUnitsLenient converts thresholds which look like fractions of the node capacity to percentages
+	ThresholdsUnitsLenient = "Lenient"
 )
 
 func validateNodeUtilizationParams(params *api.StrategyParameters) error {
@@ -59,6 +132,58 @@ func validateNodeUtilizationParams(params *api.StrategyParameters) error {
 	if params.ThresholdPriority != nil && params.ThresholdPriorityClassName != "" {
 		return fmt.Errorf("only one of thresholdPriority and thresholdPriorityClassName can be set")
 	}
+	switch params.NodeResourceUtilizationThresholds.TieBreaker {
+	case "", TieBreakerName, TieBreakerCreationTimestamp, TieBreakerRandom:
+	default:
+		return fmt.Errorf("tieBreaker %q is not one of %q, %q, %q", params.NodeResourceUtilizationThresholds.TieBreaker, TieBreakerName, TieBreakerCreationTimestamp, TieBreakerRandom)
+	}
+	switch params.NodeResourceUtilizationThresholds.ThresholdsUnits {
+	case "", ThresholdsUnitsStrict, ThresholdsUnitsLenient:
+	default:
+		return fmt.Errorf("thresholdsUnits %q is not one of %q, %q", params.NodeResourceUtilizationThresholds.ThresholdsUnits, ThresholdsUnitsStrict, ThresholdsUnitsLenient)
+	}
+	if err := validateHysteresis(params.NodeResourceUtilizationThresholds.Hysteresis); err != nil {
+		return err
+	}
+	if percent := params.NodeResourceUtilizationThresholds.MinFreeCapacityPercent; percent < MinResourcePercentage || percent >= MaxResourcePercentage {
+		return fmt.Errorf("minFreeCapacityPercent not in [%v, %v) range", MinResourcePercentage, MaxResourcePercentage)
+	}
+	switch params.NodeResourceUtilizationThresholds.UtilizationMetric {
+	case "", UtilizationMetricRequests, UtilizationMetricLimits:
+	default:
+		return fmt.Errorf("utilizationMetric %q is not one of %q, %q", params.NodeResourceUtilizationThresholds.UtilizationMetric, UtilizationMetricRequests, UtilizationMetricLimits)
+	}
+	if params.NodeResourceUtilizationThresholds.MetricsUtilization && params.NodeResourceUtilizationThresholds.UtilizationMetric == UtilizationMetricLimits {
+		return fmt.Errorf("metricsUtilization can not be combined with utilizationMetric %q", UtilizationMetricLimits)
+	}
+	if err := validatePrometheus(params.NodeResourceUtilizationThresholds); err != nil {
+		return err
+	}
+	if err := validateTrend(params.NodeResourceUtilizationThresholds); err != nil {
+		return err
+	}
+	if params.NodeResourceUtilizationThresholds.Parallelism < 0 {
+		return fmt.Errorf("parallelism must not be negative")
+	}
+	if params.NodeResourceUtilizationThresholds.MinPodsOnSourceNode < 0 {
+		return fmt.Errorf("minPodsOnSourceNode must not be negative")
+	}
+	if _, err := balance.GetDestinationScorer(params.NodeResourceUtilizationThresholds.DestinationScorer); err != nil {
+		return err
+	}
+	if domain, topologyKey := params.NodeResourceUtilizationThresholds.BalancingDomain, params.NodeResourceUtilizationThresholds.TopologyKey; domain != "" && topologyKey != "" && domain != topologyKey {
+		return fmt.Errorf("balancingDomain %q and its alias topologyKey %q can not be set to different values", domain, topologyKey)
+	}
+	if domain := balancingDomain(params.NodeResourceUtilizationThresholds); domain != "" {
+		if errs := utilvalidation.IsQualifiedName(domain); len(errs) > 0 {
+			return fmt.Errorf("balancingDomain %q is not a valid label key: %v", domain, strings.Join(errs, "; "))
+		}
+	}
+	for _, pattern := range params.NodeResourceUtilizationThresholds.ExcludedContainers {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid excludedContainers pattern %q: %v", pattern, err)
+		}
+	}
 
 	return nil
 }
@@ -73,25 +198,143 @@ func validateThresholds(thresholds api.ResourceThresholds) error {
 			return fmt.Errorf("%v threshold not in [%v, %v] range", name, MinResourcePercentage, MaxResourcePercentage)
 		}
 	}
+	if isFractional(thresholds) {
+		return fmt.Errorf("thresholds %v look like fractions of the node capacity but are percentages, did you mean %v? "+
+			"Set thresholdsUnits to %q to convert them", formatThresholds(thresholds), formatThresholds(toPercentages(thresholds)), ThresholdsUnitsLenient)
+	}
 	return nil
 }
 
+// isFractional checks if all thresholds are within [0, 1] with at least one of them strictly in between,
+// which most likely means they were configured as fractions of the node capacity instead of percentages
+func isFractional(thresholds api.ResourceThresholds) bool {
+	fractional := false
+	for _, percent := range thresholds {
+		if percent < 0 || percent > 1 {
+			return false
+		}
+		if percent > 0 && percent < 1 {
+			fractional = true
+		}
+	}
+	return fractional
+}
+
+// toPercentages returns a copy of the thresholds multiplied by 100, rounded to drop floating point noise
+func toPercentages(thresholds api.ResourceThresholds) api.ResourceThresholds {
+	percentages := make(api.ResourceThresholds, len(thresholds))
+	for name, fraction := range thresholds {
+		percentages[name] = api.Percentage(math.Round(float64(fraction)*1e8) / 1e6)
+	}
+	return percentages
+}
+
+// formatThresholds formats thresholds as "name: percent" pairs sorted by resource name
+func formatThresholds(thresholds api.ResourceThresholds) string {
+	pairs := make([]string, 0, len(thresholds))
+	for name, percent := range thresholds {
+		pairs = append(pairs, fmt.Sprintf("%v: %v", name, percent))
+	}
+	sort.Strings(pairs)
+	return "{" + strings.Join(pairs, ", ") + "}"
+}
+
+// convertFractionalThresholds returns the strategy parameters with the thresholds which look like
+// fractions of the node capacity converted to percentages when thresholdsUnits is Lenient. The
+// parameters are copied before the conversion, the policy is shared by all cycles.
+func convertFractionalThresholds(params *api.StrategyParameters, strategyName string) *api.StrategyParameters {
+	if params.NodeResourceUtilizationThresholds.ThresholdsUnits != ThresholdsUnitsLenient {
+		return params
+	}
+	params = params.DeepCopy()
+	convert := func(thresholds api.ResourceThresholds, field string) api.ResourceThresholds {
+		if !isFractional(thresholds) {
+			return thresholds
+		}
+		percentages := toPercentages(thresholds)
+		klog.InfoS("Converted thresholds configured as fractions of the node capacity to percentages",
+			"strategy", strategyName, "field", field, "thresholds", formatThresholds(thresholds), "percentages", formatThresholds(percentages))
+		return percentages
+	}
+	nodeThresholds := params.NodeResourceUtilizationThresholds
+	nodeThresholds.Thresholds = convert(nodeThresholds.Thresholds, "thresholds")
+	nodeThresholds.TargetThresholds = convert(nodeThresholds.TargetThresholds, "targetThresholds")
+	nodeThresholds.WarningThresholds = convert(nodeThresholds.WarningThresholds, "warningThresholds")
+	for i := range nodeThresholds.PriorityBands {
+		nodeThresholds.PriorityBands[i].Thresholds = convert(nodeThresholds.PriorityBands[i].Thresholds, fmt.Sprintf("priorityBands[%d].thresholds", i))
+		nodeThresholds.PriorityBands[i].TargetThresholds = convert(nodeThresholds.PriorityBands[i].TargetThresholds, fmt.Sprintf("priorityBands[%d].targetThresholds", i))
+	}
+	return params
+}
+
+// validateWarningThresholds checks if warning thresholds have valid resource percentages configured
+// and only refer to resources whose usage is computed by the strategy
+func validateWarningThresholds(warningThresholds, thresholds api.ResourceThresholds) error {
+	for name, percent := range warningThresholds {
+		if percent < MinResourcePercentage || percent > MaxResourcePercentage {
+			return fmt.Errorf("%v warning threshold not in [%v, %v] range", name, MinResourcePercentage, MaxResourcePercentage)
+		}
+		if _, ok := thresholds[name]; !ok && !isBasicResource(name) {
+			return fmt.Errorf("%v warning threshold is not configured in thresholds", name)
+		}
+	}
+	if isFractional(warningThresholds) {
+		return fmt.Errorf("warning thresholds %v look like fractions of the node capacity but are percentages, did you mean %v? "+
+			"Set thresholdsUnits to %q to convert them", formatThresholds(warningThresholds), formatThresholds(toPercentages(warningThresholds)), ThresholdsUnitsLenient)
+	}
+	return nil
+}
+
+// validateHysteresis checks if the hysteresis is a valid percentage
+func validateHysteresis(hysteresis api.Percentage) error {
+	if hysteresis < MinResourcePercentage || hysteresis > MaxResourcePercentage {
+		return fmt.Errorf("hysteresis not in [%v, %v] range", MinResourcePercentage, MaxResourcePercentage)
+	}
+	return nil
+}
+
+// validateThresholdsOperator checks if thresholds operator is one of the supported values
+func validateThresholdsOperator(operator string) error {
+	switch operator {
+	case "", ThresholdsOperatorOr, ThresholdsOperatorAnd:
+		return nil
+	default:
+		return fmt.Errorf("thresholdsOperator %q is not one of %q, %q", operator, ThresholdsOperatorOr, ThresholdsOperatorAnd)
+	}
+}
+
 func getNodeUsage(
 	ctx context.Context,
 	client clientset.Interface,
 	nodes []*v1.Node,
 	lowThreshold, highThreshold api.ResourceThresholds,
 	resourceNames []v1.ResourceName,
+	podResources podResources,
+	parallelism int,
+	podFilter func(pod *v1.Pod) bool,
+	countNominatedPods bool,
 ) []NodeUsage {
-	var nodeUsageList []NodeUsage
+	if parallelism <= 0 {
+		parallelism = DefaultParallelism
+	}
 
-	for _, node := range nodes {
-		pods, err := podutil.ListPodsOnANode(ctx, client, node)
+	var nominatedPods map[string][]*v1.Pod
+	if countNominatedPods {
+		var err error
+		if nominatedPods, err = listNominatedPods(ctx, client, podFilter); err != nil {
+			klog.ErrorS(err, "Node usages will not count nominated pods")
+		}
+	}
+
+	// every worker fills the entry of its node, keeping the order of nodes
+	nodeUsages := make([]*NodeUsage, len(nodes))
+	workqueue.ParallelizeUntil(ctx, parallelism, len(nodes), func(i int) {
+		node := nodes[i]
+		pods, err := podutil.ListPodsOnANode(ctx, client, node, podutil.WithFilter(podFilter))
 		if err != nil {
 			klog.V(2).InfoS("Node will not be processed, error accessing its pods", "node", klog.KObj(node), "err", err)
-			continue
+			return
 		}
-
 		// A threshold is in percentages but in <0;100> interval.
 		// Performing `threshold * 0.01` will convert <0;100> interval into <0;1>.
 		// Multiplying it with capacity will give fraction of the capacity corresponding to the given high/low resource threshold in Quantity units.
@@ -107,7 +350,7 @@ func getNodeUsage(
 		for _, name := range resourceNames {
 			if !isBasicResource(name) {
 				cap := nodeCapacity[name]
-				lowResourceThreshold[name] = resource.NewQuantity(int64(float64(lowThreshold[name])*float64(cap.Value())*0.01), resource.DecimalSI)
+				lowResourceThreshold[name] = resource.NewQuantity(int64(float64(lowThreshold[name])*float64(cap.Value())*0.01), resourceFormat(name))
 			}
 		}
 		highResourceThreshold := map[v1.ResourceName]*resource.Quantity{
@@ -118,22 +361,86 @@ func getNodeUsage(
 		for _, name := range resourceNames {
 			if !isBasicResource(name) {
 				cap := nodeCapacity[name]
-				highResourceThreshold[name] = resource.NewQuantity(int64(float64(highThreshold[name])*float64(cap.Value())*0.01), resource.DecimalSI)
+				highResourceThreshold[name] = resource.NewQuantity(int64(float64(highThreshold[name])*float64(cap.Value())*0.01), resourceFormat(name))
 			}
 		}
 
-		nodeUsageList = append(nodeUsageList, NodeUsage{
+		// pods nominated to the node count in its usage but can not be evicted from it
+		usagePods := pods
+		if nominated := nominatedPods[node.Name]; len(nominated) > 0 {
+			usagePods = append(append(make([]*v1.Pod, 0, len(pods)+len(nominated)), pods...), nominated...)
+		}
+		nodeUsages[i] = &NodeUsage{
 			node:                  node,
-			usage:                 nodeUtilization(node, pods, resourceNames),
+			usage:                 nodeUtilization(node, usagePods, resourceNames, podResources),
 			allPods:               pods,
 			lowResourceThreshold:  lowResourceThreshold,
 			highResourceThreshold: highResourceThreshold,
-		})
-	}
+		}
+	})
 
+	var nodeUsageList []NodeUsage
+	for _, nodeUsage := range nodeUsages {
+		if nodeUsage != nil {
+			nodeUsageList = append(nodeUsageList, *nodeUsage)
+		}
+	}
 	return nodeUsageList
 }
 
+// listNominatedPods returns the pending pods accepted by the filter which are not bound to a node yet, by the node
+// they are nominated to, e.g. preemptors waiting for their victims to terminate
+func listNominatedPods(ctx context.Context, client clientset.Interface, podFilter func(pod *v1.Pod) bool) (map[string][]*v1.Pod, error) {
+	podList, err := client.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: "spec.nodeName=,status.phase=" + string(v1.PodPending),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list pending pods: %v", err)
+	}
+	nominatedPods := map[string][]*v1.Pod{}
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if pod.Status.Phase != v1.PodPending || pod.Status.NominatedNodeName == "" || pod.Spec.NodeName != "" ||
+			(podFilter != nil && !podFilter(pod)) {
+			continue
+		}
+		nominatedPods[pod.Status.NominatedNodeName] = append(nominatedPods[pod.Status.NominatedNodeName], pod)
+	}
+	return nominatedPods, nil
+}
+
+// withoutDaemonSetPods extends the filter of the pods counted in node usages to exclude DaemonSet pods
+func withoutDaemonSetPods(podFilter func(pod *v1.Pod) bool) func(pod *v1.Pod) bool {
+	return func(pod *v1.Pod) bool {
+		if utils.IsDaemonsetPod(podutil.OwnerRef(pod)) {
+			return false
+		}
+		return podFilter == nil || podFilter(pod)
+	}
+}
+
+// withoutTerminatingPods extends the filter of the pods counted in node usages to exclude terminating pods
+func withoutTerminatingPods(podFilter func(pod *v1.Pod) bool) func(pod *v1.Pod) bool {
+	return func(pod *v1.Pod) bool {
+		if pod.DeletionTimestamp != nil {
+			return false
+		}
+		return podFilter == nil || podFilter(pod)
+	}
+}
+
+// usagePodFilter returns the filter of the pods counted in node usages, extending the given filter
+// with the parameters excluding DaemonSet and terminating pods
+func usagePodFilter(params *api.NodeResourceUtilizationThresholds, podFilter func(pod *v1.Pod) bool) func(pod *v1.Pod) bool {
+	if params.ExcludeDaemonSetPods {
+		podFilter = withoutDaemonSetPods(podFilter)
+	}
+	if params.CountTerminatingPods != nil && !*params.CountTerminatingPods {
+		podFilter = withoutTerminatingPods(podFilter)
+	}
+	return podFilter
+}
+
 func resourceUsagePercentages(nodeUsage NodeUsage) map[v1.ResourceName]float64 {
 	nodeCapacity := nodeUsage.node.Status.Capacity
 	if len(nodeUsage.node.Status.Allocatable) > 0 {
@@ -151,6 +458,53 @@ func resourceUsagePercentages(nodeUsage NodeUsage) map[v1.ResourceName]float64 {
 	return resourceUsagePercentage
 }
 
+// reportWarningThresholds reports nodes whose usage crossed the warning thresholds.
+// Crossing a warning threshold never evicts any pod, it only exposes nodes which would
+// be processed once the same values are enforced through the strategy's thresholds.
+func reportWarningThresholds(
+	nodeUsages []NodeUsage,
+	warningThresholds api.ResourceThresholds,
+	strategy string,
+	crossed func(usagePercentage map[v1.ResourceName]float64, warningThresholds api.ResourceThresholds) bool,
+) []*v1.Node {
+	if len(warningThresholds) == 0 {
+		return nil
+	}
+
+	var warnedNodes []*v1.Node
+	for _, nodeUsage := range nodeUsages {
+		usagePercentage := resourceUsagePercentages(nodeUsage)
+		if crossed(usagePercentage, warningThresholds) {
+			klog.V(1).InfoS("Node usage crossed warning thresholds", "node", klog.KObj(nodeUsage.node), "strategy", strategy, "usagePercentage", usagePercentage, "warningThresholds", warningThresholds)
+			metrics.NodeUtilizationWarning.With(map[string]string{"strategy": strategy, "node": nodeUsage.node.Name}).Set(1)
+			warnedNodes = append(warnedNodes, nodeUsage.node)
+		} else {
+			metrics.NodeUtilizationWarning.With(map[string]string{"strategy": strategy, "node": nodeUsage.node.Name}).Set(0)
+		}
+	}
+	return warnedNodes
+}
+
+// isAboveWarningThresholds checks if at least one resource usage is above its warning threshold
+func isAboveWarningThresholds(usagePercentage map[v1.ResourceName]float64, warningThresholds api.ResourceThresholds) bool {
+	for name, threshold := range warningThresholds {
+		if usagePercentage[name] > float64(threshold) {
+			return true
+		}
+	}
+	return false
+}
+
+// isBelowWarningThresholds checks if all resource usages are below their warning thresholds
+func isBelowWarningThresholds(usagePercentage map[v1.ResourceName]float64, warningThresholds api.ResourceThresholds) bool {
+	for name, threshold := range warningThresholds {
+		if usagePercentage[name] > float64(threshold) {
+			return false
+		}
+	}
+	return true
+}
+
 // classifyNodes classifies the nodes into low-utilization or high-utilization nodes. If a node lies between
 // low and high thresholds, it is simply ignored.
 func classifyNodes(
@@ -176,18 +530,21 @@ func classifyNodes(
 
 // evictPodsFromSourceNodes evicts pods based on priority, if all the pods on the node have priority, if not
 // evicts them based on QoS as fallback option.
-// TODO: @ravig Break this function into smaller functions.
 func evictPodsFromSourceNodes(
 	ctx context.Context,
 	sourceNodes, destinationNodes []NodeUsage,
 	podEvictor *evictions.PodEvictor,
 	podFilter func(pod *v1.Pod) bool,
 	resourceNames []v1.ResourceName,
-	strategy string,
+	reason evictions.EvictionReason,
 	continueEviction continueEvictionCond,
+	selectPods podSelector,
+	tieBreaker nodeTieBreaker,
+	podResources podResources,
+	scorer balance.DestinationScorer,
 ) {
 
-	sortNodesByUsage(sourceNodes)
+	sortNodesByUsage(sourceNodes, tieBreaker)
 
 	// upper bound on total number of pods/cpu/memory and optional extended resources to be moved
 	totalAvailableUsage := map[v1.ResourceName]*resource.Quantity{
@@ -196,16 +553,26 @@ func evictPodsFromSourceNodes(
 		v1.ResourceMemory: {},
 	}
 
-	var taintsOfDestinationNodes = make(map[string][]v1.Taint, len(destinationNodes))
+	// replacements of evicted pods are preferably scheduled to destination nodes
+	hint := evictions.ReschedulingHint{}
+	destinations := make([]*v1.Node, 0, len(destinationNodes))
 	for _, node := range destinationNodes {
-		taintsOfDestinationNodes[node.node.Name] = node.node.Spec.Taints
+		// the capacity of destination nodes is weighted by their score
+		score := balance.ScoreDestination(scorer, node.node, node.usage)
+		if score == 0 {
+			klog.V(2).InfoS("Skipping destination node with a score of 0", "node", klog.KObj(node.node))
+			continue
+		}
+		destinations = append(destinations, node.node)
+		hint.PreferredNodes = append(hint.PreferredNodes, node.node.Name)
 
 		for _, name := range resourceNames {
 			if _, ok := totalAvailableUsage[name]; !ok {
-				totalAvailableUsage[name] = resource.NewQuantity(0, resource.DecimalSI)
+				totalAvailableUsage[name] = resource.NewQuantity(0, resourceFormat(name))
 			}
-			totalAvailableUsage[name].Add(*node.highResourceThreshold[name])
-			totalAvailableUsage[name].Sub(*node.usage[name])
+			available := node.highResourceThreshold[name].DeepCopy()
+			available.Sub(*node.usage[name])
+			totalAvailableUsage[name].Add(balance.WeightQuantity(available, score))
 		}
 	}
 
@@ -222,87 +589,140 @@ func evictPodsFromSourceNodes(
 	}
 	klog.V(1).InfoS("Total capacity to be moved", keysAndValues...)
 
+	candidates := &nodeUsageCandidates{
+		nodeUsages:       make(map[string]NodeUsage, len(sourceNodes)),
+		podFilter:        podFilter,
+		selectPods:       selectPods,
+		continueEviction: continueEviction,
+	}
+	sources := make([]balance.Source, 0, len(sourceNodes))
 	for _, node := range sourceNodes {
-		klog.V(3).InfoS("Evicting pods from node", "node", klog.KObj(node.node), "usage", node.usage)
+		candidates.nodeUsages[node.node.Name] = node
+		sources = append(sources, balance.Source{Node: node.node, Usage: node.usage})
+	}
+	capacity := balance.NewResourceCapacity(destinations, totalAvailableUsage, podResources.quantity)
+	balance.Balance(ctx, sources, candidates, capacity, &hintedEvictionSink{podEvictor: podEvictor, reason: reason, hint: hint})
+}
 
-		nonRemovablePods, removablePods := classifyPods(node.allPods, podFilter)
-		klog.V(2).InfoS("Pods on node", "node", klog.KObj(node.node), "allPods", len(node.allPods), "nonRemovablePods", len(nonRemovablePods), "removablePods", len(removablePods))
+// nodeUsageCandidates is a balance.CandidateSource evicting the removable pods of overutilized
+// nodes by priority, as long as the continueEviction condition holds
+type nodeUsageCandidates struct {
+	// nodeUsages are keyed by node name, usages are shared with the balance sources
+	nodeUsages       map[string]NodeUsage
+	podFilter        func(pod *v1.Pod) bool
+	selectPods       podSelector
+	continueEviction continueEvictionCond
+}
 
-		if len(removablePods) == 0 {
-			klog.V(1).InfoS("No removable pods on node, try next node", "node", klog.KObj(node.node))
-			continue
-		}
+func (c *nodeUsageCandidates) Candidates(source balance.Source, capacity balance.DestinationCapacity) []*v1.Pod {
+	nodeUsage := c.nodeUsages[source.Node.Name]
+	nonRemovablePods, removablePods := classifyPods(nodeUsage.allPods, c.podFilter)
+	klog.V(2).InfoS("Pods on node", "node", klog.KObj(source.Node), "allPods", len(nodeUsage.allPods), "nonRemovablePods", len(nonRemovablePods), "removablePods", len(removablePods))
+	if len(removablePods) == 0 {
+		return nil
+	}
 
-		klog.V(1).InfoS("Evicting pods based on priority, if they have same priority, they'll be evicted based on QoS tiers")
-		// sort the evictable Pods based on priority. This also sorts them based on QoS. If there are multiple pods with same priority, they are sorted based on QoS tiers.
-		podutil.SortPodsBasedOnPriorityLowToHigh(removablePods)
-		evictPods(ctx, removablePods, node, totalAvailableUsage, taintsOfDestinationNodes, podEvictor, strategy, continueEviction)
-		klog.V(1).InfoS("Evicted pods from node", "node", klog.KObj(node.node), "evictedPods", podEvictor.NodeEvicted(node.node), "usage", node.usage)
+	klog.V(1).InfoS("Evicting pods based on priority, if they have same priority, they'll be evicted based on QoS tiers")
+	// sort the evictable Pods based on priority. This also sorts them based on QoS. If there are multiple pods with same priority, they are sorted based on QoS tiers.
+	podutil.SortPodsBasedOnPriorityLowToHigh(removablePods)
+	if c.selectPods != nil {
+		removablePods = c.selectPods(removablePods, nodeUsage, capacity)
 	}
+	return removablePods
 }
 
-func evictPods(
-	ctx context.Context,
-	inputPods []*v1.Pod,
-	nodeUsage NodeUsage,
-	totalAvailableUsage map[v1.ResourceName]*resource.Quantity,
-	taintsOfLowNodes map[string][]v1.Taint,
-	podEvictor *evictions.PodEvictor,
-	strategy string,
-	continueEviction continueEvictionCond,
-) {
+func (c *nodeUsageCandidates) Continue(source balance.Source, capacity balance.DestinationCapacity) bool {
+	return c.continueEviction(c.nodeUsages[source.Node.Name], capacity.Remaining())
+}
 
-	if continueEviction(nodeUsage, totalAvailableUsage) {
-		for _, pod := range inputPods {
-			if !utils.PodToleratesTaints(pod, taintsOfLowNodes) {
-				klog.V(3).InfoS("Skipping eviction for pod, doesn't tolerate node taint", "pod", klog.KObj(pod))
-				continue
-			}
+// hintedEvictionSink is a balance.EvictionSink evicting pods with a rescheduling hint
+type hintedEvictionSink struct {
+	podEvictor *evictions.PodEvictor
+	reason     evictions.EvictionReason
+	hint       evictions.ReschedulingHint
+}
 
-			success, err := podEvictor.EvictPod(ctx, pod, nodeUsage.node, strategy)
-			if err != nil {
-				klog.ErrorS(err, "Error evicting pod", "pod", klog.KObj(pod))
-				break
-			}
+func (s *hintedEvictionSink) Evict(ctx context.Context, pod *v1.Pod, node *v1.Node) (bool, error) {
+	return s.podEvictor.EvictPodWithHint(ctx, pod, node, s.reason, s.hint)
+}
+
+// nodeDomain is a group of nodes balanced independently from the other groups
+type nodeDomain struct {
+	name  string
+	nodes []*v1.Node
+}
+
+// balancingDomain returns the node label key splitting nodes into groups balanced independently, set by
+// balancingDomain or its topologyKey alias
+func balancingDomain(thresholds *api.NodeResourceUtilizationThresholds) string {
+	if thresholds.BalancingDomain != "" {
+		return thresholds.BalancingDomain
+	}
+	return thresholds.TopologyKey
+}
+
+// groupNodesByDomain splits the nodes by the value of the balancing domain label, ordered by value.
+// Nodes without the label form a domain of their own. Without balancing domain all nodes are in a single domain.
+func groupNodesByDomain(nodes []*v1.Node, balancingDomain string) []nodeDomain {
+	if balancingDomain == "" {
+		return []nodeDomain{{nodes: nodes}}
+	}
+	domainNodes := map[string][]*v1.Node{}
+	for _, node := range nodes {
+		value := node.Labels[balancingDomain]
+		domainNodes[value] = append(domainNodes[value], node)
+	}
+	domains := make([]nodeDomain, 0, len(domainNodes))
+	for name, nodes := range domainNodes {
+		domains = append(domains, nodeDomain{name: name, nodes: nodes})
+	}
+	sort.Slice(domains, func(i, j int) bool {
+		return domains[i].name < domains[j].name
+	})
+	return domains
+}
+
+// nodeTieBreaker reports whether node a goes before node b when both have the same utilization
+type nodeTieBreaker func(a, b *v1.Node) bool
 
-			if success {
-				klog.V(3).InfoS("Evicted pods", "pod", klog.KObj(pod), "err", err)
-
-				for name := range totalAvailableUsage {
-					if name == v1.ResourcePods {
-						nodeUsage.usage[name].Sub(*resource.NewQuantity(1, resource.DecimalSI))
-						totalAvailableUsage[name].Sub(*resource.NewQuantity(1, resource.DecimalSI))
-					} else {
-						quantity := utils.GetResourceRequestQuantity(pod, name)
-						nodeUsage.usage[name].Sub(quantity)
-						totalAvailableUsage[name].Sub(quantity)
-					}
-				}
-
-				keysAndValues := []interface{}{
-					"node", nodeUsage.node.Name,
-					"CPU", nodeUsage.usage[v1.ResourceCPU].MilliValue(),
-					"Mem", nodeUsage.usage[v1.ResourceMemory].Value(),
-					"Pods", nodeUsage.usage[v1.ResourcePods].Value(),
-				}
-				for name := range totalAvailableUsage {
-					if !isBasicResource(name) {
-						keysAndValues = append(keysAndValues, string(name), totalAvailableUsage[name].Value())
-					}
-				}
-
-				klog.V(3).InfoS("Updated node usage", keysAndValues...)
-				// check if pods can be still evicted
-				if !continueEviction(nodeUsage, totalAvailableUsage) {
-					break
-				}
+// newNodeTieBreaker returns the configured tie breaker, nodes are ordered by name by default
+func newNodeTieBreaker(tieBreaker string, seed int64, nodes []*v1.Node) nodeTieBreaker {
+	byName := func(a, b *v1.Node) bool {
+		return a.Name < b.Name
+	}
+	switch tieBreaker {
+	case TieBreakerCreationTimestamp:
+		return func(a, b *v1.Node) bool {
+			if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+				return a.CreationTimestamp.Before(&b.CreationTimestamp)
 			}
+			return byName(a, b)
 		}
+	case TieBreakerRandom:
+		// shuffle the nodes sorted by name so the order only depends on the seed
+		names := make([]string, 0, len(nodes))
+		for _, node := range nodes {
+			names = append(names, node.Name)
+		}
+		sort.Strings(names)
+		rand.New(rand.NewSource(seed)).Shuffle(len(names), func(i, j int) {
+			names[i], names[j] = names[j], names[i]
+		})
+		rank := make(map[string]int, len(names))
+		for i, name := range names {
+			rank[name] = i
+		}
+		return func(a, b *v1.Node) bool {
+			return rank[a.Name] < rank[b.Name]
+		}
+	default:
+		return byName
 	}
 }
 
-// sortNodesByUsage sorts nodes based on usage in descending order
-func sortNodesByUsage(nodes []NodeUsage) {
+// sortNodesByUsage sorts nodes based on usage in descending order, nodes with the same usage
+// are ordered with the given tie breaker
+func sortNodesByUsage(nodes []NodeUsage, tieBreaker nodeTieBreaker) {
 	sort.Slice(nodes, func(i, j int) bool {
 		ti := nodes[i].usage[v1.ResourceMemory].Value() + nodes[i].usage[v1.ResourceCPU].MilliValue() + nodes[i].usage[v1.ResourcePods].Value()
 		tj := nodes[j].usage[v1.ResourceMemory].Value() + nodes[j].usage[v1.ResourceCPU].MilliValue() + nodes[j].usage[v1.ResourcePods].Value()
@@ -315,6 +735,9 @@ func sortNodesByUsage(nodes []NodeUsage) {
 			}
 		}
 
+		if ti == tj {
+			return tieBreaker(nodes[i].node, nodes[j].node)
+		}
 		// To return sorted in descending order
 		return ti > tj
 	})
@@ -332,6 +755,34 @@ func isNodeAboveTargetUtilization(usage NodeUsage) bool {
 	return false
 }
 
+// isNodeAboveTargetUtilizationForAny checks if a node is above the high threshold for any of the given resources
+// Resources not counted in the node usage are ignored
+func isNodeAboveTargetUtilizationForAny(usage NodeUsage, resourceNames []v1.ResourceName) bool {
+	for _, name := range resourceNames {
+		nodeValue, ok := usage.usage[name]
+		if !ok {
+			continue
+		}
+		// usage.highResourceThreshold[name] < nodeValue
+		if usage.highResourceThreshold[name].Cmp(*nodeValue) == -1 {
+			return true
+		}
+	}
+	return false
+}
+
+// isNodeAboveTargetUtilizationForAll checks if a node is overutilized
+// All given resources have to be above the high threshold
+func isNodeAboveTargetUtilizationForAll(usage NodeUsage, resourceNames []v1.ResourceName) bool {
+	for _, name := range resourceNames {
+		// usage.highResourceThreshold[name] >= nodeValue
+		if usage.highResourceThreshold[name].Cmp(*usage.usage[name]) != -1 {
+			return false
+		}
+	}
+	return true
+}
+
 // isNodeWithLowUtilization checks if a node is underutilized
 // All resources have to be below the low threshold
 func isNodeWithLowUtilization(usage NodeUsage) bool {
@@ -345,6 +796,204 @@ func isNodeWithLowUtilization(usage NodeUsage) bool {
 	return true
 }
 
+// applyHysteresis returns a copy of the node usage whose low thresholds are lowered and high
+// thresholds raised by the hysteresis, in percents of the node capacity. Only the given resources
+// are shifted, so resources whose thresholds were not configured keep their defaults.
+func applyHysteresis(nodeUsage NodeUsage, hysteresis api.Percentage, resourceNames []v1.ResourceName) NodeUsage {
+	if hysteresis == 0 {
+		return nodeUsage
+	}
+	nodeCapacity := nodeUsage.node.Status.Capacity
+	if len(nodeUsage.node.Status.Allocatable) > 0 {
+		nodeCapacity = nodeUsage.node.Status.Allocatable
+	}
+	lowResourceThreshold := make(map[v1.ResourceName]*resource.Quantity, len(nodeUsage.lowResourceThreshold))
+	for name, quantity := range nodeUsage.lowResourceThreshold {
+		lowResourceThreshold[name] = quantity
+	}
+	highResourceThreshold := make(map[v1.ResourceName]*resource.Quantity, len(nodeUsage.highResourceThreshold))
+	for name, quantity := range nodeUsage.highResourceThreshold {
+		highResourceThreshold[name] = quantity
+	}
+	for _, name := range resourceNames {
+		cap := nodeCapacity[name]
+		margin := resource.NewMilliQuantity(int64(float64(hysteresis)*float64(cap.MilliValue())*0.01), resourceFormat(name))
+		if quantity, ok := lowResourceThreshold[name]; ok {
+			lowered := quantity.DeepCopy()
+			lowered.Sub(*margin)
+			lowResourceThreshold[name] = &lowered
+		}
+		if quantity, ok := highResourceThreshold[name]; ok {
+			raised := quantity.DeepCopy()
+			raised.Add(*margin)
+			highResourceThreshold[name] = &raised
+		}
+	}
+	nodeUsage.lowResourceThreshold = lowResourceThreshold
+	nodeUsage.highResourceThreshold = highResourceThreshold
+	return nodeUsage
+}
+
+const (
+	// DefaultTrendWindowSeconds is the lookback window usage trends are fitted on when not configured
+	DefaultTrendWindowSeconds = 3600
+	// DefaultTrendMinSamples is the number of samples required to project the usage of a node when not configured
+	DefaultTrendMinSamples = 3
+)
+
+// usageSample is the usage of a resource of a node, in milli units, at a descheduling cycle
+type usageSample struct {
+	time  time.Time
+	value float64
+}
+
+// usageTrendTracker holds the usage samples of nodes across descheduling cycles, by node and resource name
+type usageTrendTracker struct {
+	lock    sync.Mutex
+	samples map[string]map[v1.ResourceName][]usageSample
+}
+
+// usageTrends outlives descheduling cycles, the trend of a node is fitted on the usages of the previous cycles
+var usageTrends = newUsageTrendTracker()
+
+func newUsageTrendTracker() *usageTrendTracker {
+	return &usageTrendTracker{samples: map[string]map[v1.ResourceName][]usageSample{}}
+}
+
+// record adds the usages of the nodes to their samples and returns, by node name, the increase of their usage at
+// the horizon projected by the linear trend of the samples within the window. Nodes with too few samples or whose
+// usage does not increase are not projected, samples older than the window are dropped.
+func (t *usageTrendTracker) record(now time.Time, nodeUsages []NodeUsage, trend *api.UsageTrend) map[string]map[v1.ResourceName]*resource.Quantity {
+	window := time.Duration(DefaultTrendWindowSeconds) * time.Second
+	if trend.WindowSeconds != nil && *trend.WindowSeconds > 0 {
+		window = time.Duration(*trend.WindowSeconds) * time.Second
+	}
+	minSamples := DefaultTrendMinSamples
+	if trend.MinSamples != nil && *trend.MinSamples > 0 {
+		minSamples = int(*trend.MinSamples)
+	}
+	resourceNames := trend.Resources
+	if len(resourceNames) == 0 {
+		resourceNames = []v1.ResourceName{v1.ResourceMemory}
+	}
+	horizon := time.Duration(trend.HorizonSeconds) * time.Second
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	// samples of deleted nodes expire with the window
+	for nodeName, samplesByResource := range t.samples {
+		for resourceName, samples := range samplesByResource {
+			if samples = samplesSince(samples, now.Add(-window)); len(samples) > 0 {
+				samplesByResource[resourceName] = samples
+			} else {
+				delete(samplesByResource, resourceName)
+			}
+		}
+		if len(samplesByResource) == 0 {
+			delete(t.samples, nodeName)
+		}
+	}
+
+	increases := map[string]map[v1.ResourceName]*resource.Quantity{}
+	for _, nodeUsage := range nodeUsages {
+		nodeName := nodeUsage.node.Name
+		for _, resourceName := range resourceNames {
+			usage, ok := nodeUsage.usage[resourceName]
+			if !ok {
+				continue
+			}
+			if t.samples[nodeName] == nil {
+				t.samples[nodeName] = map[v1.ResourceName][]usageSample{}
+			}
+			samples := append(t.samples[nodeName][resourceName], usageSample{time: now, value: float64(usage.MilliValue())})
+			t.samples[nodeName][resourceName] = samples
+			if len(samples) < minSamples {
+				continue
+			}
+			slope, ok := linearTrend(samples)
+			if !ok || slope <= 0 {
+				continue
+			}
+			if increases[nodeName] == nil {
+				increases[nodeName] = map[v1.ResourceName]*resource.Quantity{}
+			}
+			increases[nodeName][resourceName] = resource.NewMilliQuantity(int64(slope*horizon.Seconds()), resourceFormat(resourceName))
+		}
+	}
+	return increases
+}
+
+// samplesSince returns the samples taken after the given time
+func samplesSince(samples []usageSample, since time.Time) []usageSample {
+	for i, sample := range samples {
+		if sample.time.After(since) {
+			return samples[i:]
+		}
+	}
+	return nil
+}
+
+// linearTrend returns the slope, per second, of the least squares line fitted on the samples. There is no trend
+// when all samples were taken at the same time.
+func linearTrend(samples []usageSample) (float64, bool) {
+	n := float64(len(samples))
+	meanX, meanY := 0.0, 0.0
+	for _, sample := range samples {
+		meanX += sample.time.Sub(samples[0].time).Seconds() / n
+		meanY += sample.value / n
+	}
+	covariance, variance := 0.0, 0.0
+	for _, sample := range samples {
+		x := sample.time.Sub(samples[0].time).Seconds() - meanX
+		covariance += x * (sample.value - meanY)
+		variance += x * x
+	}
+	if variance == 0 {
+		return 0, false
+	}
+	return covariance / variance, true
+}
+
+// projectUsage returns a copy of the node usage increased by the projected increase of its resources
+func projectUsage(nodeUsage NodeUsage, increase map[v1.ResourceName]*resource.Quantity) NodeUsage {
+	if len(increase) == 0 {
+		return nodeUsage
+	}
+	usage := make(map[v1.ResourceName]*resource.Quantity, len(nodeUsage.usage))
+	for name, quantity := range nodeUsage.usage {
+		projected := quantity.DeepCopy()
+		if delta, ok := increase[name]; ok {
+			projected.Add(*delta)
+		}
+		usage[name] = &projected
+	}
+	nodeUsage.usage = usage
+	return nodeUsage
+}
+
+// validateTrend checks the usage trend configuration of the parameters
+func validateTrend(params *api.NodeResourceUtilizationThresholds) error {
+	trend := params.Trend
+	if trend == nil {
+		return nil
+	}
+	if !params.MetricsUtilization && params.Prometheus == nil {
+		return fmt.Errorf("trend requires the actual usage of metricsUtilization or prometheus")
+	}
+	if trend.HorizonSeconds == 0 {
+		return fmt.Errorf("trend horizonSeconds must be set")
+	}
+	if trend.MinSamples != nil && *trend.MinSamples < 2 {
+		return fmt.Errorf("trend minSamples must be at least 2")
+	}
+	for _, name := range trend.Resources {
+		if name != v1.ResourceCPU && name != v1.ResourceMemory {
+			return fmt.Errorf("trend resource %q is not one of %q, %q", name, v1.ResourceCPU, v1.ResourceMemory)
+		}
+	}
+	return nil
+}
+
 // getResourceNames returns list of resource names in resource thresholds
 func getResourceNames(thresholds api.ResourceThresholds) []v1.ResourceName {
 	resourceNames := make([]v1.ResourceName, 0, len(thresholds))
@@ -364,7 +1013,15 @@ func isBasicResource(name v1.ResourceName) bool {
 	}
 }
 
-func nodeUtilization(node *v1.Node, pods []*v1.Pod, resourceNames []v1.ResourceName) map[v1.ResourceName]*resource.Quantity {
+// resourceFormat returns the format quantities of the resource are expressed in
+func resourceFormat(name v1.ResourceName) resource.Format {
+	if name == v1.ResourceEphemeralStorage {
+		return resource.BinarySI
+	}
+	return resource.DecimalSI
+}
+
+func nodeUtilization(node *v1.Node, pods []*v1.Pod, resourceNames []v1.ResourceName, podResources podResources) map[v1.ResourceName]*resource.Quantity {
 	totalReqs := map[v1.ResourceName]*resource.Quantity{
 		v1.ResourceCPU:    resource.NewMilliQuantity(0, resource.DecimalSI),
 		v1.ResourceMemory: resource.NewQuantity(0, resource.BinarySI),
@@ -372,18 +1029,16 @@ func nodeUtilization(node *v1.Node, pods []*v1.Pod, resourceNames []v1.ResourceN
 	}
 	for _, name := range resourceNames {
 		if !isBasicResource(name) {
-			totalReqs[name] = resource.NewQuantity(0, resource.DecimalSI)
+			totalReqs[name] = resource.NewQuantity(0, resourceFormat(name))
 		}
 	}
 
 	for _, pod := range pods {
-		req, _ := utils.PodRequestsAndLimits(pod)
 		for _, name := range resourceNames {
-			quantity, ok := req[name]
-			if ok && name != v1.ResourcePods {
+			if name != v1.ResourcePods {
 				// As Quantity.Add says: Add adds the provided y quantity to the current value. If the current value is zero,
 				// the format of the quantity will be updated to the format of y.
-				totalReqs[name].Add(quantity)
+				totalReqs[name].Add(podResources.quantity(pod, name))
 			}
 		}
 	}
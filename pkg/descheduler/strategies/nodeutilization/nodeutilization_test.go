@@ -17,12 +17,21 @@ limitations under the License.
 package nodeutilization
 
 import (
+	"context"
 	"fmt"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
 	"math"
+	"reflect"
 	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/test"
 	"testing"
+	"time"
 )
 
 var (
@@ -104,6 +113,24 @@ func TestValidateThresholds(t *testing.T) {
 			},
 			errInfo: nil,
 		},
+		{
+			name: "passing thresholds as fractions",
+			input: api.ResourceThresholds{
+				v1.ResourceCPU:    0.2,
+				v1.ResourceMemory: 0.35,
+				v1.ResourcePods:   1,
+			},
+			errInfo: fmt.Errorf("thresholds {cpu: 0.2, memory: 0.35, pods: 1} look like fractions of the node capacity but are percentages, " +
+				"did you mean {cpu: 20, memory: 35, pods: 100}? Set thresholdsUnits to \"Lenient\" to convert them"),
+		},
+		{
+			name: "passing a valid threshold below one percent",
+			input: api.ResourceThresholds{
+				v1.ResourceCPU:    0.5,
+				v1.ResourceMemory: 20,
+			},
+			errInfo: nil,
+		},
 	}
 
 	for _, test := range tests {
@@ -156,3 +183,666 @@ func TestResourceUsagePercentages(t *testing.T) {
 
 	t.Logf("resourceUsagePercentage: %#v\n", resourceUsagePercentage)
 }
+
+func TestValidateWarningThresholds(t *testing.T) {
+	tests := []struct {
+		name              string
+		warningThresholds api.ResourceThresholds
+		thresholds        api.ResourceThresholds
+		errInfo           error
+	}{
+		{
+			name:              "no warning thresholds",
+			warningThresholds: nil,
+			thresholds:        api.ResourceThresholds{v1.ResourceCPU: 20},
+			errInfo:           nil,
+		},
+		{
+			name:              "basic resource not configured in thresholds",
+			warningThresholds: api.ResourceThresholds{v1.ResourceMemory: 40},
+			thresholds:        api.ResourceThresholds{v1.ResourceCPU: 20},
+			errInfo:           nil,
+		},
+		{
+			name:              "invalid warning threshold value",
+			warningThresholds: api.ResourceThresholds{v1.ResourceCPU: 120},
+			thresholds:        api.ResourceThresholds{v1.ResourceCPU: 20},
+			errInfo:           fmt.Errorf("%v warning threshold not in [%v, %v] range", v1.ResourceCPU, MinResourcePercentage, MaxResourcePercentage),
+		},
+		{
+			name:              "extended resource not configured in thresholds",
+			warningThresholds: api.ResourceThresholds{extendedResource: 40},
+			thresholds:        api.ResourceThresholds{v1.ResourceCPU: 20},
+			errInfo:           fmt.Errorf("%v warning threshold is not configured in thresholds", extendedResource),
+		},
+		{
+			name:              "extended resource configured in thresholds",
+			warningThresholds: api.ResourceThresholds{extendedResource: 40},
+			thresholds:        api.ResourceThresholds{extendedResource: 20},
+			errInfo:           nil,
+		},
+	}
+
+	for _, test := range tests {
+		validateErr := validateWarningThresholds(test.warningThresholds, test.thresholds)
+
+		if validateErr == nil || test.errInfo == nil {
+			if validateErr != test.errInfo {
+				t.Errorf("%s: expected %v but got %v instead", test.name, test.errInfo, validateErr)
+			}
+		} else if validateErr.Error() != test.errInfo.Error() {
+			t.Errorf("%s: expected %v but got %v instead", test.name, test.errInfo, validateErr)
+		}
+	}
+}
+
+func TestReportWarningThresholds(t *testing.T) {
+	buildNodeUsage := func(name string, cpu, mem int64) NodeUsage {
+		return NodeUsage{
+			node: test.BuildTestNode(name, 1000, 1000, 10, nil),
+			usage: map[v1.ResourceName]*resource.Quantity{
+				v1.ResourceCPU:    resource.NewMilliQuantity(cpu, resource.DecimalSI),
+				v1.ResourceMemory: resource.NewQuantity(mem, resource.BinarySI),
+				v1.ResourcePods:   resource.NewQuantity(1, resource.DecimalSI),
+			},
+		}
+	}
+	nodeUsages := []NodeUsage{
+		buildNodeUsage("n1", 100, 100),
+		buildNodeUsage("n2", 600, 100),
+		buildNodeUsage("n3", 600, 600),
+	}
+	warningThresholds := api.ResourceThresholds{
+		v1.ResourceCPU:    50,
+		v1.ResourceMemory: 50,
+	}
+
+	tests := []struct {
+		name          string
+		crossed       func(map[v1.ResourceName]float64, api.ResourceThresholds) bool
+		expectedNodes []string
+	}{
+		{
+			name:          "above warning thresholds",
+			crossed:       isAboveWarningThresholds,
+			expectedNodes: []string{"n2", "n3"},
+		},
+		{
+			name:          "below warning thresholds",
+			crossed:       isBelowWarningThresholds,
+			expectedNodes: []string{"n1"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			warnedNodes := reportWarningThresholds(nodeUsages, warningThresholds, "test", tc.crossed)
+			var names []string
+			for _, node := range warnedNodes {
+				names = append(names, node.Name)
+			}
+			if !reflect.DeepEqual(names, tc.expectedNodes) {
+				t.Errorf("Expected %v nodes to cross the warning thresholds, got %v", tc.expectedNodes, names)
+			}
+		})
+	}
+}
+
+func TestNodeUtilizationExcludedContainers(t *testing.T) {
+	node := test.BuildTestNode("n1", 4000, 3000, 10, nil)
+	pod := test.BuildTestPod("p1", 400, 100, node.Name, func(pod *v1.Pod) {
+		pod.Spec.Containers = append(pod.Spec.Containers, v1.Container{
+			Name: "istio-proxy",
+			Resources: v1.ResourceRequirements{
+				Requests: v1.ResourceList{
+					v1.ResourceCPU:    *resource.NewMilliQuantity(100, resource.DecimalSI),
+					v1.ResourceMemory: *resource.NewQuantity(128, resource.DecimalSI),
+				},
+			},
+		})
+	})
+	resourceNames := []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory, v1.ResourcePods}
+
+	tests := []struct {
+		name               string
+		excludedContainers []string
+		expectedCPU        int64
+		expectedMemory     int64
+	}{
+		{
+			name:           "no excluded containers",
+			expectedCPU:    500,
+			expectedMemory: 228,
+		},
+		{
+			name:               "sidecar excluded by name",
+			excludedContainers: []string{"istio-proxy"},
+			expectedCPU:        400,
+			expectedMemory:     100,
+		},
+		{
+			name:               "sidecar excluded by pattern",
+			excludedContainers: []string{"*-proxy"},
+			expectedCPU:        400,
+			expectedMemory:     100,
+		},
+		{
+			name:               "pattern matching no container",
+			excludedContainers: []string{"linkerd-*"},
+			expectedCPU:        500,
+			expectedMemory:     228,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			usage := nodeUtilization(node, []*v1.Pod{pod}, resourceNames, podResources{excludedContainers: tc.excludedContainers})
+			if cpu := usage[v1.ResourceCPU].MilliValue(); cpu != tc.expectedCPU {
+				t.Errorf("Expected %v millicores of cpu, got %v", tc.expectedCPU, cpu)
+			}
+			if memory := usage[v1.ResourceMemory].Value(); memory != tc.expectedMemory {
+				t.Errorf("Expected %v bytes of memory, got %v", tc.expectedMemory, memory)
+			}
+			if pods := usage[v1.ResourcePods].Value(); pods != 1 {
+				t.Errorf("Expected 1 pod, got %v", pods)
+			}
+		})
+	}
+}
+
+func TestNodeUtilizationLimits(t *testing.T) {
+	node := test.BuildTestNode("n1", 4000, 3000, 10, nil)
+	pod := test.BuildTestPod("p1", 400, 100, node.Name, func(pod *v1.Pod) {
+		pod.Spec.Containers[0].Resources.Limits = v1.ResourceList{
+			v1.ResourceCPU: *resource.NewMilliQuantity(1000, resource.DecimalSI),
+		}
+		// the sidecar has no limit, its request is counted
+		pod.Spec.Containers = append(pod.Spec.Containers, v1.Container{
+			Name: "istio-proxy",
+			Resources: v1.ResourceRequirements{
+				Requests: v1.ResourceList{
+					v1.ResourceCPU:    *resource.NewMilliQuantity(100, resource.DecimalSI),
+					v1.ResourceMemory: *resource.NewQuantity(128, resource.DecimalSI),
+				},
+			},
+		})
+	})
+	resourceNames := []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory, v1.ResourcePods}
+
+	tests := []struct {
+		name           string
+		podResources   podResources
+		expectedCPU    int64
+		expectedMemory int64
+	}{
+		{
+			name:           "requests",
+			expectedCPU:    500,
+			expectedMemory: 228,
+		},
+		{
+			name:           "limits",
+			podResources:   podResources{limits: true},
+			expectedCPU:    1100,
+			expectedMemory: 228,
+		},
+		{
+			name:           "limits with excluded containers",
+			podResources:   podResources{limits: true, excludedContainers: []string{"istio-proxy"}},
+			expectedCPU:    1000,
+			expectedMemory: 100,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			usage := nodeUtilization(node, []*v1.Pod{pod}, resourceNames, tc.podResources)
+			if cpu := usage[v1.ResourceCPU].MilliValue(); cpu != tc.expectedCPU {
+				t.Errorf("Expected %v millicores of cpu, got %v", tc.expectedCPU, cpu)
+			}
+			if memory := usage[v1.ResourceMemory].Value(); memory != tc.expectedMemory {
+				t.Errorf("Expected %v bytes of memory, got %v", tc.expectedMemory, memory)
+			}
+		})
+	}
+
+	params := &api.StrategyParameters{NodeResourceUtilizationThresholds: &api.NodeResourceUtilizationThresholds{UtilizationMetric: "Usage"}}
+	if err := validateNodeUtilizationParams(params); err == nil {
+		t.Errorf("Expected an unknown utilizationMetric to be refused")
+	}
+}
+
+func TestConvertFractionalThresholds(t *testing.T) {
+	fractions := api.ResourceThresholds{v1.ResourceCPU: 0.2, v1.ResourceMemory: 0.3}
+	percentages := api.ResourceThresholds{v1.ResourceCPU: 20, v1.ResourceMemory: 30}
+	newParams := func(units string) *api.StrategyParameters {
+		return &api.StrategyParameters{
+			NodeResourceUtilizationThresholds: &api.NodeResourceUtilizationThresholds{
+				Thresholds:       fractions.DeepCopy(),
+				TargetThresholds: api.ResourceThresholds{v1.ResourceCPU: 50, v1.ResourceMemory: 50},
+				PriorityBands: []api.PriorityBand{
+					{Thresholds: fractions.DeepCopy(), TargetThresholds: api.ResourceThresholds{v1.ResourceCPU: 0.5, v1.ResourceMemory: 0.5}},
+				},
+				ThresholdsUnits: units,
+			},
+		}
+	}
+
+	params := newParams(ThresholdsUnitsStrict)
+	if converted := convertFractionalThresholds(params, "LowNodeUtilization"); converted != params {
+		t.Errorf("expected thresholds not to be converted in %v mode", ThresholdsUnitsStrict)
+	}
+
+	params = newParams(ThresholdsUnitsLenient)
+	converted := convertFractionalThresholds(params, "LowNodeUtilization").NodeResourceUtilizationThresholds
+	if !reflect.DeepEqual(converted.Thresholds, percentages) {
+		t.Errorf("expected thresholds to be converted to %v, got %v", percentages, converted.Thresholds)
+	}
+	if !reflect.DeepEqual(converted.TargetThresholds, params.NodeResourceUtilizationThresholds.TargetThresholds) {
+		t.Errorf("expected target thresholds not to be converted, got %v", converted.TargetThresholds)
+	}
+	if !reflect.DeepEqual(converted.PriorityBands[0].Thresholds, percentages) ||
+		!reflect.DeepEqual(converted.PriorityBands[0].TargetThresholds, api.ResourceThresholds{v1.ResourceCPU: 50, v1.ResourceMemory: 50}) {
+		t.Errorf("expected priority band thresholds to be converted, got %v", converted.PriorityBands[0])
+	}
+	if !reflect.DeepEqual(params.NodeResourceUtilizationThresholds.Thresholds, fractions) {
+		t.Errorf("expected the policy thresholds to be left unchanged, got %v", params.NodeResourceUtilizationThresholds.Thresholds)
+	}
+}
+
+func TestValidateThresholdsOperator(t *testing.T) {
+	tests := []struct {
+		operator string
+		valid    bool
+	}{
+		{operator: "", valid: true},
+		{operator: ThresholdsOperatorOr, valid: true},
+		{operator: ThresholdsOperatorAnd, valid: true},
+		{operator: "Xor", valid: false},
+	}
+
+	for _, test := range tests {
+		if err := validateThresholdsOperator(test.operator); (err == nil) != test.valid {
+			t.Errorf("expected validity of thresholdsOperator %q to be %v but got %v instead", test.operator, test.valid, err)
+		}
+	}
+}
+
+func TestSortNodesByUsage(t *testing.T) {
+	buildNodeUsage := func(name string, cpu int64, created time.Time) NodeUsage {
+		return NodeUsage{
+			node: test.BuildTestNode(name, 1000, 1000, 10, func(node *v1.Node) {
+				node.CreationTimestamp = metav1.NewTime(created)
+			}),
+			usage: map[v1.ResourceName]*resource.Quantity{
+				v1.ResourceCPU:    resource.NewMilliQuantity(cpu, resource.DecimalSI),
+				v1.ResourceMemory: resource.NewQuantity(100, resource.BinarySI),
+				v1.ResourcePods:   resource.NewQuantity(1, resource.DecimalSI),
+			},
+		}
+	}
+	now := time.Now()
+	nodeUsages := func() []NodeUsage {
+		return []NodeUsage{
+			buildNodeUsage("n3", 500, now.Add(-3*time.Hour)),
+			buildNodeUsage("n4", 100, now),
+			buildNodeUsage("n1", 500, now.Add(-time.Hour)),
+			buildNodeUsage("n2", 500, now.Add(-2*time.Hour)),
+			buildNodeUsage("n5", 800, now),
+		}
+	}
+	sortedNames := func(tieBreaker string, seed int64) []string {
+		usages := nodeUsages()
+		var nodes []*v1.Node
+		for _, usage := range usages {
+			nodes = append(nodes, usage.node)
+		}
+		sortNodesByUsage(usages, newNodeTieBreaker(tieBreaker, seed, nodes))
+		var names []string
+		for _, usage := range usages {
+			names = append(names, usage.node.Name)
+		}
+		return names
+	}
+
+	if names := sortedNames("", 0); !reflect.DeepEqual(names, []string{"n5", "n1", "n2", "n3", "n4"}) {
+		t.Errorf("Expected nodes with the same usage to be ordered by name, got %v", names)
+	}
+	if names := sortedNames(TieBreakerCreationTimestamp, 0); !reflect.DeepEqual(names, []string{"n5", "n3", "n2", "n1", "n4"}) {
+		t.Errorf("Expected nodes with the same usage to be ordered from the oldest, got %v", names)
+	}
+	names := sortedNames(TieBreakerRandom, 42)
+	if names[0] != "n5" || names[4] != "n4" {
+		t.Errorf("Expected the random tie breaker to only order nodes with the same usage, got %v", names)
+	}
+	for i := 0; i < 10; i++ {
+		if again := sortedNames(TieBreakerRandom, 42); !reflect.DeepEqual(again, names) {
+			t.Fatalf("Expected the same order for the same seed, got %v and %v", names, again)
+		}
+	}
+}
+
+func TestApplyHysteresis(t *testing.T) {
+	buildNodeUsage := func(cpu int64) NodeUsage {
+		return NodeUsage{
+			node: test.BuildTestNode("n1", 1000, 1000, 10, nil),
+			usage: map[v1.ResourceName]*resource.Quantity{
+				v1.ResourceCPU:    resource.NewMilliQuantity(cpu, resource.DecimalSI),
+				v1.ResourceMemory: resource.NewQuantity(990, resource.BinarySI),
+			},
+			lowResourceThreshold: map[v1.ResourceName]*resource.Quantity{
+				v1.ResourceCPU:    resource.NewMilliQuantity(200, resource.DecimalSI),
+				v1.ResourceMemory: resource.NewQuantity(1000, resource.BinarySI),
+			},
+			highResourceThreshold: map[v1.ResourceName]*resource.Quantity{
+				v1.ResourceCPU:    resource.NewMilliQuantity(500, resource.DecimalSI),
+				v1.ResourceMemory: resource.NewQuantity(1000, resource.BinarySI),
+			},
+		}
+	}
+	configured := []v1.ResourceName{v1.ResourceCPU}
+
+	tests := []struct {
+		name                 string
+		cpu                  int64
+		hysteresis           api.Percentage
+		expectedLow          bool
+		expectedOverutilized bool
+	}{
+		{name: "above target, no hysteresis", cpu: 520, expectedOverutilized: true},
+		{name: "above target within the margin", cpu: 520, hysteresis: 5},
+		{name: "above target beyond the margin", cpu: 560, hysteresis: 5, expectedOverutilized: true},
+		{name: "below thresholds, no hysteresis", cpu: 180, expectedLow: true},
+		{name: "below thresholds within the margin", cpu: 180, hysteresis: 5},
+		{name: "below thresholds beyond the margin", cpu: 140, hysteresis: 5, expectedLow: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			nodeUsage := buildNodeUsage(tc.cpu)
+			shifted := applyHysteresis(nodeUsage, tc.hysteresis, configured)
+			if low := isNodeWithLowUtilization(shifted); low != tc.expectedLow {
+				t.Errorf("Expected underutilized to be %v, got %v", tc.expectedLow, low)
+			}
+			if overutilized := isNodeAboveTargetUtilization(shifted); overutilized != tc.expectedOverutilized {
+				t.Errorf("Expected overutilized to be %v, got %v", tc.expectedOverutilized, overutilized)
+			}
+			if nodeUsage.lowResourceThreshold[v1.ResourceCPU].MilliValue() != 200 {
+				t.Errorf("Expected the original thresholds not to be modified")
+			}
+		})
+	}
+}
+
+func TestGroupNodesByDomain(t *testing.T) {
+	withZone := func(zone string) func(node *v1.Node) {
+		return func(node *v1.Node) {
+			node.Labels = map[string]string{"topology.kubernetes.io/zone": zone}
+		}
+	}
+	n1 := test.BuildTestNode("n1", 2000, 3000, 10, withZone("b"))
+	n2 := test.BuildTestNode("n2", 2000, 3000, 10, withZone("a"))
+	n3 := test.BuildTestNode("n3", 2000, 3000, 10, nil)
+	n4 := test.BuildTestNode("n4", 2000, 3000, 10, withZone("b"))
+	nodes := []*v1.Node{n1, n2, n3, n4}
+
+	if domains := groupNodesByDomain(nodes, ""); !reflect.DeepEqual(domains, []nodeDomain{{nodes: nodes}}) {
+		t.Errorf("Expected all nodes in a single domain, got %v", domains)
+	}
+	expected := []nodeDomain{
+		{name: "", nodes: []*v1.Node{n3}},
+		{name: "a", nodes: []*v1.Node{n2}},
+		{name: "b", nodes: []*v1.Node{n1, n4}},
+	}
+	if domains := groupNodesByDomain(nodes, "topology.kubernetes.io/zone"); !reflect.DeepEqual(domains, expected) {
+		t.Errorf("Expected nodes grouped by zone %v, got %v", expected, domains)
+	}
+
+	for domain, valid := range map[string]bool{"topology.kubernetes.io/zone": true, "nodepool": true, "not a label": false} {
+		params := &api.StrategyParameters{NodeResourceUtilizationThresholds: &api.NodeResourceUtilizationThresholds{BalancingDomain: domain}}
+		if err := validateNodeUtilizationParams(params); (err == nil) != valid {
+			t.Errorf("Expected validity of balancingDomain %q to be %v, got %v", domain, valid, err)
+		}
+	}
+
+	for _, tc := range []struct {
+		balancingDomain, topologyKey string
+		valid                        bool
+	}{
+		{topologyKey: "topology.kubernetes.io/zone", valid: true},
+		{topologyKey: "not a label", valid: false},
+		{balancingDomain: "topology.kubernetes.io/zone", topologyKey: "topology.kubernetes.io/zone", valid: true},
+		{balancingDomain: "topology.kubernetes.io/zone", topologyKey: "nodepool", valid: false},
+	} {
+		params := &api.StrategyParameters{NodeResourceUtilizationThresholds: &api.NodeResourceUtilizationThresholds{BalancingDomain: tc.balancingDomain, TopologyKey: tc.topologyKey}}
+		if err := validateNodeUtilizationParams(params); (err == nil) != tc.valid {
+			t.Errorf("Expected validity of balancingDomain %q and topologyKey %q to be %v, got %v", tc.balancingDomain, tc.topologyKey, tc.valid, err)
+		}
+	}
+}
+
+func TestGetNodeUsageParallelism(t *testing.T) {
+	var nodes []*v1.Node
+	var pods []*v1.Pod
+	for i := 0; i < 50; i++ {
+		node := test.BuildTestNode(fmt.Sprintf("n%d", i), 2000, 3000, 10, nil)
+		nodes = append(nodes, node)
+		pods = append(pods, test.BuildTestPod(fmt.Sprintf("p%d", i), int64(10*i), 0, node.Name, nil))
+	}
+	fakeClient := &fake.Clientset{}
+	fakeClient.Fake.AddReactor("list", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		fieldSelector := action.(core.ListAction).GetListRestrictions().Fields
+		if fieldSelector.Matches(fields.Set{"spec.nodeName": "n7"}) {
+			return true, nil, fmt.Errorf("unable to list pods")
+		}
+		podList := &v1.PodList{}
+		for _, pod := range pods {
+			if fieldSelector.Matches(fields.Set{"spec.nodeName": pod.Spec.NodeName}) {
+				podList.Items = append(podList.Items, *pod)
+			}
+		}
+		return true, podList, nil
+	})
+
+	thresholds := api.ResourceThresholds{v1.ResourceCPU: 20}
+	resourceNames := []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory, v1.ResourcePods}
+	sequential := getNodeUsage(context.Background(), fakeClient, nodes, thresholds, thresholds, resourceNames, podResources{}, 1, nil, false)
+	parallel := getNodeUsage(context.Background(), fakeClient, nodes, thresholds, thresholds, resourceNames, podResources{}, 8, nil, false)
+	if len(parallel) != len(nodes)-1 {
+		t.Fatalf("Expected usages of %v nodes, the node whose pods can not be listed being skipped, got %v", len(nodes)-1, len(parallel))
+	}
+	if !reflect.DeepEqual(sequential, parallel) {
+		t.Errorf("Expected node usages computed in parallel to match the sequential ones, in the order of nodes")
+	}
+
+	params := &api.StrategyParameters{NodeResourceUtilizationThresholds: &api.NodeResourceUtilizationThresholds{Parallelism: -1}}
+	if err := validateNodeUtilizationParams(params); err == nil {
+		t.Errorf("Expected a negative parallelism to be refused")
+	}
+}
+
+func TestUsageTrendProjection(t *testing.T) {
+	buildNodeUsage := func(name string, memory int64) NodeUsage {
+		return NodeUsage{
+			node: test.BuildTestNode(name, 1000, 1000, 10, nil),
+			usage: map[v1.ResourceName]*resource.Quantity{
+				v1.ResourceCPU:    resource.NewMilliQuantity(500, resource.DecimalSI),
+				v1.ResourceMemory: resource.NewQuantity(memory, resource.BinarySI),
+			},
+			highResourceThreshold: map[v1.ResourceName]*resource.Quantity{
+				v1.ResourceCPU:    resource.NewMilliQuantity(800, resource.DecimalSI),
+				v1.ResourceMemory: resource.NewQuantity(800, resource.BinarySI),
+			},
+		}
+	}
+	type sample struct {
+		offsetSeconds int
+		memory        int64
+	}
+	uintPtr := func(i uint) *uint { return &i }
+	start := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name             string
+		samples          []sample
+		trend            api.UsageTrend
+		expectedIncrease int64
+		expectedSoon     bool
+	}{
+		{
+			name:             "rising usage crossing target thresholds within the horizon",
+			samples:          []sample{{0, 500}, {60, 550}, {120, 600}},
+			trend:            api.UsageTrend{HorizonSeconds: 300},
+			expectedIncrease: 250,
+			expectedSoon:     true,
+		},
+		{
+			name:             "rising usage below target thresholds at the horizon",
+			samples:          []sample{{0, 500}, {60, 550}, {120, 600}},
+			trend:            api.UsageTrend{HorizonSeconds: 60},
+			expectedIncrease: 50,
+		},
+		{
+			name:    "too few samples",
+			samples: []sample{{0, 500}, {60, 700}},
+			trend:   api.UsageTrend{HorizonSeconds: 300},
+		},
+		{
+			name:             "configured number of samples",
+			samples:          []sample{{0, 500}, {60, 700}},
+			trend:            api.UsageTrend{HorizonSeconds: 300, MinSamples: uintPtr(2)},
+			expectedIncrease: 1000,
+			expectedSoon:     true,
+		},
+		{
+			name:    "flat usage",
+			samples: []sample{{0, 700}, {60, 700}, {120, 700}},
+			trend:   api.UsageTrend{HorizonSeconds: 3600},
+		},
+		{
+			name:    "decreasing usage",
+			samples: []sample{{0, 700}, {60, 650}, {120, 600}},
+			trend:   api.UsageTrend{HorizonSeconds: 300},
+		},
+		{
+			name:    "noisy usage fitted by least squares",
+			samples: []sample{{0, 500}, {60, 600}, {120, 560}, {180, 680}},
+			trend:   api.UsageTrend{HorizonSeconds: 600},
+			// slope of 50 bytes per 60 seconds
+			expectedIncrease: 500,
+			expectedSoon:     true,
+		},
+		{
+			name:    "samples older than the window are dropped",
+			samples: []sample{{0, 950}, {600, 500}, {660, 550}, {720, 600}},
+			trend:   api.UsageTrend{HorizonSeconds: 300, WindowSeconds: uintPtr(300)},
+			// the old sample would make the trend decrease
+			expectedIncrease: 250,
+			expectedSoon:     true,
+		},
+		{
+			name:    "cpu only projected",
+			samples: []sample{{0, 500}, {60, 550}, {120, 600}},
+			trend:   api.UsageTrend{HorizonSeconds: 300, Resources: []v1.ResourceName{v1.ResourceCPU}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tracker := newUsageTrendTracker()
+			var nodeUsage NodeUsage
+			var increases map[string]map[v1.ResourceName]*resource.Quantity
+			for _, s := range tc.samples {
+				nodeUsage = buildNodeUsage("n1", s.memory)
+				increases = tracker.record(start.Add(time.Duration(s.offsetSeconds)*time.Second), []NodeUsage{nodeUsage}, &tc.trend)
+			}
+			var increase int64
+			if quantity, ok := increases["n1"][v1.ResourceMemory]; ok {
+				increase = quantity.Value()
+			}
+			if increase != tc.expectedIncrease {
+				t.Errorf("Expected a projected memory increase of %v, got %v", tc.expectedIncrease, increase)
+			}
+			if isNodeAboveTargetUtilization(nodeUsage) {
+				t.Fatalf("Expected the current usage to be below target thresholds")
+			}
+			if soon := isNodeAboveTargetUtilization(projectUsage(nodeUsage, increases["n1"])); soon != tc.expectedSoon {
+				t.Errorf("Expected soon overutilized to be %v, got %v", tc.expectedSoon, soon)
+			}
+			if nodeUsage.usage[v1.ResourceMemory].Value() != tc.samples[len(tc.samples)-1].memory {
+				t.Errorf("Expected the projection not to modify the node usage")
+			}
+		})
+	}
+}
+
+func TestUsageTrendTrackerExpiry(t *testing.T) {
+	buildNodeUsage := func(name string) NodeUsage {
+		return NodeUsage{
+			node:  test.BuildTestNode(name, 1000, 1000, 10, nil),
+			usage: map[v1.ResourceName]*resource.Quantity{v1.ResourceMemory: resource.NewQuantity(500, resource.BinarySI)},
+		}
+	}
+	tracker := newUsageTrendTracker()
+	trend := &api.UsageTrend{HorizonSeconds: 300}
+	start := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+	tracker.record(start, []NodeUsage{buildNodeUsage("n1"), buildNodeUsage("n2")}, trend)
+	tracker.record(start.Add(time.Duration(DefaultTrendWindowSeconds)*time.Second+time.Minute), []NodeUsage{buildNodeUsage("n1")}, trend)
+	if _, ok := tracker.samples["n2"]; ok {
+		t.Errorf("Expected the samples of the node no longer sampled to expire")
+	}
+	if samples := tracker.samples["n1"][v1.ResourceMemory]; len(samples) != 1 {
+		t.Errorf("Expected 1 sample of n1 within the window, got %v", len(samples))
+	}
+}
+
+func TestValidateTrend(t *testing.T) {
+	uintPtr := func(i uint) *uint { return &i }
+	tests := []struct {
+		name    string
+		params  api.NodeResourceUtilizationThresholds
+		errInfo error
+	}{
+		{
+			name:   "no trend",
+			params: api.NodeResourceUtilizationThresholds{},
+		},
+		{
+			name:   "trend of metrics server usage",
+			params: api.NodeResourceUtilizationThresholds{MetricsUtilization: true, Trend: &api.UsageTrend{HorizonSeconds: 600}},
+		},
+		{
+			name: "trend of prometheus usage",
+			params: api.NodeResourceUtilizationThresholds{
+				Prometheus: &api.PrometheusUtilization{URL: "http://prometheus:9090"},
+				Trend:      &api.UsageTrend{HorizonSeconds: 600, Resources: []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory}},
+			},
+		},
+		{
+			name:    "trend of requests",
+			params:  api.NodeResourceUtilizationThresholds{Trend: &api.UsageTrend{HorizonSeconds: 600}},
+			errInfo: fmt.Errorf("trend requires the actual usage of metricsUtilization or prometheus"),
+		},
+		{
+			name:    "no horizon",
+			params:  api.NodeResourceUtilizationThresholds{MetricsUtilization: true, Trend: &api.UsageTrend{}},
+			errInfo: fmt.Errorf("trend horizonSeconds must be set"),
+		},
+		{
+			name:    "single sample",
+			params:  api.NodeResourceUtilizationThresholds{MetricsUtilization: true, Trend: &api.UsageTrend{HorizonSeconds: 600, MinSamples: uintPtr(1)}},
+			errInfo: fmt.Errorf("trend minSamples must be at least 2"),
+		},
+		{
+			name:    "resource without actual usage",
+			params:  api.NodeResourceUtilizationThresholds{MetricsUtilization: true, Trend: &api.UsageTrend{HorizonSeconds: 600, Resources: []v1.ResourceName{v1.ResourcePods}}},
+			errInfo: fmt.Errorf("trend resource %q is not one of %q, %q", v1.ResourcePods, v1.ResourceCPU, v1.ResourceMemory),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateTrend(&tc.params)
+			if fmt.Sprint(err) != fmt.Sprint(tc.errInfo) {
+				t.Errorf("Expected error %v, got %v", tc.errInfo, err)
+			}
+		})
+	}
+}
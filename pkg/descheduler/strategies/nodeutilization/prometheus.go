@@ -0,0 +1,246 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeutilization
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/utils"
+)
+
+const (
+	// DefaultPrometheusTimeoutSeconds bounds the duration of a query when not configured
+	DefaultPrometheusTimeoutSeconds = 30
+	// DefaultPrometheusWindowSeconds is the lookback window usages are aggregated over when not configured
+	DefaultPrometheusWindowSeconds = 1800
+
+	// PrometheusAggregationAvg aggregates usages over the window with their average, the default
+	PrometheusAggregationAvg = "Avg"
+	// PrometheusAggregationMax aggregates usages over the window with their maximum
+	PrometheusAggregationMax = "Max"
+	// PrometheusAggregationP95 aggregates usages over the window with their 95th percentile
+	PrometheusAggregationP95 = "P95"
+
+	// DefaultPrometheusCPUQuery is the CPU usage of containers, in cores, averaged over the window
+	DefaultPrometheusCPUQuery = `sum by (namespace, pod, container) (rate(container_cpu_usage_seconds_total{container!="",container!="POD"}[$window]))`
+	// DefaultPrometheusMemoryQuery is the memory working set of containers, in bytes, averaged over the window
+	DefaultPrometheusMemoryQuery = `sum by (namespace, pod, container) (avg_over_time(container_memory_working_set_bytes{container!="",container!="POD"}[$window]))`
+
+	// prometheusCPURate is the CPU usage of containers, in cores, over 5 minutes. Its maximum and percentile over the
+	// window are computed with subqueries, the rate over the whole window being its average.
+	prometheusCPURate = `rate(container_cpu_usage_seconds_total{container!="",container!="POD"}[5m])`
+	// prometheusMemoryWorkingSet is the memory working set of containers, in bytes
+	prometheusMemoryWorkingSet = `container_memory_working_set_bytes{container!="",container!="POD"}`
+
+	// prometheusWindowPlaceholder is replaced by the lookback window in queries
+	prometheusWindowPlaceholder = "$window"
+)
+
+// prometheusDefaultQueries are the default CPU and memory queries of each aggregation
+var prometheusDefaultQueries = map[string]map[v1.ResourceName]string{
+	PrometheusAggregationAvg: {
+		v1.ResourceCPU:    DefaultPrometheusCPUQuery,
+		v1.ResourceMemory: DefaultPrometheusMemoryQuery,
+	},
+	PrometheusAggregationMax: {
+		v1.ResourceCPU:    `sum by (namespace, pod, container) (max_over_time(` + prometheusCPURate + `[$window:]))`,
+		v1.ResourceMemory: `sum by (namespace, pod, container) (max_over_time(` + prometheusMemoryWorkingSet + `[$window]))`,
+	},
+	PrometheusAggregationP95: {
+		v1.ResourceCPU:    `sum by (namespace, pod, container) (quantile_over_time(0.95, ` + prometheusCPURate + `[$window:]))`,
+		v1.ResourceMemory: `sum by (namespace, pod, container) (quantile_over_time(0.95, ` + prometheusMemoryWorkingSet + `[$window]))`,
+	},
+}
+
+// prometheusProvider reads the usage of pods, aggregated over a lookback window, from a Prometheus server
+type prometheusProvider struct {
+	client             *http.Client
+	url                string
+	bearerTokenFile    string
+	window             time.Duration
+	queries            map[v1.ResourceName]string
+	excludedContainers []string
+}
+
+// newPrometheusProvider returns a prometheusProvider for the given configuration, unset fields are defaulted
+func newPrometheusProvider(config *api.PrometheusUtilization, excludedContainers []string) *prometheusProvider {
+	timeoutSeconds := uint(DefaultPrometheusTimeoutSeconds)
+	if config.TimeoutSeconds != nil && *config.TimeoutSeconds > 0 {
+		timeoutSeconds = *config.TimeoutSeconds
+	}
+	windowSeconds := uint(DefaultPrometheusWindowSeconds)
+	if config.WindowSeconds != nil && *config.WindowSeconds > 0 {
+		windowSeconds = *config.WindowSeconds
+	}
+	aggregation := config.Aggregation
+	if aggregation == "" {
+		aggregation = PrometheusAggregationAvg
+	}
+	cpuQuery := config.CPUQuery
+	if cpuQuery == "" {
+		cpuQuery = prometheusDefaultQueries[aggregation][v1.ResourceCPU]
+	}
+	memoryQuery := config.MemoryQuery
+	if memoryQuery == "" {
+		memoryQuery = prometheusDefaultQueries[aggregation][v1.ResourceMemory]
+	}
+	return &prometheusProvider{
+		client:             &http.Client{Timeout: time.Duration(timeoutSeconds) * time.Second},
+		url:                strings.TrimSuffix(config.URL, "/"),
+		bearerTokenFile:    config.BearerTokenFile,
+		window:             time.Duration(windowSeconds) * time.Second,
+		queries:            map[v1.ResourceName]string{v1.ResourceCPU: cpuQuery, v1.ResourceMemory: memoryQuery},
+		excludedContainers: excludedContainers,
+	}
+}
+
+func (p *prometheusProvider) podUsage(ctx context.Context) (map[string]v1.ResourceList, error) {
+	usage := map[string]v1.ResourceList{}
+	for _, name := range []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory} {
+		samples, err := p.query(ctx, strings.Replace(p.queries[name], prometheusWindowPlaceholder, fmt.Sprintf("%ds", int64(p.window.Seconds())), -1))
+		if err != nil {
+			return nil, err
+		}
+		values := map[string]float64{}
+		for _, sample := range samples {
+			if sample.Metric["namespace"] == "" || sample.Metric["pod"] == "" {
+				continue
+			}
+			if utils.IsContainerExcluded(sample.Metric["container"], p.excludedContainers) {
+				continue
+			}
+			values[sample.Metric["namespace"]+"/"+sample.Metric["pod"]] += sample.value
+		}
+		for pod, value := range values {
+			if usage[pod] == nil {
+				usage[pod] = v1.ResourceList{}
+			}
+			if name == v1.ResourceCPU {
+				usage[pod][name] = *resource.NewMilliQuantity(int64(math.Round(value*1000)), resource.DecimalSI)
+			} else {
+				usage[pod][name] = *resource.NewQuantity(int64(math.Round(value)), resource.BinarySI)
+			}
+		}
+	}
+	return usage, nil
+}
+
+// prometheusSample is a sample of an instant vector
+type prometheusSample struct {
+	Metric map[string]string `json:"metric"`
+	Value  []interface{}     `json:"value"`
+	value  float64
+}
+
+// prometheusResponse is the response of the instant query API
+type prometheusResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Data   struct {
+		ResultType string             `json:"resultType"`
+		Result     []prometheusSample `json:"result"`
+	} `json:"data"`
+}
+
+// query runs an instant query and returns the samples of the resulting vector
+func (p *prometheusProvider) query(ctx context.Context, query string) ([]prometheusSample, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.url+"/api/v1/query?"+url.Values{"query": []string{query}}.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create prometheus query: %v", err)
+	}
+	if p.bearerTokenFile != "" {
+		token, err := ioutil.ReadFile(p.bearerTokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read bearer token file %q: %v", p.bearerTokenFile, err)
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query %s: %v", p.url, err)
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, 64<<20))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the response of %s: %v", p.url, err)
+	}
+	response := &prometheusResponse{}
+	if err := json.Unmarshal(body, response); err != nil {
+		if resp.StatusCode/100 != 2 {
+			return nil, fmt.Errorf("prometheus %s answered %s", p.url, resp.Status)
+		}
+		return nil, fmt.Errorf("unable to decode the response of %s: %v", p.url, err)
+	}
+	if response.Status != "success" {
+		return nil, fmt.Errorf("prometheus query %q failed: %s", query, response.Error)
+	}
+	if response.Data.ResultType != "vector" {
+		return nil, fmt.Errorf("prometheus query %q returned a %s, not a vector", query, response.Data.ResultType)
+	}
+	samples := response.Data.Result
+	for i := range samples {
+		if len(samples[i].Value) != 2 {
+			return nil, fmt.Errorf("prometheus query %q returned a malformed sample", query)
+		}
+		value, ok := samples[i].Value[1].(string)
+		if !ok {
+			return nil, fmt.Errorf("prometheus query %q returned a malformed sample", query)
+		}
+		if samples[i].value, err = strconv.ParseFloat(value, 64); err != nil {
+			return nil, fmt.Errorf("prometheus query %q returned a malformed sample value %q", query, value)
+		}
+	}
+	return samples, nil
+}
+
+// validatePrometheus checks the Prometheus configuration of the parameters
+func validatePrometheus(params *api.NodeResourceUtilizationThresholds) error {
+	if params.Prometheus == nil {
+		return nil
+	}
+	if params.MetricsUtilization {
+		return fmt.Errorf("prometheus can not be combined with metricsUtilization")
+	}
+	if params.UtilizationMetric == UtilizationMetricLimits {
+		return fmt.Errorf("prometheus can not be combined with utilizationMetric %q", UtilizationMetricLimits)
+	}
+	if params.Prometheus.URL == "" {
+		return fmt.Errorf("prometheus url must be set")
+	}
+	if _, err := url.Parse(params.Prometheus.URL); err != nil {
+		return fmt.Errorf("prometheus url %q is invalid: %v", params.Prometheus.URL, err)
+	}
+	if _, ok := prometheusDefaultQueries[params.Prometheus.Aggregation]; params.Prometheus.Aggregation != "" && !ok {
+		return fmt.Errorf("prometheus aggregation %q is invalid, must be %s, %s or %s", params.Prometheus.Aggregation, PrometheusAggregationAvg, PrometheusAggregationMax, PrometheusAggregationP95)
+	}
+	return nil
+}
@@ -0,0 +1,234 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeutilization
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+func TestPrometheusProvider(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/query" {
+			http.NotFound(w, r)
+			return
+		}
+		if auth := r.Header.Get("Authorization"); auth != "Bearer secret" {
+			t.Errorf("Unexpected authorization header %q", auth)
+		}
+		query := r.URL.Query().Get("query")
+		queries = append(queries, query)
+		switch {
+		case strings.HasPrefix(query, "cpu"):
+			w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[
+				{"metric":{"namespace":"default","pod":"p1","container":"app"},"value":[1,"0.25"]},
+				{"metric":{"namespace":"default","pod":"p1","container":"sidecar"},"value":[1,"0.1"]},
+				{"metric":{"namespace":"default","pod":"p1","container":"istio-proxy"},"value":[1,"0.5"]},
+				{"metric":{"namespace":"default","pod":"p2","container":"app"},"value":[1,"1.5"]},
+				{"metric":{"container":"app"},"value":[1,"2"]}]}}`))
+		case strings.HasPrefix(query, "memory"):
+			w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[
+				{"metric":{"namespace":"default","pod":"p1","container":"app"},"value":[1,"1048576"]}]}}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"status":"error","errorType":"bad_data","error":"parse error"}`))
+		}
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "prometheus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	tokenFile := filepath.Join(dir, "token")
+	if err := ioutil.WriteFile(tokenFile, []byte("secret\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	window := uint(600)
+	provider := newPrometheusProvider(&api.PrometheusUtilization{
+		URL:             server.URL + "/",
+		BearerTokenFile: tokenFile,
+		WindowSeconds:   &window,
+		CPUQuery:        "cpu[$window]",
+		MemoryQuery:     "memory[$window]",
+	}, []string{"istio-proxy"})
+	usage, err := provider.podUsage(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expected := []string{"cpu[600s]", "memory[600s]"}; strings.Join(queries, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected queries %v, got %v", expected, queries)
+	}
+	expected := map[string]v1.ResourceList{
+		"default/p1": {v1.ResourceCPU: resource.MustParse("350m"), v1.ResourceMemory: resource.MustParse("1Mi")},
+		"default/p2": {v1.ResourceCPU: resource.MustParse("1500m")},
+	}
+	if len(usage) != len(expected) {
+		t.Fatalf("Expected usage of %v pods, got %v", len(expected), usage)
+	}
+	for pod, resources := range expected {
+		if len(usage[pod]) != len(resources) {
+			t.Errorf("Expected usage %v of pod %s, got %v", resources, pod, usage[pod])
+			continue
+		}
+		for name, quantity := range resources {
+			if got := usage[pod][name]; got.Cmp(quantity) != 0 {
+				t.Errorf("Expected %s usage %v of pod %s, got %v", name, quantity.String(), pod, got.String())
+			}
+		}
+	}
+
+	provider.queries[v1.ResourceMemory] = "invalid"
+	if _, err := provider.podUsage(context.Background()); err == nil || !strings.Contains(err.Error(), "parse error") {
+		t.Errorf("Expected the query error, got %v", err)
+	}
+}
+
+func TestPrometheusAggregation(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query().Get("query"))
+		w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[]}}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name        string
+		aggregation string
+		cpuQuery    string
+		expected    []string
+	}{
+		{
+			name: "average by default",
+			expected: []string{
+				`sum by (namespace, pod, container) (rate(container_cpu_usage_seconds_total{container!="",container!="POD"}[1800s]))`,
+				`sum by (namespace, pod, container) (avg_over_time(container_memory_working_set_bytes{container!="",container!="POD"}[1800s]))`,
+			},
+		},
+		{
+			name:        "average",
+			aggregation: PrometheusAggregationAvg,
+			expected: []string{
+				`sum by (namespace, pod, container) (rate(container_cpu_usage_seconds_total{container!="",container!="POD"}[1800s]))`,
+				`sum by (namespace, pod, container) (avg_over_time(container_memory_working_set_bytes{container!="",container!="POD"}[1800s]))`,
+			},
+		},
+		{
+			name:        "maximum",
+			aggregation: PrometheusAggregationMax,
+			expected: []string{
+				`sum by (namespace, pod, container) (max_over_time(rate(container_cpu_usage_seconds_total{container!="",container!="POD"}[5m])[1800s:]))`,
+				`sum by (namespace, pod, container) (max_over_time(container_memory_working_set_bytes{container!="",container!="POD"}[1800s]))`,
+			},
+		},
+		{
+			name:        "95th percentile",
+			aggregation: PrometheusAggregationP95,
+			expected: []string{
+				`sum by (namespace, pod, container) (quantile_over_time(0.95, rate(container_cpu_usage_seconds_total{container!="",container!="POD"}[5m])[1800s:]))`,
+				`sum by (namespace, pod, container) (quantile_over_time(0.95, container_memory_working_set_bytes{container!="",container!="POD"}[1800s]))`,
+			},
+		},
+		{
+			name:        "custom query is not aggregated",
+			aggregation: PrometheusAggregationMax,
+			cpuQuery:    "cpu[$window]",
+			expected: []string{
+				"cpu[1800s]",
+				`sum by (namespace, pod, container) (max_over_time(container_memory_working_set_bytes{container!="",container!="POD"}[1800s]))`,
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			queries = nil
+			provider := newPrometheusProvider(&api.PrometheusUtilization{URL: server.URL, Aggregation: tc.aggregation, CPUQuery: tc.cpuQuery}, nil)
+			if _, err := provider.podUsage(context.Background()); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if strings.Join(queries, "\n") != strings.Join(tc.expected, "\n") {
+				t.Errorf("Expected queries %v, got %v", tc.expected, queries)
+			}
+		})
+	}
+}
+
+func TestValidatePrometheus(t *testing.T) {
+	tests := []struct {
+		name   string
+		params *api.NodeResourceUtilizationThresholds
+		errMsg string
+	}{
+		{
+			name:   "no prometheus",
+			params: &api.NodeResourceUtilizationThresholds{},
+		},
+		{
+			name:   "valid prometheus",
+			params: &api.NodeResourceUtilizationThresholds{Prometheus: &api.PrometheusUtilization{URL: "http://prometheus:9090"}},
+		},
+		{
+			name:   "p95 aggregation",
+			params: &api.NodeResourceUtilizationThresholds{Prometheus: &api.PrometheusUtilization{URL: "http://prometheus:9090", Aggregation: PrometheusAggregationP95}},
+		},
+		{
+			name:   "invalid aggregation",
+			params: &api.NodeResourceUtilizationThresholds{Prometheus: &api.PrometheusUtilization{URL: "http://prometheus:9090", Aggregation: "p99"}},
+			errMsg: `prometheus aggregation "p99" is invalid, must be Avg, Max or P95`,
+		},
+		{
+			name:   "missing url",
+			params: &api.NodeResourceUtilizationThresholds{Prometheus: &api.PrometheusUtilization{}},
+			errMsg: "prometheus url must be set",
+		},
+		{
+			name:   "combined with metricsUtilization",
+			params: &api.NodeResourceUtilizationThresholds{MetricsUtilization: true, Prometheus: &api.PrometheusUtilization{URL: "http://prometheus:9090"}},
+			errMsg: "prometheus can not be combined with metricsUtilization",
+		},
+		{
+			name:   "combined with limits",
+			params: &api.NodeResourceUtilizationThresholds{UtilizationMetric: UtilizationMetricLimits, Prometheus: &api.PrometheusUtilization{URL: "http://prometheus:9090"}},
+			errMsg: `prometheus can not be combined with utilizationMetric "Limits"`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validatePrometheus(tc.params)
+			if tc.errMsg == "" && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+			if tc.errMsg != "" && (err == nil || err.Error() != tc.errMsg) {
+				t.Errorf("Expected error %q, got %v", tc.errMsg, err)
+			}
+		})
+	}
+}
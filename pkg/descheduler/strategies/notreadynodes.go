@@ -0,0 +1,190 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategies
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/validation"
+)
+
+// DefaultNotReadySeconds is the time after which pods are evicted from a NotReady node when not configured
+const DefaultNotReadySeconds = 300
+
+// validatedNotReadyNodesStrategyParams contains validated strategy parameters
+type validatedNotReadyNodesStrategyParams struct {
+	validation.ValidatedStrategyParams
+	notReady         time.Duration
+	forceDeleteAfter time.Duration
+	nodeRoles        []string
+}
+
+// RemovePodsFromNotReadyNodes evicts pods from nodes whose Ready condition has not been True for longer than
+// notReadySeconds, and which are not tainted NoExecute by the node lifecycle controller. Such nodes are only
+// seen when the controller is delayed or disabled, their pods are otherwise left running on a node which may be gone.
+// As the kubelet of the node can not confirm the deletion of evicted pods, pods still terminating after
+// forceDeleteAfterSeconds are force deleted when configured.
+// NotReady nodes are not passed to strategies, they are listed by the strategy within the node scope of the evictor.
+func RemovePodsFromNotReadyNodes(
+	ctx context.Context,
+	client clientset.Interface,
+	strategy api.DeschedulerStrategy,
+	nodes []*v1.Node,
+	podEvictor *evictions.PodEvictor,
+) error {
+	strategyParams, err := validateAndParseNotReadyNodesParams(ctx, client, strategy.Params)
+	if err != nil {
+		return validation.NewStrategyError(validation.ErrorReasonInvalidParameters, "invalid RemovePodsFromNotReadyNodes parameters", err)
+	}
+
+	nodeList, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return validation.NewStrategyError(validation.ErrorReasonAPI, "unable to list nodes", err)
+	}
+
+	evictable := podEvictor.Evictable(
+		evictions.WithPriorityThreshold(strategyParams.ThresholdPriority),
+		evictions.WithNodeFit(strategyParams.NodeFit),
+		evictions.WithLabelSelector(strategyParams.LabelSelector),
+	)
+
+	now := podEvictor.Now()
+	for i := range nodeList.Items {
+		node := &nodeList.Items[i]
+		if !podEvictor.InNodeScope(node) {
+			continue
+		}
+		if len(strategyParams.nodeRoles) > 0 && !nodeutil.NodeHasAnyRole(node, strategyParams.nodeRoles) {
+			continue
+		}
+		notReadySince, ok := nodeNotReadySince(node)
+		if !ok || now.Sub(notReadySince) < strategyParams.notReady {
+			continue
+		}
+		if taint := nodeLifecycleTaint(node); taint != "" {
+			klog.V(2).InfoS("Skipping NotReady node tainted by the node lifecycle controller", "node", klog.KObj(node), "taint", taint)
+			continue
+		}
+		klog.V(1).InfoS("Processing NotReady node", "node", klog.KObj(node), "notReadySince", notReadySince)
+
+		pods, err := podutil.ListPodsOnANode(
+			ctx,
+			client,
+			node,
+			podutil.WithNamespaces(strategyParams.IncludedNamespaces.UnsortedList()),
+			podutil.WithoutNamespaces(strategyParams.ExcludedNamespaces.UnsortedList()),
+		)
+		if err != nil {
+			klog.ErrorS(err, "Error listing pods on node", "node", klog.KObj(node))
+			continue
+		}
+		details := "notReadySince=" + notReadySince.UTC().Format(time.RFC3339)
+		for _, pod := range pods {
+			if !evictable.IsEvictable(pod) {
+				continue
+			}
+			if pod.DeletionTimestamp != nil {
+				// the pod was already evicted, only the kubelet of the node could complete its deletion
+				if strategyParams.forceDeleteAfter == 0 || now.Sub(pod.DeletionTimestamp.Time) < strategyParams.forceDeleteAfter {
+					continue
+				}
+				if _, err := podEvictor.ForceDeletePod(ctx, pod, node, evictions.ReasonRemovePodsFromNotReadyNodes, details); err != nil {
+					klog.ErrorS(err, "Error force deleting pod", "pod", klog.KObj(pod))
+					break
+				}
+				continue
+			}
+			if _, err := podEvictor.EvictPod(ctx, pod, node, evictions.ReasonRemovePodsFromNotReadyNodes, details); err != nil {
+				klog.ErrorS(err, "Error evicting pod", "pod", klog.KObj(pod))
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// nodeNotReadySince returns since when the Ready condition of the node is not True,
+// false when the node is ready or does not report the condition
+func nodeNotReadySince(node *v1.Node) (time.Time, bool) {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == v1.NodeReady {
+			if condition.Status == v1.ConditionTrue {
+				return time.Time{}, false
+			}
+			return condition.LastTransitionTime.Time, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// nodeLifecycleTaint returns the key of the NoExecute taint the node lifecycle controller sets on NotReady nodes,
+// whose pods are evicted by the controller according to their tolerations
+func nodeLifecycleTaint(node *v1.Node) string {
+	for _, taint := range node.Spec.Taints {
+		if taint.Effect == v1.TaintEffectNoExecute && (taint.Key == v1.TaintNodeNotReady || taint.Key == v1.TaintNodeUnreachable) {
+			return taint.Key
+		}
+	}
+	return ""
+}
+
+func validateAndParseNotReadyNodesParams(
+	ctx context.Context,
+	client clientset.Interface,
+	params *api.StrategyParameters,
+) (*validatedNotReadyNodesStrategyParams, error) {
+	notReadySeconds := uint(DefaultNotReadySeconds)
+	var forceDeleteAfterSeconds uint
+	var nodeRoles []string
+	if params != nil {
+		if params.NotReadyNodes != nil {
+			if params.NotReadyNodes.NotReadySeconds != nil {
+				notReadySeconds = *params.NotReadyNodes.NotReadySeconds
+			}
+			if params.NotReadyNodes.ForceDeleteAfterSeconds != nil {
+				forceDeleteAfterSeconds = *params.NotReadyNodes.ForceDeleteAfterSeconds
+			}
+		}
+		nodeRoles = params.NodeRoles
+	}
+	if notReadySeconds == 0 {
+		return nil, fmt.Errorf("notReadySeconds must be greater than 0")
+	}
+
+	strategyParams, err := validation.ValidateAndParseStrategyParams(ctx, client, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &validatedNotReadyNodesStrategyParams{
+		ValidatedStrategyParams: *strategyParams,
+		notReady:                time.Duration(notReadySeconds) * time.Second,
+		forceDeleteAfter:        time.Duration(forceDeleteAfterSeconds) * time.Second,
+		nodeRoles:               nodeRoles,
+	}, nil
+}
@@ -0,0 +1,214 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategies
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestRemovePodsFromNotReadyNodes(t *testing.T) {
+	ctx := context.Background()
+
+	withReady := func(status v1.ConditionStatus, since time.Duration) func(node *v1.Node) {
+		return func(node *v1.Node) {
+			node.Status.Conditions = []v1.NodeCondition{{
+				Type:               v1.NodeReady,
+				Status:             status,
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-since)),
+			}}
+		}
+	}
+	readyNode := test.BuildTestNode("ready", 2000, 3000, 10, withReady(v1.ConditionTrue, time.Hour))
+	notReadyNode := test.BuildTestNode("not-ready", 2000, 3000, 10, withReady(v1.ConditionUnknown, time.Hour))
+	recentlyNotReadyNode := test.BuildTestNode("recently-not-ready", 2000, 3000, 10, withReady(v1.ConditionFalse, time.Minute))
+	taintedNode := test.BuildTestNode("tainted", 2000, 3000, 10, func(node *v1.Node) {
+		withReady(v1.ConditionUnknown, time.Hour)(node)
+		node.Spec.Taints = []v1.Taint{{Key: v1.TaintNodeUnreachable, Effect: v1.TaintEffectNoExecute}}
+	})
+	otherReadyNode := test.BuildTestNode("other-ready", 2000, 3000, 10, withReady(v1.ConditionTrue, time.Hour))
+
+	buildPod := func(name, nodeName string, terminatingFor time.Duration) *v1.Pod {
+		return test.BuildTestPod(name, 100, 0, nodeName, func(pod *v1.Pod) {
+			test.SetRSOwnerRef(pod)
+			if terminatingFor > 0 {
+				deletionTimestamp := metav1.NewTime(time.Now().Add(-terminatingFor))
+				pod.DeletionTimestamp = &deletionTimestamp
+			}
+		})
+	}
+
+	forceDeleteAfterSeconds := uint(600)
+	zero := uint(0)
+	tests := []struct {
+		description          string
+		nodes                []*v1.Node
+		pods                 []*v1.Pod
+		params               *api.StrategyParameters
+		nodeScope            func(node *v1.Node) bool
+		expectedEvicted      []string
+		expectedForceDeleted []string
+	}{
+		{
+			description: "Pods on a node NotReady for longer than notReadySeconds are evicted",
+			nodes:       []*v1.Node{readyNode, notReadyNode, otherReadyNode},
+			pods: []*v1.Pod{
+				buildPod("p1", readyNode.Name, 0),
+				buildPod("p2", notReadyNode.Name, 0),
+				buildPod("p3", notReadyNode.Name, 0),
+			},
+			expectedEvicted: []string{"p2", "p3"},
+		},
+		{
+			description: "Pods on a node recently NotReady are kept",
+			nodes:       []*v1.Node{readyNode, recentlyNotReadyNode, otherReadyNode},
+			pods: []*v1.Pod{
+				buildPod("p1", recentlyNotReadyNode.Name, 0),
+			},
+		},
+		{
+			description: "Pods on a node tainted by the node lifecycle controller are kept",
+			nodes:       []*v1.Node{readyNode, taintedNode, otherReadyNode},
+			pods: []*v1.Pod{
+				buildPod("p1", taintedNode.Name, 0),
+			},
+		},
+		{
+			description: "Terminating pods are not evicted again nor force deleted by default",
+			nodes:       []*v1.Node{readyNode, notReadyNode, otherReadyNode},
+			pods: []*v1.Pod{
+				buildPod("p1", notReadyNode.Name, time.Hour),
+			},
+		},
+		{
+			description: "Pods terminating for longer than forceDeleteAfterSeconds are force deleted",
+			nodes:       []*v1.Node{readyNode, notReadyNode, otherReadyNode},
+			pods: []*v1.Pod{
+				buildPod("p1", notReadyNode.Name, time.Hour),
+				buildPod("p2", notReadyNode.Name, time.Minute),
+				buildPod("p3", notReadyNode.Name, 0),
+			},
+			params:               &api.StrategyParameters{NotReadyNodes: &api.NotReadyNodes{ForceDeleteAfterSeconds: &forceDeleteAfterSeconds}},
+			expectedEvicted:      []string{"p3"},
+			expectedForceDeleted: []string{"p1"},
+		},
+		{
+			description: "Terminating pods which are not evictable are not force deleted",
+			nodes:       []*v1.Node{readyNode, notReadyNode, otherReadyNode},
+			pods: []*v1.Pod{
+				buildPod("p1", notReadyNode.Name, time.Hour),
+				test.BuildTestPod("p2", 100, 0, notReadyNode.Name, func(pod *v1.Pod) {
+					test.SetDSOwnerRef(pod)
+					deletionTimestamp := metav1.NewTime(time.Now().Add(-time.Hour))
+					pod.DeletionTimestamp = &deletionTimestamp
+				}),
+			},
+			params:               &api.StrategyParameters{NotReadyNodes: &api.NotReadyNodes{ForceDeleteAfterSeconds: &forceDeleteAfterSeconds}},
+			expectedForceDeleted: []string{"p1"},
+		},
+		{
+			description: "Pods on a NotReady node out of the node scope are kept",
+			nodes:       []*v1.Node{readyNode, notReadyNode, otherReadyNode},
+			pods: []*v1.Pod{
+				buildPod("p1", notReadyNode.Name, 0),
+			},
+			nodeScope: func(node *v1.Node) bool {
+				return node.Name != notReadyNode.Name
+			},
+		},
+		{
+			description: "A notReadySeconds of 0 is refused",
+			nodes:       []*v1.Node{readyNode, notReadyNode, otherReadyNode},
+			pods: []*v1.Pod{
+				buildPod("p1", notReadyNode.Name, 0),
+			},
+			params: &api.StrategyParameters{NotReadyNodes: &api.NotReadyNodes{NotReadySeconds: &zero}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			fakeClient := &fake.Clientset{}
+			fakeClient.Fake.AddReactor("list", "nodes", func(action core.Action) (bool, runtime.Object, error) {
+				nodeList := &v1.NodeList{}
+				for _, node := range tc.nodes {
+					nodeList.Items = append(nodeList.Items, *node)
+				}
+				return true, nodeList, nil
+			})
+			fakeClient.Fake.AddReactor("list", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				fieldSelector := action.(core.ListAction).GetListRestrictions().Fields
+				podList := &v1.PodList{}
+				for _, pod := range tc.pods {
+					if fieldSelector.Matches(fields.Set{"spec.nodeName": pod.Spec.NodeName, "status.phase": string(pod.Status.Phase)}) {
+						podList.Items = append(podList.Items, *pod)
+					}
+				}
+				return true, podList, nil
+			})
+			var evicted, forceDeleted []string
+			fakeClient.Fake.AddReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() == "eviction" {
+					evicted = append(evicted, action.(core.CreateAction).GetObject().(metav1.Object).GetName())
+				}
+				return true, nil, nil
+			})
+			fakeClient.Fake.AddReactor("delete", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				forceDeleted = append(forceDeleted, action.(core.DeleteAction).GetName())
+				return true, nil, nil
+			})
+
+			readyNodes := []*v1.Node{readyNode, otherReadyNode}
+			podEvictor := evictions.NewPodEvictor(
+				fakeClient,
+				policyv1.SchemeGroupVersion.String(),
+				false,
+				0,
+				0,
+				readyNodes,
+				false,
+				false,
+				false,
+			)
+
+			podEvictor.SetNodeScope(tc.nodeScope)
+
+			strategy := api.DeschedulerStrategy{Enabled: true, Params: tc.params}
+			RemovePodsFromNotReadyNodes(ctx, fakeClient, strategy, readyNodes, podEvictor)
+			if !reflect.DeepEqual(evicted, tc.expectedEvicted) {
+				t.Errorf("Expected %v pods to be evicted, got %v", tc.expectedEvicted, evicted)
+			}
+			if !reflect.DeepEqual(forceDeleted, tc.expectedForceDeleted) {
+				t.Errorf("Expected %v pods to be force deleted, got %v", tc.expectedForceDeleted, forceDeleted)
+			}
+		})
+	}
+}
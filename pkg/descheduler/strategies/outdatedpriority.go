@@ -0,0 +1,249 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategies
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/validation"
+	"sigs.k8s.io/descheduler/pkg/utils"
+)
+
+// priorityClasses resolves the current priority and preemption policy of pods from their PriorityClass.
+// Pods get the value and preemption policy of their class when they are admitted, so they keep them when
+// the class is updated afterwards. The scheduler only looks at the admitted ones when preempting pods.
+type priorityClasses map[string]*schedulingv1.PriorityClass
+
+// priority returns the current priority of the pod, the admitted one when its class no longer exists
+func (c priorityClasses) priority(pod *v1.Pod) int32 {
+	if class, ok := c[pod.Spec.PriorityClassName]; ok && pod.Spec.PriorityClassName != "" {
+		return class.Value
+	}
+	return admittedPriority(pod)
+}
+
+// canPreempt checks if the pending pod is allowed to preempt pods by the current preemption policy of its class
+func (c priorityClasses) canPreempt(pod *v1.Pod) bool {
+	if class, ok := c[pod.Spec.PriorityClassName]; ok && pod.Spec.PriorityClassName != "" {
+		return class.PreemptionPolicy == nil || *class.PreemptionPolicy != v1.PreemptNever
+	}
+	return pod.Spec.PreemptionPolicy == nil || *pod.Spec.PreemptionPolicy != v1.PreemptNever
+}
+
+// admittedPriority returns the priority the pod was admitted with
+func admittedPriority(pod *v1.Pod) int32 {
+	if pod.Spec.Priority != nil {
+		return *pod.Spec.Priority
+	}
+	return 0
+}
+
+// schedulerCanPreempt checks if the scheduler preempts the victim for the pending pod, based on the
+// priorities and preemption policy both pods were admitted with
+func schedulerCanPreempt(pending, victim *v1.Pod) bool {
+	if pending.Spec.PreemptionPolicy != nil && *pending.Spec.PreemptionPolicy == v1.PreemptNever {
+		return false
+	}
+	return admittedPriority(victim) < admittedPriority(pending)
+}
+
+// RemovePodsWithOutdatedPriority evicts pods preventing pending pods from being scheduled because of a
+// PriorityClass updated after they were admitted. When the value of a class is lowered, its running pods
+// keep the former value, and when the preemption policy of a class changes to allow preemption, its
+// pending pods keep the former policy, so the scheduler does not preempt pods it would preempt by the
+// current classes. For each pending pod, from the highest current priority, the strategy looks for a node
+// the pod matches where evicting such pods, lowest current priority first, frees enough resources.
+func RemovePodsWithOutdatedPriority(
+	ctx context.Context,
+	client clientset.Interface,
+	strategy api.DeschedulerStrategy,
+	nodes []*v1.Node,
+	podEvictor *evictions.PodEvictor,
+) error {
+	strategyParams, err := validation.ValidateAndParseStrategyParams(ctx, client, strategy.Params)
+	if err != nil {
+		return validation.NewStrategyError(validation.ErrorReasonInvalidParameters, "invalid RemovePodsWithOutdatedPriority parameters", err)
+	}
+
+	classList, err := client.SchedulingV1().PriorityClasses().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return validation.NewStrategyError(validation.ErrorReasonAPI, "unable to list priority classes", err)
+	}
+	classes := make(priorityClasses, len(classList.Items))
+	for i := range classList.Items {
+		classes[classList.Items[i].Name] = &classList.Items[i]
+	}
+
+	pendingPodList, err := client.CoreV1().Pods(v1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.SelectorFromSet(fields.Set{"spec.nodeName": "", "status.phase": string(v1.PodPending)}).String(),
+	})
+	if err != nil {
+		return validation.NewStrategyError(validation.ErrorReasonAPI, "unable to list pending pods", err)
+	}
+	var pendingPods []*v1.Pod
+	for i := range pendingPodList.Items {
+		pod := &pendingPodList.Items[i]
+		if classes.canPreempt(pod) {
+			pendingPods = append(pendingPods, pod)
+		}
+	}
+	if len(pendingPods) == 0 {
+		klog.V(1).InfoS("No pending pod can preempt other pods, nothing to do here")
+		return nil
+	}
+	sort.SliceStable(pendingPods, func(i, j int) bool {
+		return classes.priority(pendingPods[i]) > classes.priority(pendingPods[j])
+	})
+
+	evictable := podEvictor.Evictable(
+		evictions.WithPriorityThreshold(strategyParams.ThresholdPriority),
+		evictions.WithNodeFit(strategyParams.NodeFit),
+		evictions.WithLabelSelector(strategyParams.LabelSelector),
+	)
+
+	podsByNode := make(map[*v1.Node][]*v1.Pod, len(nodes))
+	for _, node := range nodes {
+		pods, err := podutil.ListPodsOnANode(ctx, client, node)
+		if err != nil {
+			klog.ErrorS(err, "Error listing pods on node", "node", klog.KObj(node))
+			continue
+		}
+		podsByNode[node] = pods
+	}
+
+	evicted := map[*v1.Pod]bool{}
+	for _, pending := range pendingPods {
+		for _, node := range nodes {
+			pods, ok := podsByNode[node]
+			if !ok || !podMatchesNode(pending, node) {
+				continue
+			}
+			var remaining, victims []*v1.Pod
+			for _, pod := range pods {
+				if evicted[pod] {
+					continue
+				}
+				remaining = append(remaining, pod)
+				if classes.priority(pod) < classes.priority(pending) && !schedulerCanPreempt(pending, pod) &&
+					(len(strategyParams.IncludedNamespaces) == 0 || strategyParams.IncludedNamespaces.Has(pod.Namespace)) &&
+					(len(strategyParams.ExcludedNamespaces) == 0 || !strategyParams.ExcludedNamespaces.Has(pod.Namespace)) &&
+					evictable.IsEvictable(pod) {
+					victims = append(victims, pod)
+				}
+			}
+			if podFitsNodeWithPods(pending, node, remaining) {
+				// the pod is pending for another reason, or the scheduler already preempts pods for it
+				break
+			}
+			sort.SliceStable(victims, func(i, j int) bool {
+				return classes.priority(victims[i]) < classes.priority(victims[j])
+			})
+			toEvict := victimsFreeingResources(pending, node, remaining, victims)
+			if toEvict == nil {
+				continue
+			}
+
+			klog.V(1).InfoS("Evicting pods with an outdated priority to schedule a pending pod", "pendingPod", klog.KObj(pending), "node", klog.KObj(node), "pods", len(toEvict))
+			for _, pod := range toEvict {
+				success, err := podEvictor.EvictPod(ctx, pod, node, evictions.ReasonRemovePodsWithOutdatedPriority,
+					fmt.Sprintf("priority %d admitted as %d, preempted for %s", classes.priority(pod), admittedPriority(pod), klog.KObj(pending)))
+				if err != nil {
+					klog.ErrorS(err, "Error evicting pod", "pod", klog.KObj(pod))
+					break
+				}
+				if success {
+					evicted[pod] = true
+				}
+			}
+			break
+		}
+	}
+	return nil
+}
+
+// podMatchesNode checks if the node is schedulable and matches the node selector, affinity and tolerations of the pod
+func podMatchesNode(pod *v1.Pod, node *v1.Node) bool {
+	if node.Spec.Unschedulable {
+		return false
+	}
+	if ok, err := utils.PodMatchNodeSelector(pod, node); err != nil || !ok {
+		return false
+	}
+	return utils.TolerationsTolerateTaintsWithFilter(pod.Spec.Tolerations, node.Spec.Taints, func(taint *v1.Taint) bool {
+		return taint.Effect == v1.TaintEffectNoSchedule || taint.Effect == v1.TaintEffectNoExecute
+	})
+}
+
+// podFitsNodeWithPods checks if the requests of the pod fit within the node allocatable left by the given pods
+func podFitsNodeWithPods(pod *v1.Pod, node *v1.Node, pods []*v1.Pod) bool {
+	allocatable := node.Status.Capacity
+	if len(node.Status.Allocatable) > 0 {
+		allocatable = node.Status.Allocatable
+	}
+	if maxPods, ok := allocatable[v1.ResourcePods]; ok && int64(len(pods)+1) > maxPods.Value() {
+		return false
+	}
+	requests, _ := utils.PodRequestsAndLimits(pod)
+	for name, request := range requests {
+		available, ok := allocatable[name]
+		if !ok {
+			if request.IsZero() {
+				continue
+			}
+			return false
+		}
+		available = available.DeepCopy()
+		for _, other := range pods {
+			available.Sub(utils.GetResourceRequestQuantity(other, name))
+		}
+		if request.Cmp(available) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// victimsFreeingResources returns the first victims whose eviction lets the pending pod fit on the node,
+// nil when evicting all of them is not enough
+func victimsFreeingResources(pending *v1.Pod, node *v1.Node, pods, victims []*v1.Pod) []*v1.Pod {
+	isVictim := make(map[*v1.Pod]bool, len(victims))
+	for i, victim := range victims {
+		isVictim[victim] = true
+		var remaining []*v1.Pod
+		for _, pod := range pods {
+			if !isVictim[pod] {
+				remaining = append(remaining, pod)
+			}
+		}
+		if podFitsNodeWithPods(pending, node, remaining) {
+			return victims[:i+1]
+		}
+	}
+	return nil
+}
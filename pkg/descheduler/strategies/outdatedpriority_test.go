@@ -0,0 +1,176 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategies
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestRemovePodsWithOutdatedPriority(t *testing.T) {
+	ctx := context.Background()
+
+	preemptNever := v1.PreemptNever
+	preemptLowerPriority := v1.PreemptLowerPriority
+	buildClass := func(name string, value int32, preemptionPolicy *v1.PreemptionPolicy) *schedulingv1.PriorityClass {
+		return &schedulingv1.PriorityClass{ObjectMeta: metav1.ObjectMeta{Name: name}, Value: value, PreemptionPolicy: preemptionPolicy}
+	}
+	buildPod := func(name, nodeName string, cpu int64, className string, priority int32, apply func(pod *v1.Pod)) *v1.Pod {
+		return test.BuildTestPod(name, cpu, 0, nodeName, func(pod *v1.Pod) {
+			test.SetRSOwnerRef(pod)
+			pod.Spec.PriorityClassName = className
+			test.SetPodPriority(pod, priority)
+			pod.Status.Phase = v1.PodRunning
+			if nodeName == "" {
+				pod.Status.Phase = v1.PodPending
+			}
+			if apply != nil {
+				apply(pod)
+			}
+		})
+	}
+	admittedNever := func(pod *v1.Pod) {
+		pod.Spec.PreemptionPolicy = &preemptNever
+	}
+
+	node1 := test.BuildTestNode("n1", 1000, 3000, 10, nil)
+	node2 := test.BuildTestNode("n2", 1000, 3000, 10, test.SetNodeUnschedulable)
+
+	tests := []struct {
+		description     string
+		classes         []*schedulingv1.PriorityClass
+		pods            []*v1.Pod
+		expectedEvicted []string
+	}{
+		{
+			description: "Pods of a class whose value was lowered are evicted for a higher priority pending pod",
+			classes:     []*schedulingv1.PriorityClass{buildClass("batch", 100, nil), buildClass("high", 500, nil)},
+			pods: []*v1.Pod{
+				buildPod("p1", node1.Name, 500, "batch", 1000, nil),
+				buildPod("p2", node1.Name, 500, "batch", 1000, nil),
+				buildPod("p3", node2.Name, 500, "batch", 1000, nil),
+				buildPod("pending", "", 400, "high", 500, nil),
+			},
+			expectedEvicted: []string{"p1"},
+		},
+		{
+			description: "Pods are evicted for a pending pod admitted before its class allowed preemption",
+			classes:     []*schedulingv1.PriorityClass{buildClass("low", 100, nil), buildClass("high", 500, &preemptLowerPriority)},
+			pods: []*v1.Pod{
+				buildPod("p1", node1.Name, 400, "low", 100, nil),
+				buildPod("p2", node1.Name, 400, "low", 100, nil),
+				buildPod("pending", "", 700, "high", 500, admittedNever),
+			},
+			expectedEvicted: []string{"p1", "p2"},
+		},
+		{
+			description: "Pods are not evicted for pending pods whose class does not allow preemption",
+			classes:     []*schedulingv1.PriorityClass{buildClass("batch", 100, nil), buildClass("high", 500, &preemptNever)},
+			pods: []*v1.Pod{
+				buildPod("p1", node1.Name, 500, "batch", 1000, nil),
+				buildPod("p2", node1.Name, 500, "batch", 1000, nil),
+				buildPod("pending", "", 400, "high", 500, admittedNever),
+			},
+		},
+		{
+			description: "Pods the scheduler preempts by their admitted priority are not evicted",
+			classes:     []*schedulingv1.PriorityClass{buildClass("low", 100, nil), buildClass("high", 500, nil)},
+			pods: []*v1.Pod{
+				buildPod("p1", node1.Name, 500, "low", 100, nil),
+				buildPod("p2", node1.Name, 500, "low", 100, nil),
+				buildPod("pending", "", 400, "high", 500, nil),
+			},
+		},
+		{
+			description: "Pods are not evicted when evicting all of them does not free enough resources",
+			classes:     []*schedulingv1.PriorityClass{buildClass("batch", 100, nil), buildClass("high", 500, nil)},
+			pods: []*v1.Pod{
+				buildPod("p1", node1.Name, 300, "batch", 1000, nil),
+				buildPod("p2", node1.Name, 500, "system", 1000, nil),
+				buildPod("pending", "", 800, "high", 500, nil),
+			},
+		},
+		{
+			description: "Pods are not evicted when the pending pod already fits",
+			classes:     []*schedulingv1.PriorityClass{buildClass("batch", 100, nil), buildClass("high", 500, nil)},
+			pods: []*v1.Pod{
+				buildPod("p1", node1.Name, 500, "batch", 1000, nil),
+				buildPod("pending", "", 400, "high", 500, nil),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			var objects []runtime.Object
+			for _, class := range tc.classes {
+				objects = append(objects, class)
+			}
+			fakeClient := fake.NewSimpleClientset(objects...)
+			fakeClient.PrependReactor("list", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				fieldSelector := action.(core.ListAction).GetListRestrictions().Fields
+				podList := &v1.PodList{}
+				for _, pod := range tc.pods {
+					if fieldSelector.Matches(fields.Set{"spec.nodeName": pod.Spec.NodeName, "status.phase": string(pod.Status.Phase)}) {
+						podList.Items = append(podList.Items, *pod)
+					}
+				}
+				return true, podList, nil
+			})
+			var evicted []string
+			fakeClient.PrependReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() == "eviction" {
+					evicted = append(evicted, action.(core.CreateAction).GetObject().(metav1.Object).GetName())
+				}
+				return true, nil, nil
+			})
+
+			nodes := []*v1.Node{node1, node2}
+			podEvictor := evictions.NewPodEvictor(
+				fakeClient,
+				policyv1.SchemeGroupVersion.String(),
+				false,
+				0,
+				0,
+				nodes,
+				false,
+				false,
+				false,
+			)
+
+			strategy := api.DeschedulerStrategy{Enabled: true}
+			RemovePodsWithOutdatedPriority(ctx, fakeClient, strategy, nodes, podEvictor)
+			if !reflect.DeepEqual(evicted, tc.expectedEvicted) {
+				t.Errorf("Expected %v pods to be evicted, got %v", tc.expectedEvicted, evicted)
+			}
+		})
+	}
+}
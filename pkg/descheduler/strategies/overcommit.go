@@ -0,0 +1,216 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategies
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/validation"
+	"sigs.k8s.io/descheduler/pkg/utils"
+)
+
+// validatedLimitsOvercommitStrategyParams contains validated strategy parameters
+type validatedLimitsOvercommitStrategyParams struct {
+	validation.ValidatedStrategyParams
+	thresholds api.ResourceThresholds
+}
+
+// RemovePodsFromOvercommittedNodes evicts pods from nodes whose sum of pod limits exceeds the
+// configured percentage of the node allocatable. Pods with the largest limits to requests ratio
+// are evicted first, until the limits of the node are back under the thresholds. This reduces
+// the risk of noisy neighbors bursting on the same node.
+func RemovePodsFromOvercommittedNodes(
+	ctx context.Context,
+	client clientset.Interface,
+	strategy api.DeschedulerStrategy,
+	nodes []*v1.Node,
+	podEvictor *evictions.PodEvictor,
+) error {
+	strategyParams, err := validateAndParseLimitsOvercommitParams(ctx, client, strategy.Params)
+	if err != nil {
+		return validation.NewStrategyError(validation.ErrorReasonInvalidParameters, "invalid RemovePodsFromOvercommittedNodes parameters", err)
+	}
+
+	evictable := podEvictor.Evictable(
+		evictions.WithPriorityThreshold(strategyParams.ThresholdPriority),
+		evictions.WithNodeFit(strategyParams.NodeFit),
+		evictions.WithLabelSelector(strategyParams.LabelSelector),
+	)
+
+	for _, node := range nodes {
+		if !podEvictor.VisitNode(node) {
+			continue
+		}
+		pods, err := podutil.ListPodsOnANode(ctx, client, node)
+		if err != nil {
+			klog.ErrorS(err, "Error listing pods on node", "node", klog.KObj(node))
+			continue
+		}
+
+		excess := limitsExcess(node, pods, strategyParams.thresholds)
+		if len(excess) == 0 {
+			continue
+		}
+		klog.V(1).InfoS("Node limits are overcommitted", "node", klog.KObj(node), "excess", excess)
+
+		var candidates []*v1.Pod
+		for _, pod := range pods {
+			if (len(strategyParams.IncludedNamespaces) > 0 && !strategyParams.IncludedNamespaces.Has(pod.Namespace)) ||
+				(len(strategyParams.ExcludedNamespaces) > 0 && strategyParams.ExcludedNamespaces.Has(pod.Namespace)) {
+				continue
+			}
+			if evictable.IsEvictable(pod) {
+				candidates = append(candidates, pod)
+			}
+		}
+		sortPodsByLimitsToRequestsRatio(candidates, excess)
+
+		for _, pod := range candidates {
+			if len(excess) == 0 {
+				break
+			}
+			_, limits := utils.PodRequestsAndLimits(pod)
+			if !hasLimits(limits, excess) {
+				// evicting the pod does not lower the overcommitted limits
+				continue
+			}
+			success, err := podEvictor.EvictPod(ctx, pod, node, evictions.ReasonRemovePodsFromOvercommittedNodes)
+			if err != nil {
+				klog.ErrorS(err, "Error evicting pod", "pod", klog.KObj(pod))
+				break
+			}
+			if !success {
+				continue
+			}
+			for name, value := range excess {
+				limit := limits[name]
+				if value -= limit.MilliValue(); value > 0 {
+					excess[name] = value
+				} else {
+					delete(excess, name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// limitsExcess returns, in milli units, by how much the sum of the limits of the pods exceeds the
+// threshold of each resource. Resources under their threshold are not returned.
+func limitsExcess(node *v1.Node, pods []*v1.Pod, thresholds api.ResourceThresholds) map[v1.ResourceName]int64 {
+	allocatable := node.Status.Capacity
+	if len(node.Status.Allocatable) > 0 {
+		allocatable = node.Status.Allocatable
+	}
+	totalLimits := map[v1.ResourceName]int64{}
+	for _, pod := range pods {
+		_, limits := utils.PodRequestsAndLimits(pod)
+		for name := range thresholds {
+			limit := limits[name]
+			totalLimits[name] += limit.MilliValue()
+		}
+	}
+
+	excess := map[v1.ResourceName]int64{}
+	for name, threshold := range thresholds {
+		capacity := allocatable[name]
+		maxLimits := int64(float64(threshold) * float64(capacity.MilliValue()) * 0.01)
+		if totalLimits[name] > maxLimits {
+			excess[name] = totalLimits[name] - maxLimits
+		}
+	}
+	return excess
+}
+
+// limitsToRequestsRatio returns the largest limits to requests ratio of the pod across the given
+// resources. A limit without a request counts as an infinite ratio.
+func limitsToRequestsRatio(pod *v1.Pod, resourceNames map[v1.ResourceName]int64) float64 {
+	requests, limits := utils.PodRequestsAndLimits(pod)
+	ratio := 0.0
+	for name := range resourceNames {
+		limit, request := limits[name], requests[name]
+		if limit.IsZero() {
+			continue
+		}
+		if request.IsZero() {
+			return math.Inf(1)
+		}
+		ratio = math.Max(ratio, float64(limit.MilliValue())/float64(request.MilliValue()))
+	}
+	return ratio
+}
+
+// sortPodsByLimitsToRequestsRatio sorts pods from the largest to the smallest limits to requests
+// ratio of the overcommitted resources, pods with the same ratio are sorted by priority, lowest first
+func sortPodsByLimitsToRequestsRatio(pods []*v1.Pod, excess map[v1.ResourceName]int64) {
+	podutil.SortPodsBasedOnPriorityLowToHigh(pods)
+	ratios := make(map[*v1.Pod]float64, len(pods))
+	for _, pod := range pods {
+		ratios[pod] = limitsToRequestsRatio(pod, excess)
+	}
+	sort.SliceStable(pods, func(i, j int) bool {
+		return ratios[pods[i]] > ratios[pods[j]]
+	})
+}
+
+// hasLimits checks whether any of the overcommitted resources is limited
+func hasLimits(limits v1.ResourceList, excess map[v1.ResourceName]int64) bool {
+	for name := range excess {
+		if limit := limits[name]; !limit.IsZero() {
+			return true
+		}
+	}
+	return false
+}
+
+func validateAndParseLimitsOvercommitParams(
+	ctx context.Context,
+	client clientset.Interface,
+	params *api.StrategyParameters,
+) (*validatedLimitsOvercommitStrategyParams, error) {
+	if params == nil || params.LimitsOvercommit == nil || len(params.LimitsOvercommit.Thresholds) == 0 {
+		return nil, fmt.Errorf("limitsOvercommit thresholds not set")
+	}
+	for name, threshold := range params.LimitsOvercommit.Thresholds {
+		if name != v1.ResourceCPU && name != v1.ResourceMemory {
+			return nil, fmt.Errorf("only %v and %v limits can be checked, got %v", v1.ResourceCPU, v1.ResourceMemory, name)
+		}
+		if threshold <= 0 {
+			return nil, fmt.Errorf("%v threshold must be greater than zero", name)
+		}
+	}
+
+	strategyParams, err := validation.ValidateAndParseStrategyParams(ctx, client, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &validatedLimitsOvercommitStrategyParams{
+		ValidatedStrategyParams: *strategyParams,
+		thresholds:              params.LimitsOvercommit.Thresholds,
+	}, nil
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategies
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestRemovePodsFromOvercommittedNodes(t *testing.T) {
+	ctx := context.Background()
+
+	node := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	withCPULimit := func(limit int64) func(pod *v1.Pod) {
+		return func(pod *v1.Pod) {
+			test.SetRSOwnerRef(pod)
+			pod.Spec.Containers[0].Resources.Limits[v1.ResourceCPU] = *resource.NewMilliQuantity(limit, resource.DecimalSI)
+		}
+	}
+	pods := []*v1.Pod{
+		// limits to requests ratio of 10
+		test.BuildTestPod("p1", 100, 0, node.Name, withCPULimit(1000)),
+		// limits to requests ratio of 2
+		test.BuildTestPod("p2", 500, 0, node.Name, withCPULimit(1000)),
+		// limits to requests ratio of 1
+		test.BuildTestPod("p3", 1000, 0, node.Name, withCPULimit(1000)),
+		// no limits
+		test.BuildTestPod("p4", 100, 0, node.Name, test.SetRSOwnerRef),
+		// limits without requests
+		test.BuildTestPod("p5", 0, 0, node.Name, withCPULimit(500)),
+	}
+
+	tests := []struct {
+		description     string
+		thresholds      api.ResourceThresholds
+		expectedEvicted []string
+	}{
+		{
+			description:     "Pods with the largest ratio are evicted until limits are under the threshold",
+			thresholds:      api.ResourceThresholds{v1.ResourceCPU: 150},
+			expectedEvicted: []string{"p5"},
+		},
+		{
+			description:     "Several pods are evicted for a larger excess",
+			thresholds:      api.ResourceThresholds{v1.ResourceCPU: 100},
+			expectedEvicted: []string{"p5", "p1"},
+		},
+		{
+			description: "Limits under the threshold",
+			thresholds:  api.ResourceThresholds{v1.ResourceCPU: 200},
+		},
+		{
+			description: "Pods without memory limits do not overcommit memory",
+			thresholds:  api.ResourceThresholds{v1.ResourceMemory: 50},
+		},
+		{
+			description: "Only cpu and memory are supported",
+			thresholds:  api.ResourceThresholds{v1.ResourcePods: 50},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			fakeClient := &fake.Clientset{}
+			fakeClient.Fake.AddReactor("list", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				fieldSelector := action.(core.ListAction).GetListRestrictions().Fields
+				podList := &v1.PodList{}
+				for _, pod := range pods {
+					if fieldSelector.Matches(fields.Set{"spec.nodeName": pod.Spec.NodeName, "status.phase": string(pod.Status.Phase)}) {
+						podList.Items = append(podList.Items, *pod)
+					}
+				}
+				return true, podList, nil
+			})
+			var evicted []string
+			fakeClient.Fake.AddReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() == "eviction" {
+					evicted = append(evicted, action.(core.CreateAction).GetObject().(metav1.Object).GetName())
+				}
+				return true, nil, nil
+			})
+
+			podEvictor := evictions.NewPodEvictor(
+				fakeClient,
+				policyv1.SchemeGroupVersion.String(),
+				false,
+				0,
+				0,
+				[]*v1.Node{node},
+				false,
+				false,
+				false,
+			)
+
+			strategy := api.DeschedulerStrategy{
+				Enabled: true,
+				Params: &api.StrategyParameters{
+					LimitsOvercommit: &api.LimitsOvercommit{Thresholds: tc.thresholds},
+				},
+			}
+			RemovePodsFromOvercommittedNodes(ctx, fakeClient, strategy, []*v1.Node{node}, podEvictor)
+			if !reflect.DeepEqual(evicted, tc.expectedEvicted) {
+				t.Errorf("Expected %v pods to be evicted, got %v", tc.expectedEvicted, evicted)
+			}
+		})
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"sigs.k8s.io/descheduler/pkg/api"
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/validation"
 	"sigs.k8s.io/descheduler/pkg/utils"
 
 	v1 "k8s.io/api/core/v1"
@@ -48,10 +49,9 @@ func validateRemovePodsViolatingInterPodAntiAffinityParams(params *api.StrategyP
 }
 
 // RemovePodsViolatingInterPodAntiAffinity evicts pods on the node which are having a pod affinity rules.
-func RemovePodsViolatingInterPodAntiAffinity(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor) {
+func RemovePodsViolatingInterPodAntiAffinity(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor) error {
 	if err := validateRemovePodsViolatingInterPodAntiAffinityParams(strategy.Params); err != nil {
-		klog.ErrorS(err, "Invalid RemovePodsViolatingInterPodAntiAffinity parameters")
-		return
+		return validation.NewStrategyError(validation.ErrorReasonInvalidParameters, "invalid RemovePodsViolatingInterPodAntiAffinity parameters", err)
 	}
 
 	var includedNamespaces, excludedNamespaces []string
@@ -66,8 +66,7 @@ func RemovePodsViolatingInterPodAntiAffinity(ctx context.Context, client clients
 
 	thresholdPriority, err := utils.GetPriorityFromStrategyParams(ctx, client, strategy.Params)
 	if err != nil {
-		klog.ErrorS(err, "Failed to get threshold priority from strategy's params")
-		return
+		return validation.NewStrategyError(validation.ErrorReasonPriorityLookup, "failed to get threshold priority from strategy's params", err)
 	}
 
 	nodeFit := false
@@ -78,6 +77,9 @@ func RemovePodsViolatingInterPodAntiAffinity(ctx context.Context, client clients
 	evictable := podEvictor.Evictable(evictions.WithPriorityThreshold(thresholdPriority), evictions.WithNodeFit(nodeFit))
 
 	for _, node := range nodes {
+		if !podEvictor.VisitNode(node) {
+			continue
+		}
 		klog.V(1).InfoS("Processing node", "node", klog.KObj(node))
 		pods, err := podutil.ListPodsOnANode(
 			ctx,
@@ -88,14 +90,14 @@ func RemovePodsViolatingInterPodAntiAffinity(ctx context.Context, client clients
 			podutil.WithLabelSelector(labelSelector),
 		)
 		if err != nil {
-			return
+			return nil
 		}
 		// sort the evictable Pods based on priority, if there are multiple pods with same priority, they are sorted based on QoS tiers.
 		podutil.SortPodsBasedOnPriorityLowToHigh(pods)
 		totalPods := len(pods)
 		for i := 0; i < totalPods; i++ {
 			if checkPodsWithAntiAffinityExist(pods[i], pods) && evictable.IsEvictable(pods[i]) {
-				success, err := podEvictor.EvictPod(ctx, pods[i], node, "InterPodAntiAffinity")
+				success, err := podEvictor.EvictPod(ctx, pods[i], node, evictions.ReasonRemovePodsViolatingInterPodAntiAffinity)
 				if err != nil {
 					klog.ErrorS(err, "Error evicting pod")
 					break
@@ -112,6 +114,7 @@ func RemovePodsViolatingInterPodAntiAffinity(ctx context.Context, client clients
 			}
 		}
 	}
+	return nil
 }
 
 // checkPodsWithAntiAffinityExist checks if there are other pods on the node that the current pod cannot tolerate.
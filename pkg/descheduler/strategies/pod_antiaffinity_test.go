@@ -167,6 +167,7 @@ func TestPodAntiAffinity(t *testing.T) {
 			policyv1.SchemeGroupVersion.String(),
 			false,
 			test.maxPodsToEvictPerNode,
+			0,
 			test.nodes,
 			false,
 			false,
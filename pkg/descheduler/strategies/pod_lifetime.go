@@ -19,6 +19,7 @@ package strategies
 import (
 	"context"
 	"fmt"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -28,6 +29,7 @@ import (
 	"sigs.k8s.io/descheduler/pkg/api"
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/validation"
 	"sigs.k8s.io/descheduler/pkg/utils"
 )
 
@@ -56,16 +58,14 @@ func validatePodLifeTimeParams(params *api.StrategyParameters) error {
 }
 
 // PodLifeTime evicts pods on nodes that were created more than strategy.Params.MaxPodLifeTimeSeconds seconds ago.
-func PodLifeTime(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor) {
+func PodLifeTime(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor) error {
 	if err := validatePodLifeTimeParams(strategy.Params); err != nil {
-		klog.ErrorS(err, "Invalid PodLifeTime parameters")
-		return
+		return validation.NewStrategyError(validation.ErrorReasonInvalidParameters, "invalid PodLifeTime parameters", err)
 	}
 
 	thresholdPriority, err := utils.GetPriorityFromStrategyParams(ctx, client, strategy.Params)
 	if err != nil {
-		klog.ErrorS(err, "Failed to get threshold priority from strategy's params")
-		return
+		return validation.NewStrategyError(validation.ErrorReasonPriorityLookup, "failed to get threshold priority from strategy's params", err)
 	}
 
 	var includedNamespaces, excludedNamespaces []string
@@ -89,11 +89,14 @@ func PodLifeTime(ctx context.Context, client clientset.Interface, strategy api.D
 	}
 
 	for _, node := range nodes {
+		if !podEvictor.VisitNode(node) {
+			continue
+		}
 		klog.V(1).InfoS("Processing node", "node", klog.KObj(node))
 
-		pods := listOldPodsOnNode(ctx, client, node, includedNamespaces, excludedNamespaces, strategy.Params.LabelSelector, *strategy.Params.PodLifeTime.MaxPodLifeTimeSeconds, filter)
+		pods := listOldPodsOnNode(ctx, client, node, includedNamespaces, excludedNamespaces, strategy.Params.LabelSelector, *strategy.Params.PodLifeTime.MaxPodLifeTimeSeconds, filter, podEvictor.Now())
 		for _, pod := range pods {
-			success, err := podEvictor.EvictPod(ctx, pod, node, "PodLifeTime")
+			success, err := podEvictor.EvictPod(ctx, pod, node, evictions.ReasonPodLifeTime)
 			if success {
 				klog.V(1).InfoS("Evicted pod because it exceeded its lifetime", "pod", klog.KObj(pod), "maxPodLifeTime", *strategy.Params.PodLifeTime.MaxPodLifeTimeSeconds)
 			}
@@ -105,6 +108,7 @@ func PodLifeTime(ctx context.Context, client clientset.Interface, strategy api.D
 		}
 
 	}
+	return nil
 }
 
 func listOldPodsOnNode(
@@ -115,6 +119,7 @@ func listOldPodsOnNode(
 	labelSelector *metav1.LabelSelector,
 	maxPodLifeTimeSeconds uint,
 	filter func(pod *v1.Pod) bool,
+	now time.Time,
 ) []*v1.Pod {
 	pods, err := podutil.ListPodsOnANode(
 		ctx,
@@ -131,7 +136,7 @@ func listOldPodsOnNode(
 
 	var oldPods []*v1.Pod
 	for _, pod := range pods {
-		podAgeSeconds := uint(metav1.Now().Sub(pod.GetCreationTimestamp().Local()).Seconds())
+		podAgeSeconds := uint(now.Sub(pod.GetCreationTimestamp().Local()).Seconds())
 		if podAgeSeconds > maxPodLifeTimeSeconds {
 			oldPods = append(oldPods, pod)
 		}
@@ -25,6 +25,7 @@ import (
 	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/clock"
 	"k8s.io/client-go/kubernetes/fake"
 	core "k8s.io/client-go/testing"
 	"sigs.k8s.io/descheduler/pkg/api"
@@ -260,6 +261,7 @@ func TestPodLifeTime(t *testing.T) {
 			policyv1.SchemeGroupVersion.String(),
 			false,
 			tc.maxPodsToEvictPerNode,
+			0,
 			tc.nodes,
 			false,
 			false,
@@ -274,3 +276,40 @@ func TestPodLifeTime(t *testing.T) {
 	}
 
 }
+
+func TestPodLifeTimeFakeClock(t *testing.T) {
+	ctx := context.Background()
+	node1 := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	created := time.Date(2021, time.June, 1, 12, 0, 0, 0, time.UTC)
+	p1 := test.BuildTestPod("p1", 100, 0, node1.Name, test.SetRSOwnerRef)
+	p1.ObjectMeta.CreationTimestamp = metav1.NewTime(created)
+
+	fakeClient := &fake.Clientset{}
+	fakeClient.Fake.AddReactor("list", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		return true, &v1.PodList{Items: []v1.Pod{*p1}}, nil
+	})
+	maxLifeTime := uint(600)
+	strategy := api.DeschedulerStrategy{
+		Enabled: true,
+		Params:  &api.StrategyParameters{PodLifeTime: &api.PodLifeTime{MaxPodLifeTimeSeconds: &maxLifeTime}},
+	}
+
+	fakeClock := clock.NewFakeClock(created.Add(5 * time.Minute))
+	podEvictor := evictions.NewPodEvictor(fakeClient, policyv1.SchemeGroupVersion.String(), false, 0, 0, []*v1.Node{node1}, false, false, false)
+	podEvictor.SetClock(fakeClock)
+
+	PodLifeTime(ctx, fakeClient, strategy, []*v1.Node{node1}, podEvictor)
+	if podEvictor.TotalEvicted() != 0 {
+		t.Fatalf("Expected no pod to be evicted before its lifetime passed on the clock, got %v", podEvictor.TotalEvicted())
+	}
+
+	fakeClock.Step(10 * time.Minute)
+	PodLifeTime(ctx, fakeClient, strategy, []*v1.Node{node1}, podEvictor)
+	evicted := podEvictor.EvictedPods()
+	if len(evicted) != 1 {
+		t.Fatalf("Expected the pod to be evicted once its lifetime passed on the clock, got %v evictions", len(evicted))
+	}
+	if !evicted[0].Time.Time.Equal(fakeClock.Now()) {
+		t.Errorf("Expected the eviction to be recorded at %v, got %v", fakeClock.Now(), evicted[0].Time)
+	}
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategies
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/validation"
+)
+
+// validatedPodDensityStrategyParams contains validated strategy parameters
+type validatedPodDensityStrategyParams struct {
+	validation.ValidatedStrategyParams
+	topologyKey      string
+	maxPodsPerDomain int
+	podSelector      labels.Selector
+}
+
+// podDensityDomain identifies a topology domain within a namespace
+type podDensityDomain struct {
+	namespace string
+	value     string
+}
+
+// RemovePodsViolatingPodDensity evicts pods from topology domains running more than
+// maxPodsPerDomain pods matching the configured selector. It is meant for workloads
+// deployed as Deployments which should be spread like DaemonSets.
+func RemovePodsViolatingPodDensity(
+	ctx context.Context,
+	client clientset.Interface,
+	strategy api.DeschedulerStrategy,
+	nodes []*v1.Node,
+	podEvictor *evictions.PodEvictor,
+) error {
+	strategyParams, err := validateAndParsePodDensityParams(ctx, client, strategy.Params)
+	if err != nil {
+		return validation.NewStrategyError(validation.ErrorReasonInvalidParameters, "invalid RemovePodsViolatingPodDensity parameters", err)
+	}
+
+	evictable := podEvictor.Evictable(
+		evictions.WithPriorityThreshold(strategyParams.ThresholdPriority),
+		evictions.WithNodeFit(strategyParams.NodeFit),
+		evictions.WithLabelSelector(strategyParams.LabelSelector),
+	)
+
+	domainPods := make(map[podDensityDomain][]*v1.Pod)
+	podNodes := make(map[*v1.Pod]*v1.Node)
+	for _, node := range nodes {
+		value, ok := node.Labels[strategyParams.topologyKey]
+		if !ok {
+			klog.V(2).InfoS("Node has no topology label, skipping", "node", klog.KObj(node), "topologyKey", strategyParams.topologyKey)
+			continue
+		}
+		pods, err := podutil.ListPodsOnANode(ctx, client, node, podutil.WithFilter(func(pod *v1.Pod) bool {
+			return pod.DeletionTimestamp == nil && strategyParams.podSelector.Matches(labels.Set(pod.Labels))
+		}))
+		if err != nil {
+			klog.ErrorS(err, "Error listing pods on node", "node", klog.KObj(node))
+			continue
+		}
+		for _, pod := range pods {
+			if (len(strategyParams.IncludedNamespaces) > 0 && !strategyParams.IncludedNamespaces.Has(pod.Namespace)) ||
+				(len(strategyParams.ExcludedNamespaces) > 0 && strategyParams.ExcludedNamespaces.Has(pod.Namespace)) {
+				continue
+			}
+			domain := podDensityDomain{namespace: pod.Namespace, value: value}
+			domainPods[domain] = append(domainPods[domain], pod)
+			podNodes[pod] = node
+		}
+	}
+
+	// Process domains in a stable order
+	domains := make([]podDensityDomain, 0, len(domainPods))
+	for domain := range domainPods {
+		domains = append(domains, domain)
+	}
+	sort.Slice(domains, func(i, j int) bool {
+		if domains[i].namespace != domains[j].namespace {
+			return domains[i].namespace < domains[j].namespace
+		}
+		return domains[i].value < domains[j].value
+	})
+
+	for _, domain := range domains {
+		pods := domainPods[domain]
+		excess := len(pods) - strategyParams.maxPodsPerDomain
+		if excess <= 0 {
+			continue
+		}
+		klog.V(1).InfoS("Topology domain exceeds maximum pod density", "namespace", domain.namespace, "domain", domain.value, "pods", len(pods), "maxPodsPerDomain", strategyParams.maxPodsPerDomain)
+
+		// evict pods with lower priority first
+		podutil.SortPodsBasedOnPriorityLowToHigh(pods)
+		for _, pod := range pods {
+			if excess == 0 {
+				break
+			}
+			if !evictable.IsEvictable(pod) {
+				continue
+			}
+			success, err := podEvictor.EvictPod(ctx, pod, podNodes[pod], evictions.ReasonRemovePodsViolatingPodDensity)
+			if err != nil {
+				// the limit of evictions for the pod's node was reached, other nodes of the domain can be tried
+				klog.ErrorS(err, "Error evicting pod", "pod", klog.KObj(pod))
+				continue
+			}
+			if success {
+				excess--
+			}
+		}
+	}
+	return nil
+}
+
+func validateAndParsePodDensityParams(
+	ctx context.Context,
+	client clientset.Interface,
+	params *api.StrategyParameters,
+) (*validatedPodDensityStrategyParams, error) {
+	if params == nil || params.PodDensity == nil {
+		return nil, fmt.Errorf("PodDensity not set")
+	}
+	if params.PodDensity.TopologyKey == "" {
+		return nil, fmt.Errorf("topologyKey must be set")
+	}
+	if params.PodDensity.MaxPodsPerDomain < 1 {
+		return nil, fmt.Errorf("maxPodsPerDomain must be greater than zero")
+	}
+	if params.PodDensity.LabelSelector == nil {
+		return nil, fmt.Errorf("labelSelector must be set")
+	}
+	podSelector, err := metav1.LabelSelectorAsSelector(params.PodDensity.LabelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse labelSelector: %v", err)
+	}
+	if podSelector.Empty() {
+		return nil, fmt.Errorf("labelSelector can not be empty")
+	}
+
+	strategyParams, err := validation.ValidateAndParseStrategyParams(ctx, client, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &validatedPodDensityStrategyParams{
+		ValidatedStrategyParams: *strategyParams,
+		topologyKey:             params.PodDensity.TopologyKey,
+		maxPodsPerDomain:        params.PodDensity.MaxPodsPerDomain,
+		podSelector:             podSelector,
+	}, nil
+}
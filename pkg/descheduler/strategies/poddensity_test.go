@@ -0,0 +1,195 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategies
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestRemovePodsViolatingPodDensity(t *testing.T) {
+	ctx := context.Background()
+
+	zoneNode := func(name, zone string) *v1.Node {
+		return test.BuildTestNode(name, 2000, 3000, 10, func(node *v1.Node) {
+			if zone != "" {
+				node.Labels["zone"] = zone
+			}
+		})
+	}
+	nodeA1 := zoneNode("a1", "zoneA")
+	nodeA2 := zoneNode("a2", "zoneA")
+	nodeB1 := zoneNode("b1", "zoneB")
+	nodeNoZone := zoneNode("n1", "")
+
+	agent := func(name string, node *v1.Node, apply func(*v1.Pod)) *v1.Pod {
+		return test.BuildTestPod(name, 100, 0, node.Name, func(pod *v1.Pod) {
+			test.SetRSOwnerRef(pod)
+			pod.Labels = map[string]string{"app": "agent"}
+			if apply != nil {
+				apply(pod)
+			}
+		})
+	}
+	other := test.BuildTestPod("other", 100, 0, nodeA1.Name, test.SetRSOwnerRef)
+
+	createStrategy := func(maxPodsPerDomain int, nodeFit bool) api.DeschedulerStrategy {
+		return api.DeschedulerStrategy{
+			Enabled: true,
+			Params: &api.StrategyParameters{
+				PodDensity: &api.PodDensity{
+					TopologyKey:      "zone",
+					MaxPodsPerDomain: maxPodsPerDomain,
+					LabelSelector:    &metav1.LabelSelector{MatchLabels: map[string]string{"app": "agent"}},
+				},
+				NodeFit: nodeFit,
+			},
+		}
+	}
+
+	tests := []struct {
+		description             string
+		pods                    []*v1.Pod
+		nodes                   []*v1.Node
+		strategy                api.DeschedulerStrategy
+		expectedEvictedPodCount int
+	}{
+		{
+			description: "Domain under maximum density, no evictions",
+			pods: []*v1.Pod{
+				agent("p1", nodeA1, nil),
+				agent("p2", nodeB1, nil),
+				other,
+			},
+			nodes:                   []*v1.Node{nodeA1, nodeA2, nodeB1},
+			strategy:                createStrategy(1, false),
+			expectedEvictedPodCount: 0,
+		},
+		{
+			description: "Pods spread over nodes of a crowded domain are evicted",
+			pods: []*v1.Pod{
+				agent("p1", nodeA1, nil),
+				agent("p2", nodeA1, nil),
+				agent("p3", nodeA2, nil),
+				agent("p4", nodeB1, nil),
+				other,
+			},
+			nodes:                   []*v1.Node{nodeA1, nodeA2, nodeB1},
+			strategy:                createStrategy(1, false),
+			expectedEvictedPodCount: 2,
+		},
+		{
+			description: "Pods are counted per namespace",
+			pods: []*v1.Pod{
+				agent("p1", nodeA1, nil),
+				agent("p2", nodeA2, func(pod *v1.Pod) { pod.Namespace = "other" }),
+			},
+			nodes:                   []*v1.Node{nodeA1, nodeA2, nodeB1},
+			strategy:                createStrategy(1, false),
+			expectedEvictedPodCount: 0,
+		},
+		{
+			description: "Nodes without topology label are ignored",
+			pods: []*v1.Pod{
+				agent("p1", nodeNoZone, nil),
+				agent("p2", nodeNoZone, nil),
+			},
+			nodes:                   []*v1.Node{nodeNoZone, nodeB1},
+			strategy:                createStrategy(1, false),
+			expectedEvictedPodCount: 0,
+		},
+		{
+			description: "Non evictable pods count towards density but are not evicted",
+			pods: []*v1.Pod{
+				agent("p1", nodeA1, test.SetDSOwnerRef),
+				agent("p2", nodeA1, test.SetDSOwnerRef),
+				agent("p3", nodeA2, nil),
+			},
+			nodes:                   []*v1.Node{nodeA1, nodeA2, nodeB1},
+			strategy:                createStrategy(1, false),
+			expectedEvictedPodCount: 1,
+		},
+		{
+			description: "Pods are not evicted when no other node fits, nodeFit=true",
+			pods: []*v1.Pod{
+				agent("p1", nodeA1, nil),
+				agent("p2", nodeA1, nil),
+			},
+			nodes: []*v1.Node{nodeA1, test.BuildTestNode("b2", 2000, 3000, 10, func(node *v1.Node) {
+				node.Labels["zone"] = "zoneB"
+				node.Spec.Unschedulable = true
+			})},
+			strategy:                createStrategy(1, true),
+			expectedEvictedPodCount: 0,
+		},
+		{
+			description: "Invalid maxPodsPerDomain",
+			pods: []*v1.Pod{
+				agent("p1", nodeA1, nil),
+				agent("p2", nodeA1, nil),
+			},
+			nodes:                   []*v1.Node{nodeA1, nodeB1},
+			strategy:                createStrategy(0, false),
+			expectedEvictedPodCount: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			fakeClient := &fake.Clientset{}
+			fakeClient.Fake.AddReactor("list", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				fieldSelector := action.(core.ListAction).GetListRestrictions().Fields
+				podList := &v1.PodList{}
+				for _, pod := range tc.pods {
+					if fieldSelector.Matches(fields.Set{"spec.nodeName": pod.Spec.NodeName, "status.phase": string(pod.Status.Phase)}) {
+						podList.Items = append(podList.Items, *pod)
+					}
+				}
+				return true, podList, nil
+			})
+
+			podEvictor := evictions.NewPodEvictor(
+				fakeClient,
+				policyv1.SchemeGroupVersion.String(),
+				false,
+				0,
+				0,
+				tc.nodes,
+				false,
+				false,
+				false,
+			)
+
+			RemovePodsViolatingPodDensity(ctx, fakeClient, tc.strategy, tc.nodes, podEvictor)
+			if actualEvictedPodCount := podEvictor.TotalEvicted(); actualEvictedPodCount != tc.expectedEvictedPodCount {
+				t.Errorf("Expected %v pod evictions, but got %v pod evictions", tc.expectedEvictedPodCount, actualEvictedPodCount)
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategies
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/validation"
+)
+
+// DefaultRestartChurnWindowSeconds is the time window restarts are counted in when not configured
+const DefaultRestartChurnWindowSeconds = 3600
+
+// validatedRestartChurnStrategyParams contains validated strategy parameters
+type validatedRestartChurnStrategyParams struct {
+	validation.ValidatedStrategyParams
+	nodeRestartThreshold    int32
+	window                  time.Duration
+	includingInitContainers bool
+	maxPodsToEvictPerNode   int
+}
+
+// restartSample is the number of restarts of the containers of a pod at some time
+type restartSample struct {
+	at       time.Time
+	restarts int32
+}
+
+// restartTracker samples the restarts of pods. Restart counts are cumulative over the life of pods, so restarts
+// within the window are counted against samples taken by previous descheduling cycles.
+type restartTracker struct {
+	lock    sync.Mutex
+	samples map[types.UID][]restartSample
+}
+
+var restartChurns = &restartTracker{samples: make(map[types.UID][]restartSample)}
+
+// update samples the current restarts of the pods and returns the restarts of each of them within the window, all
+// restarts of pods started within the window, and the restarts since the first sample of pods seen for a shorter
+// time. Pods which are not running anymore are forgotten.
+func (t *restartTracker) update(pods []*v1.Pod, restarts map[types.UID]int32, now time.Time, window time.Duration) map[types.UID]int32 {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	windowStart := now.Add(-window)
+	samples := make(map[types.UID][]restartSample, len(pods))
+	inWindow := make(map[types.UID]int32, len(pods))
+	for _, pod := range pods {
+		current := restarts[pod.UID]
+		previous := t.samples[pod.UID]
+		// the samples before the last one taken before the window are not needed anymore
+		first := 0
+		for i, sample := range previous {
+			if sample.at.After(windowStart) {
+				break
+			}
+			first = i
+		}
+		previous = previous[first:]
+
+		baseline := current
+		if pod.Status.StartTime != nil && !pod.Status.StartTime.Time.Before(windowStart) {
+			baseline = 0
+		} else if len(previous) > 0 {
+			baseline = previous[0].restarts
+		}
+		if current > baseline {
+			inWindow[pod.UID] = current - baseline
+		}
+		samples[pod.UID] = append(previous, restartSample{at: now, restarts: current})
+	}
+	t.samples = samples
+	return inWindow
+}
+
+// RemovePodsFromNodesWithRestartChurn evicts pods from nodes whose containers restarted at least
+// nodeRestartThreshold times within the window, a symptom of node-specific issues such as a bad kernel or a broken
+// CNI crashing workloads which run fine elsewhere. The pods restarting the most are evicted first, until the
+// restarts of the remaining pods of the node fall below the threshold, so they move to other nodes. The restarts
+// of all pods of the node count, whether they are evictable or not.
+func RemovePodsFromNodesWithRestartChurn(
+	ctx context.Context,
+	client clientset.Interface,
+	strategy api.DeschedulerStrategy,
+	nodes []*v1.Node,
+	podEvictor *evictions.PodEvictor,
+) error {
+	strategyParams, err := validateAndParseRestartChurnParams(ctx, client, strategy.Params)
+	if err != nil {
+		return validation.NewStrategyError(validation.ErrorReasonInvalidParameters, "invalid RemovePodsFromNodesWithRestartChurn parameters", err)
+	}
+
+	evictable := podEvictor.Evictable(
+		evictions.WithPriorityThreshold(strategyParams.ThresholdPriority),
+		evictions.WithNodeFit(strategyParams.NodeFit),
+		evictions.WithLabelSelector(strategyParams.LabelSelector),
+	)
+
+	var allPods []*v1.Pod
+	restarts := make(map[types.UID]int32)
+	podsByNode := make(map[*v1.Node][]*v1.Pod, len(nodes))
+	for _, node := range nodes {
+		pods, err := podutil.ListPodsOnANode(ctx, client, node)
+		if err != nil {
+			klog.ErrorS(err, "Error listing pods on node", "node", klog.KObj(node))
+			continue
+		}
+		podsByNode[node] = pods
+		for _, pod := range pods {
+			containerRestarts, initRestarts := calcContainerRestarts(pod)
+			if strategyParams.includingInitContainers {
+				containerRestarts += initRestarts
+			}
+			restarts[pod.UID] = containerRestarts
+		}
+		allPods = append(allPods, pods...)
+	}
+	inWindow := restartChurns.update(allPods, restarts, podEvictor.Now(), strategyParams.window)
+
+	for _, node := range nodes {
+		pods, ok := podsByNode[node]
+		if !ok {
+			continue
+		}
+		nodeRestarts := int32(0)
+		for _, pod := range pods {
+			nodeRestarts += inWindow[pod.UID]
+		}
+		if nodeRestarts < strategyParams.nodeRestartThreshold {
+			continue
+		}
+
+		var offenders []*v1.Pod
+		for _, pod := range pods {
+			if inWindow[pod.UID] == 0 {
+				continue
+			}
+			if (len(strategyParams.IncludedNamespaces) > 0 && !strategyParams.IncludedNamespaces.Has(pod.Namespace)) ||
+				(len(strategyParams.ExcludedNamespaces) > 0 && strategyParams.ExcludedNamespaces.Has(pod.Namespace)) {
+				continue
+			}
+			if evictable.IsEvictable(pod) {
+				offenders = append(offenders, pod)
+			}
+		}
+		klog.V(1).InfoS("Containers of node restart too often", "node", klog.KObj(node), "restarts", nodeRestarts, "window", strategyParams.window, "pods", len(offenders))
+		sort.SliceStable(offenders, func(i, j int) bool {
+			return inWindow[offenders[i].UID] > inWindow[offenders[j].UID]
+		})
+
+		evicted := 0
+		for _, pod := range offenders {
+			if nodeRestarts < strategyParams.nodeRestartThreshold {
+				break
+			}
+			if strategyParams.maxPodsToEvictPerNode > 0 && evicted >= strategyParams.maxPodsToEvictPerNode {
+				break
+			}
+			success, err := podEvictor.EvictPod(ctx, pod, node, evictions.ReasonRemovePodsFromNodesWithRestartChurn,
+				fmt.Sprintf("restarts=%d", inWindow[pod.UID]), fmt.Sprintf("nodeRestarts=%d", nodeRestarts))
+			if err != nil {
+				klog.ErrorS(err, "Error evicting pod", "pod", klog.KObj(pod))
+				break
+			}
+			if success {
+				evicted++
+				nodeRestarts -= inWindow[pod.UID]
+			}
+		}
+	}
+	return nil
+}
+
+func validateAndParseRestartChurnParams(
+	ctx context.Context,
+	client clientset.Interface,
+	params *api.StrategyParameters,
+) (*validatedRestartChurnStrategyParams, error) {
+	if params == nil || params.RestartChurn == nil {
+		return nil, fmt.Errorf("restartChurn not set")
+	}
+	if params.RestartChurn.NodeRestartThreshold < 1 {
+		return nil, fmt.Errorf("nodeRestartThreshold must be greater than 0")
+	}
+	windowSeconds := uint(DefaultRestartChurnWindowSeconds)
+	if params.RestartChurn.WindowSeconds != nil {
+		windowSeconds = *params.RestartChurn.WindowSeconds
+	}
+	if windowSeconds == 0 {
+		return nil, fmt.Errorf("windowSeconds must be greater than 0")
+	}
+	if params.RestartChurn.MaxPodsToEvictPerNode < 0 {
+		return nil, fmt.Errorf("maxPodsToEvictPerNode must not be negative")
+	}
+
+	strategyParams, err := validation.ValidateAndParseStrategyParams(ctx, client, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &validatedRestartChurnStrategyParams{
+		ValidatedStrategyParams: *strategyParams,
+		nodeRestartThreshold:    params.RestartChurn.NodeRestartThreshold,
+		window:                  time.Duration(windowSeconds) * time.Second,
+		includingInitContainers: params.RestartChurn.IncludingInitContainers,
+		maxPodsToEvictPerNode:   params.RestartChurn.MaxPodsToEvictPerNode,
+	}, nil
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategies
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestRemovePodsFromNodesWithRestartChurn(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2021, time.June, 1, 12, 0, 0, 0, time.UTC)
+	node1 := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	node2 := test.BuildTestNode("n2", 2000, 3000, 10, nil)
+
+	// buildPod builds a pod started the given time ago, whose container restarted the given number of times
+	buildPod := func(name, nodeName string, restarts int32, startedAgo time.Duration) *v1.Pod {
+		return test.BuildTestPod(name, 100, 0, nodeName, func(pod *v1.Pod) {
+			test.SetRSOwnerRef(pod)
+			pod.UID = types.UID(name)
+			startTime := metav1.NewTime(now.Add(-startedAgo))
+			pod.Status.StartTime = &startTime
+			pod.Status.ContainerStatuses = []v1.ContainerStatus{{RestartCount: restarts}}
+		})
+	}
+	threshold := func(nodeRestartThreshold int32, maxPodsToEvictPerNode int) *api.StrategyParameters {
+		return &api.StrategyParameters{RestartChurn: &api.RestartChurn{NodeRestartThreshold: nodeRestartThreshold, MaxPodsToEvictPerNode: maxPodsToEvictPerNode}}
+	}
+
+	tests := []struct {
+		description     string
+		pods            []*v1.Pod
+		params          *api.StrategyParameters
+		expectedEvicted []string
+	}{
+		{
+			description: "The pods restarting the most are evicted until the node restarts fall below the threshold",
+			pods: []*v1.Pod{
+				buildPod("p1", node1.Name, 3, time.Minute),
+				buildPod("p2", node1.Name, 5, time.Minute),
+				buildPod("p3", node1.Name, 4, time.Minute),
+				buildPod("p4", node1.Name, 0, time.Minute),
+				buildPod("p5", node2.Name, 5, time.Minute),
+			},
+			params:          threshold(6, 0),
+			expectedEvicted: []string{"p2", "p3"},
+		},
+		{
+			description: "Pods of nodes below the threshold are kept",
+			pods: []*v1.Pod{
+				buildPod("p1", node1.Name, 5, time.Minute),
+				buildPod("p2", node2.Name, 5, time.Minute),
+			},
+			params: threshold(10, 0),
+		},
+		{
+			description: "Restarts of pods started before the window are not counted without samples",
+			pods: []*v1.Pod{
+				buildPod("p1", node1.Name, 20, 2*time.Hour),
+			},
+			params: threshold(10, 0),
+		},
+		{
+			description: "maxPodsToEvictPerNode limits the evictions from a node",
+			pods: []*v1.Pod{
+				buildPod("p1", node1.Name, 5, time.Minute),
+				buildPod("p2", node1.Name, 6, time.Minute),
+				buildPod("p3", node1.Name, 7, time.Minute),
+			},
+			params:          threshold(5, 1),
+			expectedEvicted: []string{"p3"},
+		},
+		{
+			description: "A nodeRestartThreshold of 0 is refused",
+			pods: []*v1.Pod{
+				buildPod("p1", node1.Name, 5, time.Minute),
+			},
+			params: threshold(0, 0),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			restartChurns = &restartTracker{samples: make(map[types.UID][]restartSample)}
+			fakeClient := &fake.Clientset{}
+			fakeClient.Fake.AddReactor("list", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				fieldSelector := action.(core.ListAction).GetListRestrictions().Fields
+				podList := &v1.PodList{}
+				for _, pod := range tc.pods {
+					if fieldSelector.Matches(fields.Set{"spec.nodeName": pod.Spec.NodeName, "status.phase": string(pod.Status.Phase)}) {
+						podList.Items = append(podList.Items, *pod)
+					}
+				}
+				return true, podList, nil
+			})
+			var evicted []string
+			fakeClient.Fake.AddReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() == "eviction" {
+					evicted = append(evicted, action.(core.CreateAction).GetObject().(metav1.Object).GetName())
+				}
+				return true, nil, nil
+			})
+
+			nodes := []*v1.Node{node1, node2}
+			podEvictor := evictions.NewPodEvictor(fakeClient, policyv1.SchemeGroupVersion.String(), false, 0, 0, nodes, false, false, false)
+			podEvictor.SetClock(clock.NewFakeClock(now))
+
+			strategy := api.DeschedulerStrategy{Enabled: true, Params: tc.params}
+			RemovePodsFromNodesWithRestartChurn(ctx, fakeClient, strategy, nodes, podEvictor)
+			if !reflect.DeepEqual(evicted, tc.expectedEvicted) {
+				t.Errorf("Expected %v pods to be evicted, got %v", tc.expectedEvicted, evicted)
+			}
+		})
+	}
+}
+
+func TestRestartTracker(t *testing.T) {
+	start := time.Date(2021, time.June, 1, 12, 0, 0, 0, time.UTC)
+	started := metav1.NewTime(start.Add(-24 * time.Hour))
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{UID: "p1"}, Status: v1.PodStatus{StartTime: &started}}
+	tracker := &restartTracker{samples: make(map[types.UID][]restartSample)}
+	window := time.Hour
+
+	steps := []struct {
+		after    time.Duration
+		restarts int32
+		expected int32
+	}{
+		// restarts before the first sample are not counted
+		{after: 0, restarts: 50, expected: 0},
+		// restarts since the first sample are counted while it is within the window
+		{after: 30 * time.Minute, restarts: 55, expected: 5},
+		{after: 60 * time.Minute, restarts: 58, expected: 8},
+		// the last sample before the window is the baseline
+		{after: 90 * time.Minute, restarts: 60, expected: 5},
+		{after: 200 * time.Minute, restarts: 60, expected: 0},
+	}
+	for _, step := range steps {
+		inWindow := tracker.update([]*v1.Pod{pod}, map[types.UID]int32{pod.UID: step.restarts}, start.Add(step.after), window)
+		if inWindow[pod.UID] != step.expected {
+			t.Errorf("Expected %v restarts within the window after %v, got %v", step.expected, step.after, inWindow[pod.UID])
+		}
+	}
+
+	tracker.update(nil, nil, start.Add(300*time.Minute), window)
+	if len(tracker.samples) != 0 {
+		t.Errorf("Expected the samples of pods not running anymore to be forgotten, got %v", tracker.samples)
+	}
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategies
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/validation"
+)
+
+// DefaultMaxStuckSeconds is the time a pod may spend creating its containers on a node when not configured
+const DefaultMaxStuckSeconds = 600
+
+// DefaultStuckPodReasons are the waiting reasons of containers which cannot be created on their node
+var DefaultStuckPodReasons = []string{
+	"ContainerCreating",
+	"CreateContainerError",
+}
+
+// validatedStuckPodsStrategyParams contains validated strategy parameters
+type validatedStuckPodsStrategyParams struct {
+	validation.ValidatedStrategyParams
+	maxStuck time.Duration
+	reasons  sets.String
+}
+
+// RemovePodsStuckInCreation evicts pods whose containers have been waiting to be created for longer
+// than maxStuckSeconds since the pod was scheduled, letting the scheduler try a different node.
+func RemovePodsStuckInCreation(
+	ctx context.Context,
+	client clientset.Interface,
+	strategy api.DeschedulerStrategy,
+	nodes []*v1.Node,
+	podEvictor *evictions.PodEvictor,
+) error {
+	strategyParams, err := validateAndParseStuckPodsParams(ctx, client, strategy.Params)
+	if err != nil {
+		return validation.NewStrategyError(validation.ErrorReasonInvalidParameters, "invalid RemovePodsStuckInCreation parameters", err)
+	}
+
+	evictable := podEvictor.Evictable(
+		evictions.WithPriorityThreshold(strategyParams.ThresholdPriority),
+		evictions.WithNodeFit(strategyParams.NodeFit),
+		evictions.WithLabelSelector(strategyParams.LabelSelector),
+	)
+
+	now := podEvictor.Now()
+	for _, node := range nodes {
+		if !podEvictor.VisitNode(node) {
+			continue
+		}
+		klog.V(1).InfoS("Processing node", "node", klog.KObj(node))
+		pods, err := podutil.ListPodsOnANode(
+			ctx,
+			client,
+			node,
+			podutil.WithFilter(func(pod *v1.Pod) bool {
+				return evictable.IsEvictable(pod) && stuckContainerReason(pod, strategyParams.reasons) != ""
+			}),
+			podutil.WithNamespaces(strategyParams.IncludedNamespaces.UnsortedList()),
+			podutil.WithoutNamespaces(strategyParams.ExcludedNamespaces.UnsortedList()),
+		)
+		if err != nil {
+			klog.ErrorS(err, "Error listing pods on node", "node", klog.KObj(node))
+			continue
+		}
+
+		for _, pod := range pods {
+			stuckFor := now.Sub(podScheduledTime(pod))
+			if stuckFor < strategyParams.maxStuck {
+				continue
+			}
+			reason := stuckContainerReason(pod, strategyParams.reasons)
+			if _, err := podEvictor.EvictPod(ctx, pod, node, evictions.ReasonRemovePodsStuckInCreation, "reason="+reason, "stuckFor="+stuckFor.Truncate(time.Second).String()); err != nil {
+				klog.ErrorS(err, "Error evicting pod", "pod", klog.KObj(pod))
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// stuckContainerReason returns the first of the given reasons an init or regular container of the pod waits for
+func stuckContainerReason(pod *v1.Pod, reasons sets.String) string {
+	for _, statuses := range [][]v1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses} {
+		for _, status := range statuses {
+			if status.State.Waiting != nil && reasons.Has(status.State.Waiting.Reason) {
+				return status.State.Waiting.Reason
+			}
+		}
+	}
+	return ""
+}
+
+// podScheduledTime returns when the pod was bound to its node, its creation time when unknown
+func podScheduledTime(pod *v1.Pod) time.Time {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodScheduled && condition.Status == v1.ConditionTrue && !condition.LastTransitionTime.IsZero() {
+			return condition.LastTransitionTime.Time
+		}
+	}
+	return pod.CreationTimestamp.Time
+}
+
+func validateAndParseStuckPodsParams(
+	ctx context.Context,
+	client clientset.Interface,
+	params *api.StrategyParameters,
+) (*validatedStuckPodsStrategyParams, error) {
+	maxStuckSeconds := uint(DefaultMaxStuckSeconds)
+	reasons := sets.NewString(DefaultStuckPodReasons...)
+	if params != nil && params.StuckPods != nil {
+		if params.StuckPods.MaxStuckSeconds != nil {
+			maxStuckSeconds = *params.StuckPods.MaxStuckSeconds
+		}
+		if len(params.StuckPods.Reasons) > 0 {
+			reasons = sets.NewString(params.StuckPods.Reasons...)
+		}
+	}
+	if maxStuckSeconds == 0 {
+		return nil, fmt.Errorf("maxStuckSeconds must be greater than 0")
+	}
+
+	strategyParams, err := validation.ValidateAndParseStrategyParams(ctx, client, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &validatedStuckPodsStrategyParams{
+		ValidatedStrategyParams: *strategyParams,
+		maxStuck:                time.Duration(maxStuckSeconds) * time.Second,
+		reasons:                 reasons,
+	}, nil
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategies
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestRemovePodsStuckInCreation(t *testing.T) {
+	ctx := context.Background()
+
+	node1 := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	node2 := test.BuildTestNode("n2", 2000, 3000, 10, nil)
+
+	buildPod := func(name string, scheduledFor time.Duration, initContainer bool, reason string) *v1.Pod {
+		return test.BuildTestPod(name, 100, 0, node1.Name, func(pod *v1.Pod) {
+			test.SetRSOwnerRef(pod)
+			pod.Status.Phase = v1.PodPending
+			pod.CreationTimestamp = metav1.NewTime(time.Now().Add(-2 * time.Hour))
+			pod.Status.Conditions = []v1.PodCondition{{
+				Type:               v1.PodScheduled,
+				Status:             v1.ConditionTrue,
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-scheduledFor)),
+			}}
+			status := v1.ContainerStatus{State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: reason}}}
+			if initContainer {
+				pod.Status.InitContainerStatuses = []v1.ContainerStatus{status}
+			} else {
+				pod.Status.ContainerStatuses = []v1.ContainerStatus{status}
+			}
+		})
+	}
+	pods := []*v1.Pod{
+		buildPod("p1", time.Hour, false, "ContainerCreating"),
+		buildPod("p2", time.Hour, true, "CreateContainerError"),
+		// scheduled recently, although created long ago
+		buildPod("p3", time.Minute, false, "ContainerCreating"),
+		buildPod("p4", time.Hour, false, "ImagePullBackOff"),
+		buildPod("p5", time.Hour, false, "CreateContainerConfigError"),
+	}
+
+	maxStuckSeconds := uint(120)
+	zero := uint(0)
+	tests := []struct {
+		description     string
+		params          *api.StrategyParameters
+		expectedEvicted []string
+	}{
+		{
+			description:     "Pods stuck creating containers for longer than the default are evicted",
+			expectedEvicted: []string{"p1", "p2"},
+		},
+		{
+			description:     "Pods are evicted once stuck for longer than maxStuckSeconds",
+			params:          &api.StrategyParameters{StuckPods: &api.StuckPods{MaxStuckSeconds: &maxStuckSeconds}},
+			expectedEvicted: []string{"p1", "p2"},
+		},
+		{
+			description:     "Configured reasons replace the default ones",
+			params:          &api.StrategyParameters{StuckPods: &api.StuckPods{Reasons: []string{"CreateContainerConfigError"}}},
+			expectedEvicted: []string{"p5"},
+		},
+		{
+			description: "A maxStuckSeconds of 0 is refused",
+			params:      &api.StrategyParameters{StuckPods: &api.StuckPods{MaxStuckSeconds: &zero}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			fakeClient := &fake.Clientset{}
+			fakeClient.Fake.AddReactor("list", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				fieldSelector := action.(core.ListAction).GetListRestrictions().Fields
+				podList := &v1.PodList{}
+				for _, pod := range pods {
+					if fieldSelector.Matches(fields.Set{"spec.nodeName": pod.Spec.NodeName, "status.phase": string(pod.Status.Phase)}) {
+						podList.Items = append(podList.Items, *pod)
+					}
+				}
+				return true, podList, nil
+			})
+			var evicted []string
+			fakeClient.Fake.AddReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() == "eviction" {
+					evicted = append(evicted, action.(core.CreateAction).GetObject().(metav1.Object).GetName())
+				}
+				return true, nil, nil
+			})
+
+			nodes := []*v1.Node{node1, node2}
+			podEvictor := evictions.NewPodEvictor(
+				fakeClient,
+				policyv1.SchemeGroupVersion.String(),
+				false,
+				0,
+				0,
+				nodes,
+				false,
+				false,
+				false,
+			)
+
+			strategy := api.DeschedulerStrategy{Enabled: true, Params: tc.params}
+			RemovePodsStuckInCreation(ctx, fakeClient, strategy, nodes, podEvictor)
+			if !reflect.DeepEqual(evicted, tc.expectedEvicted) {
+				t.Errorf("Expected %v pods to be evicted, got %v", tc.expectedEvicted, evicted)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategies
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/validation"
+)
+
+// DefaultMaxTerminatingSeconds is the time after their deletion timestamp after which pods are stuck terminating
+// when not configured
+const DefaultMaxTerminatingSeconds = 600
+
+// validatedTerminatingPodsStrategyParams contains validated strategy parameters
+type validatedTerminatingPodsStrategyParams struct {
+	validation.ValidatedStrategyParams
+	maxTerminating time.Duration
+	forceDelete    bool
+	nodeRoles      []string
+}
+
+// RemovePodsStuckTerminating removes pods still terminating on a NotReady node longer than maxTerminatingSeconds
+// after their deletion timestamp. The kubelet of such a node can not confirm the deletion, so the pods are kept
+// until the node comes back, blocking the replacement of StatefulSet pods. Stuck pods are evicted again, or force
+// deleted when explicitly enabled, whether or not the node is tainted by the node lifecycle controller.
+// NotReady nodes are not passed to strategies, they are listed by the strategy within the node scope of the evictor.
+func RemovePodsStuckTerminating(
+	ctx context.Context,
+	client clientset.Interface,
+	strategy api.DeschedulerStrategy,
+	nodes []*v1.Node,
+	podEvictor *evictions.PodEvictor,
+) error {
+	strategyParams, err := validateAndParseTerminatingPodsParams(ctx, client, strategy.Params)
+	if err != nil {
+		return validation.NewStrategyError(validation.ErrorReasonInvalidParameters, "invalid RemovePodsStuckTerminating parameters", err)
+	}
+
+	nodeList, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return validation.NewStrategyError(validation.ErrorReasonAPI, "unable to list nodes", err)
+	}
+
+	evictable := podEvictor.Evictable(
+		evictions.WithPriorityThreshold(strategyParams.ThresholdPriority),
+		evictions.WithNodeFit(strategyParams.NodeFit),
+		evictions.WithLabelSelector(strategyParams.LabelSelector),
+	)
+
+	now := podEvictor.Now()
+	for i := range nodeList.Items {
+		node := &nodeList.Items[i]
+		if !podEvictor.InNodeScope(node) {
+			continue
+		}
+		if len(strategyParams.nodeRoles) > 0 && !nodeutil.NodeHasAnyRole(node, strategyParams.nodeRoles) {
+			continue
+		}
+		notReadySince, ok := nodeNotReadySince(node)
+		if !ok {
+			continue
+		}
+		klog.V(1).InfoS("Processing NotReady node", "node", klog.KObj(node), "notReadySince", notReadySince)
+
+		pods, err := podutil.ListPodsOnANode(
+			ctx,
+			client,
+			node,
+			podutil.WithNamespaces(strategyParams.IncludedNamespaces.UnsortedList()),
+			podutil.WithoutNamespaces(strategyParams.ExcludedNamespaces.UnsortedList()),
+			podutil.WithFilter(func(pod *v1.Pod) bool {
+				return pod.DeletionTimestamp != nil && now.Sub(pod.DeletionTimestamp.Time) >= strategyParams.maxTerminating &&
+					evictable.IsEvictable(pod)
+			}),
+		)
+		if err != nil {
+			klog.ErrorS(err, "Error listing pods on node", "node", klog.KObj(node))
+			continue
+		}
+		for _, pod := range pods {
+			details := "terminatingFor=" + now.Sub(pod.DeletionTimestamp.Time).Truncate(time.Second).String()
+			if strategyParams.forceDelete {
+				if _, err := podEvictor.ForceDeletePod(ctx, pod, node, evictions.ReasonRemovePodsStuckTerminating, details); err != nil {
+					klog.ErrorS(err, "Error force deleting pod", "pod", klog.KObj(pod))
+					break
+				}
+				continue
+			}
+			if _, err := podEvictor.EvictPod(ctx, pod, node, evictions.ReasonRemovePodsStuckTerminating, details); err != nil {
+				klog.ErrorS(err, "Error evicting pod", "pod", klog.KObj(pod))
+				break
+			}
+		}
+	}
+	return nil
+}
+
+func validateAndParseTerminatingPodsParams(
+	ctx context.Context,
+	client clientset.Interface,
+	params *api.StrategyParameters,
+) (*validatedTerminatingPodsStrategyParams, error) {
+	maxTerminatingSeconds := uint(DefaultMaxTerminatingSeconds)
+	var forceDelete bool
+	var nodeRoles []string
+	if params != nil {
+		if params.TerminatingPods != nil {
+			if params.TerminatingPods.MaxTerminatingSeconds != nil {
+				maxTerminatingSeconds = *params.TerminatingPods.MaxTerminatingSeconds
+			}
+			forceDelete = params.TerminatingPods.ForceDelete
+		}
+		nodeRoles = params.NodeRoles
+	}
+	if maxTerminatingSeconds == 0 {
+		return nil, fmt.Errorf("maxTerminatingSeconds must be greater than 0")
+	}
+
+	strategyParams, err := validation.ValidateAndParseStrategyParams(ctx, client, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &validatedTerminatingPodsStrategyParams{
+		ValidatedStrategyParams: *strategyParams,
+		maxTerminating:          time.Duration(maxTerminatingSeconds) * time.Second,
+		forceDelete:             forceDelete,
+		nodeRoles:               nodeRoles,
+	}, nil
+}
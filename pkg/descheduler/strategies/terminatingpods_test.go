@@ -0,0 +1,212 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategies
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestRemovePodsStuckTerminating(t *testing.T) {
+	ctx := context.Background()
+
+	withReady := func(status v1.ConditionStatus) func(node *v1.Node) {
+		return func(node *v1.Node) {
+			node.Status.Conditions = []v1.NodeCondition{{
+				Type:               v1.NodeReady,
+				Status:             status,
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Hour)),
+			}}
+		}
+	}
+	readyNode := test.BuildTestNode("ready", 2000, 3000, 10, withReady(v1.ConditionTrue))
+	notReadyNode := test.BuildTestNode("not-ready", 2000, 3000, 10, withReady(v1.ConditionUnknown))
+	taintedNode := test.BuildTestNode("tainted", 2000, 3000, 10, func(node *v1.Node) {
+		withReady(v1.ConditionUnknown)(node)
+		node.Spec.Taints = []v1.Taint{{Key: v1.TaintNodeUnreachable, Effect: v1.TaintEffectNoExecute}}
+	})
+	otherReadyNode := test.BuildTestNode("other-ready", 2000, 3000, 10, withReady(v1.ConditionTrue))
+
+	buildPod := func(name, nodeName string, terminatingFor time.Duration) *v1.Pod {
+		return test.BuildTestPod(name, 100, 0, nodeName, func(pod *v1.Pod) {
+			test.SetRSOwnerRef(pod)
+			pod.Labels = map[string]string{"app": name}
+			if terminatingFor > 0 {
+				deletionTimestamp := metav1.NewTime(time.Now().Add(-terminatingFor))
+				pod.DeletionTimestamp = &deletionTimestamp
+			}
+		})
+	}
+
+	maxTerminatingSeconds := uint(120)
+	zero := uint(0)
+	tests := []struct {
+		description          string
+		pods                 []*v1.Pod
+		params               *api.StrategyParameters
+		nodeScope            func(node *v1.Node) bool
+		expectedEvicted      []string
+		expectedForceDeleted []string
+	}{
+		{
+			description: "Pods terminating on a NotReady node for longer than maxTerminatingSeconds are evicted again",
+			pods: []*v1.Pod{
+				buildPod("p1", notReadyNode.Name, time.Hour),
+				buildPod("p2", notReadyNode.Name, time.Minute),
+				buildPod("p3", notReadyNode.Name, 0),
+				buildPod("p4", taintedNode.Name, time.Hour),
+			},
+			expectedEvicted: []string{"p1", "p4"},
+		},
+		{
+			description: "Pods terminating on a ready node are kept",
+			pods: []*v1.Pod{
+				buildPod("p1", readyNode.Name, time.Hour),
+			},
+		},
+		{
+			description: "maxTerminatingSeconds is configurable",
+			pods: []*v1.Pod{
+				buildPod("p1", notReadyNode.Name, 5*time.Minute),
+				buildPod("p2", notReadyNode.Name, time.Minute),
+			},
+			params:          &api.StrategyParameters{TerminatingPods: &api.TerminatingPods{MaxTerminatingSeconds: &maxTerminatingSeconds}},
+			expectedEvicted: []string{"p1"},
+		},
+		{
+			description: "Stuck pods are force deleted when enabled",
+			pods: []*v1.Pod{
+				buildPod("p1", notReadyNode.Name, time.Hour),
+				buildPod("p2", taintedNode.Name, time.Hour),
+				buildPod("p3", notReadyNode.Name, time.Minute),
+			},
+			params:               &api.StrategyParameters{TerminatingPods: &api.TerminatingPods{ForceDelete: true}},
+			expectedForceDeleted: []string{"p1", "p2"},
+		},
+		{
+			description: "Stuck pods not matching the label selector are kept",
+			pods: []*v1.Pod{
+				buildPod("p1", notReadyNode.Name, time.Hour),
+				buildPod("p2", notReadyNode.Name, time.Hour),
+			},
+			params: &api.StrategyParameters{
+				TerminatingPods: &api.TerminatingPods{ForceDelete: true},
+				LabelSelector:   &metav1.LabelSelector{MatchLabels: map[string]string{"app": "p2"}},
+			},
+			expectedForceDeleted: []string{"p2"},
+		},
+		{
+			description: "Stuck pods which are not evictable are kept",
+			pods: []*v1.Pod{
+				buildPod("p1", notReadyNode.Name, time.Hour),
+				test.BuildTestPod("p2", 100, 0, notReadyNode.Name, func(pod *v1.Pod) {
+					test.SetDSOwnerRef(pod)
+					deletionTimestamp := metav1.NewTime(time.Now().Add(-time.Hour))
+					pod.DeletionTimestamp = &deletionTimestamp
+				}),
+			},
+			params:               &api.StrategyParameters{TerminatingPods: &api.TerminatingPods{ForceDelete: true}},
+			expectedForceDeleted: []string{"p1"},
+		},
+		{
+			description: "Stuck pods on a NotReady node out of the node scope are kept",
+			pods: []*v1.Pod{
+				buildPod("p1", notReadyNode.Name, time.Hour),
+				buildPod("p2", taintedNode.Name, time.Hour),
+			},
+			nodeScope: func(node *v1.Node) bool {
+				return node.Name != notReadyNode.Name
+			},
+			expectedEvicted: []string{"p2"},
+		},
+		{
+			description: "A maxTerminatingSeconds of 0 is refused",
+			pods: []*v1.Pod{
+				buildPod("p1", notReadyNode.Name, time.Hour),
+			},
+			params: &api.StrategyParameters{TerminatingPods: &api.TerminatingPods{MaxTerminatingSeconds: &zero}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			fakeClient := &fake.Clientset{}
+			fakeClient.Fake.AddReactor("list", "nodes", func(action core.Action) (bool, runtime.Object, error) {
+				return true, &v1.NodeList{Items: []v1.Node{*readyNode, *notReadyNode, *taintedNode, *otherReadyNode}}, nil
+			})
+			fakeClient.Fake.AddReactor("list", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				fieldSelector := action.(core.ListAction).GetListRestrictions().Fields
+				podList := &v1.PodList{}
+				for _, pod := range tc.pods {
+					if fieldSelector.Matches(fields.Set{"spec.nodeName": pod.Spec.NodeName, "status.phase": string(pod.Status.Phase)}) {
+						podList.Items = append(podList.Items, *pod)
+					}
+				}
+				return true, podList, nil
+			})
+			var evicted, forceDeleted []string
+			fakeClient.Fake.AddReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() == "eviction" {
+					evicted = append(evicted, action.(core.CreateAction).GetObject().(metav1.Object).GetName())
+				}
+				return true, nil, nil
+			})
+			fakeClient.Fake.AddReactor("delete", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				forceDeleted = append(forceDeleted, action.(core.DeleteAction).GetName())
+				return true, nil, nil
+			})
+
+			readyNodes := []*v1.Node{readyNode, otherReadyNode}
+			podEvictor := evictions.NewPodEvictor(
+				fakeClient,
+				policyv1.SchemeGroupVersion.String(),
+				false,
+				0,
+				0,
+				readyNodes,
+				false,
+				false,
+				false,
+			)
+
+			podEvictor.SetNodeScope(tc.nodeScope)
+
+			strategy := api.DeschedulerStrategy{Enabled: true, Params: tc.params}
+			RemovePodsStuckTerminating(ctx, fakeClient, strategy, readyNodes, podEvictor)
+			if !reflect.DeepEqual(evicted, tc.expectedEvicted) {
+				t.Errorf("Expected %v pods to be evicted, got %v", tc.expectedEvicted, evicted)
+			}
+			if !reflect.DeepEqual(forceDeleted, tc.expectedForceDeleted) {
+				t.Errorf("Expected %v pods to be force deleted, got %v", tc.expectedForceDeleted, forceDeleted)
+			}
+		})
+	}
+}
@@ -212,6 +212,7 @@ func TestRemovePodsHavingTooManyRestarts(t *testing.T) {
 			policyv1.SchemeGroupVersion.String(),
 			false,
 			tc.maxPodsToEvictPerNode,
+			0,
 			tc.nodes,
 			false,
 			false,
@@ -21,12 +21,15 @@ import (
 	"fmt"
 	"math"
 	"sort"
+	"strings"
 
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 
@@ -54,11 +57,27 @@ func RemovePodsViolatingTopologySpreadConstraint(
 	strategy api.DeschedulerStrategy,
 	nodes []*v1.Node,
 	podEvictor *evictions.PodEvictor,
-) {
+) error {
 	strategyParams, err := validation.ValidateAndParseStrategyParams(ctx, client, strategy.Params)
 	if err != nil {
-		klog.ErrorS(err, "Invalid RemovePodsViolatingTopologySpreadConstraint parameters")
-		return
+		return validation.NewStrategyError(validation.ErrorReasonInvalidParameters, "invalid RemovePodsViolatingTopologySpreadConstraint parameters", err)
+	}
+	var maxSkewReduction int32
+	if strategy.Params != nil {
+		maxSkewReduction = strategy.Params.MaxSkewReductionPerCycle
+	}
+	if maxSkewReduction < 0 {
+		return validation.NewStrategyError(validation.ErrorReasonInvalidParameters, "invalid RemovePodsViolatingTopologySpreadConstraint parameters", fmt.Errorf("maxSkewReductionPerCycle can not be negative"))
+	}
+	topologyKeys := sets.NewString()
+	if strategy.Params != nil {
+		for _, key := range strategy.Params.TopologyBalanceDomains {
+			if errs := utilvalidation.IsQualifiedName(key); len(errs) > 0 {
+				return validation.NewStrategyError(validation.ErrorReasonInvalidParameters, "invalid RemovePodsViolatingTopologySpreadConstraint parameters",
+					fmt.Errorf("topologyBalanceDomains %q is not a valid label key: %v", key, strings.Join(errs, "; ")))
+			}
+			topologyKeys.Insert(key)
+		}
 	}
 
 	evictable := podEvictor.Evictable(
@@ -87,8 +106,7 @@ func RemovePodsViolatingTopologySpreadConstraint(
 	// First record all of the constraints by namespace
 	namespaces, err := client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
 	if err != nil {
-		klog.ErrorS(err, "Couldn't list namespaces")
-		return
+		return validation.NewStrategyError(validation.ErrorReasonAPI, "couldn't list namespaces", err)
 	}
 	klog.V(1).InfoS("Processing namespaces for topology spread constraints")
 	podsForEviction := make(map[*v1.Pod]struct{})
@@ -112,6 +130,10 @@ func RemovePodsViolatingTopologySpreadConstraint(
 				if constraint.WhenUnsatisfiable == v1.ScheduleAnyway && (strategy.Params == nil || !strategy.Params.IncludeSoftConstraints) {
 					continue
 				}
+				// Ignore constraints of topology keys which are not balanced
+				if topologyKeys.Len() > 0 && !topologyKeys.Has(constraint.TopologyKey) {
+					continue
+				}
 				namespaceTopologySpreadConstraints[constraint] = struct{}{}
 			}
 		}
@@ -170,7 +192,7 @@ func RemovePodsViolatingTopologySpreadConstraint(
 				klog.V(2).InfoS("Skipping topology constraint because it is already balanced", "constraint", constraint)
 				continue
 			}
-			balanceDomains(podsForEviction, constraint, constraintTopologies, sumPods, evictable.IsEvictable, nodeMap)
+			balanceDomains(podsForEviction, constraint, constraintTopologies, sumPods, evictable.IsEvictable, nodeMap, maxSkewReduction)
 		}
 	}
 
@@ -178,11 +200,12 @@ func RemovePodsViolatingTopologySpreadConstraint(
 		if !evictable.IsEvictable(pod) {
 			continue
 		}
-		if _, err := podEvictor.EvictPod(ctx, pod, nodeMap[pod.Spec.NodeName], "PodTopologySpread"); err != nil {
+		if _, err := podEvictor.EvictPod(ctx, pod, nodeMap[pod.Spec.NodeName], evictions.ReasonRemovePodsViolatingTopologySpreadConstraint); err != nil {
 			klog.ErrorS(err, "Error evicting pod", "pod", klog.KObj(pod))
 			break
 		}
 	}
+	return nil
 }
 
 // topologyIsBalanced checks if any domains in the topology differ by more than the MaxSkew
@@ -224,16 +247,21 @@ func topologyIsBalanced(topology map[topologyPair][]*v1.Pod, constraint v1.Topol
 // Following this, the above topology domains end up "sorted" as:
 // [5, 5, 5, 5, 5, 5]
 // (assuming even distribution by the scheduler of the evicted pods)
+//
+// When maxSkewReduction is positive, pods are moved one at a time and balancing stops once the skew between the
+// largest and the smallest domain is reduced by maxSkewReduction, the remaining skew being reduced by the next cycles.
 func balanceDomains(
 	podsForEviction map[*v1.Pod]struct{},
 	constraint v1.TopologySpreadConstraint,
 	constraintTopologies map[topologyPair][]*v1.Pod,
 	sumPods float64,
 	isEvictable func(*v1.Pod) bool,
-	nodeMap map[string]*v1.Node) {
+	nodeMap map[string]*v1.Node,
+	maxSkewReduction int32) {
 
 	idealAvg := sumPods / float64(len(constraintTopologies))
 	sortedDomains := sortDomains(constraintTopologies, isEvictable)
+	initialSkew := domainsSkew(sortedDomains)
 	// i is the index for belowOrEqualAvg
 	// j is the index for aboveAvg
 	i := 0
@@ -268,6 +296,13 @@ func balanceDomains(
 			i++
 			continue
 		}
+		if maxSkewReduction > 0 {
+			if int32(initialSkew-domainsSkew(sortedDomains)) >= maxSkewReduction {
+				klog.V(2).InfoS("Skew reduction of the cycle reached, the remaining skew is left to the next cycles", "constraint", constraint, "maxSkewReductionPerCycle", maxSkewReduction)
+				return
+			}
+			movePods = 1
+		}
 
 		// remove pods from the higher topology and add them to the list of pods to be evicted
 		// also (just for tracking), add them to the list of pods in the lower topology
@@ -285,6 +320,21 @@ func balanceDomains(
 	}
 }
 
+// domainsSkew returns the difference between the number of pods of the largest and the smallest domain
+func domainsSkew(domains []topology) int {
+	minDomainSize := math.MaxInt32
+	maxDomainSize := math.MinInt32
+	for _, domain := range domains {
+		if len(domain.pods) < minDomainSize {
+			minDomainSize = len(domain.pods)
+		}
+		if len(domain.pods) > maxDomainSize {
+			maxDomainSize = len(domain.pods)
+		}
+	}
+	return maxDomainSize - minDomainSize
+}
+
 // validatePodFitsOnOtherNodes performs validation based on scheduling predicates for affinity and toleration.
 // It excludes the current node because, for the sake of domain balancing only, we care about if there is any other
 // place it could theoretically fit.
@@ -17,6 +17,31 @@ import (
 
 func TestTopologySpreadConstraint(t *testing.T) {
 	ctx := context.Background()
+	chassisTestPods := []testPodList{
+		{
+			count:  1,
+			node:   "n1",
+			labels: map[string]string{"foo": "bar"},
+			constraints: []v1.TopologySpreadConstraint{
+				{
+					MaxSkew:           1,
+					TopologyKey:       "topology.example.com/chassis",
+					WhenUnsatisfiable: v1.DoNotSchedule,
+					LabelSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{"foo": "bar"}},
+				},
+			},
+		},
+		{
+			count:  2,
+			node:   "n1",
+			labels: map[string]string{"foo": "bar"},
+		},
+		{
+			count:  1,
+			node:   "n2",
+			labels: map[string]string{"foo": "bar"},
+		},
+	}
 	testCases := []struct {
 		name                 string
 		pods                 []*v1.Pod
@@ -253,6 +278,33 @@ func TestTopologySpreadConstraint(t *testing.T) {
 			},
 			namespaces: []string{"ns1"},
 		},
+		{
+			name: "2 domains, sizes [6,0], maxSkew=1, maxSkewReductionPerCycle=2, move 1 pod to achieve [5,1]",
+			nodes: []*v1.Node{
+				test.BuildTestNode("n1", 2000, 3000, 10, func(n *v1.Node) { n.Labels["zone"] = "zoneA" }),
+				test.BuildTestNode("n2", 2000, 3000, 10, func(n *v1.Node) { n.Labels["zone"] = "zoneB" }),
+			},
+			pods: createTestPods([]testPodList{
+				{
+					count:       1,
+					node:        "n1",
+					labels:      map[string]string{"foo": "bar"},
+					constraints: getDefaultTopologyConstraints(1),
+				},
+				{
+					count:  5,
+					node:   "n1",
+					labels: map[string]string{"foo": "bar"},
+				},
+			}),
+			expectedEvictedCount: 1,
+			strategy: api.DeschedulerStrategy{
+				Params: &api.StrategyParameters{
+					MaxSkewReductionPerCycle: 2,
+				},
+			},
+			namespaces: []string{"ns1"},
+		},
 		{
 			name: "2 domains, sizes [4,0], maxSkew=1, only move 1 pod since pods with nodeSelector and nodeAffinity aren't evicted",
 			nodes: []*v1.Node{
@@ -866,6 +918,51 @@ func TestTopologySpreadConstraint(t *testing.T) {
 			},
 			namespaces: []string{"ns1"},
 		},
+		{
+			name: "2 chassis, sizes [3,1], maxSkew=1, move 1 pod to achieve [2,2] (custom topology key allowed)",
+			nodes: []*v1.Node{
+				test.BuildTestNode("n1", 2000, 3000, 10, func(n *v1.Node) { n.Labels["topology.example.com/chassis"] = "chassisA" }),
+				test.BuildTestNode("n2", 2000, 3000, 10, func(n *v1.Node) { n.Labels["topology.example.com/chassis"] = "chassisB" }),
+			},
+			pods:                 createTestPods(chassisTestPods),
+			expectedEvictedCount: 1,
+			strategy: api.DeschedulerStrategy{
+				Params: &api.StrategyParameters{
+					TopologyBalanceDomains: []string{"topology.example.com/chassis"},
+				},
+			},
+			namespaces: []string{"ns1"},
+		},
+		{
+			name: "2 chassis, sizes [3,1], maxSkew=1, move 0 pods (custom topology key not allowed)",
+			nodes: []*v1.Node{
+				test.BuildTestNode("n1", 2000, 3000, 10, func(n *v1.Node) { n.Labels["topology.example.com/chassis"] = "chassisA" }),
+				test.BuildTestNode("n2", 2000, 3000, 10, func(n *v1.Node) { n.Labels["topology.example.com/chassis"] = "chassisB" }),
+			},
+			pods:                 createTestPods(chassisTestPods),
+			expectedEvictedCount: 0,
+			strategy: api.DeschedulerStrategy{
+				Params: &api.StrategyParameters{
+					TopologyBalanceDomains: []string{"topology.kubernetes.io/zone"},
+				},
+			},
+			namespaces: []string{"ns1"},
+		},
+		{
+			name: "invalid topology balance domain, move 0 pods",
+			nodes: []*v1.Node{
+				test.BuildTestNode("n1", 2000, 3000, 10, func(n *v1.Node) { n.Labels["topology.example.com/chassis"] = "chassisA" }),
+				test.BuildTestNode("n2", 2000, 3000, 10, func(n *v1.Node) { n.Labels["topology.example.com/chassis"] = "chassisB" }),
+			},
+			pods:                 createTestPods(chassisTestPods),
+			expectedEvictedCount: 0,
+			strategy: api.DeschedulerStrategy{
+				Params: &api.StrategyParameters{
+					TopologyBalanceDomains: []string{"not a label"},
+				},
+			},
+			namespaces: []string{"ns1"},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -887,6 +984,7 @@ func TestTopologySpreadConstraint(t *testing.T) {
 				"v1",
 				false,
 				100,
+				0,
 				tc.nodes,
 				false,
 				false,
@@ -0,0 +1,72 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"errors"
+)
+
+// ErrorReason classifies why a strategy could not run
+type ErrorReason string
+
+const (
+	// ErrorReasonInvalidParameters is returned by strategies with invalid parameters
+	ErrorReasonInvalidParameters ErrorReason = "InvalidParameters"
+	// ErrorReasonPriorityLookup is returned by strategies failing to resolve their threshold priority
+	ErrorReasonPriorityLookup ErrorReason = "PriorityLookup"
+	// ErrorReasonAPI is returned by strategies failing to get the objects they work on from the API server
+	ErrorReasonAPI ErrorReason = "APIError"
+	// ErrorReasonUnknown is the reason of errors which are not StrategyErrors
+	ErrorReasonUnknown ErrorReason = "Unknown"
+)
+
+// StrategyError is returned by a strategy which could not run
+type StrategyError struct {
+	Reason  ErrorReason
+	Message string
+	Err     error
+}
+
+func (e *StrategyError) Error() string {
+	if e.Err == nil {
+		return e.Message
+	}
+	return e.Message + ": " + e.Err.Error()
+}
+
+func (e *StrategyError) Unwrap() error {
+	return e.Err
+}
+
+// NewStrategyError returns a StrategyError of the given reason, unless err already is a
+// StrategyError, e.g. returned by ValidateAndParseStrategyParams, whose reason is kept
+func NewStrategyError(reason ErrorReason, message string, err error) error {
+	var strategyErr *StrategyError
+	if errors.As(err, &strategyErr) {
+		reason = strategyErr.Reason
+	}
+	return &StrategyError{Reason: reason, Message: message, Err: err}
+}
+
+// ReasonForError returns the reason of a StrategyError, ErrorReasonUnknown for other errors
+func ReasonForError(err error) ErrorReason {
+	var strategyErr *StrategyError
+	if errors.As(err, &strategyErr) {
+		return strategyErr.Reason
+	}
+	return ErrorReasonUnknown
+}
@@ -47,7 +47,7 @@ func ValidateAndParseStrategyParams(
 
 	thresholdPriority, err := utils.GetPriorityFromStrategyParams(ctx, client, params)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get threshold priority from strategy's params: %+v", err)
+		return nil, &StrategyError{Reason: ErrorReasonPriorityLookup, Message: "failed to get threshold priority from strategy's params", Err: err}
 	}
 	if params.Namespaces != nil {
 		includedNamespaces = sets.NewString(params.Namespaces.Include...)
@@ -0,0 +1,190 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+	"sigs.k8s.io/descheduler/pkg/utils"
+)
+
+// EvictionSettings are the policy settings deciding which pods of a node are evictable
+type EvictionSettings struct {
+	EvictLocalStoragePods   bool
+	EvictSystemCriticalPods bool
+	IgnorePVCPods           bool
+	// EvictableEmptyDirSizeLimit lets pods with small emptyDir volumes be evicted without EvictLocalStoragePods
+	EvictableEmptyDirSizeLimit *resource.Quantity
+}
+
+// Assessment tells whether the evictable pods of a node fit on the other nodes
+type Assessment struct {
+	Node string
+	// EvictablePods is the number of pods which would be evicted from the node
+	EvictablePods int
+	// UnplaceablePods lists the evictable pods no other node has room for, as namespace/name
+	UnplaceablePods []string
+}
+
+// Feasible is true when all evictable pods fit on other nodes
+func (a Assessment) Feasible() bool {
+	return len(a.UnplaceablePods) == 0
+}
+
+// String describes the assessment in a human readable form
+func (a Assessment) String() string {
+	if a.Feasible() {
+		return fmt.Sprintf("all %d evictable pods of node %s fit on other nodes", a.EvictablePods, a.Node)
+	}
+	return fmt.Sprintf("%d of %d evictable pods of node %s do not fit on other nodes: %v", len(a.UnplaceablePods), a.EvictablePods, a.Node, a.UnplaceablePods)
+}
+
+// nodeCapacity tracks the resources left on a candidate node
+type nodeCapacity struct {
+	node *v1.Node
+	free v1.ResourceList
+}
+
+// Assess checks whether the evictable pods of the node fit on the other ready and schedulable
+// nodes. Pods are placed one by one, from the largest to the smallest, on the first node
+// satisfying their node selector, affinity and tolerations with enough free resources.
+// excludedNode tells which other nodes are about to be removed too and can't be used.
+func Assess(ctx context.Context, client clientset.Interface, node *v1.Node, settings EvictionSettings, excludedNode func(*v1.Node) bool) (Assessment, error) {
+	assessment := Assessment{Node: node.Name}
+
+	nodeList, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return assessment, fmt.Errorf("unable to list nodes: %v", err)
+	}
+	fieldSelector, err := fields.ParseSelector("status.phase!=" + string(v1.PodSucceeded) + ",status.phase!=" + string(v1.PodFailed))
+	if err != nil {
+		return assessment, err
+	}
+	podList, err := client.CoreV1().Pods(v1.NamespaceAll).List(ctx, metav1.ListOptions{FieldSelector: fieldSelector.String()})
+	if err != nil {
+		return assessment, fmt.Errorf("unable to list pods: %v", err)
+	}
+	podsByNode := map[string][]*v1.Pod{}
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+			continue
+		}
+		podsByNode[pod.Spec.NodeName] = append(podsByNode[pod.Spec.NodeName], pod)
+	}
+
+	var nodes []*v1.Node
+	var candidates []*nodeCapacity
+	for i := range nodeList.Items {
+		n := &nodeList.Items[i]
+		nodes = append(nodes, n)
+		if n.Name == node.Name || !nodeutil.IsReady(n) || nodeutil.IsNodeCordoned(n) || (excludedNode != nil && excludedNode(n)) {
+			continue
+		}
+		candidates = append(candidates, &nodeCapacity{node: n, free: freeResources(n, podsByNode[n.Name])})
+	}
+
+	podEvictor := evictions.NewPodEvictor(client, "", true, 0, 0, nodes, settings.EvictLocalStoragePods, settings.EvictSystemCriticalPods, settings.IgnorePVCPods)
+	podEvictor.SetEmptyDirSizeLimit(settings.EvictableEmptyDirSizeLimit)
+	evictable := podEvictor.Evictable()
+	var pods []*v1.Pod
+	for _, pod := range podsByNode[node.Name] {
+		if evictable.IsEvictable(pod) {
+			pods = append(pods, pod)
+		}
+	}
+	assessment.EvictablePods = len(pods)
+
+	// place the largest pods first
+	sort.SliceStable(pods, func(i, j int) bool {
+		ri, _ := utils.PodRequestsAndLimits(pods[i])
+		rj, _ := utils.PodRequestsAndLimits(pods[j])
+		if !ri.Cpu().Equal(*rj.Cpu()) {
+			return ri.Cpu().Cmp(*rj.Cpu()) > 0
+		}
+		return ri.Memory().Cmp(*rj.Memory()) > 0
+	})
+	for _, pod := range pods {
+		if !place(pod, candidates) {
+			klog.V(2).InfoS("Evictable pod does not fit on any other node", "pod", klog.KObj(pod), "node", klog.KObj(node))
+			assessment.UnplaceablePods = append(assessment.UnplaceablePods, pod.Namespace+"/"+pod.Name)
+		}
+	}
+	return assessment, nil
+}
+
+// freeResources returns the allocatable resources of the node not requested by its pods
+func freeResources(node *v1.Node, pods []*v1.Pod) v1.ResourceList {
+	free := v1.ResourceList{}
+	allocatable := node.Status.Allocatable
+	if len(allocatable) == 0 {
+		allocatable = node.Status.Capacity
+	}
+	for name, quantity := range allocatable {
+		free[name] = quantity.DeepCopy()
+	}
+	for _, pod := range pods {
+		subtractRequests(free, pod)
+	}
+	return free
+}
+
+// subtractRequests subtracts the pod requests, counting the pod itself, from the free resources
+func subtractRequests(free v1.ResourceList, pod *v1.Pod) {
+	requests, _ := utils.PodRequestsAndLimits(pod)
+	requests[v1.ResourcePods] = *resource.NewQuantity(1, resource.DecimalSI)
+	for name, quantity := range requests {
+		if value, ok := free[name]; ok {
+			value.Sub(quantity)
+			free[name] = value
+		}
+	}
+}
+
+// place reserves resources for the pod on the first fitting candidate
+func place(pod *v1.Pod, candidates []*nodeCapacity) bool {
+	requests, _ := utils.PodRequestsAndLimits(pod)
+	for _, candidate := range candidates {
+		if !nodeutil.PodFitsAnyOtherNode(pod, []*v1.Node{candidate.node}) {
+			continue
+		}
+		fits := candidate.free.Pods().CmpInt64(1) >= 0
+		for name, quantity := range requests {
+			value, ok := candidate.free[name]
+			if (ok && value.Cmp(quantity) < 0) || (!ok && !quantity.IsZero()) {
+				fits = false
+				break
+			}
+		}
+		if fits {
+			subtractRequests(candidate.free, pod)
+			return true
+		}
+	}
+	return false
+}
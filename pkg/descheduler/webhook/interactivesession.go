@@ -0,0 +1,76 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+)
+
+// InteractiveSessionPath is the path the interactive session webhook is served on
+const InteractiveSessionPath = "/record-interactive-session"
+
+// interactiveSubresources are the pod subresources opening an interactive session
+var interactiveSubresources = sets.NewString("exec", "attach", "portforward")
+
+// InteractiveSessionHandler reviews connections to pods. When an exec, attach or port-forward
+// session is opened, the pod is annotated with the current time, so the descheduler does not
+// evict it during the interactive session lookback window. Connections are always allowed.
+type InteractiveSessionHandler struct {
+	Client clientset.Interface
+}
+
+func (h *InteractiveSessionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	serveAdmissionReview(w, r, h.review)
+}
+
+func (h *InteractiveSessionHandler) review(r *http.Request, request *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	allowed := &admissionv1.AdmissionResponse{Allowed: true}
+	if request.Operation != admissionv1.Connect || request.Resource.Resource != "pods" || !interactiveSubresources.Has(request.SubResource) {
+		return allowed
+	}
+	if request.DryRun != nil && *request.DryRun {
+		return allowed
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{evictions.InteractiveSessionAnnotationKey: time.Now().UTC().Format(time.RFC3339)},
+		},
+	})
+	if err != nil {
+		klog.ErrorS(err, "Unable to encode interactive session annotation")
+		return allowed
+	}
+	// never block connections because of the descheduler
+	if _, err := h.Client.CoreV1().Pods(request.Namespace).Patch(r.Context(), request.Name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		klog.ErrorS(err, "Unable to record interactive session", "pod", klog.KRef(request.Namespace, request.Name), "subresource", request.SubResource)
+		return allowed
+	}
+	klog.V(2).InfoS("Recorded interactive session", "pod", klog.KRef(request.Namespace, request.Name), "subresource", request.SubResource, "user", request.UserInfo.Username)
+	return allowed
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package webhook implements a validating admission webhook assessing, when a node
+// is cordoned or labeled for removal, whether its evictable pods fit on other nodes,
+// and recording the interactive sessions opened to pods.
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+)
+
+// NodeCordonPath is the path the node cordon webhook is served on
+const NodeCordonPath = "/validate-node-cordon"
+
+// NodeCordonHandler reviews node updates. When a node gets cordoned or labeled with
+// RemovalLabel, the evictable pods of the node are checked to fit on other nodes.
+// The assessment is returned as an admission warning, infeasible updates are
+// rejected when DenyInfeasible is set.
+type NodeCordonHandler struct {
+	Client           clientset.Interface
+	EvictionSettings EvictionSettings
+	// RemovalLabel marks nodes about to be removed, ignored when empty
+	RemovalLabel   string
+	DenyInfeasible bool
+}
+
+func (h *NodeCordonHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	serveAdmissionReview(w, r, h.review)
+}
+
+// serveAdmissionReview decodes the admission review of the request and encodes the response of the review function
+func serveAdmissionReview(w http.ResponseWriter, r *http.Request, reviewFunc func(*http.Request, *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("unable to read request: %v", err), http.StatusBadRequest)
+		return
+	}
+	review := &admissionv1.AdmissionReview{}
+	if err := json.Unmarshal(body, review); err != nil || review.Request == nil {
+		http.Error(w, "invalid admission review", http.StatusBadRequest)
+		return
+	}
+
+	review.Response = reviewFunc(r, review.Request)
+	review.Response.UID = review.Request.UID
+	review.Request = nil
+	data, err := json.Marshal(review)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("unable to encode response: %v", err), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(data); err != nil {
+		klog.ErrorS(err, "Unable to write admission response")
+	}
+}
+
+func (h *NodeCordonHandler) review(r *http.Request, request *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	allowed := &admissionv1.AdmissionResponse{Allowed: true}
+	if request.Operation != admissionv1.Update {
+		return allowed
+	}
+	node, oldNode := &v1.Node{}, &v1.Node{}
+	if err := json.Unmarshal(request.Object.Raw, node); err != nil {
+		klog.ErrorS(err, "Unable to decode node")
+		return allowed
+	}
+	if err := json.Unmarshal(request.OldObject.Raw, oldNode); err != nil {
+		klog.ErrorS(err, "Unable to decode node")
+		return allowed
+	}
+	if !h.markedForRemoval(node) || h.markedForRemoval(oldNode) {
+		return allowed
+	}
+
+	assessment, err := Assess(r.Context(), h.Client, node, h.EvictionSettings, h.markedForRemoval)
+	if err != nil {
+		// never block node updates because of the descheduler
+		klog.ErrorS(err, "Unable to assess node removal", "node", klog.KObj(node))
+		return allowed
+	}
+	klog.V(1).InfoS("Assessed node removal", "node", klog.KObj(node), "feasible", assessment.Feasible(), "evictablePods", assessment.EvictablePods, "unplaceablePods", assessment.UnplaceablePods)
+	if assessment.Feasible() {
+		return allowed
+	}
+	if h.DenyInfeasible {
+		return &admissionv1.AdmissionResponse{
+			Allowed: false,
+			Result:  &metav1.Status{Status: metav1.StatusFailure, Reason: metav1.StatusReasonForbidden, Code: http.StatusForbidden, Message: "descheduler: " + assessment.String()},
+		}
+	}
+	return &admissionv1.AdmissionResponse{Allowed: true, Warnings: []string{"descheduler: " + assessment.String()}}
+}
+
+// markedForRemoval checks if the node is cordoned or labeled for removal
+func (h *NodeCordonHandler) markedForRemoval(node *v1.Node) bool {
+	if node.Spec.Unschedulable {
+		return true
+	}
+	if h.RemovalLabel == "" {
+		return false
+	}
+	_, ok := node.Labels[h.RemovalLabel]
+	return ok
+}
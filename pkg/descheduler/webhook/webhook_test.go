@@ -0,0 +1,208 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestNodeCordonHandler(t *testing.T) {
+	n1 := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	n2 := test.BuildTestNode("n2", 1000, 3000, 10, nil)
+	p1 := test.BuildTestPod("p1", 600, 0, n1.Name, test.SetRSOwnerRef)
+	p2 := test.BuildTestPod("p2", 600, 0, n1.Name, test.SetRSOwnerRef)
+	ds := test.BuildTestPod("ds", 600, 0, n1.Name, test.SetDSOwnerRef)
+	small := test.BuildTestPod("small", 100, 0, n1.Name, test.SetRSOwnerRef)
+
+	cordoned := n1.DeepCopy()
+	cordoned.Spec.Unschedulable = true
+	labeled := n1.DeepCopy()
+	labeled.Labels["example.com/remove"] = ""
+
+	tests := []struct {
+		description     string
+		pods            []*v1.Pod
+		oldNode         *v1.Node
+		node            *v1.Node
+		denyInfeasible  bool
+		expectedAllowed bool
+		expectWarning   bool
+	}{
+		{
+			description:     "Pods fit on other nodes",
+			pods:            []*v1.Pod{p1, ds, small},
+			oldNode:         n1,
+			node:            cordoned,
+			expectedAllowed: true,
+		},
+		{
+			description:     "Pods do not fit on other nodes, warning",
+			pods:            []*v1.Pod{p1, p2, small},
+			oldNode:         n1,
+			node:            cordoned,
+			expectedAllowed: true,
+			expectWarning:   true,
+		},
+		{
+			description:     "Pods do not fit on other nodes, denied",
+			pods:            []*v1.Pod{p1, p2},
+			oldNode:         n1,
+			node:            labeled,
+			denyInfeasible:  true,
+			expectedAllowed: false,
+		},
+		{
+			description:     "Node already cordoned",
+			pods:            []*v1.Pod{p1, p2},
+			oldNode:         cordoned,
+			node:            cordoned,
+			denyInfeasible:  true,
+			expectedAllowed: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			objs := []runtime.Object{n1, n2}
+			for _, pod := range tc.pods {
+				objs = append(objs, pod)
+			}
+			handler := &NodeCordonHandler{
+				Client:         fake.NewSimpleClientset(objs...),
+				RemovalLabel:   "example.com/remove",
+				DenyInfeasible: tc.denyInfeasible,
+			}
+
+			oldRaw, _ := json.Marshal(tc.oldNode)
+			raw, _ := json.Marshal(tc.node)
+			review := admissionv1.AdmissionReview{
+				Request: &admissionv1.AdmissionRequest{
+					UID:       "uid",
+					Operation: admissionv1.Update,
+					Object:    runtime.RawExtension{Raw: raw},
+					OldObject: runtime.RawExtension{Raw: oldRaw},
+				},
+			}
+			body, _ := json.Marshal(review)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, NodeCordonPath, bytes.NewReader(body)))
+
+			response := admissionv1.AdmissionReview{}
+			if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Unable to decode response: %v", err)
+			}
+			if response.Response == nil || response.Response.UID != "uid" {
+				t.Fatalf("Unexpected response %#v", response)
+			}
+			if response.Response.Allowed != tc.expectedAllowed {
+				t.Errorf("Expected allowed to be %v, got %v", tc.expectedAllowed, response.Response.Allowed)
+			}
+			if hasWarning := len(response.Response.Warnings) > 0; hasWarning != tc.expectWarning {
+				t.Errorf("Expected warning to be %v, got %v", tc.expectWarning, response.Response.Warnings)
+			}
+		})
+	}
+}
+
+func TestInteractiveSessionHandler(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		description       string
+		operation         admissionv1.Operation
+		subResource       string
+		dryRun            bool
+		expectedAnnotated bool
+	}{
+		{
+			description:       "Exec session is recorded",
+			operation:         admissionv1.Connect,
+			subResource:       "exec",
+			expectedAnnotated: true,
+		},
+		{
+			description:       "Port-forward session is recorded",
+			operation:         admissionv1.Connect,
+			subResource:       "portforward",
+			expectedAnnotated: true,
+		},
+		{
+			description: "Proxy connections are not interactive sessions",
+			operation:   admissionv1.Connect,
+			subResource: "proxy",
+		},
+		{
+			description: "Dry run connections are not recorded",
+			operation:   admissionv1.Connect,
+			subResource: "attach",
+			dryRun:      true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			pod := test.BuildTestPod("p1", 100, 0, "n1", test.SetRSOwnerRef)
+			client := fake.NewSimpleClientset(pod)
+			handler := &InteractiveSessionHandler{Client: client}
+
+			review := admissionv1.AdmissionReview{
+				Request: &admissionv1.AdmissionRequest{
+					UID:         "uid",
+					Operation:   tc.operation,
+					Resource:    metav1.GroupVersionResource{Version: "v1", Resource: "pods"},
+					SubResource: tc.subResource,
+					Namespace:   pod.Namespace,
+					Name:        pod.Name,
+					DryRun:      &tc.dryRun,
+				},
+			}
+			body, _ := json.Marshal(review)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, InteractiveSessionPath, bytes.NewReader(body)))
+
+			response := admissionv1.AdmissionReview{}
+			if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Unable to decode response: %v", err)
+			}
+			if response.Response == nil || !response.Response.Allowed {
+				t.Fatalf("Expected the connection to be allowed, got %#v", response)
+			}
+
+			updated, err := client.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Unable to get pod: %v", err)
+			}
+			_, annotated := updated.Annotations[evictions.InteractiveSessionAnnotationKey]
+			if annotated != tc.expectedAnnotated {
+				t.Errorf("Expected pod to be annotated: %v, got %v", tc.expectedAnnotated, annotated)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package framework lets out-of-tree strategies be compiled in the descheduler as plugins. A plugin
+// registers a builder under its name, usually from the init function of its package, and is enabled
+// in the policy under that name like the built-in strategies. Each time the strategy runs, the plugin
+// is built for its configuration and its extension points are called: Deschedule, then Balance.
+package framework
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	clientset "k8s.io/client-go/kubernetes"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+)
+
+// Handle gives plugins access to the cluster and to the evictor of the descheduling cycle
+type Handle interface {
+	// ClientSet is the client of the cluster
+	ClientSet() clientset.Interface
+	// Evictor evicts pods within the limits of the policy, and tells which pods are evictable
+	Evictor() *evictions.PodEvictor
+}
+
+// Plugin is the common interface of plugins
+type Plugin interface {
+	Name() string
+}
+
+// DeschedulePlugin evicts pods violating a constraint regardless of the other pods, e.g. pods on
+// nodes whose taints they do not tolerate
+type DeschedulePlugin interface {
+	Plugin
+	Deschedule(ctx context.Context, nodes []*v1.Node) error
+}
+
+// BalancePlugin evicts pods to change their distribution across nodes, e.g. to spread duplicates
+type BalancePlugin interface {
+	Plugin
+	Balance(ctx context.Context, nodes []*v1.Node) error
+}
+
+// PluginBuilder builds a plugin for the configuration of the strategy enabling it in the policy
+type PluginBuilder func(strategy api.DeschedulerStrategy, handle Handle) (Plugin, error)
+
+// Registry maps the names of plugins to their builders
+type Registry map[string]PluginBuilder
+
+// Register adds a plugin to the registry, names must be unique
+func (r Registry) Register(name string, builder PluginBuilder) error {
+	if name == "" {
+		return fmt.Errorf("plugin name must not be empty")
+	}
+	if builder == nil {
+		return fmt.Errorf("plugin %q has no builder", name)
+	}
+	if _, ok := r[name]; ok {
+		return fmt.Errorf("plugin %q is already registered", name)
+	}
+	r[name] = builder
+	return nil
+}
+
+var (
+	registryLock sync.RWMutex
+	registry     = Registry{}
+)
+
+// Register adds a plugin to the registry the descheduler enables strategies from. Plugins can not
+// replace the built-in strategies, which take precedence over plugins of the same name.
+func Register(name string, builder PluginBuilder) error {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	return registry.Register(name, builder)
+}
+
+// RegisteredPlugins returns a copy of the registry the descheduler enables strategies from
+func RegisteredPlugins() Registry {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	plugins := make(Registry, len(registry))
+	for name, builder := range registry {
+		plugins[name] = builder
+	}
+	return plugins
+}
+
+// DecodeArgs decodes the pluginArgs of the strategy parameters into args, refusing unknown fields.
+// args is left untouched when the strategy has no pluginArgs.
+func DecodeArgs(strategy api.DeschedulerStrategy, args interface{}) error {
+	if strategy.Params == nil || strategy.Params.PluginArgs == nil || len(strategy.Params.PluginArgs.Raw) == 0 {
+		return nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(strategy.Params.PluginArgs.Raw))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(args); err != nil {
+		return fmt.Errorf("unable to decode pluginArgs: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+func TestRegistry(t *testing.T) {
+	builder := func(strategy api.DeschedulerStrategy, handle Handle) (Plugin, error) {
+		return nil, nil
+	}
+	registry := Registry{}
+	if err := registry.Register("MyPlugin", builder); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	tests := []struct {
+		name    string
+		builder PluginBuilder
+		errMsg  string
+	}{
+		{name: "MyPlugin", builder: builder, errMsg: `plugin "MyPlugin" is already registered`},
+		{name: "", builder: builder, errMsg: "plugin name must not be empty"},
+		{name: "NoBuilder", errMsg: `plugin "NoBuilder" has no builder`},
+	}
+	for _, tc := range tests {
+		if err := registry.Register(tc.name, tc.builder); err == nil || err.Error() != tc.errMsg {
+			t.Errorf("Expected error %q registering %q, got %v", tc.errMsg, tc.name, err)
+		}
+	}
+	if len(registry) != 1 {
+		t.Errorf("Expected 1 registered plugin, got %v", len(registry))
+	}
+}
+
+func TestDecodeArgs(t *testing.T) {
+	type args struct {
+		Threshold int `json:"threshold"`
+	}
+	tests := []struct {
+		name     string
+		strategy api.DeschedulerStrategy
+		expected args
+		errMsg   string
+	}{
+		{
+			name:     "no params",
+			strategy: api.DeschedulerStrategy{},
+			expected: args{Threshold: 1},
+		},
+		{
+			name:     "args",
+			strategy: api.DeschedulerStrategy{Params: &api.StrategyParameters{PluginArgs: &runtime.RawExtension{Raw: []byte(`{"threshold":5}`)}}},
+			expected: args{Threshold: 5},
+		},
+		{
+			name:     "unknown field",
+			strategy: api.DeschedulerStrategy{Params: &api.StrategyParameters{PluginArgs: &runtime.RawExtension{Raw: []byte(`{"treshold":5}`)}}},
+			expected: args{Threshold: 1},
+			errMsg:   `unable to decode pluginArgs: json: unknown field "treshold"`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			decoded := args{Threshold: 1}
+			err := DecodeArgs(tc.strategy, &decoded)
+			if tc.errMsg == "" && err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if tc.errMsg != "" && (err == nil || err.Error() != tc.errMsg) {
+				t.Fatalf("Expected error %q, got %v", tc.errMsg, err)
+			}
+			if decoded != tc.expected {
+				t.Errorf("Expected args %v, got %v", tc.expected, decoded)
+			}
+		})
+	}
+}
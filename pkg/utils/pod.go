@@ -2,10 +2,12 @@ package utils
 
 import (
 	"fmt"
+	"path"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/runtime"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	"k8s.io/component-base/featuregate"
 	"k8s.io/klog/v2"
@@ -25,6 +27,17 @@ const (
 	PodOverhead featuregate.Feature = "PodOverhead"
 )
 
+// FeatureGates are the features the descheduler registers in the default feature gate
+var FeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
+	// LocalStorageCapacityIsolation is enabled by default since v1.10, ephemeral storage
+	// requests would be ignored if the feature was left unknown to the feature gate.
+	LocalStorageCapacityIsolation: {Default: true, PreRelease: featuregate.Beta},
+}
+
+func init() {
+	runtime.Must(utilfeature.DefaultMutableFeatureGate.Add(FeatureGates))
+}
+
 // GetResourceRequest finds and returns the request value for a specific resource.
 func GetResourceRequest(pod *v1.Pod, resource v1.ResourceName) int64 {
 	if resource == v1.ResourcePods {
@@ -42,16 +55,23 @@ func GetResourceRequest(pod *v1.Pod, resource v1.ResourceName) int64 {
 
 // GetResourceRequestQuantity finds and returns the request quantity for a specific resource.
 func GetResourceRequestQuantity(pod *v1.Pod, resourceName v1.ResourceName) resource.Quantity {
-	requestQuantity := resource.Quantity{}
+	return GetResourceRequestQuantityExcludingContainers(pod, resourceName, nil)
+}
 
+// zeroQuantity returns a zero quantity of the resource, in the format quantities of the resource are expressed in
+func zeroQuantity(resourceName v1.ResourceName) resource.Quantity {
 	switch resourceName {
-	case v1.ResourceCPU:
-		requestQuantity = resource.Quantity{Format: resource.DecimalSI}
 	case v1.ResourceMemory, v1.ResourceStorage, v1.ResourceEphemeralStorage:
-		requestQuantity = resource.Quantity{Format: resource.BinarySI}
+		return resource.Quantity{Format: resource.BinarySI}
 	default:
-		requestQuantity = resource.Quantity{Format: resource.DecimalSI}
+		return resource.Quantity{Format: resource.DecimalSI}
 	}
+}
+
+// GetResourceRequestQuantityExcludingContainers finds and returns the request quantity for a specific resource
+// ignoring containers whose name matches any of the excludedContainers patterns.
+func GetResourceRequestQuantityExcludingContainers(pod *v1.Pod, resourceName v1.ResourceName, excludedContainers []string) resource.Quantity {
+	requestQuantity := zeroQuantity(resourceName)
 
 	if resourceName == v1.ResourceEphemeralStorage && !utilfeature.DefaultFeatureGate.Enabled(LocalStorageCapacityIsolation) {
 		// if the local storage capacity isolation feature gate is disabled, pods request 0 disk
@@ -59,12 +79,18 @@ func GetResourceRequestQuantity(pod *v1.Pod, resourceName v1.ResourceName) resou
 	}
 
 	for _, container := range pod.Spec.Containers {
+		if IsContainerExcluded(container.Name, excludedContainers) {
+			continue
+		}
 		if rQuantity, ok := container.Resources.Requests[resourceName]; ok {
 			requestQuantity.Add(rQuantity)
 		}
 	}
 
 	for _, container := range pod.Spec.InitContainers {
+		if IsContainerExcluded(container.Name, excludedContainers) {
+			continue
+		}
 		if rQuantity, ok := container.Resources.Requests[resourceName]; ok {
 			if requestQuantity.Cmp(rQuantity) < 0 {
 				requestQuantity = rQuantity.DeepCopy()
@@ -121,6 +147,24 @@ func IsPodWithLocalStorage(pod *v1.Pod) bool {
 	return false
 }
 
+// IsPodWithProtectedLocalStorage returns true if the pod has local storage other than emptyDir volumes
+// with a sizeLimit up to the given size limit. All local storage is protected without size limit.
+func IsPodWithProtectedLocalStorage(pod *v1.Pod, emptyDirSizeLimit *resource.Quantity) bool {
+	if emptyDirSizeLimit == nil {
+		return IsPodWithLocalStorage(pod)
+	}
+	for _, volume := range pod.Spec.Volumes {
+		if volume.HostPath != nil {
+			return true
+		}
+		if volume.EmptyDir != nil && (volume.EmptyDir.SizeLimit == nil || volume.EmptyDir.SizeLimit.Cmp(*emptyDirSizeLimit) > 0) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // IsPodWithLocalStorage returns true if the pod has claimed a persistent volume.
 func IsPodWithPVC(pod *v1.Pod) bool {
 	for _, volume := range pod.Spec.Volumes {
@@ -141,18 +185,69 @@ func GetPodSource(pod *v1.Pod) (string, error) {
 	return "", fmt.Errorf("cannot get source of pod %q", pod.UID)
 }
 
+// GetResourceLimitQuantityExcludingContainers finds and returns the limit quantity for a specific resource
+// ignoring containers whose name matches any of the excludedContainers patterns. The request of containers
+// without a limit on the resource is counted instead, as they may use at least their request.
+func GetResourceLimitQuantityExcludingContainers(pod *v1.Pod, resourceName v1.ResourceName, excludedContainers []string) resource.Quantity {
+	limitQuantity := zeroQuantity(resourceName)
+
+	containerLimit := func(container v1.Container) (resource.Quantity, bool) {
+		if quantity, ok := container.Resources.Limits[resourceName]; ok {
+			return quantity, true
+		}
+		quantity, ok := container.Resources.Requests[resourceName]
+		return quantity, ok
+	}
+	for _, container := range pod.Spec.Containers {
+		if IsContainerExcluded(container.Name, excludedContainers) {
+			continue
+		}
+		if quantity, ok := containerLimit(container); ok {
+			limitQuantity.Add(quantity)
+		}
+	}
+	for _, container := range pod.Spec.InitContainers {
+		if IsContainerExcluded(container.Name, excludedContainers) {
+			continue
+		}
+		if quantity, ok := containerLimit(container); ok && limitQuantity.Cmp(quantity) < 0 {
+			limitQuantity = quantity.DeepCopy()
+		}
+	}
+
+	if pod.Spec.Overhead != nil && utilfeature.DefaultFeatureGate.Enabled(PodOverhead) {
+		if podOverhead, ok := pod.Spec.Overhead[resourceName]; ok && !limitQuantity.IsZero() {
+			limitQuantity.Add(podOverhead)
+		}
+	}
+
+	return limitQuantity
+}
+
 // PodRequestsAndLimits returns a dictionary of all defined resources summed up for all
 // containers of the pod. If PodOverhead feature is enabled, pod overhead is added to the
 // total container resource requests and to the total container limits which have a
 // non-zero quantity.
 func PodRequestsAndLimits(pod *v1.Pod) (reqs, limits v1.ResourceList) {
+	return PodRequestsAndLimitsExcludingContainers(pod, nil)
+}
+
+// PodRequestsAndLimitsExcludingContainers works like PodRequestsAndLimits but ignores
+// containers whose name matches any of the excludedContainers patterns.
+func PodRequestsAndLimitsExcludingContainers(pod *v1.Pod, excludedContainers []string) (reqs, limits v1.ResourceList) {
 	reqs, limits = v1.ResourceList{}, v1.ResourceList{}
 	for _, container := range pod.Spec.Containers {
+		if IsContainerExcluded(container.Name, excludedContainers) {
+			continue
+		}
 		addResourceList(reqs, container.Resources.Requests)
 		addResourceList(limits, container.Resources.Limits)
 	}
 	// init containers define the minimum of any resource
 	for _, container := range pod.Spec.InitContainers {
+		if IsContainerExcluded(container.Name, excludedContainers) {
+			continue
+		}
 		maxResourceList(reqs, container.Resources.Requests)
 		maxResourceList(limits, container.Resources.Limits)
 	}
@@ -173,6 +268,17 @@ func PodRequestsAndLimits(pod *v1.Pod) (reqs, limits v1.ResourceList) {
 	return
 }
 
+// IsContainerExcluded returns true if the container name matches any of the given patterns.
+// Patterns follow the path.Match syntax, malformed patterns never match.
+func IsContainerExcluded(name string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if matched, err := path.Match(pattern, name); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
 // addResourceList adds the resources in newList to list
 func addResourceList(list, newList v1.ResourceList) {
 	for name, quantity := range newList {
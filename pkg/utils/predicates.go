@@ -192,6 +192,18 @@ func NodeSelectorsEqual(n1, n2 *v1.NodeSelector) bool {
 	)
 }
 
+// PodFitsEphemeralStorage checks if the ephemeral storage requested by the pod does not exceed the allocatable
+// ephemeral storage of the node. Ephemeral storage requested by pods already running on the node is not taken
+// into account. Nodes not reporting their ephemeral storage are considered to fit.
+func PodFitsEphemeralStorage(pod *v1.Pod, node *v1.Node) bool {
+	allocatable, ok := node.Status.Allocatable[v1.ResourceEphemeralStorage]
+	if !ok {
+		return true
+	}
+	request := GetResourceRequestQuantity(pod, v1.ResourceEphemeralStorage)
+	return request.Cmp(allocatable) <= 0
+}
+
 // TolerationsTolerateTaint checks if taint is tolerated by any of the tolerations.
 func TolerationsTolerateTaint(tolerations []v1.Toleration, taint *v1.Taint) bool {
 	for i := range tolerations {
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 func TestUniqueSortTolerations(t *testing.T) {
@@ -938,3 +939,52 @@ func TestNodeSelectorTermsEqual(t *testing.T) {
 		})
 	}
 }
+
+func TestPodFitsEphemeralStorage(t *testing.T) {
+	podRequesting := func(quantity string) *v1.Pod {
+		return &v1.Pod{Spec: v1.PodSpec{Containers: []v1.Container{{
+			Resources: v1.ResourceRequirements{Requests: v1.ResourceList{v1.ResourceEphemeralStorage: resource.MustParse(quantity)}},
+		}}}}
+	}
+	nodeWith := func(quantity string) *v1.Node {
+		node := &v1.Node{}
+		if quantity != "" {
+			node.Status.Allocatable = v1.ResourceList{v1.ResourceEphemeralStorage: resource.MustParse(quantity)}
+		}
+		return node
+	}
+
+	tests := []struct {
+		name string
+		pod  *v1.Pod
+		node *v1.Node
+		fits bool
+	}{
+		{
+			name: "request lower than allocatable",
+			pod:  podRequesting("1Gi"),
+			node: nodeWith("10Gi"),
+			fits: true,
+		},
+		{
+			name: "request greater than allocatable",
+			pod:  podRequesting("20Gi"),
+			node: nodeWith("10Gi"),
+			fits: false,
+		},
+		{
+			name: "node not reporting ephemeral storage",
+			pod:  podRequesting("20Gi"),
+			node: nodeWith(""),
+			fits: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if fits := PodFitsEphemeralStorage(test.pod, test.node); fits != test.fits {
+				t.Errorf("PodFitsEphemeralStorage expected to be %v, got %v", test.fits, fits)
+			}
+		})
+	}
+}
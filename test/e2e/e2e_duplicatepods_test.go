@@ -144,6 +144,7 @@ func TestRemoveDuplicates(t *testing.T) {
 				evictionPolicyGroupVersion,
 				false,
 				0,
+				0,
 				nodes,
 				true,
 				false,
@@ -167,6 +167,7 @@ func runPodLifetimeStrategy(
 			evictionPolicyGroupVersion,
 			false,
 			0,
+			0,
 			nodes,
 			false,
 			evictCritical,
@@ -1291,6 +1292,7 @@ func initPodEvictorOrFail(t *testing.T, clientSet clientset.Interface, nodes []*
 		evictionPolicyGroupVersion,
 		false,
 		0,
+		0,
 		nodes,
 		true,
 		false,
@@ -134,6 +134,7 @@ func TestTooManyRestarts(t *testing.T) {
 				evictionPolicyGroupVersion,
 				false,
 				0,
+				0,
 				nodes,
 				true,
 				false,
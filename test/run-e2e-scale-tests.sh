@@ -0,0 +1,140 @@
+#!/usr/bin/env bash
+
+# Copyright 2021 The Kubernetes Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# Runs descheduling cycles against a large number of kwok simulated nodes
+# and fails when a cycle takes longer than SCALE_MAX_CYCLE_SECONDS.
+
+set -x
+set -o errexit
+set -o nounset
+
+SCALE_NODES=${SCALE_NODES:-5000}
+SCALE_PODS_PER_NODE=${SCALE_PODS_PER_NODE:-5}
+SCALE_CYCLES=${SCALE_CYCLES:-3}
+SCALE_MAX_CYCLE_SECONDS=${SCALE_MAX_CYCLE_SECONDS:-120}
+KWOK_VERSION=${KWOK_VERSION:-v0.1.1}
+
+if [ -n "${KIND_E2E:-}" ]; then
+    K8S_VERSION=${KUBERNETES_VERSION:-v1.21.1}
+    curl -Lo kubectl https://storage.googleapis.com/kubernetes-release/release/${K8S_VERSION}/bin/linux/amd64/kubectl && chmod +x kubectl && mv kubectl /usr/local/bin/
+    wget https://github.com/kubernetes-sigs/kind/releases/download/v0.11.0/kind-linux-amd64
+    chmod +x kind-linux-amd64
+    mv kind-linux-amd64 kind
+    export PATH=$PATH:$PWD
+    kind create cluster --image kindest/node:${K8S_VERSION} --config=./hack/kind_config.yaml
+    kind get kubeconfig > /tmp/admin.conf
+    export KUBECONFIG="/tmp/admin.conf"
+fi
+
+# kwok marks the nodes it manages and keeps them (and pods bound to them) running.
+kubectl apply -f "https://github.com/kubernetes-sigs/kwok/releases/download/${KWOK_VERSION}/kwok.yaml"
+kubectl apply -f "https://github.com/kubernetes-sigs/kwok/releases/download/${KWOK_VERSION}/stage-fast.yaml"
+
+for i in $(seq 1 "${SCALE_NODES}"); do
+cat <<EOF
+---
+apiVersion: v1
+kind: Node
+metadata:
+  name: kwok-node-${i}
+  annotations:
+    kwok.x-k8s.io/node: fake
+  labels:
+    type: kwok
+    kubernetes.io/hostname: kwok-node-${i}
+spec:
+  taints:
+  - key: kwok.x-k8s.io/node
+    value: fake
+    effect: NoSchedule
+status:
+  allocatable:
+    cpu: "32"
+    memory: 256Gi
+    pods: "110"
+  capacity:
+    cpu: "32"
+    memory: 256Gi
+    pods: "110"
+EOF
+done | kubectl apply -f - > /dev/null
+
+kubectl create namespace descheduler-scale --dry-run=client -o yaml | kubectl apply -f -
+cat <<EOF | kubectl apply -f -
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: scale
+  namespace: descheduler-scale
+spec:
+  replicas: $((SCALE_NODES * SCALE_PODS_PER_NODE))
+  selector:
+    matchLabels:
+      app: scale
+  template:
+    metadata:
+      labels:
+        app: scale
+    spec:
+      tolerations:
+      - key: kwok.x-k8s.io/node
+        operator: Exists
+        effect: NoSchedule
+      containers:
+      - name: pause
+        image: kubernetes/pause
+        resources:
+          requests:
+            cpu: 100m
+            memory: 100Mi
+EOF
+kubectl -n descheduler-scale rollout status deployment/scale --timeout=30m
+
+cat <<EOF > /tmp/scale-policy.yaml
+apiVersion: "descheduler/v1alpha1"
+kind: "DeschedulerPolicy"
+strategies:
+  "RemoveDuplicates":
+     enabled: true
+  "LowNodeUtilization":
+     enabled: true
+     params:
+       nodeResourceUtilizationThresholds:
+         thresholds:
+           "cpu" : 20
+           "memory": 20
+           "pods": 20
+         targetThresholds:
+           "cpu" : 50
+           "memory": 50
+           "pods": 50
+  "RemovePodsViolatingNodeTaints":
+     enabled: true
+  "RemovePodsViolatingTopologySpreadConstraint":
+     enabled: true
+EOF
+
+go build -o /tmp/descheduler ./cmd/descheduler
+for cycle in $(seq 1 "${SCALE_CYCLES}"); do
+    start=$(date +%s)
+    /tmp/descheduler --kubeconfig "${KUBECONFIG}" --policy-config-file /tmp/scale-policy.yaml --dry-run --disable-metrics -v 1
+    elapsed=$(( $(date +%s) - start ))
+    echo "Descheduling cycle ${cycle} over ${SCALE_NODES} nodes took ${elapsed}s"
+    if [ "${elapsed}" -gt "${SCALE_MAX_CYCLE_SECONDS}" ]; then
+        echo "Descheduling cycle exceeded ${SCALE_MAX_CYCLE_SECONDS}s"
+        exit 1
+    fi
+done